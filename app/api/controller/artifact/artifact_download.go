@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Download returns the artifact of an execution, either as a signed URL or as a reader of the content.
+func (c *Controller) Download(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) (*types.PipelineArtifact, string, io.ReadCloser, error) {
+	pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	signedURL, file, err := c.download(ctx, ArtifactBlobPath(artifact))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	return artifact, signedURL, file, nil
+}
+
+// download returns a signed URL of the file if the blob store supports it, otherwise a reader of the file.
+func (c *Controller) download(ctx context.Context, blobPath string) (string, io.ReadCloser, error) {
+	signedURL, err := c.blobStore.GetSignedURL(ctx, blobPath, time.Now().Add(1*time.Hour))
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, blobPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download file from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
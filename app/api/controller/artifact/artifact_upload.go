@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Upload uploads an artifact of a running execution. An existing artifact with the same name is replaced.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkName("artifact name", name); err != nil {
+		return nil, err
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	if execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can only be uploaded while the execution is running.")
+	}
+
+	artifact := &types.PipelineArtifact{
+		RepoID:      pipeline.RepoID,
+		PipelineID:  pipeline.ID,
+		ExecutionID: execution.ID,
+		Name:        name,
+		CreatedBy:   session.Principal.ID,
+		BlobPath:    newBlobPath(artifactBlobPathFmt, pipeline.ID, execution.ID, name),
+	}
+
+	// The existing artifact is replaced only after the new one is fully uploaded.
+	artifact.Size, err = c.uploadBlob(ctx, file, artifact.BlobPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	artifact.Created = now
+	artifact.Updated = now
+
+	var replaced *types.PipelineArtifact
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		replaced, err = c.artifactStore.Upsert(ctx, artifact)
+		return err
+	})
+	if err != nil {
+		c.deleteBlob(ctx, artifact.BlobPath)
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	if replaced != nil {
+		c.deleteBlob(ctx, ArtifactBlobPath(replaced))
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestUpload_Overwrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		legacy  bool
+		file    io.Reader
+		want    string
+		wantErr error
+	}{
+		{name: "success", file: strings.NewReader("new"), want: "new"},
+		{name: "success-legacy-path", legacy: true, file: strings.NewReader("new"), want: "new"},
+		{
+			name:    "failed-upload",
+			file:    &failingReader{data: strings.NewReader("partial")},
+			want:    "old",
+			wantErr: errUploadFailed,
+		},
+		{
+			name:    "failed-upload-legacy-path",
+			legacy:  true,
+			file:    &failingReader{data: strings.NewReader("partial")},
+			want:    "old",
+			wantErr: errUploadFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			artifacts := &fakeArtifactStore{}
+			blobs := &fakeBlobStore{files: map[string][]byte{}}
+			ctrl := newTestController(artifacts, nil, blobs)
+
+			if test.legacy {
+				// artifacts uploaded before the blob path was stored don't have it set
+				artifacts.artifacts = []*types.PipelineArtifact{{
+					ID: 1, PipelineID: testPipelineID, ExecutionID: testExecution, Name: "report", Size: 3,
+				}}
+				blobs.files[ArtifactBlobPath(artifacts.artifacts[0])] = []byte("old")
+			} else {
+				if _, err := ctrl.Upload(ctx, testSession, testRepoRef, testPipeline, 1, "report",
+					strings.NewReader("old")); err != nil {
+					t.Fatalf("failed to upload the initial artifact: %s", err)
+				}
+			}
+
+			_, err := ctrl.Upload(ctx, testSession, testRepoRef, testPipeline, 1, "report", test.file)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+
+			if len(artifacts.artifacts) != 1 {
+				t.Fatalf("expected a single artifact, got %d", len(artifacts.artifacts))
+			}
+
+			artifact, _, file, err := ctrl.Download(ctx, testSession, testRepoRef, testPipeline, 1, "report")
+			if err != nil {
+				t.Fatalf("failed to download the artifact: %s", err)
+			}
+
+			data, _ := io.ReadAll(file)
+			if !bytes.Equal(data, []byte(test.want)) {
+				t.Errorf("expected artifact content %q, got %q", test.want, data)
+			}
+			if artifact.Size != int64(len(test.want)) {
+				t.Errorf("expected artifact size %d, got %d", len(test.want), artifact.Size)
+			}
+
+			// only the blob of the current artifact must be left in the blob store
+			wantPaths := []string{ArtifactBlobPath(artifact)}
+			if paths := blobs.paths(); !slices.Equal(paths, wantPaths) {
+				t.Errorf("expected blobs %v, got %v", wantPaths, paths)
+			}
+		})
+	}
+}
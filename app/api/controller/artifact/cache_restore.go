@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RestoreCache returns the cache of a pipeline with the key. If there's no such cache, the restore keys
+// are used in order as key prefixes and the most recently updated cache matching a prefix is returned.
+func (c *Controller) RestoreCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+	restoreKeys []string,
+) (*types.PipelineCache, string, io.ReadCloser, error) {
+	pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	cache, err := c.findCache(ctx, pipeline.ID, key, restoreKeys)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if err = c.cacheStore.UpdateLastUsed(ctx, cache.ID, time.Now()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of cache %q", cache.Key)
+	}
+
+	signedURL, file, err := c.download(ctx, CacheBlobPath(cache))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download cache: %w", err)
+	}
+
+	return cache, signedURL, file, nil
+}
+
+func (c *Controller) findCache(
+	ctx context.Context,
+	pipelineID int64,
+	key string,
+	restoreKeys []string,
+) (*types.PipelineCache, error) {
+	cache, err := c.cacheStore.Find(ctx, pipelineID, key)
+	if err == nil {
+		return cache, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find cache: %w", err)
+	}
+
+	for _, restoreKey := range restoreKeys {
+		if restoreKey == "" {
+			continue
+		}
+
+		cache, err = c.cacheStore.FindByPrefix(ctx, pipelineID, restoreKey)
+		if err == nil {
+			return cache, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find cache by restore key: %w", err)
+		}
+	}
+
+	return nil, usererror.NotFoundf("No cache found for key %q.", key)
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SaveCache stores the cache of a pipeline under the key. An existing cache with the same key is replaced.
+func (c *Controller) SaveCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+	file io.Reader,
+) (*types.PipelineCache, error) {
+	pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkName("cache key", key); err != nil {
+		return nil, err
+	}
+
+	cache := &types.PipelineCache{
+		RepoID:     pipeline.RepoID,
+		PipelineID: pipeline.ID,
+		Key:        key,
+		BlobPath:   newBlobPath(cacheBlobPathFmt, pipeline.ID, key),
+	}
+
+	// The existing cache is replaced only after the new one is fully uploaded.
+	cache.Size, err = c.uploadBlob(ctx, file, cache.BlobPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload cache: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	cache.Created = now
+	cache.Updated = now
+	cache.LastUsed = now
+
+	var replaced *types.PipelineCache
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		replaced, err = c.cacheStore.Upsert(ctx, cache)
+		return err
+	})
+	if err != nil {
+		c.deleteBlob(ctx, cache.BlobPath)
+		return nil, fmt.Errorf("failed to store cache: %w", err)
+	}
+
+	if replaced != nil {
+		c.deleteBlob(ctx, CacheBlobPath(replaced))
+	}
+
+	return cache, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestSaveCache_Overwrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		legacy  bool
+		file    io.Reader
+		want    string
+		wantErr error
+	}{
+		{name: "success", file: strings.NewReader("new"), want: "new"},
+		{name: "success-legacy-path", legacy: true, file: strings.NewReader("new"), want: "new"},
+		{
+			name:    "failed-upload",
+			file:    &failingReader{data: strings.NewReader("partial")},
+			want:    "old",
+			wantErr: errUploadFailed,
+		},
+		{
+			name:    "failed-upload-legacy-path",
+			legacy:  true,
+			file:    &failingReader{data: strings.NewReader("partial")},
+			want:    "old",
+			wantErr: errUploadFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			caches := &fakeCacheStore{}
+			blobs := &fakeBlobStore{files: map[string][]byte{}}
+			ctrl := newTestController(nil, caches, blobs)
+
+			if test.legacy {
+				// caches saved before the blob path was stored don't have it set
+				caches.caches = []*types.PipelineCache{{ID: 1, PipelineID: testPipelineID, Key: "deps", Size: 3}}
+				blobs.files[CacheBlobPath(caches.caches[0])] = []byte("old")
+			} else {
+				if _, err := ctrl.SaveCache(ctx, testSession, testRepoRef, testPipeline, "deps",
+					strings.NewReader("old")); err != nil {
+					t.Fatalf("failed to save the initial cache: %s", err)
+				}
+			}
+
+			_, err := ctrl.SaveCache(ctx, testSession, testRepoRef, testPipeline, "deps", test.file)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+
+			if len(caches.caches) != 1 {
+				t.Fatalf("expected a single cache, got %d", len(caches.caches))
+			}
+
+			cache, _, file, err := ctrl.RestoreCache(ctx, testSession, testRepoRef, testPipeline, "deps", nil)
+			if err != nil {
+				t.Fatalf("failed to restore the cache: %s", err)
+			}
+
+			data, _ := io.ReadAll(file)
+			if !bytes.Equal(data, []byte(test.want)) {
+				t.Errorf("expected cache content %q, got %q", test.want, data)
+			}
+			if cache.Size != int64(len(test.want)) {
+				t.Errorf("expected cache size %d, got %d", len(test.want), cache.Size)
+			}
+
+			// only the blob of the current cache must be left in the blob store
+			wantPaths := []string{CacheBlobPath(cache)}
+			if paths := blobs.paths(); !slices.Equal(paths, wantPaths) {
+				t.Errorf("expected blobs %v, got %v", wantPaths, paths)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	artifactBlobPathFmt = "pipelines/%d/executions/%d/artifacts/%s"
+	cacheBlobPathFmt    = "pipelines/%d/caches/%s"
+)
+
+// nameRegex defines the allowed artifact names and cache keys.
+// Slashes aren't allowed as the names are used as part of the blob path.
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,254}$`)
+
+type Controller struct {
+	tx             dbtx.Transactor
+	authorizer     authz.Authorizer
+	repoFinder     refcache.RepoFinder
+	pipelineStore  store.PipelineStore
+	executionStore store.ExecutionStore
+	artifactStore  store.PipelineArtifactStore
+	cacheStore     store.PipelineCacheStore
+	blobStore      blob.Store
+	maxSize        int64
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoFinder refcache.RepoFinder,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	artifactStore store.PipelineArtifactStore,
+	cacheStore store.PipelineCacheStore,
+	blobStore blob.Store,
+	config *types.Config,
+) *Controller {
+	return &Controller{
+		tx:             tx,
+		authorizer:     authorizer,
+		repoFinder:     repoFinder,
+		pipelineStore:  pipelineStore,
+		executionStore: executionStore,
+		artifactStore:  artifactStore,
+		cacheStore:     cacheStore,
+		blobStore:      blobStore,
+		maxSize:        config.CI.ArtifactMaxSize,
+	}
+}
+
+// GetMaxSize returns the maximum size of an artifact or cache.
+func (c *Controller) GetMaxSize() int64 {
+	return c.maxSize
+}
+
+// ArtifactBlobPath returns the path of the artifact in the blob store.
+func ArtifactBlobPath(artifact *types.PipelineArtifact) string {
+	if artifact.BlobPath != "" {
+		return artifact.BlobPath
+	}
+
+	// artifacts uploaded before the blob path was stored are kept under their name
+	return fmt.Sprintf(artifactBlobPathFmt, artifact.PipelineID, artifact.ExecutionID, artifact.Name)
+}
+
+// CacheBlobPath returns the path of the cache in the blob store.
+func CacheBlobPath(cache *types.PipelineCache) string {
+	if cache.BlobPath != "" {
+		return cache.BlobPath
+	}
+
+	// caches saved before the blob path was stored are kept under their key
+	return fmt.Sprintf(cacheBlobPathFmt, cache.PipelineID, cache.Key)
+}
+
+// newBlobPath returns a unique blob path for a new version of an artifact or cache,
+// so that uploading it never overwrites the blob of the current version.
+func newBlobPath(pathFmt string, args ...any) string {
+	return fmt.Sprintf(pathFmt, args...) + "." + uuid.NewString()
+}
+
+// uploadBlob uploads the file to the blob store and returns its size.
+// A partially uploaded file is removed if the upload fails.
+func (c *Controller) uploadBlob(ctx context.Context, file io.Reader, blobPath string) (int64, error) {
+	reader := &countingReader{Reader: file}
+	if err := c.blobStore.Upload(ctx, reader, blobPath); err != nil {
+		c.deleteBlob(ctx, blobPath)
+		return 0, err
+	}
+
+	return reader.n, nil
+}
+
+// deleteBlob deletes a blob which is no longer referenced. Failures are only logged.
+func (c *Controller) deleteBlob(ctx context.Context, blobPath string) {
+	if err := c.blobStore.Delete(ctx, blobPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete blob %q", blobPath)
+	}
+}
+
+func (c *Controller) getPipelineCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	permission enum.Permission,
+) (*types.Pipeline, error) {
+	repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+func checkName(kind, name string) error {
+	if !nameRegex.MatchString(name) {
+		return usererror.BadRequestf(
+			"The %s has to start with a letter or digit, contain only letters, digits, '.', '_' and '-' "+
+				"and be at most 255 characters long.", kind)
+	}
+
+	return nil
+}
+
+// countingReader counts the number of bytes read from the underlying reader.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/refcache/refcachetest"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/registry/app/api/controller/mocks"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx/dbtxtest"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testRepoRef    = "1"
+	testPipeline   = "build"
+	testPipelineID = int64(2)
+	testExecution  = int64(3)
+)
+
+var (
+	errUploadFailed = errors.New("connection reset")
+
+	testSession = &auth.Session{Principal: types.Principal{ID: 10, Type: enum.PrincipalTypeUser}}
+)
+
+// newTestController returns a controller for a pipeline with a running execution.
+func newTestController(
+	artifactStore store.PipelineArtifactStore,
+	cacheStore store.PipelineCacheStore,
+	blobStore blob.Store,
+) *Controller {
+	repo := &types.Repository{ID: 1, ParentID: 1, Path: "space/repo"}
+
+	authorizer := new(mocks.Authorizer)
+	authorizer.On("Check", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(true, nil)
+
+	return NewController(
+		dbtxtest.Transactor{},
+		authorizer,
+		refcachetest.NewRepoFinder(repo),
+		&fakePipelineStore{pipeline: &types.Pipeline{ID: testPipelineID, RepoID: repo.ID, Identifier: testPipeline}},
+		&fakeExecutionStore{execution: &types.Execution{
+			ID:         testExecution,
+			PipelineID: testPipelineID,
+			Number:     1,
+			Status:     enum.CIStatusRunning,
+		}},
+		artifactStore,
+		cacheStore,
+		blobStore,
+		&types.Config{},
+	)
+}
+
+// failingReader returns the data and then fails, like an interrupted upload.
+type failingReader struct {
+	data io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errUploadFailed
+	}
+	return n, err
+}
+
+type fakePipelineStore struct {
+	store.PipelineStore
+	pipeline *types.Pipeline
+}
+
+func (s *fakePipelineStore) FindByIdentifier(context.Context, int64, string) (*types.Pipeline, error) {
+	return s.pipeline, nil
+}
+
+type fakeExecutionStore struct {
+	store.ExecutionStore
+	execution *types.Execution
+}
+
+func (s *fakeExecutionStore) FindByNumber(context.Context, int64, int64) (*types.Execution, error) {
+	return s.execution, nil
+}
+
+type fakeArtifactStore struct {
+	store.PipelineArtifactStore
+	artifacts []*types.PipelineArtifact
+}
+
+func (s *fakeArtifactStore) Find(_ context.Context, executionID int64, name string) (*types.PipelineArtifact, error) {
+	for _, artifact := range s.artifacts {
+		if artifact.ExecutionID == executionID && artifact.Name == name {
+			clone := *artifact
+			return &clone, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeArtifactStore) Upsert(
+	_ context.Context,
+	artifact *types.PipelineArtifact,
+) (*types.PipelineArtifact, error) {
+	for i, existing := range s.artifacts {
+		if existing.ExecutionID == artifact.ExecutionID && existing.Name == artifact.Name {
+			artifact.ID = existing.ID
+			artifact.Created = existing.Created
+			clone := *artifact
+			s.artifacts[i] = &clone
+			return existing, nil
+		}
+	}
+	artifact.ID = int64(len(s.artifacts) + 1)
+	clone := *artifact
+	s.artifacts = append(s.artifacts, &clone)
+	return nil, nil
+}
+
+type fakeCacheStore struct {
+	store.PipelineCacheStore
+	caches []*types.PipelineCache
+}
+
+func (s *fakeCacheStore) Find(_ context.Context, pipelineID int64, key string) (*types.PipelineCache, error) {
+	for _, c := range s.caches {
+		if c.PipelineID == pipelineID && c.Key == key {
+			clone := *c
+			return &clone, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeCacheStore) Upsert(_ context.Context, c *types.PipelineCache) (*types.PipelineCache, error) {
+	for i, existing := range s.caches {
+		if existing.PipelineID == c.PipelineID && existing.Key == c.Key {
+			c.ID = existing.ID
+			c.Created = existing.Created
+			clone := *c
+			s.caches[i] = &clone
+			return existing, nil
+		}
+	}
+	c.ID = int64(len(s.caches) + 1)
+	clone := *c
+	s.caches = append(s.caches, &clone)
+	return nil, nil
+}
+
+func (s *fakeCacheStore) UpdateLastUsed(_ context.Context, id int64, lastUsed time.Time) error {
+	s.caches[id-1].LastUsed = lastUsed.UnixMilli()
+	return nil
+}
+
+// fakeBlobStore keeps the files in memory. Like the real stores, it writes the data
+// as it is read, so an interrupted upload leaves a partially written file behind.
+type fakeBlobStore struct {
+	blob.Store
+	files map[string][]byte
+}
+
+func (s *fakeBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	buf := &bytes.Buffer{}
+	_, err := io.Copy(buf, file)
+	s.files[filePath] = buf.Bytes()
+	return err
+}
+
+func (s *fakeBlobStore) GetSignedURL(context.Context, string, time.Time, ...blob.SignURLOption) (string, error) {
+	return "", blob.ErrNotSupported
+}
+
+func (s *fakeBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, filePath string) error {
+	delete(s.files, filePath)
+	return nil
+}
+
+func (s *fakeBlobStore) paths() []string {
+	return slices.Sorted(maps.Keys(s.files))
+}
@@ -25,6 +25,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -62,6 +63,8 @@ type Controller struct {
 	lfsStore            store.LFSObjectStore
 	auditService        audit.Service
 	userGroupService    usergroup.Service
+
+	signatureVerifyService publickey.SignatureVerifyService
 }
 
 func NewController(
@@ -83,6 +86,7 @@ func NewController(
 	lfsStore store.LFSObjectStore,
 	auditService audit.Service,
 	userGroupService usergroup.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -103,6 +107,8 @@ func NewController(
 		lfsStore:            lfsStore,
 		auditService:        auditService,
 		userGroupService:    userGroupService,
+
+		signatureVerifyService: signatureVerifyService,
 	}
 }
 
@@ -32,6 +32,8 @@ type RestrictedGIT interface {
 	GetBranch(ctx context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error)
 	Diff(ctx context.Context, in *git.DiffParams, files ...api.FileDiffRequest) (<-chan *git.FileDiff, <-chan error)
 	GetBlob(ctx context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error)
+	ListCommitsInRange(ctx context.Context, params git.ListCommitsInRangeParams) (git.ListCommitsInRangeOutput, error)
+	ListChangedFiles(ctx context.Context, params git.ListChangedFilesParams) (git.ListChangedFilesOutput, error)
 	ProcessPreReceiveObjects(
 		ctx context.Context,
 		params git.ProcessPreReceiveObjectsParams,
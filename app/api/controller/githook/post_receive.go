@@ -45,6 +45,9 @@ const (
 
 	// gitReferenceNamePrefixTag is the prefix of pull req references.
 	gitReferenceNamePullReq = "refs/pullreq/"
+
+	// gitReferenceNameMergeQueue is the prefix of merge queue references.
+	gitReferenceNameMergeQueue = "refs/merge-queue/"
 )
 
 // refForcePushMap stores branch refs that were force pushed.
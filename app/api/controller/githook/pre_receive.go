@@ -82,7 +82,7 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
-	// For external calls (git pushes) block modification of pullreq references.
+	// For external calls (git pushes) block modification of pullreq and merge queue references.
 	if !in.Internal && c.blockPullReqRefUpdate(refUpdates, repo.State) {
 		output.Error = ptr.String(usererror.ErrPullReqRefsCantBeModified.Error())
 		return output, nil
@@ -123,6 +123,14 @@ func (c *Controller) PreReceive(
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 		}
+
+		commitViolations, err := c.verifyPushedCommits(
+			ctx, rgit, dummySession, repo, protectionRules, isRepoOwner, in,
+		)
+		if err != nil {
+			return hook.Output{}, fmt.Errorf("failed to verify pushed commits: %w", err)
+		}
+		ruleViolations = append(ruleViolations, commitViolations...)
 		if output.Error != nil {
 			return output, nil
 		}
@@ -207,6 +215,13 @@ func (c *Controller) processPushProtection(
 		return nil, fmt.Errorf("failed to process pre-receive objects: %w", err)
 	}
 
+	if out.VerifyChangedFiles {
+		violationsInput.ChangedFiles, err = listChangedFiles(ctx, rgit, repo, in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changed files: %w", err)
+		}
+	}
+
 	var violations []types.RuleViolations
 	if violationsInput.HasViolations() {
 		pushViolations, err := pushProtection.Violations(ctx, violationsInput)
@@ -226,7 +241,7 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 	}
 
 	fn := func(ref string) bool {
-		return strings.HasPrefix(ref, gitReferenceNamePullReq)
+		return strings.HasPrefix(ref, gitReferenceNamePullReq) || strings.HasPrefix(ref, gitReferenceNameMergeQueue)
 	}
 
 	return slices.ContainsFunc(refUpdates.other.created, fn) ||
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// listChangedFiles returns the files changed by the pushed branches and tags.
+// A file that is changed in the same way by multiple references is returned only once.
+func listChangedFiles(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+) ([]git.ChangedFile, error) {
+	type fileKey struct {
+		path    string
+		oldPath string
+		sha     string
+	}
+
+	seen := make(map[fileKey]struct{})
+
+	var files []git.ChangedFile
+
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New.IsNil() || (!isBranch(refUpdate.Ref) && !isTag(refUpdate.Ref)) {
+			continue
+		}
+
+		baseSHA, ok, err := GetBaseSHAForScanningChanges(ctx, rgit, repo, in.Environment, in.RefUpdates, refUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base sha: %w", err)
+		}
+
+		var baseRef string
+		if ok {
+			baseRef = baseSHA.String()
+		}
+
+		out, err := rgit.ListChangedFiles(ctx, git.ListChangedFilesParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			BaseRef: baseRef,
+			HeadRef: refUpdate.New.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changed files of %q: %w", refUpdate.Ref, err)
+		}
+
+		for _, file := range out.Files {
+			key := fileKey{path: file.Path, oldPath: file.OldPath, sha: file.SHA.String()}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
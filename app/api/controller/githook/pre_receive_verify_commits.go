@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+)
+
+// verifiedPushCommitsPageSize is the number of pushed commits that are read and verified at once.
+const verifiedPushCommitsPageSize = 1000
+
+// verifyPushedCommits checks the commits pushed to branches against the commit requirements
+// of the branch protection rules (e.g. that all commits must be signed).
+func (c *Controller) verifyPushedCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	protectionRules []types.RuleInfoInternal,
+	isRepoOwner bool,
+	in types.GithookPreReceiveInput,
+) ([]types.RuleViolations, error) {
+	branchProtection := c.protectionManager.FilterCreateBranchProtection(protectionRules)
+
+	// The verification results are not stored because the push might get rejected.
+	verifySession := c.signatureVerifyService.NewVerifySession(repo.ID)
+
+	var ruleViolations []types.RuleViolations
+
+	for _, refUpdate := range in.RefUpdates {
+		if !isBranch(refUpdate.Ref) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		var commits []*types.Commit
+		listCommits := func(ctx context.Context) ([]*types.Commit, error) {
+			if commits != nil {
+				return commits, nil
+			}
+
+			var err error
+			commits, err = listPushedCommits(ctx, rgit, verifySession, repo, in, refUpdate)
+			if err != nil {
+				return nil, err
+			}
+
+			return commits, nil
+		}
+
+		violations, err := branchProtection.CommitsVerify(ctx, protection.CommitsVerifyInput{
+			ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              &session.Principal,
+			AllowBypass:        true,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               repo,
+			BranchName:         refUpdate.Ref[len(gitReferenceNamePrefixBranch):],
+			ListCommits:        listCommits,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commits of branch %q: %w", refUpdate.Ref, err)
+		}
+
+		ruleViolations = append(ruleViolations, violations...)
+	}
+
+	return ruleViolations, nil
+}
+
+// listPushedCommits returns the commits introduced by the reference update with their signature verification results.
+func listPushedCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	verifySession *publickey.VerifySession,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+	refUpdate hook.ReferenceUpdate,
+) ([]*types.Commit, error) {
+	baseSHA, ok, err := GetBaseSHAForScanningChanges(ctx, rgit, repo, in.Environment, in.RefUpdates, refUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base sha: %w", err)
+	}
+
+	var after string
+	if ok {
+		after = baseSHA.String()
+	}
+
+	// All pushed commits are verified, otherwise old commits of a large push would bypass the rules.
+	var commits []*types.Commit
+	for page := 1; ; page++ {
+		out, err := rgit.ListCommitsInRange(ctx, git.ListCommitsInRangeParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			GitREF: refUpdate.New.String(),
+			After:  after,
+			Page:   page,
+			Limit:  verifiedPushCommitsPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pushed commits: %w", err)
+		}
+
+		pageCommits := make([]*types.Commit, len(out.Commits))
+		for i := range out.Commits {
+			pageCommits[i] = controller.MapCommit(&out.Commits[i])
+		}
+
+		if err := verifySession.VerifyCommits(ctx, pageCommits); err != nil {
+			return nil, fmt.Errorf("failed to verify signature of pushed commits: %w", err)
+		}
+
+		commits = append(commits, pageCommits...)
+
+		if len(out.Commits) < verifiedPushCommitsPageSize {
+			return commits, nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AssigneeAddInput struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// AssigneeAdd assigns a principal to an issue.
+func (c *Controller) AssigneeAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *AssigneeAddInput,
+) (*types.IssueAssignee, error) {
+	if in.AssigneeID <= 0 {
+		return nil, usererror.BadRequest("A valid assignee ID must be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	assignees, err := c.findAssignees(ctx, repo, []int64{in.AssigneeID})
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.assigneeStore.List(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	for _, assignee := range existing {
+		if assignee.PrincipalID == in.AssigneeID {
+			return assignee, nil
+		}
+	}
+
+	assignee := &types.IssueAssignee{
+		IssueID:     issue.ID,
+		PrincipalID: in.AssigneeID,
+		Created:     time.Now().UnixMilli(),
+		CreatedBy:   session.Principal.ID,
+		Assignee:    *assignees[0],
+	}
+
+	if err = c.assigneeStore.Create(ctx, assignee); err != nil {
+		return nil, fmt.Errorf("failed to assign principal to issue: %w", err)
+	}
+
+	c.writeSystemActivity(ctx, issue, session.Principal.ID, &types.IssueActivityPayloadAssigneeAdd{
+		AssigneeID: in.AssigneeID,
+	})
+
+	c.eventReporter.AssigneeAdded(ctx, &issueevents.AssigneeAddedPayload{
+		Base:       eventBase(issue, session.Principal.ID),
+		AssigneeID: in.AssigneeID,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return assignee, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssigneeDelete removes a principal from the assignees of an issue.
+func (c *Controller) AssigneeDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	assigneeID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	assignees, err := c.assigneeStore.List(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	found := false
+	for _, assignee := range assignees {
+		if assignee.PrincipalID == assigneeID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return usererror.NotFound("Assignee not found")
+	}
+
+	if err = c.assigneeStore.Delete(ctx, issue.ID, assigneeID); err != nil {
+		return fmt.Errorf("failed to remove issue assignee: %w", err)
+	}
+
+	c.writeSystemActivity(ctx, issue, session.Principal.ID, &types.IssueActivityPayloadAssigneeDelete{
+		AssigneeID: assigneeID,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return nil
+}
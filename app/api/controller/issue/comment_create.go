@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentCreateInput struct {
+	// ParentID is set only for replies
+	ParentID int64 `json:"parent_id"`
+	// Text is comment text
+	Text string `json:"text"`
+}
+
+func (in *CommentCreateInput) IsReply() bool {
+	return in.ParentID != 0
+}
+
+func (in *CommentCreateInput) Sanitize() error {
+	in.Text = strings.TrimSpace(in.Text)
+	return validateComment(in.Text)
+}
+
+// CommentCreate creates a new issue comment (issue activity, type=comment).
+func (c *Controller) CommentCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *CommentCreateInput,
+) (*types.IssueActivity, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	var parentAct *types.IssueActivity
+	if in.IsReply() {
+		parentAct, err = c.checkIsReplyable(ctx, issue, in.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify reply: %w", err)
+		}
+	}
+
+	metadataUpdates, principalInfos, err := c.processMentions(ctx, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update metadata for mentions: %w", err)
+	}
+
+	var act *types.IssueActivity
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		if issue == nil {
+			// the issue was fetched before the transaction, we re-fetch it in case of the version conflict error
+			issue, err = c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+			if err != nil {
+				return fmt.Errorf("failed to find issue by number: %w", err)
+			}
+		}
+
+		now := time.Now().UnixMilli()
+		act = &types.IssueActivity{
+			CreatedBy: session.Principal.ID,
+			Created:   now,
+			Updated:   now,
+			Edited:    now,
+			RepoID:    issue.RepoID,
+			IssueID:   issue.ID,
+			Type:      enum.PullReqActivityTypeComment,
+			Kind:      enum.PullReqActivityKindComment,
+			Text:      in.Text,
+			Author:    *session.Principal.ToPrincipalInfo(),
+		}
+		act.UpdateMetadata(metadataUpdates...)
+		_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+
+		if in.IsReply() {
+			act.ParentID = &parentAct.ID
+			err = c.writeReplyActivity(ctx, parentAct, act)
+		} else {
+			err = c.writeActivity(ctx, issue, act)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write issue comment: %w", err)
+		}
+
+		issue.CommentCount++
+		if err = c.issueStore.Update(ctx, issue); err != nil {
+			return fmt.Errorf("failed to increment issue comment counter: %w", err)
+		}
+
+		return nil
+	}, controller.TxOptionResetFunc(func() {
+		issue = nil // on the version conflict error force re-fetch of the issue
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Populate activity mentions (used only for response purposes).
+	act.Mentions = principalInfos
+
+	c.eventReporter.CommentCreated(ctx, &issueevents.CommentCreatedPayload{
+		Base:       eventBase(issue, session.Principal.ID),
+		ActivityID: act.ID,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueCommentCreated, act)
+
+	return act, nil
+}
+
+func (c *Controller) checkIsReplyable(
+	ctx context.Context,
+	issue *types.Issue,
+	parentID int64,
+) (*types.IssueActivity, error) {
+	// make sure the parent comment exists, belongs to the same issue and isn't itself a reply
+	parentAct, err := c.activityStore.Find(ctx, parentID)
+	if errors.Is(err, store.ErrResourceNotFound) || parentAct == nil {
+		return nil, usererror.BadRequest("Parent issue activity not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent issue activity: %w", err)
+	}
+
+	if parentAct.IssueID != issue.ID {
+		return nil, usererror.BadRequest("Parent issue activity doesn't belong to the same issue.")
+	}
+
+	if !parentAct.IsReplyable() {
+		return nil, usererror.BadRequest("Can't create a reply to the specified entry.")
+	}
+
+	return parentAct, nil
+}
+
+// writeReplyActivity updates the parent activity's reply sequence number (using the optimistic locking mechanism),
+// sets the correct Order and SubOrder values and writes the activity to the database.
+func (c *Controller) writeReplyActivity(ctx context.Context, parent, act *types.IssueActivity) error {
+	parentUpd, err := c.activityStore.UpdateOptLock(ctx, parent, func(act *types.IssueActivity) error {
+		act.ReplySeq++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get issue activity number: %w", err)
+	}
+
+	*parent = *parentUpd
+
+	act.Order = parentUpd.Order
+	act.SubOrder = parentUpd.ReplySeq
+
+	if err = c.activityStore.Create(ctx, act); err != nil {
+		return fmt.Errorf("failed to create issue activity: %w", err)
+	}
+
+	return nil
+}
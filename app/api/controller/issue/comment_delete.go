@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommentDelete deletes an issue comment.
+func (c *Controller) CommentDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var issue *types.Issue
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		issue, err = c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+		if err != nil {
+			return fmt.Errorf("failed to find issue by number: %w", err)
+		}
+
+		act, err := c.getCommentCheckEditAccess(ctx, session, issue, commentID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		act.Deleted = &now
+
+		if err = c.activityStore.Update(ctx, act); err != nil {
+			return fmt.Errorf("failed to mark comment as deleted: %w", err)
+		}
+
+		issue.CommentCount--
+		if err = c.issueStore.Update(ctx, issue); err != nil {
+			return fmt.Errorf("failed to decrement issue comment counter: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentUpdateInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommentUpdateInput) Sanitize() error {
+	in.Text = strings.TrimSpace(in.Text)
+	return validateComment(in.Text)
+}
+
+// CommentUpdate updates an issue comment.
+func (c *Controller) CommentUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+	in *CommentUpdateInput,
+) (*types.IssueActivity, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	act, err := c.getCommentCheckEditAccess(ctx, session, issue, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if act.Text == in.Text {
+		return act, nil
+	}
+
+	metadataUpdates, principalInfos, err := c.processMentions(ctx, in.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update metadata for mentions: %w", err)
+	}
+
+	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.IssueActivity) error {
+		now := time.Now().UnixMilli()
+		act.Edited = now
+		act.Text = in.Text
+		act.UpdateMetadata(metadataUpdates...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// Populate activity mentions (used only for response purposes).
+	act.Mentions = principalInfos
+
+	c.eventReporter.CommentUpdated(ctx, &issueevents.CommentUpdatedPayload{
+		Base:       eventBase(issue, session.Principal.ID),
+		ActivityID: act.ID,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueCommentUpdated, act)
+
+	return act, nil
+}
@@ -0,0 +1,314 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	issueStore         store.IssueStore
+	activityStore      store.IssueActivityStore
+	assigneeStore      store.IssueAssigneeStore
+	milestoneStore     store.MilestoneStore
+	repoStore          store.RepoStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	repoFinder         refcache.RepoFinder
+	labelSvc           *label.Service
+	eventReporter      *issueevents.Reporter
+	sseStreamer        sse.Streamer
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	assigneeStore store.IssueAssigneeStore,
+	milestoneStore store.MilestoneStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	repoFinder refcache.RepoFinder,
+	labelSvc *label.Service,
+	eventReporter *issueevents.Reporter,
+	sseStreamer sse.Streamer,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		issueStore:         issueStore,
+		activityStore:      activityStore,
+		assigneeStore:      assigneeStore,
+		milestoneStore:     milestoneStore,
+		repoStore:          repoStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		repoFinder:         repoFinder,
+		labelSvc:           labelSvc,
+		eventReporter:      eventReporter,
+		sseStreamer:        sseStreamer,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.RepositoryCore, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err := apiauth.CheckRepoState(ctx, session, repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// getCommentCheckEditAccess returns the issue comment with the provided ID
+// if it belongs to the issue and was created by the principal of the session.
+func (c *Controller) getCommentCheckEditAccess(
+	ctx context.Context,
+	session *auth.Session,
+	issue *types.Issue,
+	commentID int64,
+) (*types.IssueActivity, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	comment, err := c.activityStore.Find(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find comment by ID: %w", err)
+	}
+
+	if comment.Deleted != nil || comment.IssueID != issue.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if comment.Kind == enum.PullReqActivityKindSystem || comment.Type != enum.PullReqActivityTypeComment {
+		return nil, usererror.BadRequest("Only comments can be edited.")
+	}
+
+	if comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Only own comments may be updated.")
+	}
+
+	return comment, nil
+}
+
+// writeActivity updates the issue's activity sequence number (using the optimistic locking mechanism),
+// sets the correct Order value and writes the activity to the database.
+func (c *Controller) writeActivity(ctx context.Context, issue *types.Issue, act *types.IssueActivity) error {
+	issueUpd, err := c.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		return fmt.Errorf("failed to get issue activity number: %w", err)
+	}
+
+	*issue = *issueUpd
+
+	act.Order = issueUpd.ActivitySeq
+
+	if err = c.activityStore.Create(ctx, act); err != nil {
+		return fmt.Errorf("failed to create issue activity: %w", err)
+	}
+
+	return nil
+}
+
+// writeSystemActivity writes a system activity to the issue's timeline. Failures are only logged.
+func (c *Controller) writeSystemActivity(
+	ctx context.Context,
+	issue *types.Issue,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	issueUpd, err := c.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to update issue activity sequence for '%s' activity",
+			payload.ActivityType())
+		return
+	}
+
+	*issue = *issueUpd
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, issue, principalID, payload, nil); err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write issue '%s' activity", payload.ActivityType())
+	}
+}
+
+// backfill populates the assignees, the labels and the milestone of the issues.
+func (c *Controller) backfill(ctx context.Context, issues ...*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	assignees, err := c.assigneeStore.ListByIssueIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	if err = c.labelSvc.BackfillIssues(ctx, issues); err != nil {
+		return fmt.Errorf("failed to backfill issue labels: %w", err)
+	}
+
+	milestones := map[int64]*types.Milestone{}
+	for _, issue := range issues {
+		issue.Assignees = assignees[issue.ID]
+		if issue.Assignees == nil {
+			issue.Assignees = []*types.PrincipalInfo{}
+		}
+		if issue.Labels == nil {
+			issue.Labels = []*types.LabelIssueAssignmentInfo{}
+		}
+
+		if issue.MilestoneID == nil {
+			continue
+		}
+
+		m, ok := milestones[*issue.MilestoneID]
+		if !ok {
+			m, err = c.milestoneStore.Find(ctx, *issue.MilestoneID)
+			if err != nil {
+				return fmt.Errorf("failed to find issue milestone: %w", err)
+			}
+			milestones[m.ID] = m
+		}
+
+		issue.Milestone = m
+	}
+
+	return nil
+}
+
+func eventBase(issue *types.Issue, principalID int64) issueevents.Base {
+	return issueevents.Base{
+		IssueID:     issue.ID,
+		RepoID:      issue.RepoID,
+		Number:      issue.Number,
+		PrincipalID: principalID,
+	}
+}
+
+var mentionRegex = regexp.MustCompile(`@\[(\d+)\]`)
+
+// processMentions finds the principals mentioned in the text
+// and returns the metadata update that stores the mentions.
+func (c *Controller) processMentions(
+	ctx context.Context,
+	text string,
+) ([]types.PullReqActivityMetadataUpdate, map[int64]*types.PrincipalInfo, error) {
+	var ids []int64
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		if id, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, map[int64]*types.PrincipalInfo{}, nil
+	}
+
+	infos, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch info from principalInfoCache: %w", err)
+	}
+
+	mentioned := make([]int64, 0, len(infos))
+	for id := range infos {
+		mentioned = append(mentioned, id)
+	}
+
+	return []types.PullReqActivityMetadataUpdate{
+		types.WithPullReqActivityMentionsMetadataUpdate(
+			func(m *types.PullReqActivityMentionsMetadata) {
+				m.IDs = mentioned
+			}),
+	}, infos, nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("Issue title can't be empty")
+	}
+
+	const maxLen = 256
+	if utf8.RuneCountInString(title) > maxLen {
+		return usererror.BadRequestf("Issue title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
+
+func validateDescription(desc string) error {
+	const maxLen = 64 << 10 // 64K
+	if len(desc) > maxLen {
+		return usererror.BadRequest("Issue description is too long")
+	}
+
+	return nil
+}
+
+func validateComment(text string) error {
+	if text == "" {
+		return usererror.BadRequest("Comment text can't be empty")
+	}
+
+	const maxLen = 16 << 10 // 16K
+	if len(text) > maxLen {
+		return usererror.BadRequest("Issue comment is too long")
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/refcache/refcachetest"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/registry/app/api/controller/mocks"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx/dbtxtest"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testRepoID   = int64(1)
+	testRepoRef  = "1"
+	testAuthorID = int64(10)
+)
+
+// testEnv is an issue controller of a single repository and the users with their permissions for it.
+type testEnv struct {
+	ctrl        *Controller
+	repo        *types.Repository
+	issues      *fakeIssueStore
+	activities  *fakeActivityStore
+	assignees   *fakeAssigneeStore
+	principals  map[int64]*types.Principal
+	permissions map[int64][]enum.Permission
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	eventSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create event system: %v", err)
+	}
+
+	eventReporter, err := issueevents.NewReporter(eventSystem)
+	if err != nil {
+		t.Fatalf("failed to create issue event reporter: %v", err)
+	}
+
+	repo := &types.Repository{ID: testRepoID, ParentID: 1, Path: "space/repo"}
+
+	env := &testEnv{
+		repo:        repo,
+		issues:      &fakeIssueStore{},
+		activities:  &fakeActivityStore{},
+		assignees:   &fakeAssigneeStore{},
+		principals:  map[int64]*types.Principal{},
+		permissions: map[int64][]enum.Permission{},
+	}
+
+	env.addPrincipal(testAuthorID, enum.PermissionRepoView, enum.PermissionRepoReview)
+
+	authorizer := new(mocks.Authorizer)
+	authorizer.On("Check", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(
+			_ context.Context,
+			session *auth.Session,
+			_ *types.Scope,
+			_ *types.Resource,
+			permission enum.Permission,
+		) bool {
+			return slices.Contains(env.permissions[session.Principal.ID], permission)
+		}, nil)
+
+	env.ctrl = NewController(
+		dbtxtest.Transactor{},
+		authorizer,
+		env.issues,
+		env.activities,
+		env.assignees,
+		nil,
+		&fakeRepoStore{repo: repo},
+		&fakePrincipalStore{principals: env.principals},
+		nil,
+		refcachetest.NewRepoFinder(repo),
+		label.New(nil, nil, nil, nil, nil, fakeIssueLabelStore{}, refcache.SpaceFinder{}),
+		eventReporter,
+		fakeStreamer{},
+	)
+
+	return env
+}
+
+// addPrincipal creates a user that has the provided permissions for the test repository.
+func (env *testEnv) addPrincipal(id int64, permissions ...enum.Permission) *auth.Session {
+	principal := &types.Principal{ID: id, UID: "user", Type: enum.PrincipalTypeUser}
+	env.principals[id] = principal
+	env.permissions[id] = permissions
+	return &auth.Session{Principal: *principal}
+}
+
+func (env *testEnv) session(id int64) *auth.Session {
+	return &auth.Session{Principal: *env.principals[id]}
+}
+
+// stateChanges returns the state change activities written to the issue timelines.
+func (env *testEnv) stateChanges() []types.IssueActivityPayloadStateChange {
+	var changes []types.IssueActivityPayloadStateChange
+	for _, payload := range env.activities.payloads {
+		if change, ok := payload.(*types.IssueActivityPayloadStateChange); ok {
+			changes = append(changes, *change)
+		}
+	}
+	return changes
+}
+
+type fakeIssueStore struct {
+	store.IssueStore
+	issues []*types.Issue
+}
+
+func (s *fakeIssueStore) Create(_ context.Context, issue *types.Issue) error {
+	issue.ID = int64(len(s.issues) + 1)
+	issue.Version = 1
+	s.issues = append(s.issues, issue)
+	return nil
+}
+
+func (s *fakeIssueStore) FindByNumber(_ context.Context, repoID, number int64) (*types.Issue, error) {
+	for _, issue := range s.issues {
+		if issue.RepoID == repoID && issue.Number == number {
+			clone := *issue
+			return &clone, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeIssueStore) UpdateOptLock(
+	_ context.Context,
+	issue *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	stored := s.issues[issue.ID-1]
+	if err := mutateFn(stored); err != nil {
+		return nil, err
+	}
+	stored.Version++
+	clone := *stored
+	return &clone, nil
+}
+
+func (s *fakeIssueStore) UpdateActivitySeq(_ context.Context, issue *types.Issue) (*types.Issue, error) {
+	stored := s.issues[issue.ID-1]
+	stored.ActivitySeq++
+	clone := *stored
+	return &clone, nil
+}
+
+type fakeActivityStore struct {
+	store.IssueActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.Issue,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.IssueActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.IssueActivity{}, nil
+}
+
+type fakeAssigneeStore struct {
+	store.IssueAssigneeStore
+	assignees []*types.IssueAssignee
+}
+
+func (s *fakeAssigneeStore) Create(_ context.Context, v *types.IssueAssignee) error {
+	s.assignees = append(s.assignees, v)
+	return nil
+}
+
+func (s *fakeAssigneeStore) List(_ context.Context, issueID int64) ([]*types.IssueAssignee, error) {
+	var list []*types.IssueAssignee
+	for _, assignee := range s.assignees {
+		if assignee.IssueID == issueID {
+			list = append(list, assignee)
+		}
+	}
+	return list, nil
+}
+
+func (s *fakeAssigneeStore) ListByIssueIDs(
+	_ context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.PrincipalInfo, error) {
+	m := map[int64][]*types.PrincipalInfo{}
+	for _, assignee := range s.assignees {
+		if slices.Contains(issueIDs, assignee.IssueID) {
+			m[assignee.IssueID] = append(m[assignee.IssueID], &types.PrincipalInfo{ID: assignee.PrincipalID})
+		}
+	}
+	return m, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	clone := *s.repo
+	return &clone, nil
+}
+
+func (s *fakeRepoStore) Update(_ context.Context, repo *types.Repository) error {
+	*s.repo = *repo
+	return nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+type fakeIssueLabelStore struct {
+	store.IssueLabelAssignmentStore
+}
+
+func (fakeIssueLabelStore) ListAssignedByIssueIDs(
+	context.Context,
+	[]int64,
+) (map[int64][]*types.LabelIssueAssignmentInfo, error) {
+	return map[int64][]*types.LabelIssueAssignmentInfo{}, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	MilestoneID *int64  `json:"milestone_id"`
+	AssigneeIDs []int64 `json:"assignee_ids"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	if err := validateDescription(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Create creates a new issue in the repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if in.MilestoneID != nil {
+		if _, err := c.findMilestone(ctx, repo.ID, *in.MilestoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	assignees, err := c.findAssignees(ctx, repo, in.AssigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue *types.Issue
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		// Always re-fetch at the start of the transaction because the repo we have is from a cache.
+		repoFull, err := c.repoStore.Find(ctx, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		// Update the repository's issue sequence number
+
+		repoFull.IssueSeq++
+		err = c.repoStore.Update(ctx, repoFull)
+		if err != nil {
+			return fmt.Errorf("failed to update issue sequence number: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+
+		issue = &types.Issue{
+			RepoID:      repo.ID,
+			Number:      repoFull.IssueSeq,
+			CreatedBy:   session.Principal.ID,
+			Created:     now,
+			Updated:     now,
+			Edited:      now,
+			State:       enum.IssueStateOpen,
+			Title:       in.Title,
+			Description: in.Description,
+			MilestoneID: in.MilestoneID,
+			Author:      *session.Principal.ToPrincipalInfo(),
+		}
+
+		if err = c.issueStore.Create(ctx, issue); err != nil {
+			return fmt.Errorf("failed to create issue: %w", err)
+		}
+
+		for _, assignee := range assignees {
+			err = c.assigneeStore.Create(ctx, &types.IssueAssignee{
+				IssueID:     issue.ID,
+				PrincipalID: assignee.ID,
+				Created:     now,
+				CreatedBy:   session.Principal.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to assign principal to issue: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.Created(ctx, &issueevents.CreatedPayload{
+		Base: eventBase(issue, session.Principal.ID),
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueCreated, issue)
+
+	return issue, nil
+}
+
+// findAssignees returns the principal infos of the provided principal IDs.
+// Only principals that can view the repository can be assigned to its issues.
+func (c *Controller) findAssignees(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	ids []int64,
+) ([]*types.PrincipalInfo, error) {
+	assignees := make([]*types.PrincipalInfo, 0, len(ids))
+	for _, id := range ids {
+		principal, err := c.principalStore.Find(ctx, id)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, usererror.BadRequestf("Principal with ID %d doesn't exist.", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find assignee: %w", err)
+		}
+
+		// To check the assignee's access to the repo we create a dummy session object, same as for reviewers.
+		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+			Principal: *principal,
+		}, repo, enum.PermissionRepoView); err != nil {
+			if !apiauth.IsNoAccess(err) {
+				return nil, fmt.Errorf("failed to check assignee's access to the repository: %w", err)
+			}
+			return nil, usererror.BadRequestf(
+				"Principal with ID %d doesn't have enough permissions for the repository.", id)
+		}
+
+		assignees = append(assignees, principal.ToPrincipalInfo())
+	}
+
+	return assignees, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCreate_NumberSequence(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	for i := range 3 {
+		issue, err := env.ctrl.Create(ctx, env.session(testAuthorID), testRepoRef, &CreateInput{Title: "issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+
+		if want := int64(i + 1); issue.Number != want {
+			t.Errorf("issue number mismatch: want=%d got=%d", want, issue.Number)
+		}
+		if issue.State != enum.IssueStateOpen {
+			t.Errorf("issue state mismatch: want=%s got=%s", enum.IssueStateOpen, issue.State)
+		}
+	}
+
+	if env.repo.IssueSeq != 3 {
+		t.Errorf("repository issue sequence mismatch: want=3 got=%d", env.repo.IssueSeq)
+	}
+}
+
+func TestCreate_Assignees(t *testing.T) {
+	const (
+		viewerID   = int64(20)
+		outsiderID = int64(21)
+		unknownID  = int64(22)
+	)
+
+	tests := []struct {
+		name         string
+		assigneeIDs  []int64
+		expBadReq    bool
+		expAssignees int
+	}{
+		{name: "no-assignees", assigneeIDs: nil},
+		{name: "viewer", assigneeIDs: []int64{viewerID, testAuthorID}, expAssignees: 2},
+		{name: "no-repo-access", assigneeIDs: []int64{viewerID, outsiderID}, expBadReq: true},
+		{name: "unknown-principal", assigneeIDs: []int64{unknownID}, expBadReq: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.addPrincipal(viewerID, enum.PermissionRepoView)
+			env.addPrincipal(outsiderID)
+
+			issue, err := env.ctrl.Create(context.Background(), env.session(testAuthorID), testRepoRef,
+				&CreateInput{Title: "issue", AssigneeIDs: test.assigneeIDs})
+
+			if test.expBadReq {
+				assertBadRequest(t, err)
+				if len(env.issues.issues) != 0 {
+					t.Errorf("expected no issue to be created")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(issue.Assignees) != test.expAssignees {
+				t.Errorf("assignee count mismatch: want=%d got=%d", test.expAssignees, len(issue.Assignees))
+			}
+		})
+	}
+}
+
+func TestAssigneeAdd_NoRepoAccess(t *testing.T) {
+	const outsiderID = int64(21)
+
+	env := newTestEnv(t)
+	env.addPrincipal(outsiderID)
+	ctx := context.Background()
+
+	issue, err := env.ctrl.Create(ctx, env.session(testAuthorID), testRepoRef, &CreateInput{Title: "issue"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	_, err = env.ctrl.AssigneeAdd(ctx, env.session(testAuthorID), testRepoRef, issue.Number,
+		&AssigneeAddInput{AssigneeID: outsiderID})
+	assertBadRequest(t, err)
+
+	if len(env.assignees.assignees) != 0 {
+		t.Errorf("expected no assignee to be added, got=%d", len(env.assignees.assignees))
+	}
+
+	_, err = env.ctrl.AssigneeAdd(ctx, env.session(testAuthorID), testRepoRef, issue.Number,
+		&AssigneeAddInput{AssigneeID: testAuthorID})
+	if err != nil {
+		t.Fatalf("failed to add assignee: %v", err)
+	}
+}
+
+func assertBadRequest(t *testing.T, err error) {
+	t.Helper()
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request error, got=%v", err)
+	}
+}
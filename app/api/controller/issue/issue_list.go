@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List returns a list of issues of the repository.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.IssueFilter,
+) ([]*types.Issue, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	filter.RepoID = repo.ID
+
+	count, err := c.issueStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	list, err := c.issueStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	if err = c.backfill(ctx, list...); err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
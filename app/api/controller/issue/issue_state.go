@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StateInput struct {
+	State enum.IssueState `json:"state"`
+}
+
+func (in *StateInput) Sanitize() error {
+	state, ok := in.State.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Issue state must be either open or closed")
+	}
+
+	in.State = state
+
+	return nil
+}
+
+// State closes or reopens an issue.
+// The state can be changed by the author of the issue and by users with push access to the repository.
+func (c *Controller) State(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *StateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if issue.CreatedBy != session.Principal.ID {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+	}
+
+	if issue.State != in.State {
+		issue, err = c.setState(ctx, repo, issue, session.Principal.ID, &types.IssueActivityPayloadStateChange{
+			Old: issue.State,
+			New: in.State,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+// CloseByReference closes the issue with the provided number because it got referenced
+// with a closing keyword by a merged pull request or by a commit pushed to the default branch.
+// Issues that are already closed are left untouched.
+func (c *Controller) CloseByReference(
+	ctx context.Context,
+	principalID int64,
+	repo *types.RepositoryCore,
+	issueNum int64,
+	pullReqNum *int64,
+	commitSHA string,
+) error {
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if issue.State == enum.IssueStateClosed {
+		return nil
+	}
+
+	_, err = c.setState(ctx, repo, issue, principalID, &types.IssueActivityPayloadStateChange{
+		Old:             issue.State,
+		New:             enum.IssueStateClosed,
+		ClosedByPullReq: pullReqNum,
+		ClosedByCommit:  commitSHA,
+	})
+
+	return err
+}
+
+// Reference writes a reference activity to the timeline of the issue with the provided number.
+// References to issues that don't exist are ignored.
+func (c *Controller) Reference(
+	ctx context.Context,
+	principalID int64,
+	repo *types.RepositoryCore,
+	issueNum int64,
+	payload *types.IssueActivityPayloadReference,
+) error {
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	c.writeSystemActivity(ctx, issue, principalID, payload)
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return nil
+}
+
+func (c *Controller) setState(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	issue *types.Issue,
+	principalID int64,
+	payload *types.IssueActivityPayloadStateChange,
+) (*types.Issue, error) {
+	issue, err := c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if issue.State == payload.New {
+			return nil
+		}
+
+		issue.State = payload.New
+		if payload.New == enum.IssueStateClosed {
+			now := time.Now().UnixMilli()
+			issue.Closed = &now
+			issue.ClosedBy = &principalID
+		} else {
+			issue.Closed = nil
+			issue.ClosedBy = nil
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue state: %w", err)
+	}
+
+	c.writeSystemActivity(ctx, issue, principalID, payload)
+
+	base := eventBase(issue, principalID)
+	if payload.New == enum.IssueStateClosed {
+		c.eventReporter.Closed(ctx, &issueevents.ClosedPayload{
+			Base:                  base,
+			ClosedByPullReqNumber: payload.ClosedByPullReq,
+		})
+	} else {
+		c.eventReporter.Reopened(ctx, &issueevents.ReopenedPayload{
+			Base: base,
+		})
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return issue, nil
+}
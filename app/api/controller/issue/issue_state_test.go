@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestState(t *testing.T) {
+	const (
+		pusherID   = int64(20)
+		reviewerID = int64(21)
+	)
+
+	open := enum.IssueStateOpen
+	closed := enum.IssueStateClosed
+
+	tests := []struct {
+		name       string
+		initial    enum.IssueState
+		actorID    int64
+		state      enum.IssueState
+		expErr     error
+		expState   enum.IssueState
+		expChanges []types.IssueActivityPayloadStateChange
+	}{
+		{
+			name:       "author-closes",
+			initial:    open,
+			actorID:    testAuthorID,
+			state:      closed,
+			expState:   closed,
+			expChanges: []types.IssueActivityPayloadStateChange{{Old: open, New: closed}},
+		},
+		{
+			name:       "author-reopens",
+			initial:    closed,
+			actorID:    testAuthorID,
+			state:      open,
+			expState:   open,
+			expChanges: []types.IssueActivityPayloadStateChange{{Old: closed, New: open}},
+		},
+		{
+			name:       "pusher-closes",
+			initial:    open,
+			actorID:    pusherID,
+			state:      closed,
+			expState:   closed,
+			expChanges: []types.IssueActivityPayloadStateChange{{Old: open, New: closed}},
+		},
+		{
+			name:     "reviewer-forbidden",
+			initial:  open,
+			actorID:  reviewerID,
+			state:    closed,
+			expErr:   apiauth.ErrForbidden,
+			expState: open,
+		},
+		{
+			name:     "unchanged",
+			initial:  open,
+			actorID:  testAuthorID,
+			state:    open,
+			expState: open,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.addPrincipal(pusherID, enum.PermissionRepoReview, enum.PermissionRepoPush)
+			env.addPrincipal(reviewerID, enum.PermissionRepoReview)
+			ctx := context.Background()
+
+			issue := createTestIssue(t, env, test.initial)
+
+			_, err := env.ctrl.State(ctx, env.session(test.actorID), testRepoRef, issue.Number,
+				&StateInput{State: test.state})
+			if !errors.Is(err, test.expErr) {
+				t.Fatalf("error mismatch: want=%v got=%v", test.expErr, err)
+			}
+
+			stored := env.issues.issues[issue.ID-1]
+			if stored.State != test.expState {
+				t.Errorf("state mismatch: want=%s got=%s", test.expState, stored.State)
+			}
+			if (stored.State == closed) != (stored.Closed != nil && stored.ClosedBy != nil) {
+				t.Errorf("closed fields mismatch: closed=%v closed_by=%v", stored.Closed, stored.ClosedBy)
+			}
+			if stored.State == closed && test.initial == open && *stored.ClosedBy != test.actorID {
+				t.Errorf("closed by mismatch: want=%d got=%d", test.actorID, *stored.ClosedBy)
+			}
+
+			if changes := env.stateChanges(); !reflect.DeepEqual(changes, test.expChanges) {
+				t.Errorf("state change activities mismatch: want=%+v got=%+v", test.expChanges, changes)
+			}
+		})
+	}
+}
+
+func TestCloseByReference(t *testing.T) {
+	const mergerID = int64(30)
+
+	prNum := int64(5)
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	repo := env.repo.Core()
+
+	openIssue := createTestIssue(t, env, enum.IssueStateOpen)
+	closedIssue := createTestIssue(t, env, enum.IssueStateClosed)
+
+	if err := env.ctrl.CloseByReference(ctx, mergerID, repo, openIssue.Number, &prNum, ""); err != nil {
+		t.Fatalf("failed to close issue by pull request: %v", err)
+	}
+	if err := env.ctrl.CloseByReference(ctx, mergerID, repo, closedIssue.Number, nil, "abc"); err != nil {
+		t.Fatalf("failed to close already closed issue: %v", err)
+	}
+	if err := env.ctrl.CloseByReference(ctx, mergerID, repo, 99, nil, "abc"); err != nil {
+		t.Fatalf("reference to unknown issue should be ignored: %v", err)
+	}
+
+	stored := env.issues.issues[openIssue.ID-1]
+	if stored.State != enum.IssueStateClosed || stored.ClosedBy == nil || *stored.ClosedBy != mergerID {
+		t.Errorf("expected issue closed by %d, got state=%s closed_by=%v", mergerID, stored.State, stored.ClosedBy)
+	}
+
+	exp := []types.IssueActivityPayloadStateChange{{
+		Old:             enum.IssueStateOpen,
+		New:             enum.IssueStateClosed,
+		ClosedByPullReq: &prNum,
+	}}
+	if changes := env.stateChanges(); !reflect.DeepEqual(changes, exp) {
+		t.Errorf("state change activities mismatch: want=%+v got=%+v", exp, changes)
+	}
+}
+
+// createTestIssue creates an issue authored by the test author, in the provided state.
+func createTestIssue(t *testing.T, env *testEnv, state enum.IssueState) *types.Issue {
+	t.Helper()
+
+	issue, err := env.ctrl.Create(context.Background(), env.session(testAuthorID), testRepoRef,
+		&CreateInput{Title: "issue"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	env.issues.issues[issue.ID-1].State = state
+
+	return issue
+}
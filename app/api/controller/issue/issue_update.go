@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	// MilestoneID sets the milestone of the issue. Zero removes the issue from its milestone.
+	MilestoneID *int64 `json:"milestone_id"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := validateDescription(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates the title, the description or the milestone of an issue.
+// Issues can be updated by their authors and by users with push access to the repository.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *UpdateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	if issue.CreatedBy != session.Principal.ID {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+	}
+
+	titleOld := issue.Title
+	descriptionOld := issue.Description
+	milestoneOld := issue.MilestoneID
+
+	var milestoneNew *types.Milestone
+	milestoneChanged := false
+	if in.MilestoneID != nil {
+		if *in.MilestoneID != 0 {
+			milestoneNew, err = c.findMilestone(ctx, repo.ID, *in.MilestoneID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		milestoneChanged = !equalIDs(milestoneOld, milestoneID(milestoneNew))
+	}
+
+	titleChanged := in.Title != nil && *in.Title != titleOld
+	descriptionChanged := in.Description != nil && *in.Description != descriptionOld
+
+	if !titleChanged && !descriptionChanged && !milestoneChanged {
+		if err = c.backfill(ctx, issue); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if titleChanged {
+			issue.Title = *in.Title
+		}
+		if descriptionChanged {
+			issue.Description = *in.Description
+		}
+		if titleChanged || descriptionChanged {
+			issue.Edited = time.Now().UnixMilli()
+		}
+		if milestoneChanged {
+			issue.MilestoneID = milestoneID(milestoneNew)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	if titleChanged {
+		c.writeSystemActivity(ctx, issue, session.Principal.ID, &types.PullRequestActivityPayloadTitleChange{
+			Old: titleOld,
+			New: issue.Title,
+		})
+	}
+
+	if milestoneChanged {
+		payload := &types.IssueActivityPayloadMilestoneChange{}
+		if milestoneOld != nil {
+			if old, err := c.milestoneStore.Find(ctx, *milestoneOld); err == nil {
+				payload.Old = &old.Title
+			}
+		}
+		if milestoneNew != nil {
+			payload.New = &milestoneNew.Title
+		}
+		c.writeSystemActivity(ctx, issue, session.Principal.ID, payload)
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	if titleChanged || descriptionChanged {
+		c.eventReporter.Updated(ctx, &issueevents.UpdatedPayload{
+			Base:           eventBase(issue, session.Principal.ID),
+			TitleOld:       titleOld,
+			TitleNew:       issue.Title,
+			DescriptionOld: descriptionOld,
+			DescriptionNew: issue.Description,
+		})
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue)
+
+	return issue, nil
+}
+
+func milestoneID(m *types.Milestone) *int64 {
+	if m == nil {
+		return nil
+	}
+	return &m.ID
+}
+
+func equalIDs(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssignLabel assigns a label to an issue.
+func (c *Controller) AssignLabel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *types.PullReqLabelAssignInput,
+) (*types.IssueLabel, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := in.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate input: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	out, err := c.labelSvc.AssignToIssue(
+		ctx, session.Principal.ID, issue.ID, repo.ID, repo.ParentID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue label: %w", err)
+	}
+
+	if out.ActivityType == enum.LabelActivityNoop {
+		return out.IssueLabel, nil
+	}
+
+	c.writeSystemActivity(ctx, issue, session.Principal.ID, labelActivityPayload(out))
+
+	// if the label has no value, the newValueID will be nil
+	var newValueID *int64
+	if out.NewLabelValue != nil {
+		newValueID = &out.NewLabelValue.ID
+	}
+
+	c.eventReporter.LabelAssigned(ctx, &issueevents.LabelAssignedPayload{
+		Base:    eventBase(issue, session.Principal.ID),
+		LabelID: out.Label.ID,
+		ValueID: newValueID,
+	})
+
+	return out.IssueLabel, nil
+}
+
+func labelActivityPayload(out *label.AssignToIssueOut) *types.PullRequestActivityLabel {
+	var oldValue *string
+	var oldValueColor *enum.LabelColor
+	if out.OldLabelValue != nil {
+		oldValue = &out.OldLabelValue.Value
+		oldValueColor = &out.OldLabelValue.Color
+	}
+
+	var value *string
+	var valueColor *enum.LabelColor
+	if out.NewLabelValue != nil {
+		value = &out.NewLabelValue.Value
+		valueColor = &out.NewLabelValue.Color
+	}
+
+	return &types.PullRequestActivityLabel{
+		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
+			Label:         out.Label.Key,
+			LabelColor:    out.Label.Color,
+			LabelScope:    out.Label.Scope,
+			Value:         value,
+			ValueColor:    valueColor,
+			OldValue:      oldValue,
+			OldValueColor: oldValueColor,
+		},
+		Type: out.ActivityType,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UnassignLabel removes a label from an issue.
+func (c *Controller) UnassignLabel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	labelID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+
+	label, labelValue, err := c.labelSvc.UnassignFromIssue(
+		ctx, repo.ID, repo.ParentID, issue.ID, labelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue label: %w", err)
+	}
+
+	var value *string
+	var color *enum.LabelColor
+	if labelValue != nil {
+		value = &labelValue.Value
+		color = &labelValue.Color
+	}
+
+	c.writeSystemActivity(ctx, issue, session.Principal.ID, &types.PullRequestActivityLabel{
+		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
+			Label:      label.Key,
+			LabelColor: label.Color,
+			LabelScope: label.Scope,
+			Value:      value,
+			ValueColor: color,
+		},
+		Type: enum.LabelActivityUnassign,
+	})
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MilestoneCreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Due         *int64 `json:"due"`
+}
+
+func (in *MilestoneCreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateMilestoneTitle(in.Title); err != nil {
+		return err
+	}
+
+	return validateDescription(in.Description)
+}
+
+// MilestoneCreate creates a new milestone in the repository.
+func (c *Controller) MilestoneCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MilestoneCreateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	milestone := &types.Milestone{
+		RepoID:      repo.ID,
+		Title:       in.Title,
+		Description: in.Description,
+		State:       enum.MilestoneStateOpen,
+		Due:         in.Due,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   session.Principal.ID,
+		UpdatedBy:   session.Principal.ID,
+	}
+
+	if err = c.milestoneStore.Create(ctx, milestone); err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+func validateMilestoneTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("Milestone title can't be empty")
+	}
+
+	const maxLen = 128
+	if len(title) > maxLen {
+		return usererror.BadRequestf("Milestone title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MilestoneFind returns a milestone of the repository.
+func (c *Controller) MilestoneFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+) (*types.Milestone, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.findMilestone(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.backfillMilestoneCounts(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// findMilestone returns the milestone with the provided ID if it belongs to the repository.
+func (c *Controller) findMilestone(ctx context.Context, repoID, milestoneID int64) (*types.Milestone, error) {
+	milestone, err := c.milestoneStore.Find(ctx, milestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	if milestone.RepoID != repoID {
+		return nil, usererror.NotFound("Milestone not found")
+	}
+
+	return milestone, nil
+}
+
+func (c *Controller) backfillMilestoneCounts(ctx context.Context, milestones ...*types.Milestone) error {
+	for _, milestone := range milestones {
+		open, closed, err := c.issueStore.CountByMilestone(ctx, milestone.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count milestone issues: %w", err)
+		}
+
+		milestone.OpenIssues = open
+		milestone.ClosedIssues = closed
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MilestoneUpdateInput struct {
+	Title       *string              `json:"title"`
+	Description *string              `json:"description"`
+	State       *enum.MilestoneState `json:"state"`
+	Due         *int64               `json:"due"`
+}
+
+func (in *MilestoneUpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateMilestoneTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := validateDescription(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.State != nil {
+		state, ok := in.State.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Milestone state must be either open or closed")
+		}
+		in.State = &state
+	}
+
+	return nil
+}
+
+// MilestoneUpdate updates a milestone of the repository.
+func (c *Controller) MilestoneUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	in *MilestoneUpdateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.findMilestone(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	if in.Title != nil {
+		milestone.Title = *in.Title
+	}
+	if in.Description != nil {
+		milestone.Description = *in.Description
+	}
+	if in.Due != nil {
+		milestone.Due = in.Due
+		if *in.Due == 0 {
+			milestone.Due = nil
+		}
+	}
+	if in.State != nil && *in.State != milestone.State {
+		milestone.State = *in.State
+		milestone.Closed = nil
+		if milestone.State == enum.MilestoneStateClosed {
+			milestone.Closed = &now
+		}
+	}
+
+	milestone.Updated = now
+	milestone.UpdatedBy = session.Principal.ID
+
+	if err = c.milestoneStore.Update(ctx, milestone); err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	if err = c.backfillMilestoneCounts(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
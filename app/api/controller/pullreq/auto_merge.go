@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeInput struct {
+	Method             enum.MergeMethod `json:"method"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok || method == "" {
+		return usererror.BadRequestf("Unsupported merge method: %q", in.Method)
+	}
+
+	in.Method = method
+
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables the auto-merge of a pull request. The pull request will be merged automatically
+// with the provided merge method as soon as all the merge requirements are satisfied.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.AutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	// use the protection rules to find out if the merge method is allowed.
+	rulesOut, _, err := c.Merge(ctx, session, repoRef, pullreqNum, &MergeInput{
+		SourceSHA:   pr.SourceSHA,
+		DryRunRules: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if !slices.Contains(rulesOut.AllowedMethods, in.Method) {
+		return nil, usererror.BadRequestf("Merge method %q is not allowed by the protection rules", in.Method)
+	}
+
+	now := time.Now().UnixMilli()
+	autoMerge := &types.AutoMerge{
+		PullReqID:          pr.ID,
+		Created:            now,
+		Updated:            now,
+		CreatedBy:          session.Principal.ID,
+		RepoID:             repo.ID,
+		Method:             in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+	}
+
+	if err := c.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to store pull request auto-merge: %w", err)
+	}
+
+	autoMerge.EnabledBy = session.Principal.ToPrincipalInfo()
+
+	pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMergeEnable{
+		MergeMethod:        in.Method,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+	}
+	if _, err := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, nil); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for enabled auto-merge")
+	}
+
+	c.eventReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base:               eventBase(pr, &session.Principal),
+		MergeMethod:        in.Method,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return autoMerge, nil
+}
+
+// AutoMergeDisable disables the auto-merge of a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	disabled, err := pullreq.DisableAutoMerge(ctx, pullreq.AutoMergeDisableInput{
+		AutoMergeStore:    c.autoMergeStore,
+		PullReqStore:      c.pullreqStore,
+		ActivityStore:     c.activityStore,
+		PullReqEvReporter: c.eventReporter,
+		SSEStreamer:       c.sseStreamer,
+	}, repo, pr, session.Principal.ID, enum.AutoMergeDisableReasonUser)
+	if err != nil {
+		return fmt.Errorf("failed to disable pull request auto-merge: %w", err)
+	}
+
+	if !disabled {
+		return usererror.NotFound("Auto-merge is not enabled for the pull request")
+	}
+
+	return nil
+}
+
+// backfillAutoMerge populates the auto-merge settings of the pull request.
+func (c *Controller) backfillAutoMerge(ctx context.Context, pr *types.PullReq) error {
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	autoMerge.EnabledBy, err = c.principalInfoCache.Get(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info of auto-merge creator: %w", err)
+	}
+
+	pr.AutoMerge = autoMerge
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAutoMergeInput_sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      AutoMergeInput
+		want    AutoMergeInput
+		wantErr bool
+	}{
+		{
+			name:    "missing-method",
+			in:      AutoMergeInput{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-method",
+			in:      AutoMergeInput{Method: "octopus"},
+			wantErr: true,
+		},
+		{
+			name: "squash-trims-title-and-message",
+			in:   AutoMergeInput{Method: enum.MergeMethodSquash, Title: " title ", Message: "\nmessage\n"},
+			want: AutoMergeInput{Method: enum.MergeMethodSquash, Title: "title", Message: "message"},
+		},
+		{
+			name: "rebase-without-title",
+			in:   AutoMergeInput{Method: enum.MergeMethodRebase, DeleteSourceBranch: true},
+			want: AutoMergeInput{Method: enum.MergeMethodRebase, DeleteSourceBranch: true},
+		},
+		{
+			name:    "rebase-with-title",
+			in:      AutoMergeInput{Method: enum.MergeMethodRebase, Title: "title"},
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward-with-message",
+			in:      AutoMergeInput{Method: enum.MergeMethodFastForward, Message: "message"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if in != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, in)
+			}
+		})
+	}
+}
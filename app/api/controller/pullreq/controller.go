@@ -29,8 +29,10 @@ import (
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -81,6 +83,9 @@ type Controller struct {
 	userGroupService       usergroup.Service
 	branchStore            store.BranchStore
 	userGroupResolver      usergroup.Resolver
+	mergeQueue             *mergequeue.Service
+	autoMergeStore         store.AutoMergeStore
+	signatureVerifyService publickey.SignatureVerifyService
 }
 
 func NewController(
@@ -117,6 +122,9 @@ func NewController(
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
+	mergeQueue *mergequeue.Service,
+	autoMergeStore store.AutoMergeStore,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -152,6 +160,9 @@ func NewController(
 		userGroupService:       userGroupService,
 		branchStore:            branchStore,
 		userGroupResolver:      userGroupResolver,
+		mergeQueue:             mergeQueue,
+		autoMergeStore:         autoMergeStore,
+		signatureVerifyService: signatureVerifyService,
 	}
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/audit"
@@ -46,6 +47,9 @@ import (
 	"golang.org/x/exp/maps"
 )
 
+// verifiedCommitsPageSize is the number of pull request commits that are read and verified at once.
+const verifiedCommitsPageSize = 1000
+
 type MergeInput struct {
 	Method             enum.MergeMethod `json:"method"`
 	SourceSHA          string           `json:"source_sha"`
@@ -53,9 +57,10 @@ type MergeInput struct {
 	Message            string           `json:"message"`
 	DeleteSourceBranch bool             `json:"delete_source_branch"`
 
-	BypassRules bool `json:"bypass_rules"`
-	DryRun      bool `json:"dry_run"`
-	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules      bool `json:"bypass_rules"`
+	BypassMergeQueue bool `json:"bypass_merge_queue"`
+	DryRun           bool `json:"dry_run"`
+	DryRunRules      bool `json:"dry_run_rules"`
 }
 
 func (in *MergeInput) sanitize() error {
@@ -115,11 +120,51 @@ func (c *Controller) backfillApprovalInfo(
 	return nil
 }
 
+// listVerifiedCommits returns the commits of the pull request with their signature verification results.
+func (c *Controller) listVerifiedCommits(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) ([]*types.Commit, error) {
+	// All commits are verified, otherwise old commits of a large pull request would bypass the rules.
+	var commits []*types.Commit
+	for page := 1; ; page++ {
+		out, err := c.git.ListCommitsInRange(ctx, git.ListCommitsInRangeParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+			Page:       page,
+			Limit:      verifiedCommitsPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		pageCommits := make([]*types.Commit, len(out.Commits))
+		for i := range out.Commits {
+			pageCommits[i] = controller.MapCommit(&out.Commits[i])
+		}
+
+		if err := c.signatureVerifyService.VerifyCommits(ctx, repo.ID, pageCommits); err != nil {
+			return nil, fmt.Errorf("failed to verify signature of pull request commits: %w", err)
+		}
+
+		commits = append(commits, pageCommits...)
+
+		if len(out.Commits) < verifiedCommitsPageSize {
+			return commits, nil
+		}
+	}
+}
+
 // Merge merges a pull request.
 //
 // It supports dry running by providing the DryRun=true. Dry running can be used to find any rule violations that
 // might block the merging. Dry running typically should be used with BypassRules=true.
 //
+// Pull requests that require the merge queue are added to the queue instead of being merged. Users who can bypass
+// the rules can merge such pull requests directly by explicitly providing BypassMergeQueue=true.
+//
 // MergeMethod doesn't need to be provided for dry running. If no MergeMethod has been provided the function will
 // return allowed merge methods. Rules can limit allowed merge methods.
 //
@@ -262,11 +307,28 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
+	var sourceCommits []*types.Commit
+	listSourceCommits := func(ctx context.Context) ([]*types.Commit, error) {
+		if sourceCommits != nil {
+			return sourceCommits, nil
+		}
+
+		commits, err := c.listVerifiedCommits(ctx, targetRepo, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceCommits = commits
+
+		return commits, nil
+	}
+
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
 		ResolveUserGroupIDs: c.userGroupService.ListUserIDsByGroupIDs,
 		MapUserGroupIDs:     c.userGroupService.MapGroupIDsToPrincipals,
 		Actor:               &session.Principal,
 		AllowBypass:         in.BypassRules,
+		BypassMergeQueue:    in.BypassMergeQueue,
 		IsRepoOwner:         isRepoOwner,
 		TargetRepo:          targetRepo,
 		SourceRepo:          sourceRepo,
@@ -275,6 +337,7 @@ func (c *Controller) Merge(
 		Method:              in.Method,
 		CheckResults:        checkResults,
 		CodeOwners:          codeOwnerWithApproval,
+		ListSourceCommits:   listSourceCommits,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -303,6 +366,8 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
+			CancelsAutoMergeOnPush:              ruleOut.CancelsAutoMergeOnPush,
 		}, nil, nil
 	}
 
@@ -456,6 +521,8 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
+			CancelsAutoMergeOnPush:              ruleOut.CancelsAutoMergeOnPush,
 		}
 
 		return out, nil, nil
@@ -528,6 +595,26 @@ func (c *Controller) Merge(
 		}
 	}
 
+	// the merge is postponed if the target branch requires the merge queue.
+
+	if ruleOut.RequiresMergeQueue {
+		_, err = c.mergeQueue.Enqueue(ctx, session.Principal.ID, pr, mergequeue.EnqueueInput{
+			Method:             in.Method,
+			Title:              in.Title,
+			Message:            in.Message,
+			DeleteSourceBranch: deleteSourceBranch,
+			RulesBypassed:      protection.IsBypassed(violations),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+		}
+
+		return &types.MergeResponse{
+			MergeQueued:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
 	// create merge commit(s)
 
 	log.Ctx(ctx).Debug().Msgf("all pre-check passed, merge PR")
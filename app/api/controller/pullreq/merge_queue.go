@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeQueueList returns the merge queue of a branch. If no branch is provided the default branch is used.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	entries, err := c.mergeQueue.List(ctx, repo.ID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue of its target branch.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	return c.mergeQueue.Dequeue(ctx, session.Principal.ID, pr)
+}
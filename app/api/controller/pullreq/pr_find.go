@@ -55,6 +55,10 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to backfill pull request metadata: %w", err)
 	}
 
+	if err := c.backfillAutoMerge(ctx, pr); err != nil {
+		return nil, err
+	}
+
 	return pr, nil
 }
 
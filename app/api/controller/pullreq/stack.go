@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Stack returns the open pull requests the pull request is stacked on and the ones stacked on top of it.
+func (c *Controller) Stack(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqStack, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	parents, err := pullreq.ListStackParents(ctx, c.pullreqStore, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parent pull requests: %w", err)
+	}
+
+	children := make([]*types.PullReq, 0)
+	if pr.State == enum.PullReqStateOpen {
+		children, err = pullreq.ListStackChildren(ctx, c.pullreqStore, pr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child pull requests: %w", err)
+		}
+	}
+
+	return &types.PullReqStack{
+		Parents:  parents,
+		Children: children,
+	}, nil
+}
@@ -22,8 +22,10 @@ import (
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -69,6 +71,9 @@ func ProvideController(
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
+	mergeQueue *mergequeue.Service,
+	autoMergeStore store.AutoMergeStore,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -103,5 +108,8 @@ func ProvideController(
 		userGroupService,
 		branchStore,
 		userGroupResolver,
+		mergeQueue,
+		autoMergeStore,
+		signatureVerifyService,
 	)
 }
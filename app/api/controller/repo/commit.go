@@ -45,9 +45,9 @@ type CommitFileAction struct {
 	Payload  string                   `json:"payload"`
 	Encoding enum.ContentEncodingType `json:"encoding"`
 
-	// SHA can be used for optimistic locking of an update action (Optional).
-	// The provided value is compared against the latest sha of the file that's being updated.
-	// If the SHA doesn't match, the update fails.
+	// SHA can be used for optimistic locking of an update or delete action (Optional).
+	// The provided value is compared against the latest sha of the file that's being updated or deleted.
+	// If the SHA doesn't match, the action fails.
 	// WARNING: If no SHA is provided, the update action will blindly overwrite the file's content.
 	SHA sha.SHA `json:"sha"`
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
@@ -121,6 +122,8 @@ type Controller struct {
 	lfsCtrl                *lfs.Controller
 	favoriteStore          store.FavoriteStore
 	signatureVerifyService publickey.SignatureVerifyService
+	notificationChannelSvc *notificationchannel.Service
+	watchStore             store.WatchStore
 }
 
 func NewController(
@@ -163,6 +166,8 @@ func NewController(
 	lfsCtrl *lfs.Controller,
 	favoriteStore store.FavoriteStore,
 	signatureVerifyService publickey.SignatureVerifyService,
+	notificationChannelSvc *notificationchannel.Service,
+	watchStore store.WatchStore,
 ) *Controller {
 	return &Controller{
 		defaultBranch:          config.Git.DefaultBranch,
@@ -204,6 +209,8 @@ func NewController(
 		lfsCtrl:                lfsCtrl,
 		favoriteStore:          favoriteStore,
 		signatureVerifyService: signatureVerifyService,
+		notificationChannelSvc: notificationChannelSvc,
+		watchStore:             watchStore,
 	}
 }
 
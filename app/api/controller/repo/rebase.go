@@ -36,6 +36,9 @@ type RebaseInput struct {
 	HeadBranch    string  `json:"head_branch"`
 	HeadCommitSHA sha.SHA `json:"head_commit_sha"`
 
+	// UpstreamCommitSHA is optional. If provided, only the commits of the head branch after it are rebased.
+	UpstreamCommitSHA sha.SHA `json:"upstream_commit_sha"`
+
 	DryRun      bool `json:"dry_run"`
 	DryRunRules bool `json:"dry_run_rules"`
 	BypassRules bool `json:"bypass_rules"`
@@ -122,6 +125,23 @@ func (c *Controller) Rebase(
 			in.HeadCommitSHA, headBranch.Branch.Name)
 	}
 
+	if !in.UpstreamCommitSHA.IsEmpty() {
+		// The upstream commit limits the commits that are rebased, so it must be a part of the head branch.
+		upstreamAncestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          readParams,
+			AncestorCommitSHA:   in.UpstreamCommitSHA,
+			DescendantCommitSHA: headBranch.Branch.SHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check upstream commit ancestor: %w", err)
+		}
+
+		if !upstreamAncestor.Ancestor {
+			return nil, nil, usererror.BadRequestf("The upstream commit %s isn't an ancestor of the branch %s",
+				in.UpstreamCommitSHA, headBranch.Branch.Name)
+		}
+	}
+
 	baseCommitSHA := in.BaseCommitSHA
 	if baseCommitSHA.IsEmpty() {
 		baseBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
@@ -177,6 +197,7 @@ func (c *Controller) Rebase(
 		HeadBranch:            in.HeadBranch,
 		Refs:                  refs,
 		HeadBranchExpectedSHA: in.HeadCommitSHA,
+		UpstreamSHA:           in.UpstreamCommitSHA,
 		Method:                gitenum.MergeMethodRebase,
 	})
 	if err != nil {
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindWatch returns the watch level of the current user for the repository.
+// If the user doesn't watch the repository, the participating level is returned.
+func (c *Controller) FindWatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Watch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	watch, err := c.watchStore.Find(ctx, session.Principal.ID, nil, &repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.Watch{
+			PrincipalID: session.Principal.ID,
+			RepoID:      &repo.ID,
+			Level:       enum.WatchLevelParticipating,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// Watch subscribes the current user to the activity of the repository.
+func (c *Controller) Watch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.WatchInput,
+) (*types.Watch, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	watch := &types.Watch{
+		PrincipalID: session.Principal.ID,
+		RepoID:      &repo.ID,
+		Level:       in.Level,
+	}
+
+	if err = c.watchStore.Upsert(ctx, watch); err != nil {
+		return nil, fmt.Errorf("failed to watch repo: %w", err)
+	}
+
+	return watch, nil
+}
+
+// Unwatch removes the subscription of the current user to the activity of the repository.
+func (c *Controller) Unwatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = c.watchStore.Delete(ctx, session.Principal.ID, nil, &repo.ID); err != nil {
+		return fmt.Errorf("failed to unwatch repo: %w", err)
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
@@ -90,6 +91,8 @@ func ProvideController(
 	lfsCtrl *lfs.Controller,
 	favoriteStore store.FavoriteStore,
 	signatureVerifyService publickey.SignatureVerifyService,
+	notificationChannelSvc *notificationchannel.Service,
+	watchStore store.WatchStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -98,7 +101,8 @@ func ProvideController(
 		principalInfoCache, protectionManager, rpcClient, spaceFinder, repoFinder, importer, referenceSync,
 		codeOwners, repoReporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
-		rulesSvc, sseStreamer, lfsCtrl, favoriteStore, signatureVerifyService,
+		rulesSvc, sseStreamer, lfsCtrl, favoriteStore, signatureVerifyService, notificationChannelSvc,
+		watchStore,
 	)
 }
 
@@ -33,6 +33,7 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -76,38 +77,40 @@ func (s SpaceOutput) MarshalJSON() ([]byte, error) {
 type Controller struct {
 	nestedSpacesEnabled bool
 
-	tx                  dbtx.Transactor
-	urlProvider         url.Provider
-	sseStreamer         sse.Streamer
-	identifierCheck     check.SpaceIdentifier
-	authorizer          authz.Authorizer
-	spacePathStore      store.SpacePathStore
-	pipelineStore       store.PipelineStore
-	secretStore         store.SecretStore
-	connectorStore      store.ConnectorStore
-	templateStore       store.TemplateStore
-	spaceStore          store.SpaceStore
-	repoStore           store.RepoStore
-	principalStore      store.PrincipalStore
-	repoCtrl            *repo.Controller
-	membershipStore     store.MembershipStore
-	prListService       *pullreq.ListService
-	spaceFinder         refcache.SpaceFinder
-	importer            *importer.JobRepository
-	exporter            *exporter.Repository
-	resourceLimiter     limiter.ResourceLimiter
-	publicAccess        publicaccess.Service
-	auditService        audit.Service
-	gitspaceSvc         *gitspace.Service
-	labelSvc            *label.Service
-	instrumentation     instrument.Service
-	executionStore      store.ExecutionStore
-	rulesSvc            *rules.Service
-	usageMetricStore    store.UsageMetricStore
-	repoIdentifierCheck check.RepoIdentifier
-	infraProviderSvc    *infraprovider.Service
-	favoriteStore       store.FavoriteStore
-	spaceSvc            *space.Service
+	tx                     dbtx.Transactor
+	urlProvider            url.Provider
+	sseStreamer            sse.Streamer
+	identifierCheck        check.SpaceIdentifier
+	authorizer             authz.Authorizer
+	spacePathStore         store.SpacePathStore
+	pipelineStore          store.PipelineStore
+	secretStore            store.SecretStore
+	connectorStore         store.ConnectorStore
+	templateStore          store.TemplateStore
+	spaceStore             store.SpaceStore
+	repoStore              store.RepoStore
+	principalStore         store.PrincipalStore
+	repoCtrl               *repo.Controller
+	membershipStore        store.MembershipStore
+	prListService          *pullreq.ListService
+	spaceFinder            refcache.SpaceFinder
+	importer               *importer.JobRepository
+	exporter               *exporter.Repository
+	resourceLimiter        limiter.ResourceLimiter
+	publicAccess           publicaccess.Service
+	auditService           audit.Service
+	gitspaceSvc            *gitspace.Service
+	labelSvc               *label.Service
+	instrumentation        instrument.Service
+	executionStore         store.ExecutionStore
+	rulesSvc               *rules.Service
+	usageMetricStore       store.UsageMetricStore
+	repoIdentifierCheck    check.RepoIdentifier
+	infraProviderSvc       *infraprovider.Service
+	favoriteStore          store.FavoriteStore
+	spaceSvc               *space.Service
+	notificationChannelSvc *notificationchannel.Service
+	watchStore             store.WatchStore
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -123,41 +126,44 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider.Service, favoriteStore store.FavoriteStore, spaceSvc *space.Service,
+	notificationChannelSvc *notificationchannel.Service, watchStore store.WatchStore,
 ) *Controller {
 	return &Controller{
-		nestedSpacesEnabled: config.NestedSpacesEnabled,
-		tx:                  tx,
-		urlProvider:         urlProvider,
-		sseStreamer:         sseStreamer,
-		identifierCheck:     identifierCheck,
-		authorizer:          authorizer,
-		spacePathStore:      spacePathStore,
-		pipelineStore:       pipelineStore,
-		secretStore:         secretStore,
-		connectorStore:      connectorStore,
-		templateStore:       templateStore,
-		spaceStore:          spaceStore,
-		repoStore:           repoStore,
-		principalStore:      principalStore,
-		repoCtrl:            repoCtrl,
-		membershipStore:     membershipStore,
-		prListService:       prListService,
-		spaceFinder:         spaceFinder,
-		importer:            importer,
-		exporter:            exporter,
-		resourceLimiter:     limiter,
-		publicAccess:        publicAccess,
-		auditService:        auditService,
-		gitspaceSvc:         gitspaceSvc,
-		labelSvc:            labelSvc,
-		instrumentation:     instrumentation,
-		executionStore:      executionStore,
-		rulesSvc:            rulesSvc,
-		usageMetricStore:    usageMetricStore,
-		repoIdentifierCheck: repoIdentifierCheck,
-		infraProviderSvc:    infraProviderSvc,
-		favoriteStore:       favoriteStore,
-		spaceSvc:            spaceSvc,
+		nestedSpacesEnabled:    config.NestedSpacesEnabled,
+		tx:                     tx,
+		urlProvider:            urlProvider,
+		sseStreamer:            sseStreamer,
+		identifierCheck:        identifierCheck,
+		authorizer:             authorizer,
+		spacePathStore:         spacePathStore,
+		pipelineStore:          pipelineStore,
+		secretStore:            secretStore,
+		connectorStore:         connectorStore,
+		templateStore:          templateStore,
+		spaceStore:             spaceStore,
+		repoStore:              repoStore,
+		principalStore:         principalStore,
+		repoCtrl:               repoCtrl,
+		membershipStore:        membershipStore,
+		prListService:          prListService,
+		spaceFinder:            spaceFinder,
+		importer:               importer,
+		exporter:               exporter,
+		resourceLimiter:        limiter,
+		publicAccess:           publicAccess,
+		auditService:           auditService,
+		gitspaceSvc:            gitspaceSvc,
+		labelSvc:               labelSvc,
+		instrumentation:        instrumentation,
+		executionStore:         executionStore,
+		rulesSvc:               rulesSvc,
+		usageMetricStore:       usageMetricStore,
+		repoIdentifierCheck:    repoIdentifierCheck,
+		infraProviderSvc:       infraProviderSvc,
+		favoriteStore:          favoriteStore,
+		spaceSvc:               spaceSvc,
+		notificationChannelSvc: notificationChannelSvc,
+		watchStore:             watchStore,
 	}
 }
 
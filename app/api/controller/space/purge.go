@@ -85,6 +85,8 @@ func (c *Controller) PurgeNoAuth(
 				Int64("repo_parent_id", repo.ParentID).
 				Msg("failed to delete repository")
 		}
+
+		c.repoCtrl.ReportDeleted(ctx, repo)
 	}
 
 	return nil
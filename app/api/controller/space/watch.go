@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindWatch returns the watch level of the current user for the space.
+// If the user doesn't watch the space, the participating level is returned.
+func (c *Controller) FindWatch(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.Watch, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	watch, err := c.watchStore.Find(ctx, session.Principal.ID, &space.ID, nil)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.Watch{
+			PrincipalID: session.Principal.ID,
+			SpaceID:     &space.ID,
+			Level:       enum.WatchLevelParticipating,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// Watch subscribes the current user to the activity of the space.
+func (c *Controller) Watch(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.WatchInput,
+) (*types.Watch, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	watch := &types.Watch{
+		PrincipalID: session.Principal.ID,
+		SpaceID:     &space.ID,
+		Level:       in.Level,
+	}
+
+	if err = c.watchStore.Upsert(ctx, watch); err != nil {
+		return nil, fmt.Errorf("failed to watch space: %w", err)
+	}
+
+	return watch, nil
+}
+
+// Unwatch removes the subscription of the current user to the activity of the space.
+func (c *Controller) Unwatch(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err = c.watchStore.Delete(ctx, session.Principal.ID, &space.ID, nil); err != nil {
+		return fmt.Errorf("failed to unwatch space: %w", err)
+	}
+
+	return nil
+}
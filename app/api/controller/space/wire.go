@@ -24,6 +24,7 @@ import (
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -58,6 +59,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	labelSvc *label.Service, instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider2.Service, favoriteStore store.FavoriteStore, spaceSvc *space.Service,
+	notificationChannelSvc *notificationchannel.Service, watchStore store.WatchStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		sseStreamer, identifierCheck, authorizer,
@@ -70,6 +72,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		auditService, gitspaceService,
 		labelSvc, instrumentation, executionStore,
 		rulesSvc, usageMetricStore, repoIdentifierCheck,
-		infraProviderSvc, favoriteStore, spaceSvc,
+		infraProviderSvc, favoriteStore, spaceSvc, notificationChannelSvc,
+		watchStore,
 	)
 }
@@ -21,19 +21,22 @@ import (
 )
 
 type Controller struct {
-	templateStore store.TemplateStore
-	authorizer    authz.Authorizer
-	spaceFinder   refcache.SpaceFinder
+	templateStore        store.TemplateStore
+	templateVersionStore store.TemplateVersionStore
+	authorizer           authz.Authorizer
+	spaceFinder          refcache.SpaceFinder
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	templateStore store.TemplateStore,
+	templateVersionStore store.TemplateVersionStore,
 	spaceFinder refcache.SpaceFinder,
 ) *Controller {
 	return &Controller{
-		templateStore: templateStore,
-		authorizer:    authorizer,
-		spaceFinder:   spaceFinder,
+		templateStore:        templateStore,
+		templateVersionStore: templateVersionStore,
+		authorizer:           authorizer,
+		spaceFinder:          spaceFinder,
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pipelinetemplate "github.com/harness/gitness/app/pipeline/converter/template"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+var versionRegex = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._+-]{0,63}$`)
+
+type CreateVersionInput struct {
+	Version string                          `json:"version"`
+	Data    string                          `json:"data"`
+	Inputs  map[string]*types.TemplateInput `json:"inputs"`
+}
+
+// CreateVersion creates a new version of a template. Template versions are immutable,
+// hence pipelines using a version always get the same pipeline definition.
+func (c *Controller) CreateVersion(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	resolverType enum.ResolverType,
+	in *CreateVersionInput,
+) (*types.TemplateVersion, error) {
+	if err := c.sanitizeCreateVersionInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	space, err := c.spaceFinder.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	err = apiauth.CheckTemplate(ctx, c.authorizer, session, space.Path, identifier, enum.PermissionTemplateEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	template, err := c.templateStore.FindByIdentifierAndType(ctx, space.ID, identifier, resolverType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template: %w", err)
+	}
+
+	// pipelines use templates as a whole pipeline stage, hence only stage templates are versioned.
+	if template.Type != enum.ResolverTypeStage {
+		return nil, usererror.BadRequest("Only stage templates can have versions.")
+	}
+
+	version := &types.TemplateVersion{
+		TemplateID: template.ID,
+		Version:    in.Version,
+		Data:       in.Data,
+		Inputs:     in.Inputs,
+		CreatedBy:  session.Principal.ID,
+		Created:    time.Now().UnixMilli(),
+	}
+
+	err = c.templateVersionStore.Create(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("template version creation failed: %w", err)
+	}
+
+	return version, nil
+}
+
+func (c *Controller) sanitizeCreateVersionInput(in *CreateVersionInput) error {
+	if !versionRegex.MatchString(in.Version) {
+		return check.NewValidationError(
+			"Version has to start with a letter or a digit and contain at most 64 letters, digits and '.+-_'.")
+	}
+
+	if in.Inputs == nil {
+		in.Inputs = map[string]*types.TemplateInput{}
+	}
+
+	if err := pipelinetemplate.Validate(in.Data, in.Inputs); err != nil {
+		return check.NewValidationError(fmt.Sprintf("invalid template version: %s", err))
+	}
+
+	return nil
+}
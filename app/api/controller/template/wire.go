@@ -29,8 +29,9 @@ var WireSet = wire.NewSet(
 
 func ProvideController(
 	templateStore store.TemplateStore,
+	templateVersionStore store.TemplateVersionStore,
 	authorizer authz.Authorizer,
 	spaceFinder refcache.SpaceFinder,
 ) *Controller {
-	return NewController(authorizer, templateStore, spaceFinder)
+	return NewController(authorizer, templateStore, templateVersionStore, spaceFinder)
 }
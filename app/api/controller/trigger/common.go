@@ -15,8 +15,15 @@
 package trigger
 
 import (
+	"time"
+
+	triggerservice "github.com/harness/gitness/app/services/trigger"
+	gitcheck "github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 const (
@@ -24,6 +31,9 @@ const (
 	// TODO: Check whether this is sufficient for other SCM providers once we
 	// add support. For now it's good to have a limit and increase if needed.
 	triggerMaxSecretLength = 4096
+
+	// triggerMaxPathPatterns defines the max allowed number of path patterns of a trigger.
+	triggerMaxPathPatterns = 100
 )
 
 // checkSecret validates the secret of a trigger.
@@ -66,3 +76,55 @@ func deduplicateActions(in []enum.TriggerAction) []enum.TriggerAction {
 
 	return out
 }
+
+func checkPaths(paths types.TriggerPaths) error {
+	if len(paths.Include)+len(paths.Exclude) > triggerMaxPathPatterns {
+		return check.NewValidationErrorf("A trigger can have at most %d path patterns.", triggerMaxPathPatterns)
+	}
+
+	for _, patterns := range [][]string{paths.Include, paths.Exclude} {
+		for _, pattern := range patterns {
+			if pattern == "" || !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The provided path pattern '%s' is invalid.", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkCatchUp(catchUp enum.TriggerCatchUp) (enum.TriggerCatchUp, error) {
+	catchUp, ok := catchUp.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided catch-up policy '%s' is invalid.", catchUp)
+	}
+
+	return catchUp, nil
+}
+
+func checkBranch(branch string) error {
+	if branch == "" {
+		return nil
+	}
+
+	if err := gitcheck.BranchName(branch); err != nil {
+		return check.NewValidationErrorf("The provided branch name '%s' is invalid.", branch)
+	}
+
+	return nil
+}
+
+// nextCronRun validates the cron schedule and returns the time of its first run after the provided time.
+func nextCronRun(cron, timezone string, after time.Time) (int64, error) {
+	schedule, err := triggerservice.ParseCronSchedule(cron, timezone)
+	if err != nil {
+		return 0, check.NewValidationErrorf("The provided cron schedule is invalid: %s", err)
+	}
+
+	nextRun := schedule.NextRun(after)
+	if nextRun == 0 {
+		return 0, check.NewValidationError("The provided cron schedule has no upcoming runs.")
+	}
+
+	return nextRun, nil
+}
@@ -17,6 +17,7 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/auth"
@@ -34,6 +35,13 @@ type CreateInput struct {
 	Secret     string               `json:"secret"`
 	Disabled   bool                 `json:"disabled"`
 	Actions    []enum.TriggerAction `json:"actions"`
+	Paths      types.TriggerPaths   `json:"paths"`
+
+	// Cron is the schedule of a cron trigger. Cron triggers can't have actions.
+	Cron     string              `json:"cron"`
+	Timezone string              `json:"timezone"`
+	Branch   string              `json:"branch"`
+	CatchUp  enum.TriggerCatchUp `json:"catch_up"`
 }
 
 func (c *Controller) Create(
@@ -57,7 +65,7 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
-	now := time.Now().UnixMilli()
+	now := time.Now()
 	trigger := &types.Trigger{
 		Description: in.Description,
 		Disabled:    in.Disabled,
@@ -65,12 +73,28 @@ func (c *Controller) Create(
 		CreatedBy:   session.Principal.ID,
 		RepoID:      repo.ID,
 		Actions:     deduplicateActions(in.Actions),
+		Paths:       in.Paths,
 		Identifier:  in.Identifier,
 		PipelineID:  pipeline.ID,
-		Created:     now,
-		Updated:     now,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
 		Version:     0,
 	}
+
+	if in.Cron != "" {
+		trigger.Type = enum.TriggerCron
+		trigger.Cron = in.Cron
+		trigger.Timezone = in.Timezone
+		trigger.Branch = in.Branch
+		trigger.CatchUp = in.CatchUp
+
+		trigger.NextRun, err = nextCronRun(in.Cron, in.Timezone, now)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		trigger.Type = enum.TriggerHook
+	}
 	err = c.triggerStore.Create(ctx, trigger)
 	if err != nil {
 		return nil, fmt.Errorf("trigger creation failed: %w", err)
@@ -94,7 +118,43 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
-	if err := check.Identifier(in.Identifier); err != nil { //nolint:revive
+	if err := checkPaths(in.Paths); err != nil {
+		return err
+	}
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	return sanitizeCreateCronInput(in)
+}
+
+func sanitizeCreateCronInput(in *CreateInput) error {
+	in.Cron = strings.TrimSpace(in.Cron)
+	in.Timezone = strings.TrimSpace(in.Timezone)
+	in.Branch = strings.TrimSpace(in.Branch)
+
+	if in.Cron == "" {
+		if in.Timezone != "" || in.Branch != "" || in.CatchUp != "" {
+			return check.NewValidationError("Timezone, branch and catch-up policy require a cron schedule.")
+		}
+		return nil
+	}
+
+	if len(in.Actions) > 0 || !in.Paths.IsEmpty() {
+		return check.NewValidationError("A cron trigger can't have actions or path patterns.")
+	}
+
+	if _, err := nextCronRun(in.Cron, in.Timezone, time.Now()); err != nil {
+		return err
+	}
+
+	if err := checkBranch(in.Branch); err != nil {
+		return err
+	}
+
+	var err error
+	in.CatchUp, err = checkCatchUp(in.CatchUp)
+	if err != nil {
 		return err
 	}
 
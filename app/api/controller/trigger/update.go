@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -32,8 +33,19 @@ type UpdateInput struct {
 	UID        *string              `json:"uid" deprecated:"true"`
 	Identifier *string              `json:"identifier"`
 	Actions    []enum.TriggerAction `json:"actions"`
+	Paths      *types.TriggerPaths  `json:"paths"`
 	Secret     *string              `json:"secret"`
 	Disabled   *bool                `json:"disabled"` // can be nil, so keeping it a pointer
+
+	// Cron related fields can be updated only for cron triggers.
+	Cron     *string              `json:"cron"`
+	Timezone *string              `json:"timezone"`
+	Branch   *string              `json:"branch"`
+	CatchUp  *enum.TriggerCatchUp `json:"catch_up"`
+}
+
+func (in *UpdateInput) hasCronChanges() bool {
+	return in.Cron != nil || in.Timezone != nil || in.Branch != nil || in.CatchUp != nil
 }
 
 func (c *Controller) Update(
@@ -65,6 +77,8 @@ func (c *Controller) Update(
 
 	return c.triggerStore.UpdateOptLock(ctx,
 		trigger, func(original *types.Trigger) error {
+			old := *original
+
 			if in.Identifier != nil {
 				original.Identifier = *in.Identifier
 			}
@@ -74,6 +88,9 @@ func (c *Controller) Update(
 			if in.Actions != nil {
 				original.Actions = deduplicateActions(in.Actions)
 			}
+			if in.Paths != nil {
+				original.Paths = *in.Paths
+			}
 			if in.Secret != nil {
 				original.Secret = *in.Secret
 			}
@@ -81,10 +98,54 @@ func (c *Controller) Update(
 				original.Disabled = *in.Disabled
 			}
 
-			return nil
+			return applyCronUpdate(original, &old, in)
 		})
 }
 
+// applyCronUpdate updates the cron fields of the trigger.
+// The next run is recalculated if the schedule changed or if the trigger got enabled,
+// so runs missed while the trigger was disabled aren't caught up.
+func applyCronUpdate(trigger *types.Trigger, old *types.Trigger, in *UpdateInput) error {
+	if !trigger.IsCron() {
+		if in.hasCronChanges() {
+			return check.NewValidationError("Only cron triggers can have a schedule.")
+		}
+		return nil
+	}
+
+	if len(trigger.Actions) > 0 || !trigger.Paths.IsEmpty() {
+		return check.NewValidationError("A cron trigger can't have actions or path patterns.")
+	}
+
+	if in.Cron != nil {
+		trigger.Cron = *in.Cron
+	}
+	if in.Timezone != nil {
+		trigger.Timezone = *in.Timezone
+	}
+	if in.Branch != nil {
+		trigger.Branch = *in.Branch
+	}
+	if in.CatchUp != nil {
+		trigger.CatchUp = *in.CatchUp
+	}
+
+	scheduleChanged := trigger.Cron != old.Cron || trigger.Timezone != old.Timezone
+	enabled := old.Disabled && !trigger.Disabled
+	if !scheduleChanged && !enabled && trigger.NextRun != 0 {
+		return nil
+	}
+
+	nextRun, err := nextCronRun(trigger.Cron, trigger.Timezone, time.Now())
+	if err != nil {
+		return err
+	}
+
+	trigger.NextRun = nextRun
+
+	return nil
+}
+
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == nil {
@@ -116,5 +177,41 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Paths != nil {
+		if err := checkPaths(*in.Paths); err != nil {
+			return err
+		}
+	}
+
+	return sanitizeUpdateCronInput(in)
+}
+
+func sanitizeUpdateCronInput(in *UpdateInput) error {
+	if in.Cron != nil {
+		*in.Cron = strings.TrimSpace(*in.Cron)
+		if *in.Cron == "" {
+			return check.NewValidationError("The cron schedule can't be empty.")
+		}
+	}
+
+	if in.Timezone != nil {
+		*in.Timezone = strings.TrimSpace(*in.Timezone)
+	}
+
+	if in.Branch != nil {
+		*in.Branch = strings.TrimSpace(*in.Branch)
+		if err := checkBranch(*in.Branch); err != nil {
+			return err
+		}
+	}
+
+	if in.CatchUp != nil {
+		catchUp, err := checkCatchUp(*in.CatchUp)
+		if err != nil {
+			return err
+		}
+		*in.CatchUp = catchUp
+	}
+
 	return nil
 }
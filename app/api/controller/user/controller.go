@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	userevents "github.com/harness/gitness/app/events/user"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -42,6 +43,10 @@ type Controller struct {
 	eventReporter           *userevents.Reporter
 	repoFinder              refcache.RepoFinder
 	favoriteStore           store.FavoriteStore
+	spaceFinder             refcache.SpaceFinder
+	notificationPrefStore   store.NotificationPreferenceStore
+	watchStore              store.WatchStore
+	sseStreamer             sse.Streamer
 }
 
 func NewController(
@@ -57,6 +62,10 @@ func NewController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	spaceFinder refcache.SpaceFinder,
+	notificationPrefStore store.NotificationPreferenceStore,
+	watchStore store.WatchStore,
+	sseStreamer sse.Streamer,
 ) *Controller {
 	return &Controller{
 		tx:                      tx,
@@ -71,6 +80,10 @@ func NewController(
 		eventReporter:           eventReporter,
 		repoFinder:              repoFinder,
 		favoriteStore:           favoriteStore,
+		spaceFinder:             spaceFinder,
+		notificationPrefStore:   notificationPrefStore,
+		watchStore:              watchStore,
+		sseStreamer:             sseStreamer,
 	}
 }
 
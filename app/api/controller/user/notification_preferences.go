@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindNotificationPreferences returns the notification preferences of the user.
+func (c *Controller) FindNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.NotificationPreferences, error) {
+	return c.findNotificationPreferences(ctx, session.Principal.ID)
+}
+
+// UpdateNotificationPreferences updates the notification preferences of the user.
+func (c *Controller) UpdateNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.NotificationPreferencesUpdateInput,
+) (*types.NotificationPreferences, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.findNotificationPreferences(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.DisabledEvents != nil {
+		prefs.DisabledEvents = *in.DisabledEvents
+	}
+	if in.Delivery != nil {
+		prefs.Delivery = *in.Delivery
+	}
+
+	if err := c.notificationPrefStore.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// findNotificationPreferences returns the stored notification preferences of the principal,
+// or the default preferences if the principal never changed them.
+func (c *Controller) findNotificationPreferences(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationPreferences, error) {
+	prefs, err := c.notificationPrefStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.NotificationPreferences{
+			PrincipalID:    principalID,
+			DisabledEvents: []enum.NotificationEvent{},
+			Delivery:       enum.NotificationDeliveryInstant,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
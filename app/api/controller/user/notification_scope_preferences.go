@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListNotificationScopePreferences lists the space and repository notification preferences of the user.
+func (c *Controller) ListNotificationScopePreferences(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.NotificationScopePreference, error) {
+	prefs, err := c.notificationPrefStore.ListScopes(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification scope preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SetNotificationScopePreference watches or ignores notifications from a space or repository.
+func (c *Controller) SetNotificationScopePreference(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.NotificationScopePreferenceInput,
+) (*types.NotificationScopePreference, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	spaceID, repoID, err := c.getNotificationScope(ctx, session, in.ResourceType, in.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pref := &types.NotificationScopePreference{
+		PrincipalID: session.Principal.ID,
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Mode:        in.Mode,
+	}
+
+	if err := c.notificationPrefStore.UpsertScope(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to set notification scope preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+// DeleteNotificationScopePreference removes the notification preference of the user
+// for a space or repository.
+func (c *Controller) DeleteNotificationScopePreference(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.ResourceType,
+	resourceID int64,
+) error {
+	spaceID, repoID, err := c.getNotificationScope(ctx, session, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+
+	err = c.notificationPrefStore.DeleteScope(ctx, session.Principal.ID, spaceID, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification scope preference: %w", err)
+	}
+
+	return nil
+}
+
+// getNotificationScope checks that the user has access to the space/repository
+// and returns its space ID or repository ID.
+func (c *Controller) getNotificationScope(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.ResourceType,
+	resourceID int64,
+) (*int64, *int64, error) {
+	switch resourceType { // nolint:exhaustive
+	case enum.ResourceTypeRepo:
+		repo, err := c.repoFinder.FindByID(ctx, resourceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, nil, err
+		}
+		return nil, &repo.ID, nil
+	case enum.ResourceTypeSpace:
+		space, err := c.spaceFinder.FindByID(ctx, resourceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find space: %w", err)
+		}
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return nil, nil, err
+		}
+		return &space.ID, nil, nil
+	default:
+		return nil, nil, usererror.BadRequestf("Resource type %s doesn't support notification preferences.", resourceType)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	userevents "github.com/harness/gitness/app/events/user"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -42,6 +43,10 @@ func ProvideController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	spaceFinder refcache.SpaceFinder,
+	notificationPrefStore store.NotificationPreferenceStore,
+	watchStore store.WatchStore,
+	sseStreamer sse.Streamer,
 ) *Controller {
 	return NewController(
 		tx,
@@ -55,5 +60,9 @@ func ProvideController(
 		gitSignatureResultStore,
 		eventReporter,
 		repoFinder,
-		favoriteStore)
+		favoriteStore,
+		spaceFinder,
+		notificationPrefStore,
+		watchStore,
+		sseStreamer)
 }
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/artifact"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderCacheKey contains the key of the restored cache,
+// which differs from the requested key in case the cache was found using a restore key.
+const HeaderCacheKey = "X-Cache-Key"
+
+// HandleRestoreCache returns the content of the pipeline cache with the key or one of the restore keys.
+func HandleRestoreCache(artifactCtrl *artifact.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		key, err := request.GetCacheKeyFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		restoreKeys := request.GetRestoreKeysFromQuery(r)
+
+		res, signedURL, file, err := artifactCtrl.RestoreCache(
+			ctx, session, repoRef, pipelineIdentifier, key, restoreKeys)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		w.Header().Set(HeaderCacheKey, res.Key)
+
+		if file == nil {
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to close cache file after rendering")
+			}
+		}()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		render.Reader(ctx, w, http.StatusOK, file)
+	}
+}
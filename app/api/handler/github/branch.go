@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// HandleListBranches returns a http.HandlerFunc that lists the branches of a repository.
+func HandleListBranches(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, size := parsePagination(r)
+
+		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, &types.BranchFilter{
+			Page:                  page,
+			Size:                  size,
+			BranchMetadataOptions: types.BranchMetadataOptions{IncludeRules: true},
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]Branch, len(branches))
+		for i := range branches {
+			out[i] = mapBranch(&branches[i])
+		}
+
+		render.PaginationNoTotal(r, w, page, size, len(branches) < size)
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleGetBranch returns a http.HandlerFunc that finds a branch of a repository.
+func HandleGetBranch(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		branchName, err := request.PathParamOrError(r, PathParamWildcard)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		branch, err := repoCtrl.GetBranch(ctx, session, repoRef, branchName,
+			types.BranchMetadataOptions{IncludeRules: true})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mapBranch(branch))
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleListIssueComments returns a http.HandlerFunc that lists the comments of a pull request.
+// Code comments and replies are not included, same as in GitHub's issue comments.
+func HandleListIssueComments(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		activities, err := pullreqCtrl.ActivityList(ctx, session, repoRef, pullreqNumber, &types.PullReqActivityFilter{
+			Types: []enum.PullReqActivityType{enum.PullReqActivityTypeComment},
+			Kinds: []enum.PullReqActivityKind{enum.PullReqActivityKindComment},
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]IssueComment, 0, len(activities))
+		for _, activity := range activities {
+			if activity.ParentID != nil || activity.Deleted != nil {
+				continue
+			}
+
+			out = append(out, mapComment(activity))
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleCreateIssueComment returns a http.HandlerFunc that adds a comment to a pull request.
+func HandleCreateIssueComment(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(IssueCommentCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		comment, err := pullreqCtrl.CommentCreate(ctx, session, repoRef, pullreqNumber,
+			&pullreq.CommentCreateInput{Text: in.Body})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, mapComment(comment))
+	}
+}
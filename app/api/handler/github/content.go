@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleGetContent returns a http.HandlerFunc that returns the content of a file or a directory.
+func HandleGetContent(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
+		filePath := request.PathParamOrEmpty(r, PathParamWildcard)
+
+		content, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, filePath, false, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mapContent(content))
+	}
+}
+
+// HandlePutContent returns a http.HandlerFunc that creates or updates a file.
+// The file is updated if the sha of the current file blob is provided, otherwise it's created.
+func HandlePutContent(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filePath, err := request.PathParamOrError(r, PathParamWildcard)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(ContentUpdateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		action := repo.CommitFileAction{
+			Action:   git.CreateAction,
+			Path:     filePath,
+			Payload:  in.Content,
+			Encoding: enum.ContentEncodingTypeBase64,
+		}
+
+		status := http.StatusCreated
+		if in.SHA != "" {
+			action.Action = git.UpdateAction
+			action.SHA, err = sha.New(in.SHA)
+			if err != nil {
+				render.BadRequestf(ctx, w, "Invalid blob sha: %s.", err)
+				return
+			}
+
+			status = http.StatusOK
+		}
+
+		out, ok := commitFile(ctx, w, repoCtrl, session, repoRef, in.Branch, in.Message, action)
+		if !ok {
+			return
+		}
+
+		render.JSON(w, status, out)
+	}
+}
+
+// HandleDeleteContent returns a http.HandlerFunc that deletes a file.
+// The sha of the current file blob is required, the file isn't deleted if it was changed in the meantime.
+func HandleDeleteContent(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		filePath, err := request.PathParamOrError(r, PathParamWildcard)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(ContentDeleteInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		if in.SHA == "" {
+			render.TranslatedUserError(ctx, w, usererror.UnprocessableEntity("The blob sha of the file is required."))
+			return
+		}
+
+		blobSHA, err := sha.New(in.SHA)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid blob sha: %s.", err)
+			return
+		}
+
+		action := repo.CommitFileAction{
+			Action: git.DeleteAction,
+			Path:   filePath,
+			SHA:    blobSHA,
+		}
+
+		out, ok := commitFile(ctx, w, repoCtrl, session, repoRef, in.Branch, in.Message, action)
+		if !ok {
+			return
+		}
+
+		out.Content = nil
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// commitFile commits a single file action to the branch (the default branch if not provided).
+// It renders the error and returns false if the commit failed.
+func commitFile(
+	ctx context.Context,
+	w http.ResponseWriter,
+	repoCtrl *repo.Controller,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+	message string,
+	action repo.CommitFileAction,
+) (*ContentUpdateOutput, bool) {
+	if branch == "" {
+		repository, err := repoCtrl.Find(ctx, session, repoRef)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return nil, false
+		}
+
+		branch = repository.DefaultBranch
+	}
+
+	// GitHub uses a single commit message, the first line of it is the commit title.
+	title, body, _ := strings.Cut(message, "\n")
+
+	response, violations, err := repoCtrl.CommitFiles(ctx, session, repoRef, &repo.CommitFilesOptions{
+		Title:   title,
+		Message: body,
+		Branch:  branch,
+		Actions: []repo.CommitFileAction{action},
+	})
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return nil, false
+	}
+	if violations != nil {
+		render.Violations(w, violations)
+		return nil, false
+	}
+
+	content := &Content{
+		Type: mapContentType(repo.ContentTypeFile),
+		Name: path.Base(action.Path),
+		Path: action.Path,
+	}
+	if len(response.ChangedFiles) > 0 {
+		content.SHA = response.ChangedFiles[0].SHA.String()
+	}
+
+	return &ContentUpdateOutput{
+		Content: content,
+		Commit: ContentCommit{
+			SHA:     response.CommitID.String(),
+			Message: message,
+		},
+	}, true
+}
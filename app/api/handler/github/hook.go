@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// HandleListHooks returns a http.HandlerFunc that lists the webhooks of a repository.
+func HandleListHooks(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		page, size := parsePagination(r)
+
+		hooks, total, err := webhookCtrl.ListRepo(ctx, session, repoRef, false, &types.WebhookFilter{
+			Page:         page,
+			Size:         size,
+			SkipInternal: true,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]Hook, len(hooks))
+		for i, hook := range hooks {
+			out[i] = mapHook(hook)
+		}
+
+		render.Pagination(r, w, page, size, int(total))
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleCreateHook returns a http.HandlerFunc that creates a webhook.
+func HandleCreateHook(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(HookInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		if in.Config == nil {
+			render.BadRequestf(ctx, w, "Webhook config is required.")
+			return
+		}
+
+		events := in.Events
+		if len(events) == 0 {
+			events = []string{"push"}
+		}
+
+		triggers, ok := parseHookEvents(events)
+		if !ok {
+			render.BadRequestf(ctx, w, "Unsupported webhook events: %v.", in.Events)
+			return
+		}
+
+		enabled := true
+		if in.Active != nil {
+			enabled = *in.Active
+		}
+
+		// GitHub webhooks don't have a name, so generate a unique identifier for the webhook.
+		identifier := fmt.Sprintf("github-%d", time.Now().UnixNano())
+
+		hook, err := webhookCtrl.CreateRepo(ctx, session, repoRef, &types.WebhookCreateInput{
+			Identifier:  identifier,
+			DisplayName: identifier,
+			URL:         in.Config.URL,
+			Secret:      in.Config.Secret,
+			Enabled:     enabled,
+			Insecure:    in.Config.InsecureSSL == "1",
+			Triggers:    triggers,
+		}, nil)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, mapHook(hook))
+	}
+}
+
+// HandleGetHook returns a http.HandlerFunc that finds a webhook.
+func HandleGetHook(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		hookID, err := request.PathParamOrError(r, PathParamHookID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		hook, err := webhookCtrl.FindRepo(ctx, session, repoRef, hookID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mapHook(hook))
+	}
+}
+
+// HandleUpdateHook returns a http.HandlerFunc that updates a webhook.
+func HandleUpdateHook(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		hookID, err := request.PathParamOrError(r, PathParamHookID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(HookInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		update := &types.WebhookUpdateInput{
+			Enabled: in.Active,
+		}
+
+		if in.Config != nil {
+			insecure := in.Config.InsecureSSL == "1"
+			update.URL = &in.Config.URL
+			update.Insecure = &insecure
+			if in.Config.Secret != "" {
+				update.Secret = &in.Config.Secret
+			}
+		}
+
+		if in.Events != nil {
+			triggers, ok := parseHookEvents(in.Events)
+			if !ok {
+				render.BadRequestf(ctx, w, "Unsupported webhook events: %v.", in.Events)
+				return
+			}
+
+			update.Triggers = triggers
+		}
+
+		hook, err := webhookCtrl.UpdateRepo(ctx, session, repoRef, hookID, update, nil)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mapHook(hook))
+	}
+}
+
+// HandleDeleteHook returns a http.HandlerFunc that deletes a webhook.
+func HandleDeleteHook(webhookCtrl *webhook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		hookID, err := request.PathParamOrError(r, PathParamHookID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		err = webhookCtrl.DeleteRepo(ctx, session, repoRef, hookID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
@@ -0,0 +1,586 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// checkMetadataContext is the check metadata key holding the original GitHub status context.
+	checkMetadataContext = "github_context"
+
+	// checkIdentifierMaxLength is the max length of a status check identifier.
+	checkIdentifierMaxLength = 127
+
+	hookName = "web"
+)
+
+var regexpCheckIdentifierInvalidChars = regexp.MustCompile(`[^0-9a-zA-Z-_.$]`)
+
+// formatTime converts unix milliseconds to the timestamp format used by GitHub.
+func formatTime(unixMilli int64) string {
+	return time.UnixMilli(unixMilli).UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(unixMilli *int64) *string {
+	if unixMilli == nil || *unixMilli == 0 {
+		return nil
+	}
+
+	s := formatTime(*unixMilli)
+	return &s
+}
+
+func parseTime(s string) int64 {
+	if s == "" {
+		return 0
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0
+	}
+
+	return t.UnixMilli()
+}
+
+func mapUser(p *types.PrincipalInfo) User {
+	typ := "User"
+	if p.Type != enum.PrincipalTypeUser {
+		typ = "Bot"
+	}
+
+	return User{
+		Login: p.UID,
+		ID:    p.ID,
+		Type:  typ,
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
+
+func mapRepository(r *repo.RepositoryOutput) Repository {
+	owner := paths.Parent(r.Path)
+
+	return Repository{
+		ID:       r.ID,
+		Name:     r.Identifier,
+		FullName: r.Path,
+		Owner: User{
+			Login: owner,
+			ID:    r.ParentID,
+			Type:  "Organization",
+		},
+		Private:       !r.IsPublic,
+		Description:   r.Description,
+		Fork:          r.ForkID != 0,
+		DefaultBranch: r.DefaultBranch,
+		CloneURL:      r.GitURL,
+		SSHURL:        r.GitSSHURL,
+		Archived:      r.Archived,
+		Size:          r.Size,
+		ForksCount:    r.NumForks,
+		OpenPulls:     r.NumOpenPulls,
+		CreatedAt:     formatTime(r.Created),
+		UpdatedAt:     formatTime(r.Updated),
+		PushedAt:      formatTimePtr(&r.LastGITPush),
+	}
+}
+
+func mapBranch(b *types.BranchExtended) Branch {
+	return Branch{
+		Name:      b.Name,
+		Commit:    BranchCommit{SHA: b.SHA.String()},
+		Protected: len(b.Rules) > 0,
+	}
+}
+
+func mapContentType(typ repo.ContentType) string {
+	switch typ {
+	case repo.ContentTypeDir:
+		return "dir"
+	case repo.ContentTypeSymlink:
+		return "symlink"
+	case repo.ContentTypeSubmodule:
+		return "submodule"
+	case repo.ContentTypeFile:
+		return "file"
+	}
+
+	return string(typ)
+}
+
+func mapContentInfo(info *repo.ContentInfo) *Content {
+	return &Content{
+		Type: mapContentType(info.Type),
+		Name: info.Name,
+		Path: info.Path,
+		SHA:  info.SHA,
+	}
+}
+
+// mapContent returns either a single content object (file, symlink, submodule) or a list of them (directory).
+func mapContent(out *repo.GetContentOutput) any {
+	content := mapContentInfo(&out.ContentInfo)
+
+	switch c := out.Content.(type) {
+	case *repo.DirContent:
+		entries := make([]*Content, len(c.Entries))
+		for i := range c.Entries {
+			entries[i] = mapContentInfo(&c.Entries[i])
+		}
+		return entries
+	case *repo.FileContent:
+		content.Encoding = string(enum.ContentEncodingTypeBase64)
+		content.Size = c.Size
+		content.Content = c.Data
+		if c.Encoding != enum.ContentEncodingTypeBase64 {
+			content.Content = base64.StdEncoding.EncodeToString([]byte(c.Data))
+		}
+	case *repo.SymlinkContent:
+		content.Size = c.Size
+		content.Target = c.Target
+	case *repo.SubmoduleContent:
+		content.SubmoduleGitURL = c.URL
+		content.SHA = c.CommitSHA
+	}
+
+	return content
+}
+
+func mapPullReqState(state enum.PullReqState) string {
+	if state == enum.PullReqStateOpen {
+		return "open"
+	}
+
+	return "closed"
+}
+
+func mapPullRequest(pr *types.PullReq) PullRequest {
+	var mergedBy *User
+	if pr.Merger != nil {
+		u := mapUser(pr.Merger)
+		mergedBy = &u
+	}
+
+	var mergeable *bool
+	switch pr.MergeCheckStatus {
+	case enum.MergeCheckStatusMergeable:
+		mergeable = new(bool)
+		*mergeable = true
+	case enum.MergeCheckStatusConflict:
+		mergeable = new(bool)
+	case enum.MergeCheckStatusUnchecked:
+	}
+
+	var mergeCommitSHA *string
+	if pr.State == enum.PullReqStateMerged && pr.MergeSHA != nil {
+		mergeCommitSHA = pr.MergeSHA
+	}
+
+	return PullRequest{
+		ID:     pr.ID,
+		Number: pr.Number,
+		State:  mapPullReqState(pr.State),
+		Title:  pr.Title,
+		Body:   pr.Description,
+		Draft:  pr.IsDraft,
+		User:   mapUser(&pr.Author),
+		Head: PullRequestRef{
+			Label: pr.SourceBranch,
+			Ref:   pr.SourceBranch,
+			SHA:   pr.SourceSHA,
+		},
+		Base: PullRequestRef{
+			Label: pr.TargetBranch,
+			Ref:   pr.TargetBranch,
+			SHA:   pr.MergeBaseSHA,
+		},
+		Merged:         pr.State == enum.PullReqStateMerged,
+		Mergeable:      mergeable,
+		MergedBy:       mergedBy,
+		MergeCommitSHA: mergeCommitSHA,
+		Comments:       pr.Stats.Conversations,
+		Commits:        pr.Stats.Commits,
+		Additions:      pr.Stats.Additions,
+		Deletions:      pr.Stats.Deletions,
+		ChangedFiles:   pr.Stats.FilesChanged,
+		CreatedAt:      formatTime(pr.Created),
+		UpdatedAt:      formatTime(pr.Updated),
+		ClosedAt:       formatTimePtr(pr.Closed),
+		MergedAt:       formatTimePtr(pr.Merged),
+	}
+}
+
+// parsePullReqStates converts the GitHub state filter (open, closed, all) to pull request states.
+func parsePullReqStates(state string) ([]enum.PullReqState, bool) {
+	switch state {
+	case "", "open":
+		return []enum.PullReqState{enum.PullReqStateOpen}, true
+	case "closed":
+		return []enum.PullReqState{enum.PullReqStateClosed, enum.PullReqStateMerged}, true
+	case "all":
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// stripHeadOwner removes the "owner:" prefix GitHub allows in the head branch of a pull request.
+func stripHeadOwner(head string) string {
+	if idx := strings.IndexByte(head, ':'); idx >= 0 {
+		return head[idx+1:]
+	}
+
+	return head
+}
+
+func parseReviewEvent(event string) (enum.PullReqReviewDecision, bool) {
+	switch event {
+	case "APPROVE":
+		return enum.PullReqReviewDecisionApproved, true
+	case "REQUEST_CHANGES":
+		return enum.PullReqReviewDecisionChangeReq, true
+	case "COMMENT":
+		return enum.PullReqReviewDecisionReviewed, true
+	}
+
+	return "", false
+}
+
+func mapReviewDecision(decision enum.PullReqReviewDecision) string {
+	switch decision {
+	case enum.PullReqReviewDecisionApproved:
+		return "APPROVED"
+	case enum.PullReqReviewDecisionChangeReq:
+		return "CHANGES_REQUESTED"
+	case enum.PullReqReviewDecisionReviewed:
+		return "COMMENTED"
+	case enum.PullReqReviewDecisionPending:
+		return "PENDING"
+	}
+
+	return string(decision)
+}
+
+func mapReviewer(r *types.PullReqReviewer) Review {
+	var id int64
+	if r.LatestReviewID != nil {
+		id = *r.LatestReviewID
+	}
+
+	return Review{
+		ID:          id,
+		User:        mapUser(&r.Reviewer),
+		State:       mapReviewDecision(r.ReviewDecision),
+		CommitID:    r.SHA,
+		SubmittedAt: formatTime(r.Updated),
+	}
+}
+
+func mapComment(a *types.PullReqActivity) IssueComment {
+	return IssueComment{
+		ID:        a.ID,
+		Body:      a.Text,
+		User:      mapUser(&a.Author),
+		CreatedAt: formatTime(a.Created),
+		UpdatedAt: formatTime(a.Edited),
+	}
+}
+
+// checkIdentifierFromContext converts a GitHub status context (e.g. "ci/build (linux)")
+// to a valid status check identifier.
+func checkIdentifierFromContext(context string) string {
+	identifier := regexpCheckIdentifierInvalidChars.ReplaceAllString(context, "-")
+	if len(identifier) > checkIdentifierMaxLength {
+		identifier = identifier[:checkIdentifierMaxLength]
+	}
+
+	return identifier
+}
+
+// checkContext returns the original GitHub context of the status check, or its identifier.
+func checkContext(c *types.Check) string {
+	var metadata map[string]string
+	if err := json.Unmarshal(c.Metadata, &metadata); err == nil && metadata[checkMetadataContext] != "" {
+		return metadata[checkMetadataContext]
+	}
+
+	return c.Identifier
+}
+
+func parseStatusState(state string) (enum.CheckStatus, bool) {
+	switch state {
+	case "pending":
+		return enum.CheckStatusPending, true
+	case "success":
+		return enum.CheckStatusSuccess, true
+	case "failure":
+		return enum.CheckStatusFailure, true
+	case "error":
+		return enum.CheckStatusError, true
+	}
+
+	return "", false
+}
+
+func mapStatusState(status enum.CheckStatus) string {
+	switch status {
+	case enum.CheckStatusPending, enum.CheckStatusRunning:
+		return "pending"
+	case enum.CheckStatusSuccess:
+		return "success"
+	case enum.CheckStatusFailure, enum.CheckStatusFailureIgnored:
+		return "failure"
+	case enum.CheckStatusError:
+		return "error"
+	}
+
+	return string(status)
+}
+
+func mapStatus(c *types.Check) Status {
+	var creator *User
+	if c.ReportedBy != nil {
+		u := mapUser(c.ReportedBy)
+		creator = &u
+	}
+
+	return Status{
+		ID:          c.ID,
+		State:       mapStatusState(c.Status),
+		Description: c.Summary,
+		TargetURL:   c.Link,
+		Context:     checkContext(c),
+		Creator:     creator,
+		CreatedAt:   formatTime(c.Created),
+		UpdatedAt:   formatTime(c.Updated),
+	}
+}
+
+// combineStatuses returns the combined state of the statuses the same way GitHub does:
+// failure if any status failed, pending if any is pending or there are none, otherwise success.
+func combineStatuses(statuses []Status) string {
+	if len(statuses) == 0 {
+		return "pending"
+	}
+
+	state := "success"
+	for _, s := range statuses {
+		switch s.State {
+		case "failure", "error":
+			return "failure"
+		case "pending":
+			state = "pending"
+		}
+	}
+
+	return state
+}
+
+func parseCheckRunStatus(status, conclusion string) (enum.CheckStatus, bool) {
+	switch status {
+	case "queued":
+		return enum.CheckStatusPending, true
+	case "", "in_progress":
+		if conclusion == "" {
+			return enum.CheckStatusRunning, true
+		}
+	case "completed":
+	default:
+		return "", false
+	}
+
+	switch conclusion {
+	case "success", "skipped":
+		return enum.CheckStatusSuccess, true
+	case "neutral":
+		return enum.CheckStatusFailureIgnored, true
+	case "failure", "timed_out", "action_required":
+		return enum.CheckStatusFailure, true
+	case "cancelled", "stale":
+		return enum.CheckStatusError, true
+	}
+
+	return "", false
+}
+
+func mapCheckRun(c *types.Check, commitSHA string) CheckRun {
+	run := CheckRun{
+		ID:          c.ID,
+		Name:        checkContext(c),
+		HeadSHA:     commitSHA,
+		DetailsURL:  c.Link,
+		Output:      CheckRunOutput{Title: c.Summary, Summary: c.Summary},
+		StartedAt:   formatTimePtr(&c.Started),
+		CompletedAt: formatTimePtr(&c.Ended),
+	}
+
+	var conclusion string
+	switch c.Status {
+	case enum.CheckStatusPending:
+		run.Status = "queued"
+	case enum.CheckStatusRunning:
+		run.Status = "in_progress"
+	case enum.CheckStatusSuccess:
+		conclusion = "success"
+	case enum.CheckStatusFailure:
+		conclusion = "failure"
+	case enum.CheckStatusFailureIgnored:
+		conclusion = "neutral"
+	case enum.CheckStatusError:
+		conclusion = "cancelled"
+	}
+
+	if conclusion != "" {
+		run.Status = "completed"
+		run.Conclusion = &conclusion
+	}
+
+	return run
+}
+
+// hookEventTriggers maps GitHub webhook events to the webhook triggers.
+var hookEventTriggers = map[string][]enum.WebhookTrigger{
+	"push": {
+		enum.WebhookTriggerBranchCreated,
+		enum.WebhookTriggerBranchUpdated,
+		enum.WebhookTriggerBranchDeleted,
+		enum.WebhookTriggerTagCreated,
+		enum.WebhookTriggerTagUpdated,
+		enum.WebhookTriggerTagDeleted,
+	},
+	"create": {
+		enum.WebhookTriggerBranchCreated,
+		enum.WebhookTriggerTagCreated,
+	},
+	"delete": {
+		enum.WebhookTriggerBranchDeleted,
+		enum.WebhookTriggerTagDeleted,
+	},
+	"pull_request": {
+		enum.WebhookTriggerPullReqCreated,
+		enum.WebhookTriggerPullReqReopened,
+		enum.WebhookTriggerPullReqBranchUpdated,
+		enum.WebhookTriggerPullReqClosed,
+		enum.WebhookTriggerPullReqMerged,
+		enum.WebhookTriggerPullReqUpdated,
+		enum.WebhookTriggerPullReqLabelAssigned,
+		enum.WebhookTriggerPullReqTargetBranchChanged,
+		enum.WebhookTriggerPullReqAutoMergeEnabled,
+		enum.WebhookTriggerPullReqAutoMergeDisabled,
+	},
+	"pull_request_review": {
+		enum.WebhookTriggerPullReqReviewSubmitted,
+	},
+	"issue_comment": {
+		enum.WebhookTriggerPullReqCommentCreated,
+		enum.WebhookTriggerPullReqCommentUpdated,
+		enum.WebhookTriggerPullReqCommentStatusUpdated,
+	},
+	"pull_request_review_comment": {
+		enum.WebhookTriggerPullReqCommentCreated,
+		enum.WebhookTriggerPullReqCommentUpdated,
+	},
+}
+
+// parseHookEvents converts GitHub webhook events to webhook triggers.
+// An empty list of triggers means that the webhook is executed for all triggers.
+func parseHookEvents(events []string) ([]enum.WebhookTrigger, bool) {
+	triggers := make([]enum.WebhookTrigger, 0)
+	for _, event := range events {
+		if event == "*" {
+			return []enum.WebhookTrigger{}, true
+		}
+
+		eventTriggers, ok := hookEventTriggers[event]
+		if !ok {
+			return nil, false
+		}
+
+		for _, trigger := range eventTriggers {
+			if !slices.Contains(triggers, trigger) {
+				triggers = append(triggers, trigger)
+			}
+		}
+	}
+
+	return triggers, true
+}
+
+// mapHookTriggers converts webhook triggers to the GitHub events that cover them.
+func mapHookTriggers(triggers []enum.WebhookTrigger) []string {
+	if len(triggers) == 0 {
+		return []string{"*"}
+	}
+
+	events := make([]string, 0)
+	add := func(event string) {
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	for _, trigger := range triggers {
+		switch trigger {
+		case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerBranchDeleted,
+			enum.WebhookTriggerTagCreated, enum.WebhookTriggerTagUpdated, enum.WebhookTriggerTagDeleted:
+			add("push")
+		case enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerPullReqCommentUpdated,
+			enum.WebhookTriggerPullReqCommentStatusUpdated:
+			add("issue_comment")
+		case enum.WebhookTriggerPullReqReviewSubmitted:
+			add("pull_request_review")
+		case enum.WebhookTriggerArtifactCreated, enum.WebhookTriggerArtifactDeleted:
+			add("registry_package")
+		default:
+			add("pull_request")
+		}
+	}
+
+	return events
+}
+
+func mapHook(h *types.Webhook) Hook {
+	insecure := "0"
+	if h.Insecure {
+		insecure = "1"
+	}
+
+	return Hook{
+		ID:     h.ID,
+		Name:   hookName,
+		Active: h.Enabled,
+		Events: mapHookTriggers(h.Triggers),
+		Config: HookConfig{
+			URL:         h.URL,
+			ContentType: "json",
+			InsecureSSL: insecure,
+		},
+		CreatedAt: formatTime(h.Created),
+		UpdatedAt: formatTime(h.Updated),
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckIdentifierFromContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		exp     string
+	}{
+		{
+			name:    "valid",
+			context: "ci.build-1_x",
+			exp:     "ci.build-1_x",
+		},
+		{
+			name:    "invalid-chars",
+			context: "ci/build (linux)",
+			exp:     "ci-build--linux-",
+		},
+		{
+			name:    "too-long",
+			context: strings.Repeat("a", 200),
+			exp:     strings.Repeat("a", checkIdentifierMaxLength),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, checkIdentifierFromContext(test.context); want != got {
+				t.Errorf("identifier mismatch: want=%q got=%q", want, got)
+			}
+		})
+	}
+}
+
+func TestParseCheckRunStatus(t *testing.T) {
+	tests := []struct {
+		status     string
+		conclusion string
+		exp        enum.CheckStatus
+		expOK      bool
+	}{
+		{status: "queued", exp: enum.CheckStatusPending, expOK: true},
+		{status: "in_progress", exp: enum.CheckStatusRunning, expOK: true},
+		{status: "completed", conclusion: "success", exp: enum.CheckStatusSuccess, expOK: true},
+		{status: "completed", conclusion: "neutral", exp: enum.CheckStatusFailureIgnored, expOK: true},
+		{status: "completed", conclusion: "timed_out", exp: enum.CheckStatusFailure, expOK: true},
+		{status: "completed", conclusion: "cancelled", exp: enum.CheckStatusError, expOK: true},
+		{conclusion: "failure", exp: enum.CheckStatusFailure, expOK: true},
+		{status: "completed"},
+		{status: "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status+"/"+test.conclusion, func(t *testing.T) {
+			status, ok := parseCheckRunStatus(test.status, test.conclusion)
+			if ok != test.expOK {
+				t.Errorf("ok mismatch: want=%t got=%t", test.expOK, ok)
+				return
+			}
+
+			if status != test.exp {
+				t.Errorf("status mismatch: want=%q got=%q", test.exp, status)
+			}
+		})
+	}
+}
+
+func TestCombineStatuses(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []string
+		exp    string
+	}{
+		{name: "empty", exp: "pending"},
+		{name: "success", states: []string{"success", "success"}, exp: "success"},
+		{name: "pending", states: []string{"success", "pending"}, exp: "pending"},
+		{name: "failure", states: []string{"pending", "error", "success"}, exp: "failure"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statuses := make([]Status, len(test.states))
+			for i, state := range test.states {
+				statuses[i] = Status{State: state}
+			}
+
+			if want, got := test.exp, combineStatuses(statuses); want != got {
+				t.Errorf("state mismatch: want=%q got=%q", want, got)
+			}
+		})
+	}
+}
+
+func TestHookEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		events      []string
+		expTriggers []enum.WebhookTrigger
+		expEvents   []string
+		expOK       bool
+	}{
+		{
+			name:        "all",
+			events:      []string{"*"},
+			expTriggers: []enum.WebhookTrigger{},
+			expEvents:   []string{"*"},
+			expOK:       true,
+		},
+		{
+			name:   "create-and-delete",
+			events: []string{"create", "delete"},
+			expTriggers: []enum.WebhookTrigger{
+				enum.WebhookTriggerBranchCreated,
+				enum.WebhookTriggerTagCreated,
+				enum.WebhookTriggerBranchDeleted,
+				enum.WebhookTriggerTagDeleted,
+			},
+			expEvents: []string{"push"},
+			expOK:     true,
+		},
+		{
+			name:   "review-and-comments",
+			events: []string{"pull_request_review", "pull_request_review_comment"},
+			expTriggers: []enum.WebhookTrigger{
+				enum.WebhookTriggerPullReqReviewSubmitted,
+				enum.WebhookTriggerPullReqCommentCreated,
+				enum.WebhookTriggerPullReqCommentUpdated,
+			},
+			expEvents: []string{"pull_request_review", "issue_comment"},
+			expOK:     true,
+		},
+		{
+			name:   "unsupported",
+			events: []string{"push", "deployment"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			triggers, ok := parseHookEvents(test.events)
+			if ok != test.expOK {
+				t.Errorf("ok mismatch: want=%t got=%t", test.expOK, ok)
+				return
+			}
+
+			if !ok {
+				return
+			}
+
+			if !reflect.DeepEqual(test.expTriggers, triggers) {
+				t.Errorf("triggers mismatch: want=%v got=%v", test.expTriggers, triggers)
+			}
+
+			if events := mapHookTriggers(triggers); !reflect.DeepEqual(test.expEvents, events) {
+				t.Errorf("events mismatch: want=%v got=%v", test.expEvents, events)
+			}
+		})
+	}
+}
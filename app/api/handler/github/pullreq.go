@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleListPulls returns a http.HandlerFunc that lists the pull requests of a repository.
+func HandleListPulls(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		query := r.URL.Query()
+
+		states, ok := parsePullReqStates(query.Get("state"))
+		if !ok {
+			render.BadRequestf(ctx, w, "Invalid state: %q.", query.Get("state"))
+			return
+		}
+
+		var sort enum.PullReqSort = enum.PullReqSortCreated
+		if query.Get("sort") == "updated" {
+			sort = enum.PullReqSortUpdated
+		}
+
+		order := enum.OrderDesc
+		if query.Get("direction") == "asc" {
+			order = enum.OrderAsc
+		}
+
+		page, size := parsePagination(r)
+
+		pullReqs, total, err := pullreqCtrl.List(ctx, session, repoRef, &types.PullReqFilter{
+			Page:         page,
+			Size:         size,
+			SourceBranch: stripHeadOwner(query.Get("head")),
+			TargetBranch: query.Get("base"),
+			States:       states,
+			Sort:         sort,
+			Order:        order,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]PullRequest, len(pullReqs))
+		for i, pr := range pullReqs {
+			out[i] = mapPullRequest(pr)
+		}
+
+		render.Pagination(r, w, page, size, int(total))
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleCreatePull returns a http.HandlerFunc that creates a new pull request.
+func HandleCreatePull(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(PullRequestCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Create(ctx, session, repoRef, &pullreq.CreateInput{
+			IsDraft:      in.Draft,
+			Title:        in.Title,
+			Description:  in.Body,
+			SourceBranch: stripHeadOwner(in.Head),
+			TargetBranch: in.Base,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, mapPullRequest(pr))
+	}
+}
+
+// HandleGetPull returns a http.HandlerFunc that finds a pull request.
+func HandleGetPull(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber,
+			types.PullReqMetadataOptions{IncludeGitStats: true})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, mapPullRequest(pr))
+	}
+}
+
+// HandleUpdatePull returns a http.HandlerFunc that updates the title, the description,
+// the state or the target branch of a pull request.
+func HandleUpdatePull(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(PullRequestUpdateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber, types.PullReqMetadataOptions{})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if in.Title != nil || in.Body != nil {
+			update := &pullreq.UpdateInput{Title: pr.Title, Description: pr.Description}
+			if in.Title != nil {
+				update.Title = *in.Title
+			}
+			if in.Body != nil {
+				update.Description = *in.Body
+			}
+
+			pr, err = pullreqCtrl.Update(ctx, session, repoRef, pullreqNumber, update)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		if in.Base != nil && *in.Base != pr.TargetBranch {
+			pr, err = pullreqCtrl.ChangeTargetBranch(ctx, session, repoRef, pullreqNumber,
+				&pullreq.ChangeTargetBranchInput{BranchName: *in.Base})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		if in.State != nil && *in.State != mapPullReqState(pr.State) {
+			pr, err = pullreqCtrl.State(ctx, session, repoRef, pullreqNumber, &pullreq.StateInput{
+				State:   enum.PullReqState(*in.State),
+				IsDraft: pr.IsDraft,
+			})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		render.JSON(w, http.StatusOK, mapPullRequest(pr))
+	}
+}
+
+// HandleCheckPullMerged returns a http.HandlerFunc that responds with 204 if the pull request is merged
+// and with 404 otherwise.
+func HandleCheckPullMerged(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber, types.PullReqMetadataOptions{})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if pr.State != enum.PullReqStateMerged {
+			render.NotFound(ctx, w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleMergePull returns a http.HandlerFunc that merges a pull request.
+func HandleMergePull(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(PullRequestMergeInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		// unlike GitHub, merging requires the expected source sha, so use the latest one if not provided.
+		if in.SHA == "" {
+			pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber, types.PullReqMetadataOptions{})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			in.SHA = pr.SourceSHA
+		}
+
+		method := enum.MergeMethodMerge
+		if in.MergeMethod != "" {
+			method = enum.MergeMethod(in.MergeMethod)
+		}
+
+		result, violations, err := pullreqCtrl.Merge(ctx, session, repoRef, pullreqNumber, &pullreq.MergeInput{
+			Method:    method,
+			SourceSHA: in.SHA,
+			Title:     in.CommitTitle,
+			Message:   in.CommitMessage,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		if violations != nil {
+			message := violations.Message
+			if message == "" {
+				message = "Pull Request is not mergeable"
+			}
+
+			render.JSON(w, http.StatusMethodNotAllowed, ErrorResponse{Message: message})
+			return
+		}
+
+		out := PullRequestMergeOutput{
+			SHA:     result.SHA,
+			Merged:  true,
+			Message: "Pull Request successfully merged",
+		}
+		if result.MergeQueued {
+			out.Merged = false
+			out.Message = "Pull Request added to the merge queue"
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
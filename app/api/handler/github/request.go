@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/request"
+)
+
+const (
+	PathParamOwner    = "owner"
+	PathParamRepo     = "repo"
+	PathParamNumber   = "number"
+	PathParamRef      = "ref"
+	PathParamSHA      = "sha"
+	PathParamHookID   = "hook_id"
+	PathParamWildcard = "*"
+
+	queryParamPerPage = "per_page"
+
+	perPageDefault = 30
+	perPageMax     = 100
+)
+
+// getRepoRef returns the repository reference built from the owner and repo path parameters.
+// The owner is the path of the parent space of the repository (url-encoded if it's nested).
+func getRepoRef(r *http.Request) (string, error) {
+	owner, err := request.PathParamOrError(r, PathParamOwner)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := request.PathParamOrError(r, PathParamRepo)
+	if err != nil {
+		return "", err
+	}
+
+	return owner + "/" + repo, nil
+}
+
+func getNumber(r *http.Request) (int64, error) {
+	return request.PathParamAsPositiveInt64(r, PathParamNumber)
+}
+
+// parsePagination extracts the GitHub style pagination parameters (page and per_page).
+// The limit parameter is accepted as well, because the pagination link headers use it.
+func parsePagination(r *http.Request) (int, int) {
+	page := request.ParsePage(r)
+
+	perPage, _ := strconv.Atoi(r.URL.Query().Get(queryParamPerPage))
+	if perPage <= 0 {
+		perPage = int(request.ParseLimitOrDefaultWithMax(r, perPageDefault, perPageMax))
+	}
+	if perPage > perPageMax {
+		perPage = perPageMax
+	}
+
+	return page, perPage
+}
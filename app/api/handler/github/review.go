@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleListReviews returns a http.HandlerFunc that lists the latest reviews of a pull request.
+func HandleListReviews(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		reviewers, err := pullreqCtrl.ReviewerList(ctx, session, repoRef, pullreqNumber)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		out := make([]Review, 0, len(reviewers))
+		for _, reviewer := range reviewers {
+			if reviewer.ReviewDecision == enum.PullReqReviewDecisionPending {
+				continue
+			}
+
+			out = append(out, mapReviewer(reviewer))
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleCreateReview returns a http.HandlerFunc that submits a review of a pull request.
+// The review body, if provided, is added as a pull request comment.
+func HandleCreateReview(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := getNumber(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(ReviewCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		event := in.Event
+		if event == "" {
+			event = "COMMENT"
+		}
+
+		decision, ok := parseReviewEvent(event)
+		if !ok {
+			render.BadRequestf(ctx, w, "Unsupported review event: %q.", in.Event)
+			return
+		}
+
+		if in.CommitID == "" {
+			pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber, types.PullReqMetadataOptions{})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			in.CommitID = pr.SourceSHA
+		}
+
+		if in.Body != "" {
+			_, err = pullreqCtrl.CommentCreate(ctx, session, repoRef, pullreqNumber,
+				&pullreq.CommentCreateInput{Text: in.Body})
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+		}
+
+		review, err := pullreqCtrl.ReviewSubmit(ctx, session, repoRef, pullreqNumber, &pullreq.ReviewSubmitInput{
+			CommitSHA: in.CommitID,
+			Decision:  decision,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, Review{
+			ID:          review.ID,
+			User:        mapUser(session.Principal.ToPrincipalInfo()),
+			Body:        in.Body,
+			State:       mapReviewDecision(review.Decision),
+			CommitID:    review.SHA,
+			SubmittedAt: formatTime(review.Created),
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// HandleCreateStatus returns a http.HandlerFunc that reports a commit status as a status check.
+// The status context is converted to the status check identifier.
+func HandleCreateStatus(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commitSHA, err := request.PathParamOrError(r, PathParamSHA)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(StatusCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		status, ok := parseStatusState(in.State)
+		if !ok {
+			render.BadRequestf(ctx, w, "Invalid state: %q.", in.State)
+			return
+		}
+
+		statusContext := in.Context
+		if statusContext == "" {
+			statusContext = "default"
+		}
+
+		statusCheck, err := checkCtrl.Report(ctx, session, repoRef, commitSHA, &check.ReportInput{
+			Identifier: checkIdentifierFromContext(statusContext),
+			Status:     status,
+			Summary:    in.Description,
+			Link:       in.TargetURL,
+		}, map[string]string{checkMetadataContext: statusContext})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, mapStatus(statusCheck))
+	}
+}
+
+// HandleListStatuses returns a http.HandlerFunc that lists the statuses of a commit.
+func HandleListStatuses(repoCtrl *repo.Controller, checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		statuses, _, ok := listStatuses(ctx, w, r, session, repoCtrl, checkCtrl)
+		if !ok {
+			return
+		}
+
+		render.JSON(w, http.StatusOK, statuses)
+	}
+}
+
+// HandleGetCombinedStatus returns a http.HandlerFunc that returns the combined status of a commit.
+func HandleGetCombinedStatus(repoCtrl *repo.Controller, checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		statuses, commitSHA, ok := listStatuses(ctx, w, r, session, repoCtrl, checkCtrl)
+		if !ok {
+			return
+		}
+
+		render.JSON(w, http.StatusOK, CombinedStatus{
+			State:      combineStatuses(statuses),
+			SHA:        commitSHA,
+			TotalCount: len(statuses),
+			Statuses:   statuses,
+		})
+	}
+}
+
+// HandleCreateCheckRun returns a http.HandlerFunc that reports a check run as a status check.
+// Reporting a check run with the same name again updates it.
+func HandleCreateCheckRun(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := getRepoRef(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(CheckRunCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		status, ok := parseCheckRunStatus(in.Status, in.Conclusion)
+		if !ok {
+			render.BadRequestf(ctx, w, "Invalid status %q or conclusion %q.", in.Status, in.Conclusion)
+			return
+		}
+
+		var summary string
+		if in.Output != nil {
+			summary = in.Output.Summary
+			if summary == "" {
+				summary = in.Output.Title
+			}
+		}
+
+		statusCheck, err := checkCtrl.Report(ctx, session, repoRef, in.HeadSHA, &check.ReportInput{
+			Identifier: checkIdentifierFromContext(in.Name),
+			Status:     status,
+			Summary:    summary,
+			Link:       in.DetailsURL,
+			Started:    parseTime(in.StartedAt),
+			Ended:      parseTime(in.CompletedAt),
+		}, map[string]string{checkMetadataContext: in.Name})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, mapCheckRun(statusCheck, in.HeadSHA))
+	}
+}
+
+// HandleListCheckRuns returns a http.HandlerFunc that lists the check runs of a commit.
+func HandleListCheckRuns(repoCtrl *repo.Controller, checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		checks, commitSHA, ok := listChecks(ctx, w, r, session, repoCtrl, checkCtrl)
+		if !ok {
+			return
+		}
+
+		runs := make([]CheckRun, len(checks))
+		for i := range checks {
+			runs[i] = mapCheckRun(&checks[i], commitSHA)
+		}
+
+		render.JSON(w, http.StatusOK, CheckRunList{
+			TotalCount: len(runs),
+			CheckRuns:  runs,
+		})
+	}
+}
+
+func listStatuses(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	session *auth.Session,
+	repoCtrl *repo.Controller,
+	checkCtrl *check.Controller,
+) ([]Status, string, bool) {
+	checks, commitSHA, ok := listChecks(ctx, w, r, session, repoCtrl, checkCtrl)
+	if !ok {
+		return nil, "", false
+	}
+
+	statuses := make([]Status, len(checks))
+	for i := range checks {
+		statuses[i] = mapStatus(&checks[i])
+	}
+
+	return statuses, commitSHA, true
+}
+
+// listChecks lists the status checks of the commit referenced by the ref path parameter.
+// The reference can be a commit sha or a branch name.
+func listChecks(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	session *auth.Session,
+	repoCtrl *repo.Controller,
+	checkCtrl *check.Controller,
+) ([]types.Check, string, bool) {
+	repoRef, err := getRepoRef(r)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return nil, "", false
+	}
+
+	ref, err := request.PathParamOrError(r, PathParamRef)
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return nil, "", false
+	}
+
+	commitSHA := ref
+	if !isFullSHA(ref) {
+		branch, err := repoCtrl.GetBranch(ctx, session, repoRef, ref, types.BranchMetadataOptions{})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return nil, "", false
+		}
+
+		commitSHA = branch.SHA.String()
+	}
+
+	page, size := parsePagination(r)
+
+	checks, total, err := checkCtrl.ListChecks(ctx, session, repoRef, commitSHA, types.CheckListOptions{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{Page: page, Size: size},
+		},
+	})
+	if err != nil {
+		render.TranslatedUserError(ctx, w, err)
+		return nil, "", false
+	}
+
+	render.Pagination(r, w, page, size, total)
+
+	return checks, commitSHA, true
+}
+
+func isFullSHA(ref string) bool {
+	if len(ref) != 40 && len(ref) != 64 {
+		return false
+	}
+
+	_, err := sha.New(ref)
+	return err == nil
+}
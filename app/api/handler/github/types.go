@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+// The types in this file mirror (a subset of) the resources of the GitHub REST API v3.
+// Only the fields that can be backed by Gitness data are included.
+
+type User struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type Repository struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	FullName      string  `json:"full_name"`
+	Owner         User    `json:"owner"`
+	Private       bool    `json:"private"`
+	Description   string  `json:"description"`
+	Fork          bool    `json:"fork"`
+	DefaultBranch string  `json:"default_branch"`
+	CloneURL      string  `json:"clone_url"`
+	SSHURL        string  `json:"ssh_url,omitempty"`
+	Archived      bool    `json:"archived"`
+	Size          int64   `json:"size"`
+	ForksCount    int     `json:"forks_count"`
+	OpenPulls     int     `json:"open_issues_count"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+	PushedAt      *string `json:"pushed_at"`
+}
+
+type Branch struct {
+	Name      string       `json:"name"`
+	Commit    BranchCommit `json:"commit"`
+	Protected bool         `json:"protected"`
+}
+
+type BranchCommit struct {
+	SHA string `json:"sha"`
+}
+
+type Content struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding,omitempty"`
+	Size     int64  `json:"size"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Content  string `json:"content,omitempty"`
+	SHA      string `json:"sha"`
+	Target   string `json:"target,omitempty"`
+	// SubmoduleGitURL is only set for submodules.
+	SubmoduleGitURL string `json:"submodule_git_url,omitempty"`
+}
+
+type ContentUpdateInput struct {
+	Message string `json:"message"`
+	Content string `json:"content"`
+	SHA     string `json:"sha"`
+	Branch  string `json:"branch"`
+}
+
+type ContentDeleteInput struct {
+	Message string `json:"message"`
+	SHA     string `json:"sha"`
+	Branch  string `json:"branch"`
+}
+
+type ContentCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+}
+
+type ContentUpdateOutput struct {
+	Content *Content      `json:"content"`
+	Commit  ContentCommit `json:"commit"`
+}
+
+type PullRequestRef struct {
+	Label string `json:"label"`
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+}
+
+type PullRequest struct {
+	ID             int64          `json:"id"`
+	Number         int64          `json:"number"`
+	State          string         `json:"state"`
+	Title          string         `json:"title"`
+	Body           string         `json:"body"`
+	Draft          bool           `json:"draft"`
+	User           User           `json:"user"`
+	Head           PullRequestRef `json:"head"`
+	Base           PullRequestRef `json:"base"`
+	Merged         bool           `json:"merged"`
+	Mergeable      *bool          `json:"mergeable"`
+	MergedBy       *User          `json:"merged_by"`
+	MergeCommitSHA *string        `json:"merge_commit_sha"`
+	Comments       int            `json:"comments"`
+	Commits        *int64         `json:"commits,omitempty"`
+	Additions      *int64         `json:"additions,omitempty"`
+	Deletions      *int64         `json:"deletions,omitempty"`
+	ChangedFiles   *int64         `json:"changed_files,omitempty"`
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+	ClosedAt       *string        `json:"closed_at"`
+	MergedAt       *string        `json:"merged_at"`
+}
+
+type PullRequestCreateInput struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft"`
+}
+
+type PullRequestUpdateInput struct {
+	Title *string `json:"title"`
+	Body  *string `json:"body"`
+	State *string `json:"state"`
+	Base  *string `json:"base"`
+}
+
+type PullRequestMergeInput struct {
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+	SHA           string `json:"sha"`
+	MergeMethod   string `json:"merge_method"`
+}
+
+type PullRequestMergeOutput struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+type Review struct {
+	ID          int64  `json:"id"`
+	User        User   `json:"user"`
+	Body        string `json:"body"`
+	State       string `json:"state"`
+	CommitID    string `json:"commit_id"`
+	SubmittedAt string `json:"submitted_at"`
+}
+
+type ReviewCreateInput struct {
+	CommitID string `json:"commit_id"`
+	Body     string `json:"body"`
+	Event    string `json:"event"`
+}
+
+type IssueComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type IssueCommentCreateInput struct {
+	Body string `json:"body"`
+}
+
+type Status struct {
+	ID          int64  `json:"id"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+	Context     string `json:"context"`
+	Creator     *User  `json:"creator"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type StatusCreateInput struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+type CombinedStatus struct {
+	State      string   `json:"state"`
+	SHA        string   `json:"sha"`
+	TotalCount int      `json:"total_count"`
+	Statuses   []Status `json:"statuses"`
+}
+
+type CheckRunOutput struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+type CheckRun struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	HeadSHA     string         `json:"head_sha"`
+	Status      string         `json:"status"`
+	Conclusion  *string        `json:"conclusion"`
+	DetailsURL  string         `json:"details_url"`
+	Output      CheckRunOutput `json:"output"`
+	StartedAt   *string        `json:"started_at"`
+	CompletedAt *string        `json:"completed_at"`
+}
+
+type CheckRunCreateInput struct {
+	Name        string          `json:"name"`
+	HeadSHA     string          `json:"head_sha"`
+	Status      string          `json:"status"`
+	Conclusion  string          `json:"conclusion"`
+	DetailsURL  string          `json:"details_url"`
+	Output      *CheckRunOutput `json:"output"`
+	StartedAt   string          `json:"started_at"`
+	CompletedAt string          `json:"completed_at"`
+}
+
+type CheckRunList struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+type HookConfig struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Secret      string `json:"secret,omitempty"`
+	InsecureSSL string `json:"insecure_ssl"`
+}
+
+type Hook struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Active    bool       `json:"active"`
+	Events    []string   `json:"events"`
+	Config    HookConfig `json:"config"`
+	CreatedAt string     `json:"created_at"`
+	UpdatedAt string     `json:"updated_at"`
+}
+
+type HookInput struct {
+	Config *HookConfig `json:"config"`
+	Events []string    `json:"events"`
+	Active *bool       `json:"active"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,450 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createIssueRequest struct {
+	repoRequest
+	issue.CreateInput
+}
+
+type listIssuesRequest struct {
+	repoRequest
+}
+
+type issueRequest struct {
+	repoRequest
+	Number int64 `path:"issue_number"`
+}
+
+type getIssueRequest struct {
+	issueRequest
+}
+
+type updateIssueRequest struct {
+	issueRequest
+	issue.UpdateInput
+}
+
+type stateIssueRequest struct {
+	issueRequest
+	issue.StateInput
+}
+
+type listIssueActivitiesRequest struct {
+	issueRequest
+}
+
+type commentCreateIssueRequest struct {
+	issueRequest
+	issue.CommentCreateInput
+}
+
+type issueCommentRequest struct {
+	issueRequest
+	ID int64 `path:"issue_comment_id"`
+}
+
+type commentUpdateIssueRequest struct {
+	issueCommentRequest
+	issue.CommentUpdateInput
+}
+
+type commentDeleteIssueRequest struct {
+	issueCommentRequest
+}
+
+type assigneeAddIssueRequest struct {
+	issueRequest
+	issue.AssigneeAddInput
+}
+
+type assigneeDeleteIssueRequest struct {
+	issueRequest
+	AssigneeID int64 `path:"assignee_id"`
+}
+
+type assignLabelIssueRequest struct {
+	issueRequest
+	types.PullReqLabelAssignInput
+}
+
+type listLabelsIssueRequest struct {
+	issueRequest
+}
+
+type unassignLabelIssueRequest struct {
+	issueRequest
+	LabelID int64 `path:"label_id"`
+}
+
+type createMilestoneRequest struct {
+	repoRequest
+	issue.MilestoneCreateInput
+}
+
+type listMilestonesRequest struct {
+	repoRequest
+}
+
+type milestoneRequest struct {
+	repoRequest
+	ID int64 `path:"milestone_id"`
+}
+
+type getMilestoneRequest struct {
+	milestoneRequest
+}
+
+type updateMilestoneRequest struct {
+	milestoneRequest
+	issue.MilestoneUpdateInput
+}
+
+type deleteMilestoneRequest struct {
+	milestoneRequest
+}
+
+var queryParameterStateIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the issues to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueState("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterSortIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the issues are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.IssueSortNumber),
+				Enum:    enum.IssueSort("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterAssigneeIDIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAssigneeID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only issues assigned to the principal with this ID."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterMilestoneIDIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMilestoneID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only issues of the milestone with this ID."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterStateMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the milestones to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.MilestoneState("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+//nolint:funlen
+func issueOperations(reflector *openapi3.Reflector) {
+	createIssue := openapi3.Operation{}
+	createIssue.WithTags("issue")
+	createIssue.WithMapOfAnything(map[string]any{"operationId": "createIssue"})
+	_ = reflector.SetRequest(&createIssue, new(createIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createIssue, new(types.Issue), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues", createIssue)
+
+	listIssues := openapi3.Operation{}
+	listIssues.WithTags("issue")
+	listIssues.WithMapOfAnything(map[string]any{"operationId": "listIssues"})
+	listIssues.WithParameters(
+		queryParameterStateIssue, queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterAssigneeIDIssue, queryParameterMilestoneIDIssue,
+		queryParameterOrder, queryParameterSortIssue,
+		queryParameterCreatedLt, queryParameterCreatedGt, queryParameterUpdatedLt, queryParameterUpdatedGt,
+		QueryParameterPage, QueryParameterLimit, QueryParameterLabelID, QueryParameterValueID)
+	_ = reflector.SetRequest(&listIssues, new(listIssuesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIssues, new([]types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues", listIssues)
+
+	getIssue := openapi3.Operation{}
+	getIssue.WithTags("issue")
+	getIssue.WithMapOfAnything(map[string]any{"operationId": "getIssue"})
+	_ = reflector.SetRequest(&getIssue, new(getIssueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues/{issue_number}", getIssue)
+
+	updateIssue := openapi3.Operation{}
+	updateIssue.WithTags("issue")
+	updateIssue.WithMapOfAnything(map[string]any{"operationId": "updateIssue"})
+	_ = reflector.SetRequest(&updateIssue, new(updateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/issues/{issue_number}", updateIssue)
+
+	stateIssue := openapi3.Operation{}
+	stateIssue.WithTags("issue")
+	stateIssue.WithMapOfAnything(map[string]any{"operationId": "stateIssue"})
+	_ = reflector.SetRequest(&stateIssue, new(stateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/issues/{issue_number}/state", stateIssue)
+
+	listIssueActivities := openapi3.Operation{}
+	listIssueActivities.WithTags("issue")
+	listIssueActivities.WithMapOfAnything(map[string]any{"operationId": "listIssueActivities"})
+	listIssueActivities.WithParameters(
+		queryParameterKindPullRequestActivity, queryParameterTypePullRequestActivity,
+		queryParameterAfter, queryParameterBeforePullRequestActivity, QueryParameterLimit)
+	_ = reflector.SetRequest(&listIssueActivities, new(listIssueActivitiesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new([]types.IssueActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/issues/{issue_number}/activities", listIssueActivities)
+
+	commentCreateIssue := openapi3.Operation{}
+	commentCreateIssue.WithTags("issue")
+	commentCreateIssue.WithMapOfAnything(map[string]any{"operationId": "commentCreateIssue"})
+	_ = reflector.SetRequest(&commentCreateIssue, new(commentCreateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(types.IssueActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/comments", commentCreateIssue)
+
+	commentUpdateIssue := openapi3.Operation{}
+	commentUpdateIssue.WithTags("issue")
+	commentUpdateIssue.WithMapOfAnything(map[string]any{"operationId": "commentUpdateIssue"})
+	_ = reflector.SetRequest(&commentUpdateIssue, new(commentUpdateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(types.IssueActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", commentUpdateIssue)
+
+	commentDeleteIssue := openapi3.Operation{}
+	commentDeleteIssue.WithTags("issue")
+	commentDeleteIssue.WithMapOfAnything(map[string]any{"operationId": "commentDeleteIssue"})
+	_ = reflector.SetRequest(&commentDeleteIssue, new(commentDeleteIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", commentDeleteIssue)
+
+	assigneeAddIssue := openapi3.Operation{}
+	assigneeAddIssue.WithTags("issue")
+	assigneeAddIssue.WithMapOfAnything(map[string]any{"operationId": "assigneeAddIssue"})
+	_ = reflector.SetRequest(&assigneeAddIssue, new(assigneeAddIssueRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(types.IssueAssignee), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/issues/{issue_number}/assignees", assigneeAddIssue)
+
+	assigneeDeleteIssue := openapi3.Operation{}
+	assigneeDeleteIssue.WithTags("issue")
+	assigneeDeleteIssue.WithMapOfAnything(map[string]any{"operationId": "assigneeDeleteIssue"})
+	_ = reflector.SetRequest(&assigneeDeleteIssue, new(assigneeDeleteIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/assignees/{assignee_id}", assigneeDeleteIssue)
+
+	assignLabelIssue := openapi3.Operation{}
+	assignLabelIssue.WithTags("issue")
+	assignLabelIssue.WithMapOfAnything(map[string]any{"operationId": "assignLabelIssue"})
+	_ = reflector.SetRequest(&assignLabelIssue, new(assignLabelIssueRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&assignLabelIssue, new(types.IssueLabel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assignLabelIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assignLabelIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assignLabelIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assignLabelIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/issues/{issue_number}/labels", assignLabelIssue)
+
+	listLabelsIssue := openapi3.Operation{}
+	listLabelsIssue.WithTags("issue")
+	listLabelsIssue.WithMapOfAnything(map[string]any{"operationId": "listLabelsIssue"})
+	listLabelsIssue.WithParameters(
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&listLabelsIssue, new(listLabelsIssueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listLabelsIssue, new(types.ScopesLabels), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listLabelsIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listLabelsIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listLabelsIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listLabelsIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/issues/{issue_number}/labels", listLabelsIssue)
+
+	unassignLabelIssue := openapi3.Operation{}
+	unassignLabelIssue.WithTags("issue")
+	unassignLabelIssue.WithMapOfAnything(map[string]any{"operationId": "unassignLabelIssue"})
+	_ = reflector.SetRequest(&unassignLabelIssue, new(unassignLabelIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&unassignLabelIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&unassignLabelIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&unassignLabelIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&unassignLabelIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&unassignLabelIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/labels/{label_id}", unassignLabelIssue)
+
+	createMilestone := openapi3.Operation{}
+	createMilestone.WithTags("milestone")
+	createMilestone.WithMapOfAnything(map[string]any{"operationId": "createMilestone"})
+	_ = reflector.SetRequest(&createMilestone, new(createMilestoneRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createMilestone, new(types.Milestone), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/milestones", createMilestone)
+
+	listMilestones := openapi3.Operation{}
+	listMilestones.WithTags("milestone")
+	listMilestones.WithMapOfAnything(map[string]any{"operationId": "listMilestones"})
+	listMilestones.WithParameters(
+		queryParameterStateMilestone, queryParameterQueryPullRequest, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&listMilestones, new(listMilestonesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listMilestones, new([]types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/milestones", listMilestones)
+
+	getMilestone := openapi3.Operation{}
+	getMilestone.WithTags("milestone")
+	getMilestone.WithMapOfAnything(map[string]any{"operationId": "getMilestone"})
+	_ = reflector.SetRequest(&getMilestone, new(getMilestoneRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/milestones/{milestone_id}", getMilestone)
+
+	updateMilestone := openapi3.Operation{}
+	updateMilestone.WithTags("milestone")
+	updateMilestone.WithMapOfAnything(map[string]any{"operationId": "updateMilestone"})
+	_ = reflector.SetRequest(&updateMilestone, new(updateMilestoneRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/milestones/{milestone_id}", updateMilestone)
+
+	deleteMilestone := openapi3.Operation{}
+	deleteMilestone.WithTags("milestone")
+	deleteMilestone.WithMapOfAnything(map[string]any{"operationId": "deleteMilestone"})
+	_ = reflector.SetRequest(&deleteMilestone, new(deleteMilestoneRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteMilestone, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/milestones/{milestone_id}", deleteMilestone)
+}
@@ -68,6 +68,7 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
+	issueOperations(&reflector)
 	webhookOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
@@ -49,6 +49,16 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type artifactRequest struct {
+	executionRequest
+	Name string `path:"artifact_name"`
+}
+
+type cacheRequest struct {
+	pipelineRequest
+	Key string `path:"cache_key"`
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -85,6 +95,25 @@ type updatePipelineRequest struct {
 	pipeline.UpdateInput
 }
 
+var queryParameterRestoreKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRestoreKey,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The key prefixes which are tried in order if there's no cache with the exact key."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterQueryPipeline = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -343,4 +372,72 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/logs/{stage_number}/{step_number}",
 		logView,
 	)
+
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]any{"operationId": "listArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]any{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&artifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&artifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDownload)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]any{"operationId": "uploadArtifact"})
+	_ = reflector.SetRequest(&artifactUpload, new(artifactRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.PipelineArtifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactUpload)
+
+	cacheRestore := openapi3.Operation{}
+	cacheRestore.WithTags("pipeline")
+	cacheRestore.WithMapOfAnything(map[string]any{"operationId": "restoreCache"})
+	cacheRestore.WithParameters(queryParameterRestoreKey)
+	_ = reflector.SetRequest(&cacheRestore, new(cacheRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&cacheRestore, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&cacheRestore, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheRestore)
+
+	cacheSave := openapi3.Operation{}
+	cacheSave.WithTags("pipeline")
+	cacheSave.WithMapOfAnything(map[string]any{"operationId": "saveCache"})
+	_ = reflector.SetRequest(&cacheSave, new(cacheRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&cacheSave, new(types.PipelineCache), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheSave)
 }
@@ -53,6 +53,11 @@ type getPullReqByBranchesRequest struct {
 	TargetBranch string `path:"target_branch"`
 }
 
+type autoMergePullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
 type updatePullReqRequest struct {
 	pullReqRequest
 	pullreq.UpdateInput
@@ -885,6 +890,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/codeowners", codeOwners)
 
+	stack := openapi3.Operation{}
+	stack.WithTags("pullreq")
+	stack.WithMapOfAnything(map[string]any{"operationId": "stackPullReq"})
+	_ = reflector.SetRequest(&stack, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&stack, types.PullReqStack{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&stack, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stack, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stack, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stack, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stack, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack", stack)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("pullreq")
 	opDiff.WithMapOfAnything(map[string]any{"operationId": "diffPullReq"})
@@ -973,4 +991,54 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opPRCandidates, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/candidates", opPRCandidates)
+
+	opMergeQueueList := openapi3.Operation{}
+	opMergeQueueList.WithTags("pullreq")
+	opMergeQueueList.WithMapOfAnything(map[string]any{"operationId": "listMergeQueue"})
+	opMergeQueueList.WithParameters(queryParameterBranch)
+	_ = reflector.SetRequest(&opMergeQueueList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new([]types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/merge-queue", opMergeQueueList)
+
+	opMergeQueueRemove := openapi3.Operation{}
+	opMergeQueueRemove.WithTags("pullreq")
+	opMergeQueueRemove.WithMapOfAnything(map[string]any{"operationId": "removePullReqFromMergeQueue"})
+	_ = reflector.SetRequest(&opMergeQueueRemove, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueRemove)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]any{"operationId": "enablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeDisable := openapi3.Operation{}
+	opAutoMergeDisable.WithTags("pullreq")
+	opAutoMergeDisable.WithMapOfAnything(map[string]any{"operationId": "disablePullReqAutoMerge"})
+	_ = reflector.SetRequest(&opAutoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeDisable)
 }
@@ -1471,6 +1471,115 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPatch,
 		"/repos/{repo_ref}/labels/{key}/values/{value}", opUpdateLabelValue)
 
+	opCreateNotificationChannel := openapi3.Operation{}
+	opCreateNotificationChannel.WithTags("repository")
+	opCreateNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "createRepoNotificationChannel"})
+	_ = reflector.SetRequest(&opCreateNotificationChannel, &struct {
+		repoRequest
+		types.NotificationChannelCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(types.NotificationChannel), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPost, "/repos/{repo_ref}/notification-channels", opCreateNotificationChannel)
+
+	opListNotificationChannels := openapi3.Operation{}
+	opListNotificationChannels.WithTags("repository")
+	opListNotificationChannels.WithMapOfAnything(
+		map[string]any{"operationId": "listRepoNotificationChannels"})
+	_ = reflector.SetRequest(&opListNotificationChannels, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new([]*types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/notification-channels", opListNotificationChannels)
+
+	opUpdateNotificationChannel := openapi3.Operation{}
+	opUpdateNotificationChannel.WithTags("repository")
+	opUpdateNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "updateRepoNotificationChannel"})
+	_ = reflector.SetRequest(&opUpdateNotificationChannel, &struct {
+		repoRequest
+		types.NotificationChannelUpdateInput
+		Identifier string `path:"notification_channel_identifier"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch,
+		"/repos/{repo_ref}/notification-channels/{notification_channel_identifier}",
+		opUpdateNotificationChannel,
+	)
+
+	opDeleteNotificationChannel := openapi3.Operation{}
+	opDeleteNotificationChannel.WithTags("repository")
+	opDeleteNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "deleteRepoNotificationChannel"})
+	_ = reflector.SetRequest(&opDeleteNotificationChannel, &struct {
+		repoRequest
+		Identifier string `path:"notification_channel_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete,
+		"/repos/{repo_ref}/notification-channels/{notification_channel_identifier}",
+		opDeleteNotificationChannel,
+	)
+
+	opFindWatch := openapi3.Operation{}
+	opFindWatch.WithTags("repository")
+	opFindWatch.WithMapOfAnything(map[string]any{"operationId": "findRepoWatch"})
+	_ = reflector.SetRequest(&opFindWatch, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(types.Watch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/watch", opFindWatch)
+
+	opWatch := openapi3.Operation{}
+	opWatch.WithTags("repository")
+	opWatch.WithMapOfAnything(map[string]any{"operationId": "watchRepo"})
+	_ = reflector.SetRequest(&opWatch, &struct {
+		repoRequest
+		types.WatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opWatch, new(types.Watch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/watch", opWatch)
+
+	opUnwatch := openapi3.Operation{}
+	opUnwatch.WithTags("repository")
+	opUnwatch.WithMapOfAnything(map[string]any{"operationId": "unwatchRepo"})
+	_ = reflector.SetRequest(&opUnwatch, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnwatch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/watch", opUnwatch)
+
 	opRebaseBranch := openapi3.Operation{}
 	opRebaseBranch.WithTags("repository")
 	opRebaseBranch.WithMapOfAnything(
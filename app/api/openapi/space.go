@@ -710,6 +710,115 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPatch,
 		"/spaces/{space_ref}/labels/{key}/values/{value}", opUpdateLabelValue)
 
+	opCreateNotificationChannel := openapi3.Operation{}
+	opCreateNotificationChannel.WithTags("space")
+	opCreateNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "createSpaceNotificationChannel"})
+	_ = reflector.SetRequest(&opCreateNotificationChannel, &struct {
+		spaceRequest
+		types.NotificationChannelCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(types.NotificationChannel), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPost, "/spaces/{space_ref}/notification-channels", opCreateNotificationChannel)
+
+	opListNotificationChannels := openapi3.Operation{}
+	opListNotificationChannels.WithTags("space")
+	opListNotificationChannels.WithMapOfAnything(
+		map[string]any{"operationId": "listSpaceNotificationChannels"})
+	_ = reflector.SetRequest(&opListNotificationChannels, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new([]*types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListNotificationChannels, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/notification-channels", opListNotificationChannels)
+
+	opUpdateNotificationChannel := openapi3.Operation{}
+	opUpdateNotificationChannel.WithTags("space")
+	opUpdateNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "updateSpaceNotificationChannel"})
+	_ = reflector.SetRequest(&opUpdateNotificationChannel, &struct {
+		spaceRequest
+		types.NotificationChannelUpdateInput
+		Identifier string `path:"notification_channel_identifier"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}",
+		opUpdateNotificationChannel,
+	)
+
+	opDeleteNotificationChannel := openapi3.Operation{}
+	opDeleteNotificationChannel.WithTags("space")
+	opDeleteNotificationChannel.WithMapOfAnything(
+		map[string]any{"operationId": "deleteSpaceNotificationChannel"})
+	_ = reflector.SetRequest(&opDeleteNotificationChannel, &struct {
+		spaceRequest
+		Identifier string `path:"notification_channel_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationChannel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}",
+		opDeleteNotificationChannel,
+	)
+
+	opFindWatch := openapi3.Operation{}
+	opFindWatch.WithTags("space")
+	opFindWatch.WithMapOfAnything(map[string]any{"operationId": "findSpaceWatch"})
+	_ = reflector.SetRequest(&opFindWatch, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(types.Watch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/watch", opFindWatch)
+
+	opWatch := openapi3.Operation{}
+	opWatch.WithTags("space")
+	opWatch.WithMapOfAnything(map[string]any{"operationId": "watchSpace"})
+	_ = reflector.SetRequest(&opWatch, &struct {
+		spaceRequest
+		types.WatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opWatch, new(types.Watch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/watch", opWatch)
+
+	opUnwatch := openapi3.Operation{}
+	opUnwatch.WithTags("space")
+	opUnwatch.WithMapOfAnything(map[string]any{"operationId": "unwatchSpace"})
+	_ = reflector.SetRequest(&opUnwatch, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnwatch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnwatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/watch", opUnwatch)
+
 	countPullReq := openapi3.Operation{}
 	countPullReq.WithTags("space")
 	countPullReq.WithMapOfAnything(map[string]any{"operationId": "countSpacePullReq"})
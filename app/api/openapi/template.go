@@ -18,9 +18,11 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/template"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 
+	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
@@ -41,6 +43,35 @@ type updateTemplateRequest struct {
 	template.UpdateInput
 }
 
+type templateVersionsRequest struct {
+	Type string `path:"template_type"`
+	Ref  string `path:"template_ref"`
+}
+
+type createTemplateVersionRequest struct {
+	templateVersionsRequest
+	template.CreateVersionInput
+}
+
+type getTemplateVersionRequest struct {
+	templateVersionsRequest
+	Version string `path:"template_version"`
+}
+
+var queryParameterQueryTemplateVersion = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the template versions by their version."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 func templateOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("template")
@@ -86,4 +117,43 @@ func templateOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/templates/{template_ref}", opUpdate)
+
+	opCreateVersion := openapi3.Operation{}
+	opCreateVersion.WithTags("template")
+	opCreateVersion.WithMapOfAnything(map[string]any{"operationId": "createTemplateVersion"})
+	_ = reflector.SetRequest(&opCreateVersion, new(createTemplateVersionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(types.TemplateVersion), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreateVersion, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/templates/{template_type}/{template_ref}/versions", opCreateVersion)
+
+	opListVersions := openapi3.Operation{}
+	opListVersions.WithTags("template")
+	opListVersions.WithMapOfAnything(map[string]any{"operationId": "listTemplateVersions"})
+	opListVersions.WithParameters(queryParameterQueryTemplateVersion, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListVersions, new(templateVersionsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListVersions, []types.TemplateVersion{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListVersions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListVersions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListVersions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListVersions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/templates/{template_type}/{template_ref}/versions", opListVersions)
+
+	opFindVersion := openapi3.Operation{}
+	opFindVersion.WithTags("template")
+	opFindVersion.WithMapOfAnything(map[string]any{"operationId": "findTemplateVersion"})
+	_ = reflector.SetRequest(&opFindVersion, new(getTemplateVersionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindVersion, new(types.TemplateVersion), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindVersion, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindVersion, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindVersion, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindVersion, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/templates/{template_type}/{template_ref}/versions/{template_version}", opFindVersion)
 }
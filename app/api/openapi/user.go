@@ -182,6 +182,14 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
 
+	opListWatches := openapi3.Operation{}
+	opListWatches.WithTags("user")
+	opListWatches.WithMapOfAnything(map[string]any{"operationId": "listWatches"})
+	_ = reflector.SetJSONResponse(&opListWatches, new([]*types.Watch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListWatches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListWatches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/watches", opListWatches)
+
 	opKeyCreate := openapi3.Operation{}
 	opKeyCreate.WithTags("user")
 	opKeyCreate.WithMapOfAnything(map[string]any{"operationId": "createPublicKey"})
@@ -279,4 +287,62 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteFavorite, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.SetJSONResponse(&opDeleteFavorite, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/favorite/{resource_id}", opDeleteFavorite)
+
+	opFindNotificationPreferences := openapi3.Operation{}
+	opFindNotificationPreferences.WithTags("user")
+	opFindNotificationPreferences.WithMapOfAnything(map[string]any{"operationId": "findNotificationPreferences"})
+	_ = reflector.SetJSONResponse(&opFindNotificationPreferences, new(types.NotificationPreferences), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindNotificationPreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindNotificationPreferences, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindNotificationPreferences, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences", opFindNotificationPreferences)
+
+	opUpdateNotificationPreferences := openapi3.Operation{}
+	opUpdateNotificationPreferences.WithTags("user")
+	opUpdateNotificationPreferences.WithMapOfAnything(map[string]any{"operationId": "updateNotificationPreferences"})
+	_ = reflector.SetRequest(&opUpdateNotificationPreferences,
+		new(types.NotificationPreferencesUpdateInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(types.NotificationPreferences), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPreferences, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notification-preferences", opUpdateNotificationPreferences)
+
+	opListNotificationScopes := openapi3.Operation{}
+	opListNotificationScopes.WithTags("user")
+	opListNotificationScopes.WithMapOfAnything(map[string]any{"operationId": "listNotificationScopePreferences"})
+	_ = reflector.SetJSONResponse(&opListNotificationScopes,
+		new([]*types.NotificationScopePreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationScopes, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListNotificationScopes, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListNotificationScopes, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences/scopes", opListNotificationScopes)
+
+	opSetNotificationScope := openapi3.Operation{}
+	opSetNotificationScope.WithTags("user")
+	opSetNotificationScope.WithMapOfAnything(map[string]any{"operationId": "setNotificationScopePreference"})
+	_ = reflector.SetRequest(&opSetNotificationScope, new(types.NotificationScopePreferenceInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(types.NotificationScopePreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSetNotificationScope, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/notification-preferences/scopes", opSetNotificationScope)
+
+	opDeleteNotificationScope := openapi3.Operation{}
+	opDeleteNotificationScope.WithTags("user")
+	opDeleteNotificationScope.WithMapOfAnything(map[string]any{"operationId": "deleteNotificationScopePreference"})
+	opDeleteNotificationScope.WithParameters(QueryParameterResourceType)
+	_ = reflector.SetRequest(&opDeleteNotificationScope, new(favoriteRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationScope, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/notification-preferences/scopes/{resource_id}", opDeleteNotificationScope)
 }
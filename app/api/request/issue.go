@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamIssueNumber    = "issue_number"
+	PathParamIssueCommentID = "issue_comment_id"
+	PathParamAssigneeID     = "assignee_id"
+	PathParamMilestoneID    = "milestone_id"
+
+	QueryParamAssigneeID  = "assignee_id"
+	QueryParamMilestoneID = "milestone_id"
+)
+
+func GetIssueNumberFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueNumber)
+}
+
+func GetIssueCommentIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueCommentID)
+}
+
+func GetAssigneeIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAssigneeID)
+}
+
+func GetMilestoneIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamMilestoneID)
+}
+
+// ParseSortIssue extracts the issue sort parameter from the url.
+func ParseSortIssue(r *http.Request) enum.IssueSort {
+	result, _ := enum.IssueSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
+	return result
+}
+
+// parseIssueStates extracts the issue states from the url.
+func parseIssueStates(r *http.Request) []enum.IssueState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.IssueState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.IssueState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.IssueState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseIssueFilter extracts the issue query parameters from the url.
+func ParseIssueFilter(r *http.Request) (*types.IssueFilter, error) {
+	createdBy, err := QueryParamListAsPositiveInt64(r, QueryParamCreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing createdby filter: %w", err)
+	}
+
+	labelID, err := QueryParamListAsPositiveInt64(r, QueryParamLabelID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing labelid filter: %w", err)
+	}
+
+	valueID, err := QueryParamListAsPositiveInt64(r, QueryParamValueID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing valueid filter: %w", err)
+	}
+
+	assigneeID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAssigneeID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing assignee ID filter: %w", err)
+	}
+
+	milestoneID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMilestoneID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing milestone ID filter: %w", err)
+	}
+
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing issue created filter: %w", err)
+	}
+
+	updatedFilter, err := ParseUpdated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing issue updated filter: %w", err)
+	}
+
+	return &types.IssueFilter{
+		Page:          ParsePage(r),
+		Size:          ParseLimit(r),
+		Query:         ParseQuery(r),
+		CreatedBy:     createdBy,
+		AssigneeID:    assigneeID,
+		MilestoneID:   milestoneID,
+		States:        parseIssueStates(r),
+		Sort:          ParseSortIssue(r),
+		Order:         ParseOrder(r),
+		LabelID:       labelID,
+		ValueID:       valueID,
+		CreatedFilter: createdFilter,
+		UpdatedFilter: updatedFilter,
+	}, nil
+}
+
+// ParseMilestoneFilter extracts the milestone query parameters from the url.
+func ParseMilestoneFilter(r *http.Request) *types.MilestoneFilter {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	states := make([]enum.MilestoneState, 0, len(strStates))
+	for _, s := range strStates {
+		if state, ok := enum.MilestoneState(s).Sanitize(); ok {
+			states = append(states, state)
+		}
+	}
+
+	return &types.MilestoneFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		States:          states,
+	}
+}
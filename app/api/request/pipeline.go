@@ -26,6 +26,9 @@ const (
 	PathParamStageNumber        = "stage_number"
 	PathParamStepNumber         = "step_number"
 	PathParamTriggerIdentifier  = "trigger_identifier"
+	PathParamArtifactName       = "artifact_name"
+	PathParamCacheKey           = "cache_key"
+	QueryParamRestoreKey        = "restore_key"
 	QueryParamLatest            = "latest"
 	QueryParamLastExecutions    = "last_executions"
 	QueryParamBranch            = "branch"
@@ -60,6 +63,20 @@ func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
 
+func GetArtifactNameFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamArtifactName)
+}
+
+func GetCacheKeyFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCacheKey)
+}
+
+// GetRestoreKeysFromQuery returns the cache key prefixes which are used in case there's no cache with the exact key.
+func GetRestoreKeysFromQuery(r *http.Request) []string {
+	keys, _ := QueryParamList(r, QueryParamRestoreKey)
+	return keys
+}
+
 func ParseListPipelinesFilterFromRequest(r *http.Request) (types.ListPipelinesFilter, error) {
 	lastExecs, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLastExecutions, 10)
 	if err != nil {
@@ -19,8 +19,9 @@ import (
 )
 
 const (
-	PathParamTemplateRef  = "template_ref"
-	PathParamTemplateType = "template_type"
+	PathParamTemplateRef     = "template_ref"
+	PathParamTemplateType    = "template_type"
+	PathParamTemplateVersion = "template_version"
 )
 
 func GetTemplateRefFromPath(r *http.Request) (string, error) {
@@ -30,3 +31,7 @@ func GetTemplateRefFromPath(r *http.Request) (string, error) {
 func GetTemplateTypeFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTemplateType)
 }
+
+func GetTemplateVersionFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamTemplateVersion)
+}
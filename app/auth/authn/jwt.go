@@ -32,6 +32,8 @@ import (
 
 const (
 	headerTokenPrefixBearer = "Bearer "
+	// headerTokenPrefixToken is the prefix used by GitHub API clients.
+	headerTokenPrefixToken = "token "
 	//nolint:gosec // wrong flagging
 	HeaderTokenPrefixRemoteAuth = "RemoteAuth "
 )
@@ -188,6 +190,9 @@ func extractToken(r *http.Request, cookieName string) string {
 	// strip bearer prefix if present
 	case strings.HasPrefix(headerToken, headerTokenPrefixBearer):
 		return headerToken[len(headerTokenPrefixBearer):]
+	// strip the github style token prefix if present
+	case strings.HasPrefix(headerToken, headerTokenPrefixToken):
+		return headerToken[len(headerTokenPrefixToken):]
 	// for ssh git-lfs-authenticate the returned token prefix would be RemoteAuth of type JWT
 	case strings.HasPrefix(headerToken, HeaderTokenPrefixRemoteAuth):
 		return headerToken[len(HeaderTokenPrefixRemoteAuth):]
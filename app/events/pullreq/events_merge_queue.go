@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeGroupCreatedEvent events.EventType = "merge-group-created"
+
+// MergeGroupCreatedPayload is sent when the merge queue creates a merge group commit of a pull request.
+// The status checks required by the target branch are expected to be reported for the GroupSHA commit.
+type MergeGroupCreatedPayload struct {
+	Base
+	Ref      string `json:"ref"`
+	BaseSHA  string `json:"base_sha"`
+	GroupSHA string `json:"group_sha"`
+}
+
+func (r *Reporter) MergeGroupCreated(ctx context.Context, payload *MergeGroupCreatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeGroupCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge group created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge group created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeGroupCreated(
+	fn events.HandlerFunc[*MergeGroupCreatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeGroupCreatedEvent, fn, opts...)
+}
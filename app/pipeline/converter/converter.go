@@ -16,12 +16,18 @@ package converter
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/pipeline/converter/jsonnet"
 	"github.com/harness/gitness/app/pipeline/converter/starlark"
+	"github.com/harness/gitness/app/pipeline/converter/template"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -32,26 +38,57 @@ const (
 )
 
 type converter struct {
-	fileService  file.Service
-	publicAccess publicaccess.Service
+	fileService          file.Service
+	publicAccess         publicaccess.Service
+	spaceFinder          refcache.SpaceFinder
+	templateStore        store.TemplateStore
+	templateVersionStore store.TemplateVersionStore
 }
 
-func newConverter(fileService file.Service, publicAccess publicaccess.Service) Service {
+func newConverter(
+	fileService file.Service,
+	publicAccess publicaccess.Service,
+	spaceFinder refcache.SpaceFinder,
+	templateStore store.TemplateStore,
+	templateVersionStore store.TemplateVersionStore,
+) Service {
 	return &converter{
-		fileService:  fileService,
-		publicAccess: publicAccess,
+		fileService:          fileService,
+		publicAccess:         publicAccess,
+		spaceFinder:          spaceFinder,
+		templateStore:        templateStore,
+		templateVersionStore: templateVersionStore,
 	}
 }
 
 func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File, error) {
-	path := args.Pipeline.ConfigPath
-
 	// get public access visibility of the repo
 	repoIsPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, args.Repo.Path)
 	if err != nil {
 		return nil, err
 	}
 
+	f, err := c.convert(ctx, args, repoIsPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	data, templates, err := template.Expand(f.Data, c.resolveTemplate(ctx, args.Repo))
+	if err != nil {
+		return nil, fmt.Errorf("could not expand templates: %w", err)
+	}
+
+	if args.Execution != nil {
+		args.Execution.Templates = templates
+	}
+
+	return &file.File{Data: data}, nil
+}
+
+// convert converts the file from jsonnet/starlark to drone yaml.
+func (c *converter) convert(ctx context.Context, args *ConvertArgs, repoIsPublic bool) (*file.File, error) {
+	path := args.Pipeline.ConfigPath
+
 	if isJSONNet(path) {
 		str, err := jsonnet.Parse(
 			args.Repo,
@@ -84,6 +121,28 @@ func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File,
 	return args.File, nil
 }
 
+// resolveTemplate returns the function resolving the template versions used by the pipelines of the repository.
+// Pipelines can only use the templates of the spaces the repository belongs to.
+func (c *converter) resolveTemplate(ctx context.Context, repo *types.Repository) template.ResolveFunc {
+	return func(spacePath, identifier, version string) (*types.TemplateVersion, error) {
+		space, err := c.spaceFinder.FindByRef(ctx, spacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if !paths.IsAncesterOf(space.Path, repo.Path) {
+			return nil, fmt.Errorf("space %q isn't an ancestor of the repository", space.Path)
+		}
+
+		t, err := c.templateStore.FindByIdentifierAndType(ctx, space.ID, identifier, enum.ResolverTypeStage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find template: %w", err)
+		}
+
+		return c.templateVersionStore.Find(ctx, t.ID, version)
+	}
+}
+
 func isJSONNet(path string) bool {
 	return strings.HasSuffix(path, ".drone.jsonnet")
 }
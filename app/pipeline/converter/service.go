@@ -34,6 +34,8 @@ type (
 
 	// Service converts a file which is in starlark/jsonnet form by looking
 	// at the extension and calling the appropriate parser.
+	// Pipelines which use a template are replaced by the rendered template version,
+	// and the resolved template versions are recorded on the execution.
 	Service interface {
 		Convert(ctx context.Context, args *ConvertArgs) (*file.File, error)
 	}
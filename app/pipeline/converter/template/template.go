@@ -0,0 +1,449 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-yaml/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// keyUse is the key of a drone pipeline which makes the pipeline use a template.
+const keyUse = "use"
+
+var (
+	inputNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// placeholderRegex matches references of inputs in the data of a template version, e.g. ${{ inputs.name }}.
+	placeholderRegex = regexp.MustCompile(`\$\{\{\s*inputs\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// ResolveFunc returns the version of the template with the identifier which belongs to the space with the path.
+type ResolveFunc func(spacePath, identifier, version string) (*types.TemplateVersion, error)
+
+// use is the template a drone pipeline uses, e.g.
+//
+//	kind: pipeline
+//	name: build
+//	use:
+//	  template: acme/backend/go-build
+//	  version: 1.2.0
+//	  inputs:
+//	    go_version: "1.22"
+//
+// The template is referenced by the path of its space and its identifier.
+type use struct {
+	Template string         `yaml:"template"`
+	Version  string         `yaml:"version"`
+	Inputs   map[string]any `yaml:"inputs"`
+}
+
+// Expand replaces the drone pipelines which use a template with the template version rendered with the inputs.
+// Keys of the pipeline next to "use" take precedence over the keys of the template, hence the pipeline can
+// e.g. keep its own name, dependencies and trigger. The data is returned as is if no pipeline uses a template.
+func Expand(data []byte, resolve ResolveFunc) ([]byte, []*types.ExecutionTemplate, error) {
+	resources, err := yaml.ParseRawBytes(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse yaml: %w", err)
+	}
+
+	var templates []*types.ExecutionTemplate
+	documents := make([][]byte, len(resources))
+	for i, resource := range resources {
+		documents[i] = resource.Data
+
+		if resource.Kind != "" && resource.Kind != yaml.KindPipeline {
+			continue
+		}
+
+		var document struct {
+			Name string `yaml:"name"`
+			Use  *use   `yaml:"use"`
+		}
+		if err = yamlv3.Unmarshal(resource.Data, &document); err != nil {
+			return nil, nil, fmt.Errorf("could not parse pipeline: %w", err)
+		}
+
+		if document.Use == nil {
+			continue
+		}
+
+		name := document.Name
+		if name == "" {
+			name = "default"
+		}
+
+		expanded, template, err := expandDocument(resource.Data, document.Use, resolve)
+		if err != nil {
+			return nil, nil, fmt.Errorf("pipeline %q: %w", name, err)
+		}
+
+		template.Pipeline = name
+
+		documents[i] = expanded
+		templates = append(templates, template)
+	}
+
+	if len(templates) == 0 {
+		return data, nil, nil
+	}
+
+	return bytes.Join(documents, []byte("---\n")), templates, nil
+}
+
+func expandDocument(
+	data []byte,
+	u *use,
+	resolve ResolveFunc,
+) ([]byte, *types.ExecutionTemplate, error) {
+	spacePath, identifier, err := paths.DisectLeaf(u.Template)
+	if err != nil || spacePath == "" {
+		return nil, nil, fmt.Errorf("template %q must be referenced as <space path>/<identifier>", u.Template)
+	}
+
+	if u.Version == "" {
+		return nil, nil, fmt.Errorf("version of template %q is required", u.Template)
+	}
+
+	version, err := resolve(spacePath, identifier, u.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not resolve template %q version %q: %w", u.Template, u.Version, err)
+	}
+
+	values, err := checkInputs(version.Inputs, u.Inputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid inputs of template %q: %w", u.Template, err)
+	}
+
+	templateRoot, err := parseMapping([]byte(version.Data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse template %q: %w", u.Template, err)
+	}
+
+	if err = render(templateRoot, values); err != nil {
+		return nil, nil, fmt.Errorf("could not render template %q: %w", u.Template, err)
+	}
+
+	pipelineRoot, err := parseMapping(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse pipeline: %w", err)
+	}
+
+	for i := 0; i+1 < len(pipelineRoot.Content); i += 2 {
+		if pipelineRoot.Content[i].Value == keyUse {
+			continue
+		}
+		setKey(templateRoot, pipelineRoot.Content[i], pipelineRoot.Content[i+1])
+	}
+
+	expanded, err := yamlv3.Marshal(templateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not marshal pipeline: %w", err)
+	}
+
+	return expanded, &types.ExecutionTemplate{
+		SpacePath:  spacePath,
+		Identifier: identifier,
+		Version:    version.Version,
+	}, nil
+}
+
+// Validate validates the data and the declared inputs of a template version.
+// The data has to be a single drone pipeline which references declared inputs only.
+func Validate(data string, inputs map[string]*types.TemplateInput) error {
+	for name, input := range inputs {
+		if err := validateInput(name, input); err != nil {
+			return err
+		}
+	}
+
+	resources, err := yaml.ParseRawString(data)
+	if err != nil {
+		return fmt.Errorf("could not parse data: %w", err)
+	}
+
+	if len(resources) != 1 {
+		return fmt.Errorf("data has to be a single pipeline")
+	}
+
+	root, err := parseMapping([]byte(data))
+	if err != nil {
+		return fmt.Errorf("could not parse data: %w", err)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == keyUse {
+			return fmt.Errorf("template can't use another template")
+		}
+	}
+
+	// render the template with the default values to make sure it results in a valid pipeline.
+	values, err := checkInputs(inputs, placeholderValues(inputs))
+	if err != nil {
+		return err
+	}
+
+	if err = render(root, values); err != nil {
+		return err
+	}
+
+	rendered, err := yamlv3.Marshal(root)
+	if err != nil {
+		return fmt.Errorf("could not marshal data: %w", err)
+	}
+
+	manifest, err := yaml.ParseBytes(rendered)
+	if err != nil {
+		return fmt.Errorf("could not parse data as pipeline: %w", err)
+	}
+
+	if len(manifest.Resources) != 1 || manifest.Resources[0].GetKind() != yaml.KindPipeline {
+		return fmt.Errorf("data has to be a single pipeline")
+	}
+
+	return nil
+}
+
+func validateInput(name string, input *types.TemplateInput) error {
+	if !inputNameRegex.MatchString(name) {
+		return fmt.Errorf("input name %q is invalid, it has to match %s", name, inputNameRegex)
+	}
+
+	if input == nil {
+		return fmt.Errorf("input %q has no declaration", name)
+	}
+
+	inputType, ok := input.Type.Sanitize()
+	if !ok {
+		return fmt.Errorf("input %q has an unknown type %q", name, input.Type)
+	}
+	input.Type = inputType
+
+	for _, value := range input.Enum {
+		if !hasType(value, input.Type) {
+			return fmt.Errorf("enum value %v of input %q isn't of type %s", value, name, input.Type)
+		}
+	}
+
+	if input.Default != nil {
+		if err := checkValue(name, input, input.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// placeholderValues returns values for the required inputs without defaults, so that a template can be validated.
+func placeholderValues(inputs map[string]*types.TemplateInput) map[string]any {
+	values := map[string]any{}
+	for name, input := range inputs {
+		if !input.Required || input.Default != nil {
+			continue
+		}
+
+		if len(input.Enum) > 0 {
+			values[name] = input.Enum[0]
+		} else {
+			values[name] = zeroValue(input.Type)
+		}
+	}
+
+	return values
+}
+
+// checkInputs validates the provided inputs against the declared inputs
+// and returns the values of all declared inputs with defaults applied.
+func checkInputs(declared map[string]*types.TemplateInput, provided map[string]any) (map[string]any, error) {
+	for name := range provided {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("input %q isn't declared", name)
+		}
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]any, len(declared))
+	for _, name := range names {
+		input := declared[name]
+
+		value, ok := provided[name]
+		switch {
+		case ok:
+		case input.Default != nil:
+			value = input.Default
+		case input.Required:
+			return nil, fmt.Errorf("input %q is required", name)
+		default:
+			value = zeroValue(input.Type)
+		}
+
+		if err := checkValue(name, input, value); err != nil {
+			return nil, err
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+func checkValue(name string, input *types.TemplateInput, value any) error {
+	if !hasType(value, input.Type) {
+		return fmt.Errorf("value %v of input %q isn't of type %s", value, name, input.Type)
+	}
+
+	if len(input.Enum) == 0 {
+		return nil
+	}
+
+	for _, allowed := range input.Enum {
+		if formatValue(allowed) == formatValue(value) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("value %v of input %q isn't one of %v", value, name, input.Enum)
+}
+
+func hasType(value any, inputType enum.TemplateInputType) bool {
+	switch value.(type) {
+	case string:
+		return inputType == enum.TemplateInputTypeString
+	case int, int64, uint64, float64:
+		return inputType == enum.TemplateInputTypeNumber
+	case bool:
+		return inputType == enum.TemplateInputTypeBoolean
+	default:
+		return false
+	}
+}
+
+func zeroValue(inputType enum.TemplateInputType) any {
+	switch inputType {
+	case enum.TemplateInputTypeNumber:
+		return 0
+	case enum.TemplateInputTypeBoolean:
+		return false
+	case enum.TemplateInputTypeString:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func valueTag(value any) string {
+	switch value.(type) {
+	case int, int64, uint64:
+		return "!!int"
+	case float64:
+		return "!!float"
+	case bool:
+		return "!!bool"
+	default:
+		return "!!str"
+	}
+}
+
+// render replaces the references of inputs in the scalar values of the node.
+// A scalar consisting of a single reference only takes over the type of the input value.
+func render(node *yamlv3.Node, values map[string]any) error {
+	for _, child := range node.Content {
+		if err := render(child, values); err != nil {
+			return err
+		}
+	}
+
+	if node.Kind != yamlv3.ScalarNode {
+		return nil
+	}
+
+	matches := placeholderRegex.FindAllStringSubmatchIndex(node.Value, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	for _, match := range matches {
+		name := node.Value[match[2]:match[3]]
+		if _, ok := values[name]; !ok {
+			return fmt.Errorf("input %q isn't declared", name)
+		}
+	}
+
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(node.Value) {
+		value := values[node.Value[matches[0][2]:matches[0][3]]]
+		node.Value = formatValue(value)
+		node.Tag = valueTag(value)
+		node.Style = 0
+		return nil
+	}
+
+	node.Value = placeholderRegex.ReplaceAllStringFunc(node.Value, func(s string) string {
+		return formatValue(values[placeholderRegex.FindStringSubmatch(s)[1]])
+	})
+	node.Tag = "!!str"
+	node.Style = 0
+
+	return nil
+}
+
+// parseMapping parses a single yaml document and returns its root mapping.
+func parseMapping(data []byte) (*yamlv3.Node, error) {
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if document.Kind != yamlv3.DocumentNode || len(document.Content) != 1 ||
+		document.Content[0].Kind != yamlv3.MappingNode {
+		return nil, fmt.Errorf("document has to be a mapping")
+	}
+
+	return document.Content[0], nil
+}
+
+// setKey sets the value of the key in the mapping node, replacing the existing value if there is one.
+func setKey(mapping *yamlv3.Node, key, value *yamlv3.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key.Value {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, key, value)
+}
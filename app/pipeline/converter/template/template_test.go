@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-yaml/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+const testTemplateData = `kind: pipeline
+type: docker
+name: template
+steps:
+- name: build
+  image: golang:${{ inputs.go_version }}
+  commands:
+  - go build ./...
+- name: test
+  image: golang:${{ inputs.go_version }}
+  privileged: ${{ inputs.privileged }}
+  commands:
+  - go test ./...
+`
+
+func testTemplateVersion() *types.TemplateVersion {
+	return &types.TemplateVersion{
+		Version: "1.0.0",
+		Data:    testTemplateData,
+		Inputs: map[string]*types.TemplateInput{
+			"go_version": {
+				Type:     enum.TemplateInputTypeString,
+				Required: true,
+				Enum:     []any{"1.21", "1.22"},
+			},
+			"privileged": {
+				Type:    enum.TemplateInputTypeBoolean,
+				Default: false,
+			},
+		},
+	}
+}
+
+func testResolve(spacePath, identifier, version string) (*types.TemplateVersion, error) {
+	if spacePath != "acme/backend" || identifier != "go-build" || version != "1.0.0" {
+		return nil, errors.New("not found")
+	}
+	return testTemplateVersion(), nil
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		expErr       bool
+		expTemplates []*types.ExecutionTemplate
+		check        func(t *testing.T, manifest *yaml.Manifest)
+	}{
+		{
+			name: "without templates",
+			data: "kind: pipeline\nname: build\nsteps:\n- name: build\n  image: alpine\n",
+		},
+		{
+			name: "with template",
+			data: `kind: pipeline
+name: build
+use:
+  template: acme/backend/go-build
+  version: 1.0.0
+  inputs:
+    go_version: "1.22"
+    privileged: true
+---
+kind: pipeline
+name: deploy
+depends_on: [build]
+steps:
+- name: deploy
+  image: alpine
+`,
+			expTemplates: []*types.ExecutionTemplate{
+				{Pipeline: "build", SpacePath: "acme/backend", Identifier: "go-build", Version: "1.0.0"},
+			},
+			check: func(t *testing.T, manifest *yaml.Manifest) {
+				require.Len(t, manifest.Resources, 2)
+				pipeline, ok := manifest.Resources[0].(*yaml.Pipeline)
+				require.True(t, ok)
+				require.Equal(t, "build", pipeline.Name)
+				require.Equal(t, "docker", pipeline.Type)
+				require.Len(t, pipeline.Steps, 2)
+				require.Equal(t, "golang:1.22", pipeline.Steps[0].Image)
+				require.True(t, pipeline.Steps[1].Privileged)
+				require.Equal(t, "deploy", manifest.Resources[1].(*yaml.Pipeline).Name)
+			},
+		},
+		{
+			name: "unknown template",
+			data: `kind: pipeline
+use:
+  template: acme/go-build
+  version: 1.0.0
+`,
+			expErr: true,
+		},
+		{
+			name: "template without space",
+			data: `kind: pipeline
+use:
+  template: go-build
+  version: 1.0.0
+`,
+			expErr: true,
+		},
+		{
+			name: "missing required input",
+			data: `kind: pipeline
+use:
+  template: acme/backend/go-build
+  version: 1.0.0
+`,
+			expErr: true,
+		},
+		{
+			name: "undeclared input",
+			data: `kind: pipeline
+use:
+  template: acme/backend/go-build
+  version: 1.0.0
+  inputs:
+    go_version: "1.22"
+    os: linux
+`,
+			expErr: true,
+		},
+		{
+			name: "input of wrong type",
+			data: `kind: pipeline
+use:
+  template: acme/backend/go-build
+  version: 1.0.0
+  inputs:
+    go_version: 1.22
+`,
+			expErr: true,
+		},
+		{
+			name: "input not in enum",
+			data: `kind: pipeline
+use:
+  template: acme/backend/go-build
+  version: 1.0.0
+  inputs:
+    go_version: "1.20"
+`,
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, templates, err := Expand([]byte(test.data), testResolve)
+			if test.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expTemplates, templates)
+
+			if test.check == nil {
+				require.Equal(t, test.data, string(data))
+				return
+			}
+
+			manifest, err := yaml.ParseBytes(data)
+			require.NoError(t, err)
+			test.check(t, manifest)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		inputs map[string]*types.TemplateInput
+		expErr bool
+	}{
+		{
+			name:   "valid",
+			data:   testTemplateData,
+			inputs: testTemplateVersion().Inputs,
+		},
+		{
+			name:   "undeclared input",
+			data:   testTemplateData,
+			inputs: map[string]*types.TemplateInput{"go_version": {}},
+			expErr: true,
+		},
+		{
+			name: "invalid input name",
+			data: "kind: pipeline\nname: x\n",
+			inputs: map[string]*types.TemplateInput{
+				"go-version": {Type: enum.TemplateInputTypeString},
+			},
+			expErr: true,
+		},
+		{
+			name: "unknown input type",
+			data: "kind: pipeline\nname: x\n",
+			inputs: map[string]*types.TemplateInput{
+				"go_version": {Type: "list"},
+			},
+			expErr: true,
+		},
+		{
+			name: "default of wrong type",
+			data: "kind: pipeline\nname: x\n",
+			inputs: map[string]*types.TemplateInput{
+				"count": {Type: enum.TemplateInputTypeNumber, Default: "two"},
+			},
+			expErr: true,
+		},
+		{
+			name:   "multiple documents",
+			data:   "kind: pipeline\nname: x\n---\nkind: pipeline\nname: y\n",
+			expErr: true,
+		},
+		{
+			name:   "nested template",
+			data:   "kind: pipeline\nuse:\n  template: acme/other\n  version: 1.0.0\n",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.data, test.inputs)
+			if test.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
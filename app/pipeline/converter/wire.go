@@ -17,6 +17,8 @@ package converter
 import (
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
 )
@@ -27,6 +29,12 @@ var WireSet = wire.NewSet(
 )
 
 // ProvideService provides a service which can convert templates.
-func ProvideService(fileService file.Service, publicAccess publicaccess.Service) Service {
-	return newConverter(fileService, publicAccess)
+func ProvideService(
+	fileService file.Service,
+	publicAccess publicaccess.Service,
+	spaceFinder refcache.SpaceFinder,
+	templateStore store.TemplateStore,
+	templateVersionStore store.TemplateVersionStore,
+) Service {
+	return newConverter(fileService, publicAccess, spaceFinder, templateStore, templateVersionStore)
 }
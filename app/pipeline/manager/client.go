@@ -26,6 +26,7 @@ import (
 
 	"github.com/drone/drone-go/drone"
 	"github.com/drone/runner-go/client"
+	"golang.org/x/exp/maps"
 )
 
 type embedded struct {
@@ -101,8 +102,17 @@ func (e *embedded) Detail(ctx context.Context, stage *drone.Stage) (*client.Cont
 		return nil, err
 	}
 
+	build := ConvertToDroneBuild(details.Execution)
+	if len(details.Stage.Envs) > 0 {
+		// stages can define additional environment variables (e.g. matrix values) on top of the execution params.
+		params := make(map[string]string, len(build.Params)+len(details.Stage.Envs))
+		maps.Copy(params, build.Params)
+		maps.Copy(params, details.Stage.Envs)
+		build.Params = params
+	}
+
 	return &client.Context{
-		Build:   ConvertToDroneBuild(details.Execution),
+		Build:   build,
 		Repo:    ConvertToDroneRepo(details.Repo, details.RepoIsPublic),
 		Stage:   ConvertToDroneStage(details.Stage),
 		Secrets: ConvertToDroneSecrets(details.Secrets),
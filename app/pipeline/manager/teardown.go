@@ -115,6 +115,13 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	err = t.skipMatrixSiblings(ctx, stage, stages)
+	if err != nil {
+		log.Error().Err(err).
+			Msg("manager: cannot skip matrix stages")
+		return err
+	}
+
 	err = t.cancelDownstream(ctx, stages)
 	if err != nil {
 		log.Error().Err(err).
@@ -258,6 +265,62 @@ func (t *teardown) cancelDownstream(
 	return errs
 }
 
+// skipMatrixSiblings is a helper function that skips the pending
+// stages of the same matrix once a stage of a fail fast matrix failed.
+// Stages of the matrix which are already running are not interrupted.
+func (t *teardown) skipMatrixSiblings(
+	ctx context.Context,
+	stage *types.Stage,
+	stages []*types.Stage,
+) error {
+	var failed *types.Stage
+	for _, s := range stages {
+		if s.ID == stage.ID {
+			failed = s
+			break
+		}
+	}
+	if failed == nil || !failed.FailFast || !failed.Status.IsFailed() {
+		return nil
+	}
+
+	var errs error
+	for _, s := range stages {
+		if s.ID == failed.ID || s.Name != failed.Name {
+			continue
+		}
+		if s.Status != enum.CIStatusPending {
+			continue
+		}
+
+		log := log.With().
+			Int64("stage.id", s.ID).
+			Str("stage.name", s.Name).
+			Int64("failed.stage.id", failed.ID).
+			Logger()
+
+		log.Debug().Msg("manager: skipping matrix stage")
+
+		s.Status = enum.CIStatusSkipped
+		s.Started = time.Now().UnixMilli()
+		s.Stopped = time.Now().UnixMilli()
+		err := t.Stages.Update(noContext, s) //nolint:contextcheck
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			rErr := t.resync(ctx, s)
+			if rErr != nil {
+				log.Warn().Err(rErr).Msg("failed to resync after version conflict")
+			}
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).
+				Msg("manager: cannot update stage status")
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
 func isexecutionComplete(stages []*types.Stage) bool {
 	for _, stage := range stages {
 		if stage.Status == enum.CIStatusPending ||
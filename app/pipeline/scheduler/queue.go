@@ -150,6 +150,13 @@ func (q *queue) signal(ctx context.Context) error {
 			continue
 		}
 
+		// if the stage is part of a matrix with a limit of
+		// parallel stages, we need to make sure the limit is
+		// not exceeded before proceeding.
+		if !withinMaxParallel(item, items) {
+			continue
+		}
+
 		// if the system defines concurrency limits
 		// per repository we need to make sure those limits
 		// are not exceeded before proceeding.
@@ -269,6 +276,31 @@ func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	return count < stage.Limit
 }
 
+// withinMaxParallel returns true if the stage can be started without exceeding
+// the max parallel limit of the matrix it was expanded from.
+func withinMaxParallel(stage *types.Stage, siblings []*types.Stage) bool {
+	if stage.MaxParallel == 0 {
+		return true
+	}
+	count := 0
+	for _, sibling := range siblings {
+		if sibling.ExecutionID != stage.ExecutionID {
+			continue
+		}
+		if sibling.ID == stage.ID {
+			continue
+		}
+		if sibling.Name != stage.Name {
+			continue
+		}
+		if sibling.ID < stage.ID ||
+			sibling.Status == enum.CIStatusRunning {
+			count++
+		}
+	}
+	return count < stage.MaxParallel
+}
+
 func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool {
 	// if no throttle limit is defined (default) then
 	// return false to indicate no throttling is needed.
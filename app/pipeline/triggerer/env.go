@@ -16,6 +16,8 @@ package triggerer
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types"
@@ -43,3 +45,22 @@ func Envs(
 		"DRONE_BUILD_LINK": urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, pipeline.Seq),
 	}
 }
+
+// matrixEnvs returns the environment variables of a stage expanded from a pipeline matrix.
+// Every matrix axis is available as a variable, DRONE_STAGE_MATRIX contains all values (e.g. "GO=1.22,OS=linux").
+func matrixEnvs(matrix map[string]string) map[string]string {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	envs := make(map[string]string, len(matrix)+1)
+	pairs := make([]string, 0, len(matrix))
+	for axis, value := range matrix {
+		envs[axis] = value
+		pairs = append(pairs, axis+"="+value)
+	}
+	sort.Strings(pairs)
+	envs["DRONE_STAGE_MATRIX"] = strings.Join(pairs, ",")
+
+	return envs
+}
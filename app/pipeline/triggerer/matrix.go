@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-yaml/yaml"
+	"golang.org/x/exp/maps"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// matrixMaxStages is the maximum number of stages a single pipeline matrix can be expanded into.
+const matrixMaxStages = 256
+
+var matrixAxisRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// strategy is the execution strategy of a drone pipeline, e.g.
+//
+//	strategy:
+//	  fail_fast: true
+//	  max_parallel: 2
+//	  matrix:
+//	    GO_VERSION: ["1.21", "1.22"]
+//	    IMAGE: [alpine, bookworm]
+//
+// The drone yaml parser ignores unknown fields, hence the strategy is parsed separately.
+type strategy struct {
+	FailFast    bool                `yaml:"fail_fast"`
+	MaxParallel int                 `yaml:"max_parallel"`
+	Matrix      map[string][]string `yaml:"matrix"`
+}
+
+// parseStrategies returns the strategies of the pipelines in the drone yaml, keyed by pipeline name.
+// Pipelines without a matrix aren't part of the result.
+func parseStrategies(data []byte) (map[string]*strategy, error) {
+	resources, err := yaml.ParseRawBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse yaml: %w", err)
+	}
+
+	strategies := map[string]*strategy{}
+	for _, resource := range resources {
+		if resource.Kind != yaml.KindPipeline {
+			continue
+		}
+
+		var document struct {
+			Name     string    `yaml:"name"`
+			Strategy *strategy `yaml:"strategy"`
+		}
+		if err = yamlv3.Unmarshal(resource.Data, &document); err != nil {
+			return nil, fmt.Errorf("could not parse pipeline strategy: %w", err)
+		}
+
+		if document.Strategy == nil || len(document.Strategy.Matrix) == 0 {
+			continue
+		}
+
+		if err = document.Strategy.validate(); err != nil {
+			return nil, fmt.Errorf("invalid strategy of pipeline %q: %w", document.Name, err)
+		}
+
+		name := document.Name
+		if name == "" {
+			name = "default"
+		}
+		strategies[name] = document.Strategy
+	}
+
+	return strategies, nil
+}
+
+func (s *strategy) validate() error {
+	if s.MaxParallel < 0 {
+		return fmt.Errorf("max_parallel can't be negative")
+	}
+
+	total := 1
+	for axis, values := range s.Matrix {
+		if !matrixAxisRegex.MatchString(axis) {
+			return fmt.Errorf("matrix axis %q is not a valid environment variable name", axis)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("matrix axis %q doesn't have any values", axis)
+		}
+
+		total *= len(values)
+		if total > matrixMaxStages {
+			return fmt.Errorf("matrix can be expanded into at most %d stages", matrixMaxStages)
+		}
+	}
+
+	return nil
+}
+
+// expand returns all combinations of the matrix values.
+// The axes are expanded in alphabetical order to get a stable order of the stages.
+func (s *strategy) expand() []map[string]string {
+	axes := make([]string, 0, len(s.Matrix))
+	for axis := range s.Matrix {
+		axes = append(axes, axis)
+	}
+	sort.Strings(axes)
+
+	combinations := []map[string]string{{}}
+	for _, axis := range axes {
+		next := make([]map[string]string, 0, len(combinations)*len(s.Matrix[axis]))
+		for _, combination := range combinations {
+			for _, value := range s.Matrix[axis] {
+				expanded := make(map[string]string, len(combination)+1)
+				maps.Copy(expanded, combination)
+				expanded[axis] = value
+				next = append(next, expanded)
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+// expandStage expands the stage into one stage per matrix combination of the strategy.
+// All expanded stages keep the name of the pipeline, so stages depending on it wait for all of them.
+func expandStage(stage *types.Stage, s *strategy) []*types.Stage {
+	if s == nil {
+		return []*types.Stage{stage}
+	}
+
+	combinations := s.expand()
+	stages := make([]*types.Stage, len(combinations))
+	for i, matrix := range combinations {
+		expanded := *stage
+		expanded.Matrix = matrix
+		expanded.Envs = matrixEnvs(matrix)
+		expanded.FailFast = s.FailFast
+		expanded.MaxParallel = s.MaxParallel
+		stages[i] = &expanded
+	}
+
+	return stages
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestParseStrategies(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]*strategy
+		wantErr bool
+	}{
+		{
+			name: "no-matrix",
+			data: "kind: pipeline\nname: build\nsteps: []\n",
+			want: map[string]*strategy{},
+		},
+		{
+			name: "matrix",
+			data: "kind: pipeline\nname: test\nstrategy:\n  fail_fast: true\n  max_parallel: 2\n" +
+				"  matrix:\n    GO: [1.20, 1.21]\n    OS: [alpine]\n",
+			want: map[string]*strategy{
+				"test": {
+					FailFast:    true,
+					MaxParallel: 2,
+					Matrix:      map[string][]string{"GO": {"1.20", "1.21"}, "OS": {"alpine"}},
+				},
+			},
+		},
+		{
+			name: "multiple-documents",
+			data: "kind: pipeline\nname: build\n---\nkind: pipeline\nstrategy:\n  matrix:\n    GO: [\"1.22\"]\n",
+			want: map[string]*strategy{
+				"default": {Matrix: map[string][]string{"GO": {"1.22"}}},
+			},
+		},
+		{
+			name:    "invalid-axis",
+			data:    "kind: pipeline\nname: test\nstrategy:\n  matrix:\n    go-version: [\"1.22\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty-axis",
+			data:    "kind: pipeline\nname: test\nstrategy:\n  matrix:\n    GO: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "negative-max-parallel",
+			data:    "kind: pipeline\nname: test\nstrategy:\n  max_parallel: -1\n  matrix:\n    GO: [\"1.22\"]\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseStrategies([]byte(test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestExpandStage(t *testing.T) {
+	stage := &types.Stage{Name: "test", OS: "linux"}
+	s := &strategy{
+		FailFast:    true,
+		MaxParallel: 1,
+		Matrix: map[string][]string{
+			"OS": {"alpine", "bookworm"},
+			"GO": {"1.21", "1.22"},
+		},
+	}
+
+	stages := expandStage(stage, s)
+
+	want := []map[string]string{
+		{"GO": "1.21", "OS": "alpine"},
+		{"GO": "1.21", "OS": "bookworm"},
+		{"GO": "1.22", "OS": "alpine"},
+		{"GO": "1.22", "OS": "bookworm"},
+	}
+	if len(stages) != len(want) {
+		t.Fatalf("want %d stages, got %d", len(want), len(stages))
+	}
+	for i, st := range stages {
+		if st.Name != "test" || !st.FailFast || st.MaxParallel != 1 {
+			t.Errorf("stage %d: unexpected stage %+v", i, st)
+		}
+		if !reflect.DeepEqual(st.Matrix, want[i]) {
+			t.Errorf("stage %d: want matrix %v, got %v", i, want[i], st.Matrix)
+		}
+		if st.Envs["GO"] != want[i]["GO"] || st.Envs["OS"] != want[i]["OS"] {
+			t.Errorf("stage %d: matrix values missing from envs %v", i, st.Envs)
+		}
+	}
+	if got := stages[1].Envs["DRONE_STAGE_MATRIX"]; got != "GO=1.21,OS=bookworm" {
+		t.Errorf("unexpected DRONE_STAGE_MATRIX %q", got)
+	}
+
+	if got := expandStage(stage, nil); len(got) != 1 || got[0] != stage {
+		t.Errorf("stage without strategy must not be expanded")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// changedFiles lazily loads the list of files changed by the push or the pull request of a hook.
+type changedFiles struct {
+	git  git.Interface
+	repo *types.Repository
+	hook *Hook
+
+	loaded bool
+	known  bool
+	files  []string
+}
+
+func newChangedFiles(gitInterface git.Interface, repo *types.Repository, hook *Hook) *changedFiles {
+	return &changedFiles{
+		git:  gitInterface,
+		repo: repo,
+		hook: hook,
+	}
+}
+
+// get returns the list of changed files. The second return value is false if the changed files
+// can't be determined for the hook (e.g. for manual and cron executions or for new branches),
+// in which case path filters should not be applied.
+func (c *changedFiles) get(ctx context.Context) ([]string, bool) {
+	if c.loaded {
+		return c.files, c.known
+	}
+
+	c.loaded = true
+
+	files, known, err := c.load(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("trigger: failed to get changed files, ignoring path filters")
+		return nil, false
+	}
+
+	c.files = files
+	c.known = known
+
+	return c.files, c.known
+}
+
+func (c *changedFiles) load(ctx context.Context) ([]string, bool, error) {
+	params := &git.DiffParams{
+		ReadParams: git.CreateReadParams(c.repo),
+		HeadRef:    c.hook.After,
+	}
+
+	switch c.hook.Event() {
+	case enum.TriggerEventPush:
+		before, err := sha.NewOrEmpty(c.hook.Before)
+		if err != nil || before.IsEmpty() || before.IsNil() {
+			return nil, false, nil //nolint:nilerr // new branch, nothing to compare with
+		}
+
+		params.BaseRef = before.String()
+	case enum.TriggerEventPullRequest:
+		if c.hook.Target == "" {
+			return nil, false, nil
+		}
+
+		params.BaseRef = api.BranchPrefix + c.hook.Target
+		params.MergeBase = true
+	case enum.TriggerEventCron, enum.TriggerEventManual, enum.TriggerEventTag:
+		return nil, false, nil
+	}
+
+	if params.HeadRef == "" || params.BaseRef == "" {
+		return nil, false, nil
+	}
+
+	out, err := c.git.DiffFileNames(ctx, params)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	return out.Files, true, nil
+}
+
+// skip returns true if the changed files are known and none of them matches the path patterns.
+func (c *changedFiles) skip(ctx context.Context, include, exclude []string) bool {
+	if len(include) == 0 && len(exclude) == 0 {
+		return false
+	}
+
+	files, ok := c.get(ctx)
+	if !ok {
+		return false
+	}
+
+	return skipPaths(include, exclude, files)
+}
+
+// skipPaths returns true if none of the changed files matches the path patterns.
+func skipPaths(include, exclude []string, files []string) bool {
+	if len(include) == 0 && len(exclude) == 0 {
+		return false
+	}
+
+	for _, file := range files {
+		if matchPath(include, exclude, file) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchPath(include, exclude []string, file string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return false
+		}
+	}
+
+	if len(include) == 0 {
+		return true
+	}
+
+	for _, pattern := range include {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return true
+		}
+	}
+
+	return false
+}
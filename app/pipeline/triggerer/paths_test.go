@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import "testing"
+
+func TestSkipPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		files   []string
+		want    bool
+	}{
+		{
+			name:  "no-patterns",
+			files: []string{"a.go"},
+			want:  false,
+		},
+		{
+			name:    "include-match",
+			include: []string{"services/api/**"},
+			files:   []string{"README.md", "services/api/cmd/main.go"},
+			want:    false,
+		},
+		{
+			name:    "include-no-match",
+			include: []string{"services/api/**"},
+			files:   []string{"README.md", "services/web/index.ts"},
+			want:    true,
+		},
+		{
+			name:    "exclude-all",
+			exclude: []string{"**/*.md"},
+			files:   []string{"README.md", "docs/intro.md"},
+			want:    true,
+		},
+		{
+			name:    "exclude-some",
+			exclude: []string{"**/*.md"},
+			files:   []string{"README.md", "main.go"},
+			want:    false,
+		},
+		{
+			name:    "include-and-exclude",
+			include: []string{"services/api/**"},
+			exclude: []string{"**/*_test.go"},
+			files:   []string{"services/api/handler_test.go"},
+			want:    true,
+		},
+		{
+			name:    "no-changes",
+			include: []string{"**"},
+			files:   nil,
+			want:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := skipPaths(test.include, test.exclude, test.files); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -71,6 +72,15 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+	Paths        types.TriggerPaths `json:"paths"`
+}
+
+// Event returns the trigger event of the hook.
+func (h *Hook) Event() enum.TriggerEvent {
+	if h.Trigger == enum.TriggerCron {
+		return enum.TriggerEventCron
+	}
+	return h.Action.GetTriggerEvent()
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -94,6 +104,7 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	git              git.Interface
 }
 
 func New(
@@ -110,6 +121,7 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	git git.Interface,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -125,6 +137,7 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		git:              git,
 	}
 }
 
@@ -150,7 +163,7 @@ func (t *triggerer) Trigger(
 		}
 	}()
 
-	event := base.Action.GetTriggerEvent()
+	event := base.Event()
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -163,6 +176,13 @@ func (t *triggerer) Trigger(
 		return nil, fmt.Errorf("could not check if repo is public: %w", err)
 	}
 
+	changes := newChangedFiles(t.git, repo, base)
+	if changes.skip(ctx, base.Paths.Include, base.Paths.Exclude) {
+		log.Info().Msg("trigger: skipping execution, changed files do not match trigger paths")
+		return t.createExecutionSkipped(ctx, pipeline, base,
+			"Skipped: none of the changed files match the paths of the trigger.")
+	}
+
 	file, err := t.fileService.Get(ctx, repo, pipeline.ConfigPath, base.After)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not find yaml")
@@ -233,7 +253,14 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		strategies, err := parseStrategies(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse pipeline strategy")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
 		var matched []*yaml.Pipeline
+		var skippedByPaths bool
 		var dag = dag.New()
 		for _, document := range manifest.Resources {
 			pipeline, ok := document.(*yaml.Pipeline)
@@ -264,6 +291,9 @@ func (t *triggerer) Trigger(
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match repo")
 			case skipCron(pipeline, base.Cron):
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match cron job")
+			case changes.skip(ctx, pipeline.Trigger.Paths.Include, pipeline.Trigger.Paths.Exclude):
+				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match changed paths")
+				skippedByPaths = true
 			default:
 				matched = append(matched, pipeline)
 				node.Skip = false
@@ -274,13 +304,19 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, "Error: Dependency cycle detected in Pipeline")
 		}
 
+		if len(matched) == 0 && skippedByPaths {
+			log.Info().Msg("trigger: skipping execution, no pipelines match changed paths")
+			return t.createExecutionSkipped(ctx, pipeline, base,
+				"Skipped: none of the changed files match the paths of the pipeline.")
+		}
+
 		if len(matched) == 0 {
 			log.Info().Msg("trigger: skipping execution, no matching pipelines")
 			//nolint:nilnil // on purpose
 			return nil, nil
 		}
 
-		for i, match := range matched {
+		for _, match := range matched {
 			onSuccess := match.Trigger.Status.Match(string(enum.CIStatusSuccess))
 			onFailure := match.Trigger.Status.Match(string(enum.CIStatusFailure))
 			if len(match.Trigger.Status.Include)+len(match.Trigger.Status.Exclude) == 0 {
@@ -289,7 +325,6 @@ func (t *triggerer) Trigger(
 
 			stage := &types.Stage{
 				RepoID:    repo.ID,
-				Number:    int64(i + 1),
 				Name:      match.Name,
 				Kind:      match.Kind,
 				Type:      match.Type,
@@ -322,10 +357,12 @@ func (t *triggerer) Trigger(
 			if len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
-			stages = append(stages, stage)
+			stages = append(stages, expandStage(stage, strategies[stage.Name])...)
 		}
 
-		for _, stage := range stages {
+		for i, stage := range stages {
+			stage.Number = int64(i + 1)
+
 			// here we re-work the dependencies for the stage to
 			// account for the fact that some steps may be skipped
 			// and may otherwise break the dependency chain.
@@ -536,6 +573,27 @@ func (t *triggerer) createExecutionWithError(
 	pipeline *types.Pipeline,
 	base *Hook,
 	message string,
+) (*types.Execution, error) {
+	return t.createFinishedExecution(ctx, pipeline, base, enum.CIStatusError, message)
+}
+
+// createExecutionSkipped creates a skipped execution with the reason why it was skipped.
+func (t *triggerer) createExecutionSkipped(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	reason string,
+) (*types.Execution, error) {
+	return t.createFinishedExecution(ctx, pipeline, base, enum.CIStatusSkipped, reason)
+}
+
+// createFinishedExecution creates an execution without stages which is already finished.
+func (t *triggerer) createFinishedExecution(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	status enum.CIStatus,
+	message string,
 ) (*types.Execution, error) {
 	log := log.With().
 		Int64("pipeline.id", pipeline.ID).
@@ -555,9 +613,9 @@ func (t *triggerer) createExecutionWithError(
 		PipelineID:   pipeline.ID,
 		Number:       pipeline.Seq,
 		Parent:       base.Parent,
-		Status:       enum.CIStatusError,
+		Status:       status,
 		Error:        message,
-		Event:        base.Action.GetTriggerEvent(),
+		Event:        base.Event(),
 		Action:       base.Action,
 		Link:         base.Link,
 		Title:        base.Title,
@@ -575,6 +633,7 @@ func (t *triggerer) createExecutionWithError(
 		AuthorAvatar: base.AuthorAvatar,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
+		Cron:         base.Cron,
 		Created:      now,
 		Updated:      now,
 		Started:      now,
@@ -19,12 +19,14 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/artifact"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
@@ -45,12 +47,14 @@ import (
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handlerartifact "github.com/harness/gitness/app/api/handler/artifact"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
 	handlerinfraProvider "github.com/harness/gitness/app/api/handler/infraprovider"
+	handlerissue "github.com/harness/gitness/app/api/handler/issue"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
@@ -109,6 +113,7 @@ func NewAPIHandler(
 	repoSettingsCtrl *reposettings.Controller,
 	executionCtrl *execution.Controller,
 	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller,
 	spaceCtrl *space.Controller,
 	pipelineCtrl *pipeline.Controller,
 	secretCtrl *secret.Controller,
@@ -117,6 +122,7 @@ func NewAPIHandler(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *controllergithook.Controller,
 	git git.Interface,
@@ -162,12 +168,20 @@ func NewAPIHandler(
 			r.Use(middlewareauthn.Attempt(authenticator))
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
-				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
+				artifactCtrl, pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl, issueCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
 				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender)
 		})
 	})
 
+	if config.GitHubAPI.Enabled {
+		r.Route("/v3", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(authenticator))
+
+			setupGitHubAPI(r, repoCtrl, pullreqCtrl, checkCtrl, webhookCtrl)
+		})
+	}
+
 	// wrap router in terminatedPath encoder.
 	return encode.TerminatedPathBefore(terminatedPathPrefixesAPI, r)
 }
@@ -194,6 +208,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	executionCtrl *execution.Controller,
 	triggerCtrl *trigger.Controller,
 	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller,
 	pipelineCtrl *pipeline.Controller,
 	connectorCtrl *connector.Controller,
 	templateCtrl *template.Controller,
@@ -201,6 +216,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	secretCtrl *secret.Controller,
 	spaceCtrl *space.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *controllergithook.Controller,
 	git git.Interface,
@@ -219,11 +235,11 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupAccountWithAuth(r, userCtrl, config)
 	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
+		logCtrl, artifactCtrl, pullreqCtrl, issueCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, appCtx, userCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
@@ -289,6 +305,13 @@ func setupSpaces(
 			})
 
 			SetupSpaceLabels(r, spaceCtrl)
+			SetupSpaceNotificationChannels(r, spaceCtrl)
+
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFindWatch(spaceCtrl))
+				r.Put("/", handlerspace.HandleWatch(spaceCtrl))
+				r.Delete("/", handlerspace.HandleUnwatch(spaceCtrl))
+			})
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
 
@@ -300,6 +323,18 @@ func setupSpaces(
 	})
 }
 
+func SetupSpaceNotificationChannels(r chi.Router, spaceCtrl *space.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlerspace.HandleCreateNotificationChannel(spaceCtrl))
+		r.Get("/", handlerspace.HandleListNotificationChannels(spaceCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Patch("/", handlerspace.HandleUpdateNotificationChannel(spaceCtrl))
+			r.Delete("/", handlerspace.HandleDeleteNotificationChannel(spaceCtrl))
+		})
+	})
+}
+
 func SetupSpaceLabels(r chi.Router, spaceCtrl *space.Controller) {
 	r.Route("/labels", func(r chi.Router) {
 		r.Post("/", handlerspace.HandleDefineLabel(spaceCtrl))
@@ -365,7 +400,9 @@ func setupRepos(r chi.Router,
 	executionCtrl *execution.Controller,
 	triggerCtrl *trigger.Controller,
 	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
@@ -476,9 +513,11 @@ func setupRepos(r chi.Router,
 
 			SetupPullReq(r, pullreqCtrl)
 
+			SetupIssues(r, issueCtrl)
+
 			SetupWebhookRepo(r, webhookCtrl)
 
-			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
+			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, artifactCtrl)
 
 			SetupChecks(r, checkCtrl)
 
@@ -487,6 +526,25 @@ func setupRepos(r chi.Router,
 			SetupRulesRepo(r, repoCtrl)
 
 			SetupRepoLabels(r, repoCtrl)
+			SetupRepoNotificationChannels(r, repoCtrl)
+
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleFindWatch(repoCtrl))
+				r.Put("/", handlerrepo.HandleWatch(repoCtrl))
+				r.Delete("/", handlerrepo.HandleUnwatch(repoCtrl))
+			})
+		})
+	})
+}
+
+func SetupRepoNotificationChannels(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleCreateNotificationChannel(repoCtrl))
+		r.Get("/", handlerrepo.HandleListNotificationChannels(repoCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Patch("/", handlerrepo.HandleUpdateNotificationChannel(repoCtrl))
+			r.Delete("/", handlerrepo.HandleDeleteNotificationChannel(repoCtrl))
 		})
 	})
 }
@@ -527,7 +585,8 @@ func setupPipelines(
 	pipelineCtrl *pipeline.Controller,
 	executionCtrl *execution.Controller,
 	triggerCtrl *trigger.Controller,
-	logCtrl *logs.Controller) {
+	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller) {
 	r.Route("/pipelines", func(r chi.Router) {
 		r.Get("/", handlerrepo.HandleListPipelines(repoCtrl))
 		// Create takes path and parentId via body, not uri
@@ -537,8 +596,12 @@ func setupPipelines(
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
-			setupExecutions(r, executionCtrl, logCtrl)
+			setupExecutions(r, executionCtrl, logCtrl, artifactCtrl)
 			setupTriggers(r, triggerCtrl)
+			r.Route(fmt.Sprintf("/caches/{%s}", request.PathParamCacheKey), func(r chi.Router) {
+				r.Get("/", handlerartifact.HandleRestoreCache(artifactCtrl))
+				r.Put("/", handlerartifact.HandleSaveCache(artifactCtrl))
+			})
 		})
 	})
 }
@@ -571,6 +634,13 @@ func setupTemplates(
 				r.Get("/", handlertemplate.HandleFind(templateCtrl))
 				r.Patch("/", handlertemplate.HandleUpdate(templateCtrl))
 				r.Delete("/", handlertemplate.HandleDelete(templateCtrl))
+
+				r.Route("/versions", func(r chi.Router) {
+					r.Get("/", handlertemplate.HandleListVersions(templateCtrl))
+					r.Post("/", handlertemplate.HandleCreateVersion(templateCtrl))
+					r.Get(fmt.Sprintf("/{%s}", request.PathParamTemplateVersion),
+						handlertemplate.HandleFindVersion(templateCtrl))
+				})
 			})
 	})
 }
@@ -597,6 +667,7 @@ func setupExecutions(
 	r chi.Router,
 	executionCtrl *execution.Controller,
 	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller,
 ) {
 	r.Route("/executions", func(r chi.Router) {
 		r.Get("/", handlerexecution.HandleList(executionCtrl))
@@ -616,6 +687,13 @@ func setupExecutions(
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleTail(logCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerartifact.HandleList(artifactCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamArtifactName), func(r chi.Router) {
+					r.Get("/", handlerartifact.HandleDownload(artifactCtrl))
+					r.Put("/", handlerartifact.HandleUpload(artifactCtrl))
+				})
+			})
 		})
 	})
 }
@@ -658,6 +736,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
 		)
 		r.Get("/candidates", handlerpullreq.HandlePRBranchCandidates(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
@@ -693,6 +772,11 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Delete("/merge-queue", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
 			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
@@ -709,6 +793,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Delete("/*", handlerpullreq.HandleFileViewDelete(pullreqCtrl))
 			})
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
+			r.Get("/stack", handlerpullreq.HandleStack(pullreqCtrl))
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
@@ -728,6 +813,51 @@ func setupPullReqLabels(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	})
 }
 
+func SetupIssues(r chi.Router, issueCtrl *issue.Controller) {
+	r.Route("/issues", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleUpdate(issueCtrl))
+			r.Post("/state", handlerissue.HandleState(issueCtrl))
+			r.Get("/activities", handlerissue.HandleListActivities(issueCtrl))
+			r.Route("/comments", func(r chi.Router) {
+				r.Post("/", handlerissue.HandleCommentCreate(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueCommentID), func(r chi.Router) {
+					r.Patch("/", handlerissue.HandleCommentUpdate(issueCtrl))
+					r.Delete("/", handlerissue.HandleCommentDelete(issueCtrl))
+				})
+			})
+			r.Route("/assignees", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssigneeAdd(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAssigneeID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleAssigneeDelete(issueCtrl))
+				})
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssignLabel(issueCtrl))
+				r.Get("/", handlerissue.HandleListLabels(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleUnassignLabel(issueCtrl))
+				})
+			})
+		})
+	})
+
+	r.Route("/milestones", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleMilestoneCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleMilestoneList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleMilestoneFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleMilestoneUpdate(issueCtrl))
+			r.Delete("/", handlerissue.HandleMilestoneDelete(issueCtrl))
+		})
+	})
+}
+
 func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreateRepo(webhookCtrl))
@@ -773,13 +903,15 @@ func SetupRulesRepo(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupUser(r chi.Router, appCtx context.Context, userCtrl *user.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/watches", handleruser.HandleListWatches(userCtrl))
+		r.Get("/events", handleruser.HandleEvents(appCtx, userCtrl))
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -818,6 +950,19 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamResourceID),
 				handleruser.HandleDeleteFavorite(userCtrl))
 		})
+
+		// Notification preferences
+		r.Route("/notification-preferences", func(r chi.Router) {
+			r.Get("/", handleruser.HandleFindNotificationPreferences(userCtrl))
+			r.Patch("/", handleruser.HandleUpdateNotificationPreferences(userCtrl))
+
+			r.Route("/scopes", func(r chi.Router) {
+				r.Get("/", handleruser.HandleListNotificationScopePreferences(userCtrl))
+				r.Put("/", handleruser.HandleSetNotificationScopePreference(userCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamResourceID),
+					handleruser.HandleDeleteNotificationScopePreference(userCtrl))
+			})
+		})
 	})
 }
 
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/webhook"
+	handlergithub "github.com/harness/gitness/app/api/handler/github"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupGitHubAPI sets up the GitHub v3 compatible REST API.
+// It maps the most commonly used GitHub endpoints onto the existing controllers,
+// which allows off-the-shelf GitHub clients to work against the repositories.
+func setupGitHubAPI(
+	r chi.Router,
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	checkCtrl *check.Controller,
+	webhookCtrl *webhook.Controller,
+) {
+	r.Route(fmt.Sprintf("/repos/{%s}/{%s}", handlergithub.PathParamOwner, handlergithub.PathParamRepo),
+		func(r chi.Router) {
+			r.Get("/", handlergithub.HandleGetRepo(repoCtrl))
+
+			r.Get("/branches", handlergithub.HandleListBranches(repoCtrl))
+			r.Get("/branches/*", handlergithub.HandleGetBranch(repoCtrl))
+
+			r.Get("/contents", handlergithub.HandleGetContent(repoCtrl))
+			r.Get("/contents/*", handlergithub.HandleGetContent(repoCtrl))
+			r.Put("/contents/*", handlergithub.HandlePutContent(repoCtrl))
+			r.Delete("/contents/*", handlergithub.HandleDeleteContent(repoCtrl))
+
+			setupGitHubPulls(r, pullreqCtrl)
+			setupGitHubChecks(r, repoCtrl, checkCtrl)
+			setupGitHubHooks(r, webhookCtrl)
+		})
+}
+
+func setupGitHubPulls(r chi.Router, pullreqCtrl *pullreq.Controller) {
+	r.Route("/pulls", func(r chi.Router) {
+		r.Get("/", handlergithub.HandleListPulls(pullreqCtrl))
+		r.Post("/", handlergithub.HandleCreatePull(pullreqCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", handlergithub.PathParamNumber), func(r chi.Router) {
+			r.Get("/", handlergithub.HandleGetPull(pullreqCtrl))
+			r.Patch("/", handlergithub.HandleUpdatePull(pullreqCtrl))
+			r.Get("/merge", handlergithub.HandleCheckPullMerged(pullreqCtrl))
+			r.Put("/merge", handlergithub.HandleMergePull(pullreqCtrl))
+			r.Get("/reviews", handlergithub.HandleListReviews(pullreqCtrl))
+			r.Post("/reviews", handlergithub.HandleCreateReview(pullreqCtrl))
+		})
+	})
+
+	// pull requests are issues in GitHub, only the issue comments are supported.
+	r.Route(fmt.Sprintf("/issues/{%s}", handlergithub.PathParamNumber), func(r chi.Router) {
+		r.Get("/comments", handlergithub.HandleListIssueComments(pullreqCtrl))
+		r.Post("/comments", handlergithub.HandleCreateIssueComment(pullreqCtrl))
+	})
+}
+
+func setupGitHubChecks(r chi.Router, repoCtrl *repo.Controller, checkCtrl *check.Controller) {
+	r.Post(fmt.Sprintf("/statuses/{%s}", handlergithub.PathParamSHA), handlergithub.HandleCreateStatus(checkCtrl))
+	r.Post("/check-runs", handlergithub.HandleCreateCheckRun(checkCtrl))
+
+	r.Route(fmt.Sprintf("/commits/{%s}", handlergithub.PathParamRef), func(r chi.Router) {
+		r.Get("/statuses", handlergithub.HandleListStatuses(repoCtrl, checkCtrl))
+		r.Get("/status", handlergithub.HandleGetCombinedStatus(repoCtrl, checkCtrl))
+		r.Get("/check-runs", handlergithub.HandleListCheckRuns(repoCtrl, checkCtrl))
+	})
+}
+
+func setupGitHubHooks(r chi.Router, webhookCtrl *webhook.Controller) {
+	r.Route("/hooks", func(r chi.Router) {
+		r.Get("/", handlergithub.HandleListHooks(webhookCtrl))
+		r.Post("/", handlergithub.HandleCreateHook(webhookCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", handlergithub.PathParamHookID), func(r chi.Router) {
+			r.Get("/", handlergithub.HandleGetHook(webhookCtrl))
+			r.Patch("/", handlergithub.HandleUpdateHook(webhookCtrl))
+			r.Delete("/", handlergithub.HandleDeleteHook(webhookCtrl))
+		})
+	})
+}
@@ -18,12 +18,14 @@ import (
 	"context"
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller/artifact"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
@@ -85,6 +87,7 @@ func ProvideRouter(
 	repoSettingsCtrl *reposettings.Controller,
 	executionCtrl *execution.Controller,
 	logCtrl *logs.Controller,
+	artifactCtrl *artifact.Controller,
 	spaceCtrl *space.Controller,
 	pipelineCtrl *pipeline.Controller,
 	secretCtrl *secret.Controller,
@@ -93,6 +96,7 @@ func ProvideRouter(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *githook.Controller,
 	git git.Interface,
@@ -129,10 +133,10 @@ func ProvideRouter(
 
 	apiHandler := NewAPIHandler(
 		appCtx, config,
-		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
-		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender)
+		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, artifactCtrl, spaceCtrl,
+		pipelineCtrl, secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, issueCtrl,
+		webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl,
+		searchCtrl, infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender)
 	routers[2] = NewAPIRouter(apiHandler)
 
 	sec := NewSecure(config)
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleAutoMergeEnabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleTargetBranchChanged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+// handleBranchUpdated handles new pushes to the source branch. If the protection rules require it,
+// the auto-merge is cancelled, otherwise the pull request is merged if it satisfies the rules.
+func (s *Service) handleBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	am, pr, session, repo, err := s.load(ctx, event.Payload.PullReqID)
+	if err != nil || am == nil {
+		return err
+	}
+
+	_, out, err := s.verifyRules(ctx, session, repo, pr, am)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && out.CancelsAutoMergeOnPush {
+		return s.disable(ctx, pr.ID, event.Payload.PrincipalID, enum.AutoMergeDisableReasonBranchUpdated)
+	}
+
+	return s.tryMerge(ctx, pr.ID)
+}
+
+func (s *Service) handleClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.disable(ctx, event.Payload.PullReqID, event.Payload.PrincipalID, enum.AutoMergeDisableReasonClosed)
+}
+
+// handleMerged removes the auto-merge settings of pull requests that got merged, by auto-merge or otherwise.
+func (s *Service) handleMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	if err := s.autoMergeStore.Delete(ctx, event.Payload.PullReqID); err != nil {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// handleCheckReported handles status check reports and attempts to merge
+// all pull requests with auto-merge enabled whose source commit is the reported commit.
+func (s *Service) handleCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	autoMerges, err := s.autoMergeStore.ListBySourceSHA(ctx, event.Payload.RepoID, event.Payload.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request auto-merges: %w", err)
+	}
+
+	for _, am := range autoMerges {
+		if err := s.tryMerge(ctx, am.PullReqID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
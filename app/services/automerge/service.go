@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service merges pull requests with enabled auto-merge once all the merge requirements are satisfied.
+// The merge is attempted every time an event occurs that could affect the outcome of the protection rules:
+// a review is submitted, a status check is reported, a comment is resolved or the source branch is updated.
+// The merge is performed on behalf of the user that enabled the auto-merge.
+type Service struct {
+	pullreqCtrl       *controllerpullreq.Controller
+	autoMergeStore    store.AutoMergeStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	principalStore    store.PrincipalStore
+	repoFinder        refcache.RepoFinder
+	pullreqEvReporter *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	pullreqCtrl *controllerpullreq.Controller,
+	autoMergeStore store.AutoMergeStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	repoFinder refcache.RepoFinder,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	s := &Service{
+		pullreqCtrl:       pullreqCtrl,
+		autoMergeStore:    autoMergeStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		principalStore:    principalStore,
+		repoFinder:        repoFinder,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       sseStreamer,
+	}
+
+	const groupPullReq = "gitness:automerge:pullreq"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterAutoMergeEnabled(s.handleAutoMergeEnabled)
+			_ = r.RegisterReviewSubmitted(s.handleReviewSubmitted)
+			_ = r.RegisterCommentStatusUpdated(s.handleCommentStatusUpdated)
+			_ = r.RegisterBranchUpdated(s.handleBranchUpdated)
+			_ = r.RegisterTargetBranchChanged(s.handleTargetBranchChanged)
+			_ = r.RegisterClosed(s.handleClosed)
+			_ = r.RegisterMerged(s.handleMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheck = "gitness:automerge:check"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterReported(s.handleCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// tryMerge merges the pull request if the auto-merge is enabled for it and all the merge requirements are met.
+func (s *Service) tryMerge(ctx context.Context, pullreqID int64) error {
+	am, pr, session, repo, err := s.load(ctx, pullreqID)
+	if err != nil || am == nil {
+		return err
+	}
+
+	if pr.IsDraft {
+		return nil
+	}
+
+	ready, _, err := s.verifyRules(ctx, session, repo, pr, am)
+	if err != nil || !ready {
+		return err
+	}
+
+	out, violations, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &controllerpullreq.MergeInput{
+		Method:             am.Method,
+		SourceSHA:          pr.SourceSHA,
+		Title:              am.Title,
+		Message:            am.Message,
+		DeleteSourceBranch: am.DeleteSourceBranch,
+	})
+	if err != nil {
+		// The failure could be temporary (e.g. the pull request got updated in the meantime).
+		// The auto-merge stays enabled and the merge will be attempted again on the next event.
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq_id", pr.ID).
+			Msg("auto-merge failed to merge the pull request")
+		return nil
+	}
+	if violations != nil {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_id", pr.ID).
+			Str("message", violations.Message).
+			Msg("auto-merge is blocked")
+		return nil
+	}
+
+	if out.MergeQueued {
+		log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Msg("auto-merge added the pull request to the merge queue")
+	} else {
+		log.Ctx(ctx).Info().Int64("pullreq_id", pr.ID).Msg("auto-merge merged the pull request")
+	}
+
+	// The job is done: the pull request has been merged or the merge queue took over.
+	if err := s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// verifyRules dry-runs the protection rules on behalf of the user that enabled the auto-merge.
+// It returns true if there are no blocking rule violations.
+func (s *Service) verifyRules(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	am *types.AutoMerge,
+) (bool, *types.MergeResponse, error) {
+	out, _, err := s.pullreqCtrl.Merge(ctx, session, repo.Path, pr.Number, &controllerpullreq.MergeInput{
+		Method:      am.Method,
+		SourceSHA:   pr.SourceSHA,
+		DryRunRules: true,
+	})
+	if err != nil {
+		// The error is most likely permanent (e.g. the user lost the permission to merge).
+		// The auto-merge stays enabled, it can be disabled by the users.
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq_id", pr.ID).
+			Msg("auto-merge failed to verify protection rules")
+		return false, nil, nil
+	}
+
+	return !protection.IsCritical(out.RuleViolations), out, nil
+}
+
+// load returns the auto-merge settings of the pull request along with the pull request itself,
+// the session of the user that enabled the auto-merge and the target repository.
+// It returns nil auto-merge if the pull request doesn't have auto-merge enabled.
+func (s *Service) load(
+	ctx context.Context,
+	pullreqID int64,
+) (*types.AutoMerge, *types.PullReq, *auth.Session, *types.RepositoryCore, error) {
+	am, err := s.autoMergeStore.Find(ctx, pullreqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, nil, nil, nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, am.CreatedBy)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to find principal that enabled auto-merge: %w", err)
+	}
+
+	return am, pr, &auth.Session{Principal: *principal}, repo, nil
+}
+
+func (s *Service) disable(
+	ctx context.Context,
+	pullreqID int64,
+	principalID int64,
+	reason enum.AutoMergeDisableReason,
+) error {
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	_, err = pullreq.DisableAutoMerge(ctx, pullreq.AutoMergeDisableInput{
+		AutoMergeStore:    s.autoMergeStore,
+		PullReqStore:      s.pullreqStore,
+		ActivityStore:     s.activityStore,
+		PullReqEvReporter: s.pullreqEvReporter,
+		SSEStreamer:       s.sseStreamer,
+	}, repo, pr, principalID, reason)
+
+	return err
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/artifact"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineArtifacts        = "gitness:cleanup:pipeline-artifacts"
+	jobCronPipelineArtifacts        = "35 */4 * * *" // At minute 35 past every 4th hour.
+	jobMaxDurationPipelineArtifacts = 10 * time.Minute
+
+	pipelineArtifactsBatchSize = 100
+)
+
+type pipelineArtifactsCleanupJob struct {
+	retentionTime time.Duration
+
+	artifactStore store.PipelineArtifactStore
+	blobStore     blob.Store
+}
+
+func newPipelineArtifactsCleanupJob(
+	retentionTime time.Duration,
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+) *pipelineArtifactsCleanupJob {
+	return &pipelineArtifactsCleanupJob{
+		retentionTime: retentionTime,
+
+		artifactStore: artifactStore,
+		blobStore:     blobStore,
+	}
+}
+
+// Handle purges pipeline artifacts (both the files and the metadata) that are past the retention time.
+func (j *pipelineArtifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline artifacts older than %s (aka updated before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n := 0
+	for {
+		artifacts, err := j.artifactStore.ListUpdatedBefore(ctx, olderThan, pipelineArtifactsBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list old pipeline artifacts: %w", err)
+		}
+
+		for _, a := range artifacts {
+			// the file is deleted first, so the artifact is retried by the next run if the deletion fails.
+			if err = j.blobStore.Delete(ctx, artifact.ArtifactBlobPath(a)); err != nil {
+				return "", fmt.Errorf("failed to delete file of pipeline artifact %d: %w", a.ID, err)
+			}
+
+			if err = j.artifactStore.Delete(ctx, a.ID); err != nil {
+				return "", fmt.Errorf("failed to delete pipeline artifact %d: %w", a.ID, err)
+			}
+
+			n++
+		}
+
+		if len(artifacts) < pipelineArtifactsBatchSize {
+			break
+		}
+	}
+
+	result := "no old pipeline artifacts found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d pipeline artifacts", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
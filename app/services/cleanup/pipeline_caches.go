@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/artifact"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineCaches        = "gitness:cleanup:pipeline-caches"
+	jobCronPipelineCaches        = "55 */4 * * *" // At minute 55 past every 4th hour.
+	jobMaxDurationPipelineCaches = 10 * time.Minute
+
+	pipelineCachesBatchSize = 100
+)
+
+type pipelineCachesCleanupJob struct {
+	retentionTime time.Duration
+
+	cacheStore store.PipelineCacheStore
+	blobStore  blob.Store
+}
+
+func newPipelineCachesCleanupJob(
+	retentionTime time.Duration,
+	cacheStore store.PipelineCacheStore,
+	blobStore blob.Store,
+) *pipelineCachesCleanupJob {
+	return &pipelineCachesCleanupJob{
+		retentionTime: retentionTime,
+
+		cacheStore: cacheStore,
+		blobStore:  blobStore,
+	}
+}
+
+// Handle purges pipeline caches (both the files and the metadata) that weren't used within the retention time.
+func (j *pipelineCachesCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	unusedSince := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline caches unused for %s (aka last used before %s)",
+		j.retentionTime,
+		unusedSince.Format(time.RFC3339Nano))
+
+	n := 0
+	for {
+		caches, err := j.cacheStore.ListUnusedSince(ctx, unusedSince, pipelineCachesBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list unused pipeline caches: %w", err)
+		}
+
+		for _, c := range caches {
+			// the file is deleted first, so the cache is retried by the next run if the deletion fails.
+			if err = j.blobStore.Delete(ctx, artifact.CacheBlobPath(c)); err != nil {
+				return "", fmt.Errorf("failed to delete file of pipeline cache %d: %w", c.ID, err)
+			}
+
+			if err = j.cacheStore.Delete(ctx, c.ID); err != nil {
+				return "", fmt.Errorf("failed to delete pipeline cache %d: %w", c.ID, err)
+			}
+
+			n++
+		}
+
+		if len(caches) < pipelineCachesBatchSize {
+			break
+		}
+	}
+
+	result := "no unused pipeline caches found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d pipeline caches", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -22,12 +22,15 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	PipelineArtifactsRetentionTime   time.Duration
+	PipelineCachesRetentionTime      time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +44,14 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.PipelineArtifactsRetentionTime <= 0 {
+		return errors.New("config.PipelineArtifactsRetentionTime has to be provided")
+	}
+
+	if c.PipelineCachesRetentionTime <= 0 {
+		return errors.New("config.PipelineCachesRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +64,9 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	artifactStore         store.PipelineArtifactStore
+	cacheStore            store.PipelineCacheStore
+	blobStore             blob.Store
 }
 
 func NewService(
@@ -63,6 +77,9 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	artifactStore store.PipelineArtifactStore,
+	cacheStore store.PipelineCacheStore,
+	blobStore blob.Store,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +94,9 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		artifactStore:         artifactStore,
+		cacheStore:            cacheStore,
+		blobStore:             blobStore,
 	}, nil
 }
 
@@ -126,6 +146,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineArtifacts,
+		jobTypePipelineArtifacts,
+		jobCronPipelineArtifacts,
+		jobMaxDurationPipelineArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline artifacts cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineCaches,
+		jobTypePipelineCaches,
+		jobCronPipelineCaches,
+		jobMaxDurationPipelineCaches,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline caches cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +202,27 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypePipelineArtifacts,
+		newPipelineArtifactsCleanupJob(
+			s.config.PipelineArtifactsRetentionTime,
+			s.artifactStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline artifacts cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypePipelineCaches,
+		newPipelineCachesCleanupJob(
+			s.config.PipelineCachesRetentionTime,
+			s.cacheStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline caches cleanup: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuelink
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// referenceRegex matches issue references like "issue #12", optionally preceded by a closing keyword
+// ("fixes issue #12"). Issues are numbered separately from pull requests, so a plain "#12" is left
+// to refer to the pull request #12 and isn't treated as an issue reference.
+var referenceRegex = regexp.MustCompile(
+	`(?i)(?:\b(close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+)?\bissue\s+#(\d+)\b`)
+
+// Reference is a reference to an issue found in a pull request description or a commit message.
+type Reference struct {
+	Number int64
+	// Closing is true if the reference is preceded by a closing keyword, e.g. "closes issue #12".
+	Closing bool
+}
+
+// ParseReferences returns the issue references found in the text, in the order of their first appearance.
+// An issue referenced more than once is closing if any of its references is closing.
+func ParseReferences(text string) []Reference {
+	matches := referenceRegex.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	refs := make([]Reference, 0, len(matches))
+	idx := make(map[int64]int, len(matches))
+
+	for _, match := range matches {
+		num, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil || num <= 0 {
+			continue
+		}
+
+		closing := match[1] != ""
+
+		if i, ok := idx[num]; ok {
+			refs[i].Closing = refs[i].Closing || closing
+			continue
+		}
+
+		idx[num] = len(refs)
+		refs = append(refs, Reference{Number: num, Closing: closing})
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	return refs
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuelink
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Reference
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: nil,
+		},
+		{
+			name: "no-references",
+			text: "Refactor the parser",
+			want: nil,
+		},
+		{
+			name: "plain-reference",
+			text: "Related to issue #4",
+			want: []Reference{{Number: 4}},
+		},
+		{
+			name: "closing-keywords",
+			text: "fixes issue #12, Closes Issue #13 and resolved: issue  #14",
+			want: []Reference{
+				{Number: 12, Closing: true},
+				{Number: 13, Closing: true},
+				{Number: 14, Closing: true},
+			},
+		},
+		{
+			name: "reference-at-start",
+			text: "issue #7 is fixed",
+			want: []Reference{{Number: 7}},
+		},
+		{
+			name: "duplicate-becomes-closing",
+			text: "See issue #3.\n\nFix issue #3",
+			want: []Reference{{Number: 3, Closing: true}},
+		},
+		{
+			name: "not-a-reference",
+			text: "abc#5 and issue #x and color #ffffff and tissue #6 and issue#7",
+			want: nil,
+		},
+		{
+			name: "keyword-not-adjacent",
+			text: "fixes the bug in issue #9",
+			want: []Reference{{Number: 9}},
+		},
+		{
+			name: "pull-request-reference",
+			text: "Follow-up of #8, fixes #10",
+			want: nil,
+		},
+		{
+			name: "zero-is-ignored",
+			text: "fixes issue #0",
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseReferences(test.text)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issuelink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	controllerissue "github.com/harness/gitness/app/api/controller/issue"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxCommits is the maximum number of pushed commits that are scanned for issue references.
+const maxCommits = 100
+
+// Service links issues with the pull requests and the commits that reference them.
+// Issues referenced in a pull request get a reference entry in their timeline. Issues referenced
+// with a closing keyword ("fixes issue #12") get closed when the pull request gets merged into the default branch
+// or when a commit that references them gets pushed to the default branch.
+type Service struct {
+	issueCtrl    *controllerissue.Controller
+	git          git.Interface
+	pullreqStore store.PullReqStore
+	repoFinder   refcache.RepoFinder
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueCtrl *controllerissue.Controller,
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	repoFinder refcache.RepoFinder,
+) (*Service, error) {
+	s := &Service{
+		issueCtrl:    issueCtrl,
+		git:          git,
+		pullreqStore: pullreqStore,
+		repoFinder:   repoFinder,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	const groupPullReq = "gitness:issuelink:pullreq"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(s.handlePullReqCreated)
+			_ = r.RegisterMerged(s.handlePullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupGit = "gitness:issuelink:git"
+	_, err = gitReaderFactory.Launch(ctx, groupGit, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(s.handleBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// handlePullReqCreated writes a reference entry to the timeline of every issue
+// referenced in the title or the description of the new pull request.
+func (s *Service) handlePullReqCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	refs := ParseReferences(pr.Title + "\n" + pr.Description)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	for _, ref := range refs {
+		err = s.issueCtrl.Reference(ctx, event.Payload.PrincipalID, repo, ref.Number,
+			&types.IssueActivityPayloadReference{
+				PullReqNumber: &pr.Number,
+				Title:         pr.Title,
+				Closing:       ref.Closing,
+			})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", pr.ID).
+				Int64("issue_number", ref.Number).
+				Msg("failed to reference issue from pull request")
+		}
+	}
+
+	return nil
+}
+
+// handlePullReqMerged closes the issues referenced with a closing keyword in the merged pull request
+// if the pull request got merged into the default branch of the repository.
+func (s *Service) handlePullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if pr.TargetBranch != repo.DefaultBranch {
+		return nil
+	}
+
+	for _, ref := range ParseReferences(pr.Title + "\n" + pr.Description) {
+		if !ref.Closing {
+			continue
+		}
+
+		err = s.issueCtrl.CloseByReference(ctx, event.Payload.PrincipalID, repo, ref.Number, &pr.Number, "")
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", pr.ID).
+				Int64("issue_number", ref.Number).
+				Msg("failed to close issue referenced by merged pull request")
+		}
+	}
+
+	return nil
+}
+
+// handleBranchUpdated closes the issues referenced with a closing keyword
+// in the commit messages of the commits pushed to the default branch.
+func (s *Service) handleBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	repo, err := s.repoFinder.FindByID(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if strings.TrimPrefix(event.Payload.Ref, "refs/heads/") != repo.DefaultBranch {
+		return nil
+	}
+
+	out, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     event.Payload.NewSHA,
+		After:      event.Payload.OldSHA,
+		Limit:      maxCommits,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pushed commits: %w", err)
+	}
+
+	for _, commit := range out.Commits {
+		for _, ref := range ParseReferences(commit.Message) {
+			if !ref.Closing {
+				continue
+			}
+
+			err = s.issueCtrl.CloseByReference(ctx, event.Payload.PrincipalID, repo, ref.Number,
+				nil, commit.SHA.String())
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Str("commit_sha", commit.SHA.String()).
+					Int64("issue_number", ref.Number).
+					Msg("failed to close issue referenced by commit")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	// localIndexVersion is the version of the on-disk index format.
+	// Index files with a different version are ignored until the repository gets re-indexed.
+	localIndexVersion = 2
+
+	// localIndexPrefixLen is the length of the prefix of index files: the magic, the format version
+	// and the length of the encoded index that's followed by the contents of all indexed files.
+	localIndexPrefixLen = 16
+
+	localIndexFileExt = ".idx"
+
+	// binarySniffLen is the number of leading bytes inspected to detect binary files.
+	binarySniffLen = 8000
+)
+
+// localIndexMagic identifies index files.
+var localIndexMagic = [4]byte{'G', 'K', 'S', 'I'}
+
+// trigram is a sequence of three lower-cased bytes packed into an integer.
+type trigram uint32
+
+type localIndexFile struct {
+	Path string
+
+	// Offset and Size locate the content of the file in the contents of the index.
+	Offset int64
+	Size   int64
+}
+
+// localIndex is an inverted trigram index over all files of a single repository branch.
+type localIndex struct {
+	Version   int
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Files     []localIndexFile
+
+	// Postings maps each trigram to the sorted list of indices (into Files) of files containing it.
+	Postings map[trigram][]uint32
+
+	// contents holds the contents of all files added to the index. It isn't part of the encoded index,
+	// the contents are stored after it and only read for files that are candidates of a search.
+	contents []byte
+
+	// contentsOffset is the offset of the contents in the file the index was read from.
+	contentsOffset int64
+}
+
+func newLocalIndex(repoID int64, branch string, commitSHA string) *localIndex {
+	return &localIndex{
+		Version:   localIndexVersion,
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Files:     []localIndexFile{},
+		Postings:  map[trigram][]uint32{},
+	}
+}
+
+// add adds a file to the index. Files have to be added in the order they should be returned in.
+func (idx *localIndex) add(path string, content []byte) {
+	fileIdx := uint32(len(idx.Files))
+	idx.Files = append(idx.Files, localIndexFile{
+		Path:   path,
+		Offset: int64(len(idx.contents)),
+		Size:   int64(len(content)),
+	})
+	idx.contents = append(idx.contents, content...)
+
+	seen := make(map[trigram]struct{})
+	forEachTrigram(content, func(t trigram) {
+		if _, ok := seen[t]; ok {
+			return
+		}
+		seen[t] = struct{}{}
+		idx.Postings[t] = append(idx.Postings[t], fileIdx)
+	})
+}
+
+// addFromTar adds all regular text files of the provided tar stream to the index.
+// Files larger than maxFileSize (if positive) and binary files are skipped.
+func (idx *localIndex) addFromTar(r io.Reader, maxFileSize int64) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read next archive entry: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if maxFileSize > 0 && hdr.Size > maxFileSize {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read archive entry %q: %w", hdr.Name, err)
+		}
+
+		if isBinary(content) {
+			continue
+		}
+
+		idx.add(hdr.Name, content)
+	}
+}
+
+// readContent reads the content of a file of the index from r, which has to hold the index file
+// the index was read from (or the contents of an index that was built in memory).
+func (idx *localIndex) readContent(r io.ReaderAt, file localIndexFile) ([]byte, error) {
+	content := make([]byte, file.Size)
+	if _, err := io.ReadFull(io.NewSectionReader(r, idx.contentsOffset+file.Offset, file.Size), content); err != nil {
+		return nil, fmt.Errorf("failed to read content of %q: %w", file.Path, err)
+	}
+	return content, nil
+}
+
+// candidates returns the indices of the files that contain all trigrams of all provided literals.
+// If none of the literals is long enough to contain a trigram, all files are returned.
+func (idx *localIndex) candidates(literals []string) []uint32 {
+	var result []uint32
+	filtered := false
+
+	for _, literal := range literals {
+		var missing bool
+		forEachTrigram([]byte(literal), func(t trigram) {
+			if missing {
+				return
+			}
+
+			postings, ok := idx.Postings[t]
+			if !ok {
+				missing = true
+				return
+			}
+
+			if !filtered {
+				result = append([]uint32(nil), postings...)
+				filtered = true
+				return
+			}
+
+			result = intersectPostings(result, postings)
+		})
+
+		if missing {
+			return nil
+		}
+	}
+
+	if filtered {
+		return result
+	}
+
+	result = make([]uint32, len(idx.Files))
+	for i := range idx.Files {
+		result[i] = uint32(i)
+	}
+
+	return result
+}
+
+// intersectPostings intersects two sorted posting lists. The result reuses the memory of a.
+func intersectPostings(a, b []uint32) []uint32 {
+	result := a[:0]
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// forEachTrigram calls fn for every (ASCII case-folded) trigram of data, including duplicates.
+func forEachTrigram(data []byte, fn func(t trigram)) {
+	if len(data) < 3 {
+		return
+	}
+
+	t := trigram(toLowerASCII(data[0]))<<8 | trigram(toLowerASCII(data[1]))
+	for i := 2; i < len(data); i++ {
+		t = (t<<8 | trigram(toLowerASCII(data[i]))) & 0xFFFFFF
+		fn(t)
+	}
+}
+
+func toLowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
+
+func isBinary(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func localIndexPath(root string, repoID int64) string {
+	return filepath.Join(root, strconv.FormatInt(repoID, 10)+localIndexFileExt)
+}
+
+// writeLocalIndex atomically stores the index in the provided file.
+func writeLocalIndex(path string, idx *localIndex) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	encoded := &bytes.Buffer{}
+	zw := gzip.NewWriter(encoded)
+	if err = gob.NewEncoder(zw).Encode(idx); err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("failed to flush index: %w", err)
+	}
+
+	prefix := make([]byte, localIndexPrefixLen)
+	copy(prefix, localIndexMagic[:])
+	binary.BigEndian.PutUint32(prefix[4:], localIndexVersion)
+	binary.BigEndian.PutUint64(prefix[8:], uint64(encoded.Len())) //nolint:gosec
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	for _, data := range [][]byte{prefix, encoded.Bytes(), idx.contents} {
+		if _, err = f.Write(data); err != nil {
+			return fmt.Errorf("failed to write index file: %w", err)
+		}
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+// readLocalIndex decodes the index stored in r without the contents of the indexed files,
+// those are read from r on demand.
+// It returns nil (without an error) in case the index uses an outdated format.
+func readLocalIndex(r io.ReaderAt) (*localIndex, error) {
+	prefix := make([]byte, localIndexPrefixLen)
+	_, err := io.ReadFull(io.NewSectionReader(r, 0, localIndexPrefixLen), prefix)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+
+	if !bytes.Equal(prefix[:4], localIndexMagic[:]) || binary.BigEndian.Uint32(prefix[4:]) != localIndexVersion {
+		return nil, nil //nolint:nilnil
+	}
+	encodedLen := int64(binary.BigEndian.Uint64(prefix[8:])) //nolint:gosec
+
+	zr, err := gzip.NewReader(io.NewSectionReader(r, localIndexPrefixLen, encodedLen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+	defer zr.Close()
+
+	idx := &localIndex{}
+	if err := gob.NewDecoder(zr).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	if idx.Version != localIndexVersion {
+		return nil, nil //nolint:nilnil
+	}
+
+	idx.contentsOffset = localIndexPrefixLen + encodedLen
+
+	return idx, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// localIndexCacheSize is the maximum number of decoded indexes kept in memory.
+const localIndexCacheSize = 64
+
+// localIndexCache keeps decoded indexes (without the file contents) in memory, so searches don't have to decode
+// the postings of every repository again. An entry is only used as long as the index file it was read from
+// hasn't been replaced.
+type localIndexCache struct {
+	mx      sync.Mutex
+	entries map[int64]*localIndexCacheEntry
+}
+
+type localIndexCacheEntry struct {
+	file     os.FileInfo
+	idx      *localIndex
+	lastUsed time.Time
+}
+
+func newLocalIndexCache() *localIndexCache {
+	return &localIndexCache{
+		entries: map[int64]*localIndexCacheEntry{},
+	}
+}
+
+// get returns the index stored in the provided (open) index file of the repository.
+// It returns nil (without an error) in case the index uses an outdated format.
+func (c *localIndexCache) get(repoID int64, f *os.File) (*localIndex, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat index file: %w", err)
+	}
+
+	if idx := c.lookup(repoID, info); idx != nil {
+		return idx, nil
+	}
+
+	idx, err := readLocalIndex(f)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if _, ok := c.entries[repoID]; !ok && len(c.entries) >= localIndexCacheSize {
+		c.evictOldest()
+	}
+	c.entries[repoID] = &localIndexCacheEntry{
+		file:     info,
+		idx:      idx,
+		lastUsed: time.Now(),
+	}
+
+	return idx, nil
+}
+
+func (c *localIndexCache) lookup(repoID int64, info os.FileInfo) *localIndex {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	entry, ok := c.entries[repoID]
+	if !ok {
+		return nil
+	}
+
+	if !os.SameFile(entry.file, info) || !entry.file.ModTime().Equal(info.ModTime()) ||
+		entry.file.Size() != info.Size() {
+		delete(c.entries, repoID)
+		return nil
+	}
+
+	entry.lastUsed = time.Now()
+
+	return entry.idx
+}
+
+func (c *localIndexCache) remove(repoID int64) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	delete(c.entries, repoID)
+}
+
+func (c *localIndexCache) evictOldest() {
+	var oldestID int64
+	var oldest *localIndexCacheEntry
+	for repoID, entry := range c.entries {
+		if oldest == nil || entry.lastUsed.Before(oldest.lastUsed) {
+			oldestID, oldest = repoID, entry
+		}
+	}
+	if oldest != nil {
+		delete(c.entries, oldestID)
+	}
+}
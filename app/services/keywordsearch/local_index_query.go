@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+)
+
+// localQuery is a compiled search query for the local index.
+type localQuery struct {
+	re *regexp.Regexp
+
+	// literals are strings every matching file is guaranteed to contain (ignoring ASCII case).
+	literals []string
+}
+
+func newLocalQuery(query string, enableRegex bool) (*localQuery, error) {
+	if !enableRegex {
+		q := &localQuery{
+			re: regexp.MustCompile("(?i)" + regexp.QuoteMeta(query)),
+		}
+		// the trigram index only folds ASCII characters
+		if isASCII(query) {
+			q.literals = []string{query}
+		}
+		return q, nil
+	}
+
+	re, err := regexp.Compile(query)
+	if err != nil {
+		return nil, errors.InvalidArgumentf("invalid regular expression: %s", err)
+	}
+
+	parsed, err := syntax.Parse(query, syntax.Perl)
+	if err != nil {
+		return nil, errors.InvalidArgumentf("invalid regular expression: %s", err)
+	}
+
+	return &localQuery{
+		re:       re,
+		literals: requiredLiterals(parsed.Simplify()),
+	}, nil
+}
+
+// requiredLiterals returns the literal strings that any match of the regular expression has to contain.
+// The analysis is conservative - if in doubt, no literal is returned.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op { //nolint:exhaustive // all other operations don't guarantee any literal.
+	case syntax.OpLiteral:
+		literal := string(re.Rune)
+		// the trigram index only folds ASCII characters
+		if re.Flags&syntax.FoldCase != 0 && !isASCII(literal) {
+			return nil
+		}
+		return []string{literal}
+	case syntax.OpCapture:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+		return nil
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	default:
+		return nil
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFile returns the per line matches of the query within the content, limited to maxMatches.
+func (q *localQuery) matchFile(content []byte, maxMatches int) []types.Match {
+	lines := bytes.Split(content, []byte{'\n'})
+
+	var matches []types.Match
+	for i, line := range lines {
+		if len(matches) >= maxMatches {
+			break
+		}
+
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+
+		locs := q.re.FindAllIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fragments := make([]types.Fragment, 0, len(locs))
+		prevEnd := 0
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				// ignore empty matches, they can't be highlighted
+				continue
+			}
+
+			fragments = append(fragments, types.Fragment{
+				Pre:   string(line[prevEnd:loc[0]]),
+				Match: string(line[loc[0]:loc[1]]),
+			})
+			prevEnd = loc[1]
+		}
+
+		if len(fragments) == 0 {
+			continue
+		}
+
+		fragments[len(fragments)-1].Post = string(line[prevEnd:])
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = string(bytes.TrimSuffix(lines[i-1], []byte{'\r'}))
+		}
+		if i < len(lines)-1 {
+			match.After = string(bytes.TrimSuffix(lines[i+1], []byte{'\r'}))
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+var languageByExtension = map[string]string{
+	".c":     "C",
+	".h":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".hpp":   "C++",
+	".cs":    "C#",
+	".css":   "CSS",
+	".dart":  "Dart",
+	".go":    "Go",
+	".html":  "HTML",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".json":  "JSON",
+	".kt":    "Kotlin",
+	".md":    "Markdown",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".sh":    "Shell",
+	".sql":   "SQL",
+	".swift": "Swift",
+	".tf":    "HCL",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".xml":   "XML",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+}
+
+// languageFromPath returns a best guess of the programming language of a file based on its name.
+func languageFromPath(filePath string) string {
+	switch path.Base(filePath) {
+	case "Dockerfile":
+		return "Dockerfile"
+	case "Makefile":
+		return "Makefile"
+	}
+
+	return languageByExtension[strings.ToLower(path.Ext(filePath))]
+}
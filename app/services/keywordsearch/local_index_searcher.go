@@ -16,27 +16,262 @@ package keywordsearch
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitapi "github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
 )
 
+const defaultMaxResultCount = 100
+
+type LocalIndexConfig struct {
+	// Root is the directory in which the index files are stored.
+	Root string
+
+	// MaxFileSize is the maximum size (in bytes) of files that get indexed. Larger files are skipped.
+	MaxFileSize int64
+}
+
+func (c *LocalIndexConfig) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Root == "" {
+		return errors.New("config.Root is required")
+	}
+	if c.MaxFileSize < 0 {
+		return errors.New("config.MaxFileSize can't be negative")
+	}
+	return nil
+}
+
+// LocalIndexSearcher maintains an embedded trigram index per repository
+// (built from the default branch) and searches it.
 type LocalIndexSearcher struct {
+	config  LocalIndexConfig
+	git     git.Interface
+	indexes *localIndexCache
+
+	repoLocksMx sync.Mutex
+	repoLocks   map[int64]*sync.Mutex
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(config LocalIndexConfig, gitInterface git.Interface) (*LocalIndexSearcher, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided local index config is invalid: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		config:    config,
+		git:       gitInterface,
+		indexes:   newLocalIndexCache(),
+		repoLocks: map[int64]*sync.Mutex{},
+	}, nil
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	q, err := newLocalQuery(query, enableRegex)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	// sort the repos to get a stable result order
+	repoIDs = slices.Clone(repoIDs)
+	slices.Sort(repoIDs)
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		remaining := maxResultCount - result.Stats.TotalMatches
+		if remaining <= 0 {
+			break
+		}
+
+		fileMatches, matchCount, err := s.searchRepo(ctx, repoID, q, remaining)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to search index of repo %d: %w", repoID, err)
+		}
+
+		result.FileMatches = append(result.FileMatches, fileMatches...)
+		result.Stats.TotalFiles += len(fileMatches)
+		result.Stats.TotalMatches += matchCount
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+func (s *LocalIndexSearcher) searchRepo(
+	ctx context.Context,
+	repoID int64,
+	q *localQuery,
+	maxMatches int,
+) ([]types.FileMatch, int, error) {
+	f, err := os.Open(localIndexPath(s.config.Root, repoID))
+	if errors.Is(err, os.ErrNotExist) {
+		log.Ctx(ctx).Debug().Msgf("repo %d isn't indexed yet, skipping it", repoID)
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	idx, err := s.indexes.get(repoID, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	if idx == nil {
+		log.Ctx(ctx).Debug().Msgf("index of repo %d is outdated, skipping it", repoID)
+		return nil, 0, nil
+	}
+
+	return searchLocalIndex(idx, f, q, maxMatches)
+}
+
+// searchLocalIndex returns the files of the index matching the query, limited to maxMatches line matches.
+// The contents of candidate files are read from r.
+func searchLocalIndex(
+	idx *localIndex,
+	r io.ReaderAt,
+	q *localQuery,
+	maxMatches int,
+) ([]types.FileMatch, int, error) {
+	var fileMatches []types.FileMatch
+	matchCount := 0
+
+	for _, fileIdx := range idx.candidates(q.literals) {
+		if matchCount >= maxMatches {
+			break
+		}
+
+		file := idx.Files[fileIdx]
+
+		content, err := idx.readContent(r, file)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		matches := q.matchFile(content, maxMatches-matchCount)
+		if len(matches) == 0 {
+			continue
+		}
+
+		fileMatches = append(fileMatches, types.FileMatch{
+			FileName:   file.Path,
+			RepoID:     idx.RepoID,
+			RepoBranch: idx.Branch,
+			Language:   languageFromPath(file.Path),
+			Matches:    matches,
+		})
+		matchCount += len(matches)
+	}
+
+	return fileMatches, matchCount, nil
+}
+
+// Index rebuilds the index of the repository from the latest commit of its default branch.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	mx := s.repoLock(repo.ID)
+	mx.Lock()
+	defer mx.Unlock()
+
+	indexPath := localIndexPath(s.config.Root, repo.ID)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (e.g. empty repository) - remove any stale index
+		return s.removeIndex(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+	idx := newLocalIndex(repo.ID, repo.DefaultBranch, commitSHA)
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		err := s.git.Archive(ctx, git.ArchiveParams{
+			ReadParams: git.CreateReadParams(repo),
+			ArchiveParams: gitapi.ArchiveParams{
+				Format:  gitapi.ArchiveFormatTar,
+				Treeish: commitSHA,
+			},
+		}, pw)
+		_ = pw.CloseWithError(err)
+	}()
+
+	if err := idx.addFromTar(pr, s.config.MaxFileSize); err != nil {
+		return fmt.Errorf("failed to index commit %s: %w", commitSHA, err)
+	}
+
+	if err := writeLocalIndex(indexPath, idx); err != nil {
+		return fmt.Errorf("failed to store index: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("indexed %d files of repo %d at commit %s", len(idx.Files), repo.ID, commitSHA)
+
 	return nil
 }
+
+// Delete removes the index of the repository.
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	mx := s.repoLock(repoID)
+	mx.Lock()
+	defer mx.Unlock()
+
+	return s.removeIndex(repoID)
+}
+
+func (s *LocalIndexSearcher) removeIndex(repoID int64) error {
+	s.indexes.remove(repoID)
+
+	err := os.Remove(localIndexPath(s.config.Root, repoID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove index: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) repoLock(repoID int64) *sync.Mutex {
+	s.repoLocksMx.Lock()
+	defer s.repoLocksMx.Unlock()
+
+	mx, ok := s.repoLocks[repoID]
+	if !ok {
+		mx = &sync.Mutex{}
+		s.repoLocks[repoID] = mx
+	}
+
+	return mx
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func testLocalIndex() *localIndex {
+	idx := newLocalIndex(42, "main", "abc")
+	idx.add("main.go", []byte("package main\n\nfunc main() {\n\tprintln(\"Hello World\")\n}\n"))
+	idx.add("README.md", []byte("# Hello\r\nThis is a hello world example.\r\n"))
+	idx.add("lib/util.go", []byte("package lib\n\nfunc Add(a, b int) int { return a + b }\n"))
+	return idx
+}
+
+func TestLocalIndex_Search(t *testing.T) {
+	idx := testLocalIndex()
+
+	tests := []struct {
+		name       string
+		query      string
+		regex      bool
+		maxMatches int
+		wantFiles  []string
+		wantCount  int
+	}{
+		{
+			name:       "literal-case-insensitive",
+			query:      "hello world",
+			maxMatches: 10,
+			wantFiles:  []string{"main.go", "README.md"},
+			wantCount:  2,
+		},
+		{
+			name:       "literal-multiple-lines",
+			query:      "package",
+			maxMatches: 10,
+			wantFiles:  []string{"main.go", "lib/util.go"},
+			wantCount:  2,
+		},
+		{
+			name:       "literal-no-match",
+			query:      "gitness",
+			maxMatches: 10,
+			wantFiles:  nil,
+			wantCount:  0,
+		},
+		{
+			name:       "literal-short",
+			query:      "a",
+			maxMatches: 1,
+			wantFiles:  []string{"main.go"},
+			wantCount:  1,
+		},
+		{
+			name:       "regex",
+			query:      `func [A-Z]\w*\(`,
+			regex:      true,
+			maxMatches: 10,
+			wantFiles:  []string{"lib/util.go"},
+			wantCount:  1,
+		},
+		{
+			name:       "regex-case-sensitive",
+			query:      `Hello`,
+			regex:      true,
+			maxMatches: 10,
+			wantFiles:  []string{"main.go", "README.md"},
+			wantCount:  2,
+		},
+		{
+			name:       "regex-alternation",
+			query:      `Add|println`,
+			regex:      true,
+			maxMatches: 10,
+			wantFiles:  []string{"main.go", "lib/util.go"},
+			wantCount:  2,
+		},
+		{
+			name:       "limit",
+			query:      "hello",
+			maxMatches: 1,
+			wantFiles:  []string{"main.go"},
+			wantCount:  1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := newLocalQuery(test.query, test.regex)
+			if err != nil {
+				t.Fatalf("failed to create query: %s", err)
+			}
+
+			fileMatches, count, err := searchLocalIndex(idx, bytes.NewReader(idx.contents), q, test.maxMatches)
+			if err != nil {
+				t.Fatalf("failed to search: %s", err)
+			}
+
+			var files []string
+			for _, fm := range fileMatches {
+				files = append(files, fm.FileName)
+			}
+
+			if !reflect.DeepEqual(files, test.wantFiles) {
+				t.Errorf("files: want=%v got=%v", test.wantFiles, files)
+			}
+			if count != test.wantCount {
+				t.Errorf("count: want=%d got=%d", test.wantCount, count)
+			}
+		})
+	}
+}
+
+func TestLocalQuery_MatchFile(t *testing.T) {
+	q, err := newLocalQuery("ab", false)
+	if err != nil {
+		t.Fatalf("failed to create query: %s", err)
+	}
+
+	matches := q.matchFile([]byte("first\nxxABxxab\nlast"), 10)
+
+	want := []types.Match{
+		{
+			LineNum: 2,
+			Fragments: []types.Fragment{
+				{Pre: "xx", Match: "AB"},
+				{Pre: "xx", Match: "ab", Post: ""},
+			},
+			Before: "first",
+			After:  "last",
+		},
+	}
+
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("want=%+v got=%+v", want, matches)
+	}
+}
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: `hello`, want: []string{"hello"}},
+		{expr: `foo.*bar`, want: []string{"foo", "bar"}},
+		{expr: `(abc)+x?`, want: []string{"abc"}},
+		{expr: `foo|bar`, want: nil},
+		{expr: `(?i)äbc`, want: nil},
+		{expr: `[a-z]+`, want: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			re, err := syntax.Parse(test.expr, syntax.Perl)
+			if err != nil {
+				t.Fatalf("failed to parse: %s", err)
+			}
+
+			got := requiredLiterals(re.Simplify())
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLocalIndex_AddFromTar(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "small.txt", content: []byte("small text")},
+		{name: "binary.bin", content: []byte{'a', 0, 'b'}},
+		{name: "large.txt", content: bytes.Repeat([]byte{'x'}, 100)},
+	}
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0o644,
+			Size:     int64(len(f.content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatalf("failed to write header: %s", err)
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			t.Fatalf("failed to write content: %s", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %s", err)
+	}
+
+	idx := newLocalIndex(1, "main", "abc")
+	if err := idx.addFromTar(buf, 50); err != nil {
+		t.Fatalf("failed to add from tar: %s", err)
+	}
+
+	if len(idx.Files) != 1 || idx.Files[0].Path != "small.txt" {
+		t.Errorf("expected only small.txt to be indexed, got %+v", idx.Files)
+	}
+}
+
+func TestLocalIndex_WriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index", "42.idx")
+
+	want := testLocalIndex()
+	if err := writeLocalIndex(path, want); err != nil {
+		t.Fatalf("failed to write index: %s", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open index: %s", err)
+	}
+	defer f.Close()
+
+	got, err := readLocalIndex(f)
+	if err != nil {
+		t.Fatalf("failed to read index: %s", err)
+	}
+
+	if got.contents != nil {
+		t.Errorf("file contents shouldn't be loaded with the index")
+	}
+	if !reflect.DeepEqual(got.Files, want.Files) || !reflect.DeepEqual(got.Postings, want.Postings) {
+		t.Errorf("read index doesn't match the written one")
+	}
+
+	for _, file := range want.Files {
+		content, err := got.readContent(f, file)
+		if err != nil {
+			t.Fatalf("failed to read content of %s: %s", file.Path, err)
+		}
+		wantContent := want.contents[file.Offset : file.Offset+file.Size]
+		if !bytes.Equal(content, wantContent) {
+			t.Errorf("content of %s: want=%q got=%q", file.Path, wantContent, content)
+		}
+	}
+}
+
+func TestLocalIndex_ReadOutdated(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "gzip-v1", data: []byte{0x1f, 0x8b, 0x08, 0, 0, 0, 0, 0, 0, 0xff, 0, 0, 0, 0, 0, 0}},
+		{name: "other-version", data: append(localIndexMagic[:], 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idx, err := readLocalIndex(bytes.NewReader(test.data))
+			if err != nil || idx != nil {
+				t.Errorf("expected no index and no error, got idx=%v err=%v", idx, err)
+			}
+		})
+	}
+}
+
+func TestLocalIndexSearcher_SearchReindexDelete(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewLocalIndexSearcher(LocalIndexConfig{Root: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("failed to create searcher: %s", err)
+	}
+
+	search := func(query string) []string {
+		result, err := s.Search(ctx, []int64{42, 43}, query, false, 10)
+		if err != nil {
+			t.Fatalf("failed to search: %s", err)
+		}
+		var files []string
+		for _, fm := range result.FileMatches {
+			files = append(files, fm.FileName)
+		}
+		return files
+	}
+
+	if err := writeLocalIndex(localIndexPath(s.config.Root, 42), testLocalIndex()); err != nil {
+		t.Fatalf("failed to write index: %s", err)
+	}
+
+	if got := search("hello world"); !reflect.DeepEqual(got, []string{"main.go", "README.md"}) {
+		t.Errorf("unexpected files %v", got)
+	}
+
+	// the cached index must not be used once the index file got replaced
+	idx := newLocalIndex(42, "main", "def")
+	idx.add("other.go", []byte("hello world again"))
+	if err := writeLocalIndex(localIndexPath(s.config.Root, 42), idx); err != nil {
+		t.Fatalf("failed to write index: %s", err)
+	}
+
+	if got := search("hello world"); !reflect.DeepEqual(got, []string{"other.go"}) {
+		t.Errorf("unexpected files after reindex %v", got)
+	}
+
+	if err := s.Delete(ctx, 42); err != nil {
+		t.Fatalf("failed to delete index: %s", err)
+	}
+
+	if got := search("hello world"); got != nil {
+		t.Errorf("unexpected files after delete %v", got)
+	}
+	if _, err := os.Stat(localIndexPath(s.config.Root, 42)); !os.IsNotExist(err) {
+		t.Errorf("expected index file to be removed, got %v", err)
+	}
+	if len(s.indexes.entries) != 0 {
+		t.Errorf("expected index to be removed from cache")
+	}
+}
@@ -106,6 +106,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterDeleted(service.handleRepoDeleted)
 			return nil
 		})
 	if err != nil {
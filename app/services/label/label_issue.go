@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AssignToIssueOut struct {
+	Label         *types.Label
+	IssueLabel    *types.IssueLabel
+	OldLabelValue *types.LabelValue
+	NewLabelValue *types.LabelValue
+	ActivityType  enum.PullReqLabelActivityType
+}
+
+// AssignToIssue assigns a label (optionally with a value) to an issue.
+// The labels are shared with pull requests, so the same label scoping rules apply.
+func (s *Service) AssignToIssue(
+	ctx context.Context,
+	principalID int64,
+	issueID int64,
+	repoID int64,
+	repoParentID int64,
+	in *types.PullReqLabelAssignInput,
+) (*AssignToIssueOut, error) {
+	label, err := s.labelStore.FindByID(ctx, in.LabelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	if err := s.checkPullreqLabelInScope(ctx, repoParentID, repoID, label); err != nil {
+		return nil, err
+	}
+
+	oldIssueLabel, err := s.issueLabelAssignmentStore.FindByLabelID(ctx, issueID, label.ID)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	var oldLabelValue *types.LabelValue
+	if oldIssueLabel != nil && oldIssueLabel.ValueID != nil {
+		oldLabelValue, err = s.labelValueStore.FindByID(ctx, *oldIssueLabel.ValueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find label value by id: %w", err)
+		}
+	}
+
+	noop := &AssignToIssueOut{
+		Label:         label,
+		IssueLabel:    oldIssueLabel,
+		OldLabelValue: oldLabelValue,
+		ActivityType:  enum.LabelActivityNoop,
+	}
+
+	switch {
+	case oldIssueLabel != nil && oldLabelValue == nil && in.Value == "" && in.ValueID == nil:
+		return noop, nil
+	case oldLabelValue != nil && in.ValueID != nil && oldLabelValue.ID == *in.ValueID:
+		return noop, nil
+	case oldLabelValue != nil && in.Value != "" && oldLabelValue.Value == in.Value:
+		return noop, nil
+	}
+
+	var newLabelValue *types.LabelValue
+	if in.ValueID != nil {
+		newLabelValue, err = s.labelValueStore.FindByID(ctx, *in.ValueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find label value by id: %w", err)
+		}
+		if label.ID != newLabelValue.LabelID {
+			return nil, errors.InvalidArgument("label value is not associated with label")
+		}
+	}
+
+	newIssueLabel := newIssueLabel(issueID, principalID, in)
+	if in.Value != "" {
+		newLabelValue, err = s.getOrDefineValue(ctx, principalID, label, in.Value)
+		if err != nil {
+			return nil, err
+		}
+		newIssueLabel.ValueID = &newLabelValue.ID
+	}
+
+	err = s.issueLabelAssignmentStore.Assign(ctx, newIssueLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign label to issue: %w", err)
+	}
+
+	activityType := enum.LabelActivityAssign
+	if oldIssueLabel != nil {
+		activityType = enum.LabelActivityReassign
+	}
+
+	return &AssignToIssueOut{
+		Label:         label,
+		IssueLabel:    newIssueLabel,
+		OldLabelValue: oldLabelValue,
+		NewLabelValue: newLabelValue,
+		ActivityType:  activityType,
+	}, nil
+}
+
+// UnassignFromIssue removes a label from an issue.
+func (s *Service) UnassignFromIssue(
+	ctx context.Context, repoID, repoParentID, issueID, labelID int64,
+) (*types.Label, *types.LabelValue, error) {
+	label, err := s.labelStore.FindByID(ctx, labelID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	if err := s.checkPullreqLabelInScope(ctx, repoParentID, repoID, label); err != nil {
+		return nil, nil, err
+	}
+
+	value, err := s.issueLabelAssignmentStore.FindValueByLabelID(ctx, issueID, labelID)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find label value: %w", err)
+	}
+
+	return label, value, s.issueLabelAssignmentStore.Unassign(ctx, issueID, labelID)
+}
+
+// ListIssueLabels lists the labels assigned to an issue, or assignable to it if requested by the filter.
+func (s *Service) ListIssueLabels(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	spaceID int64,
+	issueID int64,
+	filter *types.AssignableLabelFilter,
+) (*types.ScopesLabels, int64, error) {
+	issueAssignments, err := s.issueLabelAssignmentStore.ListAssigned(ctx, issueID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list labels assigned to issue: %w", err)
+	}
+
+	return s.listScopeLabels(ctx, repo, spaceID, issueAssignments, filter)
+}
+
+// BackfillIssues populates the labels of the provided issues.
+func (s *Service) BackfillIssues(
+	ctx context.Context,
+	issues []*types.Issue,
+) error {
+	issueIDs := make([]int64, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+
+	issueAssignments, err := s.issueLabelAssignmentStore.ListAssignedByIssueIDs(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list labels assigned to issues: %w", err)
+	}
+
+	for _, issue := range issues {
+		issue.Labels = issueAssignments[issue.ID]
+	}
+
+	return nil
+}
+
+func newIssueLabel(
+	issueID int64,
+	principalID int64,
+	in *types.PullReqLabelAssignInput,
+) *types.IssueLabel {
+	now := time.Now().UnixMilli()
+	return &types.IssueLabel{
+		IssueID:   issueID,
+		LabelID:   in.LabelID,
+		ValueID:   in.ValueID,
+		Created:   now,
+		Updated:   now,
+		CreatedBy: principalID,
+		UpdatedBy: principalID,
+	}
+}
@@ -296,6 +296,23 @@ func (s *Service) ListPullReqLabels(
 	spaceID int64,
 	pullreqID int64,
 	filter *types.AssignableLabelFilter,
+) (*types.ScopesLabels, int64, error) {
+	pullreqAssignments, err := s.pullReqLabelAssignmentStore.ListAssigned(ctx, pullreqID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list labels assigned to pullreq: %w", err)
+	}
+
+	return s.listScopeLabels(ctx, repo, spaceID, pullreqAssignments, filter)
+}
+
+// listScopeLabels returns the labels assigned to a pull request or an issue.
+// If the filter requests assignable labels, all labels available in the repo and its parent spaces are returned.
+func (s *Service) listScopeLabels(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	spaceID int64,
+	assignments map[int64]*types.LabelAssignment,
+	filter *types.AssignableLabelFilter,
 ) (*types.ScopesLabels, int64, error) {
 	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, spaceID)
 	if err != nil {
@@ -312,13 +329,8 @@ func (s *Service) ListPullReqLabels(
 
 	scopeLabelsMap := make(map[int64]*types.ScopeData)
 
-	pullreqAssignments, err := s.pullReqLabelAssignmentStore.ListAssigned(ctx, pullreqID)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list labels assigned to pullreq: %w", err)
-	}
-
 	if !filter.Assignable {
-		sortedAssignments := maps.Values(pullreqAssignments)
+		sortedAssignments := maps.Values(assignments)
 		sort.Slice(sortedAssignments, func(i, j int) bool {
 			if sortedAssignments[i].Key != sortedAssignments[j].Key {
 				return sortedAssignments[i].Key < sortedAssignments[j].Key
@@ -356,7 +368,7 @@ func (s *Service) ListPullReqLabels(
 
 	allAssignments := make([]*types.LabelAssignment, len(labelInfos))
 	for i, labelInfo := range labelInfos {
-		assignment, ok := pullreqAssignments[labelInfo.ID]
+		assignment, ok := assignments[labelInfo.ID]
 		if !ok {
 			assignment = &types.LabelAssignment{
 				LabelInfo: *labelInfo,
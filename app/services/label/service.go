@@ -26,6 +26,7 @@ type Service struct {
 	labelStore                  store.LabelStore
 	labelValueStore             store.LabelValueStore
 	pullReqLabelAssignmentStore store.PullReqLabelAssignmentStore
+	issueLabelAssignmentStore   store.IssueLabelAssignmentStore
 	spaceFinder                 refcache.SpaceFinder
 }
 
@@ -35,6 +36,7 @@ func New(
 	labelStore store.LabelStore,
 	labelValueStore store.LabelValueStore,
 	pullReqLabelAssignmentStore store.PullReqLabelAssignmentStore,
+	issueLabelAssignmentStore store.IssueLabelAssignmentStore,
 	spaceFinder refcache.SpaceFinder,
 ) *Service {
 	return &Service{
@@ -43,6 +45,7 @@ func New(
 		labelStore:                  labelStore,
 		labelValueStore:             labelValueStore,
 		pullReqLabelAssignmentStore: pullReqLabelAssignmentStore,
+		issueLabelAssignmentStore:   issueLabelAssignmentStore,
 		spaceFinder:                 spaceFinder,
 	}
 }
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/types"
+)
+
+type checksStatus int
+
+const (
+	checksPending checksStatus = iota
+	checksPassed
+	checksFailed
+)
+
+// evaluateChecks returns the combined status of the required status checks of a merge group commit.
+// A failed status is returned as soon as any of the required checks has failed, along with the list
+// of the failed check identifiers. If there are no required checks, the merge group passes immediately.
+func evaluateChecks(required []string, results []types.CheckResult) (checksStatus, []string) {
+	statuses := make(map[string]types.CheckResult, len(results))
+	for _, result := range results {
+		statuses[result.Identifier] = result
+	}
+
+	var failed []string
+	pending := false
+
+	for _, identifier := range required {
+		result, ok := statuses[identifier]
+		if !ok || !result.Status.IsCompleted() {
+			pending = true
+			continue
+		}
+
+		if !result.Status.IsSuccess() {
+			failed = append(failed, identifier)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return checksFailed, failed
+	}
+
+	if pending {
+		return checksPending, nil
+	}
+
+	return checksPassed, nil
+}
+
+// groupsEvaluation is the outcome of the status check evaluation of all merge groups of a merge queue.
+type groupsEvaluation struct {
+	// merge is the number of merge groups, counting from the head of the queue, that can be merged.
+	merge int
+	// failed is the index of the first merge group that failed the required checks, or -1.
+	failed       int
+	failedChecks []string
+}
+
+// evaluateGroups evaluates the status check results of the merge groups, provided in the queue order.
+// A merge group commit contains all merge groups ahead of it, so once a group passes the required checks,
+// all groups up to it can be merged, even if the checks of some of the groups ahead of it are still pending.
+// The evaluation stops at the first failed group.
+func evaluateGroups(required []string, results [][]types.CheckResult) groupsEvaluation {
+	eval := groupsEvaluation{failed: -1}
+
+	for i, groupResults := range results {
+		status, failed := evaluateChecks(required, groupResults)
+		switch status {
+		case checksPending:
+			continue
+		case checksPassed:
+			eval.merge = i + 1
+		case checksFailed:
+			eval.failed = i
+			eval.failedChecks = failed
+			return eval
+		}
+	}
+
+	return eval
+}
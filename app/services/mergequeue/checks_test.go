@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEvaluateChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		required  []string
+		results   []types.CheckResult
+		expStatus checksStatus
+		expFailed []string
+	}{
+		{
+			name:      "no-required-checks",
+			required:  nil,
+			results:   []types.CheckResult{{Identifier: "lint", Status: enum.CheckStatusFailure}},
+			expStatus: checksPassed,
+		},
+		{
+			name:      "missing-check",
+			required:  []string{"build"},
+			results:   nil,
+			expStatus: checksPending,
+		},
+		{
+			name:     "running-check",
+			required: []string{"build", "test"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusRunning},
+			},
+			expStatus: checksPending,
+		},
+		{
+			name:     "all-passed",
+			required: []string{"build", "test"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusFailureIgnored},
+				{Identifier: "lint", Status: enum.CheckStatusFailure},
+			},
+			expStatus: checksPassed,
+		},
+		{
+			name:     "failed-while-pending",
+			required: []string{"test", "build", "deploy"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusPending},
+				{Identifier: "test", Status: enum.CheckStatusError},
+				{Identifier: "deploy", Status: enum.CheckStatusFailure},
+			},
+			expStatus: checksFailed,
+			expFailed: []string{"deploy", "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, failed := evaluateChecks(test.required, test.results)
+			if status != test.expStatus {
+				t.Errorf("status mismatch: want=%d got=%d", test.expStatus, status)
+			}
+			if !reflect.DeepEqual(failed, test.expFailed) {
+				t.Errorf("failed checks mismatch: want=%v got=%v", test.expFailed, failed)
+			}
+		})
+	}
+}
+
+func TestEvaluateGroups(t *testing.T) {
+	required := []string{"build"}
+	pending := []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusRunning}}
+	passed := []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusSuccess}}
+	failed := []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusFailure}}
+
+	tests := []struct {
+		name    string
+		results [][]types.CheckResult
+		exp     groupsEvaluation
+	}{
+		{
+			name:    "checks-not-reported",
+			results: [][]types.CheckResult{nil, nil},
+			exp:     groupsEvaluation{merge: 0, failed: -1},
+		},
+		{
+			name:    "checks-running",
+			results: [][]types.CheckResult{pending, nil},
+			exp:     groupsEvaluation{merge: 0, failed: -1},
+		},
+		{
+			name:    "first-group-passed",
+			results: [][]types.CheckResult{passed, pending},
+			exp:     groupsEvaluation{merge: 1, failed: -1},
+		},
+		{
+			name:    "later-group-passed-before-first",
+			results: [][]types.CheckResult{pending, passed, nil},
+			exp:     groupsEvaluation{merge: 2, failed: -1},
+		},
+		{
+			name:    "failed-behind-passed",
+			results: [][]types.CheckResult{passed, failed, passed},
+			exp:     groupsEvaluation{merge: 1, failed: 1, failedChecks: []string{"build"}},
+		},
+		{
+			name:    "failed-first",
+			results: [][]types.CheckResult{failed, passed},
+			exp:     groupsEvaluation{merge: 0, failed: 0, failedChecks: []string{"build"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eval := evaluateGroups(required, test.results)
+			if !reflect.DeepEqual(eval, test.exp) {
+				t.Errorf("evaluation mismatch: want=%+v got=%+v", test.exp, eval)
+			}
+		})
+	}
+}
+
+// TestEvaluateGroups_AdvanceOnCheckReport verifies that a merge group waiting for its checks
+// becomes mergeable once the required checks of the merge group commit are reported.
+func TestEvaluateGroups_AdvanceOnCheckReport(t *testing.T) {
+	required := []string{"build", "test"}
+	results := [][]types.CheckResult{nil, nil}
+
+	report := func(group int, identifier string, status enum.CheckStatus) groupsEvaluation {
+		results[group] = append(results[group], types.CheckResult{Identifier: identifier, Status: status})
+		return evaluateGroups(required, results)
+	}
+
+	if eval := evaluateGroups(required, results); eval.merge != 0 || eval.failed != -1 {
+		t.Fatalf("expected the queue to wait for checks, got=%+v", eval)
+	}
+
+	if eval := report(0, "build", enum.CheckStatusSuccess); eval.merge != 0 || eval.failed != -1 {
+		t.Fatalf("expected the queue to wait for the remaining check, got=%+v", eval)
+	}
+
+	if eval := report(0, "test", enum.CheckStatusSuccess); eval.merge != 1 || eval.failed != -1 {
+		t.Fatalf("expected the first group to advance, got=%+v", eval)
+	}
+
+	report(1, "build", enum.CheckStatusSuccess)
+	if eval := report(1, "test", enum.CheckStatusSuccess); eval.merge != 2 || eval.failed != -1 {
+		t.Fatalf("expected both groups to advance, got=%+v", eval)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+// handleTargetBranchUpdated handles branch updated events.
+// If the branch has a merge queue, all merge group commits have to be rebuilt on top of the new branch head.
+func (s *Service) handleTargetBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch, ok := strings.CutPrefix(event.Payload.Ref, "refs/heads/")
+	if !ok {
+		return nil
+	}
+
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, event.Payload.RepoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return s.Trigger(ctx, event.Payload.RepoID, branch)
+}
+
+func (s *Service) handlePullReqBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.triggerForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handlePullReqTargetBranchChanged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.triggerForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handlePullReqClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.triggerForPullReq(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handlePullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.triggerForPullReq(ctx, event.Payload.PullReqID)
+}
+
+// handleCheckReported handles status check reports. If the check is reported for a merge group commit,
+// the merge queue is processed to merge or remove the pull requests.
+func (s *Service) handleCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	entry, err := s.mergeQueueStore.FindByGroupSHA(ctx, event.Payload.RepoID, event.Payload.SHA)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry by merge group commit: %w", err)
+	}
+
+	return s.Trigger(ctx, entry.RepoID, entry.TargetBranch)
+}
+
+// triggerForPullReq processes the merge queue the pull request is in, if any.
+func (s *Service) triggerForPullReq(ctx context.Context, pullreqID int64) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pullreqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return s.Trigger(ctx, entry.RepoID, entry.TargetBranch)
+}
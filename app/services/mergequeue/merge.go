@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// merge fast-forwards the target branch to the merge group commit of the last of the provided groups
+// and marks all pull requests of the groups as merged.
+func (s *Service) merge(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	targetSHA sha.SHA,
+	groups []mergeGroup,
+) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	last := groups[len(groups)-1].entry
+
+	// the target branch is updated with the githooks enabled (but as an internal operation),
+	// so that the rest of the system gets notified about the branch update.
+	writeParams, err := s.createWriteParams(ctx, repo, false)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        last.TargetBranch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    sha.Must(last.GroupSHA),
+		OldValue:    targetSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward target branch to the merge group commit: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("branch", last.TargetBranch).
+		Str("group_sha", last.GroupSHA).
+		Int("pullreq_count", len(groups)).
+		Msg("merge queue fast-forwarded the target branch")
+
+	for _, group := range groups {
+		s.markAsMerged(ctx, repo, group)
+	}
+
+	return nil
+}
+
+// markAsMerged updates the pull request of the merge group after the target branch has been fast-forwarded.
+// At this point the commits are already in the target branch, so failures are logged, but otherwise ignored.
+func (s *Service) markAsMerged(ctx context.Context, repo *types.RepositoryCore, group mergeGroup) {
+	entry := group.entry
+	mergedBy := entry.CreatedBy
+	deleteSourceBranch := entry.DeleteSourceBranch &&
+		group.pr.SourceRepoID != nil && *group.pr.SourceRepoID == group.pr.TargetRepoID
+
+	var activitySeqMerge, activitySeqBranchDeleted int64
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, group.pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := time.Now().UnixMilli()
+		method := entry.Method
+
+		pr.Merged = &nowMilli
+		pr.MergedBy = &mergedBy
+		pr.MergeMethod = &method
+
+		pr.MergeTargetSHA = ptr.String(entry.BaseSHA)
+		pr.MergeBaseSHA = entry.MergeBaseSHA
+		pr.MergeSHA = ptr.String(entry.GroupSHA)
+		pr.MarkAsMerged()
+
+		bypassed := entry.RulesBypassed
+		pr.MergeViolationsBypassed = &bypassed
+
+		// update sequence for PR activities
+		pr.ActivitySeq++
+		activitySeqMerge = pr.ActivitySeq
+
+		if deleteSourceBranch {
+			pr.ActivitySeq++
+			activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to mark pull request from the merge queue as merged")
+		return
+	}
+
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to delete merge queue entry of merged pull request")
+	}
+
+	s.deleteGroupRef(ctx, repo, entry)
+
+	pr.ActivitySeq = activitySeqMerge
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod:   entry.Method,
+		MergeSHA:      entry.GroupSHA,
+		TargetSHA:     entry.BaseSHA,
+		SourceSHA:     entry.SourceSHA,
+		RulesBypassed: entry.RulesBypassed,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.pullreqEvReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  mergedBy,
+			Number:       pr.Number,
+		},
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.GroupSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	})
+
+	if deleteSourceBranch {
+		s.deleteSourceBranch(ctx, repo, pr, mergedBy, activitySeqBranchDeleted, entry.SourceSHA)
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+}
+
+func (s *Service) deleteSourceBranch(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+	activitySeq int64,
+	sourceSHA string,
+) {
+	writeParams, err := s.createWriteParams(ctx, repo, false)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to create write params for source branch deletion")
+		return
+	}
+
+	err = s.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  pr.SourceBranch,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to delete source branch after merging")
+		return
+	}
+
+	// NOTE: there is a chance someone pushed on the branch between merge and delete.
+	// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
+	pr.ActivitySeq = activitySeq
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, principalID,
+		&types.PullRequestActivityPayloadBranchDelete{SHA: sourceSHA}, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).
+			Msgf("failed to write pull request activity for successful automatic branch delete")
+	}
+}
+
+// createSystemReferencesWriteParams creates write parameters for operations on the merge queue references.
+func (s *Service) createSystemReferencesWriteParams(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+) (git.WriteParams, error) {
+	return s.createWriteParams(ctx, repo, true)
+}
+
+// createWriteParams creates internal write parameters with the system principal as the actor.
+func (s *Service) createWriteParams(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	disableHooks bool,
+) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		repo.ID,
+		principal.ID,
+		disableHooks,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
@@ -0,0 +1,481 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitapi "github.com/harness/gitness/git/api"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// lockExpiry is the max time the repository is locked for the processing of a merge queue.
+	lockExpiry = 5 * time.Minute
+
+	// maxProcessRounds limits the number of times a merge queue is re-evaluated within a single job execution.
+	maxProcessRounds = 10
+)
+
+type processJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*processJob)(nil)
+
+func (j *processJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input processJobInput
+	if err := json.NewDecoder(strings.NewReader(data)).Decode(&input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal merge queue job input json: %w", err)
+	}
+
+	if err := j.service.process(ctx, input.RepoID, input.Branch); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+type sweepJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*sweepJob)(nil)
+
+func (j *sweepJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	queues, err := j.service.mergeQueueStore.ListQueues(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queues: %w", err)
+	}
+
+	for _, q := range queues {
+		if err := j.service.process(ctx, q.RepoID, q.Branch); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", q.RepoID).
+				Str("branch", q.Branch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return "", nil
+}
+
+// process brings the merge queue of the branch up to date:
+// It removes entries of pull requests that can't be merged anymore, (re)creates the merge group commits,
+// merges the pull requests whose merge group passed the required status checks
+// and removes the pull requests whose merge group failed them.
+func (s *Service) process(ctx context.Context, repoID int64, branch string) error {
+	unlock, err := s.locker.LockPR(ctx, repoID, 0, lockExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue processing: %w", err)
+	}
+	defer unlock()
+
+	repo, err := s.repoFinder.FindByID(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	for range maxProcessRounds {
+		changed, err := s.processRound(ctx, repo, branch)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// processRound executes a single pass over the merge queue. It returns true if the queue needs to be re-evaluated.
+//
+//nolint:gocognit // the steps are easier to follow in a single function
+func (s *Service) processRound(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	branch string,
+) (bool, error) {
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, repo.ID, branch)
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	systemPrincipalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	targetBranch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: branch,
+	})
+	if errors.IsNotFound(err) {
+		// the target branch is gone - no pull request from the queue can be merged.
+		for _, entry := range entries {
+			if err := s.removeByEntry(ctx, repo, systemPrincipalID, entry,
+				enum.MergeQueueRemoveReasonTargetChanged, nil); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	targetSHA := targetBranch.Branch.SHA
+
+	// build merge groups
+
+	base := targetSHA
+	groups := make([]mergeGroup, 0, len(entries))
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if reason, ok := entryObsolete(entry, pr); ok {
+			if err := s.remove(ctx, repo, systemPrincipalID, pr, entry, reason, nil); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if entry.GroupSHA != "" && entry.BaseSHA == base.String() {
+			// the merge group commit is up to date.
+			groups = append(groups, mergeGroup{entry: entry, pr: pr})
+			base = sha.Must(entry.GroupSHA)
+			continue
+		}
+
+		groupSHA, conflicts, err := s.buildGroup(ctx, repo, pr, entry, base)
+		if err != nil {
+			return false, err
+		}
+
+		if groupSHA.IsEmpty() {
+			if err := s.remove(ctx, repo, systemPrincipalID, pr, entry,
+				enum.MergeQueueRemoveReasonConflict, conflicts); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		groups = append(groups, mergeGroup{entry: entry, pr: pr})
+		base = groupSHA
+	}
+
+	if len(groups) == 0 {
+		return false, nil
+	}
+
+	// evaluate status checks of the merge groups
+
+	protectionRules, err := s.protectionManager.ListRepoBranchRules(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	// no actor: status checks can't be bypassed in the merge queue.
+	requiredChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Repo:    repo,
+		PullReq: groups[0].pr,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	required := make([]string, 0, len(requiredChecks.RequiredIdentifiers)+len(requiredChecks.BypassableIdentifiers))
+	for id := range requiredChecks.RequiredIdentifiers {
+		required = append(required, id)
+	}
+	for id := range requiredChecks.BypassableIdentifiers {
+		required = append(required, id)
+	}
+
+	results := make([][]types.CheckResult, len(groups))
+	for i, group := range groups {
+		results[i], err = s.checkStore.ListResults(ctx, repo.ID, group.entry.GroupSHA)
+		if err != nil {
+			return false, fmt.Errorf("failed to list status checks of merge group: %w", err)
+		}
+	}
+
+	eval := evaluateGroups(required, results)
+
+	if eval.failed < 0 {
+		if eval.merge == 0 {
+			return false, nil
+		}
+
+		if err := s.merge(ctx, repo, targetSHA, groups[:eval.merge]); err != nil {
+			return false, err
+		}
+
+		return eval.merge < len(groups), nil
+	}
+
+	// Merge everything ahead of the failed group that is already proven to be good,
+	// then remove the failed pull request. The groups behind it must be rebuilt without it.
+	if eval.merge > 0 {
+		if err := s.merge(ctx, repo, targetSHA, groups[:eval.merge]); err != nil {
+			return false, err
+		}
+	}
+
+	failedGroup := groups[eval.failed]
+	if err := s.remove(ctx, repo, systemPrincipalID, failedGroup.pr, failedGroup.entry,
+		enum.MergeQueueRemoveReasonChecksFailed, eval.failedChecks); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type mergeGroup struct {
+	entry *types.MergeQueueEntry
+	pr    *types.PullReq
+}
+
+// entryObsolete returns if the merge queue entry can't be processed anymore and the reason for it.
+func entryObsolete(entry *types.MergeQueueEntry, pr *types.PullReq) (enum.MergeQueueRemoveReason, bool) {
+	switch {
+	case pr.State != enum.PullReqStateOpen:
+		return enum.MergeQueueRemoveReasonClosed, true
+	case pr.TargetBranch != entry.TargetBranch:
+		return enum.MergeQueueRemoveReasonTargetChanged, true
+	case pr.SourceSHA != entry.SourceSHA:
+		return enum.MergeQueueRemoveReasonBranchUpdated, true
+	default:
+		return "", false
+	}
+}
+
+// buildGroup creates the merge group commit of the entry on top of the provided base commit.
+// It returns an empty SHA and the list of conflicting files if the pull request can't be merged.
+func (s *Service) buildGroup(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	base sha.SHA,
+) (sha.SHA, []string, error) {
+	writeParams, err := s.createSystemReferencesWriteParams(ctx, repo)
+	if err != nil {
+		return sha.None, nil, err
+	}
+
+	refMergeQueue, err := git.GetRefPath(strconv.FormatInt(entry.PullReqNumber, 10), gitenum.RefTypeMergeQueue)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to generate merge queue ref name: %w", err)
+	}
+
+	author, committer, err := s.commitIdentities(ctx, pr, entry)
+	if err != nil {
+		return sha.None, nil, err
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   writeParams,
+		BaseSHA:       base,
+		HeadSHA:       sha.Must(entry.SourceSHA),
+		Message:       git.CommitMessage(entry.Title, entry.Message),
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		Refs: []git.RefUpdate{
+			{
+				Name: refMergeQueue,
+				Old:  sha.SHA{}, // don't care about the old value.
+				New:  sha.SHA{}, // update to the result of the merge.
+			},
+		},
+		Force:  true,
+		Method: gitenum.MergeMethod(entry.Method),
+	})
+	if errors.IsInvalidArgument(err) || gitapi.IsUnrelatedHistoriesError(err) {
+		return sha.None, []string{err.Error()}, nil
+	}
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to create merge group commit: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return sha.None, mergeOutput.ConflictFiles, nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = base.String()
+	entry.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+	entry.GroupSHA = mergeOutput.MergeSHA.String()
+
+	if err := s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return sha.None, nil, fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	// The merge group ref is written with git hooks disabled, so no push event is produced for it.
+	// Report the merge group explicitly to let the pipelines produce the required status checks.
+	s.pullreqEvReporter.MergeGroupCreated(ctx, &pullreqevents.MergeGroupCreatedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  entry.CreatedBy,
+			Number:       pr.Number,
+		},
+		Ref:      refMergeQueue,
+		BaseSHA:  entry.BaseSHA,
+		GroupSHA: entry.GroupSHA,
+	})
+
+	return mergeOutput.MergeSHA, nil, nil
+}
+
+// commitIdentities returns the author and the committer of the merge group commit.
+// They follow the same rules as the ones used for merging pull requests directly.
+func (s *Service) commitIdentities(
+	ctx context.Context,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (*git.Identity, *git.Identity, error) {
+	enqueuedBy, err := s.principalInfoCache.Get(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	enqueuedByIdentity := &git.Identity{Name: enqueuedBy.DisplayName, Email: enqueuedBy.Email}
+	authorIdentity := &git.Identity{Name: pr.Author.DisplayName, Email: pr.Author.Email}
+
+	system := bootstrap.NewSystemServiceSession().Principal
+	systemIdentity := &git.Identity{Name: system.DisplayName, Email: system.Email}
+
+	switch entry.Method {
+	case enum.MergeMethodMerge:
+		return enqueuedByIdentity, systemIdentity, nil
+	case enum.MergeMethodSquash:
+		return authorIdentity, systemIdentity, nil
+	case enum.MergeMethodRebase:
+		return nil, enqueuedByIdentity, nil
+	case enum.MergeMethodFastForward:
+		return nil, nil, nil
+	}
+
+	return nil, nil, nil
+}
+
+// remove removes the entry from the merge queue and adds the activity to the pull request.
+func (s *Service) remove(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	principalID int64,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	reason enum.MergeQueueRemoveReason,
+	details []string,
+) error {
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	s.deleteGroupRef(ctx, repo, entry)
+
+	log.Ctx(ctx).Info().
+		Int64("pullreq_number", entry.PullReqNumber).
+		Str("reason", string(reason)).
+		Msg("pull request removed from the merge queue")
+
+	// the activity isn't needed for pull requests that aren't open anymore (e.g. merged directly).
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadMergeQueueRemove{
+		Reason:    reason,
+		SourceSHA: entry.SourceSHA,
+		GroupSHA:  entry.GroupSHA,
+		Details:   details,
+	})
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return nil
+}
+
+func (s *Service) removeByEntry(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	principalID int64,
+	entry *types.MergeQueueEntry,
+	reason enum.MergeQueueRemoveReason,
+	details []string,
+) error {
+	pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.remove(ctx, repo, principalID, pr, entry, reason, details)
+}
+
+// deleteGroupRef deletes the merge queue reference of the entry. Failures are logged, but otherwise ignored.
+func (s *Service) deleteGroupRef(ctx context.Context, repo *types.RepositoryCore, entry *types.MergeQueueEntry) {
+	if entry.GroupSHA == "" {
+		return
+	}
+
+	writeParams, err := s.createSystemReferencesWriteParams(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create write params for merge queue ref deletion")
+		return
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(entry.PullReqNumber, 10),
+		Type:        gitenum.RefTypeMergeQueue,
+		NewValue:    sha.Nil,
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref")
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeProcess        = "merge-queue-process"
+	jobMaxRetriesProcess  = 3
+	jobMaxDurationProcess = 10 * time.Minute
+
+	jobTypeSweep        = "merge-queue-sweep"
+	jobCronSweep        = "*/10 * * * *" // Every 10 minutes.
+	jobMaxDurationSweep = 30 * time.Minute
+)
+
+// Service maintains the per-branch merge queues. Pull requests are added to the queue of their target branch
+// instead of being merged directly. For each queued pull request the service creates a merge group commit
+// (stored in refs/merge-queue/<pr number>) which contains the pull request merged on top of all pull requests
+// ahead of it in the queue. Once the required status checks pass on a merge group commit, the target branch
+// is fast-forwarded to it and all pull requests included in it are marked as merged.
+type Service struct {
+	git                git.Interface
+	urlProvider        url.Provider
+	locker             *locker.Locker
+	scheduler          *job.Scheduler
+	repoFinder         refcache.RepoFinder
+	pullreqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	checkStore         store.CheckStore
+	mergeQueueStore    store.MergeQueueStore
+	principalInfoCache store.PrincipalInfoCache
+	protectionManager  *protection.Manager
+	pullreqEvReporter  *pullreqevents.Reporter
+	sseStreamer        sse.Streamer
+}
+
+//nolint:funlen // event registration
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	gitInterface git.Interface,
+	urlProvider url.Provider,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	repoFinder refcache.RepoFinder,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	s := &Service{
+		git:                gitInterface,
+		urlProvider:        urlProvider,
+		locker:             locker,
+		scheduler:          scheduler,
+		repoFinder:         repoFinder,
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		checkStore:         checkStore,
+		mergeQueueStore:    mergeQueueStore,
+		principalInfoCache: principalInfoCache,
+		protectionManager:  protectionManager,
+		pullreqEvReporter:  pullreqEvReporter,
+		sseStreamer:        sseStreamer,
+	}
+
+	if err := executor.Register(jobTypeProcess, &processJob{service: s}); err != nil {
+		return nil, fmt.Errorf("failed to register merge queue process job: %w", err)
+	}
+
+	if err := executor.Register(jobTypeSweep, &sweepJob{service: s}); err != nil {
+		return nil, fmt.Errorf("failed to register merge queue sweep job: %w", err)
+	}
+
+	const groupGit = "gitness:mergequeue:git"
+	_, err := gitReaderFactory.Launch(ctx, groupGit, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 15 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterBranchUpdated(s.handleTargetBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupPullReq = "gitness:mergequeue:pullreq"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 15 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterBranchUpdated(s.handlePullReqBranchUpdated)
+			_ = r.RegisterTargetBranchChanged(s.handlePullReqTargetBranchChanged)
+			_ = r.RegisterClosed(s.handlePullReqClosed)
+			_ = r.RegisterMerged(s.handlePullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupCheck = "gitness:mergequeue:check"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheck, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 15 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterReported(s.handleCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Register schedules the recurring job that periodically processes all non-empty merge queues.
+// It makes sure queues progress even if an event has been missed.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeSweep, jobTypeSweep, jobCronSweep, jobMaxDurationSweep)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queue: %w", err)
+	}
+
+	return nil
+}
+
+type EnqueueInput struct {
+	Method             enum.MergeMethod
+	Title              string
+	Message            string
+	DeleteSourceBranch bool
+	RulesBypassed      bool
+}
+
+// Enqueue adds the pull request to the end of the merge queue of its target branch.
+// The caller is responsible for verifying that the pull request can be merged.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	principalID int64,
+	pr *types.PullReq,
+	in EnqueueInput,
+) (*types.MergeQueueEntry, error) {
+	if in.Method == enum.MergeMethodFastForward {
+		return nil, errors.InvalidArgument("Fast-forward merge method can't be used with the merge queue.")
+	}
+
+	_, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return nil, errors.Conflict("Pull request is already in the merge queue.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, pr.TargetRepoID, pr.TargetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		Version:            0,
+		Created:            now,
+		Updated:            now,
+		CreatedBy:          principalID,
+		RepoID:             pr.TargetRepoID,
+		PullReqID:          pr.ID,
+		PullReqNumber:      pr.Number,
+		TargetBranch:       pr.TargetBranch,
+		State:              enum.MergeQueueEntryStateQueued,
+		Method:             in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		RulesBypassed:      in.RulesBypassed,
+		SourceSHA:          pr.SourceSHA,
+	}
+
+	if err := s.mergeQueueStore.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to create merge queue entry: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadMergeQueueAdd{
+		MergeMethod: in.Method,
+		SourceSHA:   pr.SourceSHA,
+		Position:    len(entries) + 1,
+	})
+
+	if err := s.Trigger(ctx, entry.RepoID, entry.TargetBranch); err != nil {
+		// non-critical error, the queue will be processed by the recurring job
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to trigger merge queue processing")
+	}
+
+	return entry, nil
+}
+
+// Dequeue removes the pull request from the merge queue of its target branch.
+func (s *Service) Dequeue(
+	ctx context.Context,
+	principalID int64,
+	pr *types.PullReq,
+) error {
+	unlock, err := s.locker.LockPR(ctx, pr.TargetRepoID, 0, lockExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue update: %w", err)
+	}
+	defer unlock()
+
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return errors.NotFound("Pull request is not in the merge queue.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, entry.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	err = s.remove(ctx, repo, principalID, pr, entry, enum.MergeQueueRemoveReasonDequeued, nil)
+	if err != nil {
+		return err
+	}
+
+	// all entries behind the removed one have to be rebuilt.
+	if err := s.Trigger(ctx, entry.RepoID, entry.TargetBranch); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to trigger merge queue processing")
+	}
+
+	return nil
+}
+
+// List returns the merge queue of the branch.
+func (s *Service) List(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error) {
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, repoID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Find returns the merge queue entry of the pull request.
+func (s *Service) Find(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	return s.mergeQueueStore.FindByPullReqID(ctx, pullreqID)
+}
+
+type processJobInput struct {
+	RepoID int64  `json:"repo_id"`
+	Branch string `json:"branch"`
+}
+
+// Trigger starts a background job that processes the merge queue of the branch.
+func (s *Service) Trigger(ctx context.Context, repoID int64, branch string) error {
+	var idRaw [10]byte
+	if _, err := rand.Read(idRaw[:]); err != nil {
+		return fmt.Errorf("could not generate merge queue job ID: %w", err)
+	}
+
+	data, err := json.Marshal(processJobInput{
+		RepoID: repoID,
+		Branch: branch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal merge queue job input: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobTypeProcess + "-" + base32.StdEncoding.EncodeToString(idRaw[:]),
+		Type:       jobTypeProcess,
+		MaxRetries: jobMaxRetriesProcess,
+		Timeout:    jobMaxDurationProcess,
+		Data:       string(data),
+	})
+}
+
+// writeActivity adds a system activity to the pull request. Failures are logged, but otherwise ignored.
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request activity sequence")
+		return
+	}
+
+	if _, err := s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to write pull request %s activity", payload.ActivityType())
+	}
+}
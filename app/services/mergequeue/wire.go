@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	gitInterface git.Interface,
+	urlProvider url.Provider,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	repoFinder refcache.RepoFinder,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	mergeQueueStore store.MergeQueueStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		gitReaderFactory,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		pullreqEvReporter,
+		gitInterface,
+		urlProvider,
+		locker,
+		scheduler,
+		executor,
+		repoFinder,
+		pullreqStore,
+		activityStore,
+		checkStore,
+		mergeQueueStore,
+		principalInfoCache,
+		protectionManager,
+		sseStreamer,
+	)
+}
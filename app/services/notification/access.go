@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// repoAccessFilter leaves out the principals that aren't allowed to view a repository,
+// e.g. users watching a public space that contains private repositories, or users who lost their membership.
+type repoAccessFilter struct {
+	authorizer     authz.Authorizer
+	principalStore store.PrincipalStore
+}
+
+// filter returns the principals that are allowed to view the repository.
+func (f repoAccessFilter) filter(
+	ctx context.Context,
+	repo *types.Repository,
+	principals []*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, error) {
+	allowed := make([]*types.PrincipalInfo, 0, len(principals))
+	for _, principalInfo := range principals {
+		principal, err := f.principalStore.Find(ctx, principalInfo.ID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find principal: %w", err)
+		}
+
+		if principal.Blocked {
+			continue
+		}
+
+		// To check the principal's access to the repo we create a dummy session object.
+		err = apiauth.CheckRepo(ctx, f.authorizer, &auth.Session{Principal: *principal},
+			repo.Core(), enum.PermissionRepoView)
+		if apiauth.IsNoAccess(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check principal's access to the repository: %w", err)
+		}
+
+		allowed = append(allowed, principalInfo)
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	memberID   = int64(1)
+	outsiderID = int64(2)
+	blockedID  = int64(3)
+	deletedID  = int64(4)
+	adminID    = int64(5)
+)
+
+func newTestRepoAccessFilter() repoAccessFilter {
+	return repoAccessFilter{
+		authorizer: &testAuthorizer{viewers: []int64{memberID, blockedID}},
+		principalStore: &testPrincipalStore{principals: map[int64]*types.Principal{
+			memberID:   {ID: memberID},
+			outsiderID: {ID: outsiderID},
+			blockedID:  {ID: blockedID, Blocked: true},
+			adminID:    {ID: adminID, Admin: true},
+		}},
+	}
+}
+
+func TestRepoAccessFilter(t *testing.T) {
+	repo := &types.Repository{ID: 10, Path: "space/private-repo"}
+
+	recipients := []*types.PrincipalInfo{{ID: memberID}, {ID: outsiderID}, {ID: blockedID}, {ID: deletedID}, {ID: adminID}}
+
+	allowed, err := newTestRepoAccessFilter().filter(context.Background(), repo, recipients)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids := principalIDs(allowed); !slices.Equal(ids, []int64{memberID, adminID}) {
+		t.Errorf("expected recipients %v, got %v", []int64{memberID, adminID}, ids)
+	}
+}
+
+func TestSSEClient_RepoAccess(t *testing.T) {
+	streamer := &testStreamer{}
+	client := &SSEClient{sseStreamer: streamer, repoAccess: newTestRepoAccessFilter()}
+
+	repo := &types.Repository{ID: 10, Identifier: "private-repo", Path: "space/private-repo"}
+
+	err := client.SendTagCreated(context.Background(),
+		[]*types.PrincipalInfo{{ID: memberID}, {ID: outsiderID}},
+		&TagCreatedPayload{Repo: repo, Tag: "v1.0.0", Tagger: &types.PrincipalInfo{ID: adminID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(streamer.principalIDs, []int64{memberID}) {
+		t.Errorf("expected notification published to %v, got %v", []int64{memberID}, streamer.principalIDs)
+	}
+}
+
+func principalIDs(principals []*types.PrincipalInfo) []int64 {
+	ids := make([]int64, len(principals))
+	for i, p := range principals {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// testAuthorizer grants the repository view permission to the admins and to the listed principals.
+type testAuthorizer struct {
+	authz.Authorizer
+	viewers []int64
+}
+
+func (a *testAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if permission != enum.PermissionRepoView {
+		return false, nil
+	}
+	return session.Principal.Admin || slices.Contains(a.viewers, session.Principal.ID), nil
+}
+
+type testPrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *testPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+type testStreamer struct {
+	sse.Streamer
+	principalIDs []int64
+}
+
+func (s *testStreamer) PublishToPrincipal(_ context.Context, principalID int64, _ enum.SSEType, _ any) {
+	s.principalIDs = append(s.principalIDs, principalID)
+}
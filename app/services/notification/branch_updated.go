@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -43,14 +44,11 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
-		return nil
-	}
-
+	// the client is called even without any reviewers, because chat channels are notified regardless of them.
 	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
 	if err != nil {
 		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
+			"failed to send notification for event %s for pullReqID %d: %w",
 			pullreqevents.BranchUpdatedEvent,
 			event.Payload.PullReqID,
 			err,
@@ -88,6 +86,13 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 		}
 	}
 
+	watchers, err := s.processWatchers(
+		ctx, base.Repo, enum.WatchLevel.IncludesPullReqs, committer.ID, reviewerPrincipals)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviewerPrincipals = append(reviewerPrincipals, watchers...)
+
 	return &PullReqBranchUpdatedPayload{
 		Base:      base,
 		NewSHA:    event.Payload.NewSHA,
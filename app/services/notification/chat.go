@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	chatTemplatesDir = "templates/chat"
+
+	// chatRequestTimeout is the time limit of a single request to a notification channel.
+	chatRequestTimeout = 10 * time.Second
+
+	// chatResponseBodyBytesLimit is the max number of bytes of the response body included in errors.
+	chatResponseBodyBytesLimit = 256
+)
+
+var chatTemplates map[string]*template.Template
+
+// chatMessage is a notification rendered for a specific type of notification channel.
+type chatMessage struct {
+	Event string
+	Title string
+	Text  string
+	URL   string
+}
+
+// chatFormat defines how messages are formatted and posted for a type of notification channel.
+type chatFormat interface {
+	// Text escapes the text so that it's displayed as is.
+	Text(text string) string
+	// Bold returns the text formatted in bold.
+	Bold(text string) string
+	// Link returns a hyperlink with the provided text.
+	Link(text, url string) string
+	// Body returns the request body posted to the channel.
+	Body(msg *chatMessage) any
+}
+
+var chatFormats = map[enum.NotificationChannelType]chatFormat{
+	enum.NotificationChannelTypeSlack:   slackFormat{},
+	enum.NotificationChannelTypeTeams:   teamsFormat{},
+	enum.NotificationChannelTypeGeneric: genericFormat{},
+}
+
+// slackFormat formats messages for Slack incoming webhooks using the mrkdwn syntax.
+type slackFormat struct{}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (slackFormat) Text(text string) string { return slackEscaper.Replace(text) }
+
+func (f slackFormat) Bold(text string) string { return "*" + f.Text(text) + "*" }
+
+func (f slackFormat) Link(text, url string) string { return "<" + url + "|" + f.Text(text) + ">" }
+
+func (slackFormat) Body(msg *chatMessage) any {
+	return map[string]any{
+		"text": msg.Text,
+	}
+}
+
+// teamsFormat formats messages for Microsoft Teams incoming webhooks as markdown message cards.
+type teamsFormat struct{}
+
+var teamsEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;", "#", `\#`)
+
+func (teamsFormat) Text(text string) string { return teamsEscaper.Replace(text) }
+
+func (f teamsFormat) Bold(text string) string { return "**" + f.Text(text) + "**" }
+
+func (f teamsFormat) Link(text, url string) string { return "[" + f.Text(text) + "](" + url + ")" }
+
+func (teamsFormat) Body(msg *chatMessage) any {
+	return map[string]any{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  msg.Title,
+		"title":    msg.Title,
+		// Teams renders single line breaks only if they are preceded by two spaces.
+		"text": strings.ReplaceAll(msg.Text, "\n", "  \n"),
+	}
+}
+
+// genericFormat posts messages as plain JSON documents, intended for custom integrations.
+type genericFormat struct{}
+
+func (genericFormat) Text(text string) string { return text }
+
+func (genericFormat) Bold(text string) string { return text }
+
+func (genericFormat) Link(text, url string) string { return text + " (" + url + ")" }
+
+func (genericFormat) Body(msg *chatMessage) any {
+	return map[string]any{
+		"event": msg.Event,
+		"title": msg.Title,
+		"text":  msg.Text,
+		"url":   msg.URL,
+	}
+}
+
+func LoadChatTemplates() error {
+	chatTemplates = make(map[string]*template.Template)
+
+	tmplFiles, err := files.ReadDir(chatTemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	// The functions are replaced with the ones of the chat format when a template is executed.
+	funcs := chatTemplateFuncs(genericFormat{})
+
+	for _, tmpl := range tmplFiles {
+		if tmpl.IsDir() {
+			continue
+		}
+
+		pt, err := template.New(tmpl.Name()).Funcs(funcs).ParseFS(files, path.Join(chatTemplatesDir, tmpl.Name()))
+		if err != nil {
+			return err
+		}
+
+		chatTemplates[strings.TrimSuffix(tmpl.Name(), path.Ext(tmpl.Name()))] = pt
+	}
+
+	return nil
+}
+
+func chatTemplateFuncs(format chatFormat) template.FuncMap {
+	return template.FuncMap{
+		"text": func(v any) string { return format.Text(fmt.Sprint(v)) },
+		"bold": format.Bold,
+		"link": format.Link,
+		"pullreq": func(base *BasePullReqPayload) string {
+			return format.Link(fmt.Sprintf("#%d %s", base.PullReq.Number, base.PullReq.Title), base.PullReqURL)
+		},
+	}
+}
+
+// renderChatMessage renders the chat template of the event using the format of the channel type.
+func renderChatMessage(
+	channelType enum.NotificationChannelType,
+	event string,
+	title string,
+	url string,
+	data any,
+) (*chatMessage, chatFormat, error) {
+	format, ok := chatFormats[channelType]
+	if !ok {
+		return nil, nil, fmt.Errorf("notification channel type %q is not supported", channelType)
+	}
+
+	tmpl, ok := chatTemplates[event]
+	if !ok {
+		return nil, nil, fmt.Errorf("chat template for event %q not found", event)
+	}
+
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to clone chat template %q: %w", event, err)
+	}
+
+	out := bytes.Buffer{}
+	if err := tmpl.Funcs(chatTemplateFuncs(format)).Execute(&out, data); err != nil {
+		return nil, nil, fmt.Errorf("failed to execute chat template %q: %w", event, err)
+	}
+
+	return &chatMessage{
+		Event: event,
+		Title: title,
+		Text:  strings.TrimSpace(out.String()),
+		URL:   url,
+	}, format, nil
+}
+
+// postChatMessage posts the message to the incoming webhook URL of a notification channel.
+func postChatMessage(
+	ctx context.Context,
+	httpClient *http.Client,
+	channelURL string,
+	format chatFormat,
+	msg *chatMessage,
+) error {
+	body, err := json.Marshal(format.Body(msg))
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chatRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channelURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, chatResponseBodyBytesLimit))
+		return fmt.Errorf("received response with status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitnesshttp "github.com/harness/gitness/http"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	chatEventPullReqCreated       = "pullreq_created"
+	chatEventReviewerAdded        = "reviewer_added"
+	chatEventReviewSubmitted      = "review_submitted"
+	chatEventPullReqBranchUpdated = "pullreq_branch_updated"
+	chatEventPullReqStateChanged  = "pullreq_state_changed"
+	chatEventWebhookDisabled      = "webhook_disabled"
+)
+
+// ChatClient posts notifications to the notification channels (Slack, Microsoft Teams, ...)
+// defined in the repository and its parent spaces.
+// Recipients are ignored, every message is posted to all enabled channels.
+// Delivery is best effort, a failure of a channel doesn't affect the other channels.
+type ChatClient struct {
+	channelStore store.NotificationChannelStore
+	spaceStore   store.SpaceStore
+	repoStore    store.RepoStore
+	encrypter    encrypt.Encrypter
+	httpClient   *http.Client
+}
+
+func NewChatClient(
+	config Config,
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	encrypter encrypt.Encrypter,
+) *ChatClient {
+	return &ChatClient{
+		channelStore: channelStore,
+		spaceStore:   spaceStore,
+		repoStore:    repoStore,
+		encrypter:    encrypter,
+		httpClient:   gitnesshttp.NewClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+	}
+}
+
+// SendCommentPRAuthor is a no-op, chat channels aren't notified about comments.
+func (c *ChatClient) SendCommentPRAuthor(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+// SendCommentMentions is a no-op, chat channels aren't notified about comments.
+func (c *ChatClient) SendCommentMentions(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+// SendCommentParticipants is a no-op, chat channels aren't notified about comments.
+func (c *ChatClient) SendCommentParticipants(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendPullReqCreated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return c.sendForPullReq(ctx, chatEventPullReqCreated, payload.Base, payload)
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.sendForPullReq(ctx, chatEventReviewerAdded, payload.Base, payload)
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.sendForPullReq(ctx, chatEventPullReqBranchUpdated, payload.Base, payload)
+}
+
+func (c *ChatClient) SendReviewSubmitted(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.sendForPullReq(ctx, chatEventReviewSubmitted, payload.Base, payload)
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.sendForPullReq(ctx, chatEventPullReqStateChanged, payload.Base, payload)
+}
+
+func (c *ChatClient) SendWebhookDisabled(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	var (
+		channels []*types.NotificationChannel
+		err      error
+	)
+
+	switch payload.Webhook.ParentType {
+	case enum.WebhookParentRepo:
+		var repo *types.Repository
+		repo, err = c.repoStore.Find(ctx, payload.Webhook.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find webhook repository: %w", err)
+		}
+		channels, err = c.listChannels(ctx, repo.ParentID, repo.ID)
+	case enum.WebhookParentSpace:
+		channels, err = c.listChannels(ctx, payload.Webhook.ParentID, 0)
+	case enum.WebhookParentRegistry:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf(subjectWebhookDisabled, payload.ParentPath, payload.Webhook.Identifier)
+
+	c.send(ctx, channels, chatEventWebhookDisabled, title, "", payload)
+
+	return nil
+}
+
+// SendTagCreated is a no-op, chat channels aren't notified about new tags.
+func (c *ChatClient) SendTagCreated(context.Context, []*types.PrincipalInfo, *TagCreatedPayload) error {
+	return nil
+}
+
+func (c *ChatClient) sendForPullReq(
+	ctx context.Context,
+	event string,
+	base *BasePullReqPayload,
+	payload any,
+) error {
+	channels, err := c.listChannels(ctx, base.Repo.ParentID, base.Repo.ID)
+	if err != nil {
+		return err
+	}
+
+	title := GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title)
+
+	c.send(ctx, channels, event, title, base.PullReqURL, payload)
+
+	return nil
+}
+
+// listChannels returns the enabled notification channels of the repository (if provided) and of all its ancestors.
+func (c *ChatClient) listChannels(
+	ctx context.Context,
+	spaceID int64,
+	repoID int64,
+) ([]*types.NotificationChannel, error) {
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor space ids: %w", err)
+	}
+
+	channels, err := c.channelStore.ListEnabledInScopes(ctx, repoID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+func (c *ChatClient) send(
+	ctx context.Context,
+	channels []*types.NotificationChannel,
+	event string,
+	title string,
+	url string,
+	payload any,
+) {
+	for _, channel := range channels {
+		err := c.sendToChannel(ctx, channel, event, title, url, payload)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("notification_channel.id", channel.ID).
+				Str("event", event).
+				Msg("failed to post message to notification channel")
+		}
+	}
+}
+
+func (c *ChatClient) sendToChannel(
+	ctx context.Context,
+	channel *types.NotificationChannel,
+	event string,
+	title string,
+	url string,
+	payload any,
+) error {
+	msg, format, err := renderChatMessage(channel.Type, event, title, url, payload)
+	if err != nil {
+		return err
+	}
+
+	channelURL, err := c.encrypter.Decrypt([]byte(channel.URL))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt notification channel url: %w", err)
+	}
+
+	return postChatMessage(ctx, c.httpClient, channelURL, format, msg)
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestChatMessage(t *testing.T) {
+	if err := LoadChatTemplates(); err != nil {
+		t.Fatalf("failed to load chat templates: %v", err)
+	}
+
+	payload := &ReviewerAddedPayload{
+		Base: &BasePullReqPayload{
+			Repo:       &types.Repository{Identifier: "repo"},
+			PullReq:    &types.PullReq{Number: 7, Title: "Fix <b> & [x]"},
+			Author:     &types.PrincipalInfo{DisplayName: "Author"},
+			PullReqURL: "https://example.com/pr/7",
+		},
+		Reviewer: &types.PrincipalInfo{DisplayName: "Jane_Doe"},
+	}
+
+	tests := []struct {
+		name        string
+		channelType enum.NotificationChannelType
+		status      int
+		expectedErr bool
+		expected    map[string]any
+	}{
+		{
+			name:        "slack",
+			channelType: enum.NotificationChannelTypeSlack,
+			status:      http.StatusOK,
+			expected: map[string]any{
+				"text": "*Jane_Doe* was added as a reviewer for pull request " +
+					"<https://example.com/pr/7|#7 Fix &lt;b&gt; &amp; [x]>",
+			},
+		},
+		{
+			name:        "teams",
+			channelType: enum.NotificationChannelTypeTeams,
+			status:      http.StatusOK,
+			expected: map[string]any{
+				"@type":    "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"summary":  "title",
+				"title":    "title",
+				"text": `**Jane\_Doe** was added as a reviewer for pull request ` +
+					`[\#7 Fix &lt;b&gt; & \[x\]](https://example.com/pr/7)`,
+			},
+		},
+		{
+			name:        "generic",
+			channelType: enum.NotificationChannelTypeGeneric,
+			status:      http.StatusNoContent,
+			expected: map[string]any{
+				"event": chatEventReviewerAdded,
+				"title": "title",
+				"text":  "Jane_Doe was added as a reviewer for pull request #7 Fix <b> & [x] (https://example.com/pr/7)",
+				"url":   "https://example.com/pr/7",
+			},
+		},
+		{
+			name:        "error-status",
+			channelType: enum.NotificationChannelTypeGeneric,
+			status:      http.StatusInternalServerError,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("unexpected content type: %s", ct)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Errorf("failed to unmarshal request body: %v", err)
+				}
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			msg, format, err := renderChatMessage(
+				test.channelType, chatEventReviewerAdded, "title", payload.Base.PullReqURL, payload)
+			if err != nil {
+				t.Fatalf("failed to render message: %v", err)
+			}
+
+			err = postChatMessage(context.Background(), server.Client(), server.URL, format, msg)
+			if test.expectedErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to post message: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected request body:\n got: %v\nwant: %v", got, test.expected)
+			}
+		})
+	}
+}
@@ -21,7 +21,8 @@ import (
 )
 
 // Client is an interface for sending notifications, such as emails, Slack messages etc.
-// It is implemented by MailClient and in future we can have other implementations for other channels like Slack etc.
+// It is implemented by MailClient for emails, by ChatClient for chat notification channels (Slack, Teams, ...)
+// and by SSEClient for the notification stream of the web UI.
 type Client interface {
 	SendCommentPRAuthor(
 		ctx context.Context,
@@ -38,6 +39,11 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *CommentPayload,
 	) error
+	SendPullReqCreated(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *PullReqCreatedPayload,
+	) error
 	SendReviewerAdded(
 		ctx context.Context,
 		recipients []*types.PrincipalInfo,
@@ -58,4 +64,14 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendWebhookDisabled(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *WebhookDisabledPayload,
+	) error
+	SendTagCreated(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *TagCreatedPayload,
+	) error
 }
@@ -72,10 +72,26 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
+	// The author and the users watching the repository get the same notification.
+	others, err := s.processWatchers(
+		ctx, payload.Base.Repo, gitnessenum.WatchLevel.IncludesPullReqs, payload.Commenter.ID,
+		mentions, participants, []*types.PrincipalInfo{author})
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process watchers for event %s for pullReqID %d: %w",
+			pullreqevents.CommentCreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
 	if author != nil {
+		others = append([]*types.PrincipalInfo{author}, others...)
+	}
+
+	if len(others) > 0 {
 		err = s.notificationClient.SendCommentPRAuthor(
 			ctx,
-			[]*types.PrincipalInfo{author},
+			others,
 			payload,
 		)
 		if err != nil {
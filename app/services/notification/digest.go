@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigest        = "notification-digest"
+	jobCronDigest        = "*/10 * * * *" // Every 10 minutes.
+	jobMaxDurationDigest = 10 * time.Minute
+
+	TemplateDigest = "digest.html"
+	subjectDigest  = "Your %s notification digest (%d notifications)"
+)
+
+type DigestPayload struct {
+	Delivery enum.NotificationDelivery
+	Items    []DigestItem
+}
+
+type DigestItem struct {
+	Subject string
+	Body    template.HTML
+}
+
+type digestJob struct {
+	service *Service
+}
+
+func (j *digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	return "", j.service.sendDigests(ctx)
+}
+
+// Register schedules the recurring job that sends notification digests.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeDigest, jobTypeDigest, jobCronDigest, jobMaxDurationDigest)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for notification digests: %w", err)
+	}
+
+	return nil
+}
+
+// sendDigests sends the digests of all users whose oldest pending notification
+// has been waiting for at least the digest interval.
+func (s *Service) sendDigests(ctx context.Context) error {
+	pending, err := s.digestStore.ListPending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending notification digests: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, p := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !digestDue(p, now) {
+			continue
+		}
+
+		err := s.sendDigest(ctx, p.PrincipalID, p.Delivery)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal.id", p.PrincipalID).
+				Str("delivery", string(p.Delivery)).
+				Msg("failed to send notification digest")
+		}
+	}
+
+	return nil
+}
+
+func digestDue(p *types.NotificationDigestPending, now time.Time) bool {
+	return now.Sub(time.UnixMilli(p.Oldest)) >= p.Delivery.Interval()
+}
+
+func (s *Service) sendDigest(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+) error {
+	items, err := s.digestStore.List(ctx, principalID, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to list digest items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	principal, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	payload := &DigestPayload{
+		Delivery: delivery,
+		Items:    make([]DigestItem, len(items)),
+	}
+	for i, item := range items {
+		payload.Items[i] = DigestItem{
+			Subject: item.Subject,
+			Body:    template.HTML(item.Body), //nolint:gosec // rendered from the notification templates
+		}
+	}
+
+	body, err := GetHTMLBody(TemplateDigest, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate digest mail body: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{principal.Email},
+		Subject:      fmt.Sprintf(subjectDigest, delivery, len(items)),
+		Body:         string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send digest mail: %w", err)
+	}
+
+	err = s.digestStore.Delete(ctx, principalID, delivery, items[len(items)-1].ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sent digest items: %w", err)
+	}
+
+	return nil
+}
+
+// htmlBodyContent returns the content of the body element of an HTML document,
+// so that it can be embedded into the digest mail. The input is returned as is if it has no body element.
+func htmlBodyContent(doc string) string {
+	start := strings.Index(doc, "<body>")
+	end := strings.LastIndex(doc, "</body>")
+	if start < 0 || end < start {
+		return doc
+	}
+
+	return strings.TrimSpace(doc[start+len("<body>") : end])
+}
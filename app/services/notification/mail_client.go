@@ -18,13 +18,17 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
+	TemplatePullReqCreated       = "pullreq_created.html"
 	TemplateReviewerAdded        = "reviewer_added.html"
 	TemplateCommentPRAuthor      = "comment_pr_author.html"
 	TemplateCommentMentions      = "comment_mentions.html"
@@ -32,15 +36,31 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateWebhookDisabled      = "webhook_disabled.html"
+	TemplateTagCreated           = "tag_created.html"
 )
 
 type MailClient struct {
 	mailer.Mailer
+	preferences preferenceResolver
+	digestStore store.NotificationDigestStore
 }
 
-func NewMailClient(mailer mailer.Mailer) MailClient {
+func NewMailClient(
+	mailer mailer.Mailer,
+	preferenceStore store.NotificationPreferenceStore,
+	digestStore store.NotificationDigestStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+) MailClient {
 	return MailClient{
 		Mailer: mailer,
+		preferences: preferenceResolver{
+			preferenceStore: preferenceStore,
+			spaceStore:      spaceStore,
+			repoStore:       repoStore,
+		},
+		digestStore: digestStore,
 	}
 }
 
@@ -60,7 +80,7 @@ func (m MailClient) SendCommentPRAuthor(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventCommentCreated, repoScope(payload.Base.Repo), recipients, email)
 }
 func (m MailClient) SendCommentMentions(
 	ctx context.Context,
@@ -78,7 +98,7 @@ func (m MailClient) SendCommentMentions(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventCommentCreated, repoScope(payload.Base.Repo), recipients, email)
 }
 func (m MailClient) SendCommentParticipants(
 	ctx context.Context,
@@ -96,7 +116,32 @@ func (m MailClient) SendCommentParticipants(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventCommentCreated, repoScope(payload.Base.Repo), recipients, email)
+}
+
+// SendPullReqCreated notifies the users watching the repository about a new pull request.
+// Reviewers of a new pull request are notified with SendReviewerAdded.
+func (m MailClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	email, err := GenerateEmailFromPayload(
+		TemplatePullReqCreated,
+		recipients,
+		payload.Base,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.CreatedEvent, err)
+	}
+
+	return m.send(ctx, enum.NotificationEventPullReqCreated, repoScope(payload.Base.Repo), recipients, email)
 }
 
 func (m MailClient) SendReviewerAdded(
@@ -115,7 +160,7 @@ func (m MailClient) SendReviewerAdded(
 			pullreqevents.ReviewerAddedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventReviewerAdded, repoScope(payload.Base.Repo), recipients, email)
 }
 
 func (m MailClient) SendPullReqBranchUpdated(
@@ -123,6 +168,10 @@ func (m MailClient) SendPullReqBranchUpdated(
 	recipients []*types.PrincipalInfo,
 	payload *PullReqBranchUpdatedPayload,
 ) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	email, err := GenerateEmailFromPayload(
 		TemplatePullReqBranchUpdated,
 		recipients,
@@ -134,7 +183,7 @@ func (m MailClient) SendPullReqBranchUpdated(
 			pullreqevents.BranchUpdatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventPullReqBranchUpdated, repoScope(payload.Base.Repo), recipients, email)
 }
 
 func (m MailClient) SendReviewSubmitted(
@@ -150,7 +199,7 @@ func (m MailClient) SendReviewSubmitted(
 			err,
 		)
 	}
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, enum.NotificationEventReviewSubmitted, repoScope(payload.Base.Repo), recipients, email)
 }
 
 func (m MailClient) SendPullReqStateChanged(
@@ -166,6 +215,101 @@ func (m MailClient) SendPullReqStateChanged(
 		)
 	}
 
+	return m.send(ctx, enum.NotificationEventPullReqStateChanged, repoScope(payload.Base.Repo), recipients, email)
+}
+
+func (m MailClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	body, err := GetHTMLBody(TemplateWebhookDisabled, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for disabled webhook: %w", err)
+	}
+
+	email := &mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectWebhookDisabled, payload.ParentPath, payload.Webhook.Identifier),
+		Body:         string(body),
+		RepoRef:      payload.ParentPath,
+	}
+
+	var scope notificationScope
+	switch payload.Webhook.ParentType {
+	case enum.WebhookParentRepo:
+		scope.repoID = &payload.Webhook.ParentID
+	case enum.WebhookParentSpace:
+		scope.spaceID = payload.Webhook.ParentID
+	case enum.WebhookParentRegistry:
+		return m.Mailer.Send(ctx, *email)
+	}
+
+	return m.send(ctx, enum.NotificationEventWebhookDisabled, scope, recipients, email)
+}
+
+func (m MailClient) SendTagCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *TagCreatedPayload,
+) error {
+	body, err := GetHTMLBody(TemplateTagCreated, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail body for created tag: %w", err)
+	}
+
+	email := &mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectTagCreated, payload.Repo.Identifier, payload.Tag),
+		Body:         string(body),
+		RepoRef:      payload.Repo.Path,
+	}
+
+	return m.send(ctx, enum.NotificationEventTagCreated, repoScope(payload.Repo), recipients, email)
+}
+
+// send applies the notification preferences of the recipients to the email: the email is sent
+// to the recipients who want instant notifications and queued for the digests of the others.
+func (m MailClient) send(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	scope notificationScope,
+	recipients []*types.PrincipalInfo,
+	email *mailer.Payload,
+) error {
+	deliveries, err := m.preferences.deliveries(ctx, event, scope, recipients)
+	if err != nil {
+		return fmt.Errorf("failed to resolve notification preferences of recipients: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	for delivery, principals := range deliveries {
+		if delivery == enum.NotificationDeliveryInstant {
+			continue
+		}
+
+		for _, principal := range principals {
+			err = m.digestStore.Create(ctx, &types.NotificationDigestItem{
+				PrincipalID: principal.ID,
+				Delivery:    delivery,
+				Event:       event,
+				Subject:     email.Subject,
+				Body:        htmlBodyContent(email.Body),
+				Created:     now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add notification to the digest of principal %d: %w", principal.ID, err)
+			}
+		}
+	}
+
+	instant := deliveries[enum.NotificationDeliveryInstant]
+	if len(instant) == 0 {
+		return nil
+	}
+
+	email.ToRecipients = RetrieveEmailsFromPrincipals(instant)
+
 	return m.Mailer.Send(ctx, *email)
 }
 
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/types"
+)
+
+// MultiClient sends every notification through all of its clients.
+type MultiClient []Client
+
+func (m MultiClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendCommentPRAuthor(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendCommentMentions(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendCommentParticipants(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendPullReqCreated(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendReviewerAdded(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendPullReqBranchUpdated(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendReviewSubmitted(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendPullReqStateChanged(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendWebhookDisabled(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendTagCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *TagCreatedPayload,
+) error {
+	return m.each(func(c Client) error { return c.SendTagCreated(ctx, recipients, payload) })
+}
+
+// each calls the function for all clients, a failure of a client doesn't prevent calling the others.
+func (m MultiClient) each(fn func(c Client) error) error {
+	var errs []error
+	for _, c := range m {
+		if err := fn(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// notificationScope identifies the repository and/or the space a notification originates from.
+type notificationScope struct {
+	repoID  *int64
+	spaceID int64
+}
+
+func repoScope(repo *types.Repository) notificationScope {
+	return notificationScope{repoID: &repo.ID, spaceID: repo.ParentID}
+}
+
+// preferenceResolver applies the notification preferences of users to the recipients of a notification.
+type preferenceResolver struct {
+	preferenceStore store.NotificationPreferenceStore
+	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
+}
+
+// deliveries groups the recipients of a notification by the delivery mode they prefer.
+// Recipients who don't want to receive the notification are left out.
+func (r preferenceResolver) deliveries(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	scope notificationScope,
+	recipients []*types.PrincipalInfo,
+) (map[enum.NotificationDelivery][]*types.PrincipalInfo, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	prefList, err := r.preferenceStore.ListByPrincipals(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	prefs := make(map[int64]*types.NotificationPreferences, len(prefList))
+	for _, p := range prefList {
+		prefs[p.PrincipalID] = p
+	}
+
+	spaceIDs, err := r.scopeSpaceIDs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	scopePrefList, err := r.preferenceStore.ListScopesForPrincipals(ctx, principalIDs, scope.repoID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification scope preferences: %w", err)
+	}
+
+	scopePrefs := make(map[int64][]*types.NotificationScopePreference)
+	for _, p := range scopePrefList {
+		scopePrefs[p.PrincipalID] = append(scopePrefs[p.PrincipalID], p)
+	}
+
+	result := make(map[enum.NotificationDelivery][]*types.PrincipalInfo)
+	for _, recipient := range recipients {
+		mode := resolveScopeMode(scopePrefs[recipient.ID], scope.repoID, spaceIDs)
+
+		delivery, ok := resolveDelivery(prefs[recipient.ID], mode, event)
+		if !ok {
+			continue
+		}
+
+		result[delivery] = append(result[delivery], recipient)
+	}
+
+	return result, nil
+}
+
+// scopeSpaceIDs returns the IDs of the scope's space and all its ancestors, starting with the closest one.
+func (r preferenceResolver) scopeSpaceIDs(ctx context.Context, scope notificationScope) ([]int64, error) {
+	spaceID := scope.spaceID
+	if spaceID == 0 && scope.repoID != nil {
+		repo, err := r.repoStore.Find(ctx, *scope.repoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+		spaceID = repo.ParentID
+	}
+
+	return ancestorSpaceIDs(ctx, r.spaceStore, spaceID)
+}
+
+// ancestorSpaceIDs returns the ID of the space and the IDs of all its ancestors, starting with the space itself.
+func ancestorSpaceIDs(ctx context.Context, spaceStore store.SpaceStore, spaceID int64) ([]int64, error) {
+	ancestors, err := spaceStore.GetAncestorsData(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces: %w", err)
+	}
+
+	parents := make(map[int64]int64, len(ancestors))
+	for _, ancestor := range ancestors {
+		parents[ancestor.ID] = ancestor.ParentID
+	}
+
+	spaceIDs := make([]int64, 0, len(ancestors))
+	for id := spaceID; id != 0 && len(spaceIDs) < len(ancestors); id = parents[id] {
+		spaceIDs = append(spaceIDs, id)
+	}
+
+	return spaceIDs, nil
+}
+
+// resolveScopeMode returns the mode of the most specific scope preference:
+// the repository preference, or the preference of the closest space.
+// spaceIDs must be ordered from the closest space to the root space.
+func resolveScopeMode(
+	prefs []*types.NotificationScopePreference,
+	repoID *int64,
+	spaceIDs []int64,
+) enum.NotificationScopeMode {
+	if len(prefs) == 0 {
+		return ""
+	}
+
+	if repoID != nil {
+		for _, p := range prefs {
+			if p.RepoID != nil && *p.RepoID == *repoID {
+				return p.Mode
+			}
+		}
+	}
+
+	for _, spaceID := range spaceIDs {
+		for _, p := range prefs {
+			if p.SpaceID != nil && *p.SpaceID == spaceID {
+				return p.Mode
+			}
+		}
+	}
+
+	return ""
+}
+
+// resolveDelivery returns how the notification about the event should be delivered to a user
+// and false if the user doesn't want to receive it at all.
+func resolveDelivery(
+	prefs *types.NotificationPreferences,
+	mode enum.NotificationScopeMode,
+	event enum.NotificationEvent,
+) (enum.NotificationDelivery, bool) {
+	if mode == enum.NotificationScopeModeIgnore {
+		return "", false
+	}
+
+	if prefs == nil {
+		return enum.NotificationDeliveryInstant, true
+	}
+
+	if mode != enum.NotificationScopeModeWatch && !prefs.EventEnabled(event) {
+		return "", false
+	}
+
+	delivery, _ := prefs.Delivery.Sanitize()
+
+	return delivery, true
+}
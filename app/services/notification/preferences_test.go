@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestResolveScopeMode(t *testing.T) {
+	repoID := int64(10)
+	spaceParent := int64(2)
+	spaceRoot := int64(1)
+	spaceIDs := []int64{spaceParent, spaceRoot}
+
+	tests := []struct {
+		name     string
+		prefs    []*types.NotificationScopePreference
+		repoID   *int64
+		expected enum.NotificationScopeMode
+	}{
+		{
+			name:     "none",
+			repoID:   &repoID,
+			expected: "",
+		},
+		{
+			name: "repo-wins",
+			prefs: []*types.NotificationScopePreference{
+				{SpaceID: &spaceParent, Mode: enum.NotificationScopeModeIgnore},
+				{RepoID: &repoID, Mode: enum.NotificationScopeModeWatch},
+			},
+			repoID:   &repoID,
+			expected: enum.NotificationScopeModeWatch,
+		},
+		{
+			name: "closest-space-wins",
+			prefs: []*types.NotificationScopePreference{
+				{SpaceID: &spaceRoot, Mode: enum.NotificationScopeModeIgnore},
+				{SpaceID: &spaceParent, Mode: enum.NotificationScopeModeWatch},
+			},
+			repoID:   &repoID,
+			expected: enum.NotificationScopeModeWatch,
+		},
+		{
+			name: "root-space",
+			prefs: []*types.NotificationScopePreference{
+				{SpaceID: &spaceRoot, Mode: enum.NotificationScopeModeIgnore},
+			},
+			expected: enum.NotificationScopeModeIgnore,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := resolveScopeMode(test.prefs, test.repoID, spaceIDs); got != test.expected {
+				t.Errorf("expected mode %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveDelivery(t *testing.T) {
+	daily := &types.NotificationPreferences{
+		DisabledEvents: []enum.NotificationEvent{enum.NotificationEventPullReqBranchUpdated},
+		Delivery:       enum.NotificationDeliveryDaily,
+	}
+
+	tests := []struct {
+		name             string
+		prefs            *types.NotificationPreferences
+		mode             enum.NotificationScopeMode
+		event            enum.NotificationEvent
+		expectedDelivery enum.NotificationDelivery
+		expectedOK       bool
+	}{
+		{
+			name:             "no-preferences",
+			event:            enum.NotificationEventPullReqBranchUpdated,
+			expectedDelivery: enum.NotificationDeliveryInstant,
+			expectedOK:       true,
+		},
+		{
+			name:       "ignored-scope",
+			mode:       enum.NotificationScopeModeIgnore,
+			event:      enum.NotificationEventReviewerAdded,
+			expectedOK: false,
+		},
+		{
+			name:       "disabled-event",
+			prefs:      daily,
+			event:      enum.NotificationEventPullReqBranchUpdated,
+			expectedOK: false,
+		},
+		{
+			name:             "disabled-event-watched-scope",
+			prefs:            daily,
+			mode:             enum.NotificationScopeModeWatch,
+			event:            enum.NotificationEventPullReqBranchUpdated,
+			expectedDelivery: enum.NotificationDeliveryDaily,
+			expectedOK:       true,
+		},
+		{
+			name:             "enabled-event",
+			prefs:            daily,
+			event:            enum.NotificationEventReviewerAdded,
+			expectedDelivery: enum.NotificationDeliveryDaily,
+			expectedOK:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			delivery, ok := resolveDelivery(test.prefs, test.mode, test.event)
+			if ok != test.expectedOK || delivery != test.expectedDelivery {
+				t.Errorf("expected (%q, %t), got (%q, %t)", test.expectedDelivery, test.expectedOK, delivery, ok)
+			}
+		})
+	}
+}
+
+func TestDigestDue(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		delivery enum.NotificationDelivery
+		age      time.Duration
+		expected bool
+	}{
+		{name: "hourly-not-due", delivery: enum.NotificationDeliveryHourly, age: 30 * time.Minute, expected: false},
+		{name: "hourly-due", delivery: enum.NotificationDeliveryHourly, age: time.Hour, expected: true},
+		{name: "daily-not-due", delivery: enum.NotificationDeliveryDaily, age: 23 * time.Hour, expected: false},
+		{name: "daily-due", delivery: enum.NotificationDeliveryDaily, age: 25 * time.Hour, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &types.NotificationDigestPending{
+				Delivery: test.delivery,
+				Oldest:   now.Add(-test.age).UnixMilli(),
+			}
+			if got := digestDue(p, now); got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestHTMLBodyContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		expected string
+	}{
+		{
+			name:     "document",
+			doc:      "<html><head></head><body>\n<p>hello</p>\n</body></html>",
+			expected: "<p>hello</p>",
+		},
+		{
+			name:     "fragment",
+			doc:      "<p>hello</p>",
+			expected: "<p>hello</p>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := htmlBodyContent(test.doc); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -21,8 +21,14 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
+type PullReqCreatedPayload struct {
+	Base      *BasePullReqPayload
+	Reviewers []*types.PrincipalInfo
+}
+
 func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
@@ -37,6 +43,31 @@ func (s *Service) notifyPullReqCreated(
 		return fmt.Errorf("failed to get principal infos from cache: %w", err)
 	}
 
+	payloadReviewers := make([]*types.PrincipalInfo, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		payloadReviewers = append(payloadReviewers, reviewer)
+	}
+
+	// Reviewers are notified separately, only the users watching the repository get the notification.
+	watchers, err := s.processWatchers(
+		ctx, base.Repo, enum.WatchLevel.IncludesPullReqs, base.Author.ID, payloadReviewers)
+	if err != nil {
+		return fmt.Errorf("failed to process watchers: %w", err)
+	}
+
+	if err := s.notificationClient.SendPullReqCreated(
+		ctx,
+		watchers,
+		&PullReqCreatedPayload{Base: base, Reviewers: payloadReviewers},
+	); err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	for _, reviewer := range reviewers {
 		payload := &ReviewerAddedPayload{
 			Base:     base,
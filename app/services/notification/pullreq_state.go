@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -169,6 +170,13 @@ func (s *Service) processPullReqStateChangedEvent(
 
 	recipients[len(reviewers)] = author
 
+	watchers, err := s.processWatchers(
+		ctx, basePayload.Repo, enum.WatchLevel.IncludesPullReqs, stateModifierPrincipal.ID, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipients = append(recipients, watchers...)
+
 	return &PullReqStateChangedPayload{
 		Base:      basePayload,
 		ChangedBy: stateModifierPrincipal,
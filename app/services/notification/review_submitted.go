@@ -91,10 +91,19 @@ func (s *Service) processReviewSubmittedEvent(
 		)
 	}
 
+	recipients := []*types.PrincipalInfo{authorPrincipal}
+
+	watchers, err := s.processWatchers(
+		ctx, base.Repo, enum.WatchLevel.IncludesPullReqs, reviewerPrincipal.ID, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipients = append(recipients, watchers...)
+
 	return &ReviewSubmittedPayload{
 		Base:     base,
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
-	}, []*types.PrincipalInfo{authorPrincipal}, nil
+	}, recipients, nil
 }
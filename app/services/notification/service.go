@@ -22,10 +22,14 @@ import (
 	"io/fs"
 	"path"
 
+	"github.com/harness/gitness/app/auth/authz"
+	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 )
@@ -34,6 +38,9 @@ const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+
+	subjectWebhookDisabled = "[%s] Webhook %s was disabled"
+	subjectTagCreated      = "[%s] New tag %s"
 )
 
 var (
@@ -47,6 +54,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	err = LoadChatTemplates()
+	if err != nil {
+		panic(err)
+	}
 }
 
 func LoadTemplates() error {
@@ -82,12 +94,18 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// AllowLoopback and AllowPrivateNetwork control whether notification channels
+	// can post messages to loopback and private network addresses.
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
 }
 
 type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	gitReaderFactory      *events.ReaderFactory[*gitevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -96,6 +114,12 @@ type Service struct {
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
 	urlProvider           url.Provider
+	mailer                mailer.Mailer
+	digestStore           store.NotificationDigestStore
+	scheduler             *job.Scheduler
+	spaceStore            store.SpaceStore
+	watchStore            store.WatchStore
+	repoAccess            repoAccessFilter
 }
 
 func NewService(
@@ -103,6 +127,7 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -111,11 +136,20 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	mailer mailer.Mailer,
+	digestStore store.NotificationDigestStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	spaceStore store.SpaceStore,
+	watchStore store.WatchStore,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		gitReaderFactory:      gitReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -124,6 +158,16 @@ func NewService(
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
 		urlProvider:           urlProvider,
+		mailer:                mailer,
+		digestStore:           digestStore,
+		scheduler:             scheduler,
+		spaceStore:            spaceStore,
+		watchStore:            watchStore,
+		repoAccess:            repoAccessFilter{authorizer: authorizer, principalStore: principalStore},
+	}
+
+	if err := jobExecutor.Register(jobTypeDigest, &digestJob{service: service}); err != nil {
+		return nil, fmt.Errorf("failed to register notification digest job: %w", err)
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -155,6 +199,24 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.gitReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterTagCreated(service.notifyTagCreated)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SSENotification is a notification published to the event stream of a user.
+type SSENotification struct {
+	Event  enum.NotificationEvent `json:"event"`
+	Title  string                 `json:"title"`
+	URL    string                 `json:"url,omitempty"`
+	RepoID int64                  `json:"repo_id,omitempty"`
+	Actor  *types.PrincipalInfo   `json:"actor,omitempty"`
+}
+
+// SSEClient publishes notifications to the event streams of the recipients, to be shown in the web UI.
+type SSEClient struct {
+	sseStreamer sse.Streamer
+	repoAccess  repoAccessFilter
+}
+
+func NewSSEClient(
+	sseStreamer sse.Streamer,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+) *SSEClient {
+	return &SSEClient{
+		sseStreamer: sseStreamer,
+		repoAccess:  repoAccessFilter{authorizer: authorizer, principalStore: principalStore},
+	}
+}
+
+func (c *SSEClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventCommentCreated, payload.Base, payload.Commenter)
+}
+
+func (c *SSEClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventCommentCreated, payload.Base, payload.Commenter)
+}
+
+func (c *SSEClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventCommentCreated, payload.Base, payload.Commenter)
+}
+
+func (c *SSEClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventPullReqCreated, payload.Base, payload.Base.Author)
+}
+
+func (c *SSEClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventReviewerAdded, payload.Base, payload.Reviewer)
+}
+
+func (c *SSEClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventPullReqBranchUpdated,
+		payload.Base, payload.Committer)
+}
+
+func (c *SSEClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventReviewSubmitted, payload.Base, payload.Reviewer)
+}
+
+func (c *SSEClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.publishForPullReq(ctx, recipients, enum.NotificationEventPullReqStateChanged, payload.Base, payload.ChangedBy)
+}
+
+func (c *SSEClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	notification := &SSENotification{
+		Event: enum.NotificationEventWebhookDisabled,
+		Title: fmt.Sprintf(subjectWebhookDisabled, payload.ParentPath, payload.Webhook.Identifier),
+	}
+	if payload.Webhook.ParentType == enum.WebhookParentRepo {
+		notification.RepoID = payload.Webhook.ParentID
+	}
+
+	c.publish(ctx, recipients, notification)
+
+	return nil
+}
+
+func (c *SSEClient) SendTagCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *TagCreatedPayload,
+) error {
+	return c.publishForRepo(ctx, payload.Repo, recipients, &SSENotification{
+		Event:  enum.NotificationEventTagCreated,
+		Title:  fmt.Sprintf(subjectTagCreated, payload.Repo.Identifier, payload.Tag),
+		URL:    payload.TagURL,
+		RepoID: payload.Repo.ID,
+		Actor:  payload.Tagger,
+	})
+}
+
+func (c *SSEClient) publishForPullReq(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	event enum.NotificationEvent,
+	base *BasePullReqPayload,
+	actor *types.PrincipalInfo,
+) error {
+	return c.publishForRepo(ctx, base.Repo, recipients, &SSENotification{
+		Event:  event,
+		Title:  GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title),
+		URL:    base.PullReqURL,
+		RepoID: base.Repo.ID,
+		Actor:  actor,
+	})
+}
+
+// publishForRepo publishes the notification about a repository activity
+// only to the recipients that are allowed to view the repository.
+func (c *SSEClient) publishForRepo(
+	ctx context.Context,
+	repo *types.Repository,
+	recipients []*types.PrincipalInfo,
+	notification *SSENotification,
+) error {
+	recipients, err := c.repoAccess.filter(ctx, repo, recipients)
+	if err != nil {
+		return fmt.Errorf("failed to filter notification recipients by repository access: %w", err)
+	}
+
+	c.publish(ctx, recipients, notification)
+
+	return nil
+}
+
+func (c *SSEClient) publish(ctx context.Context, recipients []*types.PrincipalInfo, notification *SSENotification) {
+	for _, recipient := range recipients {
+		c.sseStreamer.PublishToPrincipal(ctx, recipient.ID, enum.SSETypeNotification, notification)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TagCreatedPayload struct {
+	Repo   *types.Repository
+	Tagger *types.PrincipalInfo
+	Tag    string
+	SHA    string
+	TagURL string
+}
+
+func (s *Service) notifyTagCreated(
+	ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload],
+) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+	}
+
+	tagger, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info for %d: %w", event.Payload.PrincipalID, err)
+	}
+
+	watchers, err := s.processWatchers(ctx, repo, enum.WatchLevel.IncludesReleases, tagger.ID)
+	if err != nil {
+		return fmt.Errorf("failed to process watchers: %w", err)
+	}
+
+	if len(watchers) == 0 {
+		return nil
+	}
+
+	tag := strings.TrimPrefix(event.Payload.Ref, "refs/tags/")
+
+	err = s.notificationClient.SendTagCreated(ctx, watchers, &TagCreatedPayload{
+		Repo:   repo,
+		Tagger: tagger,
+		Tag:    tag,
+		SHA:    event.Payload.SHA,
+		TagURL: s.urlProvider.GenerateUIRefURL(ctx, repo.Path, event.Payload.SHA),
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for repoID %d: %w",
+			gitevents.TagCreatedEvent,
+			event.Payload.RepoID,
+			err,
+		)
+	}
+
+	return nil
+}
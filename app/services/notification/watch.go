@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// processWatchers returns the users watching the repository, directly or through one of its parent spaces,
+// whose watch level includes the activity. The actor and the users who are already notified are left out,
+// as well as the users that aren't allowed to view the repository.
+func (s *Service) processWatchers(
+	ctx context.Context,
+	repo *types.Repository,
+	includes func(enum.WatchLevel) bool,
+	actorID int64,
+	notified ...[]*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, error) {
+	spaceIDs, err := ancestorSpaceIDs(ctx, s.spaceStore, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	watches, err := s.watchStore.ListInScopes(ctx, &repo.ID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watches: %w", err)
+	}
+
+	seen := map[int64]bool{actorID: true}
+	for _, principals := range notified {
+		for _, principal := range principals {
+			if principal != nil {
+				seen[principal.ID] = true
+			}
+		}
+	}
+
+	var watcherIDs []int64
+	for principalID, level := range resolveWatchLevels(watches, repo.ID, spaceIDs) {
+		if !seen[principalID] && includes(level) {
+			watcherIDs = append(watcherIDs, principalID)
+		}
+	}
+	if len(watcherIDs) == 0 {
+		return nil, nil
+	}
+
+	watchers, err := s.principalInfoView.FindMany(ctx, watcherIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watchers from principalInfoView: %w", err)
+	}
+
+	// A space watch covers all repositories in the space, but only the ones the user can view are notified about.
+	watchers, err = s.repoAccess.filter(ctx, repo, watchers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter watchers by repository access: %w", err)
+	}
+
+	return watchers, nil
+}
+
+// resolveWatchLevels returns the watch level of every user watching the repository.
+// The most specific watch wins: the repository watch, or the watch of the closest space.
+// spaceIDs must be ordered from the closest space to the root space.
+func resolveWatchLevels(watches []*types.Watch, repoID int64, spaceIDs []int64) map[int64]enum.WatchLevel {
+	// The rank of a scope is 0 for the repository and increases with the distance of the space.
+	ranks := make(map[int64]int, len(spaceIDs))
+	for i, spaceID := range spaceIDs {
+		ranks[spaceID] = i + 1
+	}
+
+	levels := make(map[int64]enum.WatchLevel)
+	levelRanks := make(map[int64]int)
+	for _, w := range watches {
+		var (
+			rank int
+			ok   bool
+		)
+		switch {
+		case w.RepoID != nil:
+			ok = *w.RepoID == repoID
+		case w.SpaceID != nil:
+			rank, ok = ranks[*w.SpaceID]
+		}
+		if !ok {
+			continue
+		}
+
+		if current, exists := levelRanks[w.PrincipalID]; exists && current <= rank {
+			continue
+		}
+
+		levels[w.PrincipalID] = w.Level
+		levelRanks[w.PrincipalID] = rank
+	}
+
+	return levels
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestResolveWatchLevels(t *testing.T) {
+	repoID := int64(10)
+	otherRepoID := int64(11)
+	spaceParent := int64(2)
+	spaceRoot := int64(1)
+	spaceIDs := []int64{spaceParent, spaceRoot}
+
+	tests := []struct {
+		name     string
+		watches  []*types.Watch
+		expected map[int64]enum.WatchLevel
+	}{
+		{
+			name:     "none",
+			expected: map[int64]enum.WatchLevel{},
+		},
+		{
+			name: "repo-wins",
+			watches: []*types.Watch{
+				{PrincipalID: 1, SpaceID: &spaceParent, Level: enum.WatchLevelAll},
+				{PrincipalID: 1, RepoID: &repoID, Level: enum.WatchLevelParticipating},
+			},
+			expected: map[int64]enum.WatchLevel{1: enum.WatchLevelParticipating},
+		},
+		{
+			name: "closest-space-wins",
+			watches: []*types.Watch{
+				{PrincipalID: 1, SpaceID: &spaceParent, Level: enum.WatchLevelReleases},
+				{PrincipalID: 1, SpaceID: &spaceRoot, Level: enum.WatchLevelAll},
+			},
+			expected: map[int64]enum.WatchLevel{1: enum.WatchLevelReleases},
+		},
+		{
+			name: "multiple-users",
+			watches: []*types.Watch{
+				{PrincipalID: 1, SpaceID: &spaceRoot, Level: enum.WatchLevelAll},
+				{PrincipalID: 2, RepoID: &repoID, Level: enum.WatchLevelPullReqs},
+			},
+			expected: map[int64]enum.WatchLevel{1: enum.WatchLevelAll, 2: enum.WatchLevelPullReqs},
+		},
+		{
+			name: "other-scopes-ignored",
+			watches: []*types.Watch{
+				{PrincipalID: 1, RepoID: &otherRepoID, Level: enum.WatchLevelAll},
+			},
+			expected: map[int64]enum.WatchLevel{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := resolveWatchLevels(test.watches, repoID, spaceIDs)
+			if !maps.Equal(got, test.expected) {
+				t.Errorf("expected levels %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestWatchLevelIncludes(t *testing.T) {
+	tests := []struct {
+		level    enum.WatchLevel
+		pullReqs bool
+		releases bool
+	}{
+		{level: enum.WatchLevelAll, pullReqs: true, releases: true},
+		{level: enum.WatchLevelPullReqs, pullReqs: true, releases: false},
+		{level: enum.WatchLevelReleases, pullReqs: false, releases: true},
+		{level: enum.WatchLevelParticipating, pullReqs: false, releases: false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.level), func(t *testing.T) {
+			if got := test.level.IncludesPullReqs(); got != test.pullReqs {
+				t.Errorf("expected IncludesPullReqs %t, got %t", test.pullReqs, got)
+			}
+			if got := test.level.IncludesReleases(); got != test.releases {
+				t.Errorf("expected IncludesReleases %t, got %t", test.releases, got)
+			}
+		})
+	}
+}
@@ -17,17 +17,25 @@ package notification
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
+	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideChatClient,
+	ProvideSSEClient,
+	ProvideClient,
 	ProvideNotificationService,
 )
 
@@ -36,6 +44,7 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -44,12 +53,21 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	mailer mailer.Mailer,
+	digestStore store.NotificationDigestStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	spaceStore store.SpaceStore,
+	watchStore store.WatchStore,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
 ) (*Service, error) {
 	return NewService(
 		ctx,
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		gitReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -58,9 +76,47 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		mailer,
+		digestStore,
+		scheduler,
+		jobExecutor,
+		spaceStore,
+		watchStore,
+		authorizer,
+		principalStore,
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
-	return NewMailClient(mailer)
+func ProvideMailClient(
+	mailer mailer.Mailer,
+	preferenceStore store.NotificationPreferenceStore,
+	digestStore store.NotificationDigestStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+) MailClient {
+	return NewMailClient(mailer, preferenceStore, digestStore, spaceStore, repoStore)
+}
+
+func ProvideChatClient(
+	config Config,
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	encrypter encrypt.Encrypter,
+) *ChatClient {
+	return NewChatClient(config, channelStore, spaceStore, repoStore, encrypter)
+}
+
+func ProvideSSEClient(
+	sseStreamer sse.Streamer,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+) *SSEClient {
+	return NewSSEClient(sseStreamer, authorizer, principalStore)
+}
+
+// ProvideClient provides the client sending notifications by email, to chat notification channels
+// and to the event streams of the recipients.
+func ProvideClient(mailClient MailClient, chatClient *ChatClient, sseClient *SSEClient) Client {
+	return MultiClient{mailClient, chatClient, sseClient}
 }
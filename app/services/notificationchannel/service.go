@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// Service manages the notification channels of spaces and repositories.
+type Service struct {
+	config       notification.Config
+	channelStore store.NotificationChannelStore
+	encrypter    encrypt.Encrypter
+}
+
+func NewService(
+	config notification.Config,
+	channelStore store.NotificationChannelStore,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		config:       config,
+		channelStore: channelStore,
+		encrypter:    encrypter,
+	}
+}
+
+// Create creates a new notification channel in the specified space/repo.
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *types.NotificationChannelCreateInput,
+) (*types.NotificationChannel, error) {
+	if err := s.sanitizeCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	encryptedURL, err := s.encrypter.Encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	channel := &types.NotificationChannel{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Identifier:  in.Identifier,
+		DisplayName: in.DisplayName,
+		Type:        in.Type,
+		URL:         string(encryptedURL),
+		Enabled:     in.Enabled,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+	}
+
+	if err := s.channelStore.Create(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// Update updates the notification channel of the specified space/repo.
+func (s *Service) Update(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+	in *types.NotificationChannelUpdateInput,
+) (*types.NotificationChannel, error) {
+	if err := s.sanitizeUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	channel, err := s.channelStore.FindByIdentifier(ctx, spaceID, repoID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	if in.Identifier != nil {
+		channel.Identifier = *in.Identifier
+	}
+	if in.DisplayName != nil {
+		channel.DisplayName = *in.DisplayName
+	}
+	if in.URL != nil {
+		encryptedURL, err := s.encrypter.Encrypt(*in.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+		}
+		channel.URL = string(encryptedURL)
+	}
+	if in.Enabled != nil {
+		channel.Enabled = *in.Enabled
+	}
+
+	if err := s.channelStore.Update(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// Delete deletes the notification channel of the specified space/repo.
+func (s *Service) Delete(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+) error {
+	channel, err := s.channelStore.FindByIdentifier(ctx, spaceID, repoID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	if err := s.channelStore.Delete(ctx, channel.ID); err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	return nil
+}
+
+// List lists the notification channels of the specified space/repo.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID, repoID *int64,
+) ([]*types.NotificationChannel, error) {
+	channels, err := s.channelStore.List(ctx, spaceID, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+func (s *Service) sanitizeCreateInput(in *types.NotificationChannelCreateInput) error {
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+
+	channelType, ok := in.Type.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("Notification channel type %q is not supported.", in.Type)
+	}
+	in.Type = channelType
+
+	return s.checkURL(in.URL)
+}
+
+func (s *Service) sanitizeUpdateInput(in *types.NotificationChannelUpdateInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+	if in.DisplayName != nil {
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+	if in.URL != nil {
+		if err := s.checkURL(*in.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkURL validates the incoming webhook URL of a notification channel,
+// which is subject to the same restrictions as the URL of a webhook.
+func (s *Service) checkURL(url string) error {
+	return webhook.CheckURL(url, s.config.AllowLoopback, s.config.AllowPrivateNetwork, false)
+}
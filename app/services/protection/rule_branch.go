@@ -29,6 +29,7 @@ type Branch struct {
 	Bypass    DefBypass          `json:"bypass"`
 	PullReq   DefPullReq         `json:"pullreq"`
 	Lifecycle DefBranchLifecycle `json:"lifecycle"`
+	Commits   DefCommits         `json:"commits"`
 }
 
 var (
@@ -53,6 +54,25 @@ func (v *Branch) MergeVerify(
 		violations[i].Bypassed = bypassed
 	}
 
+	// users that can bypass the rule are allowed to merge directly, without going through the merge queue,
+	// but only if they explicitly ask for it.
+	if bypassable && in.BypassMergeQueue {
+		out.RequiresMergeQueue = false
+	}
+
+	commitsViolations, err := v.Commits.verify(ctx, in.ListSourceCommits)
+	if err != nil {
+		return out, violations, fmt.Errorf("commits verify error: %w", err)
+	}
+
+	commitsBypassable := bypassable || v.Commits.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupIDs)
+	for i := range commitsViolations {
+		commitsViolations[i].Bypassable = commitsBypassable
+		commitsViolations[i].Bypassed = in.AllowBypass && commitsBypassable
+	}
+
+	violations = append(violations, commitsViolations...)
+
 	return
 }
 
@@ -131,11 +151,35 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+func (v *Branch) CommitsVerify(
+	ctx context.Context,
+	in CommitsVerifyInput,
+) ([]types.RuleViolations, error) {
+	violations, err := v.Commits.verify(ctx, in.ListCommits)
+	if err != nil {
+		return nil, fmt.Errorf("commits verify error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID) ||
+		v.Commits.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return violations, nil
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
-	uniqueUserMap := make(map[int64]struct{}, len(v.Bypass.UserIDs)+len(v.PullReq.Reviewers.DefaultReviewerIDs))
+	uniqueUserMap := make(map[int64]struct{},
+		len(v.Bypass.UserIDs)+len(v.Commits.Bypass.UserIDs)+len(v.PullReq.Reviewers.DefaultReviewerIDs))
 	for _, id := range v.Bypass.UserIDs {
 		uniqueUserMap[id] = struct{}{}
 	}
+	for _, id := range v.Commits.Bypass.UserIDs {
+		uniqueUserMap[id] = struct{}{}
+	}
 	for _, id := range v.PullReq.Reviewers.DefaultReviewerIDs {
 		uniqueUserMap[id] = struct{}{}
 	}
@@ -151,11 +195,14 @@ func (v *Branch) UserIDs() ([]int64, error) {
 func (v *Branch) UserGroupIDs() ([]int64, error) {
 	uniqueGroupsMap := make(
 		map[int64]struct{},
-		len(v.Bypass.UserGroupIDs)+len(v.PullReq.Reviewers.DefaultUserGroupReviewerIDs),
+		len(v.Bypass.UserGroupIDs)+len(v.Commits.Bypass.UserGroupIDs)+len(v.PullReq.Reviewers.DefaultUserGroupReviewerIDs),
 	)
 	for _, id := range v.Bypass.UserGroupIDs {
 		uniqueGroupsMap[id] = struct{}{}
 	}
+	for _, id := range v.Commits.Bypass.UserGroupIDs {
+		uniqueGroupsMap[id] = struct{}{}
+	}
 	for _, id := range v.PullReq.Reviewers.DefaultUserGroupReviewerIDs {
 		uniqueGroupsMap[id] = struct{}{}
 	}
@@ -181,5 +228,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -247,6 +248,100 @@ func TestBranch_MergeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge-queue",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{admin.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{RequireMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				PullReq:     &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "merge-queue-bypass-not-requested",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{admin.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{RequireMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:       admin,
+				AllowBypass: true,
+				PullReq:     &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "merge-queue-bypass-not-allowed",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{admin.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{RequireMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:            user,
+				AllowBypass:      true,
+				BypassMergeQueue: true,
+				PullReq:          &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "merge-queue-bypass",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{admin.ID}},
+				PullReq: DefPullReq{
+					Merge: DefMerge{RequireMergeQueue: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:            admin,
+				AllowBypass:      true,
+				BypassMergeQueue: true,
+				PullReq:          &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "cancel-auto-merge-on-push",
+			branch: Branch{
+				PullReq: DefPullReq{
+					Merge: DefMerge{CancelAutoMergeOnPush: true},
+				},
+			},
+			in: MergeVerifyInput{
+				Actor:   user,
+				PullReq: &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:         enum.MergeMethods,
+				CancelsAutoMergeOnPush: true,
+			},
+			expVs: []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -569,6 +664,190 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 	}
 }
 
+// nolint:gocognit // it's a unit test
+func TestBranch_CommitsVerify(t *testing.T) {
+	user := &types.Principal{ID: 42}
+	releaser := &types.Principal{ID: 43}
+
+	commits := []*types.Commit{
+		{
+			SHA:       sha.Must("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+			Signature: &types.GitSignatureResult{Result: enum.GitSignatureGood},
+		},
+		{
+			SHA:       sha.Must("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+			Signature: &types.GitSignatureResult{Result: enum.GitSignatureUnverified},
+		},
+		{
+			SHA: sha.Must("cccccccccccccccccccccccccccccccccccccccc"),
+		},
+	}
+	listCommits := func(context.Context) ([]*types.Commit, error) { return commits, nil }
+	listSignedCommits := func(context.Context) ([]*types.Commit, error) { return commits[:1], nil }
+
+	tests := []struct {
+		name   string
+		branch Branch
+		in     CommitsVerifyInput
+		expVs  []types.RuleViolations
+	}{
+		{
+			name:   "empty",
+			branch: Branch{},
+			in: CommitsVerifyInput{
+				Actor:       user,
+				ListCommits: listCommits,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "all-signed",
+			branch: Branch{
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: CommitsVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				ListCommits: listSignedCommits,
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "unsigned-no-bypass",
+			branch: Branch{
+				Bypass:  DefBypass{RepoOwners: true},
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: CommitsVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				ListCommits: listCommits,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: false,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeCommitsRequireSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "unsigned-rule-bypass",
+			branch: Branch{
+				Bypass:  DefBypass{UserIDs: []int64{user.ID}},
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: CommitsVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				ListCommits: listCommits,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeCommitsRequireSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "unsigned-commits-bypass",
+			branch: Branch{
+				Commits: DefCommits{
+					RequireSigned: true,
+					Bypass:        DefBypass{UserIDs: []int64{releaser.ID}},
+				},
+			},
+			in: CommitsVerifyInput{
+				Actor:       releaser,
+				AllowBypass: true,
+				ListCommits: listCommits,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeCommitsRequireSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "unsigned-commits-bypass-other-user",
+			branch: Branch{
+				Commits: DefCommits{
+					RequireSigned: true,
+					Bypass:        DefBypass{UserIDs: []int64{releaser.ID}},
+				},
+			},
+			in: CommitsVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				ListCommits: listCommits,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: false,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeCommitsRequireSigned},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.branch.Sanitize(); err != nil {
+				t.Errorf("invalid: %s", err.Error())
+				return
+			}
+
+			results, err := test.branch.CommitsVerify(ctx, test.in)
+			if err != nil {
+				t.Errorf("error: %s", err.Error())
+				return
+			}
+
+			if want, got := len(test.expVs), len(results); want != got {
+				t.Errorf("number of violations mismatch: want=%d got=%d", want, got)
+				return
+			}
+
+			for i := range results {
+				if want, got := test.expVs[i].Bypassable, results[i].Bypassable; want != got {
+					t.Errorf("rule result %d, bypassable mismatch: want=%t got=%t", i, want, got)
+					return
+				}
+
+				if want, got := test.expVs[i].Bypassed, results[i].Bypassed; want != got {
+					t.Errorf("rule result %d, bypassed mismatch: want=%t got=%t", i, want, got)
+					return
+				}
+
+				if want, got := len(test.expVs[i].Violations), len(results[i].Violations); want != got {
+					t.Errorf("rule result %d, violations count mismatch: want=%d got=%d", i, want, got)
+					return
+				}
+
+				for j := range results[i].Violations {
+					if want, got := test.expVs[i].Violations[j].Code, results[i].Violations[j].Code; want != got {
+						t.Errorf("rule result %d, violation %d, code mismatch: want=%s got=%s", i, j, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
 func mockUserGroupResolver(_ context.Context, _ []int64) ([]int64, error) {
 	return []int64{43}, nil
 }
@@ -57,19 +57,7 @@ func (p *Push) Violations(
 	ctx context.Context,
 	in *PushViolationsInput,
 ) (PushViolationsOutput, error) {
-	var violations types.RuleViolations
-
-	if in.FindOversizeFilesOutput != nil {
-		for _, fileInfos := range in.FindOversizeFilesOutput.FileInfos {
-			if p.Push.FileSizeLimit > 0 && fileInfos.Size > p.Push.FileSizeLimit {
-				violations.Addf(codePushFileSizeLimit,
-					"Found file(s) exceeding the filesize limit of %d.",
-					p.Push.FileSizeLimit,
-				)
-				break
-			}
-		}
-	}
+	violations, pathViolations := p.Push.fileViolations(in)
 
 	if p.Push.PrincipalCommitterMatch && in.PrincipalCommitterMatch &&
 		in.CommitterMismatchCount > 0 {
@@ -91,17 +79,55 @@ func (p *Push) Violations(
 	violations.Bypassable = bypassable
 	violations.Bypassed = bypassable
 
+	result := []types.RuleViolations{violations}
+
+	if len(pathViolations.Violations) > 0 {
+		pathBypassable := bypassable ||
+			p.Push.ProtectedPaths.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+		pathViolations.Bypassable = pathBypassable
+		pathViolations.Bypassed = pathBypassable
+
+		result = append(result, pathViolations)
+	}
+
 	return PushViolationsOutput{
-		Violations: []types.RuleViolations{violations},
+		Violations: result,
 	}, nil
 }
 
 func (p *Push) UserIDs() ([]int64, error) {
-	return p.Bypass.UserIDs, nil
+	uniqueUserMap := make(map[int64]struct{}, len(p.Bypass.UserIDs)+len(p.Push.ProtectedPaths.Bypass.UserIDs))
+	for _, id := range p.Bypass.UserIDs {
+		uniqueUserMap[id] = struct{}{}
+	}
+	for _, id := range p.Push.ProtectedPaths.Bypass.UserIDs {
+		uniqueUserMap[id] = struct{}{}
+	}
+
+	ids := make([]int64, 0, len(uniqueUserMap))
+	for id := range uniqueUserMap {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
 }
 
 func (p *Push) UserGroupIDs() ([]int64, error) {
-	return p.Bypass.UserGroupIDs, nil
+	uniqueGroupsMap := make(map[int64]struct{},
+		len(p.Bypass.UserGroupIDs)+len(p.Push.ProtectedPaths.Bypass.UserGroupIDs))
+	for _, id := range p.Bypass.UserGroupIDs {
+		uniqueGroupsMap[id] = struct{}{}
+	}
+	for _, id := range p.Push.ProtectedPaths.Bypass.UserGroupIDs {
+		uniqueGroupsMap[id] = struct{}{}
+	}
+
+	ids := make([]int64, 0, len(uniqueGroupsMap))
+	for id := range uniqueGroupsMap {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
 }
 
 func (p *Push) Sanitize() error {
@@ -109,5 +135,9 @@ func (p *Push) Sanitize() error {
 		return fmt.Errorf("bypass: %w", err)
 	}
 
+	if err := p.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
 	return nil
 }
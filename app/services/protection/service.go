@@ -45,6 +45,7 @@ type (
 		RefProtection
 		MergeVerifier
 		CreatePullReqVerifier
+		CommitsVerifier
 		Protection
 	}
 
@@ -65,6 +65,8 @@ func (s branchRuleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
+			out.CancelsAutoMergeOnPush = out.CancelsAutoMergeOnPush || rOut.CancelsAutoMergeOnPush
 			out.DefaultReviewerApprovals = append(out.DefaultReviewerApprovals, rOut.DefaultReviewerApprovals...)
 
 			return nil
@@ -172,6 +174,31 @@ func (s branchRuleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyIn
 	return violations, nil
 }
 
+func (s branchRuleSet) CommitsVerify(ctx context.Context, in CommitsVerifyInput) ([]types.RuleViolations, error) {
+	var violations []types.RuleViolations
+
+	err := s.forEachRuleMatchBranch(
+		in.Repo.ID,
+		in.Repo.Identifier,
+		in.Repo.DefaultBranch,
+		in.BranchName,
+		func(r *types.RuleInfoInternal, p BranchProtection) error {
+			rVs, err := p.CommitsVerify(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
+	}
+
+	return violations, nil
+}
+
 func (s branchRuleSet) UserIDs() ([]int64, error) {
 	return collectIDs(s.manager, s.rules, Protection.UserIDs)
 }
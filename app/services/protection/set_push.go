@@ -70,6 +70,8 @@ func (s pushRuleSet) PushVerify(
 		out.PrincipalCommitterMatch = out.PrincipalCommitterMatch || rOut.PrincipalCommitterMatch
 
 		out.SecretScanningEnabled = out.SecretScanningEnabled || rOut.SecretScanningEnabled
+
+		out.VerifyChangedFiles = out.VerifyChangedFiles || rOut.VerifyChangedFiles
 	}
 
 	return out, violations, nil
@@ -40,6 +40,7 @@ type (
 		MapUserGroupIDs     func(ctx context.Context, userGroupIDs []int64) (map[int64][]*types.Principal, error)
 		Actor               *types.Principal
 		AllowBypass         bool
+		BypassMergeQueue    bool // the merge queue is skipped only if explicitly requested by a user who can bypass
 		IsRepoOwner         bool
 		TargetRepo          *types.RepositoryCore
 		SourceRepo          *types.RepositoryCore
@@ -48,6 +49,10 @@ type (
 		Method              enum.MergeMethod // the method can be empty for dry run or dry run rules
 		CheckResults        []types.CheckResult
 		CodeOwners          *codeowners.Evaluation
+
+		// ListSourceCommits returns the commits of the pull request with their signature verification results.
+		// It's called only if a rule requires verification of the commits, so the caller should cache the result.
+		ListSourceCommits func(ctx context.Context) ([]*types.Commit, error)
 	}
 
 	MergeVerifyOutput struct {
@@ -59,6 +64,8 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
+		CancelsAutoMergeOnPush              bool
 		DefaultReviewerApprovals            []*types.DefaultReviewerApprovalsResponse
 	}
 
@@ -141,6 +148,8 @@ func (v *DefPullReq) MergeVerify(
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.RequiresMergeQueue = v.Merge.RequireMergeQueue
+	out.CancelsAutoMergeOnPush = v.Merge.CancelAutoMergeOnPush
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -470,9 +479,11 @@ func (c *DefStatusChecks) Sanitize() error {
 }
 
 type DefMerge struct {
-	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
-	DeleteBranch      bool               `json:"delete_branch,omitempty"`
-	Block             bool               `json:"block,omitempty"`
+	StrategiesAllowed     []enum.MergeMethod `json:"strategies_allowed,omitempty"`
+	DeleteBranch          bool               `json:"delete_branch,omitempty"`
+	Block                 bool               `json:"block,omitempty"`
+	RequireMergeQueue     bool               `json:"require_merge_queue,omitempty"`
+	CancelAutoMergeOnPush bool               `json:"cancel_auto_merge_on_push,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -491,6 +502,10 @@ func (v *DefMerge) Sanitize() error {
 
 	slices.Sort(v.StrategiesAllowed)
 
+	if v.RequireMergeQueue && len(v.StrategiesAllowed) == 1 && v.StrategiesAllowed[0] == enum.MergeMethodFastForward {
+		return errors.InvalidArgument("Merge queue can't be required if fast-forward is the only allowed strategy.")
+	}
+
 	return nil
 }
 
@@ -16,17 +16,34 @@ package protection
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
 	codePushFileSizeLimit           = "push.file.size.limit"
 	codePushPrincipalCommitterMatch = "push.principal.committer.match"
 	codeSecretScanningEnabled       = "push.secret.scanning.enabled"
+	codePushProtectedPath           = "push.path.protected"
+	codePushForbiddenFileExtension  = "push.file.extension.forbidden"
+
+	codeCommitsRequireSigned = "commits.require_signed"
 )
 
+// maxViolatingFilesReported is the maximum number of files reported per violation code of a push rule.
+const maxViolatingFilesReported = 50
+
+// maxUnsignedCommitsReported is the maximum number of commit SHAs listed in the unsigned commits violation message.
+const maxUnsignedCommitsReported = 10
+
 type (
 	PushVerifyInput struct {
 		ResolveUserGroupID func(ctx context.Context, userGroupIDs []int64) ([]int64, error)
@@ -46,6 +63,10 @@ type (
 		CommitterMismatchCount  int64
 		SecretScanningEnabled   bool
 		FoundSecretCount        int
+
+		// ChangedFiles are the files changed by the pushed references.
+		// It's populated only if the PushVerifyOutput.VerifyChangedFiles is set.
+		ChangedFiles []git.ChangedFile
 	}
 
 	PushViolationsOutput struct {
@@ -56,6 +77,7 @@ type (
 		FileSizeLimit           int64
 		PrincipalCommitterMatch bool
 		SecretScanningEnabled   bool
+		VerifyChangedFiles      bool
 		Protections             map[int64]PushProtection
 	}
 
@@ -68,17 +90,52 @@ type (
 		Violations(context.Context, *PushViolationsInput) (PushViolationsOutput, error)
 	}
 
+	CommitsVerifier interface {
+		CommitsVerify(ctx context.Context, in CommitsVerifyInput) ([]types.RuleViolations, error)
+	}
+
+	CommitsVerifyInput struct {
+		ResolveUserGroupID func(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+		Actor              *types.Principal
+		AllowBypass        bool
+		IsRepoOwner        bool
+		Repo               *types.RepositoryCore
+		BranchName         string
+
+		// ListCommits returns the new commits of the branch with their signature verification results.
+		// It's called only if a rule requires verification of the commits, so the caller should cache the result.
+		ListCommits func(ctx context.Context) ([]*types.Commit, error)
+	}
+
 	DefPush struct {
-		FileSizeLimit           int64 `json:"file_size_limit"`
-		PrincipalCommitterMatch bool  `json:"principal_committer_match"`
-		SecretScanningEnabled   bool  `json:"secret_scanning_enabled"`
+		FileSizeLimit           int64             `json:"file_size_limit"`
+		PrincipalCommitterMatch bool              `json:"principal_committer_match"`
+		SecretScanningEnabled   bool              `json:"secret_scanning_enabled"`
+		ProtectedPaths          DefProtectedPaths `json:"protected_paths"`
+		ForbiddenFileExtensions []string          `json:"forbidden_file_extensions,omitempty"`
+	}
+
+	// DefProtectedPaths holds the glob patterns of the file paths that can't be changed with a push.
+	DefProtectedPaths struct {
+		Patterns []string `json:"patterns,omitempty"`
+
+		// Bypass lists the principals that are allowed to push changes to the protected paths.
+		// The rule's bypass list applies as well.
+		Bypass DefBypass `json:"bypass"`
 	}
 )
 
+// Ensures that the DefPush and DefProtectedPaths types implement Sanitizer interface.
+var (
+	_ Sanitizer = (*DefPush)(nil)
+	_ Sanitizer = (*DefProtectedPaths)(nil)
+)
+
 func (in *PushViolationsInput) HasViolations() bool {
 	return in.FindOversizeFilesOutput != nil && (in.FindOversizeFilesOutput.Total > 0) ||
 		in.CommitterMismatchCount > 0 ||
-		in.FoundSecretCount > 0
+		in.FoundSecretCount > 0 ||
+		len(in.ChangedFiles) > 0
 }
 
 func (v *DefPush) PushVerify(
@@ -89,5 +146,241 @@ func (v *DefPush) PushVerify(
 		FileSizeLimit:           v.FileSizeLimit,
 		PrincipalCommitterMatch: v.PrincipalCommitterMatch,
 		SecretScanningEnabled:   v.SecretScanningEnabled,
+		VerifyChangedFiles: v.FileSizeLimit > 0 ||
+			len(v.ProtectedPaths.Patterns) > 0 ||
+			len(v.ForbiddenFileExtensions) > 0,
 	}, nil, nil
 }
+
+// fileViolations returns the violations for the changed files: Oversize files, files with forbidden extensions
+// and files on protected paths. The protected path violations are returned separately
+// because they have their own bypass list. The bypass flags of the violations are not set.
+func (v *DefPush) fileViolations(in *PushViolationsInput) (types.RuleViolations, types.RuleViolations) {
+	var violations, pathViolations types.RuleViolations
+
+	if v.FileSizeLimit > 0 && in.FindOversizeFilesOutput != nil {
+		v.oversizeFileViolations(&violations, in.FindOversizeFilesOutput.FileInfos, in.ChangedFiles)
+	}
+
+	if len(v.ForbiddenFileExtensions) > 0 {
+		var count int
+		for _, file := range in.ChangedFiles {
+			if file.Status == gitenum.FileDiffStatusDeleted {
+				continue
+			}
+
+			ext := strings.ToLower(path.Ext(file.Path))
+			if ext == "" || !slices.Contains(v.ForbiddenFileExtensions, ext) {
+				continue
+			}
+
+			if count < maxViolatingFilesReported {
+				violations.Addf(codePushForbiddenFileExtension,
+					"File %q has a forbidden extension %q.", file.Path, ext)
+			}
+			count++
+		}
+
+		if count > maxViolatingFilesReported {
+			violations.Addf(codePushForbiddenFileExtension,
+				"Found %d more file(s) with a forbidden extension.", count-maxViolatingFilesReported)
+		}
+	}
+
+	if len(v.ProtectedPaths.Patterns) > 0 {
+		var count int
+		for _, file := range in.ChangedFiles {
+			filePath, ok := v.ProtectedPaths.matches(file.Path, file.OldPath)
+			if !ok {
+				continue
+			}
+
+			if count < maxViolatingFilesReported {
+				pathViolations.Addf(codePushProtectedPath,
+					"Changes to the protected path %q are not allowed.", filePath)
+			}
+			count++
+		}
+
+		if count > maxViolatingFilesReported {
+			pathViolations.Addf(codePushProtectedPath,
+				"Found %d more changed file(s) on protected paths.", count-maxViolatingFilesReported)
+		}
+	}
+
+	return violations, pathViolations
+}
+
+// oversizeFileViolations adds a violation for each oversize file. The path of an oversize file is known
+// only if the file is present in the final state of the pushed references.
+func (v *DefPush) oversizeFileViolations(
+	violations *types.RuleViolations,
+	fileInfos []git.FileInfo,
+	changedFiles []git.ChangedFile,
+) {
+	paths := make(map[string][]string)
+	for _, file := range changedFiles {
+		if file.Status == gitenum.FileDiffStatusDeleted {
+			continue
+		}
+		paths[file.SHA.String()] = append(paths[file.SHA.String()], file.Path)
+	}
+
+	var unknownPath bool
+	for _, fileInfo := range fileInfos {
+		if fileInfo.Size <= v.FileSizeLimit {
+			continue
+		}
+
+		filePaths, ok := paths[fileInfo.SHA.String()]
+		if !ok {
+			unknownPath = true
+			continue
+		}
+
+		for _, filePath := range filePaths {
+			violations.Addf(codePushFileSizeLimit,
+				"File %q of size %d exceeds the file size limit of %d.", filePath, fileInfo.Size, v.FileSizeLimit)
+		}
+	}
+
+	if unknownPath {
+		violations.Addf(codePushFileSizeLimit,
+			"Found file(s) exceeding the filesize limit of %d.",
+			v.FileSizeLimit,
+		)
+	}
+}
+
+func (v *DefPush) Sanitize() error {
+	if v.FileSizeLimit < 0 {
+		return errors.InvalidArgument("File size limit can't be negative.")
+	}
+
+	if err := v.ProtectedPaths.Sanitize(); err != nil {
+		return fmt.Errorf("protected paths: %w", err)
+	}
+
+	if len(v.ForbiddenFileExtensions) > maxElements {
+		return errors.InvalidArgument("Too many forbidden file extensions provided.")
+	}
+
+	extensions := make([]string, 0, len(v.ForbiddenFileExtensions))
+	for _, ext := range v.ForbiddenFileExtensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		if len(ext) < 2 || strings.ContainsAny(ext[1:], "./") {
+			return errors.InvalidArgumentf("Invalid forbidden file extension: %q.", ext)
+		}
+
+		if !slices.Contains(extensions, ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	slices.Sort(extensions)
+	v.ForbiddenFileExtensions = extensions
+
+	return nil
+}
+
+// matches returns the first of the provided file paths that matches any of the protected path patterns.
+func (v *DefProtectedPaths) matches(filePaths ...string) (string, bool) {
+	for _, filePath := range filePaths {
+		if filePath == "" {
+			continue
+		}
+
+		for _, pattern := range v.Patterns {
+			if patternMatches(pattern, filePath) {
+				return filePath, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (v *DefProtectedPaths) Sanitize() error {
+	if len(v.Patterns) > maxElements {
+		return errors.InvalidArgument("Too many protected path patterns provided.")
+	}
+
+	for _, pattern := range v.Patterns {
+		if err := patternValidate(pattern); err != nil {
+			return err
+		}
+	}
+
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	return nil
+}
+
+// DefCommits holds the requirements for the commits that are pushed or merged to a branch.
+type DefCommits struct {
+	RequireSigned bool `json:"require_signed,omitempty"`
+
+	// Bypass lists the principals that are allowed to push or merge commits that don't satisfy the requirements.
+	// The rule's bypass list applies as well.
+	Bypass DefBypass `json:"bypass"`
+}
+
+// Ensures that the DefCommits type implements Sanitizer interface.
+var _ Sanitizer = (*DefCommits)(nil)
+
+// verify checks the commits provided by the listCommits function and returns the violations.
+// The bypass flags of the violations are not set.
+func (v *DefCommits) verify(
+	ctx context.Context,
+	listCommits func(ctx context.Context) ([]*types.Commit, error),
+) ([]types.RuleViolations, error) {
+	if !v.RequireSigned || listCommits == nil {
+		return nil, nil
+	}
+
+	commits, err := listCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	var unsigned []string
+	for _, commit := range commits {
+		if commit.Signature != nil && commit.Signature.Result == enum.GitSignatureGood {
+			continue
+		}
+
+		unsigned = append(unsigned, commit.SHA.String())
+	}
+
+	if len(unsigned) == 0 {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	if len(unsigned) > maxUnsignedCommitsReported {
+		violations.Addf(codeCommitsRequireSigned,
+			"Found %d commit(s) without a verified signature of the committer, including: %s.",
+			len(unsigned), strings.Join(unsigned[:maxUnsignedCommitsReported], ", "))
+	} else {
+		violations.Addf(codeCommitsRequireSigned,
+			"Found %d commit(s) without a verified signature of the committer: %s.",
+			len(unsigned), strings.Join(unsigned, ", "))
+	}
+
+	return []types.RuleViolations{violations}, nil
+}
+
+func (v *DefCommits) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	return nil
+}
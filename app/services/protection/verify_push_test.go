@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// nolint:gocognit // it's a unit test
+func TestPush_Violations(t *testing.T) {
+	user := &types.Principal{ID: 42}
+	admin := &types.Principal{ID: 43}
+
+	shaSmall := sha.Must("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	shaLarge := sha.Must("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	shaLost := sha.Must("cccccccccccccccccccccccccccccccccccccccc")
+
+	changedFiles := []git.ChangedFile{
+		{Path: "README.md", Status: gitenum.FileDiffStatusModified, SHA: shaSmall},
+		{Path: "assets/video.mp4", Status: gitenum.FileDiffStatusAdded, SHA: shaLarge},
+		{Path: "tools/setup.EXE", Status: gitenum.FileDiffStatusAdded, SHA: shaSmall},
+		{Path: "bin/old.exe", Status: gitenum.FileDiffStatusDeleted, SHA: sha.Nil},
+		{Path: "ci/build.yml", OldPath: ".github/build.yml", Status: gitenum.FileDiffStatusRenamed, SHA: shaSmall},
+		{Path: ".github/workflows/test.yml", Status: gitenum.FileDiffStatusModified, SHA: shaSmall},
+	}
+
+	tests := []struct {
+		name      string
+		push      Push
+		actor     *types.Principal
+		oversize  []git.FileInfo
+		expCodes  [][]string
+		expParams [][][]any
+		expBypass []bool
+	}{
+		{
+			name:      "empty",
+			actor:     user,
+			expCodes:  [][]string{nil},
+			expParams: [][][]any{nil},
+			expBypass: []bool{false},
+		},
+		{
+			name: "file-size-limit",
+			push: Push{
+				Push: DefPush{FileSizeLimit: 100},
+			},
+			actor: user,
+			oversize: []git.FileInfo{
+				{SHA: shaLarge, Size: 200},
+				{SHA: shaLost, Size: 300},
+			},
+			expCodes: [][]string{{codePushFileSizeLimit, codePushFileSizeLimit}},
+			expParams: [][][]any{{
+				{"assets/video.mp4", int64(200), int64(100)},
+				{int64(100)},
+			}},
+			expBypass: []bool{false},
+		},
+		{
+			name: "forbidden-extensions",
+			push: Push{
+				Push: DefPush{ForbiddenFileExtensions: []string{"exe", ".MP4"}},
+			},
+			actor:     user,
+			expCodes:  [][]string{{codePushForbiddenFileExtension, codePushForbiddenFileExtension}},
+			expParams: [][][]any{{{"assets/video.mp4", ".mp4"}, {"tools/setup.EXE", ".exe"}}},
+			expBypass: []bool{false},
+		},
+		{
+			name: "protected-paths",
+			push: Push{
+				Push: DefPush{
+					ProtectedPaths: DefProtectedPaths{Patterns: []string{".github/**"}},
+				},
+			},
+			actor:     user,
+			expCodes:  [][]string{nil, {codePushProtectedPath, codePushProtectedPath}},
+			expParams: [][][]any{nil, {{".github/build.yml"}, {".github/workflows/test.yml"}}},
+			expBypass: []bool{false, false},
+		},
+		{
+			name: "protected-paths-bypass",
+			push: Push{
+				Push: DefPush{
+					ForbiddenFileExtensions: []string{"exe"},
+					ProtectedPaths: DefProtectedPaths{
+						Patterns: []string{"ci/*"},
+						Bypass:   DefBypass{UserIDs: []int64{admin.ID}},
+					},
+				},
+			},
+			actor:     admin,
+			expCodes:  [][]string{{codePushForbiddenFileExtension}, {codePushProtectedPath}},
+			expParams: [][][]any{{{"tools/setup.EXE", ".exe"}}, {{"ci/build.yml"}}},
+			expBypass: []bool{false, true},
+		},
+		{
+			name: "protected-paths-rule-bypass",
+			push: Push{
+				Bypass: DefBypass{UserIDs: []int64{user.ID}},
+				Push: DefPush{
+					ProtectedPaths: DefProtectedPaths{
+						Patterns: []string{"ci/*"},
+						Bypass:   DefBypass{UserIDs: []int64{admin.ID}},
+					},
+				},
+			},
+			actor:     user,
+			expCodes:  [][]string{nil, {codePushProtectedPath}},
+			expParams: [][][]any{nil, {{"ci/build.yml"}}},
+			expBypass: []bool{true, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.push.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			in := &PushViolationsInput{
+				Actor:        test.actor,
+				ChangedFiles: changedFiles,
+			}
+			if len(test.oversize) > 0 {
+				in.FindOversizeFilesOutput = &git.FindOversizeFilesOutput{
+					FileInfos: test.oversize,
+					Total:     int64(len(test.oversize)),
+				}
+			}
+
+			out, err := test.push.Violations(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := len(test.expCodes), len(out.Violations); want != got {
+				t.Errorf("rule violations count mismatch: want=%d got=%d", want, got)
+				return
+			}
+
+			for i, violations := range out.Violations {
+				if want, got := test.expBypass[i], violations.Bypassable; want != got {
+					t.Errorf("rule violations %d bypassable mismatch: want=%t got=%t", i, want, got)
+				}
+
+				inspectBranchViolations(t, test.expCodes[i], test.expParams[i], []types.RuleViolations{violations})
+			}
+		})
+	}
+}
+
+func TestDefPush_Sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     DefPush
+		expExts []string
+		expErr  bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "extensions-normalized",
+			def:     DefPush{ForbiddenFileExtensions: []string{"EXE", " .dll", ".exe"}},
+			expExts: []string{".dll", ".exe"},
+		},
+		{
+			name:   "extension-empty",
+			def:    DefPush{ForbiddenFileExtensions: []string{""}},
+			expErr: true,
+		},
+		{
+			name:   "extension-with-path",
+			def:    DefPush{ForbiddenFileExtensions: []string{"a/b"}},
+			expErr: true,
+		},
+		{
+			name:   "negative-file-size-limit",
+			def:    DefPush{FileSizeLimit: -1},
+			expErr: true,
+		},
+		{
+			name:   "invalid-path-pattern",
+			def:    DefPush{ProtectedPaths: DefProtectedPaths{Patterns: []string{"[a-"}}},
+			expErr: true,
+		},
+		{
+			name:   "empty-path-pattern",
+			def:    DefPush{ProtectedPaths: DefProtectedPaths{Patterns: []string{""}}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			want, got := test.expExts, test.def.ForbiddenFileExtensions
+			if len(want) > 0 && !reflect.DeepEqual(want, got) {
+				t.Errorf("extensions mismatch: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ASN.1 object identifiers used in CMS signatures (RFC 5652).
+var (
+	oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+	oidAttributeContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+
+	oidDigestSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidDigestSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidDigestSHA512 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+	oidSignatureRSAPSS = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+)
+
+var (
+	errUnsupported  = errors.New("unsupported")
+	errBadSignature = errors.New("bad signature")
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     asn1.RawValue `asn1:"optional,explicit,tag:0"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// cmsSignature is a parsed detached CMS (PKCS #7) signature with a single signer,
+// as produced by gpgsm (S/MIME) and gitsign.
+type cmsSignature struct {
+	certificates []*x509.Certificate
+	signer       *x509.Certificate
+
+	digestAlgorithm    asn1.ObjectIdentifier
+	signatureAlgorithm asn1.ObjectIdentifier
+	signature          []byte
+
+	// signedAttrs holds the DER encoding of the signed attributes, nil if there are no signed attributes.
+	signedAttrs   []byte
+	messageDigest []byte
+
+	unsignedAttrs []attribute
+}
+
+// parseCMS parses DER encoded CMS signed data and finds the signer's certificate.
+func parseCMS(der []byte) (*cmsSignature, error) {
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal content info: %w", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data after content info")
+	}
+
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unexpected content type %s", ci.ContentType)
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signed data: %w", err)
+	}
+
+	if len(sd.EncapContentInfo.EContent.Bytes) > 0 {
+		return nil, errors.New("signature is not detached")
+	}
+
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expected exactly one signer, got %d", len(sd.SignerInfos))
+	}
+
+	certificates, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificates: %w", err)
+	}
+
+	si := sd.SignerInfos[0]
+
+	signer, err := findSigner(certificates, si.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &cmsSignature{
+		certificates:       certificates,
+		signer:             signer,
+		digestAlgorithm:    si.DigestAlgorithm.Algorithm,
+		signatureAlgorithm: si.SignatureAlgorithm.Algorithm,
+		signature:          si.Signature,
+	}
+
+	if len(si.SignedAttrs.FullBytes) > 0 {
+		attrs, err := parseAttributes(si.SignedAttrs.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signed attributes: %w", err)
+		}
+
+		for _, attr := range attrs {
+			if attr.Type.Equal(oidAttributeMessageDigest) {
+				if _, err := asn1.Unmarshal(attr.Values.Bytes, &sig.messageDigest); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal message digest: %w", err)
+				}
+			}
+		}
+
+		if sig.messageDigest == nil {
+			return nil, errors.New("message digest attribute is missing")
+		}
+
+		// The signature is calculated over the DER encoding of the SET OF attributes,
+		// not over the implicitly tagged [0] value that is found in the signer info.
+		sig.signedAttrs = bytes.Clone(si.SignedAttrs.FullBytes)
+		sig.signedAttrs[0] = asn1.TagSet | 0x20 // constructed
+	}
+
+	if len(si.UnsignedAttrs.FullBytes) > 0 {
+		sig.unsignedAttrs, err = parseAttributes(si.UnsignedAttrs.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse unsigned attributes: %w", err)
+		}
+	}
+
+	return sig, nil
+}
+
+func findSigner(certificates []*x509.Certificate, sid asn1.RawValue) (*x509.Certificate, error) {
+	switch {
+	case sid.Class == asn1.ClassUniversal && sid.Tag == asn1.TagSequence:
+		var ias issuerAndSerialNumber
+		if _, err := asn1.Unmarshal(sid.FullBytes, &ias); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal signer identifier: %w", err)
+		}
+
+		for _, cert := range certificates {
+			if cert.SerialNumber.Cmp(ias.SerialNumber) == 0 && bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) {
+				return cert, nil
+			}
+		}
+	case sid.Class == asn1.ClassContextSpecific && sid.Tag == 0:
+		for _, cert := range certificates {
+			if bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert, nil
+			}
+		}
+	default:
+		return nil, errors.New("unrecognized signer identifier")
+	}
+
+	return nil, errors.New("signer certificate not found")
+}
+
+func parseAttributes(data []byte) ([]attribute, error) {
+	var attrs []attribute
+	for len(data) > 0 {
+		var attr attribute
+		rest, err := asn1.Unmarshal(data, &attr)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs, attr)
+		data = rest
+	}
+
+	return attrs, nil
+}
+
+// unsignedAttribute returns the first value of the unsigned attribute with the provided type.
+func (s *cmsSignature) unsignedAttribute(oid asn1.ObjectIdentifier) []byte {
+	for _, attr := range s.unsignedAttrs {
+		if attr.Type.Equal(oid) {
+			var value asn1.RawValue
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &value); err != nil {
+				return nil
+			}
+			return value.FullBytes
+		}
+	}
+
+	return nil
+}
+
+// verify checks that the signature matches the signed content and that it's signed by the signer certificate.
+// Returns errUnsupported for unsupported algorithms and errBadSignature if the signature doesn't match.
+func (s *cmsSignature) verify(signedContent []byte) error {
+	var hash crypto.Hash
+	switch {
+	case s.digestAlgorithm.Equal(oidDigestSHA256):
+		hash = crypto.SHA256
+	case s.digestAlgorithm.Equal(oidDigestSHA384):
+		hash = crypto.SHA384
+	case s.digestAlgorithm.Equal(oidDigestSHA512):
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("digest algorithm %s: %w", s.digestAlgorithm, errUnsupported)
+	}
+
+	if s.signatureAlgorithm.Equal(oidSignatureRSAPSS) {
+		return fmt.Errorf("signature algorithm %s: %w", s.signatureAlgorithm, errUnsupported)
+	}
+
+	message := signedContent
+	if s.signedAttrs != nil {
+		h := hash.New()
+		h.Write(signedContent)
+		if !bytes.Equal(h.Sum(nil), s.messageDigest) {
+			return fmt.Errorf("message digest mismatch: %w", errBadSignature)
+		}
+
+		message = s.signedAttrs
+	}
+
+	h := hash.New()
+	h.Write(message)
+	digest := h.Sum(nil)
+
+	var ok bool
+	switch pub := s.signer.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(pub, digest, s.signature)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(pub, hash, digest, s.signature) == nil
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(pub, message, s.signature)
+	default:
+		return fmt.Errorf("public key type %T: %w", pub, errUnsupported)
+	}
+
+	if !ok {
+		return errBadSignature
+	}
+
+	return nil
+}
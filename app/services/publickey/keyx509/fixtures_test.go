@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The fixtures are generated for every test run, so the certificate validity periods
+// are always relative to the current time.
+
+var (
+	oidData                     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignatureECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+var testSerial int64
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+// newTestCA creates a self-signed certificate authority.
+func newTestCA(t *testing.T, name string) testCert {
+	t.Helper()
+
+	return newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+// newTestLeaf creates a signing certificate for the email, valid in the provided period.
+func newTestLeaf(t *testing.T, issuer testCert, email string, notBefore, notAfter time.Time) testCert {
+	t.Helper()
+
+	return newTestCert(t, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: email},
+		EmailAddresses: []string{email},
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, &issuer)
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, issuer *testCert) testCert {
+	t.Helper()
+
+	testSerial++
+	template.SerialNumber = big.NewInt(testSerial)
+
+	key := newTestKey(t)
+
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return testCert{cert: cert, key: key}
+}
+
+func encodeCerts(certs ...*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+// testRekorLog is a fake Rekor transparency log that issues signed entry timestamps.
+type testRekorLog struct {
+	key   *ecdsa.PrivateKey
+	logID []byte
+}
+
+func newTestRekorLog(t *testing.T) *testRekorLog {
+	t.Helper()
+
+	key := newTestKey(t)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	logID := sha256.Sum256(der)
+
+	return &testRekorLog{key: key, logID: logID[:]}
+}
+
+func (l *testRekorLog) publicKeys(t *testing.T) map[string]crypto.PublicKey {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(&l.key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	keys, err := newPublicKeys(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("failed to create public keys: %v", err)
+	}
+
+	return keys
+}
+
+// entry creates the serialized TransparencyLogEntry protobuf message for the signature, as stored by gitsign.
+// The log signs the entry with the signedTime, but the entry claims the integratedTime,
+// which allows to create entries tampered with after they have been signed.
+func (l *testRekorLog) entry(
+	t *testing.T,
+	signer *x509.Certificate,
+	signature []byte,
+	integratedTime time.Time,
+	signedTime time.Time,
+) []byte {
+	t.Helper()
+
+	var body rekorBody
+	body.Spec.Signature.Content = base64.StdEncoding.EncodeToString(signature)
+	body.Spec.Signature.PublicKey.Content = base64.StdEncoding.EncodeToString(encodeCerts(signer))
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal rekor body: %v", err)
+	}
+
+	const logIndex = 42
+
+	payload, err := json.Marshal(rekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(bodyJSON),
+		IntegratedTime: signedTime.Unix(),
+		LogID:          hex.EncodeToString(l.logID),
+		LogIndex:       logIndex,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rekor payload: %v", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	set, err := ecdsa.SignASN1(rand.Reader, l.key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign rekor payload: %v", err)
+	}
+
+	var data []byte
+	data = protowire.AppendTag(data, 1, protowire.VarintType)
+	data = protowire.AppendVarint(data, logIndex)
+	data = protowire.AppendTag(data, 2, protowire.BytesType)
+	data = protowire.AppendBytes(data, protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), l.logID))
+	data = protowire.AppendTag(data, 4, protowire.VarintType)
+	data = protowire.AppendVarint(data, uint64(integratedTime.Unix())) //nolint:gosec
+	data = protowire.AppendTag(data, 5, protowire.BytesType)
+	data = protowire.AppendBytes(data, protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), set))
+	data = protowire.AppendTag(data, 7, protowire.BytesType)
+	data = protowire.AppendBytes(data, bodyJSON)
+
+	return data
+}
+
+// signCMS creates a PEM encoded detached CMS signature of the content, as produced by gpgsm and gitsign.
+// The optional rekor function returns the transparency log entry stored in the unsigned attributes.
+func signCMS(
+	t *testing.T,
+	signer testCert,
+	chain []*x509.Certificate,
+	content []byte,
+	rekor func(signature []byte) []byte,
+) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(content)
+
+	signedAttrs := marshalAttributes(t,
+		newAttribute(t, oidAttributeContentType, oidData),
+		newAttribute(t, oidAttributeMessageDigest, digest[:]),
+	)
+
+	attrsDigest := sha256.Sum256(mustMarshal(t, asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signedAttrs,
+	}))
+	signature, err := ecdsa.SignASN1(rand.Reader, signer.key, attrsDigest[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	si := signerInfo{
+		Version: 1,
+		SID: asn1.RawValue{FullBytes: mustMarshal(t, issuerAndSerialNumber{
+			Issuer:       asn1.RawValue{FullBytes: signer.cert.RawIssuer},
+			SerialNumber: signer.cert.SerialNumber,
+		})},
+		DigestAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidDigestSHA256},
+		SignedAttrs: asn1.RawValue{
+			Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedAttrs,
+		},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSignatureECDSAWithSHA256},
+		Signature:          signature,
+	}
+
+	if rekor != nil {
+		entry := mustMarshal(t, rekor(signature))
+		si.UnsignedAttrs = asn1.RawValue{
+			Class: asn1.ClassContextSpecific, Tag: 1, IsCompound: true,
+			Bytes: marshalAttributes(t, newAttribute(t, oidRekorTransparencyLogEntry, asn1.RawValue{FullBytes: entry})),
+		}
+	}
+
+	var certs []byte
+	for _, cert := range append([]*x509.Certificate{signer.cert}, chain...) {
+		certs = append(certs, cert.Raw...)
+	}
+
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidDigestSHA256}},
+		EncapContentInfo: encapContentInfo{EContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certs},
+		SignerInfos:      []signerInfo{si},
+	}
+
+	der := mustMarshal(t, contentInfo{
+		ContentType: oidSignedData,
+		Content: asn1.RawValue{
+			Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: mustMarshal(t, sd),
+		},
+	})
+
+	return pem.EncodeToMemory(&pem.Block{Type: SignatureType, Bytes: der})
+}
+
+func newAttribute(t *testing.T, oid asn1.ObjectIdentifier, value any) attribute {
+	t.Helper()
+
+	return attribute{
+		Type: oid,
+		Values: asn1.RawValue{
+			Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: mustMarshal(t, value),
+		},
+	}
+}
+
+func marshalAttributes(t *testing.T, attrs ...attribute) []byte {
+	t.Helper()
+
+	var data []byte
+	for _, attr := range attrs {
+		data = append(data, mustMarshal(t, attr)...)
+	}
+	return data
+}
+
+func mustMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+
+	data, err := asn1.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to marshal ASN.1 value: %v", err)
+	}
+	return data
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// oidRekorTransparencyLogEntry is the unsigned CMS attribute in which gitsign stores the Rekor
+// transparency log entry (a serialized dev.sigstore.rekor.v1.TransparencyLogEntry protobuf message).
+var oidRekorTransparencyLogEntry = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 3, 8}
+
+// rekorEntry holds the fields of a Rekor transparency log entry required for the offline verification.
+type rekorEntry struct {
+	logIndex             int64
+	logID                []byte
+	integratedTime       int64
+	signedEntryTimestamp []byte
+	body                 []byte
+}
+
+// rekorEntryFromSignature extracts the Rekor transparency log entry from the CMS signature.
+// Returns nil if the signature doesn't contain it.
+func rekorEntryFromSignature(sig *cmsSignature) (*rekorEntry, error) {
+	raw := sig.unsignedAttribute(oidRekorTransparencyLogEntry)
+	if raw == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	var data []byte
+	if _, err := asn1.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transparency log entry attribute: %w", err)
+	}
+
+	return parseRekorEntry(data)
+}
+
+// parseRekorEntry decodes the protobuf message dev.sigstore.rekor.v1.TransparencyLogEntry.
+// Only the fields required for verification of the signed entry timestamp are decoded.
+func parseRekorEntry(data []byte) (*rekorEntry, error) {
+	const (
+		fieldLogIndex          = 1
+		fieldLogID             = 2
+		fieldIntegratedTime    = 4
+		fieldInclusionPromise  = 5
+		fieldCanonicalizedBody = 7
+
+		fieldLogIDKeyID                  = 1
+		fieldPromiseSignedEntryTimestamp = 1
+	)
+
+	entry := &rekorEntry{}
+
+	err := forEachField(data, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == fieldLogIndex && typ == protowire.VarintType:
+			entry.logIndex = int64(varint) //nolint:gosec
+		case num == fieldIntegratedTime && typ == protowire.VarintType:
+			entry.integratedTime = int64(varint) //nolint:gosec
+		case num == fieldCanonicalizedBody && typ == protowire.BytesType:
+			entry.body = value
+		case num == fieldLogID && typ == protowire.BytesType:
+			return forEachField(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if num == fieldLogIDKeyID && typ == protowire.BytesType {
+					entry.logID = value
+				}
+				return nil
+			})
+		case num == fieldInclusionPromise && typ == protowire.BytesType:
+			return forEachField(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if num == fieldPromiseSignedEntryTimestamp && typ == protowire.BytesType {
+					entry.signedEntryTimestamp = value
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transparency log entry: %w", err)
+	}
+
+	if len(entry.body) == 0 || len(entry.logID) == 0 || len(entry.signedEntryTimestamp) == 0 {
+		return nil, errors.New("incomplete transparency log entry")
+	}
+
+	return entry, nil
+}
+
+func forEachField(
+	data []byte,
+	fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error,
+) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var value []byte
+		var varint uint64
+
+		switch typ { //nolint:exhaustive
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rekorPayload is the payload signed by the Rekor's signed entry timestamp.
+// The fields are in the lexicographical order, so the JSON encoding is the canonical one.
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// rekorBody is the part of a canonicalized "hashedrekord" log entry body that binds it to a signature.
+type rekorBody struct {
+	Spec struct {
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyRekorEntry verifies offline that the entry is included in a trusted transparency log,
+// using the log's signed entry timestamp (the inclusion promise), and that the entry belongs to the signature.
+// It returns the time when the entry has been integrated into the log.
+func verifyRekorEntry(entry *rekorEntry, logKeys map[string]crypto.PublicKey, sig *cmsSignature) (time.Time, error) {
+	logID := hex.EncodeToString(entry.logID)
+
+	key, ok := logKeys[logID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("transparency log %s is not trusted", logID)
+	}
+
+	payload, err := json.Marshal(rekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(entry.body),
+		IntegratedTime: entry.integratedTime,
+		LogID:          logID,
+		LogIndex:       entry.logIndex,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to marshal signed entry timestamp payload: %w", err)
+	}
+
+	digest := sha256.Sum256(payload)
+
+	switch pub := key.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(pub, digest[:], entry.signedEntryTimestamp)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(pub, payload, entry.signedEntryTimestamp)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported transparency log key type %T", pub)
+	}
+	if !ok {
+		return time.Time{}, errors.New("invalid signed entry timestamp")
+	}
+
+	var body rekorBody
+	if err := json.Unmarshal(entry.body, &body); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal transparency log entry body: %w", err)
+	}
+
+	entrySignature, err := base64.StdEncoding.DecodeString(body.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(entrySignature, sig.signature) {
+		return time.Time{}, errors.New("transparency log entry doesn't match the signature")
+	}
+
+	entryCertPEM, err := base64.StdEncoding.DecodeString(body.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		return time.Time{}, errors.New("transparency log entry has malformed certificate")
+	}
+
+	block, _ := pem.Decode(entryCertPEM)
+	if block == nil || !bytes.Equal(block.Bytes, sig.signer.Raw) {
+		return time.Time{}, errors.New("transparency log entry doesn't match the signing certificate")
+	}
+
+	return time.Unix(entry.integratedTime, 0), nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseRekorEntry(t *testing.T) {
+	ca := newTestCA(t, "Test Fulcio Root")
+	leaf := newTestLeaf(t, ca, "jane.doe@example.com", time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	integratedTime := time.Unix(1700000000, 0)
+
+	valid := newTestRekorLog(t).entry(t, leaf.cert, []byte("signature"), integratedTime, integratedTime)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expError bool
+	}{
+		{name: "valid", data: valid},
+		{name: "truncated", data: valid[:len(valid)-1], expError: true},
+		{
+			name:     "incomplete",
+			data:     protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1),
+			expError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry, err := parseRekorEntry(test.data)
+			if test.expError {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.integratedTime != integratedTime.Unix() {
+				t.Errorf("integrated time mismatch: want=%d got=%d", integratedTime.Unix(), entry.integratedTime)
+			}
+			if entry.logIndex != 42 {
+				t.Errorf("log index mismatch: want=42 got=%d", entry.logIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Config holds the configuration of the trust roots used to verify X.509 signatures.
+type Config struct {
+	// CABundlePath is a path to a PEM file with the certificates of the trusted certificate authorities,
+	// e.g. a corporate CA bundle. Both root and intermediate certificates can be provided.
+	CABundlePath string
+
+	// FulcioRootsPath is a path to a PEM file with the Sigstore Fulcio root and intermediate certificates.
+	// Fulcio certificates are short-lived, so they are verified at the time the signature has been
+	// integrated into the Rekor transparency log.
+	FulcioRootsPath string
+
+	// RekorPublicKeysPath is a path to a PEM file with the public keys of the trusted Rekor transparency logs.
+	RekorPublicKeysPath string
+
+	// FulcioIssuers optionally limits the accepted OIDC issuers of the Fulcio certificates.
+	FulcioIssuers []string
+}
+
+// Trust holds the trust roots used to verify X.509 signatures.
+type Trust struct {
+	ca            *certPool
+	fulcio        *certPool
+	rekorKeys     map[string]crypto.PublicKey // map key is the log ID: the hex encoded SHA-256 of the key.
+	fulcioIssuers []string
+}
+
+type certPool struct {
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+}
+
+// LoadTrust loads the trust roots from the files provided in the config.
+// It returns nil if no trust roots are configured, meaning X.509 signatures can not be verified.
+func LoadTrust(config Config) (*Trust, error) {
+	if config.CABundlePath == "" && config.FulcioRootsPath == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	if config.FulcioRootsPath != "" && config.RekorPublicKeysPath == "" {
+		return nil, errors.New("fulcio roots require rekor public keys for verification of the signing time")
+	}
+
+	trust := &Trust{
+		fulcioIssuers: config.FulcioIssuers,
+	}
+
+	var err error
+
+	if config.CABundlePath != "" {
+		trust.ca, err = loadCertPool(config.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA bundle: %w", err)
+		}
+	}
+
+	if config.FulcioRootsPath != "" {
+		trust.fulcio, err = loadCertPool(config.FulcioRootsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load fulcio roots: %w", err)
+		}
+
+		trust.rekorKeys, err = loadPublicKeys(config.RekorPublicKeysPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rekor public keys: %w", err)
+		}
+	}
+
+	return trust, nil
+}
+
+func loadCertPool(path string) (*certPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCertPool(data)
+}
+
+// newCertPool creates a pool of certificates from PEM data.
+// Self-signed certificates are roots, all other certificates are intermediates.
+func newCertPool(data []byte) (*certPool, error) {
+	pool := &certPool{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+	}
+
+	var rootCount int
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		if cert.CheckSignatureFrom(cert) == nil {
+			pool.roots.AddCert(cert)
+			rootCount++
+		} else {
+			pool.intermediates.AddCert(cert)
+		}
+	}
+
+	if rootCount == 0 {
+		return nil, errors.New("no root certificates found")
+	}
+
+	return pool, nil
+}
+
+func loadPublicKeys(path string) (map[string]crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPublicKeys(data)
+}
+
+// newPublicKeys parses PEM encoded public keys and returns them mapped by the transparency log ID.
+func newPublicKeys(data []byte) (map[string]crypto.PublicKey, error) {
+	keys := make(map[string]crypto.PublicKey)
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+
+		logID := sha256.Sum256(block.Bytes)
+		keys[hex.EncodeToString(logID[:])] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no public keys found")
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+)
+
+func TestNewCertPool(t *testing.T) {
+	root := newTestCA(t, "Test Root CA")
+	intermediate := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, &root)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expError bool
+	}{
+		{name: "root", data: encodeCerts(root.cert)},
+		{name: "root-and-intermediate", data: encodeCerts(intermediate.cert, root.cert)},
+		{name: "intermediate-only", data: encodeCerts(intermediate.cert), expError: true},
+		{name: "empty", data: nil, expError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newCertPool(test.data)
+			if test.expError != (err != nil) {
+				t.Errorf("error mismatch: want error=%t got=%v", test.expError, err)
+			}
+		})
+	}
+}
+
+func TestLoadTrust(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expNil   bool
+		expError bool
+	}{
+		{name: "not-configured", config: Config{}, expNil: true},
+		{name: "fulcio-without-rekor", config: Config{FulcioRootsPath: "fulcio.pem"}, expNil: true, expError: true},
+		{name: "missing-ca-bundle", config: Config{CABundlePath: "/nonexistent/ca.pem"}, expNil: true, expError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trust, err := LoadTrust(test.config)
+			if test.expError != (err != nil) {
+				t.Errorf("error mismatch: want error=%t got=%v", test.expError, err)
+			}
+			if test.expNil != (trust == nil) {
+				t.Errorf("trust mismatch: want nil=%t got=%v", test.expNil, trust)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/keyfetcher"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SignatureType is the PEM block type of X.509 signatures produced by gpgsm and gitsign.
+const SignatureType = "SIGNED MESSAGE"
+
+var (
+	// oidFulcioIssuer and oidFulcioIssuerV2 are the Fulcio certificate extensions holding the OIDC issuer.
+	oidFulcioIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidFulcioIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+	oidEmailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+)
+
+type Verify struct {
+	trust *Trust
+
+	signature      *cmsSignature
+	keyID          string
+	keyFingerprint string
+}
+
+func NewVerify(trust *Trust) *Verify {
+	return &Verify{trust: trust}
+}
+
+// Parse parses the provided PEM encoded CMS signature and finds the signer's certificate.
+func (v *Verify) Parse(
+	ctx context.Context,
+	signature []byte,
+	objectSHA sha.SHA,
+) enum.GitSignatureResult {
+	block, _ := pem.Decode(signature)
+	if block == nil {
+		log.Ctx(ctx).Warn().
+			Str("object_sha", objectSHA.String()).
+			Msg("failed to decode signature")
+		return enum.GitSignatureInvalid
+	}
+	if block.Type != SignatureType {
+		log.Ctx(ctx).Warn().
+			Str("signature_type", block.Type).
+			Str("object_sha", objectSHA.String()).
+			Msg("unexpected X.509 signature block type")
+		return enum.GitSignatureInvalid
+	}
+
+	sig, err := parseCMS(block.Bytes)
+	if err != nil {
+		log.Ctx(ctx).Warn().
+			Err(err).
+			Str("object_sha", objectSHA.String()).
+			Msg("failed to parse X.509 signature")
+		return enum.GitSignatureInvalid
+	}
+
+	fingerprint := sha256.Sum256(sig.signer.Raw)
+
+	v.signature = sig
+	v.keyID = fmt.Sprintf("%X", sig.signer.SerialNumber)
+	v.keyFingerprint = fmt.Sprintf("%X", fingerprint)
+
+	return ""
+}
+
+// Key returns the signer's certificate. X.509 certificates are not stored in the DB,
+// their trust is established with the configured trust roots, so the returned key is never nil.
+func (v *Verify) Key(
+	_ context.Context,
+	_ keyfetcher.Service,
+	principalID int64,
+) (*types.PublicKey, error) {
+	return &types.PublicKey{
+		PrincipalID: principalID,
+		Fingerprint: v.keyFingerprint,
+		Content:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: v.signature.signer.Raw})),
+		Usage:       enum.PublicKeyUsageSign,
+		Scheme:      enum.PublicKeySchemeX509,
+	}, nil
+}
+
+// Verify checks the signature, that the signer's certificate belongs to the committer
+// and that the certificate has been issued by one of the trusted certificate authorities.
+func (v *Verify) Verify(
+	ctx context.Context,
+	_ []byte,
+	signedContent []byte,
+	objectSHA sha.SHA,
+	committer types.Signature,
+) enum.GitSignatureResult {
+	sig := v.signature
+
+	if err := sig.verify(signedContent); err != nil {
+		if errors.Is(err, errUnsupported) {
+			return enum.GitSignatureUnsupported
+		}
+		if errors.Is(err, errBadSignature) {
+			return enum.GitSignatureBad
+		}
+
+		log.Ctx(ctx).Warn().
+			Err(err).
+			Str("object_sha", objectSHA.String()).
+			Msg("failed to verify X.509 signature")
+		return enum.GitSignatureInvalid
+	}
+
+	if !slices.ContainsFunc(certificateEmails(sig.signer), func(email string) bool {
+		return strings.EqualFold(email, committer.Identity.Email)
+	}) {
+		return enum.GitSignatureBad
+	}
+
+	if v.trust == nil {
+		return enum.GitSignatureUnverified
+	}
+
+	var expired bool
+
+	if v.trust.ca != nil {
+		// The signing time attribute and the committer time are both controlled by the signer,
+		// so without a trusted timestamp the certificate chain must be valid now.
+		switch err := v.trust.ca.verify(sig, time.Now()); {
+		case err == nil:
+			return enum.GitSignatureGood
+		case isExpired(err):
+			expired = true
+		}
+	}
+
+	if v.trust.fulcio != nil {
+		result := v.verifyFulcio(ctx, objectSHA)
+		if result != enum.GitSignatureUnverified {
+			return result
+		}
+	}
+
+	if expired {
+		return enum.GitSignatureKeyExpired
+	}
+
+	return enum.GitSignatureUnverified
+}
+
+// verifyFulcio verifies that the signer's certificate is issued by Fulcio.
+// Fulcio certificates are valid only for a few minutes, so their validity is checked at the time
+// the signature has been recorded in the Rekor transparency log.
+func (v *Verify) verifyFulcio(ctx context.Context, objectSHA sha.SHA) enum.GitSignatureResult {
+	sig := v.signature
+
+	if err := v.trust.fulcio.verify(sig, sig.signer.NotBefore); err != nil {
+		return enum.GitSignatureUnverified // not issued by Fulcio
+	}
+
+	if len(v.trust.fulcioIssuers) > 0 && !slices.Contains(v.trust.fulcioIssuers, fulcioIssuer(sig.signer)) {
+		return enum.GitSignatureUnverified
+	}
+
+	entry, err := rekorEntryFromSignature(sig)
+	if err != nil {
+		log.Ctx(ctx).Warn().
+			Err(err).
+			Str("object_sha", objectSHA.String()).
+			Msg("failed to parse transparency log entry of X.509 signature")
+		return enum.GitSignatureInvalid
+	}
+	if entry == nil {
+		return enum.GitSignatureUnverified
+	}
+
+	integratedTime, err := verifyRekorEntry(entry, v.trust.rekorKeys, sig)
+	if err != nil {
+		log.Ctx(ctx).Info().
+			Err(err).
+			Str("object_sha", objectSHA.String()).
+			Msg("transparency log entry verification failed")
+		return enum.GitSignatureBad
+	}
+
+	switch err := v.trust.fulcio.verify(sig, integratedTime); {
+	case err == nil:
+		return enum.GitSignatureGood
+	case isExpired(err):
+		return enum.GitSignatureKeyExpired
+	default:
+		return enum.GitSignatureUnverified
+	}
+}
+
+func (v *Verify) KeyScheme() enum.PublicKeyScheme {
+	return enum.PublicKeySchemeX509
+}
+
+func (v *Verify) KeyID() string {
+	return v.keyID
+}
+
+func (v *Verify) KeyFingerprint() string {
+	return v.keyFingerprint
+}
+
+// verify verifies the signer's certificate chain at the provided time.
+// The intermediate certificates embedded in the signature are used to build the chain.
+func (p *certPool) verify(sig *cmsSignature, at time.Time) error {
+	intermediates := p.intermediates.Clone()
+	for _, cert := range sig.certificates {
+		if cert != sig.signer {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	_, err := sig.signer.Verify(x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+
+	return err
+}
+
+func isExpired(err error) bool {
+	var errInvalid x509.CertificateInvalidError
+	return errors.As(err, &errInvalid) && errInvalid.Reason == x509.Expired
+}
+
+// certificateEmails returns all email addresses of the certificate:
+// from the subject alternative name extension and from the subject.
+func certificateEmails(cert *x509.Certificate) []string {
+	emails := slices.Clone(cert.EmailAddresses)
+	for _, name := range cert.Subject.Names {
+		if !name.Type.Equal(oidEmailAddress) {
+			continue
+		}
+		if email, ok := name.Value.(string); ok {
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+// fulcioIssuer returns the OIDC issuer of a Fulcio certificate.
+func fulcioIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidFulcioIssuerV2):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(oidFulcioIssuer):
+			return string(ext.Value)
+		}
+	}
+
+	return ""
+}
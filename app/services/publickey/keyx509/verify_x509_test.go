@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyx509
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestVerify(t *testing.T) {
+	const email = "jane.doe@example.com"
+
+	now := time.Now()
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit message\n")
+
+	root := newTestCA(t, "Test Root CA")
+	intermediate := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             now.Add(-24 * time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, &root)
+	untrustedRoot := newTestCA(t, "Untrusted Root CA")
+
+	caPool, err := newCertPool(encodeCerts(root.cert))
+	if err != nil {
+		t.Fatalf("failed to create CA pool: %v", err)
+	}
+
+	leaf := newTestLeaf(t, intermediate, email, now.Add(-time.Hour), now.Add(time.Hour))
+	expiredLeaf := newTestLeaf(t, intermediate, email, now.Add(-20*time.Hour), now.Add(-time.Hour))
+	untrustedLeaf := newTestLeaf(t, untrustedRoot, email, now.Add(-time.Hour), now.Add(time.Hour))
+
+	fulcioRoot := newTestCA(t, "Test Fulcio Root")
+	fulcioPool, err := newCertPool(encodeCerts(fulcioRoot.cert))
+	if err != nil {
+		t.Fatalf("failed to create fulcio pool: %v", err)
+	}
+
+	rekorLog := newTestRekorLog(t)
+	untrustedRekorLog := newTestRekorLog(t)
+
+	// Fulcio certificates are valid only for a few minutes.
+	signedAt := now.Add(-2 * time.Hour)
+	fulcioLeaf := newTestLeaf(t, fulcioRoot, email, signedAt.Add(-time.Minute), signedAt.Add(9*time.Minute))
+
+	caTrust := &Trust{ca: caPool}
+	fulcioTrust := &Trust{fulcio: fulcioPool, rekorKeys: rekorLog.publicKeys(t)}
+
+	tests := []struct {
+		name      string
+		trust     *Trust
+		signature []byte
+		content   []byte
+		email     string
+		exp       enum.GitSignatureResult
+	}{
+		{
+			name:      "good",
+			trust:     caTrust,
+			signature: signCMS(t, leaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			exp:       enum.GitSignatureGood,
+		},
+		{
+			name:      "bad-digest",
+			trust:     caTrust,
+			signature: signCMS(t, leaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			content:   []byte("tampered content"),
+			exp:       enum.GitSignatureBad,
+		},
+		{
+			name:      "email-mismatch",
+			trust:     caTrust,
+			signature: signCMS(t, leaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			email:     "john.doe@example.com",
+			exp:       enum.GitSignatureBad,
+		},
+		{
+			name:      "email-case-insensitive",
+			trust:     caTrust,
+			signature: signCMS(t, leaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			email:     "Jane.Doe@Example.com",
+			exp:       enum.GitSignatureGood,
+		},
+		{
+			// the certificate was valid at the commit time, but the commit time is controlled by the signer.
+			name:      "expired-cert",
+			trust:     caTrust,
+			signature: signCMS(t, expiredLeaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			exp:       enum.GitSignatureKeyExpired,
+		},
+		{
+			name:      "missing-intermediate",
+			trust:     caTrust,
+			signature: signCMS(t, leaf, nil, content, nil),
+			exp:       enum.GitSignatureUnverified,
+		},
+		{
+			name:      "untrusted-root",
+			trust:     caTrust,
+			signature: signCMS(t, untrustedLeaf, nil, content, nil),
+			exp:       enum.GitSignatureUnverified,
+		},
+		{
+			name:      "no-trust",
+			trust:     nil,
+			signature: signCMS(t, leaf, []*x509.Certificate{intermediate.cert}, content, nil),
+			exp:       enum.GitSignatureUnverified,
+		},
+		{
+			name:  "rekor-valid",
+			trust: fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, func(signature []byte) []byte {
+				return rekorLog.entry(t, fulcioLeaf.cert, signature, signedAt, signedAt)
+			}),
+			exp: enum.GitSignatureGood,
+		},
+		{
+			name:  "rekor-tampered-time",
+			trust: fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, func(signature []byte) []byte {
+				return rekorLog.entry(t, fulcioLeaf.cert, signature, now, signedAt)
+			}),
+			exp: enum.GitSignatureBad,
+		},
+		{
+			name:  "rekor-other-signature",
+			trust: fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, func([]byte) []byte {
+				return rekorLog.entry(t, fulcioLeaf.cert, []byte("other signature"), signedAt, signedAt)
+			}),
+			exp: enum.GitSignatureBad,
+		},
+		{
+			name:  "rekor-untrusted-log",
+			trust: fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, func(signature []byte) []byte {
+				return untrustedRekorLog.entry(t, fulcioLeaf.cert, signature, signedAt, signedAt)
+			}),
+			exp: enum.GitSignatureBad,
+		},
+		{
+			name:  "rekor-integrated-after-expiry",
+			trust: fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, func(signature []byte) []byte {
+				return rekorLog.entry(t, fulcioLeaf.cert, signature, now, now)
+			}),
+			exp: enum.GitSignatureKeyExpired,
+		},
+		{
+			name:      "rekor-missing",
+			trust:     fulcioTrust,
+			signature: signCMS(t, fulcioLeaf, nil, content, nil),
+			exp:       enum.GitSignatureUnverified,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			signedContent := content
+			if test.content != nil {
+				signedContent = test.content
+			}
+
+			committerEmail := email
+			if test.email != "" {
+				committerEmail = test.email
+			}
+
+			committer := types.Signature{
+				Identity: types.Identity{Name: "Jane Doe", Email: committerEmail},
+				When:     now.Add(-2 * time.Hour),
+			}
+
+			v := NewVerify(test.trust)
+
+			if result := v.Parse(ctx, test.signature, sha.None); result != "" {
+				t.Fatalf("failed to parse signature: %s", result)
+			}
+
+			if result := v.Verify(ctx, nil, signedContent, sha.None, committer); result != test.exp {
+				t.Errorf("result mismatch: want=%s got=%s", test.exp, result)
+			}
+		})
+	}
+}
+
+func TestVerify_ParseInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature []byte
+	}{
+		{name: "not-pem", signature: []byte("garbage")},
+		{name: "wrong-block-type", signature: []byte("-----BEGIN PGP SIGNATURE-----\nAAAA\n-----END PGP SIGNATURE-----\n")},
+		{name: "not-cms", signature: []byte("-----BEGIN SIGNED MESSAGE-----\nAAAA\n-----END SIGNED MESSAGE-----\n")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := NewVerify(nil).Parse(context.Background(), test.signature, sha.None)
+			if result != enum.GitSignatureInvalid {
+				t.Errorf("result mismatch: want=%s got=%s", enum.GitSignatureInvalid, result)
+			}
+		})
+	}
+}
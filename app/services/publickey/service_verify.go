@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/services/publickey/keypgp"
 	"github.com/harness/gitness/app/services/publickey/keyssh"
+	"github.com/harness/gitness/app/services/publickey/keyx509"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git/sha"
 	gitness_store "github.com/harness/gitness/store"
@@ -38,17 +39,22 @@ type SignatureVerifyService struct {
 	principalStore          store.PrincipalStore
 	keyFetcher              keyfetcher.Service
 	gitSignatureResultStore store.GitSignatureResultStore
+
+	// x509Trust holds the trust roots for X.509 signatures. It's nil if X.509 verification is not configured.
+	x509Trust *keyx509.Trust
 }
 
 func NewSignatureVerifyService(
 	principalStore store.PrincipalStore,
 	keyFetcher keyfetcher.Service,
 	gitSignatureResultStore store.GitSignatureResultStore,
+	x509Trust *keyx509.Trust,
 ) SignatureVerifyService {
 	return SignatureVerifyService{
 		principalStore:          principalStore,
 		keyFetcher:              keyFetcher,
 		gitSignatureResultStore: gitSignatureResultStore,
+		x509Trust:               x509Trust,
 	}
 }
 
@@ -252,6 +258,8 @@ func verifyGitObjectSignature[T signedObject](
 		v = &keyssh.Verify{}
 	case keypgp.SignatureType:
 		v = &keypgp.Verify{}
+	case keyx509.SignatureType:
+		v = keyx509.NewVerify(s.x509Trust)
 	default:
 		return &sigVerUnsupported, nil // We mark unsupported signature types as unsupported.
 	}
@@ -348,7 +356,7 @@ func verifyGitObjectSignature[T signedObject](
 }
 
 // verifier is interface to verify a git object signature.
-// It's implemented by keypgp.Verify and keyssh.Verify.
+// It's implemented by keypgp.Verify, keyssh.Verify and keyx509.Verify.
 type verifier interface {
 	// Parse parses the provided signature and extracts info about the signing key (ID/Fingerprint).
 	Parse(
@@ -15,8 +15,12 @@
 package publickey
 
 import (
+	"fmt"
+
 	"github.com/harness/gitness/app/services/keyfetcher"
+	"github.com/harness/gitness/app/services/publickey/keyx509"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -34,12 +38,24 @@ func ProvideSSHAuthService(
 }
 
 func ProvideSignatureVerifyService(
+	config *types.Config,
 	principalStore store.PrincipalStore,
 	keyFetcher keyfetcher.Service,
 	gitSignatureResultStore store.GitSignatureResultStore,
-) SignatureVerifyService {
+) (SignatureVerifyService, error) {
+	x509Trust, err := keyx509.LoadTrust(keyx509.Config{
+		CABundlePath:        config.SignatureVerification.X509.CABundlePath,
+		FulcioRootsPath:     config.SignatureVerification.X509.FulcioRootsPath,
+		RekorPublicKeysPath: config.SignatureVerification.X509.RekorPublicKeysPath,
+		FulcioIssuers:       config.SignatureVerification.X509.FulcioIssuers,
+	})
+	if err != nil {
+		return SignatureVerifyService{}, fmt.Errorf("failed to load X.509 signature trust roots: %w", err)
+	}
+
 	return NewSignatureVerifyService(
 		principalStore,
 		keyFetcher,
-		gitSignatureResultStore)
+		gitSignatureResultStore,
+		x509Trust), nil
 }
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeDisableInput struct {
+	AutoMergeStore    store.AutoMergeStore
+	PullReqStore      store.PullReqStore
+	ActivityStore     store.PullReqActivityStore
+	PullReqEvReporter *pullreqevents.Reporter
+	SSEStreamer       sse.Streamer
+}
+
+// DisableAutoMerge disables the auto-merge of the pull request. It records the activity and reports the event.
+// It returns false if the auto-merge hasn't been enabled for the pull request.
+func DisableAutoMerge(
+	ctx context.Context,
+	in AutoMergeDisableInput,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+	reason enum.AutoMergeDisableReason,
+) (bool, error) {
+	_, err := in.AutoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	if err := in.AutoMergeStore.Delete(ctx, pr.ID); err != nil {
+		return false, fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	pr, err = in.PullReqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return true, fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMergeDisable{Reason: reason}
+	if _, err := in.ActivityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for disabled auto-merge")
+	}
+
+	in.PullReqEvReporter.AutoMergeDisabled(ctx, &pullreqevents.AutoMergeDisabledPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		Reason: reason,
+	})
+
+	in.SSEStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return true, nil
+}
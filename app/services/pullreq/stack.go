@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxStackDepth limits the number of parents returned for a stacked pull request.
+	maxStackDepth = 50
+
+	// maxStackChildren limits the number of pull requests stacked directly on top of a pull request.
+	maxStackChildren = 100
+)
+
+// isStackable returns true if the pull request can be a part of a stack.
+// Only pull requests whose source and target branches are in the same repository can be stacked.
+func isStackable(pr *types.PullReq) bool {
+	return pr.SourceRepoID != nil && *pr.SourceRepoID == pr.TargetRepoID
+}
+
+// FindStackParent returns the open pull request whose source branch is the target branch of the pull request.
+// It returns nil if there's no such pull request, or if there are several of them because then the stack is ambiguous.
+func FindStackParent(
+	ctx context.Context,
+	pullreqStore store.PullReqStore,
+	pr *types.PullReq,
+) (*types.PullReq, error) {
+	if !isStackable(pr) {
+		return nil, nil
+	}
+
+	parents, err := pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         2,
+		SourceRepoID: pr.TargetRepoID,
+		SourceBranch: pr.TargetBranch,
+		TargetRepoID: pr.TargetRepoID,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parent pull requests: %w", err)
+	}
+
+	if len(parents) != 1 {
+		return nil, nil
+	}
+
+	return parents[0], nil
+}
+
+// ListStackParents returns the chain of the open pull requests the pull request is stacked on,
+// starting from the direct parent of the pull request.
+func ListStackParents(
+	ctx context.Context,
+	pullreqStore store.PullReqStore,
+	pr *types.PullReq,
+) ([]*types.PullReq, error) {
+	visited := map[int64]struct{}{pr.ID: {}}
+	parents := make([]*types.PullReq, 0)
+
+	for len(parents) < maxStackDepth {
+		parent, err := FindStackParent(ctx, pullreqStore, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent == nil {
+			break
+		}
+
+		if _, ok := visited[parent.ID]; ok {
+			// The pull requests form a cycle (e.g. A->B and B->A).
+			break
+		}
+
+		visited[parent.ID] = struct{}{}
+		parents = append(parents, parent)
+		pr = parent
+	}
+
+	return parents, nil
+}
+
+// ListStackChildren returns the open pull requests that target the source branch of the pull request.
+// The pull request itself doesn't have to be open, which allows finding the children of a merged pull request.
+func ListStackChildren(
+	ctx context.Context,
+	pullreqStore store.PullReqStore,
+	pr *types.PullReq,
+) ([]*types.PullReq, error) {
+	if !isStackable(pr) {
+		return []*types.PullReq{}, nil
+	}
+
+	children, err := pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         maxStackChildren,
+		SourceRepoID: pr.TargetRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		TargetBranch: pr.SourceBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child pull requests: %w", err)
+	}
+
+	return children, nil
+}
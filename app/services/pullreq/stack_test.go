@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStack(t *testing.T) {
+	repoID := int64(1)
+	forkRepoID := int64(2)
+
+	newPR := func(id int64, source, target string, state enum.PullReqState) *types.PullReq {
+		return &types.PullReq{
+			ID: id, Number: id, State: state,
+			SourceRepoID: &repoID, TargetRepoID: repoID,
+			SourceBranch: source, TargetBranch: target,
+		}
+	}
+
+	// main <- a (1) <- b (2) <- c (3), d (4) is merged, e (5) and f (6) are both on top of c.
+	prA := newPR(1, "a", "main", enum.PullReqStateOpen)
+	prB := newPR(2, "b", "a", enum.PullReqStateOpen)
+	prC := newPR(3, "c", "b", enum.PullReqStateOpen)
+	prD := newPR(4, "d", "c", enum.PullReqStateMerged)
+	prE := newPR(5, "e", "c", enum.PullReqStateOpen)
+	prF := newPR(6, "f", "c", enum.PullReqStateOpen)
+
+	// x (7) and y (8) target each other's branches.
+	prX := newPR(7, "x", "y", enum.PullReqStateOpen)
+	prY := newPR(8, "y", "x", enum.PullReqStateOpen)
+
+	// g (9) and h (10) have the same source branch, so the stack of i (11) is ambiguous.
+	prG := newPR(9, "g", "main", enum.PullReqStateOpen)
+	prH := newPR(10, "g", "b", enum.PullReqStateOpen)
+	prI := newPR(11, "i", "g", enum.PullReqStateOpen)
+
+	fork := newPR(12, "b", "a", enum.PullReqStateOpen)
+	fork.SourceRepoID = &forkRepoID
+
+	pullreqStore := &stackPullReqStore{prs: []*types.PullReq{prA, prB, prC, prD, prE, prF, prX, prY, prG, prH, prI}}
+	ctx := context.Background()
+
+	parentTests := []struct {
+		name string
+		pr   *types.PullReq
+		exp  []int64
+	}{
+		{name: "not-stacked", pr: prA, exp: []int64{}},
+		{name: "chain", pr: prE, exp: []int64{3, 2, 1}},
+		{name: "cycle", pr: prX, exp: []int64{8}},
+		{name: "ambiguous", pr: prI, exp: []int64{}},
+		{name: "fork", pr: fork, exp: []int64{}},
+	}
+
+	for _, test := range parentTests {
+		t.Run("parents-"+test.name, func(t *testing.T) {
+			parents, err := ListStackParents(ctx, pullreqStore, test.pr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ids := pullReqIDs(parents); !slices.Equal(ids, test.exp) {
+				t.Errorf("parents mismatch: want=%v got=%v", test.exp, ids)
+			}
+		})
+	}
+
+	childrenTests := []struct {
+		name string
+		pr   *types.PullReq
+		exp  []int64
+	}{
+		{name: "single", pr: prA, exp: []int64{2}},
+		{name: "ambiguous-source-branch", pr: prB, exp: []int64{3, 10}},
+		{name: "open-only", pr: prC, exp: []int64{5, 6}},
+		{name: "merged-parent", pr: prD, exp: []int64{}},
+		{name: "fork", pr: fork, exp: []int64{}},
+	}
+
+	for _, test := range childrenTests {
+		t.Run("children-"+test.name, func(t *testing.T) {
+			children, err := ListStackChildren(ctx, pullreqStore, test.pr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ids := pullReqIDs(children); !slices.Equal(ids, test.exp) {
+				t.Errorf("children mismatch: want=%v got=%v", test.exp, ids)
+			}
+		})
+	}
+}
+
+func pullReqIDs(prs []*types.PullReq) []int64 {
+	ids := make([]int64, len(prs))
+	for i, pr := range prs {
+		ids[i] = pr.ID
+	}
+	return ids
+}
+
+// stackPullReqStore implements the pull request listing used by the stack functions.
+type stackPullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *stackPullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	list := make([]*types.PullReq, 0)
+	for _, pr := range s.prs {
+		switch {
+		case opts.SourceRepoID != 0 && (pr.SourceRepoID == nil || *pr.SourceRepoID != opts.SourceRepoID),
+			opts.TargetRepoID != 0 && pr.TargetRepoID != opts.TargetRepoID,
+			opts.SourceBranch != "" && pr.SourceBranch != opts.SourceBranch,
+			opts.TargetBranch != "" && pr.TargetBranch != opts.TargetBranch,
+			len(opts.States) > 0 && !slices.Contains(opts.States, pr.State):
+			continue
+		}
+
+		list = append(list, pr)
+		if opts.Size > 0 && len(list) == opts.Size {
+			break
+		}
+	}
+	return list, nil
+}
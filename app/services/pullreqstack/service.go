@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqstack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	controllerrepo "github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service maintains stacked pull requests. A pull request is stacked on top of another one
+// if it targets the other one's source branch. When the parent pull request gets merged,
+// the child pull requests are retargeted to the parent's target branch. If the parent has been merged
+// with the squash or rebase method, the source branches of the children are rebased onto the target branch.
+// Both operations are performed on behalf of the user that merged the parent pull request.
+type Service struct {
+	pullreqCtrl    pullReqController
+	repoCtrl       repoController
+	git            git.Interface
+	pullreqStore   store.PullReqStore
+	activityStore  store.PullReqActivityStore
+	principalStore store.PrincipalStore
+	repoFinder     refcache.RepoFinder
+	sseStreamer    sse.Streamer
+}
+
+// pullReqController is the part of the pull request controller used to retarget the stacked pull requests.
+type pullReqController interface {
+	ChangeTargetBranch(
+		ctx context.Context,
+		session *auth.Session,
+		repoRef string,
+		pullreqNum int64,
+		in *controllerpullreq.ChangeTargetBranchInput,
+	) (*types.PullReq, error)
+}
+
+// repoController is the part of the repository controller used to rebase the stacked pull requests.
+type repoController interface {
+	Rebase(
+		ctx context.Context,
+		session *auth.Session,
+		repoRef string,
+		in *controllerrepo.RebaseInput,
+	) (*types.RebaseResponse, *types.MergeViolations, error)
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqCtrl *controllerpullreq.Controller,
+	repoCtrl *controllerrepo.Controller,
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	repoFinder refcache.RepoFinder,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	s := &Service{
+		pullreqCtrl:    pullreqCtrl,
+		repoCtrl:       repoCtrl,
+		git:            git,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		principalStore: principalStore,
+		repoFinder:     repoFinder,
+		sseStreamer:    sseStreamer,
+	}
+
+	const groupPullReq = "gitness:pullreqstack:pullreq"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReq, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 2 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(s.handleMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// handleMerged retargets and, if needed, rebases the pull requests stacked on top of the merged pull request.
+func (s *Service) handleMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	parent, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	children, err := pullreq.ListStackChildren(ctx, s.pullreqStore, parent)
+	if err != nil {
+		return err
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, parent.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that merged the pull request: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	// With the squash and rebase merge methods the commits of the parent don't end up in the target branch,
+	// so the children would contain them as well if their source branches weren't rebased.
+	rebase := event.Payload.MergeMethod == enum.MergeMethodSquash ||
+		event.Payload.MergeMethod == enum.MergeMethodRebase
+
+	for _, child := range children {
+		_, err = s.pullreqCtrl.ChangeTargetBranch(ctx, session, repo.Path, child.Number,
+			&controllerpullreq.ChangeTargetBranchInput{BranchName: parent.TargetBranch})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", child.ID).
+				Int64("parent_pullreq_id", parent.ID).
+				Msg("failed to retarget stacked pull request")
+			continue
+		}
+
+		if !rebase {
+			continue
+		}
+
+		if err := s.rebase(ctx, session, repo, parent, child, event.Payload.SourceSHA); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", child.ID).
+				Int64("parent_pullreq_id", parent.ID).
+				Msg("failed to rebase stacked pull request")
+		}
+	}
+
+	return nil
+}
+
+// rebase rebases the commits of the child pull request that aren't in the parent pull request
+// onto the target branch. The outcome is recorded as a pull request activity.
+func (s *Service) rebase(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	parent *types.PullReq,
+	child *types.PullReq,
+	parentSourceSHA string,
+) error {
+	headSHA, err := sha.New(child.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source SHA of the pull request: %w", err)
+	}
+
+	// The child's branch might not contain the latest commit of the parent's branch,
+	// so the upstream is the last commit the two branches have in common.
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref1:       child.SourceSHA,
+		Ref2:       parentSourceSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merge base with the parent pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadStackRebase{
+		ParentNumber: parent.Number,
+		TargetBranch: parent.TargetBranch,
+		OldSHA:       child.SourceSHA,
+	}
+
+	out, violations, err := s.repoCtrl.Rebase(ctx, session, repo.Path, &controllerrepo.RebaseInput{
+		BaseBranch:        parent.TargetBranch,
+		HeadBranch:        child.SourceBranch,
+		HeadCommitSHA:     headSHA,
+		UpstreamCommitSHA: mergeBase.MergeBaseSHA,
+	})
+
+	switch {
+	case err != nil:
+		log.Ctx(ctx).Warn().Err(err).Int64("pullreq_id", child.ID).Msg("rebase of stacked pull request failed")
+		payload.Message = "Failed to rebase the source branch."
+	case violations != nil:
+		payload.ConflictFiles = violations.ConflictFiles
+		payload.Message = violations.Message
+	case out.AlreadyAncestor:
+		return nil
+	default:
+		payload.NewSHA = out.NewHeadBranchSHA.String()
+	}
+
+	return s.writeActivity(ctx, repo, child.ID, session.Principal.ID, payload)
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pullreqID int64,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadStackRebase,
+) error {
+	// The pull request has been updated by the retargeting, so it needs to be reloaded.
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	if _, err := s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); err != nil {
+		return fmt.Errorf("failed to write pull request activity for stack rebase: %w", err)
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return nil
+}
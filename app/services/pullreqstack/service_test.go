@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqstack
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	controllerrepo "github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache/refcachetest"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRepoID    = int64(1)
+	testRepoPath  = "space/repo"
+	testMergerID  = int64(7)
+	testParentSHA = "1111111111111111111111111111111111111111"
+	testChildSHA  = "2222222222222222222222222222222222222222"
+	testMergeBase = "3333333333333333333333333333333333333333"
+	testRebaseSHA = "4444444444444444444444444444444444444444"
+)
+
+func TestHandleMerged(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      enum.MergeMethod
+		retargetErr error
+		rebaseOut   *types.RebaseResponse
+		violations  *types.MergeViolations
+		rebaseErr   error
+		expRebase   []repoRebaseCall
+		expActivity []*types.PullRequestActivityPayloadStackRebase
+	}{
+		{
+			name:   "merge-retarget-only",
+			method: enum.MergeMethodMerge,
+		},
+		{
+			name:        "retarget-failed",
+			method:      enum.MergeMethodSquash,
+			retargetErr: errors.New("forbidden"),
+		},
+		{
+			name:      "squash-rebase",
+			method:    enum.MergeMethodSquash,
+			rebaseOut: &types.RebaseResponse{NewHeadBranchSHA: sha.Must(testRebaseSHA)},
+			expRebase: []repoRebaseCall{{repoRef: testRepoPath, in: controllerrepo.RebaseInput{
+				BaseBranch:        "main",
+				HeadBranch:        "feature-2",
+				HeadCommitSHA:     sha.Must(testChildSHA),
+				UpstreamCommitSHA: sha.Must(testMergeBase),
+			}}},
+			expActivity: []*types.PullRequestActivityPayloadStackRebase{{
+				ParentNumber: 10,
+				TargetBranch: "main",
+				OldSHA:       testChildSHA,
+				NewSHA:       testRebaseSHA,
+			}},
+		},
+		{
+			name:       "rebase-conflict",
+			method:     enum.MergeMethodRebase,
+			violations: &types.MergeViolations{ConflictFiles: []string{"a.txt"}, Message: "conflict"},
+			expRebase: []repoRebaseCall{{repoRef: testRepoPath, in: controllerrepo.RebaseInput{
+				BaseBranch:        "main",
+				HeadBranch:        "feature-2",
+				HeadCommitSHA:     sha.Must(testChildSHA),
+				UpstreamCommitSHA: sha.Must(testMergeBase),
+			}}},
+			expActivity: []*types.PullRequestActivityPayloadStackRebase{{
+				ParentNumber:  10,
+				TargetBranch:  "main",
+				OldSHA:        testChildSHA,
+				ConflictFiles: []string{"a.txt"},
+				Message:       "conflict",
+			}},
+		},
+		{
+			name:      "already-up-to-date",
+			method:    enum.MergeMethodSquash,
+			rebaseOut: &types.RebaseResponse{AlreadyAncestor: true},
+			expRebase: []repoRebaseCall{{repoRef: testRepoPath, in: controllerrepo.RebaseInput{
+				BaseBranch:        "main",
+				HeadBranch:        "feature-2",
+				HeadCommitSHA:     sha.Must(testChildSHA),
+				UpstreamCommitSHA: sha.Must(testMergeBase),
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repoID := testRepoID
+			parent := &types.PullReq{
+				ID: 100, Number: 10, State: enum.PullReqStateMerged,
+				SourceRepoID: &repoID, TargetRepoID: repoID,
+				SourceBranch: "feature-1", TargetBranch: "main", SourceSHA: testParentSHA,
+			}
+			child := &types.PullReq{
+				ID: 200, Number: 20, State: enum.PullReqStateOpen,
+				SourceRepoID: &repoID, TargetRepoID: repoID,
+				SourceBranch: "feature-2", TargetBranch: "feature-1", SourceSHA: testChildSHA,
+			}
+			unrelated := &types.PullReq{
+				ID: 300, Number: 30, State: enum.PullReqStateOpen,
+				SourceRepoID: &repoID, TargetRepoID: repoID,
+				SourceBranch: "feature-3", TargetBranch: "main",
+			}
+
+			pullreqStore := &fakePullReqStore{prs: []*types.PullReq{parent, child, unrelated}}
+			activityStore := &fakeActivityStore{}
+			pullreqCtrl := &fakePullReqController{err: test.retargetErr}
+			repoCtrl := &fakeRepoController{out: test.rebaseOut, violations: test.violations, err: test.rebaseErr}
+			gitService := &fakeGit{mergeBase: testMergeBase}
+
+			s := &Service{
+				pullreqCtrl:    pullreqCtrl,
+				repoCtrl:       repoCtrl,
+				git:            gitService,
+				pullreqStore:   pullreqStore,
+				activityStore:  activityStore,
+				principalStore: &fakePrincipalStore{},
+				repoFinder:     refcachetest.NewRepoFinder(&types.Repository{ID: repoID, Path: testRepoPath}),
+				sseStreamer:    &fakeStreamer{},
+			}
+
+			err := s.handleMerged(context.Background(), &events.Event[*pullreqevents.MergedPayload]{
+				Payload: &pullreqevents.MergedPayload{
+					Base:        pullreqevents.Base{PullReqID: parent.ID, PrincipalID: testMergerID},
+					MergeMethod: test.method,
+					SourceSHA:   testParentSHA,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// only the pull request stacked on top of the merged one is retargeted, on behalf of the merger.
+			expRetarget := []retargetCall{{principalID: testMergerID, repoRef: testRepoPath, number: 20, branch: "main"}}
+			if !reflect.DeepEqual(pullreqCtrl.calls, expRetarget) {
+				t.Errorf("retarget mismatch: want=%+v got=%+v", expRetarget, pullreqCtrl.calls)
+			}
+
+			if !reflect.DeepEqual(repoCtrl.calls, test.expRebase) {
+				t.Errorf("rebase mismatch: want=%+v got=%+v", test.expRebase, repoCtrl.calls)
+			}
+
+			if len(test.expRebase) > 0 {
+				expMergeBase := git.MergeBaseParams{
+					ReadParams: git.CreateReadParams(&types.RepositoryCore{ID: repoID, Path: testRepoPath}),
+					Ref1:       testChildSHA,
+					Ref2:       testParentSHA,
+				}
+				if !reflect.DeepEqual(gitService.calls, []git.MergeBaseParams{expMergeBase}) {
+					t.Errorf("merge base mismatch: want=%+v got=%+v", expMergeBase, gitService.calls)
+				}
+			}
+
+			if !reflect.DeepEqual(activityStore.payloads, test.expActivity) {
+				t.Errorf("activity mismatch: want=%+v got=%+v", test.expActivity, activityStore.payloads)
+			}
+		})
+	}
+}
+
+type retargetCall struct {
+	principalID int64
+	repoRef     string
+	number      int64
+	branch      string
+}
+
+type fakePullReqController struct {
+	calls []retargetCall
+	err   error
+}
+
+func (c *fakePullReqController) ChangeTargetBranch(
+	_ context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *controllerpullreq.ChangeTargetBranchInput,
+) (*types.PullReq, error) {
+	c.calls = append(c.calls, retargetCall{
+		principalID: session.Principal.ID,
+		repoRef:     repoRef,
+		number:      pullreqNum,
+		branch:      in.BranchName,
+	})
+	return nil, c.err
+}
+
+type repoRebaseCall struct {
+	repoRef string
+	in      controllerrepo.RebaseInput
+}
+
+type fakeRepoController struct {
+	calls      []repoRebaseCall
+	out        *types.RebaseResponse
+	violations *types.MergeViolations
+	err        error
+}
+
+func (c *fakeRepoController) Rebase(
+	_ context.Context,
+	_ *auth.Session,
+	repoRef string,
+	in *controllerrepo.RebaseInput,
+) (*types.RebaseResponse, *types.MergeViolations, error) {
+	c.calls = append(c.calls, repoRebaseCall{repoRef: repoRef, in: *in})
+	return c.out, c.violations, c.err
+}
+
+type fakeGit struct {
+	git.Interface
+	mergeBase string
+	calls     []git.MergeBaseParams
+}
+
+func (g *fakeGit) MergeBase(_ context.Context, params git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	g.calls = append(g.calls, params)
+	return git.MergeBaseOutput{MergeBaseSHA: sha.Must(g.mergeBase)}, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	for _, pr := range s.prs {
+		if pr.ID == id {
+			return pr, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (s *fakePullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	var list []*types.PullReq
+	for _, pr := range s.prs {
+		if opts.TargetBranch != "" && pr.TargetBranch != opts.TargetBranch ||
+			len(opts.States) > 0 && pr.State != opts.States[0] {
+			continue
+		}
+		list = append(list, pr)
+	}
+	return list, nil
+}
+
+func (s *fakePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	payloads []*types.PullRequestActivityPayloadStackRebase
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload.(*types.PullRequestActivityPayloadStackRebase)) //nolint:errcheck
+	return &types.PullReqActivity{}, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id}, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (s *fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqstack
+
+import (
+	"context"
+
+	controllerpullreq "github.com/harness/gitness/app/api/controller/pullreq"
+	controllerrepo "github.com/harness/gitness/app/api/controller/repo"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqCtrl *controllerpullreq.Controller,
+	repoCtrl *controllerrepo.Controller,
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	repoFinder refcache.RepoFinder,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		pullreqEvReaderFactory,
+		pullreqCtrl,
+		repoCtrl,
+		git,
+		pullreqStore,
+		activityStore,
+		principalStore,
+		repoFinder,
+		sseStreamer,
+	)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package refcachetest provides reference caches for tests.
+package refcachetest
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// NewRepoFinder returns a RepoFinder that finds the provided repositories by ID.
+// Only numeric repository references are supported, there are no space caches.
+func NewRepoFinder(repos ...*types.Repository) refcache.RepoFinder {
+	return refcache.NewRepoFinder(nil, nil, repoIDCache(repos), nil, cache.Evictor[*types.RepositoryCore]{})
+}
+
+type repoIDCache []*types.Repository
+
+var _ store.RepoIDCache = repoIDCache(nil)
+
+func (c repoIDCache) Get(_ context.Context, id int64) (*types.RepositoryCore, error) {
+	for _, repo := range c {
+		if repo.ID == id {
+			return repo.Core(), nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (c repoIDCache) Stats() (int64, int64) {
+	return 0, 0
+}
+
+func (c repoIDCache) Evict(context.Context, int64) {}
@@ -0,0 +1,301 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/gorhill/cronexpr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCron        = "pipeline-cron-triggers"
+	jobCronCron        = "* * * * *" // Every minute.
+	jobMaxDurationCron = 10 * time.Minute
+
+	// cronBatchSize is the max number of due cron triggers processed in a single job run.
+	cronBatchSize = 100
+
+	// cronLockExpiry is the expiry of the lock held while a cron trigger is being fired.
+	cronLockExpiry = time.Minute
+
+	// cronOverdueTolerance is how late a scheduled run can be started
+	// and not be considered missed.
+	cronOverdueTolerance = 5 * time.Minute
+
+	// cronMaxCatchUpRuns is the max number of executions started for missed runs
+	// of a trigger with the enum.TriggerCatchUpAll catch-up policy.
+	cronMaxCatchUpRuns = 10
+
+	// cronMaxScheduleScan limits iterations over a schedule when counting missed runs.
+	cronMaxScheduleScan = 100_000
+)
+
+// CronSchedule is a cron expression evaluated in a specific time zone.
+type CronSchedule struct {
+	expr *cronexpr.Expression
+	loc  *time.Location
+}
+
+// ParseCronSchedule parses the cron expression and loads the time zone.
+// Empty time zone is treated as UTC.
+func ParseCronSchedule(cron, timezone string) (CronSchedule, error) {
+	expr, err := cronexpr.Parse(cron)
+	if err != nil {
+		return CronSchedule{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("invalid time zone: %w", err)
+		}
+	}
+
+	return CronSchedule{expr: expr, loc: loc}, nil
+}
+
+// Next returns the first scheduled time after the provided time.
+// It returns zero time if there are no more scheduled runs.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	return s.expr.Next(t.In(s.loc))
+}
+
+// NextRun returns the first scheduled time after the provided time in unix milliseconds,
+// or zero if there are no more scheduled runs.
+func (s CronSchedule) NextRun(t time.Time) int64 {
+	next := s.Next(t)
+	if next.IsZero() {
+		return 0
+	}
+
+	return next.UnixMilli()
+}
+
+// cronRunsDue returns the number of executions that should be started for a cron trigger
+// which has a run scheduled at nextRun, and the time of the following scheduled run.
+// Scheduled runs between nextRun and now are handled according to the catch-up policy.
+func cronRunsDue(
+	schedule CronSchedule,
+	nextRun time.Time,
+	now time.Time,
+	catchUp enum.TriggerCatchUp,
+) (int, time.Time) {
+	var (
+		count  int
+		latest time.Time
+	)
+
+	for t := nextRun; !t.IsZero() && !t.After(now) && count < cronMaxScheduleScan; t = schedule.Next(t) {
+		latest = t
+		count++
+	}
+
+	following := schedule.Next(now)
+
+	switch {
+	case count == 0:
+		return 0, following
+	case catchUp == enum.TriggerCatchUpSkip:
+		if count >= cronMaxScheduleScan || now.Sub(latest) > cronOverdueTolerance {
+			return 0, following
+		}
+		return 1, following
+	case catchUp == enum.TriggerCatchUpAll:
+		return min(count, cronMaxCatchUpRuns), following
+	default:
+		return 1, following
+	}
+}
+
+type cronJob struct {
+	service *Service
+}
+
+func (j *cronJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	return "", j.service.processDueCronTriggers(ctx)
+}
+
+// Register schedules the recurring job that fires all due cron triggers.
+// The job scheduler guarantees that only one instance runs the job at a time.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeCron, jobTypeCron, jobCronCron, jobMaxDurationCron)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for cron triggers: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) processDueCronTriggers(ctx context.Context) error {
+	now := time.Now()
+
+	triggers, err := s.triggerStore.ListCronDue(ctx, now.UnixMilli(), cronBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due cron triggers: %w", err)
+	}
+
+	for _, t := range triggers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := s.fireCronTrigger(ctx, t, now)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("trigger.id", t.ID).
+				Int64("pipeline.id", t.PipelineID).
+				Msg("failed to fire cron trigger")
+		}
+	}
+
+	return nil
+}
+
+// fireCronTrigger advances the schedule of the cron trigger and starts its executions.
+// The schedule is updated (with optimistic locking) before any execution is started,
+// so that a scheduled run is never started more than once, even across replicas.
+func (s *Service) fireCronTrigger(ctx context.Context, t *types.Trigger, now time.Time) error {
+	unlock, err := s.locker.LockCronTrigger(ctx, t.RepoID, t.ID, cronLockExpiry)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Reload the trigger: another instance might have fired it while we were waiting for the lock.
+	t, err = s.triggerStore.FindByIdentifier(ctx, t.PipelineID, t.Identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find trigger: %w", err)
+	}
+
+	if t.Disabled || !t.IsCron() || t.NextRun == 0 || t.NextRun > now.UnixMilli() {
+		return nil
+	}
+
+	runs := 0
+	nextRun := int64(0)
+
+	schedule, err := ParseCronSchedule(t.Cron, t.Timezone)
+	if err != nil {
+		// Should not happen, the schedule is validated by the API. Stop scheduling the trigger.
+		log.Ctx(ctx).Warn().Err(err).Int64("trigger.id", t.ID).Msg("invalid cron trigger schedule")
+	} else {
+		var following time.Time
+		runs, following = cronRunsDue(schedule, time.UnixMilli(t.NextRun), now, t.CatchUp)
+		if !following.IsZero() {
+			nextRun = following.UnixMilli()
+		}
+	}
+
+	trigger := *t
+	trigger.LastRun = now.UnixMilli()
+	trigger.NextRun = nextRun
+
+	err = s.triggerStore.Update(ctx, &trigger)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// The trigger has been modified in the meantime; it will be reevaluated during the next run.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cron trigger schedule: %w", err)
+	}
+
+	if runs == 0 {
+		return nil
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, trigger.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	// Don't fire triggers for disabled pipelines
+	if pipeline.Disabled {
+		return nil
+	}
+
+	hook, err := s.cronHook(ctx, pipeline, &trigger)
+	if err != nil {
+		return err
+	}
+
+	for range runs {
+		_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+		if err != nil {
+			return fmt.Errorf("failed to trigger pipeline execution: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) cronHook(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	t *types.Trigger,
+) (*triggerer.Hook, error) {
+	repo, err := s.repoFinder.FindByID(ctx, pipeline.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	// Use the branch of the trigger. If it's empty, use the default branch specified in the pipeline.
+	// It that is also empty, use the repo default branch.
+	branch := t.Branch
+	if branch == "" {
+		branch = pipeline.DefaultBranch
+		if branch == "" {
+			branch = repo.DefaultBranch
+		}
+	}
+
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	return &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Cron:        t.Identifier,
+		Ref:         ref,
+		Source:      branch,
+		Target:      branch,
+		Before:      commit.SHA.String(),
+		After:       commit.SHA.String(),
+		Title:       commit.Title,
+		Message:     commit.Message,
+		Timestamp:   commit.Author.When.UnixMilli(),
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Params:      map[string]string{},
+	}, nil
+}
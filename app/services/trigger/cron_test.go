@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCronRunsDue(t *testing.T) {
+	hourly, err := ParseCronSchedule("0 * * * *", "")
+	if err != nil {
+		t.Fatalf("failed to parse schedule: %v", err)
+	}
+
+	everyMinute, err := ParseCronSchedule("* * * * *", "")
+	if err != nil {
+		t.Fatalf("failed to parse schedule: %v", err)
+	}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		schedule      CronSchedule
+		nextRun       time.Time
+		now           time.Time
+		catchUp       enum.TriggerCatchUp
+		wantRuns      int
+		wantFollowing time.Time
+	}{
+		{
+			name:          "not-due",
+			schedule:      hourly,
+			nextRun:       at(11, 0),
+			now:           at(10, 30),
+			catchUp:       enum.TriggerCatchUpOnce,
+			wantRuns:      0,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "on-time",
+			schedule:      hourly,
+			nextRun:       at(10, 0),
+			now:           at(10, 1),
+			catchUp:       enum.TriggerCatchUpSkip,
+			wantRuns:      1,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "missed-skip",
+			schedule:      hourly,
+			nextRun:       at(7, 0),
+			now:           at(10, 40),
+			catchUp:       enum.TriggerCatchUpSkip,
+			wantRuns:      0,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "missed-skip-latest-on-time",
+			schedule:      hourly,
+			nextRun:       at(7, 0),
+			now:           at(10, 2),
+			catchUp:       enum.TriggerCatchUpSkip,
+			wantRuns:      1,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "missed-once",
+			schedule:      hourly,
+			nextRun:       at(7, 0),
+			now:           at(10, 40),
+			catchUp:       enum.TriggerCatchUpOnce,
+			wantRuns:      1,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "missed-all",
+			schedule:      hourly,
+			nextRun:       at(7, 0),
+			now:           at(10, 40),
+			catchUp:       enum.TriggerCatchUpAll,
+			wantRuns:      4,
+			wantFollowing: at(11, 0),
+		},
+		{
+			name:          "missed-all-limited",
+			schedule:      everyMinute,
+			nextRun:       at(7, 0),
+			now:           at(10, 40),
+			catchUp:       enum.TriggerCatchUpAll,
+			wantRuns:      cronMaxCatchUpRuns,
+			wantFollowing: at(10, 41),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs, following := cronRunsDue(test.schedule, test.nextRun, test.now, test.catchUp)
+			if runs != test.wantRuns {
+				t.Errorf("runs: want=%d got=%d", test.wantRuns, runs)
+			}
+			if !following.Equal(test.wantFollowing) {
+				t.Errorf("following: want=%s got=%s", test.wantFollowing, following)
+			}
+		})
+	}
+}
+
+func TestParseCronScheduleTimezone(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 2 * * *", "America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	// 2024-05-01 06:00 UTC is 02:00 in New York (EDT, UTC-4).
+	after := time.Date(2024, 5, 1, 5, 0, 0, 0, time.UTC)
+	want := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	if got := schedule.Next(after); !got.Equal(want) {
+		t.Errorf("want=%s got=%s", want, got)
+	}
+
+	if _, err := ParseCronSchedule("0 2 * * *", "Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for invalid time zone")
+	}
+
+	if _, err := ParseCronSchedule("not a cron", ""); err == nil {
+		t.Error("expected error for invalid cron expression")
+	}
+}
@@ -102,6 +102,28 @@ func (s *Service) handleEventPullReqMerged(
 	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMerged, hook)
 }
 
+// handleEventPullReqMergeGroupCreated triggers the pipelines for the merge group commit created by the merge queue.
+// The merge queue waits for the required status checks of the merge group commit before merging the pull request.
+func (s *Service) handleEventPullReqMergeGroupCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeGroupCreatedPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeGroupCreated,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.GroupSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+	// the pipeline runs for the merge group commit, not for the pull request head.
+	hook.Before = event.Payload.BaseSHA
+	hook.Ref = event.Payload.Ref
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMergeGroupCreated, hook)
+}
+
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
 // by querying the database.
 func (s *Service) augmentPullReqInfo(
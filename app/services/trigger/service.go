@@ -25,9 +25,11 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -69,6 +71,8 @@ type Service struct {
 	pipelineStore store.PipelineStore
 	triggerSvc    triggerer.Triggerer
 	commitSvc     commit.Service
+	locker        *locker.Locker
+	scheduler     *job.Scheduler
 }
 
 func New(
@@ -82,6 +86,9 @@ func New(
 	commitSvc commit.Service,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided trigger service config is invalid: %w", err)
@@ -94,6 +101,12 @@ func New(
 		commitSvc:     commitSvc,
 		pipelineStore: pipelineStore,
 		triggerSvc:    triggerSvc,
+		locker:        locker,
+		scheduler:     scheduler,
+	}
+
+	if err := executor.Register(jobTypeCron, &cronJob{service: service}); err != nil {
+		return nil, fmt.Errorf("failed to register cron triggers job: %w", err)
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -134,6 +147,7 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeGroupCreated(service.handleEventPullReqMergeGroupCreated)
 
 			return nil
 		})
@@ -178,7 +192,11 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 			continue
 		}
 
-		_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+		// Each trigger has its own path filters.
+		triggerHook := *hook
+		triggerHook.Paths = t.Paths
+
+		_, err = s.triggerSvc.Trigger(ctx, pipeline, &triggerHook)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
@@ -21,9 +21,11 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -43,7 +45,10 @@ func ProvideService(
 	triggerSvc triggerer.Triggerer,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqEvFactory *events.ReaderFactory[*pullreqevents.Reader],
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
 ) (*Service, error) {
 	return New(ctx, config, triggerStore, pullReqStore, repoFinder, pipelineStore, triggerSvc,
-		commitSvc, gitReaderFactory, pullReqEvFactory)
+		commitSvc, gitReaderFactory, pullReqEvFactory, locker, scheduler, executor)
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"net"
 	"net/url"
+	"strings"
 
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
@@ -33,6 +34,16 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxDeliveryAttempts defines the max allowed number of delivery attempts of a retry policy.
+	webhookMaxDeliveryAttempts = 10
+	// webhookMaxRetryBackoff defines the max allowed initial retry backoff in seconds.
+	webhookMaxRetryBackoff = 3600
+	// webhookMaxRetryableStatusCodes defines the max allowed number of retryable status codes.
+	webhookMaxRetryableStatusCodes = 50
+	// webhookMaxFilterLength defines the max allowed length of a webhook filter expression.
+	webhookMaxFilterLength = 1024
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	webhookMaxPayloadTemplateLength = 65536
 )
 
 var ErrInternalWebhookOperationNotAllowed = errors.Forbidden("changes to internal webhooks are not allowed")
@@ -106,6 +117,73 @@ func CheckTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// CheckRetryPolicy validates the retry policy of a webhook.
+func CheckRetryPolicy(policy types.WebhookRetryPolicy) error {
+	if policy.MaxAttempts < 0 || policy.MaxAttempts > webhookMaxDeliveryAttempts {
+		return check.NewValidationErrorf("The max delivery attempts of a webhook must be between 0 and %d.",
+			webhookMaxDeliveryAttempts)
+	}
+
+	if policy.Backoff < 0 || policy.Backoff > webhookMaxRetryBackoff {
+		return check.NewValidationErrorf("The retry backoff of a webhook must be between 0 and %d seconds.",
+			webhookMaxRetryBackoff)
+	}
+
+	if policy.DisableAfterFailures < 0 {
+		return check.NewValidationError("The number of failures after which a webhook is disabled can't be negative.")
+	}
+
+	if len(policy.RetryableStatusCodes) > webhookMaxRetryableStatusCodes {
+		return check.NewValidationErrorf("A webhook can have at most %d retryable status codes.",
+			webhookMaxRetryableStatusCodes)
+	}
+
+	for _, code := range policy.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			return check.NewValidationErrorf("The retryable status code %d is invalid.", code)
+		}
+	}
+
+	if !policy.Enabled() &&
+		(policy.Backoff != 0 || policy.DisableAfterFailures != 0 || len(policy.RetryableStatusCodes) != 0) {
+		return check.NewValidationError("The max delivery attempts of a webhook retry policy must be provided.")
+	}
+
+	return nil
+}
+
+// CheckFilter validates the filter expression of a webhook.
+func CheckFilter(filter string) error {
+	if len(filter) > webhookMaxFilterLength {
+		return check.NewValidationErrorf("The filter of a webhook can be at most %d characters long.",
+			webhookMaxFilterLength)
+	}
+
+	if strings.TrimSpace(filter) == "" {
+		return nil
+	}
+
+	if _, err := compileFilter(filter); err != nil {
+		return check.NewValidationErrorf("The filter of the webhook is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// CheckPayloadTemplate validates the payload template of a webhook.
+func CheckPayloadTemplate(payloadTemplate string) error {
+	if len(payloadTemplate) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+
+	if _, err := parsePayloadTemplate(payloadTemplate); err != nil {
+		return check.NewValidationErrorf("The payload template of the webhook is invalid: %s", err)
+	}
+
+	return nil
+}
+
 // DeduplicateTriggers de-duplicates the triggers provided by the user.
 func DeduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	if len(in) == 0 {
@@ -66,7 +66,16 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := CheckTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := CheckRetryPolicy(in.RetryPolicy); err != nil {
+		return err
+	}
+	if err := CheckFilter(in.Filter); err != nil {
+		return err
+	}
+	if err := CheckPayloadTemplate(in.PayloadTemplate); err != nil { //nolint:revive
 		return err
 	}
 
@@ -121,6 +130,9 @@ func (s *Service) Create(
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		RetryPolicy:           in.RetryPolicy,
+		Filter:                in.Filter,
+		PayloadTemplate:       in.PayloadTemplate,
 	}
 
 	err = s.webhookStore.Create(ctx, hook)
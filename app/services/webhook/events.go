@@ -210,7 +210,8 @@ func (w *WebhookExecutor) TriggerForEvent(
 					result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// webhooks with a retry policy are retried by a job - no need to reprocess the event
+		if result.Execution.Result == enum.WebhookExecutionResultRetriableError && !w.retriesManaged(result.Webhook) {
 			retryRequired = true
 		}
 	}
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// IssuePayload describes the body of the issue created, closed and reopened triggers.
+type IssuePayload struct {
+	BaseSegment
+	IssueSegment
+}
+
+func (s *Service) handleEventIssueCreated(
+	ctx context.Context,
+	event *events.Event[*issueevents.CreatedPayload],
+) error {
+	return s.triggerForEventIssue(ctx, enum.WebhookTriggerIssueCreated, event.ID, event.Payload.Base)
+}
+
+func (s *Service) handleEventIssueClosed(
+	ctx context.Context,
+	event *events.Event[*issueevents.ClosedPayload],
+) error {
+	return s.triggerForEventIssue(ctx, enum.WebhookTriggerIssueClosed, event.ID, event.Payload.Base)
+}
+
+func (s *Service) handleEventIssueReopened(
+	ctx context.Context,
+	event *events.Event[*issueevents.ReopenedPayload],
+) error {
+	return s.triggerForEventIssue(ctx, enum.WebhookTriggerIssueReopened, event.ID, event.Payload.Base)
+}
+
+func (s *Service) triggerForEventIssue(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	base issueevents.Base,
+) error {
+	return s.triggerForEventWithIssue(ctx, triggerType, eventID, base.PrincipalID, base.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssuePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(ctx, issue, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
+
+// IssueUpdatedPayload describes the body of the issue updated trigger.
+type IssueUpdatedPayload struct {
+	BaseSegment
+	IssueSegment
+	IssueUpdateSegment
+}
+
+func (s *Service) handleEventIssueUpdated(
+	ctx context.Context,
+	event *events.Event[*issueevents.UpdatedPayload],
+) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssueUpdatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueUpdated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(ctx, issue, repo, s.urlProvider),
+				},
+				IssueUpdateSegment: IssueUpdateSegment{
+					TitleChanged:       event.Payload.TitleOld != event.Payload.TitleNew,
+					TitleOld:           event.Payload.TitleOld,
+					TitleNew:           event.Payload.TitleNew,
+					DescriptionChanged: event.Payload.DescriptionOld != event.Payload.DescriptionNew,
+					DescriptionOld:     event.Payload.DescriptionOld,
+					DescriptionNew:     event.Payload.DescriptionNew,
+				},
+			}, nil
+		})
+}
+
+// IssueCommentPayload describes the body of the issue comment created trigger.
+type IssueCommentPayload struct {
+	BaseSegment
+	IssueSegment
+	IssueCommentSegment
+}
+
+func (s *Service) handleEventIssueCommentCreated(
+	ctx context.Context,
+	event *events.Event[*issueevents.CommentCreatedPayload],
+) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueCommentCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			activity, err := s.issueActivityStore.Find(ctx, event.Payload.ActivityID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue activity by id %d: %w", event.Payload.ActivityID, err)
+			}
+
+			return &IssueCommentPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueCommentCreated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(ctx, issue, repo, s.urlProvider),
+				},
+				IssueCommentSegment: IssueCommentSegment{
+					CommentInfo: CommentInfo{
+						ID:       activity.ID,
+						ParentID: activity.ParentID,
+						Text:     activity.Text,
+						Created:  activity.Created,
+						Updated:  activity.Updated,
+						Kind:     activity.Kind,
+					},
+				},
+			}, nil
+		})
+}
+
+// triggerForEventWithIssue triggers all webhooks for the given repo and triggerType
+// using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
+// The method tries to find the issue and the repository of the issue and provides them to the bodyFn.
+func (s *Service) triggerForEventWithIssue(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, principalID int64, issueID int64,
+	createBodyFn func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error),
+) error {
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	issue, err := s.issueStore.Find(ctx, issueID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("issue with id '%d' doesn't exist anymore", issueID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue for id '%d': %w", issueID, err)
+	}
+
+	repo, err := s.findRepositoryForEvent(ctx, issue.RepoID)
+	if err != nil {
+		return err
+	}
+
+	body, err := createBodyFn(principal, issue, repo)
+	if err != nil {
+		return fmt.Errorf("body creation function failed: %w", err)
+	}
+
+	parents, err := s.getParentInfoRepo(ctx, repo.ID, true)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook parent info: %w", err)
+	}
+
+	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+}
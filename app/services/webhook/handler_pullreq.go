@@ -771,3 +771,85 @@ func (s *Service) handleEventPullReqTargetBranchChanged(
 			}, nil
 		})
 }
+
+// PullReqAutoMergePayload describes the body of the pullreq auto-merge enabled and disabled triggers.
+type PullReqAutoMergePayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqAutoMergeSegment
+}
+
+// handleEventPullReqAutoMergeEnabled handles auto-merge enabled events for pull requests
+// and triggers pullreq auto-merge enabled webhooks for the target repo.
+func (s *Service) handleEventPullReqAutoMergeEnabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.triggerForEventPullReqAutoMerge(ctx, enum.WebhookTriggerPullReqAutoMergeEnabled,
+		event.ID, event.Payload.Base, PullReqAutoMergeSegment{
+			MergeMethod:        event.Payload.MergeMethod,
+			DeleteSourceBranch: event.Payload.DeleteSourceBranch,
+		})
+}
+
+// handleEventPullReqAutoMergeDisabled handles auto-merge disabled events for pull requests
+// and triggers pullreq auto-merge disabled webhooks for the target repo.
+func (s *Service) handleEventPullReqAutoMergeDisabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeDisabledPayload],
+) error {
+	return s.triggerForEventPullReqAutoMerge(ctx, enum.WebhookTriggerPullReqAutoMergeDisabled,
+		event.ID, event.Payload.Base, PullReqAutoMergeSegment{
+			DisableReason: event.Payload.Reason,
+		})
+}
+
+func (s *Service) triggerForEventPullReqAutoMerge(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	base pullreqevents.Base,
+	segment PullReqAutoMergeSegment,
+) error {
+	return s.triggerForEventWithPullReq(
+		ctx,
+		trigger,
+		eventID,
+		base.PrincipalID,
+		base.PullReqID,
+		func(
+			principal *types.Principal,
+			pr *types.PullReq,
+			targetRepo,
+			sourceRepo *types.Repository,
+		) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(ctx, targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(ctx, sourceRepo, s.urlProvider)
+
+			return &PullReqAutoMergePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqAutoMergeSegment: segment,
+			}, nil
+		})
+}
@@ -0,0 +1,323 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRetry        = "webhook-retry"
+	jobMaxDurationRetry = time.Minute
+
+	// retryDefaultBackoff is the delay before the first retry if the retry policy doesn't specify it.
+	retryDefaultBackoff = 30 * time.Second
+
+	// retryMaxBackoff caps the delay between two delivery attempts.
+	retryMaxBackoff = time.Hour
+)
+
+// deliveryHandler handles the outcome of a webhook delivery attempt.
+type deliveryHandler interface {
+	handleDelivery(ctx context.Context, webhook *types.WebhookCore, execution *types.WebhookExecutionCore, attempt int)
+}
+
+// retriesManaged returns true if failed deliveries of the webhook are handled by its retry policy.
+func (w *WebhookExecutor) retriesManaged(webhook *types.WebhookCore) bool {
+	return w.deliveryHandler != nil && webhook.RetryPolicy.Enabled()
+}
+
+// transportErrorResult returns the execution result used in case no response was received from the remote server.
+func (w *WebhookExecutor) transportErrorResult(webhook *types.WebhookCore) enum.WebhookExecutionResult {
+	if w.retriesManaged(webhook) {
+		return enum.WebhookExecutionResultRetriableError
+	}
+
+	return enum.WebhookExecutionResultFatalError
+}
+
+func (w *WebhookExecutor) handleDelivery(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	execution *types.WebhookExecutionCore,
+	attempt int,
+) {
+	if execution == nil || !w.retriesManaged(webhook) {
+		return
+	}
+
+	w.deliveryHandler.handleDelivery(ctx, webhook, execution, attempt)
+}
+
+// applyRetryableStatusCodes overrides the result of a failed execution
+// based on the status codes the retry policy considers retryable.
+func applyRetryableStatusCodes(execution *types.WebhookExecutionCore, codes []int) {
+	if len(codes) == 0 ||
+		execution.Response.StatusCode == 0 ||
+		execution.Result == enum.WebhookExecutionResultSuccess {
+		return
+	}
+
+	if slices.Contains(codes, execution.Response.StatusCode) {
+		execution.Result = enum.WebhookExecutionResultRetriableError
+	} else {
+		execution.Result = enum.WebhookExecutionResultFatalError
+	}
+}
+
+// retryBackoff returns the delay before the next delivery attempt, after the provided attempt failed.
+// The delay doubles with every attempt.
+func retryBackoff(policy types.WebhookRetryPolicy, attempt int) time.Duration {
+	backoff := retryDefaultBackoff
+	if policy.Backoff > 0 {
+		backoff = time.Duration(policy.Backoff) * time.Second
+	}
+
+	for i := 1; i < attempt && backoff < retryMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, retryMaxBackoff)
+}
+
+type retryJobData struct {
+	ExecutionID int64 `json:"execution_id"`
+	Attempt     int   `json:"attempt"`
+}
+
+type retryJob struct {
+	service *Service
+}
+
+func (j *retryJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input retryJobData
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal webhook retry job data: %w", err)
+	}
+
+	return "", j.service.retryDelivery(ctx, input)
+}
+
+// handleDelivery schedules the next delivery attempt of a failed execution
+// or records the final outcome of the delivery according to the retry policy of the webhook.
+func (s *Service) handleDelivery(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	execution *types.WebhookExecutionCore,
+	attempt int,
+) {
+	policy := webhook.RetryPolicy
+
+	if execution.Result == enum.WebhookExecutionResultSuccess {
+		if webhook.ConsecutiveFailures > 0 {
+			s.updateConsecutiveFailures(ctx, webhook.ID, false)
+		}
+		return
+	}
+
+	canRetry := execution.Result == enum.WebhookExecutionResultRetriableError &&
+		execution.Retriggerable &&
+		execution.ID != 0 && // the execution failed to be stored
+		attempt < policy.MaxAttempts
+
+	if canRetry {
+		err := s.scheduleRetry(ctx, execution.ID, attempt+1, retryBackoff(policy, attempt))
+		if err == nil {
+			return
+		}
+
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("webhook.id", webhook.ID).
+			Int64("execution.id", execution.ID).
+			Msg("failed to schedule webhook delivery retry")
+	}
+
+	s.updateConsecutiveFailures(ctx, webhook.ID, true)
+}
+
+func (s *Service) scheduleRetry(ctx context.Context, executionID int64, attempt int, delay time.Duration) error {
+	data, err := json.Marshal(retryJobData{
+		ExecutionID: executionID,
+		Attempt:     attempt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook retry job data: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("%s-%d", jobTypeRetry, executionID),
+		Type:       jobTypeRetry,
+		MaxRetries: 0,
+		Timeout:    jobMaxDurationRetry,
+		Data:       string(data),
+		Delay:      delay,
+	})
+}
+
+// retryDelivery resends the request of a failed execution. The new execution references the failed one.
+func (s *Service) retryDelivery(ctx context.Context, input retryJobData) error {
+	execution, err := s.webhookExecutionStore.Find(ctx, input.ExecutionID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find webhook execution: %w", err)
+	}
+
+	hook, err := s.webhookStore.Find(ctx, execution.WebhookID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	// the webhook might have been disabled or its retry policy changed in the meantime
+	if !hook.Enabled || !hook.RetryPolicy.Enabled() {
+		return nil
+	}
+
+	webhook := GitnessWebhookToWebhookCore(hook)
+
+	// pass body explicitly
+	body := &bytes.Buffer{}
+	body.WriteString(execution.Request.Body)
+
+	newExecution, err := s.WebhookExecutor.executeWebhook(
+		ctx, webhook, execution.TriggerID, execution.TriggerType, body, &execution.ID)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Int64("webhook.id", webhook.ID).
+			Int("attempt", input.Attempt).
+			Msg("webhook delivery retry failed")
+	}
+
+	s.handleDelivery(ctx, webhook, newExecution, input.Attempt)
+
+	return nil
+}
+
+// updateConsecutiveFailures increments (or resets) the number of consecutive failed deliveries of the webhook.
+// The webhook is disabled once the number reaches the limit of its retry policy and its creator gets notified.
+func (s *Service) updateConsecutiveFailures(ctx context.Context, webhookID int64, failed bool) {
+	hook, err := s.webhookStore.Find(ctx, webhookID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("webhook.id", webhookID).Msg("failed to find webhook")
+		return
+	}
+
+	var disabled bool
+	hook, err = s.webhookStore.UpdateOptLock(ctx, hook, func(hook *types.Webhook) error {
+		disabled = false
+
+		if !failed {
+			hook.ConsecutiveFailures = 0
+			return nil
+		}
+
+		hook.ConsecutiveFailures++
+
+		limit := hook.RetryPolicy.DisableAfterFailures
+		if hook.Enabled && limit > 0 && hook.ConsecutiveFailures >= limit {
+			hook.Enabled = false
+			disabled = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("webhook.id", webhookID).
+			Msg("failed to update consecutive failures of webhook")
+		return
+	}
+
+	if disabled {
+		log.Ctx(ctx).Info().Int64("webhook.id", webhookID).
+			Int("failures", hook.ConsecutiveFailures).
+			Msg("webhook disabled after consecutive failed deliveries")
+
+		s.notifyWebhookDisabled(ctx, hook)
+	}
+}
+
+func (s *Service) notifyWebhookDisabled(ctx context.Context, hook *types.Webhook) {
+	if s.notificationClient == nil {
+		return
+	}
+
+	err := func() error {
+		owner, err := s.principalStore.Find(ctx, hook.CreatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to find webhook owner: %w", err)
+		}
+
+		if owner.Email == "" {
+			return nil
+		}
+
+		parentPath, err := s.getParentPath(ctx, hook)
+		if err != nil {
+			return err
+		}
+
+		return s.notificationClient.SendWebhookDisabled(
+			ctx,
+			[]*types.PrincipalInfo{owner.ToPrincipalInfo()},
+			&notification.WebhookDisabledPayload{
+				Webhook:    hook,
+				ParentPath: parentPath,
+				Failures:   hook.ConsecutiveFailures,
+			},
+		)
+	}()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("webhook.id", hook.ID).
+			Msg("failed to notify owner about disabled webhook")
+	}
+}
+
+func (s *Service) getParentPath(ctx context.Context, hook *types.Webhook) (string, error) {
+	switch hook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := s.repoStore.Find(ctx, hook.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find webhook repository: %w", err)
+		}
+		return repo.Path, nil
+	case enum.WebhookParentSpace:
+		space, err := s.spaceStore.Find(ctx, hook.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find webhook space: %w", err)
+		}
+		return space.Path, nil
+	case enum.WebhookParentRegistry:
+	}
+
+	return "", fmt.Errorf("webhook parent type '%s' is not supported", hook.ParentType)
+}
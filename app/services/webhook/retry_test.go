@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff int64
+		attempt int
+		want    time.Duration
+	}{
+		{name: "default", backoff: 0, attempt: 1, want: retryDefaultBackoff},
+		{name: "first-retry", backoff: 10, attempt: 1, want: 10 * time.Second},
+		{name: "second-retry", backoff: 10, attempt: 2, want: 20 * time.Second},
+		{name: "fifth-retry", backoff: 10, attempt: 5, want: 160 * time.Second},
+		{name: "capped", backoff: 3600, attempt: 3, want: retryMaxBackoff},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := retryBackoff(types.WebhookRetryPolicy{MaxAttempts: 10, Backoff: test.backoff}, test.attempt)
+			if got != test.want {
+				t.Errorf("want=%s got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyRetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		codes  []int
+		status int
+		result enum.WebhookExecutionResult
+		want   enum.WebhookExecutionResult
+	}{
+		{
+			name:   "no-codes",
+			codes:  nil,
+			status: 500,
+			result: enum.WebhookExecutionResultRetriableError,
+			want:   enum.WebhookExecutionResultRetriableError,
+		},
+		{
+			name:   "listed-code",
+			codes:  []int{404, 503},
+			status: 404,
+			result: enum.WebhookExecutionResultFatalError,
+			want:   enum.WebhookExecutionResultRetriableError,
+		},
+		{
+			name:   "unlisted-code",
+			codes:  []int{503},
+			status: 500,
+			result: enum.WebhookExecutionResultRetriableError,
+			want:   enum.WebhookExecutionResultFatalError,
+		},
+		{
+			name:   "success",
+			codes:  []int{200},
+			status: 200,
+			result: enum.WebhookExecutionResultSuccess,
+			want:   enum.WebhookExecutionResultSuccess,
+		},
+		{
+			name:   "no-response",
+			codes:  []int{503},
+			status: 0,
+			result: enum.WebhookExecutionResultRetriableError,
+			want:   enum.WebhookExecutionResultRetriableError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			execution := &types.WebhookExecutionCore{
+				Result:   test.result,
+				Response: types.WebhookExecutionResponse{StatusCode: test.status},
+			}
+
+			applyRetryableStatusCodes(execution, test.codes)
+
+			if execution.Result != test.want {
+				t.Errorf("want=%s got=%s", test.want, execution.Result)
+			}
+		})
+	}
+}
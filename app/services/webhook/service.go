@@ -22,18 +22,25 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	gitnesshttp "github.com/harness/gitness/http"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
+
+	"github.com/antonmedv/expr/vm"
 )
 
 const (
@@ -120,6 +127,13 @@ type WebhookExecutor struct {
 	principalStore             store.PrincipalStore
 	webhookExecutorStore       WebhookExecutorStore
 	source                     string
+
+	// filterCache holds the compiled filters of webhooks, so they aren't compiled for every event.
+	filterCache *cache.TTLCache[string, *vm.Program]
+
+	// deliveryHandler handles the outcome of webhook deliveries for webhooks with a retry policy.
+	// If nil, retry policies of webhooks are ignored.
+	deliveryHandler deliveryHandler
 }
 
 func NewWebhookExecutor(
@@ -134,10 +148,10 @@ func NewWebhookExecutor(
 ) *WebhookExecutor {
 	return &WebhookExecutor{
 		webhookExecutorStore:       webhookExecutorStore,
-		secureHTTPClient:           newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient:         newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClient:           gitnesshttp.NewClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient:         gitnesshttp.NewClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClientInternal:   gitnesshttp.NewClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: gitnesshttp.NewClient(config.AllowLoopback, true, true),
 		config:                     config,
 		webhookURLProvider:         webhookURLProvider,
 		encrypter:                  encrypter,
@@ -145,6 +159,7 @@ func NewWebhookExecutor(
 		secretService:              secretService,
 		principalStore:             principalStore,
 		source:                     source,
+		filterCache:                newFilterCache(),
 	}
 }
 
@@ -161,12 +176,16 @@ type Service struct {
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
+	issueStore            store.IssueStore
+	issueActivityStore    store.IssueActivityStore
 	labelStore            store.LabelStore
 	labelValueStore       store.LabelValueStore
 	encrypter             encrypt.Encrypter
 	config                Config
 	auditService          audit.Service
 	sseStreamer           sse.Streamer
+	scheduler             *job.Scheduler
+	notificationClient    notification.Client
 }
 
 func NewService(
@@ -175,12 +194,15 @@ func NewService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueReaderFactory *events.ReaderFactory[*issueevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	issueStore store.IssueStore,
+	issueActivityStore store.IssueActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
@@ -192,6 +214,9 @@ func NewService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	notificationClient notification.Client,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service Config is invalid: %w", err)
@@ -212,6 +237,8 @@ func NewService(
 		repoStore:             repoStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
+		issueStore:            issueStore,
+		issueActivityStore:    issueActivityStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
@@ -221,6 +248,14 @@ func NewService(
 		labelValueStore:       labelValueStore,
 		auditService:          auditService,
 		sseStreamer:           sseStreamer,
+		scheduler:             scheduler,
+		notificationClient:    notificationClient,
+	}
+
+	executor.deliveryHandler = service
+
+	if err := jobExecutor.Register(jobTypeRetry, &retryJob{service: service}); err != nil {
+		return nil, fmt.Errorf("failed to register webhook retry job: %w", err)
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -271,6 +306,8 @@ func NewService(
 			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
 			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
 			_ = r.RegisterTargetBranchChanged(service.handleEventPullReqTargetBranchChanged)
+			_ = r.RegisterAutoMergeEnabled(service.handleEventPullReqAutoMergeEnabled)
+			_ = r.RegisterAutoMergeDisabled(service.handleEventPullReqAutoMergeDisabled)
 
 			return nil
 		})
@@ -278,5 +315,28 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = issueReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *issueevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventIssueCreated)
+			_ = r.RegisterUpdated(service.handleEventIssueUpdated)
+			_ = r.RegisterClosed(service.handleEventIssueClosed)
+			_ = r.RegisterReopened(service.handleEventIssueReopened)
+			_ = r.RegisterCommentCreated(service.handleEventIssueCommentCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch issue event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// payloadTriggerKey is the key under which the trigger type is available to filters and payload templates,
+// next to the top level fields of the webhook payload.
+const payloadTriggerKey = "trigger"
+
+const (
+	// maxPayloadSize is the max size of a webhook request body rendered from a payload template.
+	maxPayloadSize = 1 << 20 // 1 MiB
+
+	// payloadRenderTimeout is the max duration of the rendering of a payload template.
+	payloadRenderTimeout = 5 * time.Second
+
+	// maxPayloadRenderSteps is the max number of loop iterations and template invocations
+	// executed while rendering a payload template.
+	maxPayloadRenderSteps = 100_000
+
+	// templateStepFunc is the name of the function that is injected into the loops and templates
+	// of a payload template to count the execution steps.
+	templateStepFunc = "_step"
+
+	// filterCacheDuration is for how long a compiled filter is kept after it was compiled.
+	filterCacheDuration = 15 * time.Minute
+)
+
+// compileFilter compiles the filter expression of a webhook.
+//
+// The expression has access to the fields of the JSON payload of the event (e.g. `pull_req.target_branch`),
+// the `trigger` variable holding the trigger type and the `glob(value, pattern)` function.
+// Example: `trigger == "pullreq_created" && glob(pull_req.target_branch, "release/*")`.
+func compileFilter(filter string) (*vm.Program, error) {
+	return expr.Compile(filter,
+		expr.Env(map[string]any{}),
+		expr.AllowUndefinedVariables(),
+		expr.AsBool(),
+		expr.Function("glob", globFunc, new(func(string, string) bool)),
+	)
+}
+
+// globFunc is the expr binding of globMatch. Undefined values are treated as empty strings.
+func globFunc(params ...any) (any, error) {
+	value, _ := params[0].(string)
+	pattern, _ := params[1].(string)
+
+	return globMatch(value, pattern)
+}
+
+// globMatch matches the value against the glob pattern, e.g. `release/*` or `feature/**`.
+func globMatch(value, pattern string) (bool, error) {
+	matched, err := doublestar.Match(pattern, value)
+	if err != nil {
+		return false, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+
+	return matched, nil
+}
+
+// filterCompiler compiles filters for the filter cache.
+type filterCompiler struct{}
+
+func (filterCompiler) Find(_ context.Context, filter string) (*vm.Program, error) {
+	return compileFilter(filter)
+}
+
+// newFilterCache returns a cache of compiled filters, keyed by the filter expression.
+func newFilterCache() *cache.TTLCache[string, *vm.Program] {
+	return cache.New[string, *vm.Program](filterCompiler{}, filterCacheDuration)
+}
+
+// parsePayloadTemplate parses the payload template of a webhook.
+//
+// The template has access to the same data as filters, plus the `json` function
+// which renders a value as JSON, e.g. `{"summary": {{ json .pull_req.title }}}`.
+//
+// Text templates can't be interrupted, so a step counter is injected at the start of every loop iteration
+// and template invocation, which aborts the execution once a limit is reached (see renderWebhookPayload).
+func parsePayloadTemplate(payloadTemplate string) (*template.Template, error) {
+	tmpl, err := template.New("payload").
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{
+			"json":           templateJSON,
+			"glob":           globMatch,
+			templateStepFunc: func() (string, error) { return "", nil },
+		}).
+		Parse(payloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		injectStepCounter(t.Tree.Root)
+	}
+
+	return tmpl, nil
+}
+
+// injectStepCounter inserts a call of the step function at the start of the template
+// and at the start of the body of every range loop in it.
+func injectStepCounter(root *parse.ListNode) {
+	root.Nodes = append([]parse.Node{newStepNode()}, root.Nodes...)
+	injectStepCounterInLoops(root)
+}
+
+func injectStepCounterInLoops(list *parse.ListNode) {
+	if list == nil {
+		return
+	}
+
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parse.RangeNode:
+			n.List.Nodes = append([]parse.Node{newStepNode()}, n.List.Nodes...)
+			injectStepCounterInLoops(n.List)
+			injectStepCounterInLoops(n.ElseList)
+		case *parse.IfNode:
+			injectStepCounterInLoops(n.List)
+			injectStepCounterInLoops(n.ElseList)
+		case *parse.WithNode:
+			injectStepCounterInLoops(n.List)
+			injectStepCounterInLoops(n.ElseList)
+		}
+	}
+}
+
+// newStepNode returns the action node `{{ _step }}`.
+func newStepNode() *parse.ActionNode {
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Cmds: []*parse.CommandNode{{
+				NodeType: parse.NodeCommand,
+				Args:     []parse.Node{parse.NewIdentifier(templateStepFunc)},
+			}},
+		},
+	}
+}
+
+func templateJSON(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// payloadData converts the webhook payload to the generic representation used by filters and templates.
+// The field names are the same as in the JSON body of the webhook request.
+func payloadData(triggerType enum.WebhookTrigger, body any) (map[string]any, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+
+	data := map[string]any{}
+	if err = json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to deserialize payload: %w", err)
+	}
+
+	data[payloadTriggerKey] = string(triggerType)
+
+	return data, nil
+}
+
+// evaluateFilter returns true if the event payload matches the compiled filter of the webhook.
+func evaluateFilter(program *vm.Program, data map[string]any) (bool, error) {
+	out, err := expr.Run(program, data)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate filter: %w", err)
+	}
+
+	matched, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("filter evaluated to %T instead of bool", out)
+	}
+
+	return matched, nil
+}
+
+// renderWebhookPayload renders the request body of a webhook using its payload template.
+// The size of the rendered body is limited to maxPayloadSize and the rendering
+// to maxPayloadRenderSteps steps and payloadRenderTimeout.
+func renderWebhookPayload(
+	ctx context.Context,
+	payloadTemplate string,
+	triggerType enum.WebhookTrigger,
+	body any,
+) ([]byte, error) {
+	data, err := payloadData(triggerType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := parsePayloadTemplate(payloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payload template: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, payloadRenderTimeout)
+	defer cancel()
+
+	steps := 0
+	tmpl.Funcs(template.FuncMap{
+		templateStepFunc: func() (string, error) {
+			steps++
+			if steps > maxPayloadRenderSteps {
+				return "", errTooManySteps
+			}
+			return "", ctx.Err()
+		},
+	})
+
+	w := &limitedWriter{limit: maxPayloadSize}
+
+	err = tmpl.Execute(w, data)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("failed to render payload template: rendering took longer than %s",
+			payloadRenderTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render payload template: %w", err)
+	}
+
+	return w.buf.Bytes(), nil
+}
+
+var (
+	errPayloadTooLarge = fmt.Errorf("rendered payload exceeds the limit of %d bytes", maxPayloadSize)
+	errTooManySteps    = fmt.Errorf("rendering exceeds the limit of %d loop iterations and template calls",
+		maxPayloadRenderSteps)
+)
+
+// limitedWriter is a buffer that fails writes past the size limit.
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errPayloadTooLarge
+	}
+
+	return w.buf.Write(p)
+}
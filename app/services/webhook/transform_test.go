@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEvaluateFilter(t *testing.T) {
+	body := &PullReqCreatedPayload{
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: "fix", TargetBranch: "release/1.0"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "trigger", filter: `trigger == "pullreq_created"`, want: true},
+		{name: "other-trigger", filter: `trigger == "branch_created"`, want: false},
+		{name: "glob", filter: `glob(pull_req.target_branch, "release/*")`, want: true},
+		{name: "glob-no-match", filter: `glob(pull_req.target_branch, "main")`, want: false},
+		{name: "number", filter: `pull_req.number > 5 && pull_req.title == "fix"`, want: true},
+		{name: "undefined", filter: `missing == "x"`, want: false},
+		{name: "not-bool", filter: `pull_req.number`, wantErr: true},
+		{name: "syntax", filter: `trigger ==`, wantErr: true},
+	}
+
+	data, err := payloadData(enum.WebhookTriggerPullReqCreated, body)
+	if err != nil {
+		t.Fatalf("failed to build payload data: %s", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			program, err := compileFilter(test.filter)
+			if err == nil {
+				var got bool
+				got, err = evaluateFilter(program, data)
+				if err == nil && got != test.want {
+					t.Errorf("want=%t, got=%t", test.want, got)
+				}
+			}
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRenderWebhookPayload(t *testing.T) {
+	body := &PullReqCreatedPayload{
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: `say "hi"`, TargetBranch: "main"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "json",
+			template: `{"text": {{ json .pull_req.title }}, "n": {{ .pull_req.number }}}`,
+			want:     `{"text": "say \"hi\"", "n": 7}`,
+		},
+		{name: "trigger", template: `{{ .trigger }}`, want: "pullreq_created"},
+		{name: "missing", template: `[{{ .missing }}]`, want: "[<no value>]"},
+		{name: "invalid", template: `{{ .pull_req.title`, wantErr: true},
+		{
+			name:     "too-large",
+			template: `{{ range 2000 }}` + strings.Repeat("x", 1024) + `{{ end }}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderWebhookPayload(context.Background(), test.template, enum.WebhookTriggerPullReqCreated, body)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("want=%q, got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderWebhookPayload_Limits(t *testing.T) {
+	body := &PullReqCreatedPayload{}
+
+	got, err := renderWebhookPayload(context.Background(), strings.Repeat("x", maxPayloadSize),
+		enum.WebhookTriggerPullReqCreated, body)
+	if err != nil {
+		t.Fatalf("unexpected error for a payload at the limit: %s", err)
+	}
+	if len(got) != maxPayloadSize {
+		t.Errorf("expected payload of %d bytes, got %d", maxPayloadSize, len(got))
+	}
+
+	_, err = renderWebhookPayload(context.Background(), strings.Repeat("x", maxPayloadSize+1),
+		enum.WebhookTriggerPullReqCreated, body)
+	if !errors.Is(err, errPayloadTooLarge) {
+		t.Errorf("expected error %v, got %v", errPayloadTooLarge, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = renderWebhookPayload(ctx, `{{ range 10 }}x{{ end }}`, enum.WebhookTriggerPullReqCreated, body)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRenderWebhookPayload_StepLimit(t *testing.T) {
+	body := &PullReqCreatedPayload{}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "range-within-limit",
+			template: `{{ range $i := 3 }}{{ $i }}{{ else }}none{{ end }}`,
+			want:     "012",
+		},
+		{
+			name:     "nested-template",
+			template: `{{ define "x" }}[{{ . }}]{{ end }}{{ range 2 }}{{ if true }}{{ template "x" . }}{{ end }}{{ end }}`,
+			want:     "[0][1]",
+		},
+		{
+			name:     "range-without-output",
+			template: `{{ range 10000000000 }}{{ end }}`,
+			wantErr:  errTooManySteps,
+		},
+		{
+			name:     "nested-range",
+			template: `{{ range 100000 }}{{ if true }}{{ range 100000 }}{{ end }}{{ end }}{{ end }}`,
+			wantErr:  errTooManySteps,
+		},
+		{
+			name:     "recursive-template",
+			template: `{{ define "x" }}{{ template "x" }}{{ template "x" }}{{ end }}{{ template "x" }}`,
+			wantErr:  errTooManySteps,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderWebhookPayload(context.Background(), test.template, enum.WebhookTriggerPullReqCreated, body)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if string(got) != test.want {
+				t.Errorf("want=%q, got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr bool
+	}{
+		{name: "empty", filter: ""},
+		{name: "valid", filter: `glob(pull_req.target_branch, "main")`},
+		{name: "not-bool", filter: `1 + 2`, wantErr: true},
+		{name: "syntax", filter: `(`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckFilter(test.filter)
+			if (err != nil) != test.wantErr {
+				t.Errorf("wantErr=%t, got=%v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	gitnessstore "github.com/harness/gitness/app/store"
@@ -104,7 +105,10 @@ func (w *WebhookExecutor) triggerWebhooks(
 
 	// precalculate whether a webhook should be executed
 	skipExecution := make(map[int64]bool)
+	executed := make(map[int64]bool)
 	for _, execution := range executions {
+		executed[execution.WebhookID] = true
+
 		// skip execution in case of success or unrecoverable error
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
 			execution.Result == enum.WebhookExecutionResultFatalError {
@@ -130,22 +134,82 @@ func (w *WebhookExecutor) triggerWebhooks(
 			continue
 		}
 
+		// failed deliveries of webhooks with a retry policy are retried by a job, not by reprocessing the trigger
+		if w.retriesManaged(webhook) && executed[webhook.ID] {
+			continue
+		}
+
 		// check if webhook is registered for trigger (empty list => all triggers are registered)
 		triggerRegistered := len(webhook.Triggers) == 0 || slices.Contains(webhook.Triggers, triggerType)
 		if !triggerRegistered {
 			continue
 		}
 
+		// check if the event matches the filter of the webhook
+		if !w.filterMatches(ctx, webhook, triggerType, body) {
+			continue
+		}
+
 		// execute trigger and store output in result
 		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+
+		w.handleDelivery(ctx, webhook, results[i].Execution, 1)
 	}
 
 	return results, nil
 }
 
+// filterMatches returns true if the webhook has no filter or the payload matches it.
+// Webhooks whose filter can't be evaluated for the payload aren't triggered.
+func (w *WebhookExecutor) filterMatches(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	triggerType enum.WebhookTrigger,
+	body any,
+) bool {
+	if strings.TrimSpace(webhook.Filter) == "" {
+		return true
+	}
+
+	program, err := w.filterCache.Get(ctx, webhook.Filter)
+	if err == nil {
+		var data map[string]any
+		data, err = payloadData(triggerType, body)
+		if err == nil {
+			var matched bool
+			matched, err = evaluateFilter(program, data)
+			if err == nil {
+				return matched
+			}
+		}
+	}
+
+	log.Ctx(ctx).Warn().Err(err).
+		Int64("webhook.id", webhook.ID).
+		Str("trigger_type", string(triggerType)).
+		Msg("failed to evaluate webhook filter, skipping webhook")
+
+	return false
+}
+
 func (w *WebhookExecutor) RetriggerWebhookExecution(
 	ctx context.Context,
 	webhookExecutionID int64,
+) (*TriggerResult, error) {
+	result, err := w.retriggerWebhookExecution(ctx, webhookExecutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a manual retrigger starts a new sequence of delivery attempts
+	w.handleDelivery(ctx, result.Webhook, result.Execution, 1)
+
+	return result, nil
+}
+
+func (w *WebhookExecutor) retriggerWebhookExecution(
+	ctx context.Context,
+	webhookExecutionID int64,
 ) (*TriggerResult, error) {
 	// find execution
 	webhookExecution, err := w.webhookExecutorStore.Find(ctx, webhookExecutionID)
@@ -260,9 +324,10 @@ func (w *WebhookExecutor) executeWebhook(
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
 		// we assume timeout without any response is not worth retrying - protect the system
+		// (unless the webhook has a retry policy, in which case retries are bounded by the policy)
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = w.transportErrorResult(webhook)
 		return &execution, tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
@@ -273,21 +338,27 @@ func (w *WebhookExecutor) executeWebhook(
 
 	case err != nil:
 		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
+		// (unless the webhook has a retry policy, in which case retries are bounded by the policy)
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = w.transportErrorResult(webhook)
 		return &execution, tErr
 	}
 
 	// handle response
 	err = handleWebhookResponse(&execution, resp)
 
+	if w.retriesManaged(webhook) {
+		applyRetryableStatusCodes(&execution, webhook.RetryPolicy.RetryableStatusCodes)
+	}
+
 	return &execution, err
 }
 
 // prepareHTTPRequest prepares a new http.Request object for the webhook using the provided body as request body.
 // All execution.Request.XXX values are set accordingly.
-// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized.
+// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be rendered
+// using the payload template of the webhook, or JSON serialized if the webhook doesn't have one.
 func (w *WebhookExecutor) prepareHTTPRequest(
 	ctx context.Context, execution *types.WebhookExecutionCore,
 	triggerType enum.WebhookTrigger, webhook *types.WebhookCore, body any,
@@ -319,6 +390,20 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 		bBuff.Write(bBytes)
 
 	default:
+		// if the webhook defines its own body, render it from the payload
+		if webhook.PayloadTemplate != "" {
+			bBytes, err := renderWebhookPayload(ctx, webhook.PayloadTemplate, triggerType, body)
+			if err != nil {
+				// ASSUMPTION: there was an issue with the static user input, not retriable
+				execution.Error = err.Error()
+				execution.Result = enum.WebhookExecutionResultFatalError
+				return nil, err
+			}
+
+			bBuff.Write(bBytes)
+			break
+		}
+
 		// all other types we json serialize
 		err := json.NewEncoder(bBuff).Encode(body)
 		if err != nil {
@@ -573,6 +658,10 @@ func GitnessWebhookToWebhookCore(webhook *types.Webhook) *types.WebhookCore {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		RetryPolicy:           webhook.RetryPolicy,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		Filter:                webhook.Filter,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
@@ -596,6 +685,10 @@ func CoreWebhookToGitnessWebhook(webhook *types.WebhookCore) *types.Webhook {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		RetryPolicy:           webhook.RetryPolicy,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		Filter:                webhook.Filter,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
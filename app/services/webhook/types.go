@@ -105,11 +105,37 @@ type PullReqReviewSegment struct {
 	ReviewerInfo   PrincipalInfo              `json:"reviewer"`
 }
 
+type PullReqAutoMergeSegment struct {
+	MergeMethod        enum.MergeMethod            `json:"merge_method,omitempty"`
+	DeleteSourceBranch bool                        `json:"delete_source_branch,omitempty"`
+	DisableReason      enum.AutoMergeDisableReason `json:"disable_reason,omitempty"`
+}
+
 type PullReqTargetBrancheChangedSegment struct {
 	OldTargetBranch string `json:"old_target_branch"`
 	OldMergeBaseSHA string `json:"old_merge_base_sha"`
 }
 
+// IssueSegment contains details for all issue related payloads for webhooks.
+type IssueSegment struct {
+	Issue IssueInfo `json:"issue"`
+}
+
+// IssueCommentSegment contains details for all issue comment related payloads for webhooks.
+type IssueCommentSegment struct {
+	CommentInfo CommentInfo `json:"comment"`
+}
+
+// IssueUpdateSegment contains details what has been updated in the issue.
+type IssueUpdateSegment struct {
+	TitleChanged       bool   `json:"title_changed"`
+	TitleOld           string `json:"title_old"`
+	TitleNew           string `json:"title_new"`
+	DescriptionChanged bool   `json:"description_changed"`
+	DescriptionOld     string `json:"description_old"`
+	DescriptionNew     string `json:"description_new"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -195,6 +221,36 @@ func pullReqInfoFrom(
 	}
 }
 
+// IssueInfo describes the issue related info for a webhook payload.
+// NOTE: don't use types package as we want issue payload to be independent from API calls.
+type IssueInfo struct {
+	Number      int64           `json:"number"`
+	State       enum.IssueState `json:"state"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	MilestoneID *int64          `json:"milestone_id,omitempty"`
+	Author      PrincipalInfo   `json:"author"`
+	IssueURL    string          `json:"issue_url"`
+}
+
+// issueInfoFrom gets the IssueInfo from a types.Issue.
+func issueInfoFrom(
+	ctx context.Context,
+	issue *types.Issue,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) IssueInfo {
+	return IssueInfo{
+		Number:      issue.Number,
+		State:       issue.State,
+		Title:       issue.Title,
+		Description: issue.Description,
+		MilestoneID: issue.MilestoneID,
+		Author:      principalInfoFrom(&issue.Author),
+		IssueURL:    urlProvider.GenerateUIIssueURL(ctx, repo.Path, issue.Number),
+	}
+}
+
 // PrincipalInfo describes the principal related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type PrincipalInfo struct {
@@ -64,6 +64,21 @@ func (s *Service) sanitizeUpdateInput(in *types.WebhookUpdateInput) error {
 			return err
 		}
 	}
+	if in.RetryPolicy != nil {
+		if err := CheckRetryPolicy(*in.RetryPolicy); err != nil {
+			return err
+		}
+	}
+	if in.Filter != nil {
+		if err := CheckFilter(*in.Filter); err != nil {
+			return err
+		}
+	}
+	if in.PayloadTemplate != nil {
+		if err := CheckPayloadTemplate(*in.PayloadTemplate); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -111,6 +126,10 @@ func (s *Service) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		// start counting failed deliveries from scratch when the webhook gets (re-)enabled
+		if *in.Enabled && !hook.Enabled {
+			hook.ConsecutiveFailures = 0
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
@@ -119,6 +138,15 @@ func (s *Service) Update(
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
+	if in.RetryPolicy != nil {
+		hook.RetryPolicy = *in.RetryPolicy
+	}
+	if in.Filter != nil {
+		hook.Filter = *in.Filter
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
 
 	if err := s.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -18,7 +18,9 @@ import (
 	"context"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -26,6 +28,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -44,12 +47,15 @@ func ProvideService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueReaderFactory *events.ReaderFactory[*issueevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	issueStore store.IssueStore,
+	issueActivityStore store.IssueActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
@@ -61,6 +67,9 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	notificationClient notification.Client,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -68,11 +77,14 @@ func ProvideService(
 		tx,
 		gitReaderFactory,
 		prReaderFactory,
+		issueReaderFactory,
 		webhookStore,
 		webhookExecutionStore,
 		spaceStore, repoStore,
 		pullreqStore,
 		activityStore,
+		issueStore,
+		issueActivityStore,
 		urlProvider,
 		principalStore,
 		git,
@@ -84,6 +96,9 @@ func ProvideService(
 		sseStreamer,
 		secretService,
 		spacePathStore,
+		scheduler,
+		jobExecutor,
+		notificationClient,
 	)
 }
 
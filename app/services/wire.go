@@ -16,6 +16,7 @@ package services
 
 import (
 	"github.com/harness/gitness/app/services/aitaskevent"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
@@ -25,15 +26,19 @@ import (
 	"github.com/harness/gitness/app/services/gitspaceoperationsevent"
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/issuelink"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqstack"
 	"github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
 	registryasyncprocessing "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 
 	"github.com/google/wire"
@@ -61,6 +66,11 @@ type Services struct {
 	registryWebhooksService        *registrywebhooks.Service
 	Branch                         *branch.Service
 	registryAsyncProcessingService *registryasyncprocessing.Service
+	RegistryCleanup                *registrycleanup.Service
+	MergeQueue                     *mergequeue.Service
+	AutoMerge                      *automerge.Service
+	PullReqStack                   *pullreqstack.Service
+	IssueLink                      *issuelink.Service
 }
 
 type GitspaceServices struct {
@@ -111,6 +121,11 @@ func ProvideServices(
 	registryWebhooksService *registrywebhooks.Service,
 	branchSvc *branch.Service,
 	registryAsyncProcessingService *registryasyncprocessing.Service,
+	registryCleanupSvc *registrycleanup.Service,
+	mergeQueueSvc *mergequeue.Service,
+	autoMergeSvc *automerge.Service,
+	pullReqStackSvc *pullreqstack.Service,
+	issueLinkSvc *issuelink.Service,
 ) Services {
 	return Services{
 		Webhook:                        webhooksSvc,
@@ -130,5 +145,10 @@ func ProvideServices(
 		registryWebhooksService:        registryWebhooksService,
 		Branch:                         branchSvc,
 		registryAsyncProcessingService: registryAsyncProcessingService,
+		RegistryCleanup:                registryCleanupSvc,
+		MergeQueue:                     mergeQueueSvc,
+		AutoMerge:                      autoMergeSvc,
+		PullReqStack:                   pullReqStackSvc,
+		IssueLink:                      issueLinkSvc,
 	}
 }
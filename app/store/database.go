@@ -572,6 +572,49 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	// MergeQueueStore defines the merge queue data storage.
+	MergeQueueStore interface {
+		// Find finds the merge queue entry by ID.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error)
+
+		// FindByGroupSHA finds the merge queue entry by the SHA of its merge group commit.
+		FindByGroupSHA(ctx context.Context, repoID int64, groupSHA string) (*types.MergeQueueEntry, error)
+
+		// Create adds a new pull request to the end of the merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the merge queue entry.
+		Delete(ctx context.Context, id int64) error
+
+		// ListByBranch returns all entries of the merge queue of a branch in the queue order.
+		ListByBranch(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error)
+
+		// ListQueues returns all branches that have a non-empty merge queue.
+		ListQueues(ctx context.Context) ([]types.MergeQueue, error)
+	}
+
+	// AutoMergeStore defines the pull request auto-merge data storage.
+	AutoMergeStore interface {
+		// Find finds the auto-merge settings of a pull request.
+		Find(ctx context.Context, pullreqID int64) (*types.AutoMerge, error)
+
+		// Upsert creates or replaces the auto-merge settings of a pull request.
+		Upsert(ctx context.Context, autoMerge *types.AutoMerge) error
+
+		// Delete removes the auto-merge settings of a pull request.
+		Delete(ctx context.Context, pullreqID int64) error
+
+		// ListBySourceSHA returns auto-merge settings of all open pull requests
+		// of the target repository that have the provided source SHA.
+		ListBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) ([]*types.AutoMerge, error)
+	}
+
 	// RuleStore defines database interface for protection rules.
 	RuleStore interface {
 		// Find finds a protection rule by ID.
@@ -1084,6 +1127,20 @@ type (
 		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Template, error)
 	}
 
+	TemplateVersionStore interface {
+		// Find returns a version of a template.
+		Find(ctx context.Context, templateID int64, version string) (*types.TemplateVersion, error)
+
+		// Create creates a new template version.
+		Create(ctx context.Context, version *types.TemplateVersion) error
+
+		// List lists the versions of a template.
+		List(ctx context.Context, templateID int64, filter types.ListQueryFilter) ([]*types.TemplateVersion, error)
+
+		// Count the number of versions of a template matching the given filter.
+		Count(ctx context.Context, templateID int64, filter types.ListQueryFilter) (int64, error)
+	}
+
 	TriggerStore interface {
 		// FindByIdentifier returns a trigger given a pipeline and a trigger identifier.
 		FindByIdentifier(ctx context.Context, pipelineID int64, identifier string) (*types.Trigger, error)
@@ -1112,6 +1169,9 @@ type (
 		// ListAllEnabled lists all enabled triggers for a given repo without pagination.
 		// It's used only internally to trigger builds.
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
+
+		// ListCronDue lists enabled cron triggers which have a scheduled run at or before the provided time.
+		ListCronDue(ctx context.Context, now int64, limit int) ([]*types.Trigger, error)
 	}
 
 	PluginStore interface {
@@ -1414,6 +1474,131 @@ type (
 		) (map[int64][]*types.LabelPullReqAssignmentInfo, error)
 	}
 
+	IssueStore interface {
+		// Find the issue by id.
+		Find(ctx context.Context, id int64) (*types.Issue, error)
+
+		// FindByNumber finds the issue by repo ID and issue number.
+		FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error)
+
+		// Create a new issue.
+		Create(ctx context.Context, issue *types.Issue) error
+
+		// Update the issue. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, issue *types.Issue) error
+
+		// UpdateOptLock the issue details using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			issue *types.Issue,
+			mutateFn func(issue *types.Issue) error,
+		) (*types.Issue, error)
+
+		// UpdateActivitySeq the issue's activity sequence number.
+		// It will set new values to the ActivitySeq, Version and Updated fields.
+		UpdateActivitySeq(ctx context.Context, issue *types.Issue) (*types.Issue, error)
+
+		// Count of issues in a repository.
+		Count(ctx context.Context, opts *types.IssueFilter) (int64, error)
+
+		// List returns a list of issues in a repository.
+		List(ctx context.Context, opts *types.IssueFilter) ([]*types.Issue, error)
+
+		// CountByMilestone returns the number of open and closed issues of a milestone.
+		CountByMilestone(ctx context.Context, milestoneID int64) (open int64, closed int64, err error)
+	}
+
+	IssueActivityStore interface {
+		// Find the issue activity by id.
+		Find(ctx context.Context, id int64) (*types.IssueActivity, error)
+
+		// Create a new issue activity. Value of the Order field should be fetched with UpdateActivitySeq.
+		// Value of the SubOrder field (for replies) should be the incremented ReplySeq field (non-replies have 0).
+		Create(ctx context.Context, act *types.IssueActivity) error
+
+		// CreateWithPayload create a new system activity from the provided payload.
+		CreateWithPayload(
+			ctx context.Context,
+			issue *types.Issue,
+			principalID int64,
+			payload types.PullReqActivityPayload,
+			metadata *types.PullReqActivityMetadata,
+		) (*types.IssueActivity, error)
+
+		// Update the issue activity. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, act *types.IssueActivity) error
+
+		// UpdateOptLock updates the issue activity using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			act *types.IssueActivity,
+			mutateFn func(act *types.IssueActivity) error,
+		) (*types.IssueActivity, error)
+
+		// Count returns number of issue activities of an issue.
+		Count(ctx context.Context, issueID int64, opts *types.PullReqActivityFilter) (int64, error)
+
+		// List returns a list of issue activities of an issue.
+		List(ctx context.Context, issueID int64, opts *types.PullReqActivityFilter) ([]*types.IssueActivity, error)
+	}
+
+	IssueAssigneeStore interface {
+		// Create adds a new assignee to an issue.
+		Create(ctx context.Context, v *types.IssueAssignee) error
+
+		// Delete removes an assignee from an issue.
+		Delete(ctx context.Context, issueID, principalID int64) error
+
+		// List returns the assignees of an issue.
+		List(ctx context.Context, issueID int64) ([]*types.IssueAssignee, error)
+
+		// ListByIssueIDs returns the assignees of the specified issues.
+		ListByIssueIDs(ctx context.Context, issueIDs []int64) (map[int64][]*types.PrincipalInfo, error)
+	}
+
+	IssueLabelAssignmentStore interface {
+		// Assign assigns a label to an issue.
+		Assign(ctx context.Context, label *types.IssueLabel) error
+
+		// Unassign removes a label from an issue.
+		Unassign(ctx context.Context, issueID int64, labelID int64) error
+
+		// ListAssigned list labels assigned to a specified issue.
+		ListAssigned(ctx context.Context, issueID int64) (map[int64]*types.LabelAssignment, error)
+
+		// FindByLabelID finds a label assigned to an issue with a specified id.
+		FindByLabelID(ctx context.Context, issueID int64, labelID int64) (*types.IssueLabel, error)
+
+		// FindValueByLabelID finds a value assigned to an issue label.
+		FindValueByLabelID(ctx context.Context, issueID int64, labelID int64) (*types.LabelValue, error)
+
+		// ListAssignedByIssueIDs list labels assigned to specified issues.
+		ListAssignedByIssueIDs(
+			ctx context.Context,
+			issueIDs []int64,
+		) (map[int64][]*types.LabelIssueAssignmentInfo, error)
+	}
+
+	MilestoneStore interface {
+		// Find finds the milestone by id.
+		Find(ctx context.Context, id int64) (*types.Milestone, error)
+
+		// Create creates a new milestone.
+		Create(ctx context.Context, milestone *types.Milestone) error
+
+		// Update updates the milestone.
+		Update(ctx context.Context, milestone *types.Milestone) error
+
+		// Delete deletes the milestone.
+		Delete(ctx context.Context, id int64) error
+
+		// Count returns the number of milestones of a repository.
+		Count(ctx context.Context, repoID int64, filter *types.MilestoneFilter) (int64, error)
+
+		// List returns a list of milestones of a repository.
+		List(ctx context.Context, repoID int64, filter *types.MilestoneFilter) ([]*types.Milestone, error)
+	}
+
 	LFSObjectStore interface {
 		// Find finds an LFS object with a specified oid and repo-id.
 		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
@@ -1517,4 +1702,141 @@ type (
 		List(ctx context.Context, filter *types.AITaskFilter) ([]*types.AITask, error)
 		Count(ctx context.Context, filter *types.AITaskFilter) (int64, error)
 	}
+
+	NotificationChannelStore interface {
+		// Find finds the notification channel by id.
+		Find(ctx context.Context, id int64) (*types.NotificationChannel, error)
+
+		// FindByIdentifier finds a notification channel defined in a specified space/repo
+		// with a specified identifier.
+		FindByIdentifier(
+			ctx context.Context,
+			spaceID, repoID *int64,
+			identifier string,
+		) (*types.NotificationChannel, error)
+
+		// Create creates a new notification channel.
+		Create(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Update updates an existing notification channel.
+		Update(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Delete deletes the notification channel with the specified id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists notification channels defined in a specified space/repo.
+		List(ctx context.Context, spaceID, repoID *int64) ([]*types.NotificationChannel, error)
+
+		// ListEnabledInScopes lists enabled notification channels defined in the specified repo/spaces.
+		ListEnabledInScopes(
+			ctx context.Context,
+			repoID int64,
+			spaceIDs []int64,
+		) ([]*types.NotificationChannel, error)
+	}
+
+	NotificationPreferenceStore interface {
+		// Find finds the notification preferences of the principal.
+		Find(ctx context.Context, principalID int64) (*types.NotificationPreferences, error)
+
+		// ListByPrincipals lists the notification preferences of the provided principals.
+		// Principals that never changed their preferences are not included in the result.
+		ListByPrincipals(ctx context.Context, principalIDs []int64) ([]*types.NotificationPreferences, error)
+
+		// Upsert creates or updates the notification preferences of a principal.
+		Upsert(ctx context.Context, prefs *types.NotificationPreferences) error
+
+		// ListScopes lists the space/repo notification preferences of the principal.
+		ListScopes(ctx context.Context, principalID int64) ([]*types.NotificationScopePreference, error)
+
+		// ListScopesForPrincipals lists the notification preferences of the provided principals
+		// defined for the specified repo/spaces.
+		ListScopesForPrincipals(
+			ctx context.Context,
+			principalIDs []int64,
+			repoID *int64,
+			spaceIDs []int64,
+		) ([]*types.NotificationScopePreference, error)
+
+		// UpsertScope creates or updates a space/repo notification preference of a principal.
+		UpsertScope(ctx context.Context, pref *types.NotificationScopePreference) error
+
+		// DeleteScope deletes the notification preference of the principal for the specified space/repo.
+		DeleteScope(ctx context.Context, principalID int64, spaceID, repoID *int64) error
+	}
+
+	NotificationDigestStore interface {
+		// Create stores a notification to be sent as part of a digest.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPending lists principals and delivery modes with notifications waiting to be sent.
+		ListPending(ctx context.Context) ([]*types.NotificationDigestPending, error)
+
+		// List lists the pending notifications of the principal for the delivery mode, oldest first.
+		List(
+			ctx context.Context,
+			principalID int64,
+			delivery enum.NotificationDelivery,
+		) ([]*types.NotificationDigestItem, error)
+
+		// Delete deletes the pending notifications of the principal for the delivery mode
+		// up to and including the notification with the provided id.
+		Delete(ctx context.Context, principalID int64, delivery enum.NotificationDelivery, maxID int64) error
+	}
+
+	WatchStore interface {
+		// Find finds the watch of the principal for the specified space/repo.
+		Find(ctx context.Context, principalID int64, spaceID, repoID *int64) (*types.Watch, error)
+
+		// ListByPrincipal lists all spaces and repositories watched by the principal.
+		ListByPrincipal(ctx context.Context, principalID int64) ([]*types.Watch, error)
+
+		// ListInScopes lists the watches defined for the specified repo and spaces.
+		ListInScopes(ctx context.Context, repoID *int64, spaceIDs []int64) ([]*types.Watch, error)
+
+		// Upsert creates or updates a watch of a principal for a space/repo.
+		Upsert(ctx context.Context, watch *types.Watch) error
+
+		// Delete deletes the watch of the principal for the specified space/repo.
+		Delete(ctx context.Context, principalID int64, spaceID, repoID *int64) error
+	}
+
+	PipelineArtifactStore interface {
+		// Find finds the artifact of the execution by its name.
+		Find(ctx context.Context, executionID int64, name string) (*types.PipelineArtifact, error)
+
+		// List lists the artifacts of the execution.
+		List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error)
+
+		// Upsert creates an artifact or replaces the artifact of the execution with the same name.
+		// It returns the replaced artifact, or nil if there was none.
+		Upsert(ctx context.Context, artifact *types.PipelineArtifact) (*types.PipelineArtifact, error)
+
+		// ListUpdatedBefore lists the artifacts which weren't updated since the provided time.
+		ListUpdatedBefore(ctx context.Context, before time.Time, limit int) ([]*types.PipelineArtifact, error)
+
+		// Delete deletes the artifact.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	PipelineCacheStore interface {
+		// Find finds the cache of the pipeline by its key.
+		Find(ctx context.Context, pipelineID int64, key string) (*types.PipelineCache, error)
+
+		// FindByPrefix finds the most recently updated cache of the pipeline with a key starting with the prefix.
+		FindByPrefix(ctx context.Context, pipelineID int64, prefix string) (*types.PipelineCache, error)
+
+		// Upsert creates a cache or replaces the cache of the pipeline with the same key.
+		// It returns the replaced cache, or nil if there was none.
+		Upsert(ctx context.Context, cache *types.PipelineCache) (*types.PipelineCache, error)
+
+		// UpdateLastUsed marks the cache as used at the provided time.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed time.Time) error
+
+		// ListUnusedSince lists the caches which weren't used since the provided time.
+		ListUnusedSince(ctx context.Context, since time.Time, limit int) ([]*types.PipelineCache, error)
+
+		// Delete deletes the cache.
+		Delete(ctx context.Context, id int64) error
+	}
 )
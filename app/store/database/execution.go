@@ -75,6 +75,7 @@ type execution struct {
 	AuthorAvatar string             `db:"execution_author_avatar"`
 	Sender       string             `db:"execution_sender"`
 	Params       sqlxtypes.JSONText `db:"execution_params"`
+	Templates    sqlxtypes.JSONText `db:"execution_templates"`
 	Cron         string             `db:"execution_cron"`
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
@@ -121,6 +122,7 @@ const (
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_templates
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -209,6 +211,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_author_avatar
 		,execution_sender
 		,execution_params
+		,execution_templates
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
@@ -245,6 +248,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_author_avatar
 		,:execution_sender
 		,:execution_params
+		,:execution_templates
 		,:execution_cron
 		,:execution_deploy
 		,:execution_deploy_id
@@ -24,6 +24,11 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 	if err != nil {
 		return nil, err
 	}
+	var templates []*types.ExecutionTemplate
+	err = in.Templates.Unmarshal(&templates)
+	if err != nil {
+		return nil, err
+	}
 	return &types.Execution{
 		ID:           in.ID,
 		PipelineID:   in.PipelineID,
@@ -52,6 +57,7 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		AuthorAvatar: in.AuthorAvatar,
 		Sender:       in.Sender,
 		Params:       params,
+		Templates:    templates,
 		Cron:         in.Cron,
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
@@ -93,6 +99,7 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		AuthorAvatar: in.AuthorAvatar,
 		Sender:       in.Sender,
 		Params:       EncodeToSQLXJSON(in.Params),
+		Templates:    EncodeToSQLXJSON(in.Templates),
 		Cron:         in.Cron,
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
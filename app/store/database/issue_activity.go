@@ -0,0 +1,508 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.IssueActivityStore = (*IssueActivityStore)(nil)
+
+// NewIssueActivityStore returns a new IssueActivityStore.
+func NewIssueActivityStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *IssueActivityStore {
+	return &IssueActivityStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueActivityStore implements store.IssueActivityStore backed by a relational database.
+type IssueActivityStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// issueActivity is used to fetch issue activity data from the database.
+type issueActivity struct {
+	ID      int64 `db:"issue_activity_id"`
+	Version int64 `db:"issue_activity_version"`
+
+	CreatedBy int64    `db:"issue_activity_created_by"`
+	Created   int64    `db:"issue_activity_created"`
+	Updated   int64    `db:"issue_activity_updated"`
+	Edited    int64    `db:"issue_activity_edited"`
+	Deleted   null.Int `db:"issue_activity_deleted"`
+
+	ParentID null.Int `db:"issue_activity_parent_id"`
+	RepoID   int64    `db:"issue_activity_repo_id"`
+	IssueID  int64    `db:"issue_activity_issue_id"`
+
+	Order    int64 `db:"issue_activity_order"`
+	SubOrder int64 `db:"issue_activity_sub_order"`
+	ReplySeq int64 `db:"issue_activity_reply_seq"`
+
+	Type enum.PullReqActivityType `db:"issue_activity_type"`
+	Kind enum.PullReqActivityKind `db:"issue_activity_kind"`
+
+	Text     string          `db:"issue_activity_text"`
+	Payload  json.RawMessage `db:"issue_activity_payload"`
+	Metadata json.RawMessage `db:"issue_activity_metadata"`
+}
+
+const (
+	issueActivityColumns = `
+		 issue_activity_id
+		,issue_activity_version
+		,issue_activity_created_by
+		,issue_activity_created
+		,issue_activity_updated
+		,issue_activity_edited
+		,issue_activity_deleted
+		,issue_activity_parent_id
+		,issue_activity_repo_id
+		,issue_activity_issue_id
+		,issue_activity_order
+		,issue_activity_sub_order
+		,issue_activity_reply_seq
+		,issue_activity_type
+		,issue_activity_kind
+		,issue_activity_text
+		,issue_activity_payload
+		,issue_activity_metadata`
+
+	issueActivitySelectBase = `
+	SELECT` + issueActivityColumns + `
+	FROM issue_activities`
+)
+
+// Find finds the issue activity by id.
+func (s *IssueActivityStore) Find(ctx context.Context, id int64) (*types.IssueActivity, error) {
+	const sqlQuery = issueActivitySelectBase + `
+	WHERE issue_activity_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issueActivity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue activity")
+	}
+
+	act, err := s.mapIssueActivity(ctx, dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map issue activity: %w", err)
+	}
+
+	return act, nil
+}
+
+// Create creates a new issue activity.
+func (s *IssueActivityStore) Create(ctx context.Context, act *types.IssueActivity) error {
+	const sqlQuery = `
+	INSERT INTO issue_activities (
+		 issue_activity_version
+		,issue_activity_created_by
+		,issue_activity_created
+		,issue_activity_updated
+		,issue_activity_edited
+		,issue_activity_deleted
+		,issue_activity_parent_id
+		,issue_activity_repo_id
+		,issue_activity_issue_id
+		,issue_activity_order
+		,issue_activity_sub_order
+		,issue_activity_reply_seq
+		,issue_activity_type
+		,issue_activity_kind
+		,issue_activity_text
+		,issue_activity_payload
+		,issue_activity_metadata
+	) values (
+		 :issue_activity_version
+		,:issue_activity_created_by
+		,:issue_activity_created
+		,:issue_activity_updated
+		,:issue_activity_edited
+		,:issue_activity_deleted
+		,:issue_activity_parent_id
+		,:issue_activity_repo_id
+		,:issue_activity_issue_id
+		,:issue_activity_order
+		,:issue_activity_sub_order
+		,:issue_activity_reply_seq
+		,:issue_activity_type
+		,:issue_activity_kind
+		,:issue_activity_text
+		,:issue_activity_payload
+		,:issue_activity_metadata
+	) RETURNING issue_activity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbAct, err := mapInternalIssueActivity(act)
+	if err != nil {
+		return fmt.Errorf("failed to map issue activity: %w", err)
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbAct)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue activity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&act.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue activity")
+	}
+
+	return nil
+}
+
+// CreateWithPayload creates a new system issue activity with the provided payload.
+func (s *IssueActivityStore) CreateWithPayload(
+	ctx context.Context,
+	issue *types.Issue,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+	metadata *types.PullReqActivityMetadata,
+) (*types.IssueActivity, error) {
+	now := time.Now().UnixMilli()
+	act := &types.IssueActivity{
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+		Edited:    now,
+		RepoID:    issue.RepoID,
+		IssueID:   issue.ID,
+		Order:     issue.ActivitySeq,
+		SubOrder:  0,
+		ReplySeq:  0,
+		Type:      payload.ActivityType(),
+		Kind:      enum.PullReqActivityKindSystem,
+		Text:      "",
+		Metadata:  metadata,
+	}
+
+	_ = act.SetPayload(payload)
+
+	err := s.Create(ctx, act)
+	if err != nil {
+		err = fmt.Errorf("failed to write issue system '%s' activity: %w", payload.ActivityType(), err)
+		return nil, err
+	}
+
+	return act, nil
+}
+
+// Update updates the issue activity.
+func (s *IssueActivityStore) Update(ctx context.Context, act *types.IssueActivity) error {
+	const sqlQuery = `
+	UPDATE issue_activities
+	SET
+		 issue_activity_version = :issue_activity_version
+		,issue_activity_updated = :issue_activity_updated
+		,issue_activity_edited = :issue_activity_edited
+		,issue_activity_deleted = :issue_activity_deleted
+		,issue_activity_reply_seq = :issue_activity_reply_seq
+		,issue_activity_text = :issue_activity_text
+		,issue_activity_payload = :issue_activity_payload
+		,issue_activity_metadata = :issue_activity_metadata
+	WHERE issue_activity_id = :issue_activity_id AND issue_activity_version = :issue_activity_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbAct, err := mapInternalIssueActivity(act)
+	if err != nil {
+		return fmt.Errorf("failed to map issue activity: %w", err)
+	}
+	dbAct.Version++
+	dbAct.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbAct)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue activity object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update issue activity")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	updatedAct, err := s.mapIssueActivity(ctx, dbAct)
+	if err != nil {
+		return fmt.Errorf("failed to map db issue activity: %w", err)
+	}
+	*act = *updatedAct
+
+	return nil
+}
+
+// UpdateOptLock updates the issue activity using the optimistic locking mechanism.
+func (s *IssueActivityStore) UpdateOptLock(ctx context.Context,
+	act *types.IssueActivity,
+	mutateFn func(act *types.IssueActivity) error,
+) (*types.IssueActivity, error) {
+	for {
+		dup := *act
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		act, err = s.Find(ctx, act.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Count of issue activities of an issue.
+func (s *IssueActivityStore) Count(ctx context.Context,
+	issueID int64,
+	opts *types.PullReqActivityFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("issue_activities").
+		Where("issue_activity_issue_id = ?", issueID)
+
+	stmt = applyIssueActivityFilter(&types.PullReqActivityFilter{
+		After:  opts.After,
+		Before: opts.Before,
+		Types:  opts.Types,
+		Kinds:  opts.Kinds,
+	}, stmt)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of issue activities of an issue.
+func (s *IssueActivityStore) List(ctx context.Context,
+	issueID int64,
+	filter *types.PullReqActivityFilter,
+) ([]*types.IssueActivity, error) {
+	stmt := database.Builder.
+		Select(issueActivityColumns).
+		From("issue_activities").
+		Where("issue_activity_issue_id = ?", issueID)
+
+	stmt = applyIssueActivityFilter(filter, stmt)
+
+	stmt = stmt.OrderBy("issue_activity_order asc", "issue_activity_sub_order asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert issue activity query to sql")
+	}
+
+	dst := make([]*issueActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing issue activity list query")
+	}
+
+	return s.mapSliceIssueActivity(ctx, dst)
+}
+
+func mapIssueActivity(act *issueActivity) (*types.IssueActivity, error) {
+	metadata := &types.PullReqActivityMetadata{}
+	err := json.Unmarshal(act.Metadata, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize metadata: %w", err)
+	}
+
+	return &types.IssueActivity{
+		ID:         act.ID,
+		Version:    act.Version,
+		CreatedBy:  act.CreatedBy,
+		Created:    act.Created,
+		Updated:    act.Updated,
+		Edited:     act.Edited,
+		Deleted:    act.Deleted.Ptr(),
+		ParentID:   act.ParentID.Ptr(),
+		RepoID:     act.RepoID,
+		IssueID:    act.IssueID,
+		Order:      act.Order,
+		SubOrder:   act.SubOrder,
+		ReplySeq:   act.ReplySeq,
+		Type:       act.Type,
+		Kind:       act.Kind,
+		Text:       act.Text,
+		PayloadRaw: act.Payload,
+		Metadata:   metadata,
+		Author:     types.PrincipalInfo{},
+	}, nil
+}
+
+func mapInternalIssueActivity(act *types.IssueActivity) (*issueActivity, error) {
+	m := &issueActivity{
+		ID:        act.ID,
+		Version:   act.Version,
+		CreatedBy: act.CreatedBy,
+		Created:   act.Created,
+		Updated:   act.Updated,
+		Edited:    act.Edited,
+		Deleted:   null.IntFromPtr(act.Deleted),
+		ParentID:  null.IntFromPtr(act.ParentID),
+		RepoID:    act.RepoID,
+		IssueID:   act.IssueID,
+		Order:     act.Order,
+		SubOrder:  act.SubOrder,
+		ReplySeq:  act.ReplySeq,
+		Type:      act.Type,
+		Kind:      act.Kind,
+		Text:      act.Text,
+		Payload:   act.PayloadRaw,
+	}
+
+	var err error
+	m.Metadata, err = json.Marshal(act.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	return m, nil
+}
+
+func (s *IssueActivityStore) mapIssueActivity(
+	ctx context.Context,
+	act *issueActivity,
+) (*types.IssueActivity, error) {
+	m, err := mapIssueActivity(act)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.pCache.Get(ctx, act.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load issue activity author")
+	}
+	if author != nil {
+		m.Author = *author
+	}
+
+	return m, nil
+}
+
+func (s *IssueActivityStore) mapSliceIssueActivity(
+	ctx context.Context,
+	activities []*issueActivity,
+) ([]*types.IssueActivity, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, len(activities))
+	for _, act := range activities {
+		ids = append(ids, act.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue activity principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.IssueActivity, len(activities))
+	for i, act := range activities {
+		m[i], err = mapIssueActivity(act)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map issue activity %d: %w", act.ID, err)
+		}
+		if author, ok := infoMap[act.CreatedBy]; ok {
+			m[i].Author = *author
+		}
+	}
+
+	return m, nil
+}
+
+func applyIssueActivityFilter(
+	filter *types.PullReqActivityFilter,
+	stmt squirrel.SelectBuilder,
+) squirrel.SelectBuilder {
+	if len(filter.Types) == 1 {
+		stmt = stmt.Where("issue_activity_type = ?", filter.Types[0])
+	} else if len(filter.Types) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"issue_activity_type": filter.Types})
+	}
+
+	if len(filter.Kinds) == 1 {
+		stmt = stmt.Where("issue_activity_kind = ?", filter.Kinds[0])
+	} else if len(filter.Kinds) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"issue_activity_kind": filter.Kinds})
+	}
+
+	if filter.After != 0 {
+		stmt = stmt.Where("issue_activity_created > ?", filter.After)
+	}
+
+	if filter.Before != 0 {
+		stmt = stmt.Where("issue_activity_created < ?", filter.Before)
+	}
+
+	if filter.Limit > 0 {
+		stmt = stmt.Limit(database.Limit(filter.Limit))
+	}
+
+	return stmt
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.IssueAssigneeStore = (*IssueAssigneeStore)(nil)
+
+// NewIssueAssigneeStore returns a new IssueAssigneeStore.
+func NewIssueAssigneeStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *IssueAssigneeStore {
+	return &IssueAssigneeStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueAssigneeStore implements store.IssueAssigneeStore backed by a relational database.
+type IssueAssigneeStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type issueAssignee struct {
+	IssueID     int64 `db:"issue_assignee_issue_id"`
+	PrincipalID int64 `db:"issue_assignee_principal_id"`
+	Created     int64 `db:"issue_assignee_created"`
+	CreatedBy   int64 `db:"issue_assignee_created_by"`
+}
+
+const (
+	issueAssigneeColumns = `
+		 issue_assignee_issue_id
+		,issue_assignee_principal_id
+		,issue_assignee_created
+		,issue_assignee_created_by`
+)
+
+// Create adds a new assignee to an issue. Adding an existing assignee is a no-op.
+func (s *IssueAssigneeStore) Create(ctx context.Context, v *types.IssueAssignee) error {
+	const sqlQuery = `
+	INSERT INTO issue_assignees (` + issueAssigneeColumns + `
+	) values (
+		 :issue_assignee_issue_id
+		,:issue_assignee_principal_id
+		,:issue_assignee_created
+		,:issue_assignee_created_by
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &issueAssignee{
+		IssueID:     v.IssueID,
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		CreatedBy:   v.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue assignee object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue assignee")
+	}
+
+	return nil
+}
+
+// Delete removes an assignee from an issue.
+func (s *IssueAssigneeStore) Delete(ctx context.Context, issueID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM issue_assignees
+	WHERE issue_assignee_issue_id = $1 AND issue_assignee_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, issueID, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete issue assignee")
+	}
+
+	return nil
+}
+
+// List returns the assignees of an issue.
+func (s *IssueAssigneeStore) List(ctx context.Context, issueID int64) ([]*types.IssueAssignee, error) {
+	dst, err := s.list(ctx, []int64{issueID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(dst))
+	for i, v := range dst {
+		ids[i] = v.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue assignee principal infos: %w", err)
+	}
+
+	result := make([]*types.IssueAssignee, len(dst))
+	for i, v := range dst {
+		result[i] = &types.IssueAssignee{
+			IssueID:     v.IssueID,
+			PrincipalID: v.PrincipalID,
+			Created:     v.Created,
+			CreatedBy:   v.CreatedBy,
+		}
+		if info, ok := infoMap[v.PrincipalID]; ok {
+			result[i].Assignee = *info
+		}
+	}
+
+	return result, nil
+}
+
+// ListByIssueIDs returns the assignees of the specified issues.
+func (s *IssueAssigneeStore) ListByIssueIDs(
+	ctx context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.PrincipalInfo, error) {
+	dst, err := s.list(ctx, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(dst))
+	for i, v := range dst {
+		ids[i] = v.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue assignee principal infos: %w", err)
+	}
+
+	result := make(map[int64][]*types.PrincipalInfo)
+	for _, v := range dst {
+		if info, ok := infoMap[v.PrincipalID]; ok {
+			result[v.IssueID] = append(result[v.IssueID], info)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *IssueAssigneeStore) list(ctx context.Context, issueIDs []int64) ([]*issueAssignee, error) {
+	stmt := database.Builder.
+		Select(issueAssigneeColumns).
+		From("issue_assignees").
+		Where(squirrel.Eq{"issue_assignee_issue_id": issueIDs}).
+		OrderBy("issue_assignee_created asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*issueAssignee, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing issue assignee list query")
+	}
+
+	return dst, nil
+}
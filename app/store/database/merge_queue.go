@@ -0,0 +1,385 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID                 int64                     `db:"merge_queue_entry_id"`
+	Version            int64                     `db:"merge_queue_entry_version"`
+	Created            int64                     `db:"merge_queue_entry_created"`
+	Updated            int64                     `db:"merge_queue_entry_updated"`
+	CreatedBy          int64                     `db:"merge_queue_entry_created_by"`
+	RepoID             int64                     `db:"merge_queue_entry_repo_id"`
+	PullReqID          int64                     `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber      int64                     `db:"merge_queue_entry_pullreq_number"`
+	TargetBranch       string                    `db:"merge_queue_entry_target_branch"`
+	State              enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	Method             enum.MergeMethod          `db:"merge_queue_entry_method"`
+	Title              string                    `db:"merge_queue_entry_title"`
+	Message            string                    `db:"merge_queue_entry_message"`
+	DeleteSourceBranch bool                      `db:"merge_queue_entry_delete_source_branch"`
+	RulesBypassed      bool                      `db:"merge_queue_entry_rules_bypassed"`
+	SourceSHA          string                    `db:"merge_queue_entry_source_sha"`
+	BaseSHA            string                    `db:"merge_queue_entry_base_sha"`
+	MergeBaseSHA       string                    `db:"merge_queue_entry_merge_base_sha"`
+	GroupSHA           string                    `db:"merge_queue_entry_group_sha"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_version
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_created_by
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_pullreq_number
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_state
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_delete_source_branch
+		,merge_queue_entry_rules_bypassed
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_base_sha
+		,merge_queue_entry_group_sha`
+)
+
+// Find finds the merge queue entry by ID.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_pullreq_id = ?", pullreqID)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByGroupSHA finds the merge queue entry by the SHA of its merge group commit.
+func (s *MergeQueueStore) FindByGroupSHA(
+	ctx context.Context,
+	repoID int64,
+	groupSHA string,
+) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ? AND merge_queue_entry_group_sha = ?", repoID, groupSHA).
+		OrderBy("merge_queue_entry_id").
+		Limit(1)
+
+	return s.find(ctx, stmt)
+}
+
+func (s *MergeQueueStore) find(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) (*types.MergeQueueEntry, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// Create adds a new pull request to the end of the merge queue.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_version
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+			,merge_queue_entry_created_by
+			,merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_pullreq_number
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_state
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_delete_source_branch
+			,merge_queue_entry_rules_bypassed
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha
+			,merge_queue_entry_group_sha
+		) values (
+			 :merge_queue_entry_version
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_pullreq_number
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_state
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_delete_source_branch
+			,:merge_queue_entry_rules_bypassed
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_base_sha
+			,:merge_queue_entry_group_sha
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert merge queue entry")
+	}
+
+	return nil
+}
+
+// Update updates the merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_version = :merge_queue_entry_version
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+			,merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_source_sha = :merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha = :merge_queue_entry_merge_base_sha
+			,merge_queue_entry_group_sha = :merge_queue_entry_group_sha
+		WHERE merge_queue_entry_id = :merge_queue_entry_id AND
+			merge_queue_entry_version = :merge_queue_entry_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	updated := time.Now().UnixMilli()
+
+	dbEntry := mapInternalMergeQueueEntry(entry)
+	dbEntry.Version++
+	dbEntry.Updated = updated
+
+	query, args, err := db.BindNamed(sqlQuery, dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	entry.Version = dbEntry.Version
+	entry.Updated = dbEntry.Updated
+
+	return nil
+}
+
+// Delete removes the merge queue entry.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete merge queue entry")
+	}
+
+	return nil
+}
+
+// ListByBranch returns all entries of the merge queue of a branch in the queue order.
+func (s *MergeQueueStore) ListByBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ? AND merge_queue_entry_target_branch = ?", repoID, branch).
+		OrderBy("merge_queue_entry_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	return mapMergeQueueEntries(dst), nil
+}
+
+// ListQueues returns all branches that have a non-empty merge queue.
+func (s *MergeQueueStore) ListQueues(ctx context.Context) ([]types.MergeQueue, error) {
+	stmt := database.Builder.
+		Select("merge_queue_entry_repo_id, merge_queue_entry_target_branch").
+		From("merge_queue_entries").
+		GroupBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch").
+		OrderBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queues")
+	}
+	defer rows.Close()
+
+	var queues []types.MergeQueue
+	for rows.Next() {
+		var q types.MergeQueue
+		if err := rows.Scan(&q.RepoID, &q.Branch); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan merge queue")
+		}
+		queues = append(queues, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queues")
+	}
+
+	return queues, nil
+}
+
+func mapInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:                 entry.ID,
+		Version:            entry.Version,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+		CreatedBy:          entry.CreatedBy,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		RulesBypassed:      entry.RulesBypassed,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeBaseSHA:       entry.MergeBaseSHA,
+		GroupSHA:           entry.GroupSHA,
+	}
+}
+
+func mapMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:                 entry.ID,
+		Version:            entry.Version,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+		CreatedBy:          entry.CreatedBy,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		RulesBypassed:      entry.RulesBypassed,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeBaseSHA:       entry.MergeBaseSHA,
+		GroupSHA:           entry.GroupSHA,
+	}
+}
+
+func mapMergeQueueEntries(entries []*mergeQueueEntry) []*types.MergeQueueEntry {
+	res := make([]*types.MergeQueueEntry, len(entries))
+	for i := range entries {
+		res[i] = mapMergeQueueEntry(entries[i])
+	}
+	return res
+}
@@ -0,0 +1,5 @@
+DROP INDEX merge_queue_entries_group_sha;
+DROP INDEX merge_queue_entries_repo_id_target_branch;
+DROP INDEX merge_queue_entries_pullreq_id;
+
+DROP TABLE IF EXISTS merge_queue_entries;
@@ -0,0 +1,166 @@
+ALTER TABLE repositories ADD COLUMN repo_issue_seq INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE milestones (
+     milestone_id SERIAL PRIMARY KEY
+    ,milestone_version INTEGER NOT NULL
+    ,milestone_repo_id INTEGER NOT NULL
+    ,milestone_title TEXT NOT NULL
+    ,milestone_description TEXT NOT NULL
+    ,milestone_state TEXT NOT NULL
+    ,milestone_due BIGINT
+    ,milestone_closed BIGINT
+    ,milestone_created BIGINT NOT NULL
+    ,milestone_updated BIGINT NOT NULL
+    ,milestone_created_by INTEGER NOT NULL
+    ,milestone_updated_by INTEGER NOT NULL
+    ,CONSTRAINT fk_milestone_repo_id FOREIGN KEY (milestone_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_milestone_created_by FOREIGN KEY (milestone_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,CONSTRAINT fk_milestone_updated_by FOREIGN KEY (milestone_updated_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX milestones_repo_id_title
+    ON milestones(milestone_repo_id, LOWER(milestone_title));
+
+CREATE TABLE issues (
+     issue_id SERIAL PRIMARY KEY
+    ,issue_version INTEGER NOT NULL
+    ,issue_repo_id INTEGER NOT NULL
+    ,issue_number INTEGER NOT NULL
+    ,issue_created_by INTEGER NOT NULL
+    ,issue_created BIGINT NOT NULL
+    ,issue_updated BIGINT NOT NULL
+    ,issue_edited BIGINT NOT NULL
+    ,issue_closed BIGINT
+    ,issue_closed_by INTEGER
+    ,issue_state TEXT NOT NULL
+    ,issue_title TEXT NOT NULL
+    ,issue_description TEXT NOT NULL
+    ,issue_milestone_id INTEGER
+    ,issue_activity_seq INTEGER NOT NULL DEFAULT 0
+    ,issue_comment_count INTEGER NOT NULL DEFAULT 0
+    ,CONSTRAINT fk_issue_repo_id FOREIGN KEY (issue_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_created_by FOREIGN KEY (issue_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,CONSTRAINT fk_issue_closed_by FOREIGN KEY (issue_closed_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,CONSTRAINT fk_issue_milestone_id FOREIGN KEY (issue_milestone_id)
+        REFERENCES milestones (milestone_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX issues_repo_id_number
+    ON issues(issue_repo_id, issue_number);
+
+CREATE INDEX issues_milestone_id
+    ON issues(issue_milestone_id);
+
+CREATE TABLE issue_assignees (
+     issue_assignee_issue_id INTEGER NOT NULL
+    ,issue_assignee_principal_id INTEGER NOT NULL
+    ,issue_assignee_created BIGINT NOT NULL
+    ,issue_assignee_created_by INTEGER NOT NULL
+    ,CONSTRAINT fk_issue_assignee_issue_id FOREIGN KEY (issue_assignee_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_assignee_principal_id FOREIGN KEY (issue_assignee_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_assignee_created_by FOREIGN KEY (issue_assignee_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,PRIMARY KEY (issue_assignee_issue_id, issue_assignee_principal_id)
+);
+
+CREATE INDEX issue_assignees_principal_id
+    ON issue_assignees(issue_assignee_principal_id);
+
+CREATE TABLE issue_labels (
+     issue_label_issue_id INTEGER NOT NULL
+    ,issue_label_label_id INTEGER NOT NULL
+    ,issue_label_label_value_id INTEGER DEFAULT NULL
+    ,issue_label_created BIGINT NOT NULL
+    ,issue_label_updated BIGINT NOT NULL
+    ,issue_label_created_by INTEGER NOT NULL
+    ,issue_label_updated_by INTEGER NOT NULL
+    ,CONSTRAINT fk_issue_label_issue_id FOREIGN KEY (issue_label_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_label_label_id FOREIGN KEY (issue_label_label_id)
+        REFERENCES labels (label_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_label_label_value_id FOREIGN KEY (issue_label_label_value_id)
+        REFERENCES label_values (label_value_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL
+    ,CONSTRAINT fk_issue_label_created_by FOREIGN KEY (issue_label_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,CONSTRAINT fk_issue_label_updated_by FOREIGN KEY (issue_label_updated_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,PRIMARY KEY (issue_label_issue_id, issue_label_label_id)
+);
+
+CREATE TABLE issue_activities (
+     issue_activity_id SERIAL PRIMARY KEY
+    ,issue_activity_version INTEGER NOT NULL
+    ,issue_activity_created_by INTEGER NOT NULL
+    ,issue_activity_created BIGINT NOT NULL
+    ,issue_activity_updated BIGINT NOT NULL
+    ,issue_activity_edited BIGINT NOT NULL
+    ,issue_activity_deleted BIGINT
+    ,issue_activity_parent_id INTEGER
+    ,issue_activity_repo_id INTEGER NOT NULL
+    ,issue_activity_issue_id INTEGER NOT NULL
+    ,issue_activity_order INTEGER NOT NULL
+    ,issue_activity_sub_order INTEGER NOT NULL
+    ,issue_activity_reply_seq INTEGER NOT NULL
+    ,issue_activity_type TEXT NOT NULL
+    ,issue_activity_kind TEXT NOT NULL
+    ,issue_activity_text TEXT NOT NULL
+    ,issue_activity_payload TEXT NOT NULL DEFAULT '{}'
+    ,issue_activity_metadata TEXT NOT NULL DEFAULT '{}'
+    ,CONSTRAINT fk_issue_activity_created_by FOREIGN KEY (issue_activity_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+    ,CONSTRAINT fk_issue_activity_parent_id FOREIGN KEY (issue_activity_parent_id)
+        REFERENCES issue_activities (issue_activity_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_activity_repo_id FOREIGN KEY (issue_activity_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_issue_activity_issue_id FOREIGN KEY (issue_activity_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX issue_activities_issue_id_order_sub_order
+    ON issue_activities(issue_activity_issue_id, issue_activity_order, issue_activity_sub_order);
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN webhook_consecutive_failures;
+ALTER TABLE webhooks DROP COLUMN webhook_retry_policy;
@@ -0,0 +1,31 @@
+CREATE TABLE notification_channels (
+    notification_channel_id SERIAL PRIMARY KEY,
+    notification_channel_version INTEGER NOT NULL DEFAULT 0,
+    notification_channel_space_id INTEGER DEFAULT NULL,
+    notification_channel_repo_id INTEGER DEFAULT NULL,
+    notification_channel_uid TEXT NOT NULL,
+    notification_channel_display_name TEXT NOT NULL,
+    notification_channel_type TEXT NOT NULL,
+    notification_channel_url TEXT NOT NULL,
+    notification_channel_enabled BOOLEAN NOT NULL,
+    notification_channel_created BIGINT NOT NULL,
+    notification_channel_updated BIGINT NOT NULL,
+    notification_channel_created_by INTEGER NOT NULL,
+
+    CONSTRAINT fk_notification_channels_space_id FOREIGN KEY (notification_channel_space_id)
+        REFERENCES spaces (space_id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_channels_repo_id FOREIGN KEY (notification_channel_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE,
+    CONSTRAINT chk_notification_channel_space_or_repo
+        CHECK (notification_channel_space_id IS NULL OR notification_channel_repo_id IS NULL),
+    CONSTRAINT fk_notification_channels_created_by FOREIGN KEY (notification_channel_created_by)
+        REFERENCES principals (principal_id)
+);
+
+CREATE UNIQUE INDEX notification_channels_space_id_uid
+ON notification_channels(notification_channel_space_id, LOWER(notification_channel_uid))
+WHERE notification_channel_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX notification_channels_repo_id_uid
+ON notification_channels(notification_channel_repo_id, LOWER(notification_channel_uid))
+WHERE notification_channel_repo_id IS NOT NULL;
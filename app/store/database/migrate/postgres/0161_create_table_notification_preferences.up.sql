@@ -0,0 +1,53 @@
+CREATE TABLE notification_preferences (
+    notification_preference_principal_id INTEGER PRIMARY KEY,
+    notification_preference_disabled_events TEXT NOT NULL DEFAULT '[]',
+    notification_preference_delivery TEXT NOT NULL,
+    notification_preference_created BIGINT NOT NULL,
+    notification_preference_updated BIGINT NOT NULL,
+
+    CONSTRAINT fk_notification_preferences_principal_id FOREIGN KEY (notification_preference_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE
+);
+
+CREATE TABLE notification_scope_preferences (
+    notification_scope_preference_id SERIAL PRIMARY KEY,
+    notification_scope_preference_principal_id INTEGER NOT NULL,
+    notification_scope_preference_space_id INTEGER DEFAULT NULL,
+    notification_scope_preference_repo_id INTEGER DEFAULT NULL,
+    notification_scope_preference_mode TEXT NOT NULL,
+    notification_scope_preference_created BIGINT NOT NULL,
+    notification_scope_preference_updated BIGINT NOT NULL,
+
+    CONSTRAINT fk_notification_scope_preferences_principal_id FOREIGN KEY (notification_scope_preference_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_scope_preferences_space_id FOREIGN KEY (notification_scope_preference_space_id)
+        REFERENCES spaces (space_id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_scope_preferences_repo_id FOREIGN KEY (notification_scope_preference_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE,
+    CONSTRAINT chk_notification_scope_preference_space_or_repo
+        CHECK ((notification_scope_preference_space_id IS NULL) <> (notification_scope_preference_repo_id IS NULL))
+);
+
+CREATE UNIQUE INDEX notification_scope_preferences_principal_id_space_id
+ON notification_scope_preferences(notification_scope_preference_principal_id, notification_scope_preference_space_id)
+WHERE notification_scope_preference_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX notification_scope_preferences_principal_id_repo_id
+ON notification_scope_preferences(notification_scope_preference_principal_id, notification_scope_preference_repo_id)
+WHERE notification_scope_preference_repo_id IS NOT NULL;
+
+CREATE TABLE notification_digest_items (
+    notification_digest_item_id SERIAL PRIMARY KEY,
+    notification_digest_item_principal_id INTEGER NOT NULL,
+    notification_digest_item_delivery TEXT NOT NULL,
+    notification_digest_item_event TEXT NOT NULL,
+    notification_digest_item_subject TEXT NOT NULL,
+    notification_digest_item_body TEXT NOT NULL,
+    notification_digest_item_created BIGINT NOT NULL,
+
+    CONSTRAINT fk_notification_digest_items_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_principal_id_delivery
+ON notification_digest_items(notification_digest_item_principal_id, notification_digest_item_delivery);
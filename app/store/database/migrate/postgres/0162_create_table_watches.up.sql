@@ -0,0 +1,34 @@
+CREATE TABLE watches (
+    watch_id SERIAL PRIMARY KEY,
+    watch_principal_id INTEGER NOT NULL,
+    watch_space_id INTEGER DEFAULT NULL,
+    watch_repo_id INTEGER DEFAULT NULL,
+    watch_level TEXT NOT NULL,
+    watch_created BIGINT NOT NULL,
+    watch_updated BIGINT NOT NULL,
+
+    CONSTRAINT fk_watches_principal_id FOREIGN KEY (watch_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE,
+    CONSTRAINT fk_watches_space_id FOREIGN KEY (watch_space_id)
+        REFERENCES spaces (space_id) ON DELETE CASCADE,
+    CONSTRAINT fk_watches_repo_id FOREIGN KEY (watch_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE,
+    CONSTRAINT chk_watch_space_or_repo
+        CHECK ((watch_space_id IS NULL) <> (watch_repo_id IS NULL))
+);
+
+CREATE UNIQUE INDEX watches_principal_id_space_id
+ON watches(watch_principal_id, watch_space_id)
+WHERE watch_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX watches_principal_id_repo_id
+ON watches(watch_principal_id, watch_repo_id)
+WHERE watch_repo_id IS NOT NULL;
+
+CREATE INDEX watches_space_id
+ON watches(watch_space_id)
+WHERE watch_space_id IS NOT NULL;
+
+CREATE INDEX watches_repo_id
+ON watches(watch_repo_id)
+WHERE watch_repo_id IS NOT NULL;
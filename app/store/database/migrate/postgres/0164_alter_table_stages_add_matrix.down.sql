@@ -0,0 +1,4 @@
+ALTER TABLE stages DROP COLUMN stage_max_parallel;
+ALTER TABLE stages DROP COLUMN stage_fail_fast;
+ALTER TABLE stages DROP COLUMN stage_envs;
+ALTER TABLE stages DROP COLUMN stage_matrix;
@@ -0,0 +1,17 @@
+CREATE TABLE pipeline_artifacts (
+    partifact_id SERIAL PRIMARY KEY,
+    partifact_repo_id INTEGER NOT NULL,
+    partifact_pipeline_id INTEGER NOT NULL,
+    partifact_execution_id INTEGER NOT NULL,
+    partifact_name TEXT NOT NULL,
+    partifact_size BIGINT NOT NULL,
+    partifact_created_by INTEGER NOT NULL,
+    partifact_created BIGINT NOT NULL,
+    partifact_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_artifacts_execution_id_name
+ON pipeline_artifacts(partifact_execution_id, partifact_name);
+
+CREATE INDEX pipeline_artifacts_updated
+ON pipeline_artifacts(partifact_updated);
@@ -0,0 +1,16 @@
+CREATE TABLE pipeline_caches (
+    pcache_id SERIAL PRIMARY KEY,
+    pcache_repo_id INTEGER NOT NULL,
+    pcache_pipeline_id INTEGER NOT NULL,
+    pcache_key TEXT NOT NULL,
+    pcache_size BIGINT NOT NULL,
+    pcache_created BIGINT NOT NULL,
+    pcache_updated BIGINT NOT NULL,
+    pcache_last_used BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_caches_pipeline_id_key
+ON pipeline_caches(pcache_pipeline_id, pcache_key);
+
+CREATE INDEX pipeline_caches_last_used
+ON pipeline_caches(pcache_last_used);
@@ -0,0 +1 @@
+DROP TABLE template_versions;
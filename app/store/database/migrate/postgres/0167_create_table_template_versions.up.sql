@@ -0,0 +1,14 @@
+CREATE TABLE template_versions (
+    tversion_id SERIAL PRIMARY KEY,
+    tversion_template_id INTEGER NOT NULL,
+    tversion_version TEXT NOT NULL,
+    tversion_data TEXT NOT NULL,
+    tversion_inputs TEXT NOT NULL,
+    tversion_created_by INTEGER NOT NULL,
+    tversion_created BIGINT NOT NULL,
+    CONSTRAINT fk_tversion_template_id FOREIGN KEY (tversion_template_id)
+        REFERENCES templates (template_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX template_versions_template_id_version
+ON template_versions(tversion_template_id, tversion_version);
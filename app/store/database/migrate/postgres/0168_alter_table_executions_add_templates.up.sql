@@ -0,0 +1 @@
+ALTER TABLE executions ADD COLUMN execution_templates TEXT NOT NULL DEFAULT '[]';
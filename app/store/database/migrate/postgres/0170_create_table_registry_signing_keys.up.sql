@@ -0,0 +1,13 @@
+CREATE TABLE registry_signing_keys (
+    rsk_registry_id INTEGER NOT NULL,
+    rsk_key_type TEXT NOT NULL,
+    rsk_public_key TEXT NOT NULL,
+    rsk_private_key BYTEA NOT NULL,
+    rsk_fingerprint TEXT NOT NULL,
+    rsk_created_at BIGINT NOT NULL,
+    CONSTRAINT pk_registry_signing_keys PRIMARY KEY (rsk_registry_id, rsk_key_type),
+    CONSTRAINT fk_registry_signing_keys_registry_id FOREIGN KEY (rsk_registry_id)
+        REFERENCES registries (registry_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE pipeline_artifacts ADD COLUMN partifact_blob_path TEXT NOT NULL DEFAULT '';
+ALTER TABLE pipeline_caches ADD COLUMN pcache_blob_path TEXT NOT NULL DEFAULT '';
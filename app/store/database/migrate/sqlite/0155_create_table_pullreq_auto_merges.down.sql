@@ -0,0 +1,3 @@
+DROP INDEX pullreq_auto_merges_repo_id;
+
+DROP TABLE IF EXISTS pullreq_auto_merges;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS issue_activities;
+DROP TABLE IF EXISTS issue_labels;
+DROP TABLE IF EXISTS issue_assignees;
+DROP TABLE IF EXISTS issues;
+DROP TABLE IF EXISTS milestones;
+
+ALTER TABLE repositories DROP COLUMN repo_issue_seq;
@@ -0,0 +1,8 @@
+DROP INDEX triggers_cron_next_run;
+
+ALTER TABLE triggers DROP COLUMN trigger_cron;
+ALTER TABLE triggers DROP COLUMN trigger_timezone;
+ALTER TABLE triggers DROP COLUMN trigger_branch;
+ALTER TABLE triggers DROP COLUMN trigger_catch_up;
+ALTER TABLE triggers DROP COLUMN trigger_last_run;
+ALTER TABLE triggers DROP COLUMN trigger_next_run;
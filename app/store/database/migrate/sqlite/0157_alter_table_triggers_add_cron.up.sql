@@ -0,0 +1,10 @@
+ALTER TABLE triggers ADD COLUMN trigger_cron TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_branch TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_catch_up TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_last_run BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE triggers ADD COLUMN trigger_next_run BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX triggers_cron_next_run
+    ON triggers(trigger_next_run)
+    WHERE trigger_cron <> '';
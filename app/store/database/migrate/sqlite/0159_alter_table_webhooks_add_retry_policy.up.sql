@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN webhook_retry_policy TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE webhooks ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE stages ADD COLUMN stage_matrix TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE stages ADD COLUMN stage_envs TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE stages ADD COLUMN stage_fail_fast BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE stages ADD COLUMN stage_max_parallel INTEGER NOT NULL DEFAULT 0;
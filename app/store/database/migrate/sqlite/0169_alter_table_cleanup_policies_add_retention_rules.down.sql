@@ -0,0 +1,2 @@
+ALTER TABLE cleanup_policies DROP COLUMN cp_downloaded_within_ms;
+ALTER TABLE cleanup_policies DROP COLUMN cp_keep_last;
@@ -0,0 +1,2 @@
+ALTER TABLE pipeline_artifacts DROP COLUMN partifact_blob_path;
+ALTER TABLE pipeline_caches DROP COLUMN pcache_blob_path;
@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationChannelStore = (*NotificationChannelStore)(nil)
+
+// NewNotificationChannelStore returns a new NotificationChannelStore.
+func NewNotificationChannelStore(db *sqlx.DB) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		db: db,
+	}
+}
+
+// NotificationChannelStore implements store.NotificationChannelStore backed by a relational database.
+type NotificationChannelStore struct {
+	db *sqlx.DB
+}
+
+type notificationChannel struct {
+	ID          int64                        `db:"notification_channel_id"`
+	Version     int64                        `db:"notification_channel_version"`
+	SpaceID     null.Int                     `db:"notification_channel_space_id"`
+	RepoID      null.Int                     `db:"notification_channel_repo_id"`
+	Identifier  string                       `db:"notification_channel_uid"`
+	DisplayName string                       `db:"notification_channel_display_name"`
+	Type        enum.NotificationChannelType `db:"notification_channel_type"`
+	URL         string                       `db:"notification_channel_url"`
+	Enabled     bool                         `db:"notification_channel_enabled"`
+	Created     int64                        `db:"notification_channel_created"`
+	Updated     int64                        `db:"notification_channel_updated"`
+	CreatedBy   int64                        `db:"notification_channel_created_by"`
+}
+
+const (
+	notificationChannelColumns = `
+		 notification_channel_id
+		,notification_channel_version
+		,notification_channel_space_id
+		,notification_channel_repo_id
+		,notification_channel_uid
+		,notification_channel_display_name
+		,notification_channel_type
+		,notification_channel_url
+		,notification_channel_enabled
+		,notification_channel_created
+		,notification_channel_updated
+		,notification_channel_created_by`
+
+	notificationChannelSelectBase = `
+	SELECT` + notificationChannelColumns + `
+	FROM notification_channels`
+)
+
+// Find finds the notification channel by id.
+func (s *NotificationChannelStore) Find(ctx context.Context, id int64) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel")
+	}
+
+	return mapNotificationChannel(dst), nil
+}
+
+// FindByIdentifier finds a notification channel defined in a specified space/repo with a specified identifier.
+func (s *NotificationChannelStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE (notification_channel_space_id = $1 OR notification_channel_repo_id = $2) AND
+			LOWER(notification_channel_uid) = LOWER($3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel")
+	}
+
+	return mapNotificationChannel(dst), nil
+}
+
+// Create creates a new notification channel.
+func (s *NotificationChannelStore) Create(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		INSERT INTO notification_channels (
+			 notification_channel_version
+			,notification_channel_space_id
+			,notification_channel_repo_id
+			,notification_channel_uid
+			,notification_channel_display_name
+			,notification_channel_type
+			,notification_channel_url
+			,notification_channel_enabled
+			,notification_channel_created
+			,notification_channel_updated
+			,notification_channel_created_by
+		) values (
+			 :notification_channel_version
+			,:notification_channel_space_id
+			,:notification_channel_repo_id
+			,:notification_channel_uid
+			,:notification_channel_display_name
+			,:notification_channel_type
+			,:notification_channel_url
+			,:notification_channel_enabled
+			,:notification_channel_created
+			,:notification_channel_updated
+			,:notification_channel_created_by
+		) RETURNING notification_channel_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationChannel(channel))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&channel.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create notification channel")
+	}
+
+	return nil
+}
+
+// Update updates an existing notification channel.
+func (s *NotificationChannelStore) Update(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		UPDATE notification_channels
+		SET
+			 notification_channel_version = :notification_channel_version
+			,notification_channel_updated = :notification_channel_updated
+			,notification_channel_uid = :notification_channel_uid
+			,notification_channel_display_name = :notification_channel_display_name
+			,notification_channel_url = :notification_channel_url
+			,notification_channel_enabled = :notification_channel_enabled
+		WHERE notification_channel_id = :notification_channel_id AND
+			notification_channel_version = :notification_channel_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel := mapInternalNotificationChannel(channel)
+	dbChannel.Version++
+	dbChannel.Updated = time.Now().UnixMilli()
+
+	query, args, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification channel")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	channel.Version = dbChannel.Version
+	channel.Updated = dbChannel.Updated
+
+	return nil
+}
+
+// Delete deletes the notification channel with the specified id.
+func (s *NotificationChannelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_channels
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification channel")
+	}
+
+	return nil
+}
+
+// List lists notification channels defined in a specified space/repo.
+func (s *NotificationChannelStore) List(
+	ctx context.Context,
+	spaceID, repoID *int64,
+) ([]*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_space_id = $1 OR notification_channel_repo_id = $2
+		ORDER BY notification_channel_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationChannel
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification channels")
+	}
+
+	return mapNotificationChannels(dst), nil
+}
+
+// ListEnabledInScopes lists enabled notification channels defined in the specified repo/spaces.
+func (s *NotificationChannelStore) ListEnabledInScopes(
+	ctx context.Context,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where(squirrel.Or{
+			squirrel.Eq{"notification_channel_space_id": spaceIDs},
+			squirrel.Eq{"notification_channel_repo_id": repoID},
+		}).
+		Where("notification_channel_enabled = ?", true).
+		OrderBy("notification_channel_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationChannel
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification channels in scopes")
+	}
+
+	return mapNotificationChannels(dst), nil
+}
+
+func mapNotificationChannel(channel *notificationChannel) *types.NotificationChannel {
+	return &types.NotificationChannel{
+		ID:          channel.ID,
+		Version:     channel.Version,
+		SpaceID:     channel.SpaceID.Ptr(),
+		RepoID:      channel.RepoID.Ptr(),
+		Identifier:  channel.Identifier,
+		DisplayName: channel.DisplayName,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		CreatedBy:   channel.CreatedBy,
+	}
+}
+
+func mapInternalNotificationChannel(channel *types.NotificationChannel) *notificationChannel {
+	return &notificationChannel{
+		ID:          channel.ID,
+		Version:     channel.Version,
+		SpaceID:     null.IntFromPtr(channel.SpaceID),
+		RepoID:      null.IntFromPtr(channel.RepoID),
+		Identifier:  channel.Identifier,
+		DisplayName: channel.DisplayName,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		CreatedBy:   channel.CreatedBy,
+	}
+}
+
+func mapNotificationChannels(channels []*notificationChannel) []*types.NotificationChannel {
+	res := make([]*types.NotificationChannel, len(channels))
+	for i := range channels {
+		res[i] = mapNotificationChannel(channels[i])
+	}
+	return res
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64                     `db:"notification_digest_item_id"`
+	PrincipalID int64                     `db:"notification_digest_item_principal_id"`
+	Delivery    enum.NotificationDelivery `db:"notification_digest_item_delivery"`
+	Event       enum.NotificationEvent    `db:"notification_digest_item_event"`
+	Subject     string                    `db:"notification_digest_item_subject"`
+	Body        string                    `db:"notification_digest_item_body"`
+	Created     int64                     `db:"notification_digest_item_created"`
+}
+
+type notificationDigestPending struct {
+	PrincipalID int64                     `db:"notification_digest_item_principal_id"`
+	Delivery    enum.NotificationDelivery `db:"notification_digest_item_delivery"`
+	Oldest      int64                     `db:"oldest"`
+}
+
+const notificationDigestItemColumns = `
+	 notification_digest_item_id
+	,notification_digest_item_principal_id
+	,notification_digest_item_delivery
+	,notification_digest_item_event
+	,notification_digest_item_subject
+	,notification_digest_item_body
+	,notification_digest_item_created`
+
+// Create stores a notification to be sent as part of a digest.
+func (s *NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+		INSERT INTO notification_digest_items (
+			 notification_digest_item_principal_id
+			,notification_digest_item_delivery
+			,notification_digest_item_event
+			,notification_digest_item_subject
+			,notification_digest_item_body
+			,notification_digest_item_created
+		) VALUES (
+			 :notification_digest_item_principal_id
+			,:notification_digest_item_delivery
+			,:notification_digest_item_event
+			,:notification_digest_item_subject
+			,:notification_digest_item_body
+			,:notification_digest_item_created
+		) RETURNING notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, notificationDigestItem(*item))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&item.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create notification digest item")
+	}
+
+	return nil
+}
+
+// ListPending lists principals and delivery modes with notifications waiting to be sent.
+func (s *NotificationDigestStore) ListPending(ctx context.Context) ([]*types.NotificationDigestPending, error) {
+	const sqlQuery = `
+		SELECT
+			 notification_digest_item_principal_id
+			,notification_digest_item_delivery
+			,MIN(notification_digest_item_created) AS oldest
+		FROM notification_digest_items
+		GROUP BY notification_digest_item_principal_id, notification_digest_item_delivery`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestPending
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pending notification digests")
+	}
+
+	res := make([]*types.NotificationDigestPending, len(dst))
+	for i := range dst {
+		res[i] = (*types.NotificationDigestPending)(dst[i])
+	}
+
+	return res, nil
+}
+
+// List lists the pending notifications of the principal for the delivery mode, oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = `
+		SELECT` + notificationDigestItemColumns + `
+		FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1 AND notification_digest_item_delivery = $2
+		ORDER BY notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestItem
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID, delivery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification digest items")
+	}
+
+	res := make([]*types.NotificationDigestItem, len(dst))
+	for i := range dst {
+		res[i] = (*types.NotificationDigestItem)(dst[i])
+	}
+
+	return res, nil
+}
+
+// Delete deletes the pending notifications of the principal for the delivery mode
+// up to and including the notification with the provided id.
+func (s *NotificationDigestStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	maxID int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1 AND
+			notification_digest_item_delivery = $2 AND
+			notification_digest_item_id <= $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, delivery, maxID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification digest items")
+	}
+
+	return nil
+}
@@ -0,0 +1,381 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreferences struct {
+	PrincipalID    int64                     `db:"notification_preference_principal_id"`
+	DisabledEvents sqlxtypes.JSONText        `db:"notification_preference_disabled_events"`
+	Delivery       enum.NotificationDelivery `db:"notification_preference_delivery"`
+	Created        int64                     `db:"notification_preference_created"`
+	Updated        int64                     `db:"notification_preference_updated"`
+}
+
+type notificationScopePreference struct {
+	ID          int64                      `db:"notification_scope_preference_id"`
+	PrincipalID int64                      `db:"notification_scope_preference_principal_id"`
+	SpaceID     null.Int                   `db:"notification_scope_preference_space_id"`
+	RepoID      null.Int                   `db:"notification_scope_preference_repo_id"`
+	Mode        enum.NotificationScopeMode `db:"notification_scope_preference_mode"`
+	Created     int64                      `db:"notification_scope_preference_created"`
+	Updated     int64                      `db:"notification_scope_preference_updated"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_principal_id
+		,notification_preference_disabled_events
+		,notification_preference_delivery
+		,notification_preference_created
+		,notification_preference_updated`
+
+	notificationScopePreferenceColumns = `
+		 notification_scope_preference_id
+		,notification_scope_preference_principal_id
+		,notification_scope_preference_space_id
+		,notification_scope_preference_repo_id
+		,notification_scope_preference_mode
+		,notification_scope_preference_created
+		,notification_scope_preference_updated`
+)
+
+// Find finds the notification preferences of the principal.
+func (s *NotificationPreferenceStore) Find(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationPreferences, error) {
+	const sqlQuery = `
+		SELECT` + notificationPreferenceColumns + `
+		FROM notification_preferences
+		WHERE notification_preference_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationPreferences{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification preferences")
+	}
+
+	return mapNotificationPreferences(dst)
+}
+
+// ListByPrincipals lists the notification preferences of the provided principals.
+func (s *NotificationPreferenceStore) ListByPrincipals(
+	ctx context.Context,
+	principalIDs []int64,
+) ([]*types.NotificationPreferences, error) {
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs})
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreferences
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	res := make([]*types.NotificationPreferences, len(dst))
+	for i := range dst {
+		if res[i], err = mapNotificationPreferences(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Upsert creates or updates the notification preferences of a principal.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, prefs *types.NotificationPreferences) error {
+	const sqlQuery = `
+		INSERT INTO notification_preferences (` + notificationPreferenceColumns + `
+		) VALUES (
+			 :notification_preference_principal_id
+			,:notification_preference_disabled_events
+			,:notification_preference_delivery
+			,:notification_preference_created
+			,:notification_preference_updated
+		)
+		ON CONFLICT (notification_preference_principal_id) DO UPDATE SET
+			 notification_preference_disabled_events = :notification_preference_disabled_events
+			,notification_preference_delivery = :notification_preference_delivery
+			,notification_preference_updated = :notification_preference_updated
+		RETURNING notification_preference_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	now := time.Now().UnixMilli()
+	dbPrefs := mapInternalNotificationPreferences(prefs)
+	dbPrefs.Created = now
+	dbPrefs.Updated = now
+
+	query, args, err := db.BindNamed(sqlQuery, dbPrefs)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preferences object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&prefs.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification preferences")
+	}
+
+	prefs.Updated = now
+
+	return nil
+}
+
+// ListScopes lists the space/repo notification preferences of the principal.
+func (s *NotificationPreferenceStore) ListScopes(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationScopePreference, error) {
+	const sqlQuery = `
+		SELECT` + notificationScopePreferenceColumns + `
+		FROM notification_scope_preferences
+		WHERE notification_scope_preference_principal_id = $1
+		ORDER BY notification_scope_preference_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationScopePreference
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification scope preferences")
+	}
+
+	return mapNotificationScopePreferences(dst), nil
+}
+
+// ListScopesForPrincipals lists the notification preferences of the provided principals
+// defined for the specified repo/spaces.
+func (s *NotificationPreferenceStore) ListScopesForPrincipals(
+	ctx context.Context,
+	principalIDs []int64,
+	repoID *int64,
+	spaceIDs []int64,
+) ([]*types.NotificationScopePreference, error) {
+	scopes := squirrel.Or{
+		squirrel.Eq{"notification_scope_preference_space_id": spaceIDs},
+	}
+	if repoID != nil {
+		scopes = append(scopes, squirrel.Eq{"notification_scope_preference_repo_id": *repoID})
+	}
+
+	stmt := database.Builder.
+		Select(notificationScopePreferenceColumns).
+		From("notification_scope_preferences").
+		Where(squirrel.Eq{"notification_scope_preference_principal_id": principalIDs}).
+		Where(scopes)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationScopePreference
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification scope preferences")
+	}
+
+	return mapNotificationScopePreferences(dst), nil
+}
+
+// UpsertScope creates or updates a space/repo notification preference of a principal.
+func (s *NotificationPreferenceStore) UpsertScope(
+	ctx context.Context,
+	pref *types.NotificationScopePreference,
+) error {
+	const sqlUpdate = `
+		UPDATE notification_scope_preferences
+		SET
+			 notification_scope_preference_mode = :notification_scope_preference_mode
+			,notification_scope_preference_updated = :notification_scope_preference_updated
+		WHERE notification_scope_preference_principal_id = :notification_scope_preference_principal_id AND
+			(notification_scope_preference_space_id = :notification_scope_preference_space_id OR
+			notification_scope_preference_repo_id = :notification_scope_preference_repo_id)
+		RETURNING notification_scope_preference_created`
+
+	const sqlInsert = `
+		INSERT INTO notification_scope_preferences (
+			 notification_scope_preference_principal_id
+			,notification_scope_preference_space_id
+			,notification_scope_preference_repo_id
+			,notification_scope_preference_mode
+			,notification_scope_preference_created
+			,notification_scope_preference_updated
+		) VALUES (
+			 :notification_scope_preference_principal_id
+			,:notification_scope_preference_space_id
+			,:notification_scope_preference_repo_id
+			,:notification_scope_preference_mode
+			,:notification_scope_preference_created
+			,:notification_scope_preference_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	now := time.Now().UnixMilli()
+	dbPref := mapInternalNotificationScopePreference(pref)
+	dbPref.Created = now
+	dbPref.Updated = now
+
+	query, args, err := db.BindNamed(sqlUpdate, dbPref)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification scope preference object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&dbPref.Created)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification scope preference")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		query, args, err = db.BindNamed(sqlInsert, dbPref)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification scope preference object")
+		}
+
+		if _, err = db.ExecContext(ctx, query, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to create notification scope preference")
+		}
+	}
+
+	pref.Created = dbPref.Created
+	pref.Updated = dbPref.Updated
+
+	return nil
+}
+
+// DeleteScope deletes the notification preference of the principal for the specified space/repo.
+func (s *NotificationPreferenceStore) DeleteScope(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM notification_scope_preferences
+		WHERE notification_scope_preference_principal_id = $1 AND
+			(notification_scope_preference_space_id = $2 OR notification_scope_preference_repo_id = $3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, spaceID, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification scope preference")
+	}
+
+	return nil
+}
+
+func mapNotificationPreferences(prefs *notificationPreferences) (*types.NotificationPreferences, error) {
+	res := &types.NotificationPreferences{
+		PrincipalID: prefs.PrincipalID,
+		Delivery:    prefs.Delivery,
+		Created:     prefs.Created,
+		Updated:     prefs.Updated,
+	}
+
+	if len(prefs.DisabledEvents) > 0 {
+		if err := json.Unmarshal(prefs.DisabledEvents, &res.DisabledEvents); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal disabled events of principal %d: %w",
+				prefs.PrincipalID, err)
+		}
+	}
+
+	return res, nil
+}
+
+func mapInternalNotificationPreferences(prefs *types.NotificationPreferences) *notificationPreferences {
+	disabledEvents := prefs.DisabledEvents
+	if disabledEvents == nil {
+		disabledEvents = []enum.NotificationEvent{}
+	}
+
+	return &notificationPreferences{
+		PrincipalID:    prefs.PrincipalID,
+		DisabledEvents: EncodeToSQLXJSON(disabledEvents),
+		Delivery:       prefs.Delivery,
+		Created:        prefs.Created,
+		Updated:        prefs.Updated,
+	}
+}
+
+func mapNotificationScopePreference(pref *notificationScopePreference) *types.NotificationScopePreference {
+	return &types.NotificationScopePreference{
+		PrincipalID: pref.PrincipalID,
+		SpaceID:     pref.SpaceID.Ptr(),
+		RepoID:      pref.RepoID.Ptr(),
+		Mode:        pref.Mode,
+		Created:     pref.Created,
+		Updated:     pref.Updated,
+	}
+}
+
+func mapInternalNotificationScopePreference(pref *types.NotificationScopePreference) *notificationScopePreference {
+	return &notificationScopePreference{
+		PrincipalID: pref.PrincipalID,
+		SpaceID:     null.IntFromPtr(pref.SpaceID),
+		RepoID:      null.IntFromPtr(pref.RepoID),
+		Mode:        pref.Mode,
+		Created:     pref.Created,
+		Updated:     pref.Updated,
+	}
+}
+
+func mapNotificationScopePreferences(prefs []*notificationScopePreference) []*types.NotificationScopePreference {
+	res := make([]*types.NotificationScopePreference, len(prefs))
+	for i := range prefs {
+		res[i] = mapNotificationScopePreference(prefs[i])
+	}
+	return res
+}
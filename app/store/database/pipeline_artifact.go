@@ -0,0 +1,277 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*PipelineArtifactStore)(nil)
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) *PipelineArtifactStore {
+	return &PipelineArtifactStore{
+		db: db,
+	}
+}
+
+// PipelineArtifactStore implements store.PipelineArtifactStore backed by a relational database.
+type PipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+type pipelineArtifact struct {
+	ID          int64  `db:"partifact_id"`
+	RepoID      int64  `db:"partifact_repo_id"`
+	PipelineID  int64  `db:"partifact_pipeline_id"`
+	ExecutionID int64  `db:"partifact_execution_id"`
+	Name        string `db:"partifact_name"`
+	Size        int64  `db:"partifact_size"`
+	CreatedBy   int64  `db:"partifact_created_by"`
+	Created     int64  `db:"partifact_created"`
+	Updated     int64  `db:"partifact_updated"`
+	BlobPath    string `db:"partifact_blob_path"`
+}
+
+const (
+	pipelineArtifactColumns = `
+		 partifact_id
+		,partifact_repo_id
+		,partifact_pipeline_id
+		,partifact_execution_id
+		,partifact_name
+		,partifact_size
+		,partifact_created_by
+		,partifact_created
+		,partifact_updated
+		,partifact_blob_path`
+)
+
+// Find finds the artifact of the execution by its name.
+func (s *PipelineArtifactStore) Find(
+	ctx context.Context,
+	executionID int64,
+	name string,
+) (*types.PipelineArtifact, error) {
+	return s.findInternal(ctx, executionID, name, false)
+}
+
+func (s *PipelineArtifactStore) findInternal(
+	ctx context.Context,
+	executionID int64,
+	name string,
+	lock bool,
+) (*types.PipelineArtifact, error) {
+	sqlQuery := `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE partifact_execution_id = $1 AND partifact_name = $2`
+
+	if lock && !strings.HasPrefix(s.db.DriverName(), "sqlite") {
+		sqlQuery += "\n" + database.SQLForUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineArtifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, executionID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline artifact")
+	}
+
+	return mapPipelineArtifact(dst), nil
+}
+
+// List lists the artifacts of the execution.
+func (s *PipelineArtifactStore) List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error) {
+	const sqlQuery = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE partifact_execution_id = $1
+		ORDER BY partifact_name`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineArtifact
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+// Upsert creates an artifact or replaces the artifact of the execution with the same name.
+// It returns the replaced artifact, or nil if there was none. It should be called in a transaction,
+// so that the replaced artifact stays locked until the transaction ends.
+func (s *PipelineArtifactStore) Upsert(
+	ctx context.Context,
+	artifact *types.PipelineArtifact,
+) (*types.PipelineArtifact, error) {
+	const sqlInsert = `
+		INSERT INTO pipeline_artifacts (
+			 partifact_repo_id
+			,partifact_pipeline_id
+			,partifact_execution_id
+			,partifact_name
+			,partifact_size
+			,partifact_created_by
+			,partifact_created
+			,partifact_updated
+			,partifact_blob_path
+		) VALUES (
+			 :partifact_repo_id
+			,:partifact_pipeline_id
+			,:partifact_execution_id
+			,:partifact_name
+			,:partifact_size
+			,:partifact_created_by
+			,:partifact_created
+			,:partifact_updated
+			,:partifact_blob_path
+		)
+		ON CONFLICT (partifact_execution_id, partifact_name) DO NOTHING
+		RETURNING partifact_id`
+
+	const sqlUpdate = `
+		UPDATE pipeline_artifacts
+		SET
+			 partifact_size = :partifact_size
+			,partifact_created_by = :partifact_created_by
+			,partifact_updated = :partifact_updated
+			,partifact_blob_path = :partifact_blob_path
+		WHERE partifact_id = :partifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlInsert, mapInternalPipelineArtifact(artifact))
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&artifact.ID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to insert pipeline artifact")
+	}
+
+	// The artifact already exists. It's locked before it's read, so that concurrent uploads
+	// replace each other one at a time and each of them gets the artifact it has actually replaced.
+	replaced, err := s.findInternal(ctx, artifact.ExecutionID, artifact.Name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact.ID = replaced.ID
+	artifact.Created = replaced.Created
+
+	query, args, err = db.BindNamed(sqlUpdate, mapInternalPipelineArtifact(artifact))
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline artifact")
+	}
+
+	return replaced, nil
+}
+
+// ListUpdatedBefore lists the artifacts which weren't updated since the provided time.
+func (s *PipelineArtifactStore) ListUpdatedBefore(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]*types.PipelineArtifact, error) {
+	const sqlQuery = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts
+		WHERE partifact_updated < $1
+		ORDER BY partifact_updated
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineArtifact
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before.UnixMilli(), limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list old pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+// Delete deletes the artifact.
+func (s *PipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_artifacts
+		WHERE partifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pipeline artifact")
+	}
+
+	return nil
+}
+
+func mapPipelineArtifact(a *pipelineArtifact) *types.PipelineArtifact {
+	return &types.PipelineArtifact{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		PipelineID:  a.PipelineID,
+		ExecutionID: a.ExecutionID,
+		Name:        a.Name,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Updated:     a.Updated,
+		BlobPath:    a.BlobPath,
+	}
+}
+
+func mapPipelineArtifacts(artifacts []*pipelineArtifact) []*types.PipelineArtifact {
+	res := make([]*types.PipelineArtifact, len(artifacts))
+	for i := range artifacts {
+		res[i] = mapPipelineArtifact(artifacts[i])
+	}
+	return res
+}
+
+func mapInternalPipelineArtifact(a *types.PipelineArtifact) *pipelineArtifact {
+	return &pipelineArtifact{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		PipelineID:  a.PipelineID,
+		ExecutionID: a.ExecutionID,
+		Name:        a.Name,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Updated:     a.Updated,
+		BlobPath:    a.BlobPath,
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	ID         int64  `db:"pcache_id"`
+	RepoID     int64  `db:"pcache_repo_id"`
+	PipelineID int64  `db:"pcache_pipeline_id"`
+	Key        string `db:"pcache_key"`
+	Size       int64  `db:"pcache_size"`
+	Created    int64  `db:"pcache_created"`
+	Updated    int64  `db:"pcache_updated"`
+	LastUsed   int64  `db:"pcache_last_used"`
+	BlobPath   string `db:"pcache_blob_path"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 pcache_id
+		,pcache_repo_id
+		,pcache_pipeline_id
+		,pcache_key
+		,pcache_size
+		,pcache_created
+		,pcache_updated
+		,pcache_last_used
+		,pcache_blob_path`
+)
+
+// Find finds the cache of the pipeline by its key.
+func (s *PipelineCacheStore) Find(ctx context.Context, pipelineID int64, key string) (*types.PipelineCache, error) {
+	return s.findInternal(ctx, pipelineID, key, false)
+}
+
+func (s *PipelineCacheStore) findInternal(
+	ctx context.Context,
+	pipelineID int64,
+	key string,
+	lock bool,
+) (*types.PipelineCache, error) {
+	sqlQuery := `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches
+		WHERE pcache_pipeline_id = $1 AND pcache_key = $2`
+
+	if lock && !strings.HasPrefix(s.db.DriverName(), "sqlite") {
+		sqlQuery += "\n" + database.SQLForUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pipelineID, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline cache")
+	}
+
+	return mapPipelineCache(dst), nil
+}
+
+// FindByPrefix finds the most recently updated cache of the pipeline with a key starting with the prefix.
+func (s *PipelineCacheStore) FindByPrefix(
+	ctx context.Context,
+	pipelineID int64,
+	prefix string,
+) (*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pcache_pipeline_id = ?", pipelineID).
+		Where(PrefixMatch("pcache_key", prefix)).
+		OrderBy("pcache_updated DESC", "pcache_id DESC").
+		Limit(1)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err = db.GetContext(ctx, dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline cache by prefix")
+	}
+
+	return mapPipelineCache(dst), nil
+}
+
+// Upsert creates a cache or replaces the cache of the pipeline with the same key.
+// It returns the replaced cache, or nil if there was none. It should be called in a transaction,
+// so that the replaced cache stays locked until the transaction ends.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) (*types.PipelineCache, error) {
+	const sqlInsert = `
+		INSERT INTO pipeline_caches (
+			 pcache_repo_id
+			,pcache_pipeline_id
+			,pcache_key
+			,pcache_size
+			,pcache_created
+			,pcache_updated
+			,pcache_last_used
+			,pcache_blob_path
+		) VALUES (
+			 :pcache_repo_id
+			,:pcache_pipeline_id
+			,:pcache_key
+			,:pcache_size
+			,:pcache_created
+			,:pcache_updated
+			,:pcache_last_used
+			,:pcache_blob_path
+		)
+		ON CONFLICT (pcache_pipeline_id, pcache_key) DO NOTHING
+		RETURNING pcache_id`
+
+	const sqlUpdate = `
+		UPDATE pipeline_caches
+		SET
+			 pcache_size = :pcache_size
+			,pcache_updated = :pcache_updated
+			,pcache_last_used = :pcache_last_used
+			,pcache_blob_path = :pcache_blob_path
+		WHERE pcache_id = :pcache_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlInsert, mapInternalPipelineCache(cache))
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline cache object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&cache.ID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to insert pipeline cache")
+	}
+
+	// The cache already exists. It's locked before it's read, so that concurrent saves
+	// replace each other one at a time and each of them gets the cache it has actually replaced.
+	replaced, err := s.findInternal(ctx, cache.PipelineID, cache.Key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.ID = replaced.ID
+	cache.Created = replaced.Created
+
+	query, args, err = db.BindNamed(sqlUpdate, mapInternalPipelineCache(cache))
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline cache object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline cache")
+	}
+
+	return replaced, nil
+}
+
+// UpdateLastUsed marks the cache as used at the provided time.
+func (s *PipelineCacheStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed time.Time) error {
+	stmt := database.Builder.
+		Update("pipeline_caches").
+		Set("pcache_last_used", lastUsed.UnixMilli()).
+		Where(squirrel.Eq{"pcache_id": id})
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update last used time of pipeline cache")
+	}
+
+	return nil
+}
+
+// ListUnusedSince lists the caches which weren't used since the provided time.
+func (s *PipelineCacheStore) ListUnusedSince(
+	ctx context.Context,
+	since time.Time,
+	limit int,
+) ([]*types.PipelineCache, error) {
+	const sqlQuery = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches
+		WHERE pcache_last_used < $1
+		ORDER BY pcache_last_used
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineCache
+	if err := db.SelectContext(ctx, &dst, sqlQuery, since.UnixMilli(), limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list unused pipeline caches")
+	}
+
+	return mapPipelineCaches(dst), nil
+}
+
+// Delete deletes the cache.
+func (s *PipelineCacheStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_caches
+		WHERE pcache_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pipeline cache")
+	}
+
+	return nil
+}
+
+func mapPipelineCache(c *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		ID:         c.ID,
+		RepoID:     c.RepoID,
+		PipelineID: c.PipelineID,
+		Key:        c.Key,
+		Size:       c.Size,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		LastUsed:   c.LastUsed,
+		BlobPath:   c.BlobPath,
+	}
+}
+
+func mapPipelineCaches(caches []*pipelineCache) []*types.PipelineCache {
+	res := make([]*types.PipelineCache, len(caches))
+	for i := range caches {
+		res[i] = mapPipelineCache(caches[i])
+	}
+	return res
+}
+
+func mapInternalPipelineCache(c *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		ID:         c.ID,
+		RepoID:     c.RepoID,
+		PipelineID: c.PipelineID,
+		Key:        c.Key,
+		Size:       c.Size,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		LastUsed:   c.LastUsed,
+		BlobPath:   c.BlobPath,
+	}
+}
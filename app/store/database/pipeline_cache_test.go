@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineCacheStore_Upsert(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	cacheStore := database.NewPipelineCacheStore(db)
+
+	first := &types.PipelineCache{RepoID: 1, PipelineID: 1, Key: "deps", Size: 1, Created: 1, BlobPath: "a"}
+	replaced, err := cacheStore.Upsert(ctx, first)
+	require.NoError(t, err)
+	require.Nil(t, replaced)
+
+	second := &types.PipelineCache{RepoID: 1, PipelineID: 1, Key: "deps", Size: 2, Created: 2, BlobPath: "b"}
+	replaced, err = cacheStore.Upsert(ctx, second)
+	require.NoError(t, err)
+	require.NotNil(t, replaced)
+	require.Equal(t, "a", replaced.BlobPath)
+	require.Equal(t, first.ID, second.ID)
+	require.Equal(t, first.Created, second.Created)
+
+	cache, err := cacheStore.Find(ctx, 1, "deps")
+	require.NoError(t, err)
+	require.Equal(t, "b", cache.BlobPath)
+	require.Equal(t, int64(2), cache.Size)
+}
+
+func TestPipelineCacheStore_FindByPrefix(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	cacheStore := database.NewPipelineCacheStore(db)
+
+	for i, key := range []string{"go_1", "go-2", "node"} {
+		_, err := cacheStore.Upsert(ctx, &types.PipelineCache{
+			RepoID:     1,
+			PipelineID: 1,
+			Key:        key,
+			Created:    int64(i),
+			Updated:    int64(i),
+			LastUsed:   int64(i),
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		expKey string
+	}{
+		{
+			name:   "most recently updated match",
+			prefix: "go",
+			expKey: "go-2",
+		},
+		{
+			name:   "underscore is not a wildcard",
+			prefix: "go_",
+			expKey: "go_1",
+		},
+		{
+			name:   "percent is not a wildcard",
+			prefix: "%",
+		},
+		{
+			name:   "no match",
+			prefix: "python",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache, err := cacheStore.FindByPrefix(ctx, 1, test.prefix)
+			if test.expKey == "" {
+				require.True(t, errors.Is(err, gitness_store.ErrResourceNotFound), "unexpected error: %v", err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expKey, cache.Key)
+		})
+	}
+}
+
+func TestPipelineCacheStore_ListUnusedSince(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	cacheStore := database.NewPipelineCacheStore(db)
+
+	now := time.Now()
+
+	oldCache := &types.PipelineCache{RepoID: 1, PipelineID: 1, Key: "old"}
+	_, err := cacheStore.Upsert(ctx, oldCache)
+	require.NoError(t, err)
+	require.NoError(t, cacheStore.UpdateLastUsed(ctx, oldCache.ID, now.Add(-time.Hour)))
+
+	newCache := &types.PipelineCache{RepoID: 1, PipelineID: 1, Key: "new"}
+	_, err = cacheStore.Upsert(ctx, newCache)
+	require.NoError(t, err)
+	require.NoError(t, cacheStore.UpdateLastUsed(ctx, newCache.ID, now))
+
+	caches, err := cacheStore.ListUnusedSince(ctx, now.Add(-time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, caches, 1)
+	require.Equal(t, oldCache.ID, caches[0].ID)
+
+	require.NoError(t, cacheStore.Delete(ctx, oldCache.ID))
+
+	caches, err = cacheStore.ListUnusedSince(ctx, now.Add(-time.Minute), 10)
+	require.NoError(t, err)
+	require.Empty(t, caches)
+}
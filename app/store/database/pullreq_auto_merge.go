@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutoMergeStore = (*AutoMergeStore)(nil)
+
+// NewAutoMergeStore returns a new AutoMergeStore.
+func NewAutoMergeStore(db *sqlx.DB) *AutoMergeStore {
+	return &AutoMergeStore{
+		db: db,
+	}
+}
+
+// AutoMergeStore implements store.AutoMergeStore backed by a relational database.
+type AutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type autoMerge struct {
+	PullReqID          int64            `db:"auto_merge_pullreq_id"`
+	Created            int64            `db:"auto_merge_created"`
+	Updated            int64            `db:"auto_merge_updated"`
+	CreatedBy          int64            `db:"auto_merge_created_by"`
+	RepoID             int64            `db:"auto_merge_repo_id"`
+	Method             enum.MergeMethod `db:"auto_merge_method"`
+	Title              string           `db:"auto_merge_title"`
+	Message            string           `db:"auto_merge_message"`
+	DeleteSourceBranch bool             `db:"auto_merge_delete_source_branch"`
+}
+
+const (
+	autoMergeColumns = `
+		 auto_merge_pullreq_id
+		,auto_merge_created
+		,auto_merge_updated
+		,auto_merge_created_by
+		,auto_merge_repo_id
+		,auto_merge_method
+		,auto_merge_title
+		,auto_merge_message
+		,auto_merge_delete_source_branch`
+)
+
+// Find finds the auto-merge settings of a pull request.
+func (s *AutoMergeStore) Find(ctx context.Context, pullreqID int64) (*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		Where("auto_merge_pullreq_id = ?", pullreqID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autoMerge{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto-merge")
+	}
+
+	return mapAutoMerge(dst), nil
+}
+
+// Upsert creates or replaces the auto-merge settings of a pull request.
+func (s *AutoMergeStore) Upsert(ctx context.Context, autoMerge *types.AutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (
+			 auto_merge_pullreq_id
+			,auto_merge_created
+			,auto_merge_updated
+			,auto_merge_created_by
+			,auto_merge_repo_id
+			,auto_merge_method
+			,auto_merge_title
+			,auto_merge_message
+			,auto_merge_delete_source_branch
+		) values (
+			 :auto_merge_pullreq_id
+			,:auto_merge_created
+			,:auto_merge_updated
+			,:auto_merge_created_by
+			,:auto_merge_repo_id
+			,:auto_merge_method
+			,:auto_merge_title
+			,:auto_merge_message
+			,:auto_merge_delete_source_branch
+		) ON CONFLICT (auto_merge_pullreq_id) DO UPDATE SET
+			 auto_merge_updated = :auto_merge_updated
+			,auto_merge_created_by = :auto_merge_created_by
+			,auto_merge_method = :auto_merge_method
+			,auto_merge_title = :auto_merge_title
+			,auto_merge_message = :auto_merge_message
+			,auto_merge_delete_source_branch = :auto_merge_delete_source_branch
+		RETURNING auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto-merge object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&autoMerge.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert pull request auto-merge")
+	}
+
+	return nil
+}
+
+// Delete removes the auto-merge settings of a pull request.
+func (s *AutoMergeStore) Delete(ctx context.Context, pullreqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullreqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request auto-merge")
+	}
+
+	return nil
+}
+
+// ListBySourceSHA returns auto-merge settings of all open pull requests
+// of the target repository that have the provided source SHA.
+func (s *AutoMergeStore) ListBySourceSHA(
+	ctx context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		InnerJoin("pullreqs ON pullreq_id = auto_merge_pullreq_id").
+		Where("auto_merge_repo_id = ?", repoID).
+		Where("pullreq_source_sha = ?", sourceSHA).
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		OrderBy("auto_merge_pullreq_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*autoMerge
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto-merges")
+	}
+
+	res := make([]*types.AutoMerge, len(dst))
+	for i := range dst {
+		res[i] = mapAutoMerge(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapInternalAutoMerge(am *types.AutoMerge) *autoMerge {
+	return &autoMerge{
+		PullReqID:          am.PullReqID,
+		Created:            am.Created,
+		Updated:            am.Updated,
+		CreatedBy:          am.CreatedBy,
+		RepoID:             am.RepoID,
+		Method:             am.Method,
+		Title:              am.Title,
+		Message:            am.Message,
+		DeleteSourceBranch: am.DeleteSourceBranch,
+	}
+}
+
+func mapAutoMerge(am *autoMerge) *types.AutoMerge {
+	return &types.AutoMerge{
+		PullReqID:          am.PullReqID,
+		Created:            am.Created,
+		Updated:            am.Updated,
+		CreatedBy:          am.CreatedBy,
+		RepoID:             am.RepoID,
+		Method:             am.Method,
+		Title:              am.Title,
+		Message:            am.Message,
+		DeleteSourceBranch: am.DeleteSourceBranch,
+	}
+}
@@ -82,6 +82,7 @@ type repository struct {
 	DefaultBranch string `db:"repo_default_branch"`
 	ForkID        int64  `db:"repo_fork_id"`
 	PullReqSeq    int64  `db:"repo_pullreq_seq"`
+	IssueSeq      int64  `db:"repo_issue_seq"`
 
 	NumForks       int `db:"repo_num_forks"`
 	NumPulls       int `db:"repo_num_pulls"`
@@ -114,6 +115,7 @@ const (
 		,repo_git_uid
 		,repo_default_branch
 		,repo_pullreq_seq
+		,repo_issue_seq
 		,repo_fork_id
 		,repo_num_forks
 		,repo_num_pulls
@@ -235,6 +237,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_default_branch
 			,repo_fork_id
 			,repo_pullreq_seq
+			,repo_issue_seq
 			,repo_num_forks
 			,repo_num_pulls
 			,repo_num_closed_pulls
@@ -260,6 +263,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_default_branch
 			,:repo_fork_id
 			,:repo_pullreq_seq
+			,:repo_issue_seq
 			,:repo_num_forks
 			,:repo_num_pulls
 			,:repo_num_closed_pulls
@@ -305,6 +309,7 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_description = :repo_description
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
+			,repo_issue_seq = :repo_issue_seq
 			,repo_num_pulls = :repo_num_pulls
 			,repo_num_closed_pulls = :repo_num_closed_pulls
 			,repo_num_open_pulls = :repo_num_open_pulls
@@ -897,6 +902,7 @@ func (s *RepoStore) mapToRepo(
 		DefaultBranch:  in.DefaultBranch,
 		ForkID:         in.ForkID,
 		PullReqSeq:     in.PullReqSeq,
+		IssueSeq:       in.IssueSeq,
 		NumForks:       in.NumForks,
 		NumPulls:       in.NumPulls,
 		NumClosedPulls: in.NumClosedPulls,
@@ -986,6 +992,7 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		DefaultBranch:  in.DefaultBranch,
 		ForkID:         in.ForkID,
 		PullReqSeq:     in.PullReqSeq,
+		IssueSeq:       in.IssueSeq,
 		NumForks:       in.NumForks,
 		NumPulls:       in.NumPulls,
 		NumClosedPulls: in.NumClosedPulls,
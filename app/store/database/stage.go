@@ -61,6 +61,10 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_matrix
+	,stage_envs
+	,stage_fail_fast
+	,stage_max_parallel
 	`
 )
 
@@ -93,6 +97,10 @@ type stage struct {
 	OnFailure     bool               `db:"stage_on_failure"`
 	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
 	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	Matrix        sqlxtypes.JSONText `db:"stage_matrix"`
+	Envs          sqlxtypes.JSONText `db:"stage_envs"`
+	FailFast      bool               `db:"stage_fail_fast"`
+	MaxParallel   int                `db:"stage_max_parallel"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +160,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_matrix
+			,stage_envs
+			,stage_fail_fast
+			,stage_max_parallel
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,7 +192,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
-
+			,:stage_matrix
+			,:stage_envs
+			,:stage_fail_fast
+			,:stage_max_parallel
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
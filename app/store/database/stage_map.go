@@ -82,6 +82,16 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal stage.labels")
 	}
+	var matrix map[string]string
+	err = json.Unmarshal(in.Matrix, &matrix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.matrix")
+	}
+	var envs map[string]string
+	err = json.Unmarshal(in.Envs, &envs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.envs")
+	}
 	return &types.Stage{
 		ID:          in.ID,
 		ExecutionID: in.ExecutionID,
@@ -110,6 +120,10 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		OnFailure:   in.OnFailure,
 		DependsOn:   dependsOn,
 		Labels:      labels,
+		Matrix:      matrix,
+		Envs:        envs,
+		FailFast:    in.FailFast,
+		MaxParallel: in.MaxParallel,
 	}, nil
 }
 
@@ -142,6 +156,10 @@ func mapStageToInternal(in *types.Stage) *stage {
 		OnFailure:   in.OnFailure,
 		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
 		Labels:      EncodeToSQLXJSON(in.Labels),
+		Matrix:      EncodeToSQLXJSON(in.Matrix),
+		Envs:        EncodeToSQLXJSON(in.Envs),
+		FailFast:    in.FailFast,
+		MaxParallel: in.MaxParallel,
 	}
 }
 
@@ -191,6 +209,8 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
+	matrixJSON := sqlxtypes.JSONText{}
+	envsJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
 	err := rows.Scan(
 		&stage.ID,
@@ -220,6 +240,10 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&matrixJSON,
+		&envsJSON,
+		&stage.FailFast,
+		&stage.MaxParallel,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -247,6 +271,14 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal labJSON: %w", err)
 	}
+	err = json.Unmarshal(matrixJSON, &stage.Matrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal matrixJSON: %w", err)
+	}
+	err = json.Unmarshal(envsJSON, &stage.Envs)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal envsJSON: %w", err)
+	}
 	if step.ID.Valid {
 		// try to unmarshal step dependencies if step exists
 		err = json.Unmarshal(stepDepJSON, &step.DependsOn)
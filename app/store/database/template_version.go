@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.TemplateVersionStore = (*templateVersionStore)(nil)
+
+const (
+	templateVersionColumns = `
+	tversion_id,
+	tversion_template_id,
+	tversion_version,
+	tversion_data,
+	tversion_inputs,
+	tversion_created_by,
+	tversion_created
+	`
+)
+
+// NewTemplateVersionStore returns a new TemplateVersionStore.
+func NewTemplateVersionStore(db *sqlx.DB) store.TemplateVersionStore {
+	return &templateVersionStore{
+		db: db,
+	}
+}
+
+type templateVersionStore struct {
+	db *sqlx.DB
+}
+
+// templateVersion represents a template version object stored in the database.
+type templateVersion struct {
+	ID         int64              `db:"tversion_id"`
+	TemplateID int64              `db:"tversion_template_id"`
+	Version    string             `db:"tversion_version"`
+	Data       string             `db:"tversion_data"`
+	Inputs     sqlxtypes.JSONText `db:"tversion_inputs"`
+	CreatedBy  int64              `db:"tversion_created_by"`
+	Created    int64              `db:"tversion_created"`
+}
+
+// Find returns a version of a template.
+func (s *templateVersionStore) Find(
+	ctx context.Context,
+	templateID int64,
+	version string,
+) (*types.TemplateVersion, error) {
+	const findQueryStmt = `
+		SELECT` + templateVersionColumns + `
+		FROM template_versions
+		WHERE tversion_template_id = $1 AND tversion_version = $2`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(templateVersion)
+	if err := db.GetContext(ctx, dst, findQueryStmt, templateID, version); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find template version")
+	}
+
+	return mapInternalToTemplateVersion(dst)
+}
+
+// Create creates a template version. Template versions are immutable, so there's no update.
+func (s *templateVersionStore) Create(ctx context.Context, version *types.TemplateVersion) error {
+	const templateVersionInsertStmt = `
+	INSERT INTO template_versions (
+		tversion_template_id,
+		tversion_version,
+		tversion_data,
+		tversion_inputs,
+		tversion_created_by,
+		tversion_created
+	) VALUES (
+		:tversion_template_id,
+		:tversion_version,
+		:tversion_data,
+		:tversion_inputs,
+		:tversion_created_by,
+		:tversion_created
+	) RETURNING tversion_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(templateVersionInsertStmt, mapTemplateVersionToInternal(version))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind template version object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&version.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Template version query failed")
+	}
+
+	return nil
+}
+
+// List lists the versions of a template, the most recent first.
+func (s *templateVersionStore) List(
+	ctx context.Context,
+	templateID int64,
+	filter types.ListQueryFilter,
+) ([]*types.TemplateVersion, error) {
+	stmt := database.Builder.
+		Select(templateVersionColumns).
+		From("template_versions").
+		Where("tversion_template_id = ?", templateID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("tversion_version", filter.Query))
+	}
+
+	stmt = stmt.OrderBy("tversion_created DESC", "tversion_id DESC")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*templateVersion{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	versions := make([]*types.TemplateVersion, len(dst))
+	for i := range dst {
+		if versions[i], err = mapInternalToTemplateVersion(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// Count returns the number of versions of a template.
+func (s *templateVersionStore) Count(
+	ctx context.Context,
+	templateID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("template_versions").
+		Where("tversion_template_id = ?", templateID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("tversion_version", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func mapInternalToTemplateVersion(in *templateVersion) (*types.TemplateVersion, error) {
+	var inputs map[string]*types.TemplateInput
+	if err := in.Inputs.Unmarshal(&inputs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template version inputs: %w", err)
+	}
+
+	return &types.TemplateVersion{
+		ID:         in.ID,
+		TemplateID: in.TemplateID,
+		Version:    in.Version,
+		Data:       in.Data,
+		Inputs:     inputs,
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+	}, nil
+}
+
+func mapTemplateVersionToInternal(in *types.TemplateVersion) *templateVersion {
+	return &templateVersion{
+		ID:         in.ID,
+		TemplateID: in.TemplateID,
+		Version:    in.Version,
+		Data:       in.Data,
+		Inputs:     EncodeToSQLXJSON(in.Inputs),
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+	}
+}
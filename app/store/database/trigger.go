@@ -48,6 +48,13 @@ type trigger struct {
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
+	Cron        string             `db:"trigger_cron"`
+	Timezone    string             `db:"trigger_timezone"`
+	Branch      string             `db:"trigger_branch"`
+	CatchUp     string             `db:"trigger_catch_up"`
+	LastRun     int64              `db:"trigger_last_run"`
+	NextRun     int64              `db:"trigger_next_run"`
+	Paths       sqlxtypes.JSONText `db:"trigger_paths"`
 }
 
 func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
@@ -57,6 +64,14 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		return nil, errors.Wrap(err, "could not unmarshal trigger.actions")
 	}
 
+	var paths types.TriggerPaths
+	if len(trigger.Paths) > 0 {
+		err = json.Unmarshal(trigger.Paths, &paths)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal trigger.paths")
+		}
+	}
+
 	return &types.Trigger{
 		ID:          trigger.ID,
 		Description: trigger.Description,
@@ -71,6 +86,13 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
 		Version:     trigger.Version,
+		Cron:        trigger.Cron,
+		Timezone:    trigger.Timezone,
+		Branch:      trigger.Branch,
+		CatchUp:     enum.TriggerCatchUp(trigger.CatchUp),
+		LastRun:     trigger.LastRun,
+		NextRun:     trigger.NextRun,
+		Paths:       paths,
 	}, nil
 }
 
@@ -101,6 +123,13 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
+		Cron:        t.Cron,
+		Timezone:    t.Timezone,
+		Branch:      t.Branch,
+		CatchUp:     string(t.CatchUp),
+		LastRun:     t.LastRun,
+		NextRun:     t.NextRun,
+		Paths:       EncodeToSQLXJSON(t.Paths),
 	}
 }
 
@@ -119,6 +148,8 @@ const (
 	triggerColumns = `
 		trigger_id
 		,trigger_uid
+		,trigger_type
+		,trigger_repo_id
 		,trigger_disabled
 		,trigger_actions
 		,trigger_description
@@ -126,6 +157,13 @@ const (
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron
+		,trigger_timezone
+		,trigger_branch
+		,trigger_catch_up
+		,trigger_last_run
+		,trigger_next_run
+		,trigger_paths
 	`
 )
 
@@ -164,6 +202,13 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron
+		,trigger_timezone
+		,trigger_branch
+		,trigger_catch_up
+		,trigger_last_run
+		,trigger_next_run
+		,trigger_paths
 	) VALUES (
 		:trigger_uid
 		,:trigger_description
@@ -177,6 +222,13 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
+		,:trigger_cron
+		,:trigger_timezone
+		,:trigger_branch
+		,:trigger_catch_up
+		,:trigger_last_run
+		,:trigger_next_run
+		,:trigger_paths
 	) RETURNING trigger_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -190,6 +242,8 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		return database.ProcessSQLErrorf(ctx, err, "Trigger query failed")
 	}
 
+	t.ID = trigger.ID
+
 	return nil
 }
 
@@ -204,6 +258,13 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
 		,trigger_version = :trigger_version
+		,trigger_cron = :trigger_cron
+		,trigger_timezone = :trigger_timezone
+		,trigger_branch = :trigger_branch
+		,trigger_catch_up = :trigger_catch_up
+		,trigger_last_run = :trigger_last_run
+		,trigger_next_run = :trigger_next_run
+		,trigger_paths = :trigger_paths
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
 	trigger := mapTriggerToInternal(t)
@@ -323,6 +384,35 @@ func (s *triggerStore) ListAllEnabled(
 }
 
 // Count of triggers under a given pipeline.
+func (s *triggerStore) ListCronDue(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_cron <> ''").
+		Where("trigger_disabled = false").
+		Where("trigger_next_run > 0 AND trigger_next_run <= ?", now).
+		OrderBy("trigger_next_run ASC").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list due cron triggers query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
 func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
 		Select("count(*)").
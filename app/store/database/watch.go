@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.WatchStore = (*WatchStore)(nil)
+
+// NewWatchStore returns a new WatchStore.
+func NewWatchStore(db *sqlx.DB) *WatchStore {
+	return &WatchStore{
+		db: db,
+	}
+}
+
+// WatchStore implements store.WatchStore backed by a relational database.
+type WatchStore struct {
+	db *sqlx.DB
+}
+
+type watch struct {
+	ID          int64           `db:"watch_id"`
+	PrincipalID int64           `db:"watch_principal_id"`
+	SpaceID     null.Int        `db:"watch_space_id"`
+	RepoID      null.Int        `db:"watch_repo_id"`
+	Level       enum.WatchLevel `db:"watch_level"`
+	Created     int64           `db:"watch_created"`
+	Updated     int64           `db:"watch_updated"`
+}
+
+const (
+	watchColumns = `
+		 watch_id
+		,watch_principal_id
+		,watch_space_id
+		,watch_repo_id
+		,watch_level
+		,watch_created
+		,watch_updated`
+)
+
+// Find finds the watch of the principal for the specified space/repo.
+func (s *WatchStore) Find(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+) (*types.Watch, error) {
+	const sqlQuery = `
+		SELECT` + watchColumns + `
+		FROM watches
+		WHERE watch_principal_id = $1 AND (watch_space_id = $2 OR watch_repo_id = $3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &watch{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, spaceID, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find watch")
+	}
+
+	return mapWatch(dst), nil
+}
+
+// ListByPrincipal lists all spaces and repositories watched by the principal.
+func (s *WatchStore) ListByPrincipal(ctx context.Context, principalID int64) ([]*types.Watch, error) {
+	const sqlQuery = `
+		SELECT` + watchColumns + `
+		FROM watches
+		WHERE watch_principal_id = $1
+		ORDER BY watch_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*watch
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list watches of principal")
+	}
+
+	return mapWatches(dst), nil
+}
+
+// ListInScopes lists the watches defined for the specified repo and spaces.
+func (s *WatchStore) ListInScopes(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+) ([]*types.Watch, error) {
+	scopes := squirrel.Or{
+		squirrel.Eq{"watch_space_id": spaceIDs},
+	}
+	if repoID != nil {
+		scopes = append(scopes, squirrel.Eq{"watch_repo_id": *repoID})
+	}
+
+	stmt := database.Builder.
+		Select(watchColumns).
+		From("watches").
+		Where(scopes).
+		OrderBy("watch_id")
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*watch
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list watches")
+	}
+
+	return mapWatches(dst), nil
+}
+
+// Upsert creates or updates a watch of a principal for a space/repo.
+func (s *WatchStore) Upsert(ctx context.Context, w *types.Watch) error {
+	const sqlUpdate = `
+		UPDATE watches
+		SET
+			 watch_level = :watch_level
+			,watch_updated = :watch_updated
+		WHERE watch_principal_id = :watch_principal_id AND
+			(watch_space_id = :watch_space_id OR watch_repo_id = :watch_repo_id)
+		RETURNING watch_created`
+
+	const sqlInsert = `
+		INSERT INTO watches (
+			 watch_principal_id
+			,watch_space_id
+			,watch_repo_id
+			,watch_level
+			,watch_created
+			,watch_updated
+		) VALUES (
+			 :watch_principal_id
+			,:watch_space_id
+			,:watch_repo_id
+			,:watch_level
+			,:watch_created
+			,:watch_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	now := time.Now().UnixMilli()
+	dbWatch := mapInternalWatch(w)
+	dbWatch.Created = now
+	dbWatch.Updated = now
+
+	query, args, err := db.BindNamed(sqlUpdate, dbWatch)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind watch object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&dbWatch.Created)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update watch")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		query, args, err = db.BindNamed(sqlInsert, dbWatch)
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind watch object")
+		}
+
+		if _, err = db.ExecContext(ctx, query, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to create watch")
+		}
+	}
+
+	w.Created = dbWatch.Created
+	w.Updated = dbWatch.Updated
+
+	return nil
+}
+
+// Delete deletes the watch of the principal for the specified space/repo.
+func (s *WatchStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM watches
+		WHERE watch_principal_id = $1 AND (watch_space_id = $2 OR watch_repo_id = $3)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, spaceID, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete watch")
+	}
+
+	return nil
+}
+
+func mapWatch(w *watch) *types.Watch {
+	return &types.Watch{
+		PrincipalID: w.PrincipalID,
+		SpaceID:     w.SpaceID.Ptr(),
+		RepoID:      w.RepoID.Ptr(),
+		Level:       w.Level,
+		Created:     w.Created,
+		Updated:     w.Updated,
+	}
+}
+
+func mapInternalWatch(w *types.Watch) *watch {
+	return &watch{
+		PrincipalID: w.PrincipalID,
+		SpaceID:     null.IntFromPtr(w.SpaceID),
+		RepoID:      null.IntFromPtr(w.RepoID),
+		Level:       w.Level,
+		Created:     w.Created,
+		Updated:     w.Updated,
+	}
+}
+
+func mapWatches(watches []*watch) []*types.Watch {
+	res := make([]*types.Watch, len(watches))
+	for i := range watches {
+		res[i] = mapWatch(watches[i])
+	}
+	return res
+}
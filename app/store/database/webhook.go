@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
 )
 
@@ -69,6 +71,12 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+
+	RetryPolicy         sqlxtypes.JSONText `db:"webhook_retry_policy"`
+	ConsecutiveFailures int                `db:"webhook_consecutive_failures"`
+
+	Filter          string `db:"webhook_filter"`
+	PayloadTemplate string `db:"webhook_payload_template"`
 }
 
 const (
@@ -90,7 +98,11 @@ const (
 		,webhook_triggers
 		,webhook_latest_execution_result
 		,webhook_type
-		,webhook_scope`
+		,webhook_scope
+		,webhook_retry_policy
+		,webhook_consecutive_failures
+		,webhook_filter
+		,webhook_payload_template`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -179,6 +191,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_latest_execution_result
 			,webhook_type
 			,webhook_scope
+			,webhook_retry_policy
+			,webhook_consecutive_failures
+			,webhook_filter
+			,webhook_payload_template
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -196,6 +212,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_latest_execution_result
 			,:webhook_type
 			,:webhook_scope
+			,:webhook_retry_policy
+			,:webhook_consecutive_failures
+			,:webhook_filter
+			,:webhook_payload_template
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -233,6 +253,10 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
+			,webhook_retry_policy = :webhook_retry_policy
+			,webhook_consecutive_failures = :webhook_consecutive_failures
+			,webhook_filter = :webhook_filter
+			,webhook_payload_template = :webhook_payload_template
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -491,6 +515,15 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Type:                  hook.Type,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		Filter:                hook.Filter,
+		PayloadTemplate:       hook.PayloadTemplate,
+	}
+
+	if len(hook.RetryPolicy) > 0 {
+		if err := json.Unmarshal(hook.RetryPolicy, &res.RetryPolicy); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal retry policy of hook %d: %w", hook.ID, err)
+		}
 	}
 
 	switch {
@@ -528,6 +561,10 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Type:                  hook.Type,
+		RetryPolicy:           EncodeToSQLXJSON(hook.RetryPolicy),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		Filter:                hook.Filter,
+		PayloadTemplate:       hook.PayloadTemplate,
 	}
 
 	switch hook.ParentType {
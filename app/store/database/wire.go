@@ -60,6 +60,7 @@ var WireSet = wire.NewSet(
 	ProvideCheckStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
+	ProvideTemplateVersionStore,
 	ProvideTriggerStore,
 	ProvidePluginStore,
 	ProvidePublicKeyStore,
@@ -81,6 +82,19 @@ var WireSet = wire.NewSet(
 	ProvideFavoriteStore,
 	ProvideGitspaceSettingsStore,
 	ProvideAITaskStore,
+	ProvideMergeQueueStore,
+	ProvideAutoMergeStore,
+	ProvideIssueStore,
+	ProvideIssueActivityStore,
+	ProvideIssueAssigneeStore,
+	ProvideIssueLabelStore,
+	ProvideMilestoneStore,
+	ProvideNotificationChannelStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationDigestStore,
+	ProvideWatchStore,
+	ProvidePipelineArtifactStore,
+	ProvidePipelineCacheStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -239,6 +253,11 @@ func ProvideTemplateStore(db *sqlx.DB) store.TemplateStore {
 	return NewTemplateStore(db)
 }
 
+// ProvideTemplateVersionStore provides a template version store.
+func ProvideTemplateVersionStore(db *sqlx.DB) store.TemplateVersionStore {
+	return NewTemplateVersionStore(db)
+}
+
 // ProvideTriggerStore provides a trigger store.
 func ProvideTriggerStore(db *sqlx.DB) store.TriggerStore {
 	return NewTriggerStore(db)
@@ -404,3 +423,77 @@ func ProvideFavoriteStore(db *sqlx.DB) store.FavoriteStore {
 func ProvideAITaskStore(db *sqlx.DB) store.AITaskStore {
 	return NewAITaskStore(db)
 }
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideAutoMergeStore provides a pull request auto-merge store.
+func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
+	return NewAutoMergeStore(db)
+}
+
+// ProvideIssueStore provides an issue store.
+func ProvideIssueStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueStore {
+	return NewIssueStore(db, principalInfoCache)
+}
+
+// ProvideIssueActivityStore provides an issue activity store.
+func ProvideIssueActivityStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueActivityStore {
+	return NewIssueActivityStore(db, principalInfoCache)
+}
+
+// ProvideIssueAssigneeStore provides an issue assignee store.
+func ProvideIssueAssigneeStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueAssigneeStore {
+	return NewIssueAssigneeStore(db, principalInfoCache)
+}
+
+// ProvideIssueLabelStore provides an issue label assignment store.
+func ProvideIssueLabelStore(db *sqlx.DB) store.IssueLabelAssignmentStore {
+	return NewIssueLabelStore(db)
+}
+
+// ProvideMilestoneStore provides a milestone store.
+func ProvideMilestoneStore(db *sqlx.DB) store.MilestoneStore {
+	return NewMilestoneStore(db)
+}
+
+// ProvideNotificationChannelStore provides a notification channel store.
+func ProvideNotificationChannelStore(db *sqlx.DB) store.NotificationChannelStore {
+	return NewNotificationChannelStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvideWatchStore provides a watch store.
+func ProvideWatchStore(db *sqlx.DB) store.WatchStore {
+	return NewWatchStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a pipeline artifact store.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
+// ProvidePipelineCacheStore provides a pipeline cache store.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
@@ -64,6 +64,9 @@ type Provider interface {
 	// GenerateUIPRURL returns the url for the UI screen of an existing pr.
 	GenerateUIPRURL(ctx context.Context, repoPath string, prID int64) string
 
+	// GenerateUIIssueURL returns the url for the UI screen of an existing issue.
+	GenerateUIIssueURL(ctx context.Context, repoPath string, issueNum int64) string
+
 	// GenerateUICompareURL returns the url for the UI screen comparing two references.
 	GenerateUICompareURL(ctx context.Context, repoPath string, ref1 string, ref2 string) string
 
@@ -230,6 +233,10 @@ func (p *provider) GenerateUIPRURL(_ context.Context, repoPath string, prID int6
 	return p.uiURL.JoinPath(repoPath, "pulls", fmt.Sprint(prID)).String()
 }
 
+func (p *provider) GenerateUIIssueURL(_ context.Context, repoPath string, issueNum int64) string {
+	return p.uiURL.JoinPath(repoPath, "issues", fmt.Sprint(issueNum)).String()
+}
+
 func (p *provider) GenerateUICompareURL(_ context.Context, repoPath string, ref1 string, ref2 string) string {
 	return p.uiURL.JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
 }
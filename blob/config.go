@@ -21,6 +21,7 @@ type Provider string
 const (
 	ProviderGCS        Provider = "gcs"
 	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
 )
 
 type Config struct {
@@ -29,4 +30,22 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+
+	// S3 holds the configuration of the S3 compatible blob store (AWS S3, MinIO, ...).
+	S3 S3Config
+}
+
+type S3Config struct {
+	// Endpoint is the URL of the S3 compatible service, e.g. "http://localhost:9000" for a local MinIO.
+	// If empty, the AWS S3 endpoint of the region is used.
+	Endpoint string
+	Region   string
+
+	// AccessKeyID and SecretAccessKey are the static credentials used to access the bucket.
+	// If empty, the default AWS credential chain is used (environment, shared config, instance role).
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// ForcePathStyle forces path style addressing of the bucket (required by MinIO).
+	ForcePathStyle bool
 }
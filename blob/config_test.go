@@ -35,6 +35,11 @@ func TestProviderConstants(t *testing.T) {
 			provider: ProviderFileSystem,
 			expected: "filesystem",
 		},
+		{
+			name:     "S3 provider",
+			provider: ProviderS3,
+			expected: "s3",
+		},
 	}
 
 	for _, test := range tests {
@@ -295,6 +295,34 @@ func TestFileSystemStore_Download(t *testing.T) {
 	}
 }
 
+func TestFileSystemStore_Delete(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "blob-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	store := &FileSystemStore{basePath: tempDir}
+	ctx := context.Background()
+
+	if err = store.Upload(ctx, strings.NewReader("content"), "dir/file.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	if err = store.Delete(ctx, "dir/file.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = store.Download(ctx, "dir/file.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+
+	// deleting a file which doesn't exist isn't an error
+	if err = store.Delete(ctx, "dir/file.txt"); err != nil {
+		t.Errorf("unexpected error deleting missing file: %v", err)
+	}
+}
+
 func TestFileSystemStore_GetSignedURL(t *testing.T) {
 	store := &FileSystemStore{basePath: "/tmp"}
 	ctx := context.Background()
@@ -35,4 +35,7 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete deletes a file from the blob store. Deleting a file which doesn't exist isn't an error.
+	Delete(ctx context.Context, filePath string) error
 }
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	// maxS3SignedURLExpiry is the longest validity period of a presigned URL allowed by the signature version 4.
+	maxS3SignedURLExpiry = 7 * 24 * time.Hour
+)
+
+// S3Store is a blob store backed by AWS S3 or any S3 compatible service (e.g. MinIO).
+type S3Store struct {
+	config   Config
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket name is required")
+	}
+
+	if (cfg.S3.AccessKeyID == "") != (cfg.S3.SecretAccessKey == "") {
+		return nil, errors.New("both access key ID and secret access key must be provided")
+	}
+
+	region := cfg.S3.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(cfg.S3.ForcePathStyle),
+	}
+
+	if cfg.S3.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.S3.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(strings.HasPrefix(cfg.S3.Endpoint, "http://"))
+	}
+
+	if cfg.S3.AccessKeyID != "" {
+		// Use static credentials [MinIO and other S3 compatible services]
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.S3.AccessKeyID, cfg.S3.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	client := s3.New(sess)
+
+	return &S3Store{
+		config:   cfg,
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	// The uploader switches to a multipart upload for large files, and aborts it on failure.
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write file %q to S3 bucket %q: %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(
+	_ context.Context,
+	filePath string,
+	expire time.Time,
+	opts ...SignURLOption) (string, error) {
+	config := SignURLConfig{
+		Method: http.MethodGet,
+	}
+
+	for _, opt := range opts {
+		opt.Apply(&config)
+	}
+
+	expiry := time.Until(expire)
+	if expiry <= 0 || expiry > maxS3SignedURLExpiry {
+		return "", fmt.Errorf("signed URL expiry must be in the future and at most %s away", maxS3SignedURLExpiry)
+	}
+
+	req, err := c.newSignRequest(filePath, config)
+	if err != nil {
+		return "", err
+	}
+
+	for _, header := range config.Headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return "", fmt.Errorf("invalid header %q, expected format is \"key:value\"", header)
+		}
+		req.HTTPRequest.Header.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	if len(config.QueryParameters) > 0 {
+		query := req.HTTPRequest.URL.Query()
+		for key, values := range config.QueryParameters {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		req.HTTPRequest.URL.RawQuery = query.Encode()
+	}
+
+	signedURL, err := req.Presign(expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file %q: %w", filePath, err)
+	}
+
+	if config.Insecure {
+		// The URL scheme isn't a part of the signature, so it can be safely changed.
+		u, err := url.Parse(signedURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse signed URL: %w", err)
+		}
+		u.Scheme = "http"
+		signedURL = u.String()
+	}
+
+	return signedURL, nil
+}
+
+func (c *S3Store) newSignRequest(filePath string, config SignURLConfig) (*request.Request, error) {
+	bucket := aws.String(c.config.Bucket)
+	key := aws.String(filePath)
+
+	switch config.Method {
+	case http.MethodGet:
+		req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{Bucket: bucket, Key: key})
+		return req, nil
+	case http.MethodHead:
+		req, _ := c.client.HeadObjectRequest(&s3.HeadObjectInput{Bucket: bucket, Key: key})
+		return req, nil
+	case http.MethodPut:
+		input := &s3.PutObjectInput{Bucket: bucket, Key: key}
+		if config.ContentType != "" {
+			input.ContentType = aws.String(config.ContentType)
+		}
+		req, _ := c.client.PutObjectRequest(input)
+		return req, nil
+	case http.MethodDelete:
+		req, _ := c.client.DeleteObjectRequest(&s3.DeleteObjectInput{Bucket: bucket, Key: key})
+		return req, nil
+	default:
+		return nil, fmt.Errorf("signing URL for method %q: %w", config.Method, ErrNotSupported)
+	}
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to create reader for file %q in bucket %q: %w", filePath, c.config.Bucket, err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	// S3 doesn't return an error when deleting an object which doesn't exist.
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file %q from bucket %q: %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return reqErr.Code() != s3.ErrCodeNoSuchBucket
+	}
+
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
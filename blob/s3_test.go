@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3 compatible service (like a local MinIO)
+// that supports path style put and get of objects.
+type fakeS3 struct {
+	mx      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.objects[r.URL.Path] = data
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+
+				`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Store(t *testing.T) (Store, *fakeS3, *httptest.Server) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(Config{
+		Provider: ProviderS3,
+		Bucket:   "test-bucket",
+		S3: S3Config{
+			Endpoint:        server.URL,
+			AccessKeyID:     "minioadmin",
+			SecretAccessKey: "minioadmin",
+			ForcePathStyle:  true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create S3 store: %v", err)
+	}
+
+	return store, fake, server
+}
+
+func TestNewS3Store_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "empty bucket",
+			config: Config{Provider: ProviderS3},
+		},
+		{
+			name: "access key without secret",
+			config: Config{
+				Provider: ProviderS3,
+				Bucket:   "test-bucket",
+				S3:       S3Config{AccessKeyID: "key"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, err := NewS3Store(test.config)
+			if err == nil {
+				t.Error("expected error but got none")
+			}
+			if store != nil {
+				t.Error("expected nil store on error")
+			}
+		})
+	}
+}
+
+func TestS3Store_UploadDownload(t *testing.T) {
+	ctx := context.Background()
+	store, fake, _ := newTestS3Store(t)
+
+	content := []byte("hello blob")
+	if err := store.Upload(ctx, bytes.NewReader(content), "dir/file.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	if _, ok := fake.objects["/test-bucket/dir/file.txt"]; !ok {
+		t.Fatalf("object not stored under the path style key, objects: %v", fake.objects)
+	}
+
+	rc, err := store.Download(ctx, "dir/file.txt")
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("want=%q got=%q", content, got)
+	}
+
+	_, err = store.Download(ctx, "dir/missing.txt")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if err = store.Delete(ctx, "dir/file.txt"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if _, ok := fake.objects["/test-bucket/dir/file.txt"]; ok {
+		t.Errorf("object wasn't deleted")
+	}
+	if err = store.Delete(ctx, "dir/file.txt"); err != nil {
+		t.Errorf("deleting a missing file must not fail: %v", err)
+	}
+}
+
+func TestS3Store_GetSignedURL(t *testing.T) {
+	ctx := context.Background()
+	store, _, server := newTestS3Store(t)
+
+	if err := store.Upload(ctx, strings.NewReader("signed"), "file.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		expire            time.Time
+		opts              []SignURLOption
+		wantErr           bool
+		wantSignedHeaders string
+		wantQuery         url.Values
+	}{
+		{
+			name:              "get",
+			expire:            time.Now().Add(time.Hour),
+			wantSignedHeaders: "host",
+		},
+		{
+			name:   "put-with-content-type-and-headers",
+			expire: time.Now().Add(time.Hour),
+			opts: []SignURLOption{
+				SignWithMethod(http.MethodPut),
+				SignWithContentType("text/plain"),
+				SignWithHeaders([]string{"x-amz-meta-owner: gitness"}),
+			},
+			wantSignedHeaders: "content-type;host;x-amz-meta-owner",
+		},
+		{
+			name:   "query-parameters",
+			expire: time.Now().Add(time.Hour),
+			opts: []SignURLOption{
+				SignWithQueryParameters(url.Values{"response-content-type": {"text/plain"}}),
+			},
+			wantSignedHeaders: "host",
+			wantQuery:         url.Values{"response-content-type": {"text/plain"}},
+		},
+		{
+			name:    "expired",
+			expire:  time.Now().Add(-time.Minute),
+			wantErr: true,
+		},
+		{
+			name:    "too-long",
+			expire:  time.Now().Add(8 * 24 * time.Hour),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported-method",
+			expire:  time.Now().Add(time.Hour),
+			opts:    []SignURLOption{SignWithMethod(http.MethodPatch)},
+			wantErr: true,
+		},
+		{
+			name:    "malformed-header",
+			expire:  time.Now().Add(time.Hour),
+			opts:    []SignURLOption{SignWithHeaders([]string{"no-colon"})},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signedURL, err := store.GetSignedURL(ctx, "file.txt", test.expire, test.opts...)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(signedURL, server.URL+"/test-bucket/file.txt?") {
+				t.Errorf("unexpected signed URL: %s", signedURL)
+			}
+
+			u, err := url.Parse(signedURL)
+			if err != nil {
+				t.Fatalf("failed to parse signed URL: %v", err)
+			}
+
+			query := u.Query()
+			if query.Get("X-Amz-Signature") == "" {
+				t.Errorf("signed URL is missing the signature: %s", signedURL)
+			}
+			if got := query.Get("X-Amz-SignedHeaders"); got != test.wantSignedHeaders {
+				t.Errorf("signed headers: want=%q got=%q", test.wantSignedHeaders, got)
+			}
+			for key := range test.wantQuery {
+				if query.Get(key) != test.wantQuery.Get(key) {
+					t.Errorf("query parameter %q: want=%q got=%q", key, test.wantQuery.Get(key), query.Get(key))
+				}
+			}
+		})
+	}
+}
+
+func TestS3Store_GetSignedURLDownload(t *testing.T) {
+	ctx := context.Background()
+	store, _, _ := newTestS3Store(t)
+
+	if err := store.Upload(ctx, strings.NewReader("signed"), "file.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	signedURL, err := store.GetSignedURL(ctx, "file.txt", time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to sign URL: %v", err)
+	}
+
+	resp, err := http.Get(signedURL) //nolint:gosec,noctx // test server URL
+	if err != nil {
+		t.Fatalf("failed to download using signed URL: %v", err)
+	}
+	defer resp.Body.Close()
+
+	got, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(got) != "signed" {
+		t.Errorf("unexpected response: status=%d body=%q", resp.StatusCode, got)
+	}
+}
+
+func TestS3Store_Interface(_ *testing.T) {
+	var _ Store = (*S3Store)(nil)
+}
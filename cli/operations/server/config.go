@@ -51,11 +51,12 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	schemeSSH      = "ssh"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP            = "http"
+	schemeHTTPS           = "https"
+	schemeSSH             = "ssh"
+	gitnessHomeDir        = ".gitness"
+	blobDir               = "blob"
+	keywordSearchIndexDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -296,6 +297,13 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		S3: blob.S3Config{
+			Endpoint:        config.BlobStore.S3.Endpoint,
+			Region:          config.BlobStore.S3.Region,
+			AccessKeyID:     config.BlobStore.S3.AccessKeyID,
+			SecretAccessKey: config.BlobStore.S3.SecretAccessKey,
+			ForcePathStyle:  config.BlobStore.S3.ForcePathStyle,
+		},
 	}, nil
 }
 
@@ -342,6 +350,10 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+
+		// notification channels are subject to the same network restrictions as webhooks
+		AllowLoopback:       config.Webhook.AllowLoopback,
+		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 	}
 }
 
@@ -393,6 +405,8 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		PipelineArtifactsRetentionTime:   config.CI.ArtifactRetentionTime,
+		PipelineCachesRetentionTime:      config.CI.CacheRetentionTime,
 	}
 }
 
@@ -412,6 +426,19 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideLocalIndexConfig loads the local keyword search index config from the main config.
+func ProvideLocalIndexConfig(config *types.Config) keywordsearch.LocalIndexConfig {
+	root := config.KeywordSearch.IndexDir
+	if root == "" {
+		root = filepath.Join(config.Git.Root, keywordSearchIndexDir)
+	}
+
+	return keywordsearch.LocalIndexConfig{
+		Root:        root,
+		MaxFileSize: config.KeywordSearch.MaxFileSize,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -105,6 +105,26 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.RegistryCleanup.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register registry cleanup policies job")
+			return err
+		}
+
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue service")
+			return err
+		}
+
+		if err := system.services.Trigger.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline cron trigger service")
+			return err
+		}
+
+		if err := system.services.Notification.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification digest job")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -20,12 +20,14 @@ package main
 import (
 	"context"
 
+	controllerartifact "github.com/harness/gitness/app/api/controller/artifact"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
@@ -60,6 +62,7 @@ import (
 	gitspacedeleteevents "github.com/harness/gitness/app/events/gitspacedelete"
 	gitspaceinfraevents "github.com/harness/gitness/app/events/gitspaceinfra"
 	gitspaceoperationsevents "github.com/harness/gitness/app/events/gitspaceoperations"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
@@ -87,6 +90,7 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -98,18 +102,22 @@ import (
 	"github.com/harness/gitness/app/services/gitspacesettings"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/issuelink"
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqstack"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	reposervice "github.com/harness/gitness/app/services/repo"
@@ -146,9 +154,13 @@ import (
 	replicationevents "github.com/harness/gitness/registry/app/events/replication"
 	registryhelpers "github.com/harness/gitness/registry/app/helpers"
 	"github.com/harness/gitness/registry/app/pkg/docker"
+	registrysigningkey "github.com/harness/gitness/registry/app/services/signingkey"
+	alpineutils "github.com/harness/gitness/registry/app/utils/alpine"
 	cargoutils "github.com/harness/gitness/registry/app/utils/cargo"
+	debianutils "github.com/harness/gitness/registry/app/utils/debian"
 	gopackageutils "github.com/harness/gitness/registry/app/utils/gopackage"
 	registryindex "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -168,6 +180,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideBlobStoreConfig,
 		mailer.WireSet,
 		notification.WireSet,
+		notificationchannel.WireSet,
 		blob.WireSet,
 		dbtx.WireSet,
 		cache.WireSetSpace,
@@ -186,6 +199,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		repo.WireSet,
 		reposettings.WireSet,
 		pullreq.WireSet,
+		issue.WireSet,
 		controllerwebhook.WireSet,
 		controllerwebhook.ProvidePreprocessor,
 		svclabel.WireSet,
@@ -206,6 +220,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitspaceCtrl.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
+		issueevents.WireSet,
 		repoevents.WireSet,
 		ruleevents.WireSet,
 		userevents.WireSet,
@@ -235,6 +250,10 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		mergequeue.WireSet,
+		automerge.WireSet,
+		pullreqstack.WireSet,
+		issuelink.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -243,6 +262,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		logs.WireSet,
 		livelog.WireSet,
 		controllerlogs.WireSet,
+		controllerartifact.WireSet,
 		secret.WireSet,
 		connector.WireSet,
 		connectorservice.WireSet,
@@ -268,6 +288,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		codeowners.WireSet,
 		gitspaceevent.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
+		cliserver.ProvideLocalIndexConfig,
 		keywordsearch.WireSet,
 		rules.WireSet,
 		rules.ProvideValidator,
@@ -313,12 +334,16 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usage.WireSet,
 		registryevents.WireSet,
 		registrywebhooks.WireSet,
+		registrycleanup.WireSet,
 		gitspacedeleteevents.WireSet,
 		gitspacedeleteeventservice.WireSet,
 		registryindex.WireSet,
 		cliserver.ProvideBranchConfig,
 		branch.WireSet,
 		cargoutils.WireSet,
+		debianutils.WireSet,
+		alpineutils.WireSet,
+		registrysigningkey.WireSet,
 		gopackageutils.WireSet,
 		registrypostporcessingevents.ProvideAsyncProcessingReporter,
 		registrypostporcessingevents.ProvideReaderFactory,
@@ -9,12 +9,14 @@ package main
 import (
 	"context"
 
+	artifact2 "github.com/harness/gitness/app/api/controller/artifact"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	connector2 "github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	gitspace2 "github.com/harness/gitness/app/api/controller/gitspace"
 	infraprovider3 "github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
@@ -49,6 +51,7 @@ import (
 	events8 "github.com/harness/gitness/app/events/gitspacedelete"
 	events6 "github.com/harness/gitness/app/events/gitspaceinfra"
 	events7 "github.com/harness/gitness/app/events/gitspaceoperations"
+	events14 "github.com/harness/gitness/app/events/issue"
 	events9 "github.com/harness/gitness/app/events/pipeline"
 	events10 "github.com/harness/gitness/app/events/pullreq"
 	events3 "github.com/harness/gitness/app/events/repo"
@@ -77,6 +80,7 @@ import (
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/app/services/aitaskevent"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -91,18 +95,22 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/issuelink"
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/notificationchannel"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqstack"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	repo2 "github.com/harness/gitness/app/services/repo"
@@ -135,7 +143,10 @@ import (
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	api2 "github.com/harness/gitness/registry/app/api"
+	alpine3 "github.com/harness/gitness/registry/app/api/controller/pkg/alpine"
 	cargo3 "github.com/harness/gitness/registry/app/api/controller/pkg/cargo"
+	composer2 "github.com/harness/gitness/registry/app/api/controller/pkg/composer"
+	debian3 "github.com/harness/gitness/registry/app/api/controller/pkg/debian"
 	"github.com/harness/gitness/registry/app/api/controller/pkg/generic"
 	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
 	huggingface2 "github.com/harness/gitness/registry/app/api/controller/pkg/huggingface"
@@ -143,6 +154,8 @@ import (
 	nuget2 "github.com/harness/gitness/registry/app/api/controller/pkg/nuget"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	rpm2 "github.com/harness/gitness/registry/app/api/controller/pkg/rpm"
+	rubygems2 "github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	terraform2 "github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
 	huggingface3 "github.com/harness/gitness/registry/app/api/handler/huggingface"
 	"github.com/harness/gitness/registry/app/api/router"
 	"github.com/harness/gitness/registry/app/events/artifact"
@@ -150,8 +163,11 @@ import (
 	"github.com/harness/gitness/registry/app/events/replication"
 	"github.com/harness/gitness/registry/app/helpers"
 	"github.com/harness/gitness/registry/app/pkg"
+	alpine2 "github.com/harness/gitness/registry/app/pkg/alpine"
 	"github.com/harness/gitness/registry/app/pkg/base"
 	cargo2 "github.com/harness/gitness/registry/app/pkg/cargo"
+	"github.com/harness/gitness/registry/app/pkg/composer"
+	debian2 "github.com/harness/gitness/registry/app/pkg/debian"
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
@@ -163,14 +179,20 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/pkg/quarantine"
 	"github.com/harness/gitness/registry/app/pkg/rpm"
+	"github.com/harness/gitness/registry/app/pkg/rubygems"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
 	publicaccess2 "github.com/harness/gitness/registry/app/services/publicaccess"
 	refcache2 "github.com/harness/gitness/registry/app/services/refcache"
+	"github.com/harness/gitness/registry/app/services/signingkey"
 	cache2 "github.com/harness/gitness/registry/app/store/cache"
 	database2 "github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/app/utils/alpine"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/app/utils/debian"
 	gopackage3 "github.com/harness/gitness/registry/app/utils/gopackage"
 	"github.com/harness/gitness/registry/gc"
 	asyncprocessing2 "github.com/harness/gitness/registry/services/asyncprocessing"
+	cleanup2 "github.com/harness/gitness/registry/services/cleanup"
 	webhook3 "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -240,7 +262,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	favoriteStore := database.ProvideFavoriteStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore)
+	notificationPreferenceStore := database.ProvideNotificationPreferenceStore(db)
+	watchStore := database.ProvideWatchStore(db)
+	streamer := sse.ProvideEventsStreaming(pubSub)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore, spaceFinder, notificationPreferenceStore, watchStore, streamer)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
@@ -288,8 +313,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	localIndexConfig := server.ProvideLocalIndexConfig(config)
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(localIndexConfig, gitInterface)
+	if err != nil {
+		return nil, err
+	}
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
@@ -318,7 +346,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	labelStore := database.ProvideLabelStore(db)
 	labelValueStore := database.ProvideLabelValueStore(db)
 	pullReqLabelAssignmentStore := database.ProvidePullReqLabelStore(db)
-	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, spaceFinder)
+	issueLabelAssignmentStore := database.ProvideIssueLabelStore(db)
+	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, issueLabelAssignmentStore, spaceFinder)
 	instrumentService := instrument.ProvideService()
 	userGroupStore := database.ProvideUserGroupStore(db)
 	usergroupService := usergroup.ProvideService()
@@ -340,8 +369,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	remoteauthService := remoteauth.ProvideRemoteAuth(tokenStore, principalStore)
 	lfsController := lfs.ProvideController(authorizer, repoFinder, repoStore, principalStore, lfsObjectStore, blobStore, remoteauthService, provider, settingsService)
 	keyfetcherService := keyfetcher.ProvideService(publicKeyStore)
-	signatureVerifyService := publickey.ProvideSignatureVerifyService(principalStore, keyfetcherService, gitSignatureResultStore)
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService)
+	signatureVerifyService, err := publickey.ProvideSignatureVerifyService(config, principalStore, keyfetcherService, gitSignatureResultStore)
+	if err != nil {
+		return nil, err
+	}
+	notificationConfig := server.ProvideNotificationConfig(config)
+	notificationChannelStore := database.ProvideNotificationChannelStore(db)
+	notificationchannelService := notificationchannel.ProvideService(notificationConfig, notificationChannelStore, encrypter)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService, notificationchannelService, watchStore)
 	reposettingsController := reposettings.ProvideController(authorizer, repoFinder, settingsService, auditService)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -352,14 +387,18 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore)
 	commitService := commit.ProvideService(gitInterface)
 	fileService := file.ProvideService(gitInterface)
-	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
+	templateVersionStore := database.ProvideTemplateVersionStore(db)
+	converterService := converter.ProvideService(fileService, publicaccessService, spaceFinder, templateStore, templateVersionStore)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService, gitInterface)
 	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, stageStore, pipelineStore, repoFinder)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, pipelineStore, stageStore, stepStore, logStore, logStream, repoFinder)
+	pipelineArtifactStore := database.ProvidePipelineArtifactStore(db)
+	pipelineCacheStore := database.ProvidePipelineCacheStore(db)
+	artifactController := artifact2.ProvideController(transactor, authorizer, repoFinder, pipelineStore, executionStore, pipelineArtifactStore, pipelineCacheStore, blobStore, config)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
 	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
@@ -449,7 +488,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space2.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, spaceService)
+	spaceController := space2.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, spaceService, notificationchannelService, watchStore)
 	reporter7, err := events9.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -460,7 +499,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	scmService := connector.ProvideSCMConnectorHandler(secretStore)
 	connectorService := connector.ProvideConnectorHandler(secretStore, scmService)
 	connectorController := connector2.ProvideController(connectorStore, connectorService, authorizer, spaceFinder)
-	templateController := template.ProvideController(templateStore, authorizer, spaceFinder)
+	templateController := template.ProvideController(templateStore, templateVersionStore, authorizer, spaceFinder)
 	pluginController := plugin.ProvideController(pluginStore)
 	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
@@ -487,12 +526,41 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
 	branchStore := database.ProvideBranchStore(db)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter8, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, usergroupService, branchStore, usergroupResolver)
+	readerFactory2, err := events12.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, readerFactory, eventsReaderFactory, readerFactory2, reporter8, gitInterface, provider, lockerLocker, jobScheduler, executor, repoFinder, pullReqStore, pullReqActivityStore, checkStore, mergeQueueStore, principalInfoCache, protectionManager, streamer)
+	if err != nil {
+		return nil, err
+	}
+	autoMergeStore := database.ProvideAutoMergeStore(db)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter8, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, usergroupService, branchStore, usergroupResolver, mergequeueService, autoMergeStore, signatureVerifyService)
+	issueStore := database.ProvideIssueStore(db, principalInfoCache)
+	issueActivityStore := database.ProvideIssueActivityStore(db, principalInfoCache)
+	issueAssigneeStore := database.ProvideIssueAssigneeStore(db, principalInfoCache)
+	milestoneStore := database.ProvideMilestoneStore(db)
+	reporter11, err := events14.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	issueController := issue.ProvideController(transactor, authorizer, issueStore, issueActivityStore, issueAssigneeStore, milestoneStore, repoStore, principalStore, principalInfoCache, repoFinder, labelService, reporter11, streamer)
+	readerFactory13, err := events14.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
 	urlProvider := webhook.ProvideURLProvider(ctx)
 	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore)
+	mailerMailer := mailer.ProvideMailClient(config)
+	notificationDigestStore := database.ProvideNotificationDigestStore(db)
+	mailClient := notification.ProvideMailClient(mailerMailer, notificationPreferenceStore, notificationDigestStore, spaceStore, repoStore)
+	chatClient := notification.ProvideChatClient(notificationConfig, notificationChannelStore, spaceStore, repoStore, encrypter)
+	sseClient := notification.ProvideSSEClient(streamer, authorizer, principalStore)
+	notificationClient := notification.ProvideClient(mailClient, chatClient, sseClient)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, readerFactory13, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, issueStore, issueActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore, jobScheduler, executor, notificationClient)
 	if err != nil {
 		return nil, err
 	}
@@ -514,7 +582,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter9, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter9, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService, signatureVerifyService)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
 	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, spaceFinder, authorizer, usergroupService)
@@ -589,11 +657,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cleanupPolicyRepository := database2.ProvideCleanupPolicyDao(db, transactor)
 	webhooksRepository := database2.ProvideWebhookDao(db)
 	webhooksExecutionRepository := database2.ProvideWebhookExecutionDao(db)
-	readerFactory2, err := artifact.ProvideReaderFactory(eventsSystem)
+	readerFactory3, err := artifact.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	service2, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory2, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
+	service2, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory3, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -606,8 +674,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	registryHelper := cargo.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder)
 	interfacesRegistryHelper := helpers.ProvideRegistryHelper(artifactRepository, fileManager, imageRepository, artifactReporter, asyncprocessingReporter, transactor, provider, config)
-	packageWrapper := helpers.ProvidePackageWrapperProvider(interfacesRegistryHelper, registryFinder, registryHelper)
-	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, packageWrapper, cacheService, finder)
+	signingKeyRepository := database2.ProvideSigningKeyDao(db)
+	signingkeyService := signingkey.ProvideService(signingKeyRepository, encrypter)
+	debianRegistryHelper := debian.LocalRegistryHelperProvider(fileManager, artifactRepository, nodesRepository, spaceFinder, signingkeyService)
+	alpineRegistryHelper := alpine.LocalRegistryHelperProvider(fileManager, artifactRepository, nodesRepository, spaceFinder, signingkeyService)
+	packageWrapper := helpers.ProvidePackageWrapperProvider(interfacesRegistryHelper, registryFinder, registryHelper, debianRegistryHelper, alpineRegistryHelper)
+	apiController := router.APIControllerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, packageWrapper, cacheService, finder)
+	apiHandler := router.APIHandlerProvider(apiController, authenticator)
 	packageTagRepository := database2.ProvidePackageTagDao(db)
 	localBase := base.LocalBaseProvider(registryRepository, fileManager, transactor, imageRepository, artifactRepository, nodesRepository, packageTagRepository, authorizer, spaceFinder)
 	mavenDBStore := maven.DBStoreProvider(registryRepository, imageRepository, artifactRepository, spaceStore, bandwidthStatRepository, downloadStatRepository, nodesRepository, upstreamProxyConfigRepository)
@@ -658,17 +731,40 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	huggingfaceLocalRegistry := huggingface.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
 	huggingfaceController := huggingface2.ProvideController(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, huggingfaceLocalRegistry, finder)
 	huggingfaceHandler := huggingface3.ProvideHandler(huggingfaceController, packagesHandler)
-	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler)
-	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4)
-	readerFactory3, err := events3.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory3)
-	if err != nil {
-		return nil, err
-	}
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
+	debianDebianRegistryHelper := debian2.RegistryHelperProvider(localBase, fileManager, artifactRepository, asyncprocessingReporter)
+	debianLocalRegistry := debian2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, debianDebianRegistryHelper)
+	debianProxy := debian2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, debianDebianRegistryHelper, spaceFinder, secretService)
+	debianController := debian3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, debianLocalRegistry, debianProxy, signingkeyService)
+	debianHandler := api2.NewDebianHandlerProvider(debianController, packagesHandler)
+	alpineAlpineRegistryHelper := alpine2.RegistryHelperProvider(localBase, fileManager, artifactRepository, asyncprocessingReporter)
+	alpineLocalRegistry := alpine2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, alpineAlpineRegistryHelper)
+	alpineProxy := alpine2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, alpineAlpineRegistryHelper, spaceFinder, secretService)
+	alpineController := alpine3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, alpineLocalRegistry, alpineProxy, signingkeyService)
+	alpineHandler := api2.NewAlpineHandlerProvider(alpineController, packagesHandler)
+	rubygemsRegistryHelper := rubygems.RegistryHelperProvider(localBase, fileManager)
+	rubygemsLocalRegistry := rubygems.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, rubygemsRegistryHelper)
+	rubygemsProxy := rubygems.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, rubygemsRegistryHelper, spaceFinder, secretService)
+	rubygemsController := rubygems2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, rubygemsLocalRegistry, rubygemsProxy, finder)
+	rubygemsHandler := api2.NewRubyGemsHandlerProvider(rubygemsController, packagesHandler)
+	composerRegistryHelper := composer.RegistryHelperProvider(localBase, fileManager)
+	composerLocalRegistry := composer.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, composerRegistryHelper)
+	composerProxy := composer.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, composerRegistryHelper, spaceFinder, secretService)
+	composerController := composer2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, composerLocalRegistry, composerProxy, finder)
+	composerHandler := api2.NewComposerHandlerProvider(composerController, packagesHandler)
+	terraformLocalRegistry := terraform.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, localRegistryHelper, signingkeyService)
+	terraformController := terraform2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, terraformLocalRegistry, finder, config)
+	terraformHandler := api2.NewTerraformHandlerProvider(terraformController, packagesHandler)
+	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler, debianHandler, alpineHandler, rubygemsHandler, composerHandler, terraformHandler)
+	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4, terraformHandler)
+	readerFactory4, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory4)
+	if err != nil {
+		return nil, err
+	}
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, artifactController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, issueController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
 	serverServer := server2.ProvideServer(config, routerRouter)
 	sshAuthService := publickey.ProvideSSHAuthService(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, sshAuthService, repoController, lfsController)
@@ -681,7 +777,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
 	triggerConfig := server.ProvideTriggerConfig(config)
-	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoFinder, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
+	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoFinder, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory, lockerLocker, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
@@ -689,15 +785,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory4, err := events2.ProvideReaderFactory(eventsSystem)
+	readerFactory5, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory5, err := events4.ProvideReaderFactory(eventsSystem)
+	readerFactory6, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory4, readerFactory3, eventsReaderFactory, readerFactory5, publicaccessService, spaceFinder, repoFinder)
+	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory5, readerFactory4, eventsReaderFactory, readerFactory6, publicaccessService, spaceFinder, repoFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -709,67 +805,64 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory3, repoStore, provider, gitInterface, lockerLocker)
+	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory4, repoStore, provider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, pipelineArtifactStore, pipelineCacheStore, blobStore)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
-	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, readerFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider, mailerMailer, notificationDigestStore, jobScheduler, executor, spaceStore, watchStore, authorizer, principalStore)
 	if err != nil {
 		return nil, err
 	}
 	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
-	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory3, repoStore, indexer)
+	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory4, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
 	gitspaceeventConfig := server.ProvideGitspaceEventConfig(config)
-	readerFactory6, err := events5.ProvideReaderFactory(eventsSystem)
+	readerFactory7, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory6, gitspaceEventStore)
+	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory7, gitspaceEventStore)
 	if err != nil {
 		return nil, err
 	}
 	gitspacedeleteeventConfig := server.ProvideGitspaceDeleteEventConfig(config)
-	readerFactory7, err := events8.ProvideReaderFactory(eventsSystem)
+	readerFactory8, err := events8.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory7, gitspaceService)
+	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory8, gitspaceService)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory8, err := events6.ProvideReaderFactory(eventsSystem)
+	readerFactory9, err := events6.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory8, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, orchestratorOrchestrator, gitspaceService, reporter3)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory9, err := events7.ProvideReaderFactory(eventsSystem)
+	readerFactory10, err := events7.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory10, orchestratorOrchestrator, gitspaceService, reporter3)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory10, err := events13.ProvideReaderFactory(eventsSystem)
+	readerFactory11, err := events13.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	aiTaskStore := database.ProvideAITaskStore(db)
-	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory10, orchestratorOrchestrator, gitspaceService, aiTaskStore)
+	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, aiTaskStore)
 	if err != nil {
 		return nil, err
 	}
@@ -789,16 +882,30 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	rpmHelper := asyncprocessing2.ProvideRpmHelper(fileManager, artifactRepository, upstreamProxyConfigRepository, spaceFinder, secretService, registryRepository)
 	gopackageRegistryHelper := gopackage3.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder, registryFinder)
-	readerFactory11, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
+	readerFactory12, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	asyncprocessingConfig := asyncprocessing2.ProvideRegistryPostProcessingConfig(config)
-	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory11, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
+	cleanupConfig2 := cleanup2.ProvideConfig(config)
+	cleanupService2 := cleanup2.ProvideService(cleanupConfig2, jobScheduler, executor, transactor, registryRepository, cleanupPolicyRepository, artifactRepository, manifestRepository, layerRepository, spaceFinder, gcService, apiController)
+	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory12, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
+	if err != nil {
+		return nil, err
+	}
+	automergeService, err := automerge.ProvideService(ctx, config, eventsReaderFactory, readerFactory2, reporter8, pullreqController, autoMergeStore, pullReqStore, pullReqActivityStore, principalStore, repoFinder, streamer)
+	if err != nil {
+		return nil, err
+	}
+	pullreqstackService, err := pullreqstack.ProvideService(ctx, config, eventsReaderFactory, pullreqController, repoController, gitInterface, pullReqStore, pullReqActivityStore, principalStore, repoFinder, streamer)
+	if err != nil {
+		return nil, err
+	}
+	issuelinkService, err := issuelink.ProvideService(ctx, config, readerFactory, eventsReaderFactory, issueController, gitInterface, pullReqStore, repoFinder)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2, branchService, asyncprocessingService)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2, branchService, asyncprocessingService, cleanupService2, mergequeueService, automergeService, pullreqstackService, issuelinkService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
@@ -669,6 +669,46 @@ func (g *Git) DiffFileName(ctx context.Context,
 	return parseLinesToSlice(stdout.Bytes()), nil
 }
 
+// DiffRaw returns the list of files changed between the two references, as reported by git diff --raw.
+// The alternate object directories are used to access objects that are not yet in the repository,
+// e.g. objects in the quarantine directory during the pre-receive hook.
+func (g *Git) DiffRaw(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	baseRef string,
+	headRef string,
+) ([]parser.DiffRawFile, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("diff",
+		command.WithFlag("--raw"),
+		command.WithFlag("-z"),
+		command.WithFlag("--find-renames"),
+		command.WithFlag("--no-abbrev"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+		command.WithArg(baseRef, headRef),
+	)
+
+	stdout := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to trigger diff command")
+	}
+
+	files, err := parser.DiffRaw(stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse raw diff output: %w", err)
+	}
+
+	return files, nil
+}
+
 // GetDiffShortStat counts number of changed files, number of additions and deletions.
 func GetDiffShortStat(
 	ctx context.Context,
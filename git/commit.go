@@ -221,6 +221,72 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListCommitsInRangeParams struct {
+	ReadParams
+	// GitREF is the reference (usually commit SHA) from which the commits are listed.
+	GitREF string
+	// After is the optional reference (usually commit SHA) up to which the commits are listed (exclusive).
+	After string
+	// Page is the page of commits to return, each page holding Limit commits. Pages start from 1.
+	Page int
+	// Limit is the maximum number of returned commits.
+	Limit int
+}
+
+type ListCommitsInRangeOutput struct {
+	Commits []Commit
+}
+
+// ListCommitsInRange lists the commits between two references.
+// Unlike ListCommits it supports the alternate object directories,
+// so it can be used to read the commits from the quarantine area in pre-receive git hooks.
+func (s *Service) ListCommitsInRange(
+	ctx context.Context,
+	params ListCommitsInRangeParams,
+) (ListCommitsInRangeOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ListCommitsInRangeOutput{}, err
+	}
+
+	if params.GitREF == "" {
+		return ListCommitsInRangeOutput{}, errors.InvalidArgument("git reference must be provided")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHAs, err := s.git.ListCommitSHAs(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		params.Page,
+		params.Limit,
+		api.CommitFilter{AfterRef: params.After},
+	)
+	if err != nil {
+		return ListCommitsInRangeOutput{}, fmt.Errorf("failed to list commit SHAs: %w", err)
+	}
+
+	gitCommits, err := api.CatFileCommits(ctx, repoPath, params.AlternateObjectDirs, commitSHAs)
+	if err != nil {
+		return ListCommitsInRangeOutput{}, fmt.Errorf("failed to read commits: %w", err)
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(&gitCommits[i])
+		if err != nil {
+			return ListCommitsInRangeOutput{}, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+
+		commits[i] = *commit
+	}
+
+	return ListCommitsInRangeOutput{
+		Commits: commits,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
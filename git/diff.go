@@ -455,3 +455,95 @@ func (s *Service) DiffFileNames(ctx context.Context, params *DiffParams) (DiffFi
 		Files: fileNames,
 	}, nil
 }
+
+type ListChangedFilesParams struct {
+	ReadParams
+	// BaseRef is the reference the changes are compared against. If empty, all files of the HeadRef are listed.
+	BaseRef string
+	HeadRef string
+}
+
+func (p ListChangedFilesParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.HeadRef == "" {
+		return errors.InvalidArgument("head ref cannot be empty")
+	}
+
+	return nil
+}
+
+type ChangedFile struct {
+	Path string
+	// OldPath is set only for renamed and copied files.
+	OldPath string
+	Status  enum.FileDiffStatus
+	// SHA is the blob SHA of the new version of the file. It's sha.Nil for deleted files.
+	SHA sha.SHA
+}
+
+type ListChangedFilesOutput struct {
+	Files []ChangedFile
+}
+
+// ListChangedFiles lists the files changed between the two references, without the merge base.
+// Unlike DiffFileNames it supports alternate object directories, so it can be used in the pre-receive hook.
+func (s *Service) ListChangedFiles(
+	ctx context.Context,
+	params ListChangedFilesParams,
+) (ListChangedFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ListChangedFilesOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseRef := params.BaseRef
+	if baseRef == "" {
+		baseRef = sha.EmptyTree.String()
+	}
+
+	entries, err := s.git.DiffRaw(ctx, repoPath, params.AlternateObjectDirs, baseRef, params.HeadRef)
+	if err != nil {
+		return ListChangedFilesOutput{}, fmt.Errorf("failed to list changed files between '%s' and '%s': %w",
+			baseRef, params.HeadRef, err)
+	}
+
+	files := make([]ChangedFile, len(entries))
+	for i, entry := range entries {
+		blobSHA, err := sha.New(entry.NewBlobSHA)
+		if err != nil {
+			return ListChangedFilesOutput{}, fmt.Errorf("failed to parse blob sha of %q: %w", entry.Path, err)
+		}
+
+		files[i] = ChangedFile{
+			Path:    entry.Path,
+			OldPath: entry.OldPath,
+			Status:  mapDiffRawStatus(entry.Status),
+			SHA:     blobSHA,
+		}
+	}
+
+	return ListChangedFilesOutput{
+		Files: files,
+	}, nil
+}
+
+func mapDiffRawStatus(status parser.DiffStatus) enum.FileDiffStatus {
+	switch status {
+	case parser.DiffStatusAdded:
+		return enum.FileDiffStatusAdded
+	case parser.DiffStatusDeleted:
+		return enum.FileDiffStatusDeleted
+	case parser.DiffStatusRenamed:
+		return enum.FileDiffStatusRenamed
+	case parser.DiffStatusCopied:
+		return enum.FileDiffStatusCopied
+	case parser.DiffStatusModified, parser.DiffStatusType:
+		return enum.FileDiffStatusModified
+	default:
+		return enum.FileDiffStatusUndefined
+	}
+}
@@ -47,6 +47,10 @@ type MergeParams struct {
 	// If HeadBranchExpectedSHA is older than the HeadBranch latest SHA then merge will fail.
 	HeadBranchExpectedSHA sha.SHA
 
+	// UpstreamSHA is used only with the Rebase method. If provided, only the commits after it are rebased
+	// onto the base, as with `git rebase --onto <base> <upstream>`. By default, the merge base is the upstream.
+	UpstreamSHA sha.SHA
+
 	// Merge is the message of the commit that would be created. Ignored for Rebase and FastForward.
 	Message string
 
@@ -207,6 +211,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	// The commits to rebase are determined by the merge base, so the upstream replaces it.
+	methodMergeBaseSHA := mergeBaseCommitSHA
+	if mergeMethod == enum.MergeMethodRebase && !params.UpstreamSHA.IsEmpty() {
+		methodMergeBaseSHA = params.UpstreamSHA
+	}
+
 	// find short stat and number of commits
 
 	shortStat, err := s.git.DiffShortStat(
@@ -269,7 +279,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 				Author:       &author,
 				Committer:    &committer,
 				Message:      message,
-				MergeBaseSHA: mergeBaseCommitSHA,
+				MergeBaseSHA: methodMergeBaseSHA,
 				TargetSHA:    baseCommitSHA,
 				SourceSHA:    headCommitSHA,
 			})
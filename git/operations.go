@@ -428,7 +428,7 @@ func (s *Service) processAction(
 		modifiedPath, objectSHA, err =
 			r.MoveFile(ctx, treeishSHA, filePath, action.SHA, filePermissionDefault, action.Payload)
 	case DeleteAction:
-		err = r.DeleteFile(ctx, filePath)
+		err = r.DeleteFile(ctx, treeishSHA, filePath, action.SHA)
 	case PatchTextAction:
 		return "", sha.None, fmt.Errorf("action %s not supported by this method", action.Action)
 	default:
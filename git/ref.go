@@ -109,6 +109,7 @@ func GetRefPath(refName string, refType enum.RefType) (string, error) {
 		refPullReqPrefix      = "refs/pullreq/"
 		refPullReqHeadSuffix  = "/head"
 		refPullReqMergeSuffix = "/merge"
+		refMergeQueuePrefix   = "refs/merge-queue/"
 	)
 
 	switch refType {
@@ -122,6 +123,8 @@ func GetRefPath(refName string, refType enum.RefType) (string, error) {
 		return refPullReqPrefix + refName + refPullReqHeadSuffix, nil
 	case enum.RefTypePullReqMerge:
 		return refPullReqPrefix + refName + refPullReqMergeSuffix, nil
+	case enum.RefTypeMergeQueue:
+		return refMergeQueuePrefix + refName, nil
 	default:
 		return "", errors.InvalidArgumentf("provided reference type '%s' is invalid", refType)
 	}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedrepo
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+)
+
+func TestSharedRepo_DeleteFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	const filePath = "dir/file.txt"
+
+	otherSHA := sha.Must("1111111111111111111111111111111111111111")
+
+	tests := []struct {
+		name         string
+		path         string
+		sha          func(blobSHA sha.SHA) sha.SHA
+		wantNotFound bool
+		wantConflict bool
+	}{
+		{name: "no-sha", path: filePath, sha: func(sha.SHA) sha.SHA { return sha.None }},
+		{name: "matching-sha", path: filePath, sha: func(blobSHA sha.SHA) sha.SHA { return blobSHA }},
+		{
+			name:         "stale-sha",
+			path:         filePath,
+			sha:          func(sha.SHA) sha.SHA { return otherSHA },
+			wantConflict: true,
+		},
+		{
+			name:         "missing-file",
+			path:         "dir/other.txt",
+			sha:          func(sha.SHA) sha.SHA { return sha.None },
+			wantNotFound: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			sourcePath := t.TempDir()
+			if out, err := exec.Command("git", "init", "--bare", sourcePath).CombinedOutput(); err != nil {
+				t.Fatalf("failed to init source repository: %s: %s", err, out)
+			}
+
+			r, err := NewSharedRepo(t.TempDir(), sourcePath)
+			if err != nil {
+				t.Fatalf("failed to create shared repository: %s", err)
+			}
+			defer r.Close(ctx)
+
+			if err = r.Init(ctx); err != nil {
+				t.Fatalf("failed to init shared repository: %s", err)
+			}
+
+			blobSHA, err := r.CreateFile(ctx, sha.None, filePath, "100644", []byte("content"))
+			if err != nil {
+				t.Fatalf("failed to create file: %s", err)
+			}
+
+			treeSHA, err := r.WriteTree(ctx)
+			if err != nil {
+				t.Fatalf("failed to write tree: %s", err)
+			}
+
+			err = r.DeleteFile(ctx, treeSHA, test.path, test.sha(blobSHA))
+			if got := errors.IsNotFound(err); got != test.wantNotFound {
+				t.Errorf("expected not found error %t, got %v", test.wantNotFound, err)
+			}
+			if got := errors.IsConflict(err); got != test.wantConflict {
+				t.Errorf("expected conflict error %t, got %v", test.wantConflict, err)
+			}
+			if !test.wantNotFound && !test.wantConflict && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
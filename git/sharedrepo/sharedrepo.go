@@ -606,7 +606,12 @@ func (r *SharedRepo) MoveFile(
 	return newPath, fileHash, nil
 }
 
-func (r *SharedRepo) DeleteFile(ctx context.Context, filePath string) error {
+func (r *SharedRepo) DeleteFile(
+	ctx context.Context,
+	treeishSHA sha.SHA,
+	filePath string,
+	objectSHA sha.SHA,
+) error {
 	filesInIndex, err := r.LsFiles(ctx, filePath)
 	if err != nil {
 		return fmt.Errorf("deleteFile: listing files error: %w", err)
@@ -615,6 +620,18 @@ func (r *SharedRepo) DeleteFile(ctx context.Context, filePath string) error {
 		return errors.NotFoundf("file path %s not found", filePath)
 	}
 
+	// If a SHA was given, the file is deleted only if it wasn't changed in the meantime.
+	if !objectSHA.IsEmpty() {
+		entry, err := api.GetTreeNode(ctx, r.repoPath, treeishSHA.String(), filePath, false)
+		if err != nil {
+			return fmt.Errorf("deleteFile: failed to get tree entry for path %s: %w", filePath, err)
+		}
+		if !objectSHA.Equal(entry.SHA) {
+			return errors.Conflictf("sha does not match for path %s [given: %s, expected: %s]",
+				filePath, objectSHA, entry.SHA)
+		}
+	}
+
 	if err = r.RemoveFilesFromIndex(ctx, filePath); err != nil {
 		return fmt.Errorf("deleteFile: remove object error: %w", err)
 	}
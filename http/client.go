@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package webhook
+package http
 
 import (
 	"context"
@@ -26,11 +26,14 @@ import (
 )
 
 var (
-	errLoopbackNotAllowed       = errors.New("loopback not allowed")
-	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
+	ErrLoopbackNotAllowed       = errors.New("loopback not allowed")
+	ErrPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
+// NewClient returns an http client for calling user provided URLs (e.g. webhooks).
+// Connections to loopback and private network addresses are rejected unless explicitly allowed,
+// and redirects are not followed.
+func NewClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
 	// Clone http.DefaultTransport (used by http.DefaultClient)
 	tr := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck
 
@@ -78,11 +81,11 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 		}
 
 		if !allowLoopback && tcpAddr.IP.IsLoopback() {
-			return nil, errLoopbackNotAllowed
+			return nil, ErrLoopbackNotAllowed
 		}
 
 		if !allowPrivateNetwork && tcpAddr.IP.IsPrivate() {
-			return nil, errPrivateNetworkNotAllowed
+			return nil, ErrPrivateNetworkNotAllowed
 		}
 
 		// otherwise keep connection
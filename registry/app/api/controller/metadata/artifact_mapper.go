@@ -162,6 +162,16 @@ func toPackageType(packageTypeStr string) (artifactapi.PackageType, error) {
 		return artifactapi.PackageTypeGO, nil
 	case string(artifactapi.PackageTypeHUGGINGFACE):
 		return artifactapi.PackageTypeHUGGINGFACE, nil
+	case string(artifactapi.PackageTypeDEBIAN):
+		return artifactapi.PackageTypeDEBIAN, nil
+	case string(artifactapi.PackageTypeALPINE):
+		return artifactapi.PackageTypeALPINE, nil
+	case string(artifactapi.PackageTypeRUBYGEMS):
+		return artifactapi.PackageTypeRUBYGEMS, nil
+	case string(artifactapi.PackageTypeCOMPOSER):
+		return artifactapi.PackageTypeCOMPOSER, nil
+	case string(artifactapi.PackageTypeTERRAFORM):
+		return artifactapi.PackageTypeTERRAFORM, nil
 	default:
 		return "", errors.New("invalid package type")
 	}
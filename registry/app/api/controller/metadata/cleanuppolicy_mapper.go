@@ -56,12 +56,22 @@ func getCleanupPolicyEntity(
 	repoID int64,
 ) *types.CleanupPolicy {
 	expireTime := time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
+	var keepLast int
+	if cleanupPolicy.KeepLast != nil {
+		keepLast = *cleanupPolicy.KeepLast
+	}
+	var downloadedWithin time.Duration
+	if cleanupPolicy.DownloadedWithinDays != nil {
+		downloadedWithin = time.Duration(*cleanupPolicy.DownloadedWithinDays) * 24 * time.Hour
+	}
 	return &types.CleanupPolicy{
-		Name:          *cleanupPolicy.Name,
-		VersionPrefix: *cleanupPolicy.VersionPrefix,
-		PackagePrefix: *cleanupPolicy.PackagePrefix,
-		ExpiryTime:    expireTime.Milliseconds(),
-		RegistryID:    repoID,
+		Name:             *cleanupPolicy.Name,
+		VersionPrefix:    *cleanupPolicy.VersionPrefix,
+		PackagePrefix:    *cleanupPolicy.PackagePrefix,
+		ExpiryTime:       expireTime.Milliseconds(),
+		KeepLast:         keepLast,
+		DownloadedWithin: downloadedWithin.Milliseconds(),
+		RegistryID:       repoID,
 	}
 }
 
@@ -70,12 +80,16 @@ func getCleanupPolicyDto(
 ) *artifact.CleanupPolicy {
 	packagePrefix := cleanupPolicy.PackagePrefix
 	versionPrefix := cleanupPolicy.VersionPrefix
-	expiryDays := int(time.Duration(cleanupPolicy.ExpiryTime).Hours() / 24)
+	expiryDays := int((time.Duration(cleanupPolicy.ExpiryTime) * time.Millisecond).Hours() / 24)
+	keepLast := cleanupPolicy.KeepLast
+	downloadedWithinDays := int((time.Duration(cleanupPolicy.DownloadedWithin) * time.Millisecond).Hours() / 24)
 
 	return &artifact.CleanupPolicy{
-		Name:          &cleanupPolicy.Name,
-		VersionPrefix: &versionPrefix,
-		PackagePrefix: &packagePrefix,
-		ExpireDays:    &expiryDays,
+		Name:                 &cleanupPolicy.Name,
+		VersionPrefix:        &versionPrefix,
+		PackagePrefix:        &packagePrefix,
+		ExpireDays:           &expiryDays,
+		KeepLast:             &keepLast,
+		DownloadedWithinDays: &downloadedWithinDays,
 	}
 }
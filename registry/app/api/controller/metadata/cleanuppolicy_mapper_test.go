@@ -69,6 +69,16 @@ func TestGetCleanupPolicyEntity_FunctionExists(t *testing.T) {
 		assert.Equal(t, []string{"pkg-"}, entity.PackagePrefix)
 		assert.Equal(t, int64(expireDays*24*60*60*1000), entity.ExpiryTime)
 		assert.Equal(t, int64(42), entity.RegistryID)
+		assert.Equal(t, 0, entity.KeepLast)
+		assert.Equal(t, int64(0), entity.DownloadedWithin)
+
+		keepLast := 3
+		downloadedWithinDays := 14
+		input.KeepLast = &keepLast
+		input.DownloadedWithinDays = &downloadedWithinDays
+		entity = getCleanupPolicyEntity(input, 42)
+		assert.Equal(t, 3, entity.KeepLast)
+		assert.Equal(t, int64(downloadedWithinDays*24*60*60*1000), entity.DownloadedWithin)
 	})
 }
 
@@ -90,7 +100,14 @@ func TestGetCleanupPolicyDto_FunctionExists(t *testing.T) {
 		assert.Equal(t, &input.VersionPrefix, dto.VersionPrefix)
 		assert.Equal(t, &input.PackagePrefix, dto.PackagePrefix)
 
-		expireDays := int(time.Duration(input.ExpiryTime).Hours() / 24)
+		expireDays := 3
 		assert.Equal(t, &expireDays, dto.ExpireDays)
+
+		downloadedWithinDays := 30
+		input.KeepLast = 5
+		input.DownloadedWithin = (time.Duration(downloadedWithinDays) * 24 * time.Hour).Milliseconds()
+		dto = getCleanupPolicyDto(input)
+		assert.Equal(t, &input.KeepLast, dto.KeepLast)
+		assert.Equal(t, &downloadedWithinDays, dto.DownloadedWithinDays)
 	})
 }
@@ -461,6 +461,36 @@ func (_m *ArtifactRepository) GetArtifactsByRepoAndImageBatch(ctx context.Contex
 	return r0, r1
 }
 
+// GetArtifactsForCleanup provides a mock function with given fields: ctx, registryID, batchSize, lastArtifactID
+func (_m *ArtifactRepository) GetArtifactsForCleanup(ctx context.Context, registryID int64, batchSize int, lastArtifactID int64) ([]*types.ArtifactCleanupInfo, error) {
+	ret := _m.Called(ctx, registryID, batchSize, lastArtifactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsForCleanup")
+	}
+
+	var r0 []*types.ArtifactCleanupInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int64) ([]*types.ArtifactCleanupInfo, error)); ok {
+		return rf(ctx, registryID, batchSize, lastArtifactID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int64) []*types.ArtifactCleanupInfo); ok {
+		r0 = rf(ctx, registryID, batchSize, lastArtifactID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.ArtifactCleanupInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int64) error); ok {
+		r1 = rf(ctx, registryID, batchSize, lastArtifactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByName provides a mock function with given fields: ctx, imageID, version
 func (_m *ArtifactRepository) GetByName(ctx context.Context, imageID int64, version string) (*types.Artifact, error) {
 	ret := _m.Called(ctx, imageID, version)
@@ -121,6 +121,24 @@ func (_m *Provider) GenerateUIPRURL(ctx context.Context, repoPath string, prID i
 	return r0
 }
 
+// GenerateUIIssueURL provides a mock function with given fields: ctx, repoPath, issueNum
+func (_m *Provider) GenerateUIIssueURL(ctx context.Context, repoPath string, issueNum int64) string {
+	ret := _m.Called(ctx, repoPath, issueNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateUIIssueURL")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) string); ok {
+		r0 = rf(ctx, repoPath, issueNum)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GenerateUIRefURL provides a mock function with given fields: ctx, repoPath, ref
 func (_m *Provider) GenerateUIRefURL(ctx context.Context, repoPath string, ref string) string {
 	ret := _m.Called(ctx, repoPath, ref)
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/alpine"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	alpinetype "github.com/harness/gitness/registry/app/pkg/types/alpine"
+	alpineutil "github.com/harness/gitness/registry/app/utils/alpine"
+)
+
+// GetRepositoryFile returns an index file of the repository, e.g. InRelease or Packages.gz.
+func (c *controller) GetIndex(
+	ctx context.Context,
+	info alpinetype.ArtifactInfo,
+) *GetIndexResponse {
+	a := base.GetArtifactRegistry(info.Registry)
+	alpineRegistry, ok := a.(alpine.Registry)
+	if !ok {
+		return &GetIndexResponse{
+			BaseResponse{
+				fmt.Errorf("invalid registry type: expected alpine.Registry"),
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+
+	responseHeaders, fileReader, readCloser, redirectURL, err := alpineRegistry.GetIndex(ctx, info)
+
+	return &GetIndexResponse{
+		BaseResponse{
+			err,
+			responseHeaders,
+		},
+		redirectURL, fileReader, readCloser,
+	}
+}
+
+// GetRepositoryKey returns the PEM encoded public key used to sign the indexes of the registry.
+func (c *controller) GetRepositoryKey(
+	ctx context.Context,
+	info alpinetype.ArtifactInfo,
+) *GetRepositoryKeyResponse {
+	if info.Registry.Type == artifact.RegistryTypeUPSTREAM {
+		return &GetRepositoryKeyResponse{
+			BaseResponse{
+				errcode.ErrCodeInvalidRequest.WithDetail(
+					fmt.Errorf("upstream registries serve indexes signed by the upstream key")),
+				nil,
+			},
+			"",
+		}
+	}
+
+	publicKey, err := c.signingKeyService.GetRSAPublicKey(ctx, info.RegistryID)
+	if err != nil {
+		return &GetRepositoryKeyResponse{
+			BaseResponse{
+				fmt.Errorf("failed to get repository key: %w", err),
+				nil,
+			},
+			"",
+		}
+	}
+	return &GetRepositoryKeyResponse{
+		BaseResponse{
+			nil,
+			&commons.ResponseHeaders{
+				Headers: map[string]string{
+					"Content-Type": "application/x-pem-file",
+					// apk looks the key up by the name recorded in the index signature.
+					"Content-Disposition": fmt.Sprintf("attachment; filename=%q", alpineutil.KeyName(info.RegIdentifier)),
+				},
+				Code: http.StatusOK,
+			},
+		},
+		publicKey,
+	}
+}
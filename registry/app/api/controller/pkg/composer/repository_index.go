@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	composertype "github.com/harness/gitness/registry/app/pkg/types/composer"
+	composerutil "github.com/harness/gitness/registry/app/utils/composer"
+)
+
+// GetRepositoryIndex returns the packages.json of the registry. It only points composer to the p2 metadata,
+// packages are resolved one by one.
+func (c *controller) GetRepositoryIndex(
+	ctx context.Context,
+	info composertype.ArtifactInfo,
+) *GetRepositoryIndexResponse {
+	registryRef := metadata.GetRegistryRef(info.RootIdentifier, info.RegIdentifier)
+	return &GetRepositoryIndexResponse{
+		BaseResponse: BaseResponse{
+			ResponseHeaders: &commons.ResponseHeaders{
+				Headers: make(map[string]string),
+				Code:    http.StatusOK,
+			},
+		},
+		Index: composerutil.BuildRepositoryIndex(c.urlProvider.PackageURL(ctx, registryRef, "composer")),
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"mime/multipart"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/pkg/debian"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	"github.com/harness/gitness/registry/app/services/signingkey"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+type Controller interface {
+	UploadPackageFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+		file multipart.Part,
+		fileName string,
+	) *PutArtifactResponse
+
+	DownloadPackageFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetArtifactResponse
+
+	GetRepositoryFile(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetRepositoryFileResponse
+
+	GetRepositoryKey(
+		ctx context.Context,
+		info debiantype.ArtifactInfo,
+	) *GetRepositoryKeyResponse
+}
+
+// Controller handles Debian package operations.
+type controller struct {
+	fileManager       filemanager.FileManager
+	proxyStore        store.UpstreamProxyConfigRepository
+	tx                dbtx.Transactor
+	registryDao       store.RegistryRepository
+	imageDao          store.ImageRepository
+	artifactDao       store.ArtifactRepository
+	urlProvider       urlprovider.Provider
+	local             debian.LocalRegistry
+	proxy             debian.Proxy
+	signingKeyService *signingkey.Service
+}
+
+// NewController creates a new Debian controller.
+func NewController(
+	proxyStore store.UpstreamProxyConfigRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	fileManager filemanager.FileManager,
+	tx dbtx.Transactor,
+	urlProvider urlprovider.Provider,
+	local debian.LocalRegistry,
+	proxy debian.Proxy,
+	signingKeyService *signingkey.Service,
+) Controller {
+	return &controller{
+		proxyStore:        proxyStore,
+		registryDao:       registryDao,
+		imageDao:          imageDao,
+		artifactDao:       artifactDao,
+		fileManager:       fileManager,
+		tx:                tx,
+		urlProvider:       urlProvider,
+		local:             local,
+		proxy:             proxy,
+		signingKeyService: signingKeyService,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/debian"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+)
+
+// GetRepositoryFile returns an index file of the repository, e.g. InRelease or Packages.gz.
+func (c *controller) GetRepositoryFile(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) *GetRepositoryFileResponse {
+	a := base.GetArtifactRegistry(info.Registry)
+	debianRegistry, ok := a.(debian.Registry)
+	if !ok {
+		return &GetRepositoryFileResponse{
+			BaseResponse{
+				fmt.Errorf("invalid registry type: expected debian.Registry"),
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+
+	responseHeaders, fileReader, readCloser, redirectURL, err := debianRegistry.GetRepositoryFile(ctx, info)
+
+	return &GetRepositoryFileResponse{
+		BaseResponse{
+			err,
+			responseHeaders,
+		},
+		redirectURL, fileReader, readCloser,
+	}
+}
+
+// GetRepositoryKey returns the armored public key used to sign the Release files of the registry.
+func (c *controller) GetRepositoryKey(
+	ctx context.Context,
+	info debiantype.ArtifactInfo,
+) *GetRepositoryKeyResponse {
+	if info.Registry.Type == artifact.RegistryTypeUPSTREAM {
+		return &GetRepositoryKeyResponse{
+			BaseResponse{
+				errcode.ErrCodeInvalidRequest.WithDetail(
+					fmt.Errorf("upstream registries serve indexes signed by the upstream key")),
+				nil,
+			},
+			"",
+		}
+	}
+
+	publicKey, err := c.signingKeyService.GetOpenPGPPublicKey(ctx, info.RegistryID, info.RegIdentifier)
+	if err != nil {
+		return &GetRepositoryKeyResponse{
+			BaseResponse{
+				fmt.Errorf("failed to get repository key: %w", err),
+				nil,
+			},
+			"",
+		}
+	}
+	return &GetRepositoryKeyResponse{
+		BaseResponse{
+			nil,
+			&commons.ResponseHeaders{
+				Headers: map[string]string{"Content-Type": "application/pgp-keys"},
+				Code:    http.StatusOK,
+			},
+		},
+		publicKey,
+	}
+}
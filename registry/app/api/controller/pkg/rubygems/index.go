@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/rubygems"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	registrytypes "github.com/harness/gitness/registry/types"
+)
+
+// GetIndexFile returns a specs index, compact index or quick gemspec file. Every registry of a virtual
+// registry is tried in order and the first one holding the file serves it.
+func (c *controller) GetIndexFile(
+	ctx context.Context,
+	info rubygemstype.ArtifactInfo,
+	filePath string,
+) *GetIndexFileResponse {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.UpdateRegistryInfo(registry)
+		rubygemsRegistry, ok := a.(rubygems.Registry)
+		if !ok {
+			return &GetIndexFileResponse{
+				BaseResponse{
+					fmt.Errorf("invalid registry type: expected rubygems.Registry"),
+					nil,
+				},
+				"", nil, nil,
+			}
+		}
+		headers, fileReader, readCloser, redirectURL, err := rubygemsRegistry.GetIndexFile(ctx, info, filePath)
+		return &GetIndexFileResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	}
+
+	result, err := base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, false)
+	if err != nil {
+		return &GetIndexFileResponse{
+			BaseResponse{
+				err,
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+	indexResponse, ok := result.(*GetIndexFileResponse)
+	if !ok {
+		return &GetIndexFileResponse{
+			BaseResponse{
+				fmt.Errorf("invalid response type: expected GetIndexFileResponse"),
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+	return indexResponse
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/quarantine"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/store"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+)
+
+type Controller interface {
+	UploadPackageFile(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		file io.Reader,
+	) *PutArtifactResponse
+
+	DownloadPackageFile(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetArtifactResponse
+
+	GetChecksumsFile(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		signature bool,
+	) *GetArtifactResponse
+
+	GetServiceDiscovery(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetServiceDiscoveryResponse
+
+	// GetHostServiceDiscovery returns the discovery document served at the root of the host, it points to the
+	// registry configured with GITNESS_REGISTRY_TERRAFORM_DISCOVERY_REGISTRY.
+	GetHostServiceDiscovery(ctx context.Context) *GetServiceDiscoveryResponse
+
+	GetModuleVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetModuleVersionsResponse
+
+	GetModuleDownloadURL(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetModuleDownloadResponse
+
+	GetProviderVersions(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+	) *GetProviderVersionsResponse
+
+	GetProviderPackage(
+		ctx context.Context,
+		info terraformtype.ArtifactInfo,
+		os string,
+		arch string,
+	) *GetProviderPackageResponse
+}
+
+type controller struct {
+	fileManager      filemanager.FileManager
+	proxyStore       store.UpstreamProxyConfigRepository
+	tx               dbtx.Transactor
+	registryDao      store.RegistryRepository
+	imageDao         store.ImageRepository
+	artifactDao      store.ArtifactRepository
+	urlProvider      urlprovider.Provider
+	local            terraform.LocalRegistry
+	quarantineFinder quarantine.Finder
+	config           *types.Config
+}
+
+func NewController(
+	proxyStore store.UpstreamProxyConfigRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	fileManager filemanager.FileManager,
+	tx dbtx.Transactor,
+	urlProvider urlprovider.Provider,
+	local terraform.LocalRegistry,
+	quarantineFinder quarantine.Finder,
+	config *types.Config,
+) Controller {
+	return &controller{
+		proxyStore:       proxyStore,
+		registryDao:      registryDao,
+		imageDao:         imageDao,
+		artifactDao:      artifactDao,
+		fileManager:      fileManager,
+		tx:               tx,
+		urlProvider:      urlProvider,
+		local:            local,
+		quarantineFinder: quarantineFinder,
+		config:           config,
+	}
+}
+
+// read runs get against the registries of the request, terraform registries are local so no upstream is involved.
+func (c *controller) read(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	checkQuarantine bool,
+	get func(terraformRegistry terraform.Registry, info terraformtype.ArtifactInfo) response.Response,
+) (response.Response, error) {
+	f := func(registry registrytypes.Registry, a pkg.Artifact) response.Response {
+		info.UpdateRegistryInfo(registry)
+		terraformRegistry, ok := a.(terraform.Registry)
+		if !ok {
+			return BaseResponse{
+				Error: fmt.Errorf("invalid registry type: expected terraform.Registry"),
+			}
+		}
+		return get(terraformRegistry, info)
+	}
+	return base.ProxyWrapper(ctx, c.registryDao, c.quarantineFinder, f, info, checkQuarantine)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+)
+
+func (c *controller) GetServiceDiscovery(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetServiceDiscoveryResponse {
+	return c.serviceDiscovery(ctx, metadata.GetRegistryRef(info.RootIdentifier, info.RegIdentifier))
+}
+
+func (c *controller) GetHostServiceDiscovery(ctx context.Context) *GetServiceDiscoveryResponse {
+	registryRef := strings.Trim(c.config.Registry.Terraform.DiscoveryRegistry, "/")
+	if strings.Count(registryRef, "/") != 1 {
+		return &GetServiceDiscoveryResponse{BaseResponse: BaseResponse{
+			Error: usererror.NotFound("terraform service discovery is not configured"),
+		}}
+	}
+	return c.serviceDiscovery(ctx, registryRef)
+}
+
+func (c *controller) serviceDiscovery(ctx context.Context, registryRef string) *GetServiceDiscoveryResponse {
+	return &GetServiceDiscoveryResponse{
+		BaseResponse: BaseResponse{
+			ResponseHeaders: okHeaders(),
+		},
+		Discovery: terraformutil.BuildServiceDiscovery(c.urlProvider.PackageURL(ctx, registryRef, "terraform")),
+	}
+}
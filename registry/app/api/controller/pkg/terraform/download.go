@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+)
+
+func (c *controller) DownloadPackageFile(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetArtifactResponse {
+	return c.getFile(ctx, info, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		headers, fileReader, readCloser, redirectURL, err := terraformRegistry.DownloadPackageFile(ctx, info)
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	})
+}
+
+// GetChecksumsFile returns the SHA256SUMS file of a provider version, or its signature.
+func (c *controller) GetChecksumsFile(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	signature bool,
+) *GetArtifactResponse {
+	return c.getFile(ctx, info, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		headers, fileReader, readCloser, redirectURL, err := terraformRegistry.GetChecksumsFile(ctx, info, signature)
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				headers,
+			},
+			redirectURL, fileReader, readCloser,
+		}
+	})
+}
+
+func (c *controller) getFile(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	get func(terraformRegistry terraform.Registry, info terraformtype.ArtifactInfo) response.Response,
+) *GetArtifactResponse {
+	result, err := c.read(ctx, info, true, get)
+	if err != nil {
+		return &GetArtifactResponse{
+			BaseResponse{
+				err,
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+	getResponse, ok := result.(*GetArtifactResponse)
+	if !ok {
+		return &GetArtifactResponse{
+			BaseResponse{
+				fmt.Errorf("invalid response type: expected GetArtifactResponse"),
+				nil,
+			},
+			"", nil, nil,
+		}
+	}
+	return getResponse
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+)
+
+func (c *controller) GetModuleVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetModuleVersionsResponse {
+	result, err := c.read(ctx, info, false, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		versions, err := terraformRegistry.GetModuleVersions(ctx, info)
+		return &GetModuleVersionsResponse{
+			BaseResponse: BaseResponse{
+				Error:           err,
+				ResponseHeaders: okHeaders(),
+			},
+			Versions: versions,
+		}
+	})
+	if err != nil {
+		return &GetModuleVersionsResponse{BaseResponse: BaseResponse{Error: err}}
+	}
+	rs, ok := result.(*GetModuleVersionsResponse)
+	if !ok {
+		return &GetModuleVersionsResponse{BaseResponse: BaseResponse{
+			Error: fmt.Errorf("invalid response type: expected GetModuleVersionsResponse"),
+		}}
+	}
+	return rs
+}
+
+// GetModuleDownloadURL resolves the archive of a module version, Terraform expects a 204 with the URL in the
+// X-Terraform-Get header.
+func (c *controller) GetModuleDownloadURL(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetModuleDownloadResponse {
+	result, err := c.read(ctx, info, true, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		downloadURL, err := terraformRegistry.GetModuleDownloadURL(ctx, info)
+		return &GetModuleDownloadResponse{
+			BaseResponse: BaseResponse{
+				Error: err,
+				ResponseHeaders: &commons.ResponseHeaders{
+					Headers: map[string]string{"X-Terraform-Get": downloadURL},
+					Code:    http.StatusNoContent,
+				},
+			},
+			DownloadURL: downloadURL,
+		}
+	})
+	if err != nil {
+		return &GetModuleDownloadResponse{BaseResponse: BaseResponse{Error: err}}
+	}
+	rs, ok := result.(*GetModuleDownloadResponse)
+	if !ok {
+		return &GetModuleDownloadResponse{BaseResponse: BaseResponse{
+			Error: fmt.Errorf("invalid response type: expected GetModuleDownloadResponse"),
+		}}
+	}
+	return rs
+}
+
+func okHeaders() *commons.ResponseHeaders {
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/pkg/response"
+	"github.com/harness/gitness/registry/app/pkg/terraform"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+)
+
+func (c *controller) GetProviderVersions(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+) *GetProviderVersionsResponse {
+	result, err := c.read(ctx, info, false, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		versions, err := terraformRegistry.GetProviderVersions(ctx, info)
+		return &GetProviderVersionsResponse{
+			BaseResponse: BaseResponse{
+				Error:           err,
+				ResponseHeaders: okHeaders(),
+			},
+			Versions: versions,
+		}
+	})
+	if err != nil {
+		return &GetProviderVersionsResponse{BaseResponse: BaseResponse{Error: err}}
+	}
+	rs, ok := result.(*GetProviderVersionsResponse)
+	if !ok {
+		return &GetProviderVersionsResponse{BaseResponse: BaseResponse{
+			Error: fmt.Errorf("invalid response type: expected GetProviderVersionsResponse"),
+		}}
+	}
+	return rs
+}
+
+func (c *controller) GetProviderPackage(
+	ctx context.Context,
+	info terraformtype.ArtifactInfo,
+	os string,
+	arch string,
+) *GetProviderPackageResponse {
+	result, err := c.read(ctx, info, true, func(
+		terraformRegistry terraform.Registry,
+		info terraformtype.ArtifactInfo,
+	) response.Response {
+		providerPackage, err := terraformRegistry.GetProviderPackage(ctx, info, os, arch)
+		return &GetProviderPackageResponse{
+			BaseResponse: BaseResponse{
+				Error:           err,
+				ResponseHeaders: okHeaders(),
+			},
+			Package: providerPackage,
+		}
+	})
+	if err != nil {
+		return &GetProviderPackageResponse{BaseResponse: BaseResponse{Error: err}}
+	}
+	rs, ok := result.(*GetProviderPackageResponse)
+	if !ok {
+		return &GetProviderPackageResponse{BaseResponse: BaseResponse{
+			Error: fmt.Errorf("invalid response type: expected GetProviderPackageResponse"),
+		}}
+	}
+	return rs
+}
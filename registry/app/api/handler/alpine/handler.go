@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/alpine"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	alpinetype "github.com/harness/gitness/registry/app/pkg/types/alpine"
+	alpineutil "github.com/harness/gitness/registry/app/utils/alpine"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetIndex(writer http.ResponseWriter, request *http.Request)
+	GetRepositoryKey(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(http.ResponseWriter, *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller alpine.Controller
+}
+
+func NewHandler(
+	controller alpine.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	alpineInfo := &alpinetype.ArtifactInfo{
+		ArtifactInfo: info,
+		Branch:       r.PathValue("branch"),
+		Repository:   r.PathValue("repository"),
+		Architecture: r.PathValue("architecture"),
+	}
+	// Package files are named {name}-{version}.apk, which together with the architecture identifies the artifact.
+	if fileName := r.PathValue("file"); fileName != "" {
+		if name, version, err := alpineutil.ParseFileName(fileName); err == nil {
+			alpineInfo.Image = name
+			alpineInfo.Version = alpineutil.ArtifactVersion(version, alpineInfo.Architecture)
+			alpineInfo.FileName = fileName
+		}
+	}
+	return alpineInfo, nil
+}
+
+// isValidRepositoryPath reports whether the branch, repository and architecture of a request are valid.
+func isValidRepositoryPath(info *alpinetype.ArtifactInfo) bool {
+	return alpineutil.IsValidRepository(info.Branch) && alpineutil.IsValidRepository(info.Repository) &&
+		alpineutil.IsValidArchitecture(info.Architecture)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/composer"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	composertype "github.com/harness/gitness/registry/app/pkg/types/composer"
+	composerutil "github.com/harness/gitness/registry/app/utils/composer"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetRepositoryIndex(writer http.ResponseWriter, request *http.Request)
+	GetPackageMetadata(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(http.ResponseWriter, *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller composer.Controller
+}
+
+func NewHandler(
+	controller composer.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	composerInfo := &composertype.ArtifactInfo{
+		ArtifactInfo: info,
+		// version overrides the version of the composer.json on upload.
+		Version: r.URL.Query().Get("version"),
+	}
+	switch {
+	case r.PathValue("filename") != "":
+		composerInfo.Image = r.PathValue("vendor") + "/" + r.PathValue("package")
+		composerInfo.Version = r.PathValue("version")
+		composerInfo.FileName = r.PathValue("filename")
+	case r.PathValue("file") != "":
+		if name, _, err := composerutil.ParseMetadataPath(r.PathValue("vendor") + "/" + r.PathValue("file")); err == nil {
+			composerInfo.Image = name
+		}
+	}
+	return composerInfo, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	composertype "github.com/harness/gitness/registry/app/pkg/types/composer"
+	"github.com/harness/gitness/registry/request"
+)
+
+// GetRepositoryIndex serves the packages.json of the registry.
+func (h *handler) GetRepositoryIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*composertype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	response := h.controller.GetRepositoryIndex(ctx, *info)
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+
+	response.ResponseHeaders.WriteHeadersToResponse(w)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response.Index); err != nil {
+		h.HandleError(ctx, w, err)
+	}
+}
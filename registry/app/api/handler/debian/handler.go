@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/debian"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	debiantype "github.com/harness/gitness/registry/app/pkg/types/debian"
+	debianutil "github.com/harness/gitness/registry/app/utils/debian"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetRepositoryFile(writer http.ResponseWriter, request *http.Request)
+	GetRepositoryKey(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(http.ResponseWriter, *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller debian.Controller
+}
+
+func NewHandler(
+	controller debian.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	debianInfo := &debiantype.ArtifactInfo{
+		ArtifactInfo: info,
+		Distribution: r.PathValue("distribution"),
+		Component:    r.PathValue("component"),
+	}
+	// Pool files are named {name}_{version}_{architecture}.deb, which identifies the artifact.
+	if fileName := path.Base(r.PathValue("*")); fileName != "" {
+		if name, version, err := debianutil.ParseFileName(fileName); err == nil {
+			debianInfo.Image = name
+			debianInfo.Version = version
+			debianInfo.FileName = fileName
+		}
+	}
+	return debianInfo, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	"github.com/harness/gitness/registry/app/pkg"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	UploadPackageFile(writer http.ResponseWriter, request *http.Request)
+	GetIndexFile(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(http.ResponseWriter, *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller rubygems.Controller
+}
+
+func NewHandler(
+	controller rubygems.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	rubygemsInfo := &rubygemstype.ArtifactInfo{
+		ArtifactInfo: info,
+	}
+	switch {
+	case r.PathValue("file") != "":
+		if name, version, err := rubygemsutil.ParseFileName(r.PathValue("file")); err == nil {
+			rubygemsInfo.Image = name
+			rubygemsInfo.Version = version
+			rubygemsInfo.FileName = r.PathValue("file")
+		}
+	case r.PathValue("spec") != "":
+		fullName := strings.TrimSuffix(r.PathValue("spec"), rubygemsutil.QuickSpecSuffix)
+		if name, version, err := rubygemsutil.ParseFullName(fullName); err == nil {
+			rubygemsInfo.Image = name
+			rubygemsInfo.Version = version
+		}
+	case r.PathValue("name") != "":
+		rubygemsInfo.Image = r.PathValue("name")
+	}
+	return rubygemsInfo, nil
+}
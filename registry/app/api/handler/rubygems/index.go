@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+	"github.com/harness/gitness/registry/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetIndexFile serves the specs indexes, the compact index and the quick gemspecs.
+func (h *handler) GetIndexFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*rubygemstype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	filePath, contentType := indexFilePath(r)
+	if filePath == "" || (r.PathValue("name") != "" && !rubygemsutil.IsValidName(info.Image)) ||
+		(r.PathValue("spec") != "" && info.Version == "") {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("invalid rubygems index path"), w)
+		return
+	}
+
+	response := h.controller.GetIndexFile(ctx, *info, filePath)
+	if response == nil {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to get response from controller")}, w)
+		return
+	}
+
+	defer func() {
+		if response.Body != nil {
+			err := response.Body.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+
+		if response.ReadCloser != nil {
+			err := response.ReadCloser.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close read closer: %v", err)
+			}
+		}
+	}()
+
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+
+	if response.RedirectURL != "" {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	err := commons.ServeContent(w, r, response.Body, path.Base(filePath), response.ReadCloser)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Failed to serve content: %v", err)
+		h.HandleError(ctx, w, err)
+		return
+	}
+	response.ResponseHeaders.WriteToResponse(w)
+}
+
+// indexFilePath returns the path of the requested index file relative to the registry root and its content type.
+func indexFilePath(r *http.Request) (string, string) {
+	if name := r.PathValue("name"); name != "" {
+		return rubygemsutil.InfoPath(name), "text/plain; charset=utf-8"
+	}
+	if spec := r.PathValue("spec"); spec != "" {
+		if !strings.HasSuffix(spec, rubygemsutil.QuickSpecSuffix) {
+			return "", ""
+		}
+		return rubygemsutil.QuickSpecDir + "/" + spec, "application/octet-stream"
+	}
+	switch fileName := path.Base(r.URL.Path); fileName {
+	case rubygemsutil.SpecsFile, rubygemsutil.LatestSpecsFile, rubygemsutil.PrereleaseSpecsFile:
+		return fileName, "application/octet-stream"
+	case rubygemsutil.VersionsFile, rubygemsutil.NamesFile:
+		return fileName, "text/plain; charset=utf-8"
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	"github.com/harness/gitness/registry/request"
+)
+
+// UploadPackageFile handles gem push, which sends the raw .gem file as request body.
+func (h *handler) UploadPackageFile(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	contextInfo := request.ArtifactInfoFrom(r.Context())
+	info, ok := contextInfo.(*rubygemstype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+
+	response := h.controller.UploadPackageFile(r.Context(), *info, r.Body)
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+
+	response.ResponseHeaders.WriteToResponse(w)
+	_, err := fmt.Fprintf(w, "Successfully registered gem: %s (%s)", response.Package.Name,
+		response.Package.Version)
+	if err != nil {
+		h.HandleError(r.Context(), w, err)
+		return
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/request"
+)
+
+// GetServiceDiscovery serves the discovery document of the registry, its urls are absolute so Terraform can be
+// pointed to it from the host block of the CLI configuration.
+func (h *handler) GetServiceDiscovery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	response := h.controller.GetServiceDiscovery(ctx, *info)
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+	h.writeJSON(w, r, response.ResponseHeaders, response.Discovery)
+}
+
+// GetHostServiceDiscovery serves /.well-known/terraform.json of the host.
+func (h *handler) GetHostServiceDiscovery(w http.ResponseWriter, r *http.Request) {
+	response := h.controller.GetHostServiceDiscovery(r.Context())
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+	h.writeJSON(w, r, response.ResponseHeaders, response.Discovery)
+}
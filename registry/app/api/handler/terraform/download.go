@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DownloadPackageFile serves a module archive or a provider package. For providers the SHA256SUMS file of the
+// version and its signature are served from the same path.
+func (h *handler) DownloadPackageFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+	if info.FileName == "" {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage("invalid terraform file name"), w)
+		return
+	}
+
+	response := h.downloadFile(r, info)
+	if response == nil {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to get response from controller")}, w)
+		return
+	}
+
+	defer func() {
+		if response.Body != nil {
+			err := response.Body.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+
+		if response.ReadCloser != nil {
+			err := response.ReadCloser.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close read closer: %v", err)
+			}
+		}
+	}()
+
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+
+	if response.RedirectURL != "" {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+
+	err := commons.ServeContent(w, r, response.Body, info.FileName, response.ReadCloser)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Failed to serve content: %v", err)
+		h.HandleError(ctx, w, err)
+		return
+	}
+	response.ResponseHeaders.WriteToResponse(w)
+}
+
+func (h *handler) downloadFile(r *http.Request, info *terraformtype.ArtifactInfo) *terraform.GetArtifactResponse {
+	if info.Metadata.Kind == terraformmetadata.KindProvider {
+		switch info.FileName {
+		case terraformutil.SHA256SumsFileName(info.Metadata.Name, info.Version):
+			return h.controller.GetChecksumsFile(r.Context(), *info, false)
+		case terraformutil.SignatureFileName(info.Metadata.Name, info.Version):
+			return h.controller.GetChecksumsFile(r.Context(), *info, true)
+		}
+	}
+	return h.controller.DownloadPackageFile(r.Context(), *info)
+}
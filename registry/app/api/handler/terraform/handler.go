@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
+	"github.com/harness/gitness/registry/app/api/handler/packages"
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+)
+
+type Handler interface {
+	pkg.ArtifactInfoProvider
+	GetServiceDiscovery(writer http.ResponseWriter, request *http.Request)
+	GetHostServiceDiscovery(writer http.ResponseWriter, request *http.Request)
+	GetModuleVersions(writer http.ResponseWriter, request *http.Request)
+	GetModuleDownloadURL(writer http.ResponseWriter, request *http.Request)
+	UploadModule(writer http.ResponseWriter, request *http.Request)
+	GetProviderVersions(writer http.ResponseWriter, request *http.Request)
+	GetProviderPackage(writer http.ResponseWriter, request *http.Request)
+	UploadProvider(writer http.ResponseWriter, request *http.Request)
+	DownloadPackageFile(http.ResponseWriter, *http.Request)
+}
+
+type handler struct {
+	packages.Handler
+	controller terraform.Controller
+}
+
+func NewHandler(
+	controller terraform.Controller,
+	packageHandler packages.Handler,
+) Handler {
+	return &handler{
+		Handler:    packageHandler,
+		controller: controller,
+	}
+}
+
+var _ Handler = (*handler)(nil)
+
+// GetPackageArtifactInfo resolves the module ({namespace}/{name}/{system}) or the provider ({namespace}/{type})
+// addressed by the request.
+func (h *handler) GetPackageArtifactInfo(r *http.Request) (pkg.PackageArtifactInfo, error) {
+	info, err := h.Handler.GetArtifactInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	terraformInfo := &terraformtype.ArtifactInfo{
+		ArtifactInfo: info,
+		FileName:     r.PathValue("file"),
+	}
+
+	namespace := r.PathValue("namespace")
+	switch {
+	case r.PathValue("type") != "":
+		terraformInfo.Image, err = terraformutil.ProviderImage(namespace, r.PathValue("type"))
+		terraformInfo.Metadata = terraformmetadata.Metadata{
+			Kind:      terraformmetadata.KindProvider,
+			Namespace: namespace,
+			Name:      r.PathValue("type"),
+		}
+	case r.PathValue("name") != "":
+		terraformInfo.Image, err = terraformutil.ModuleImage(namespace, r.PathValue("name"), r.PathValue("system"))
+		terraformInfo.Metadata = terraformmetadata.Metadata{
+			Kind:      terraformmetadata.KindModule,
+			Namespace: namespace,
+			Name:      r.PathValue("name"),
+			System:    r.PathValue("system"),
+		}
+	}
+	if err != nil {
+		return nil, usererror.BadRequest(err.Error())
+	}
+
+	if version := r.PathValue("version"); version != "" {
+		terraformInfo.Version, err = terraformutil.NormalizeVersion(version)
+		if err != nil {
+			return nil, usererror.BadRequest(err.Error())
+		}
+	}
+	return terraformInfo, nil
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, r *http.Request, headers *commons.ResponseHeaders, body any) {
+	headers.WriteHeadersToResponse(w)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.HandleError(r.Context(), w, err)
+	}
+}
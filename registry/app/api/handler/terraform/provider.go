@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/request"
+)
+
+func (h *handler) GetProviderVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+
+	response := h.controller.GetProviderVersions(ctx, *info)
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+	h.writeJSON(w, r, response.ResponseHeaders, response.Versions)
+}
+
+// GetProviderPackage serves the download details of a provider package for one platform.
+func (h *handler) GetProviderPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := request.ArtifactInfoFrom(ctx).(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors(ctx, []error{fmt.Errorf("failed to fetch info from context")}, w)
+		return
+	}
+	os, arch := r.PathValue("os"), r.PathValue("arch")
+	if err := terraformutil.ValidatePlatform(os, arch); err != nil {
+		h.HandleErrors2(ctx, errcode.ErrCodeInvalidRequest.WithMessage(err.Error()), w)
+		return
+	}
+
+	response := h.controller.GetProviderPackage(ctx, *info, os, arch)
+	if response.GetError() != nil {
+		h.HandleError(ctx, w, response.GetError())
+		return
+	}
+	h.writeJSON(w, r, response.ResponseHeaders, response.Package)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	terraformtype "github.com/harness/gitness/registry/app/pkg/types/terraform"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/registry/request"
+)
+
+// UploadModule stores a .zip, .tar.gz or .tgz archive of a module version.
+func (h *handler) UploadModule(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, func(info *terraformtype.ArtifactInfo, fileName string) error {
+		ext, err := terraformutil.ModuleArchiveExtension(fileName)
+		if err != nil {
+			return err
+		}
+		info.FileName = terraformutil.ModuleFileName(info.Metadata.Name, info.Metadata.System, info.Version, ext)
+		return nil
+	})
+}
+
+// UploadProvider stores the zip package of a provider version for one platform. The plugin protocol versions
+// the provider supports can be set with the protocols query parameter, e.g. protocols=5.0,6.0.
+func (h *handler) UploadProvider(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, func(info *terraformtype.ArtifactInfo, fileName string) error {
+		if !strings.HasSuffix(strings.ToLower(fileName), ".zip") {
+			return terraformutil.ErrInvalidArchive
+		}
+		os, arch := r.PathValue("os"), r.PathValue("arch")
+		if err := terraformutil.ValidatePlatform(os, arch); err != nil {
+			return err
+		}
+		protocols, err := terraformutil.ParseProtocols(r.URL.Query().Get("protocols"))
+		if err != nil {
+			return err
+		}
+		info.Metadata.Protocols = protocols
+		info.FileName = terraformutil.ProviderFileName(info.Metadata.Name, info.Version, os, arch)
+		return nil
+	})
+}
+
+func (h *handler) upload(
+	w http.ResponseWriter,
+	r *http.Request,
+	prepare func(info *terraformtype.ArtifactInfo, fileName string) error,
+) {
+	file, fileName, err := utils.GetFileReader(r, "file")
+	if err != nil {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage(fmt.Sprintf("failed to parse file: %s, "+
+			"please provide correct file path ", err.Error())), w)
+		return
+	}
+	defer file.Close()
+
+	contextInfo := request.ArtifactInfoFrom(r.Context())
+	info, ok := contextInfo.(*terraformtype.ArtifactInfo)
+	if !ok {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage("failed to fetch info from context"), w)
+		return
+	}
+	if err = prepare(info, fileName); err != nil {
+		h.HandleErrors2(r.Context(), errcode.ErrCodeInvalidRequest.WithMessage(err.Error()), w)
+		return
+	}
+
+	response := h.controller.UploadPackageFile(r.Context(), *info, file)
+	if response.GetError() != nil {
+		h.HandleError(r.Context(), w, response.GetError())
+		return
+	}
+
+	response.ResponseHeaders.WriteToResponse(w)
+	_, err = fmt.Fprintf(w, "Pushed.\nSha256: %s", response.Sha256)
+	if err != nil {
+		h.HandleError(r.Context(), w, err)
+		return
+	}
+}
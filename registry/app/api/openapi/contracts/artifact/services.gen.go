@@ -7818,135 +7818,138 @@ func (sh *strictHandler) GetAllRegistries(w http.ResponseWriter, r *http.Request
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
-
-	"H4sIAAAAAAAC/+x93XLcOJLuq+DwnImY1pRV7h6fjQ1tzIUsS3LNyHJNqeSJjm6HBJGoKo5ZJBsAJasd",
-	"itirfYDdN5wn2cAfCZIACdafyjZvuuUifhKJLxOJBJD5xfOTZZrEKKbEO/ripRDDJaII839dwDsUkTH7",
-	"jf0zQMTHYUrDJPaOxMdDb+CF7F+/ZQg/egMvhkvkHXkR++gNPOIv0BKyyiFFS94ofUxZCUJxGM+9p4H6",
-	"AWIMH72np4E3QfOQUPw4ClBMw1mIsIUEVRAUJS30YDS/CfVCaxE2fUxRG0msjIUYKj4VJKA4W3pHv3gf",
-	"RpPp9fGFN/Cux1fTyenxO+/joErX08CDmIYz6FMLDcf8M7X0riqXKGjqgy4s/VzCJQLJDKiiORhSSBfG",
-	"DjH6LQsxCrwjijPkRkADs1URwGoftoz3xsr2ZRJwsAaQQoKomef+IoyCDwiTMIkt5JywIuBelAFh7EPC",
-	"+fMm8T8hnLOJ2CjVu2iZnSCcI0LfpzYIvOHfbR2J2k5drNd+l/mehRFiiHIA3LGa97MwQhbUseZu+N/d",
-	"yWggQX22jJz3Kglp7AUnyzeQ2oDNPh2CswQvIQUvwLt3wzdvhj///PPPtm5xsmzpMYIUEarQZdDm7DOQ",
-	"3xljKcJ27c4K39zboXqXJBGCMe85hf4nOEcuSnMsijYpT9laXZo76PEUztFltrxD2CDEGcYopoCVAbEo",
-	"ZKNkXqYgQDOYRdQ7+nHgzfjceUdeGNN/e+XlRIQxRXOEczKuwt+RAei8XwZ1PiqQIgxkdyZKCGvESMlP",
-	"L91IwcjPMAnvbTP0jwWiC4QBTUAUEgqwmLEQEZBXjR4Pf41/jQ8O3qAUIx9SFBweHIBrggBdIBCjB3BL",
-	"/CRFtyA3M0QNcJs38hcmobcA/Ou//luW/guMfURogsltpegMRgTd6kXjJEa3v8ZWI0DWNPOKNzcwIViO",
-	"9nGCZg2q4ToOf8sQYNIPClsDzBLMxz8LYxgpxj2CMOa/3mEY+4tDMF0gcA+jDAEfxuAOgRQn92GAAoBC",
-	"znlIAASzLIoewfXk4gWK/YR95b39ER3ODwfgNsFzGIe/Q0bQH346S3HyT+TTP/x0pnq9/QEksqk0gmEs",
-	"qqM4COM5eAjpAkBAMQwj9u80yggg4TwGf7z90+0PrBpBbOZogo1dDmWHQ9Xd8E+3PxwW01FW0KrQDUaz",
-	"jjqaT7ZlFg4OrthXJjsaSCVuDw4YhA4OGE4ODsC//vN/gC/lnaTQRyCJo0fwRwmJHwAArHQOQGOVgwPG",
-	"qIMDAKOIATv/QmR1Rh+KAxhThwa4DZDX/zUezUCyDClFwQDccniDkABISLZEwaEV6pxDRmMnHwwzeArK",
-	"WNUkRmbbhyCI/cUUYQO/xTfAPtqWC1HkhrL6LRObYHoWoigw9JN/snSSYHozkwXa+niPA5PuLz419JHI",
-	"Ao19sPnbgLYQANmdqtiRfmhSC3zIq+gEyfK/synred7C8yqwNaY3MZkmGzRaadLS233jbqvYKJkav3fa",
-	"RuU9uG85ZLeWXUfRbRfsyloNFrKyy6cNG13Zin2f+2Z0fno19Qbe9PjcrOgf0N0iST6dfkZ+xnoeBe0a",
-	"TNYBSFXSRMvCJVnlJq9yEwYdWSab0F1EroQ6k1dyGLkT9yQKI0JfJ0GI+J5EwYc7zSbiK/vdT2KKYv4n",
-	"TNMo9IXQ/pOIPVrRyf9jwnnk/d9h4a8biq9kaGyc01Hmg6SKGUNZGkCKcpcE4P464mk+rk0TWW23gT6m",
-	"j32MOIFxoGhV9qIgMidjkkVo87Qam1+B5LwdgLMIMdL/IcC1aZIrzXYmVWKeUfhbBjGMaRhvnK/1lptR",
-	"WpQHJEV+OAt9MAsjsYLLnRlJk5iUhewNojCMJvJTJ+pTnKQIUym1AaTOwic6ZfwjFNKMtNW7EqWUthCq",
-	"5RdVWTgjNSWd3LFV3MwvMU7GsDmihUwHnCIu1IrIs5CheX2+JA9xlMDgGkd1bas+ggxHunfYG9Q9Mxti",
-	"lUZOV44tEAwKljFw6fySGnWnQLrKlkso1Ny+IImvDkB91hnE+ia7ZhDrc5/Yw5oiZvaIuewRRAqSFJXS",
-	"pH0eFpU73wNOBeUzovwUSWPcaxhsekE+xTjBJvJewwBgtUYPvJMoRDG9QjRLxTq3K5mvd/ycc8VNJ04R",
-	"IIwkfYkVh3zPYoKYut5DSAc5YWWC38E4nCFCn4VbqvM95NdSI00QfQEfESY75ZPoci9tEkZYwRs1kbtl",
-	"T97rfrKG2fs7VUUXIaFFp/vEFGbac568RdHyWdR0veM94M8CRUuTitaJ3bGCNnW9d5zSlfMopgjHMLpC",
-	"+B5hYVNt3UJTnQLCewVIFBx4TASfY/9a6/e5LTV+UcHg4dQJfQbe7BVbqvyQ+6JnYIs6ztkH7sjNFym5",
-	"rySn3oVzzDkwWsI52iGjyh0/A58mNT4tFUkgZDTl0vXeD9W0TuGc7JBJlZ73Ak0UzgkI41nC4RSD9yej",
-	"GqrU6cgz6KVq13upn4rTo53zZS/4oR9+CeIqJ1Q7ZEup573QQ9VztlwRyVMxkh9o75BRtb6fQxtx9siz",
-	"PVIc0Ze91Tq1z8CgvRCwB42Yy4SeJVkcbN+Iny7yk00UsDlJMuwj8AAJiBMKZpyKp4E3jmAYT9Fn27pA",
-	"0Wc65LeH/gP4C4gJon/J6OzFv5dpRJ/hMo0Ya96iKEoG4CHBUfB/6idzdUqP5eUk1lMJPDvWzPuilcUJ",
-	"+kD4GBwvKeyIQXuln6uqWTKKkXWV+T4iZA1+bGJgLiOSlIKJhvvrGGZ0gWIa8pvu29cV1Q5zGhIc/r47",
-	"AmRvxU2WXa+t1W6fAeH1K2+6Rsyv4uySHXuqD43XisaQLnbEnXKnz8Ak7QoTv9dbAOVJ3SEUd5e4hvkb",
-	"erxCPkb0b+ixPmCoyhhfOcFyC9qTWIfSVyn00YgrkdbnQubKnL+mnogaUAtFeblutJSrWaioTqOBpI9P",
-	"A+84TuLHZcLhod1UkM56yztbnwJZYOAFIfu+DGNIhQ94CdOUUXD0xTs5npy/t55zQzxPyv2dJPEsnHsD",
-	"7837k7+dTroc/uZVz08vTyejE1vdcxQjHPq2ylZqz22kvj29eOd+/FFUuz4/H12enx2fnFprZ/N5GM/P",
-	"oI8sjbw7/nB6aav+Dt6j2FLxcmyl+TK1kXx5fX46tVbL5ohaKo5/nr59b6Vz/EgXiY3QiZ3QiYXQp4HS",
-	"IY+XpfeT/IXl08BLYvR+5h390v2GQd5D11Mvx4pN4Gyra5/utpoNE9BW9TJdbaCTFevZUdZW065tWidl",
-	"tWpt0vv0cVBd67TX/66XuhSmhS0SHFPjOiO/vjavouou6UmSCYvEYQkKyd/zVT4wPYIeeMsk4Ht8C03i",
-	"4YHhgy6tLVwYlwVbv2sNpSVVX3Pl6+Pah/vinXjzUsod/5fimb3++EVsg+VL+QyzxVEfS910Kpbb05iG",
-	"9PEdolBZeDAIQrbmwmisgUS8w7AsyaIRkLfS0F/1TUYZiPLcsNsDc51DsoGmEetjtYxHG8jmBEWBnWwY",
-	"7d15xuoQ+k5KibHCUmOSyyArMNqOlKVZFJ0kyyWMzUQ7SSGuxbtpLGa1tJ2FNg+LUeu3GieiJsquQiwu",
-	"adfw/BbiGBFSPKcT5QaWBwZdcKnqqIgKDlVoQmF0RROsBWJwqJalnfp5amKTvFjnwChZcncL5WraIV8P",
-	"TE2uohhals5VZbdhHXPFuOqz6haZIcwjDpTCE3mDDvGGarfkHVYI9UR0OysFj+iTA8EufZ3AMgsjtPGl",
-	"p19G1lxGLEbh7teQyiON+oNzcWG6JgHbUJXNes0BmFu25Nc02FunJKMLs7o7Lg4lGO8rqu6aIDyGhDwk",
-	"OPAGJi+n7nUzKUL7XrUeuon/zt3RvFb9KWQVEUvHTUbVzWvg0Amb6CwdJ1HoG7AqPwPxndNYW+Inebya",
-	"GqHocxpi9AY+ErP6bdNJY4xm4eduC68KjtC5qpk9tYc8Bh7xpzW8EHhjmzIYxm8RDOxe5Oav4iaGPhrH",
-	"90dXom7rfk8jUCdH6/xjM39UR838UaWanc6jy4vR5anL6ChKc0fj9Pj1la3OFN5VK9SdjLSTd9FMRptP",
-	"yURIzY20WBUp1EFPyykw2pPUpkkrg22bZVakOihfWAqroZhzS1gaBplfrMeRSkc5Z9q4oNk+LcwAqujA",
-	"5KcxL40wyiwGTTtdlpWmdY4IRenKE9RZpebMtlBaKlRdo9kWPPS9gcc97pCiafIJxcbF2PjSsNUuy4/H",
-	"KjDe9e7TxU+79u5gS9vV9j2C9v31o4jPuv5eousmQfh698Wj3HBs1WQ8mh+u1k2R5hl5aiUof9zUKkF5",
-	"ybo1VDTRzNa8pJ1R/K3naUyd/FHiYahtbVpjY6laaKGTtLrORDHrbjCwi4h8QOmqvWvcMyysCTnGvsPV",
-	"BEmVffAKClYr2nmmmtWvnTvbOjWwsmi9wywzg/NJzvttZ3kDs4siVTY3L0lLvekOYKuiwL59W03hmpiR",
-	"P2ysSnxgsDumCwQWlKbiXSLghQbaBeRXL1+ZAgIFNlQf57tzpY4BvEsyyqM0irePBpKXiBA4t5CHOZR4",
-	"A3lsLBhGKPAGrSqKj0a1bmTWZ4phsb+oRFOW9wd5IZBvEMt8/WS559Vgzuo0fuK+FVHYRKD2SrtGH/tm",
-	"tdoWyP9EsmVHL76bsddk3zS4ODbhyJQxtIvh1anSRyG7NXG26eJKk9kxF/Xa7Y5SC052x3l3D9r5bt1n",
-	"hsfxdWWLomXrriJ/OdZw92OTW47vYMfwbWwGrBfRmqTAFB5hExsBY4yDFsBvexPQdkmrkU+i7gz6aKdq",
-	"468znMz1Jy/q/U3NTGFqnoc5tgiZOKNQd6cdCmnXlG24z9VHhkOTtZMRhC3LWmXiBMSLMZjmrxRkoG5A",
-	"iXduWihjYtWdxKW6N3AzWGuXnAzWAWsoV7p1S43f05DJOPKkGLo56ZJwQ+Tb6NBLyp+06728fOnczygO",
-	"0GdzP76WYURv3r1xc9IQ1nZsTxyiM8uYAaRAW4GDNpxdKK+rDS2G3Si/AlLbJu0EAavcP+lR44iahouO",
-	"pkAfDiomD8Rh1VQfVIGOrXXSXNU7OL0C+/oVWNP2t0F7nfEoYlU0ithiq7TjhMNyQLUeensNPYEFG+wq",
-	"QXwaIFOLrWN0LG5H8dVDDfWg22vQFYzSp0brWx/jQEHHBtJqEKW1VurdICVxJ/n9yaggm7LCjlJRZktv",
-	"Kq6B1+p02ZCob+ed19aGa3S92npeGOSuu7CjvVSaUydpVdCxW+0VTGqUtcFxD70sVdL6zco3tFmpxvRp",
-	"wE09FFqvA59z9l8alWDWsl/UJhxMsqiL1qtFf2pUeh0NR0G4DaZ5QDcHzV4o9CL0Wo/UfVutHxxm1DyT",
-	"TmjVYhc1ojRvtw15WqzF1TCohUg0vMFwaby10S6cKQW56pfxvV7GtUk2wjTxYeR0Uun0js9svJYCGhmI",
-	"sMdFaTrcXbJa7ce6qoDlTHSOkywduR6Q1x1lBu+XpSf+jW3RjYe4OJljGXbQEE0qjw7mQKMt4EsTL+N0",
-	"udMDcnuQmEYqM2MmwS3SWXGu1IgreW2mcM4Dane50+t2zi4vgzXcwh2Xr+PY3n7L2yjaMwsZxksFqsoj",
-	"QRVRumT8LBVcSkSmEmGfBjJ8GI/KVQ6YZXqn0RDFqGnaU15tp/Nu9/DYfACGd71RlDygYAwpRTjudrp7",
-	"FyX+pxXr+tUnn45vffRapmbziXLZ8Bdv8FoutzVeGhx4YfOr8ZCMs7so9DcX8GVr197Ml87Mgl5K8Szf",
-	"RdWvk2nD/9iA4a80nE/TxD5XsJ9yBIEdhczaTACF7cRJcISgTclfZXdSzxeJlBMMPoSYZjACCQbXKaEY",
-	"waWuZpteFl+Pr6aT02NrRELVXv6o+MNoMr0+vrCVl6Rs6ElxtbXm0hVa68+IXd6+Kr51ew5cc1O7r4Pt",
-	"SqSTImhbIFbTLrteVVo1yxrXqW3Xn5XYXtmuQXeHj+MqJlesiqLQVYxYzJx0hzUEXm9f7acFtQ6UMYrp",
-	"hJVvvYFvsJBWWJja9kusoji/kMotPETgvlieMqmiTauSZaVQGy+18AyKNcu0Z7L4ZtzWG6t3p23psV5g",
-	"f/pYTyHRJppkHdnc7IMuRCibICkrrkcWWvYMrQWFHTWhnNmevPzPtsQnY+OMtghk6wbELiMDT2RqWXFs",
-	"ovJqw2oST0lUmf2l7up8HdQwVAeGzowS33QYmKW/kgLFaYXZJYz3Aqj7AqZt4ccIjRWcp5Pxu506p/QA",
-	"Pw1rVyklvIzypIKsdF2sZMAmGYPJBJar3EVdTQgb8MwwBISz0qPmB0gAERlsZhlfTOOE6vFfrk9OTq+u",
-	"vIF3djy6uJ6w3k8nk/cTY/d62CXDcQq8k1FxiCkqzmL3oblqk2qIG9UyDOCrPWxlVwjv3Mkt8c2NUBzO",
-	"56bn45rVJIsUk3k8mY7Ojk+mNyeT0+PpiPuX89/enF6c8t9ME1vZBFvkMJOXko0B7FQTY5x8Nl2/g5nY",
-	"QbnZVKXYgW12VBFEsLVkPQYht7WgFuKwsb4qx5XnMqHoGkdX2UwGyascW6QyaAFP/kJ4KQDTFMUBCvgM",
-	"ckFlrYDryQVnK12EJLeDD8FZgoHw5+dGMBmIQlzzEpDcI4zDIIznvLkAzWAWUXA7JOEyjdCt6DwjKODt",
-	"jx/HoxdsYJCGdxECYRygz4gcggsEeSMwDgDFMIzYP0gEyQIRADHiOenUesFLPYRRBO7YB7yEUfg7Cg5/",
-	"jb3GFS4/DuFxLBbZnTfwTjJCE2baHz+QU5/BmR9UnqCYYr6KjR/HoccP3P7KUMUPtd5jJpEnmKk8tsAl",
-	"DHWPXukdqxHoTjvrHA21tWvgfX5R0uovZESGYnvBpEkHbz1xmUuKHrJCZh7ikJAnIwhfur06VSWZfJRd",
-	"ah3Ug3IyulzQzSoaZM2ok+qCidWpNkFzeVlDFe0WJqeUTGIDTjYUw7vI5mlHRUwR93VHD0RiukTS4rSI",
-	"CfIzjCynAzIHvc2lQRGhenq4LKIdLr7ICmtkz3hWMZNrcwcLQS7mhllyiKPgEobeunEsnpd7BQa12f9o",
-	"ly0xU/m+rk3M3k6nYyVrQNWrytxdEphj3ywK8Lur9WbKizR0HUmXFTdCu/Xaifp0IoMsueQWqItQgwVZ",
-	"S2Vo3BhMTqeT0fHri9MbsTFgW4Xp8cWNfZtQu8DmroLBqUaLURm7Klu5GjkWRyq+lcGR5NgELgTBWcmJ",
-	"GrxygUV3FZmnncSr61eMpLJ6P3MeqKzBVIVZ/csCLia1pvkkHh01cQP8rb6mb2sJ/l7XvupqpphUWr4s",
-	"S5xpNaskCq1pq0oez5bLj465L6z8CwPLQaND8gfH/qXp4C5oZeOhdKXBG+jjz+ls5rPdGdwUwat6P6ZW",
-	"oJGveAMJFGDxMss6zicut7NEZbeVoxHC2nCi/wIE6B5FjBtEYvbIW1CakqPh8OHh4XAhqh6GCReVkEbN",
-	"DR6PR1rkrCPvx8OXhy/5NYoUxTANvSPvz/wncbzN+T/E+tXkxGTXnfB1GMC8o0OPNymv7AZ5Ef3OHsRw",
-	"iSjXChbPT1FkyMMcTdDs7xnCj2P2O7+WIRfY19LIMjVSFAlRcYxrWGf5YH96+aO9IVluWMtx/zTwXr18",
-	"2V7xNQy0jl+59GVI8/3q5Z9d6xXZuf+/C30juYG7QvgeYZX5e+ARFVtXzbQ+z/zN9NEvnraN/8gq5bgZ",
-	"flF/3WA0exLwiRA1WNlv+O8akEAoInJC30+ymEqHGALz8B7FQAS1LANNNLEy0HA+tzOmNnSolWDiwE2V",
-	"0P4rQMerl6/aK10m9CzJ4k3CqTbfNjwNvDkyKJ4JohmOSQEXGQC3O2zOEd0HzHyNquW5wGObfDuG0syA",
-	"oWuemp6spXT4FbPHbQBo4+tbD8KNgrCOnhWWxKEyIofFvS+jvrsICa3GzqvbWrWIfGRDiBy01kvhHF3y",
-	"93OupfktSYeyBEHsL6YIr6paa1zp4d0ObxPgNIAXUS0c8U1UHlgjvM8RraSCPTQt1KWksmcJ3rDebcfi",
-	"DCfLN5Ai5wo00YqvhN7SmHvktiO3jqV1cPtF/eWyfVGtH1o2J1owl93gVRG/UqXpY4r6bdAutkEaLjYA",
-	"VM2WaLB7260JUe6Z7ImNIrejLV0xFtYwqHuzYyWrepOGhyYXm7dB9lscemvl+7VWhqRIi+YAd1G4GfBF",
-	"/rRv33apDLpHclck52DZBJapDOVqdY0QUI0LYlbe1Yi2e43lPXepVHjZi4ijU8UUd3gTQiIPd4df5B9d",
-	"NqxAxh5o27gWIQr2WG5Uvvp+z7vXR39xDX3bEoShlk2y3RYqDpOsplBR5OsyhbYjO/4ijIIPquL6Npfg",
-	"br+euIgSQ/EdMoF3S5LEX+s4CZQ5+7tRrkz5ucm3t8iIaHJdu1h3STIxtxeuDsJlBrImYpUCG5W0IgW5",
-	"s6Dleb5b5KzIB/4Ni9kaIiP404vKGqKSQ2wXoqKnP3UWFi2Zaou46GlXe4FpWmMUp3rRWUN0NLjtUnjI",
-	"StJD3MXnG1xwNmqo5XzqpWcD0rP1tWcWRmj4hf33JoZL9GQVn39mhIJ7GIX8gBN9DglFsY9KydNYM01+",
-	"hzPxvXc6EM73S7hE69671lnbS1zHUx6J1+24GvJkqu0uu5nMotooOL27buvHSgmm73Hg1jArfBaiKNjJ",
-	"gVWROrcX8lX8ikrCtiPqCxQtnXyKb1G0dPIosoLfvD9xQ3ZnnVe9jHSQERMmNUkpfd6guDh5O8q0Nfk6",
-	"dBB8rZ6OtdHfOy7Wxr/BbbEFCeh0uU0eTTpdcpNlv9a7btv0D75P6SY2WmUO95LWcctVAfNmTbK2S3Yw",
-	"ivh72Co1lpvSUVSZ9G9xC/Zdbqf0J5VybntJ7vqoUhOKVWW4q8ASET67eDbZJLTk9ePOH1iK9x7fjfDt",
-	"7mKiKW19L7IdRbYmPp3f+4sQ8S94iPgXbR4PFefi5GIERJRzGYxcBTu5gwQFIIlVlkkVbL4m1VqM9Ofz",
-	"hnS1UFcHe324PdTdw6rY4LYK3oswb0230cXvoEgxyP2NKsCe6UJ6UfRMxXn7SgDtdrTVX17fSVSzwA4m",
-	"BXUNwdZIQr+5AHdrkF3liXM9/OJK75vL0TK/w7B4vzlDp0lLymC37ZYAt0aSWR4h2XYCwsr9QzX6DYQA",
-	"2u+dsOL0d4j/CtAU8vOfuMo0hg5VkG6Dsgg8qWVleCaNWQknvVL80LyN7zR8aDGLBqC4KMjhF/nXTRHT",
-	"2S2uaNG1yZzcLLza1U4e3VwNog85uqN3h40QbAk22qaqzhH96oH0/aqo0uyZF7JsDXCIyDZ7h49+Fdwh",
-	"xKoY2OQqOMwzt7RvI2oJVnKPIrPnmnYTp0Un+wDh7W1K1t4M6PmuvuNdQQkwW8J78T3/7SYMnlYXg4aV",
-	"vZST6CvA/0OF7FGwIQvhe8a3GQ67RfcwT73UhHNRwphRq4zwCZKpd3qc9zgvToTsoLCiPU9OPsRZ0wsO",
-	"7suEUQS0KkBUMRkglRTyZHOZZFY7Sq8ktO+PF51O0k1zXRwq5t8a/IZ5yqFqU9bMQ6WZesYERBXErJWn",
-	"oUffavmLjLAxA9CozYZfpGHZ6nBshafKV9QCz5C1Kg8UZd4wmXVNpSKjOEMDT2TQM6Ut6x2KW85h5Ayp",
-	"gf22ugNgeKai/URLr5BWutXdCTpNsdwd0CNK7gpA/eL4FUZd38jiOFyGcwG7YbiE87YNQF4aiNIy1xaM",
-	"AYddfR/wTlUYida3gOCv8arDyjuZMj97aXHcyFRxuwlJGX7h/+fenSgphbyuWQL5tF0kc3KWYD57WxIG",
-	"UyOS0O2bFuMIhvEUfe5vqzsaFQUyGYb4jXUoUboeSAmFmNpzAV+xz1rvTYqcl80h3G96vh6EVWZ5XUQl",
-	"aROgktQZT0naw+mrhJM+x41o4o44MvzC/985u6AqCojMsdKaXPCK9bOyu7BP1PMt7terIFJo5Vgh7UB1",
-	"fa9ZlG95orkbfKoXb/rZXP9CU5aOIEUkz4zhNEj+WG8TLzr7l5wdt226YLkKLy4eu7lJb1HBFhdBez+3",
-	"EwGuQ85d6L+raAgDh+sNfoZJeO/OE+Inm3u73Uu680mzJmJ1UWcVeANC6KoXZ/IX3hmOvCNvCNNweP8j",
-	"nz/ZVrXO8XhEAE2Azw8aByDjPtUBiGrEyB2IpgMYiMytzRGVTeiaS7ZQWAGNDQD5zhwkMyCiPZsaq0XH",
-	"dW5zgaKlqcVK3Cd7e0aWPRSvimR7+U2Tp49P/xsAAP//xxJwpbA6AQA=",
+	"H4sIAAAAAAAC/+1dbXPbOJL+KzjdXdVsTrEys3NXV7maD4otO9r120ryTG3tpBxahCROKFJDkHa8KVfd",
+	"p/sBd/9wf8nhlQRJAAQlipYTzoeJReKl2Xi60Wg0Gl9683C9CQMYxKj39ktv40TOGsYwor/OnTvoo2vy",
+	"jPx0IZpH3ib2wqD3lr086vV7Hvn1ewKjR/wjwNXxT5+8xD/RfAXXDqnsxXBNG40fN6QEiiMvWPae+uKB",
+	"E0XOY+8JP5jApYdfP45dTJa38GCkIUEUBFlJDT0RXN56cqGdCJvhF1UkkTIaYmL2KiMBBglu6m+9n8eT",
+	"2c3wHL+7uZ7OJqPhRe9Dv0gXpsOJ8Hc481hDw5C+jjW9i8o5Ckx9xCtNP5e4QRAugCiagmGD6yg7jODv",
+	"iRdBt/c2jhJoR4CB2aIIILWPKr73Vsv2dehSsLpO7CAYq3k+X3m++zMWDNy1hpxjUgTcszLAC+a4NcKf",
+	"k3D+CUYpm5COUrmLitFxvSVE8dVGB4ET+l7XEatt1cVu7dcZ74XnQ4IoC8ANxbif4joa1JHmbunf9ckw",
+	"kCBea76c9soJMfYShesTJ9YBm7w6AqdhtHZi8BpcXAxOTgZ/xf/pusXNVfTo4yZRLNCl0ObkNeDvCWPx",
+	"JKDX7qTw7b0eqndh6EMnoD1vnPknZwltlOY1K2pSnry1sjTX0OMb3MBlsr7DM0FZiJMowvMEIGVAwArp",
+	"KFnmKXDhwkn8uPf2ezzAdOxwKS+I/+PHXkoE/gmXuElBxtT7O1QAnfZLoE6/CmzwD96dihJEGlFS8sMb",
+	"O1IiOE/wYN7rRuiXFYxXmIg4BD4eKhCxEfMgAmlV//Ho1+DX4NWrE7jBDzFG3KNXr8ANVoK4LgjgA/iI",
+	"5uEGfgSpmcFqgI9pIz8RCf0IwD/+53956Z+cYI7RFkboY6HowvERLisVDbAlg0tpjQBeU80r2lxfhWD+",
+	"tY8TuDCohpvAwx0CIv0gszUAZj/9/oUXOL5g3COeHujTuwh/3uoIzPDf946P68+dANzhZqLwHrfiAuhR",
+	"zjsIOGCR+P4juJmcv4bBPCRvaW/fwaPlUR98DKOlE3h/dwhB//rDKW7iNziP8V+i149/ACFvauM7mARa",
+	"HQYulhTwgDvCL+LI8Xzye+MnCCBvGYDvPv4bromrIUhGDo+FsssB73AguhvgakfZcOQVtCh0G8FFTR1N",
+	"B1szCq9eTclbIjsSSDluX70iEHr1iuAEQ/Mf//1/YM7lHWHFgmsFmL/fcUj8AQBASqcAVFZ59YowCr9y",
+	"fJ8AO32DeHVCH2axg03s6gaoDZDW/zUYL0C49mIsS5jZFN7Aw8OHULLG4qWFOuWQ0thJP4YYPBllpCpu",
+	"XW37IOhE89UMRgp+s3eAvNRNF6zIbUzqVwxsGMWnHvRdRT/pK00n+P3tgheo6uMqclW6P3tl6CPkBYx9",
+	"kPFrQFswgLSnKlrSDya1QD95G53AWf4XMmQdzyt4XgS2xHQTk+OwQaM1Dit6uzeutrKFkqrxe6tlVNqD",
+	"/ZKDd6tZdWTd1sEur2WwkIVdPjMsdHkr+nXuyfhsNJ3hV7PhmVrRP8C7VRh+Gn3GlhLpeexWazBeB0BR",
+	"SRItDZd4ldu0yq3n1mQZb0J2EdkSak1ezmFkT9wTK4xn2Xehi40PUkbAhzrNJuwteT4PsREe0D+dzcb3",
+	"5kxof0NsjZZ18i9EON/2/nmQ+esG7C0aKBundOT5wKkixlCycbG0pi4JQP11qCf5uJomstiugT6ij+cR",
+	"pAQGrqBV2IuMyJSMSeLD5mlVNr8FyWk7IMINEdJ/YeBqmuRCs7VJ5ZgnFP6eYDHCwA8a52u5ZTNKs/J4",
+	"VoZzLIpzQDwsdIrkKzOEO0F5ITuBMZ4uJ/xVLerxbInX2jGXWuIQtBU+1inhH4qdOEFV9aaslNAWTLX8",
+	"TVRmzkhJSYd3ZBZX84t9J2HYEsaZTLuUIirUgkjiL2uCL+FD4IeOexP5ZW0rXoIk8mXvcK9f9sw0xCqJ",
+	"nLocW0FMacoyAi6ZX1yjtgqkabJeO0zNHQqS6OwAxGuZQaRv1DaDSJ+HxB7SFFKzh41lhyCUkSSo5Cbt",
+	"87Ao3/kBcMrN7xGlu0gS4945btMT8iiKwkhFHu4LRGKO7veOfQ/Xm8I42bB5ri2ZL3f8nGNFTSdKEV51",
+	"Y5LkKZZt8j2LCaLq+gAh7aaE5Qm+cAJvgYH2LNwSnR8gv9YSaYzoc+cRq4VW+cS6PEibhBCW8UYMZLvs",
+	"SXs9TNYQe79VVXSOl+hZp4fEFGLaU568h/76WdR0ueMD4M8KE6VS0TKxLStoVdcHxylZOY8xK6LA8acw",
+	"wmYbs6n2bqGJTrElQnoFkBXs94gIPsf6tdTvc1tqNFBB4eGUCX0G3hwUW4r84OuiZ2CL2M45BO7wxRfK",
+	"ua84py68ZUQ5MF47S9gio/IdPwOfJiU+rQVJwCM0pdJ1NffEsM6cJWqRSYWeDwJNMSYEeMEipHAKwNXx",
+	"uIQqsTvyDHqp2PVB6qds96h1vhwEP+TNL0ZcYYeqRbbkej4IPVTcZ0sVEd8VQ+mGdouMKvX9HNqIsofv",
+	"7aFsiz7vrZapfQYGHYSAPUjEXIbxaZgE7v6NeBJWxHc2IXG4ojCJ5hA8OAgEIdmqJVTgWtckMGgGP+vm",
+	"hRi/GtDoof8C85UTIRj/lMSL1/+ZpxF+dtYbn7AGr7H8sA8ewsh3/6m8M1emdMiDk0hPOfC0rJkPRSuz",
+	"HfQ+8zFYBim0xKCD0s9F1cwZRciaJvM5RGgHfjTxYTZfxCkFEwn3N4GTxCsSIEQj3fevK4odpjSEkff3",
+	"9gjgvWWRLG3PrcVunwHh5ZA3WSOmoThtsuNA9aEyrIiE6rXEnXynz8AkKYSJxvVmQHkSMYQsdolqmD/D",
+	"xynErIzxH+UPdkQZ5SknJ9+CdCTWovSUBACPqRKpPC6krkz5q+oJiQ+qoCgtV4+WfDUNFcVhVJD0gYQm",
+	"+Bs8UHkf/XEYLLyl4pwYfU6xz6qVA6/6hfFb4+cCtI7reqQlx7+WyrDQ0iKoSk8wpUEYPK5DCmQppoJv",
+	"K2hOBGPSeAFyupO8X3sBiRynxGHRI7wiWDy/Hl+OtCEUeib1e8fDydmVdjPfiZahruLVxfXVdDTR1iWP",
+	"UHGXO61+Mno3Hl5q9+rgnecEuqpXx3/W96vaW0+rno0uR5Pxsa7uGQxg5M11lbV8OtMx6f3o/MJ+dymr",
+	"dnN2Nr48Ox0eawf1fbJc4tE/xSKkaeRi+PNIy+AL5x7q+Ht5raX5cqMj+fLmbDTTVkvwLKOpeP3X2fsr",
+	"LZ3Xj9iA0RE60RM60RI6uXn317PRxVRbM7l7PINrpKk+G00mw9OribbnGYwih6hBZQNPqYp5vMwdj6UH",
+	"aPFb3NoVnuT+Vj+AJO2h7qamZUWTcFTV1cOtqqYBAFVVdWCtqjfZsp4e5VU19Xq2clC2q1alParqG/Rz",
+	"VVXDbFQ5MCbRrOSxaT6qqlwh1B+KhoOcCsM2wlFoAGaYu8NYaXTxt+/UJqUIrD4OE2aeW9hjHvpLavK6",
+	"qowAfZJvgzq8NDSxUziKF7Juq+DCdV4NygcPHL6sKBug/Ch+6cV9ljTBbFfSXbBLlnNCPgnGfEI8bUQS",
+	"EftL/pYPKgOPD+MIUx0/XlhajhqrjzUC0lYM/RUPKOWByDfR62VbkDnEGzB9sfytmu+RPqQ5QRFgRw2j",
+	"vT7PSB0UX3ApUVaQlxI2H1mA0X6kbJP4WIet106gJtpKCqNS8idjMe2y01po0xwxpX6LSVNKomwrxOzE",
+	"QgnP750oIG7FFNesXF9z2qYOLkUdkV7Eokocxo4/xatBKSuJRbVkU6ufJxObeJSpBaN4yfYmyu20Qzof",
+	"qJrcRjFUTJ3byq5hHrPFuOiz6CNcYOEhIXS5XF24XfvkW6UjIxYzhDgvvZ+Zgqa3SoGgl75aYCEBqo1P",
+	"Pd00suM0ojEK259DCieWytkX2OmBkgTsQ1Wa9ZoFMPdsye9osFcOSRKv1OpumO3QEd4XVN0NImkKEHoI",
+	"I5LuQOHylx27KkWoX9mb/NO0Vqvu6WMy0MnmOvS9uQKr/DVg7ymNpSl+kiZv0hpE0P3Fi1decOI8Im3K",
+	"DASy4jSdCs3s4iESwf0IXFwVc4ZkJyNB1HgqgiRtmVLhws8bjBvRWfn9Jwg35w5bNunSua1DGq40J0fI",
+	"5ChT6MxXgOMOk+fEtkRV6d9rLFre53pGhsiKUruqGgqlE3wKPNAzdbQQONHB0/GC99Bx9dtH5rcsBEv+",
+	"GsuDh1NWt3JtKxEokyN1/sHMH9GRmT+ilHkPZ3x5btjDkTuN4SZzRw/faT3ZM+euWKHsfo5r+Z3VZFR6",
+	"zxSElFxmq22RElvMSXwIlLZzrJs1Ch9bNcqkSPGj5swq2g7FlFvMqlLI/Go3jhQ6SjlTxQXJzqtgBhBF",
+	"+yqflNoMIAm7NImsKunSzKqVY4Tww60HqLZKTZmtoTRXqGiPEHeDNydbkWQvBpups/ATDNSGh8ndbbQ9",
+	"eMVWzQ/DfoKJVlatXUpVR7crbft0F7+gHtr2YNj4+ndeYe7J5VG9zpTev3tkCa93X4/WXWiy/YJD2ZUw",
+	"bBSbBEudCaAsWOYRqZaf9LRopQSlJctWZtaEma1pST2j6OF5jGgrnyY7aa+b83dwTogWKuhEle5XVkzr",
+	"UXD1IsJPpNvOiiXuKQyWEA2juUWsF6dK//ECCtrVifVImdWvnjv72nnSsmi3DVE1g9NBTvutZrmB2VmR",
+	"IpvNU9JabroG2Ioo0C+Lt1O4KmakJ8WLEu8q7DlyVGQVxxt20BvQQn3pRMePb35UZVhzdagepoaLUMfA",
+	"uQuTmKa9ZYfJFSSv8diQ06dK8iIKJdpAmmwQNwzdXr9SRdGvEa0rmfU5jpxs3VZIT88DsmkhkC6883z9",
+	"pAmcNSwTZBo/Uf8cK6wiUEp7UaKPvNNabSs4/4SSdc2dIDtjz2TfGFxHTTjD+aUE2eeVqZK/gner4qwp",
+	"VMxkdixZvWq7I9eCld1xVt8Le9auC1aRbaSsbElKi6pVRXoU1xA/1OSS4xtYMXwdiwFt6KdJClT5ZppY",
+	"CCiTxlQAft+LgKqwSCOfWN0FyUXfptr40yIKl/IZQnGgsWSmEDVP88ZrhIztc4nDKBaFpHMfOtyn6iOJ",
+	"PJW1kyCSE2dtMUkxiGffoBq/XNaWsgHFDg5LueGRVncim+okGbmNwVoKlFNYB6ShVOmWLTUa68NvN0pv",
+	"GZLNSZsbjNgFRjV62dAcIXIvb95Y9zMOXPhZ3c9curJJbt6+cfUtTKTtQH8Tk8ws5ZVKGdoyHFTh7Fx4",
+	"s3VoUaxGaRhRaZnUCgK2iWHqUGOJGkOwrCpzkoWKSfectZpK7JrXba2W5irGcXUK7OUrMNPy16C9Tmla",
+	"xiIaWbLGbdqxwmE+Q2UHvYOGHsOCDnaFrGgGyJSSlSkdi/tRfOXcbR3oDhp0GaPkoZH6lr+xL6CjA2kx",
+	"K91OM3U7SAntSSb57FKySbI7W6nIs6UzFXfAa3G4dEiUl/PWc6shFLNTW88Lg9R159W0l3JjaiWtAjp6",
+	"q72ASYmyKjgeoJelSFq3WPmKFivFJGkG3JRzS3Y68DlH/41SCSYV60VpwMEk8etovVI6PaPSq2k4MsJ1",
+	"ME0zZFpo9kyhZ7ksO6Qe2mz9YDGi6pG0QquUDM6I0rTdKuRJyWu3w6CUc7YER2jVeGWjdTiTyxrYTeMH",
+	"PY1Lg6yEaTh3fKudSquzoGrjNZchTkGEPhORaXN3TWpVb+uKApo90WUUJpux7QZ52VGm8H5peqLvyBJd",
+	"uYkbhcuI53FVpOdL0y1a0KhLsWTiZbBZt7pBrk/LZKQyUV7Nukc6C86VEnE5rw0uQW8oqBPTa7fPzoPB",
+	"DFG41/lwHF3+AB6NIh1f4Yn7RGq6NPdblpePZ8wT6eRYLjqW6E2kKqR5+PIp8tJsgn2RCVFK8yZlKpSz",
+	"t6nOzBhyjZmgsqHVWsWK3iuk8xsozpP7fvgAyX3x5F6iejvCdz6Jwd2u7rx41Njy3JVcS9VsOlA2ToLs",
+	"PGRFQJwx0BArWnO2Ag9dJ3eY4uYSDe0tVE4dqKZWDp6cLoGfUSuHoEmf/8GA4ReaRso0sM+VZCqfuaKl",
+	"VG3NJO7YT34OSwjqlPw0ueN6PrvNHiv8n70oTrDNj/+82eD60FnLatZ0yvvmejqbjIba7KGivfSA98/j",
+	"yexmeK4rz0lp6Hh3sTVz6QKt5SPdNueQBd/qHc0uubbt58FqJVJLEVRNENtpl7ZnlUrNskMIti5kWojt",
+	"VBc6XR8+lrMYn7EKikJWMWwys9Id2tSLnX11mBbULlAmDpgJKV8Zta+wkLaYmKrWWKQi2/Pgys07guA+",
+	"m54SrqJVs5JmphCLNTHx9LM5S7Vm0vhz7OYbrUeoaurRBr0/fSjf41MlmmgX2Wz2EBhWJGSAuKzYbnNI",
+	"VxhJLQjsiAGlzO7xAwNkGX18rRzRCoGsXIDoZaTfY9dlbfltrPJ2n2UST05Unv257sp87ZcwVAaGzIwc",
+	"32QYqKW/cA+V1QzTJowPAqiHAqZ94UcJjS0crpPri3adU6Y07UZCecVWqZVTQxlm2jnLI4RoHiGeH0yk",
+	"56k7tfJUXzx7lwra09QJX7xD3KWXiSHgLXLHtskFhIhderZI6NQfhLGcOejm+Hg0Jd7Q0+H4/GZCeh9N",
+	"JlcTZfdywi7FhpFzx/MpIVU+pVX7Sd1Kg6rIOFbxGWAuVtyFNaxzZ09ujm92hBovFijTLIoXDwGDYzX5",
+	"DQrKLPKWS9VZfskc5UUy3A0ns/Hp8Hh2e4wtyNmYOvvTZyej8xF9psJgwbug0RsJjxBXZqQUTeDP/ayK",
+	"hSS349kbq7lkoJW3XKRZQStLlpOKUiPWkXKWGuuLcnRWWocxvIn8abLgmSALe0gbnkGCXm2GaCngbDYw",
+	"IMk2yQhSnUJaATeTc8pWmntTLDCOwCl+xDZK0tUF6rNCdEpDILzHKPVcPI60ORcunMSPwccB8shO8kfW",
+	"eYJwjwva2PX4NfkwPJJ3PgQe2QKH6AicY0VCGiG308URBjn5gXwHrSBPARrGQEzEtNSD5/vgjryI1o5P",
+	"rj08+jXoGU2HdG+KJhVZJXdkwyhBcUjWTMMHNJoTONNd42PM94iaB5hkcpr1crP+E0EV3WG8iuitWCQr",
+	"G3l2FhLUEXegdKi4l02OPbGP5iF1Cm0rT0YKkpKt0O99fp2bl17zrBnZco4ImYzp8m2dNvfSoS2uo0MW",
+	"t9CRk8GXdieDRUkiNnkXZg2tIZy6NkHUSUGx7JhxVQQBaZ2YmBQeUCOK1ktllLs0pgGnJgycO1+3swGz",
+	"vC/2M6ecLEYV6FPhJAowopIIanZjAmJPO77OhUR2E+Q7UbGyqhGcxCvscEvOs4oZn7Jr2Dh8jleMkkWu",
+	"C5vrJrQL9SwFQC/DoDT6H/SyxUYqXUdXidn72exayBoQ9Yoydxe66vxEqwz89mrdTHl292pN0nnFRmjX",
+	"hgaJV8c8EZbNHSJlETIYlqX7e5VLm8loNhkP352PbtnShix2ZsPzW/1CpxRkaK+CwUiiRamMbZUtn40s",
+	"i0ORg0zhuLNsIsoEwVrJsRq0coZFexWZ3rUcba9fsS5juudqYf2hvAZRFWr1zwvYWNqS5uN4tNTEBvhr",
+	"fXtf1xT8rc59xdlMMCk3fWmmONVsVrgdu6StCpdXVwSoWt5xo+Wf52o2di0uebHsn5sO9oKWNx5yISTE",
+	"6Zp9f0qnmc9657spy1oxHqlUwMjXqIGLUpzs9Jz2O5+o3C5CcaU7/xomrIYIitd4TX8PfcINxDH7tkdS",
+	"XaK3g8HDw8PRilU98kIqKl7smxscXo+l7GZve98fvTl6Q8NWNlhONh5+9Ef6iIUTUP4PIjl8PFTZdcd0",
+	"HgZO2hG5kYNQzcKq3bSIHCOJB34NY6oVNA6hrMiApqKawMVfEkjij/BzGgbDJ9h33MhSNZIVwXgaFIMJ",
+	"pHmWfuwPb77XN8TLSY1k0+2Pb95UV3znuFLHP9r0dRM42c050GX1/mhbL4yIb4ZU+ncb+sZ8ATeFEcYI",
+	"S8JKsItE/mMx0vI403PtWBCkZfwHUinFzeCL+OsW9/7E4EMublEko6bPJSABj2VNdeZzEtbD/WQQLD1y",
+	"ToAlHs0DjTWxNdCidGwXRG3IUMvBxIKbU7ZN8BLQQVLkVla6DONTPAhNwqk03jo89XtLqFA8ExgnUYAy",
+	"uPAkxfVhcwbjQ8DMS1QtzwUe3eDrMbRJFBi62bh0o28XpUND+h73AaDG57cOhI2CsIyeLabEgTAiB1mc",
+	"nVLfkQOoxfyGZVurlDURNYTIfmU9cpaR3elmW5pGpVqURdCJ5qsZjLZVrSWudPCuhrcKcBLAs8wjlvhG",
+	"4r5nJbzxJFy48vlINVHnLo8+DaOG9W41FhdRuD7B42ldIQ6l4luhN/fNHXKrkVvG0i64/SL+slm+iNaP",
+	"NIsTKeFOO3gVxG9VibhWumVQG8sgCRcNAFWyJQx2b7U1wco9kz3RKHJr2tIFY2EHg7ozO7ayqps0PCS5",
+	"aN4GOWxx6KyVb9daGaDs6joLuLPCZsBnd9x9/bZL4aM7JNdFcgqWJrAc83S7WtcIAsXcLWrlXcw6fNBY",
+	"PnCXSoGXnYhYOlVUuaGbEBK+uTv4wv+os2AFPNdD1cI1SwlxwHLDv79b8x721l9QQt++BGEg3fhZbQtl",
+	"m0laUygr8rJMof3Iznzl+e7PouLuNhfjbjef2IgSQfEdVIF3T5JED/FYCVTxfnGDXKnuUEdf3yTDMv7V",
+	"7WLXKUnF3E64agiXGsiSiBUKNCpp2TXx1oKW3sVeIWfZne1fsZjtIDKMP52o7CAqKcTaEBX5ilprYZEu",
+	"vK0QF/lq3E5gTHOM4FQnOjuIjgS3NoUHbSU9yF58vsIJp1FDLeVTJz0NSM/e5x5yzGTwhfz/lpzqeNKK",
+	"z28JisG943t0gxN+xj3BYA5zF9yRZkx+h1P2vnM6IMp3kpJg17hrmbWdxNXc5eF43Y+rIb3wttplt+A3",
+	"3RoFp3PX7X1bKYziq8i1a5gUPvWg77ayYZVdb9wJ+TZ+RSFh+xH1FfTXVj7F97iglUeRFPzq/YkN2Z1l",
+	"XnUyUkNGVJiUJCX3ukFxsfJ25Gkz+TpkELxUT8fO6O8cFzvjX+G22IME1Apu41uTVkFuvOxLjXXbp3/w",
+	"ahM3sdDKc7iTtJpLrgKYmzXJqoLsHN+n52GL1GgipX2/MOhf4xLsm1xOyUcq+dh2klz3UKUkFNvKcF2B",
+	"RSwLeHZs0iS06N1j6wcs2XmPb0b42gtMZFe85++D60S2psiWxKf2eX+W5P41TXL/usrjIfJcHJ+PAcvT",
+	"ztOpi2Qndw5J8YytAX7xjUiXX5JqKcv783lD6lqo24O9/Lkd1O3Tqujgtg3eszRvpmh09hxkVzpSf6NI",
+	"sKcKSM+Knoo8by8E0HZbW13weitZzVw9mATUJQRrMwn9bgPcvUF2myPO5fSLW51vzmfL/AbT4v1uDR2T",
+	"luTJbqstAWqNhIs0Q7JuB4SU+0U0+hWkADrslbDg9DeI/wLQBPLTR1RlKlOHCkhXQZklnpRuZXgmjVlI",
+	"J71V/tC0jW80fWg2igqg2CjIwRf+122W09kur2jWtcqcbBZe1WonzW4uPqJLOdrSuUMjBCuSjVapKrzQ",
+	"fvFA+nZVVG701BNZsgM4WGabg8NHNwu2CLEiBpqcBQfpzS3Vy4jSBSupR5HYc6bVxCjr5BAgvL9Fyc6L",
+	"Afm+q294VZADzJ7wnr1Pn+GST9uLgWFmz91J9ALw/1Age+w2ZCF8y/hWw6FddA/Sq5dMOGcllDdq5RE+",
+	"gfzqnQ7nHc6zHSE9KLRoTy+DH0SJ6QQH9WWSAAWpCmBVVAaIfMt8ss1pDu1NMtttpeeo6bYXLXfSVWOd",
+	"bSqm7wx+w/TKoWJT2puHciP1jBcQFRCz0z0NHfq2u79ICRs1AJXabPCFG5aVDsdKeIr7iirg6ZFW+YYi",
+	"vzeM37omriJj98uzG/RU15Z1DsU932FkDam+PlrdAjD0pqLDREunkLaK6q4FHVMudwv0sJJtAaibHF9g",
+	"1vVGJsfB2lsy2A28tbOsWgCkpQErze/acvBvV7kOuBAVxqz1PSD4JYY6bL2SyfOzkxbLhUwRt01ICn5K",
+	"/qXeHT/MpbwuWQLpsJ3jgqdhREdvT8KgaoQTun/T4tp3vGAGP3fR6pZGRYZMgiEase5wlO4GUhQ7Uay/",
+	"C3hKXku9mxQ5LZtCuFv0vByEFUZ5V0SFGxOgwo01nsJNB6cXCSd5jI1ooo44DCH6b+3bBUVRgPgdK5WX",
+	"C05JP1u7C7uLer7G9XoRRAKtFCuoGqi25zWz8hVHNNvBpzjxJu/NdSc0eWmfXAOc3oxh9ZH0sF4TJzq7",
+	"k5w1l22yYNkKb5QddrOT3qyCLi+CdH6uFQEuQ85e6L+pbAh9i/CGeYJF/d6eJ2geNnd2u5N0651mScTK",
+	"ok4q0AaY0BUDZ9IT3knk4wcDZ+MN7r+n48fbKtYZXo8RiEMwpxuNfZBQn2of+CVi+ApE0gEEROrWsLbh",
+	"Tciai7eQWQHGBgA/Z04OjrFsz6rGStlxrdsk6chULRbyPunbU7LsITtVxNtLI02ePjz9P1BBF/alQQEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
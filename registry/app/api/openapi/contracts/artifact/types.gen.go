@@ -32,7 +32,10 @@ const (
 
 // Defines values for PackageType.
 const (
+	PackageTypeALPINE      PackageType = "ALPINE"
 	PackageTypeCARGO       PackageType = "CARGO"
+	PackageTypeCOMPOSER    PackageType = "COMPOSER"
+	PackageTypeDEBIAN      PackageType = "DEBIAN"
 	PackageTypeDOCKER      PackageType = "DOCKER"
 	PackageTypeGENERIC     PackageType = "GENERIC"
 	PackageTypeGO          PackageType = "GO"
@@ -43,6 +46,8 @@ const (
 	PackageTypeNUGET       PackageType = "NUGET"
 	PackageTypePYTHON      PackageType = "PYTHON"
 	PackageTypeRPM         PackageType = "RPM"
+	PackageTypeRUBYGEMS    PackageType = "RUBYGEMS"
+	PackageTypeTERRAFORM   PackageType = "TERRAFORM"
 )
 
 // Defines values for RegistryType.
@@ -109,7 +114,9 @@ const (
 	UpstreamConfigSourceMavenCentral UpstreamConfigSource = "MavenCentral"
 	UpstreamConfigSourceNpmJs        UpstreamConfigSource = "NpmJs"
 	UpstreamConfigSourceNugetOrg     UpstreamConfigSource = "NugetOrg"
+	UpstreamConfigSourcePackagist    UpstreamConfigSource = "Packagist"
 	UpstreamConfigSourcePyPi         UpstreamConfigSource = "PyPi"
+	UpstreamConfigSourceRubyGems     UpstreamConfigSource = "RubyGems"
 )
 
 // Defines values for WebhookExecResult.
@@ -252,6 +259,11 @@ type AccessKeySecretKey struct {
 	SecretKeySpacePath        *string `json:"secretKeySpacePath,omitempty"`
 }
 
+// AlpineArtifactDetailConfig Config for Alpine artifact details
+type AlpineArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // Anonymous defines model for Anonymous.
 type Anonymous interface{}
 
@@ -375,7 +387,12 @@ type CargoArtifactDetailConfig struct {
 
 // CleanupPolicy Cleanup Policy for Harness Artifact Registries
 type CleanupPolicy struct {
-	ExpireDays    *int      `json:"expireDays,omitempty"`
+	// DownloadedWithinDays Versions downloaded within this many days are never deleted.
+	DownloadedWithinDays *int `json:"downloadedWithinDays,omitempty"`
+	ExpireDays           *int `json:"expireDays,omitempty"`
+
+	// KeepLast Number of most recent versions of each package that are never deleted.
+	KeepLast      *int      `json:"keepLast,omitempty"`
 	Name          *string   `json:"name,omitempty"`
 	PackagePrefix *[]string `json:"packagePrefix,omitempty"`
 	VersionPrefix *[]string `json:"versionPrefix,omitempty"`
@@ -421,6 +438,16 @@ type ClientSetupStepConfig struct {
 // ClientSetupStepType ClientSetupStepType type
 type ClientSetupStepType string
 
+// ComposerArtifactDetailConfig Config for Composer artifact details
+type ComposerArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// DebianArtifactDetailConfig Config for Debian artifact details
+type DebianArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // DockerArtifactDetail Docker Artifact Detail
 type DockerArtifactDetail struct {
 	CreatedAt      *string `json:"createdAt,omitempty"`
@@ -937,6 +964,11 @@ type RpmArtifactDetailConfig struct {
 	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// RubyGemsArtifactDetailConfig Config for RubyGems artifact details
+type RubyGemsArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // SectionType refers to client setup section type
 type SectionType string
 
@@ -954,6 +986,11 @@ type TabSetupStepConfig struct {
 	Tabs *[]TabSetupStep `json:"tabs,omitempty"`
 }
 
+// TerraformArtifactDetailConfig Terraform Artifact Detail Config
+type TerraformArtifactDetailConfig struct {
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 // Trigger refers to trigger
 type Trigger string
 
@@ -2208,6 +2245,156 @@ func (t *ArtifactDetail) MergeHuggingFaceArtifactDetailConfig(v HuggingFaceArtif
 	return err
 }
 
+// AsDebianArtifactDetailConfig returns the union data inside the ArtifactDetail as a DebianArtifactDetailConfig
+func (t ArtifactDetail) AsDebianArtifactDetailConfig() (DebianArtifactDetailConfig, error) {
+	var body DebianArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromDebianArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided DebianArtifactDetailConfig
+func (t *ArtifactDetail) FromDebianArtifactDetailConfig(v DebianArtifactDetailConfig) error {
+	t.PackageType = "DEBIAN"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeDebianArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided DebianArtifactDetailConfig
+func (t *ArtifactDetail) MergeDebianArtifactDetailConfig(v DebianArtifactDetailConfig) error {
+	t.PackageType = "DEBIAN"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsAlpineArtifactDetailConfig returns the union data inside the ArtifactDetail as a AlpineArtifactDetailConfig
+func (t ArtifactDetail) AsAlpineArtifactDetailConfig() (AlpineArtifactDetailConfig, error) {
+	var body AlpineArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAlpineArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided AlpineArtifactDetailConfig
+func (t *ArtifactDetail) FromAlpineArtifactDetailConfig(v AlpineArtifactDetailConfig) error {
+	t.PackageType = "ALPINE"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAlpineArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided AlpineArtifactDetailConfig
+func (t *ArtifactDetail) MergeAlpineArtifactDetailConfig(v AlpineArtifactDetailConfig) error {
+	t.PackageType = "ALPINE"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRubyGemsArtifactDetailConfig returns the union data inside the ArtifactDetail as a RubyGemsArtifactDetailConfig
+func (t ArtifactDetail) AsRubyGemsArtifactDetailConfig() (RubyGemsArtifactDetailConfig, error) {
+	var body RubyGemsArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRubyGemsArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided RubyGemsArtifactDetailConfig
+func (t *ArtifactDetail) FromRubyGemsArtifactDetailConfig(v RubyGemsArtifactDetailConfig) error {
+	t.PackageType = "RUBYGEMS"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRubyGemsArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided RubyGemsArtifactDetailConfig
+func (t *ArtifactDetail) MergeRubyGemsArtifactDetailConfig(v RubyGemsArtifactDetailConfig) error {
+	t.PackageType = "RUBYGEMS"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsComposerArtifactDetailConfig returns the union data inside the ArtifactDetail as a ComposerArtifactDetailConfig
+func (t ArtifactDetail) AsComposerArtifactDetailConfig() (ComposerArtifactDetailConfig, error) {
+	var body ComposerArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromComposerArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided ComposerArtifactDetailConfig
+func (t *ArtifactDetail) FromComposerArtifactDetailConfig(v ComposerArtifactDetailConfig) error {
+	t.PackageType = "COMPOSER"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeComposerArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided ComposerArtifactDetailConfig
+func (t *ArtifactDetail) MergeComposerArtifactDetailConfig(v ComposerArtifactDetailConfig) error {
+	t.PackageType = "COMPOSER"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTerraformArtifactDetailConfig returns the union data inside the ArtifactDetail as a TerraformArtifactDetailConfig
+func (t ArtifactDetail) AsTerraformArtifactDetailConfig() (TerraformArtifactDetailConfig, error) {
+	var body TerraformArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTerraformArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided TerraformArtifactDetailConfig
+func (t *ArtifactDetail) FromTerraformArtifactDetailConfig(v TerraformArtifactDetailConfig) error {
+	t.PackageType = "TERRAFORM"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTerraformArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided TerraformArtifactDetailConfig
+func (t *ArtifactDetail) MergeTerraformArtifactDetailConfig(v TerraformArtifactDetailConfig) error {
+	t.PackageType = "TERRAFORM"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ArtifactDetail) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"packageType"`
@@ -2222,8 +2409,14 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 		return nil, err
 	}
 	switch discriminator {
+	case "ALPINE":
+		return t.AsAlpineArtifactDetailConfig()
 	case "CARGO":
 		return t.AsCargoArtifactDetailConfig()
+	case "COMPOSER":
+		return t.AsComposerArtifactDetailConfig()
+	case "DEBIAN":
+		return t.AsDebianArtifactDetailConfig()
 	case "DOCKER":
 		return t.AsDockerArtifactDetailConfig()
 	case "GENERIC":
@@ -2244,6 +2437,10 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 		return t.AsPythonArtifactDetailConfig()
 	case "RPM":
 		return t.AsRpmArtifactDetailConfig()
+	case "RUBYGEMS":
+		return t.AsRubyGemsArtifactDetailConfig()
+	case "TERRAFORM":
+		return t.AsTerraformArtifactDetailConfig()
 	default:
 		return nil, errors.New("unknown discriminator value: " + discriminator)
 	}
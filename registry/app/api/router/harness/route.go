@@ -61,7 +61,8 @@ type APIHandler interface {
 	http.Handler
 }
 
-func NewAPIHandler(
+// NewAPIController creates the controller that backs the registry metadata API.
+func NewAPIController(
 	repoDao store.RegistryRepository,
 	fileManager filemanager.FileManager,
 	upstreamproxyDao store.UpstreamProxyConfigRepository,
@@ -70,10 +71,8 @@ func NewAPIHandler(
 	cleanupPolicyDao store.CleanupPolicyRepository,
 	imageDao store.ImageRepository,
 	driver storagedriver.StorageDriver,
-	baseURL string,
 	spaceFinder interfaces.SpaceFinder,
 	tx dbtx.Transactor,
-	authenticator authn.Authenticator,
 	urlProvider urlprovider.Provider,
 	authorizer authz.Authorizer,
 	auditService audit.Service,
@@ -95,13 +94,10 @@ func NewAPIHandler(
 	packageWrapper interfaces.PackageWrapper,
 	publicAccess publicaccess.Service,
 	quarantineFinder quarantine.Finder,
-) APIHandler {
-	r := chi.NewRouter()
-	r.Use(audit.Middleware())
-	r.Use(middlewareauthn.Attempt(authenticator))
+) *metadata.APIController {
 	registryMetadataHelper := metadata.NewRegistryMetadataHelper(spacePathStore, spaceFinder, repoDao)
 
-	apiController := metadata.NewAPIController(
+	return metadata.NewAPIController(
 		repoDao,
 		fileManager,
 		nil,
@@ -139,6 +135,16 @@ func NewAPIHandler(
 		packageWrapper,
 		publicAccess,
 	)
+}
+
+func NewAPIHandler(
+	apiController *metadata.APIController,
+	baseURL string,
+	authenticator authn.Authenticator,
+) APIHandler {
+	r := chi.NewRouter()
+	r.Use(audit.Middleware())
+	r.Use(middlewareauthn.Attempt(authenticator))
 
 	handler := artifact.NewStrictHandler(apiController, []artifact.StrictMiddlewareFunc{})
 	muxHandler := artifact.HandlerFromMuxWithBaseURL(handler, r, baseURL)
@@ -19,7 +19,10 @@ import (
 	"net/http"
 
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/registry/app/api/handler/alpine"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/composer"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	"github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -29,6 +32,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/middleware"
 	"github.com/harness/gitness/types/enum"
 
@@ -56,6 +61,11 @@ func NewRouter(
 	cargoHandler cargo.Handler,
 	gopackageHandler gopackage.Handler,
 	huggingfaceHandler huggingface.Handler,
+	debianHandler debian.Handler,
+	alpineHandler alpine.Handler,
+	rubygemsHandler rubygems.Handler,
+	composerHandler composer.Handler,
+	terraformHandler terraform.Handler,
 ) Handler {
 	r := chi.NewRouter()
 
@@ -306,6 +316,112 @@ func NewRouter(
 				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
 				Get("/package/{name}/{version}/{architecture}/{file}/*", rpmHandler.DownloadPackageFile)
 		})
+		r.Route("/debian", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/upload/{distribution}/{component}", debianHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/repository.key", debianHandler.GetRepositoryKey)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/dists/*", debianHandler.GetRepositoryFile)
+			r.With(middleware.StoreArtifactInfo(debianHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/pool/*", debianHandler.DownloadPackageFile)
+		})
+		r.Route("/alpine", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(alpineHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/upload/{branch}/{repository}", alpineHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(alpineHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/key", alpineHandler.GetRepositoryKey)
+			r.With(middleware.StoreArtifactInfo(alpineHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/{branch}/{repository}/{architecture}/APKINDEX.tar.gz", alpineHandler.GetIndex)
+			r.With(middleware.StoreArtifactInfo(alpineHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/{branch}/{repository}/{architecture}/{file}", alpineHandler.DownloadPackageFile)
+		})
+		r.Route("/rubygems", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Post("/api/v1/gems", rubygemsHandler.UploadPackageFile)
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.StoreArtifactInfo(rubygemsHandler))
+				r.Use(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload))
+				r.Get("/specs.4.8.gz", rubygemsHandler.GetIndexFile)
+				r.Get("/latest_specs.4.8.gz", rubygemsHandler.GetIndexFile)
+				r.Get("/prerelease_specs.4.8.gz", rubygemsHandler.GetIndexFile)
+				r.Get("/quick/Marshal.4.8/{spec}", rubygemsHandler.GetIndexFile)
+				r.Get("/versions", rubygemsHandler.GetIndexFile)
+				r.Get("/names", rubygemsHandler.GetIndexFile)
+				r.Get("/info/{name}", rubygemsHandler.GetIndexFile)
+			})
+
+			r.With(middleware.StoreArtifactInfo(rubygemsHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/gems/{file}", rubygemsHandler.DownloadPackageFile)
+		})
+		r.Route("/composer", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(composerHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/upload", composerHandler.UploadPackageFile)
+			r.With(middleware.StoreArtifactInfo(composerHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/packages.json", composerHandler.GetRepositoryIndex)
+			r.With(middleware.StoreArtifactInfo(composerHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/p2/{vendor}/{file}", composerHandler.GetPackageMetadata)
+			r.With(middleware.StoreArtifactInfo(composerHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/files/{vendor}/{package}/{version}/{filename}", composerHandler.DownloadPackageFile)
+		})
+
+		r.Route("/terraform", func(r chi.Router) {
+			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/.well-known/terraform.json", terraformHandler.GetServiceDiscovery)
+
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/v1/modules/{namespace}/{name}/{system}/versions", terraformHandler.GetModuleVersions)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/v1/modules/{namespace}/{name}/{system}/{version}/download", terraformHandler.GetModuleDownloadURL)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/modules/{namespace}/{name}/{system}/{version}", terraformHandler.UploadModule)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/modules/{namespace}/{name}/{system}/{version}/{file}", terraformHandler.DownloadPackageFile)
+
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/v1/providers/{namespace}/{type}/versions", terraformHandler.GetProviderVersions)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/v1/providers/{namespace}/{type}/{version}/download/{os}/{arch}", terraformHandler.GetProviderPackage)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsUpload)).
+				Put("/providers/{namespace}/{type}/{version}/{os}/{arch}", terraformHandler.UploadProvider)
+			r.With(middleware.StoreArtifactInfo(terraformHandler)).
+				With(middleware.TrackDownloadStats(packageHandler)).
+				With(middleware.RequestPackageAccess(packageHandler, enum.PermissionArtifactsDownload)).
+				Get("/providers/{namespace}/{type}/{version}/{file}", terraformHandler.DownloadPackageFile)
+		})
 		r.Route("/cargo", func(r chi.Router) {
 			r.Use(middlewareauthn.Attempt(packageHandler.GetAuthenticator()))
 			r.With(middleware.StoreArtifactInfo(cargoHandler)).
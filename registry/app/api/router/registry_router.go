@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"strings"
 
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
 	"github.com/harness/gitness/registry/utils"
 )
 
@@ -42,7 +43,7 @@ func (r *RegistryRouter) IsEligibleTraffic(req *http.Request) bool {
 		urlPath = req.URL.RawPath
 	}
 	if utils.HasAnyPrefix(urlPath, []string{RegistryMount, "/v2/", "/registry/",
-		"/maven/", "/generic/", "/pkg/"}) ||
+		"/maven/", "/generic/", "/pkg/", terraformutil.DiscoveryPath}) ||
 		(strings.HasPrefix(urlPath, APIMount+"/v1/spaces/") &&
 			utils.HasAnySuffix(urlPath, []string{"/artifacts", "/registries"})) {
 		return true
@@ -21,11 +21,13 @@ import (
 	"github.com/harness/gitness/app/api/middleware/address"
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/registry/app/api/handler/swagger"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	generic2 "github.com/harness/gitness/registry/app/api/router/generic"
 	"github.com/harness/gitness/registry/app/api/router/harness"
 	"github.com/harness/gitness/registry/app/api/router/maven"
 	"github.com/harness/gitness/registry/app/api/router/oci"
 	"github.com/harness/gitness/registry/app/api/router/packages"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog/hlog"
@@ -42,6 +44,7 @@ func GetAppRouter(
 	mavenHandler maven.Handler,
 	genericHandler generic2.Handler,
 	packageHandler packages.Handler,
+	terraformHandler terraform.Handler,
 ) AppRouter {
 	r := chi.NewRouter()
 	r.Use(hlog.URLHandler("http.url"))
@@ -59,6 +62,8 @@ func GetAppRouter(
 		r.Handle("/generic/*", genericHandler)
 
 		r.Mount("/pkg/", packageHandler)
+		// terraform only looks for service discovery at the root of the host.
+		r.Get(terraformutil.DiscoveryPath, terraformHandler.GetHostServiceDiscovery)
 		r.Handle("/registry/swagger*", swagger.GetSwaggerHandler("/registry"))
 	})
 
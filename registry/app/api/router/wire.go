@@ -23,7 +23,11 @@ import (
 	corestore "github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/handler/alpine"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/composer"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	"github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -34,6 +38,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	"github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/interfaces"
 	generic2 "github.com/harness/gitness/registry/app/api/router/generic"
 	"github.com/harness/gitness/registry/app/api/router/harness"
@@ -62,11 +68,12 @@ func AppRouterProvider(
 	mavenHandler mavenRouter.Handler,
 	genericHandler generic2.Handler,
 	handler packagerrouter.Handler,
+	terraformHandler terraform.Handler,
 ) AppRouter {
-	return GetAppRouter(ocir, appHandler, config.APIURL, mavenHandler, genericHandler, handler)
+	return GetAppRouter(ocir, appHandler, config.APIURL, mavenHandler, genericHandler, handler, terraformHandler)
 }
 
-func APIHandlerProvider(
+func APIControllerProvider(
 	repoDao store.RegistryRepository,
 	upstreamproxyDao store.UpstreamProxyConfigRepository,
 	fileManager filemanager.FileManager,
@@ -77,7 +84,6 @@ func APIHandlerProvider(
 	driver storagedriver.StorageDriver,
 	spaceFinder refcache.SpaceFinder,
 	tx dbtx.Transactor,
-	authenticator authn.Authenticator,
 	urlProvider urlprovider.Provider,
 	authorizer authz.Authorizer,
 	auditService audit.Service,
@@ -99,8 +105,8 @@ func APIHandlerProvider(
 	packageWrapper interfaces.PackageWrapper,
 	publicAccess publicaccess.CacheService,
 	quarantineFinder quarantine.Finder,
-) harness.APIHandler {
-	return harness.NewAPIHandler(
+) *metadata.APIController {
+	return harness.NewAPIController(
 		repoDao,
 		fileManager,
 		upstreamproxyDao,
@@ -109,10 +115,8 @@ func APIHandlerProvider(
 		cleanupPolicyDao,
 		imageDao,
 		driver,
-		config.APIURL,
 		spaceFinder,
 		tx,
-		authenticator,
 		urlProvider,
 		authorizer,
 		auditService,
@@ -137,6 +141,13 @@ func APIHandlerProvider(
 	)
 }
 
+func APIHandlerProvider(
+	apiController *metadata.APIController,
+	authenticator authn.Authenticator,
+) harness.APIHandler {
+	return harness.NewAPIHandler(apiController, config.APIURL, authenticator)
+}
+
 func OCIHandlerProvider(handlerV2 *hoci.Handler) oci.RegistryOCIHandler {
 	return oci.NewOCIHandler(handlerV2)
 }
@@ -160,6 +171,11 @@ func PackageHandlerProvider(
 	cargoHandler cargo.Handler,
 	gopackageHandler gopackage.Handler,
 	huggingfaceHandler huggingface.Handler,
+	debianHandler debian.Handler,
+	alpineHandler alpine.Handler,
+	rubygemsHandler rubygems.Handler,
+	composerHandler composer.Handler,
+	terraformHandler terraform.Handler,
 ) packagerrouter.Handler {
 	return packagerrouter.NewRouter(
 		handler,
@@ -172,8 +188,13 @@ func PackageHandlerProvider(
 		cargoHandler,
 		gopackageHandler,
 		huggingfaceHandler,
+		debianHandler,
+		alpineHandler,
+		rubygemsHandler,
+		composerHandler,
+		terraformHandler,
 	)
 }
 
-var WireSet = wire.NewSet(APIHandlerProvider, OCIHandlerProvider, AppRouterProvider,
+var WireSet = wire.NewSet(APIControllerProvider, APIHandlerProvider, OCIHandlerProvider, AppRouterProvider,
 	MavenHandlerProvider, GenericHandlerProvider, PackageHandlerProvider)
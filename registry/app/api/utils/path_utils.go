@@ -95,6 +95,16 @@ func GetFilePath(packageType artifact.PackageType, imageName string, version str
 		return GetCargoFilePath(imageName, version), nil
 	case artifact.PackageTypeGO:
 		return GetGoFilePath(imageName, version), nil
+	case artifact.PackageTypeDEBIAN:
+		return GetGenericFilePath(imageName, version), nil
+	case artifact.PackageTypeALPINE:
+		return GetGenericFilePath(imageName, version), nil
+	case artifact.PackageTypeRUBYGEMS:
+		return GetGenericFilePath(imageName, version), nil
+	case artifact.PackageTypeCOMPOSER:
+		return GetGenericFilePath(imageName, version), nil
+	case artifact.PackageTypeTERRAFORM:
+		return GetGenericFilePath(imageName, version), nil
 	default:
 		return "", fmt.Errorf("unsupported package type: %s", packageType)
 	}
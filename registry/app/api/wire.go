@@ -21,7 +21,10 @@ import (
 	"github.com/harness/gitness/app/services/refcache"
 	corestore "github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
+	alpine2 "github.com/harness/gitness/registry/app/api/controller/pkg/alpine"
 	cargo2 "github.com/harness/gitness/registry/app/api/controller/pkg/cargo"
+	composer2 "github.com/harness/gitness/registry/app/api/controller/pkg/composer"
+	debian2 "github.com/harness/gitness/registry/app/api/controller/pkg/debian"
 	generic3 "github.com/harness/gitness/registry/app/api/controller/pkg/generic"
 	gopackage2 "github.com/harness/gitness/registry/app/api/controller/pkg/gopackage"
 	"github.com/harness/gitness/registry/app/api/controller/pkg/huggingface"
@@ -29,7 +32,12 @@ import (
 	nuget2 "github.com/harness/gitness/registry/app/api/controller/pkg/nuget"
 	python2 "github.com/harness/gitness/registry/app/api/controller/pkg/python"
 	rpm2 "github.com/harness/gitness/registry/app/api/controller/pkg/rpm"
+	rubygems2 "github.com/harness/gitness/registry/app/api/controller/pkg/rubygems"
+	terraform2 "github.com/harness/gitness/registry/app/api/controller/pkg/terraform"
+	"github.com/harness/gitness/registry/app/api/handler/alpine"
 	"github.com/harness/gitness/registry/app/api/handler/cargo"
+	"github.com/harness/gitness/registry/app/api/handler/composer"
+	"github.com/harness/gitness/registry/app/api/handler/debian"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
 	"github.com/harness/gitness/registry/app/api/handler/gopackage"
 	hf2 "github.com/harness/gitness/registry/app/api/handler/huggingface"
@@ -40,6 +48,8 @@ import (
 	"github.com/harness/gitness/registry/app/api/handler/packages"
 	pypi2 "github.com/harness/gitness/registry/app/api/handler/python"
 	rpm "github.com/harness/gitness/registry/app/api/handler/rpm"
+	"github.com/harness/gitness/registry/app/api/handler/rubygems"
+	"github.com/harness/gitness/registry/app/api/handler/terraform"
 	"github.com/harness/gitness/registry/app/api/interfaces"
 	"github.com/harness/gitness/registry/app/api/router"
 	storagedriver "github.com/harness/gitness/registry/app/driver"
@@ -47,8 +57,11 @@ import (
 	"github.com/harness/gitness/registry/app/driver/filesystem"
 	"github.com/harness/gitness/registry/app/driver/s3-aws"
 	"github.com/harness/gitness/registry/app/pkg"
+	alpineregistry "github.com/harness/gitness/registry/app/pkg/alpine"
 	"github.com/harness/gitness/registry/app/pkg/base"
 	cargoregistry "github.com/harness/gitness/registry/app/pkg/cargo"
+	composerregistry "github.com/harness/gitness/registry/app/pkg/composer"
+	debianregistry "github.com/harness/gitness/registry/app/pkg/debian"
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
@@ -60,6 +73,8 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/pkg/quarantine"
 	rpmregistry "github.com/harness/gitness/registry/app/pkg/rpm"
+	rubygemsregistry "github.com/harness/gitness/registry/app/pkg/rubygems"
+	terraformregistry "github.com/harness/gitness/registry/app/pkg/terraform"
 	publicaccess2 "github.com/harness/gitness/registry/app/services/publicaccess"
 	refcache2 "github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
@@ -230,6 +245,41 @@ func NewCargoHandlerProvider(
 	return cargo.NewHandler(controller, packageHandler)
 }
 
+func NewDebianHandlerProvider(
+	controller debian2.Controller,
+	packageHandler packages.Handler,
+) debian.Handler {
+	return debian.NewHandler(controller, packageHandler)
+}
+
+func NewAlpineHandlerProvider(
+	controller alpine2.Controller,
+	packageHandler packages.Handler,
+) alpine.Handler {
+	return alpine.NewHandler(controller, packageHandler)
+}
+
+func NewRubyGemsHandlerProvider(
+	controller rubygems2.Controller,
+	packageHandler packages.Handler,
+) rubygems.Handler {
+	return rubygems.NewHandler(controller, packageHandler)
+}
+
+func NewComposerHandlerProvider(
+	controller composer2.Controller,
+	packageHandler packages.Handler,
+) composer.Handler {
+	return composer.NewHandler(controller, packageHandler)
+}
+
+func NewTerraformHandlerProvider(
+	controller terraform2.Controller,
+	packageHandler packages.Handler,
+) terraform.Handler {
+	return terraform.NewHandler(controller, packageHandler)
+}
+
 func NewGoPackageHandlerProvider(
 	controller gopackage2.Controller,
 	packageHandler packages.Handler,
@@ -249,6 +299,11 @@ var WireSet = wire.NewSet(
 	NewRpmHandlerProvider,
 	NewCargoHandlerProvider,
 	NewGoPackageHandlerProvider,
+	NewDebianHandlerProvider,
+	NewAlpineHandlerProvider,
+	NewRubyGemsHandlerProvider,
+	NewComposerHandlerProvider,
+	NewTerraformHandlerProvider,
 	database.WireSet,
 	cache.WireSet,
 	refcache2.WireSet,
@@ -277,6 +332,16 @@ var WireSet = wire.NewSet(
 	huggingface.WireSet,
 	hf2.WireSet,
 	hf3.WireSet,
+	debian2.ControllerSet,
+	debianregistry.WireSet,
+	alpine2.ControllerSet,
+	alpineregistry.WireSet,
+	rubygems2.ControllerSet,
+	rubygemsregistry.WireSet,
+	composer2.ControllerSet,
+	composerregistry.WireSet,
+	terraform2.ControllerSet,
+	terraformregistry.WireSet,
 	publicaccess2.WireSet,
 )
 
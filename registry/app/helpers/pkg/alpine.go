@@ -0,0 +1,467 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/utils/alpine"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+type AlpinePackageType interface {
+	interfaces.PackageHelper
+}
+
+type alpinePackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+	alpineRegistryHelper alpine.RegistryHelper
+}
+
+func NewAlpinePackageType(
+	registryHelper interfaces.RegistryHelper,
+	alpineRegistryHelper alpine.RegistryHelper,
+) AlpinePackageType {
+	return &alpinePackageType{
+		packageType:     string(artifact.PackageTypeALPINE),
+		pathPackageType: string(types.PathPackageTypeAlpine),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCustom),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+		},
+		alpineRegistryHelper: alpineRegistryHelper,
+	}
+}
+
+func (c *alpinePackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *alpinePackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *alpinePackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *alpinePackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *alpinePackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *alpinePackageType) GetPullCommand(_ string, image string, version string) string {
+	downloadCommand := "apk add <ARTIFACT>=<VERSION>"
+
+	// Artifact versions are stored as {version}.{architecture}.
+	if i := strings.LastIndex(version, "."); i > 0 {
+		version = version[:i]
+	}
+	replacements := map[string]string{
+		"<ARTIFACT>": image,
+		"<VERSION>":  version,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *alpinePackageType) GetDownloadFileCommand(
+	regURL string,
+	fileName string,
+	version string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/<BRANCH>/<REPOSITORY>/<ARCHITECTURE>/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	// Packages are served from any branch/repository path, the architecture selects the build.
+	architecture := alpine.ArchitectureNoarch
+	if i := strings.LastIndex(version, "."); i > 0 {
+		architecture = version[i+1:]
+	}
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<ARCHITECTURE>":       architecture,
+		"<FILENAME>":           fileName,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *alpinePackageType) DeleteVersion(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete alpine artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *alpinePackageType) ReportDeleteVersionEvent(ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeALPINE,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *alpinePackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for alpine, indexes are built per registry
+}
+
+func (c *alpinePackageType) ReportBuildRegistryIndexEvent(
+	ctx context.Context, registryID int64, sources []types.SourceRef,
+) {
+	c.registryHelper.ReportBuildRegistryIndexEvent(ctx, registryID, sources)
+}
+
+func (c *alpinePackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	filePathPrefix := "/" + artifactName
+	if versionName != "" {
+		filePathPrefix += "/" + versionName
+	}
+	return filePathPrefix
+}
+
+func (c *alpinePackageType) DeleteArtifact(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete alpine artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *alpinePackageType) GetPackageURL(ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "alpine")
+}
+
+func (c *alpinePackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *alpinePackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *alpinePackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := "/" + artifactName + "/" + version + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, filename, version, auth.IsAnonymousSession(session))
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *alpinePackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromAlpineArtifactDetailConfig(artifact.AlpineArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *alpinePackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+
+	var sections []artifact.ClientSetupSection
+	isAnonymous := auth.IsAnonymousSession(session)
+	repositoryURL := registryURL
+	if !isAnonymous {
+		sections = append(sections, getAlpineAuthClientSetupSection(generateTokenType))
+		// apk reads basic auth credentials from the repository URL.
+		repositoryURL = strings.Replace(registryURL, "://",
+			"://"+url.QueryEscape(username)+":<token from step 1>@", 1)
+	}
+	sections = append(sections, getAlpineRepositoryClientSetupSection(staticStepType, registryType, repositoryURL))
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, getAlpinePublishClientSetupSection(staticStepType))
+	}
+	sections = append(sections, getAlpineInstallClientSetupSection(staticStepType))
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Alpine Client Setup",
+		SecHeader:  "Follow these instructions to install/use Alpine packages from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func getAlpineAuthClientSetupSection(
+	generateTokenType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Authentication"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Generate an identity token for authentication"),
+				Type:   &generateTokenType,
+			},
+		},
+	})
+	return section
+}
+
+func getAlpineRepositoryClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+	registryType artifact.RegistryType,
+	repositoryURL string,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Repository"),
+	}
+	sourceHeader := "Add the repository, replacing v3.20 and main with your branch and repository:"
+	steps := []artifact.ClientSetupStep{
+		{
+			Header: registryutils.StringPtr("Download the repository signing key:"),
+			Type:   &staticStepType,
+			Commands: &[]artifact.ClientSetupStepCommand{
+				{
+					Value: registryutils.StringPtr("wget -O /etc/apk/keys/<REGISTRY_NAME>.rsa.pub '" +
+						repositoryURL + "/key'"),
+				},
+			},
+		},
+	}
+	if registryType == artifact.RegistryTypeUPSTREAM {
+		// Upstream indexes keep the signature of the upstream repository.
+		sourceHeader = "Add the repository using the signing key of the upstream repository:"
+		steps = nil
+	}
+	steps = append(steps, artifact.ClientSetupStep{
+		Header: registryutils.StringPtr(sourceHeader),
+		Type:   &staticStepType,
+		Commands: &[]artifact.ClientSetupStepCommand{
+			{Value: registryutils.StringPtr("echo '" + repositoryURL + "/v3.20/main' >> /etc/apk/repositories")},
+			{Value: registryutils.StringPtr("apk update")},
+		},
+	})
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{Steps: &steps})
+	return section
+}
+
+func getAlpinePublishClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Upload a package to a branch and repository:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT '<REGISTRY_URL>/upload/v3.20/main'" +
+							" --user '<USERNAME>:<token from step 1>' --form 'file=@\"<APK_FILE>\"'"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getAlpineInstallClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Install a package using apk"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("apk add <ARTIFACT_NAME>"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func (c *alpinePackageType) BuildRegistryIndexAsync(
+	ctx context.Context,
+	registry *types.Registry,
+	payload types.BuildRegistryIndexTaskPayload,
+) error {
+	// Upstream registries serve the indexes of the upstream repository.
+	if registry.Type == artifact.RegistryTypeUPSTREAM {
+		return nil
+	}
+	err := c.alpineRegistryHelper.BuildRegistryIndex(ctx, *registry, payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to build ALPINE registry index for registry [%d]: %w", payload.RegistryID, err)
+	}
+	return nil
+}
+
+func (c *alpinePackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *alpinePackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *alpinePackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{"/" + packageName}, nil
+}
+
+func (c *alpinePackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	paths, err := c.GetNodePathsForImage(nil, packageName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(paths))
+	for i, path := range paths {
+		result[i] = path + "/" + version
+	}
+	return result, nil
+}
@@ -0,0 +1,454 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+type ComposerPackageType interface {
+	interfaces.PackageHelper
+}
+
+type composerPackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+}
+
+func NewComposerPackageType(
+	registryHelper interfaces.RegistryHelper,
+) ComposerPackageType {
+	return &composerPackageType{
+		packageType:     string(artifact.PackageTypeCOMPOSER),
+		pathPackageType: string(types.PathPackageTypeComposer),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCustom),
+			string(artifact.UpstreamConfigSourcePackagist),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+			string(artifact.UpstreamConfigSourcePackagist): {
+				urlRequired: false,
+			},
+		},
+	}
+}
+
+func (c *composerPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *composerPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *composerPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *composerPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *composerPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *composerPackageType) GetPullCommand(_ string, image string, version string) string {
+	downloadCommand := "composer require <ARTIFACT>:<VERSION>"
+	replacements := map[string]string{
+		"<ARTIFACT>": image,
+		"<VERSION>":  version,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *composerPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifactName string,
+	version string,
+	fileName string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/files/<ARTIFACT>/<VERSION>/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<ARTIFACT>":           artifactName,
+		"<VERSION>":            version,
+		"<FILENAME>":           fileName,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *composerPackageType) DeleteVersion(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete composer artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *composerPackageType) ReportDeleteVersionEvent(ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeCOMPOSER,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *composerPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for composer, metadata is built on request
+}
+
+func (c *composerPackageType) ReportBuildRegistryIndexEvent(_ context.Context, _ int64, _ []types.SourceRef) {
+	// no-op for composer, metadata is built on request
+}
+
+func (c *composerPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	filePathPrefix := "/" + artifactName
+	if versionName != "" {
+		filePathPrefix += "/" + versionName
+	}
+	return filePathPrefix
+}
+
+func (c *composerPackageType) DeleteArtifact(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete composer artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *composerPackageType) GetPackageURL(ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "composer")
+}
+
+func (c *composerPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *composerPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *composerPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := "/" + artifactName + "/" + version + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, filename,
+		auth.IsAnonymousSession(session))
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *composerPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromComposerArtifactDetailConfig(artifact.ComposerArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *composerPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+
+	var sections []artifact.ClientSetupSection
+	isAnonymous := auth.IsAnonymousSession(session)
+	if !isAnonymous {
+		sections = append(sections, getComposerAuthClientSetupSection(generateTokenType, staticStepType, registryURL))
+	}
+	sections = append(sections, getComposerRepositoryClientSetupSection(staticStepType))
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, getComposerPublishClientSetupSection(staticStepType))
+	}
+	sections = append(sections, getComposerInstallClientSetupSection(staticStepType))
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Composer Client Setup",
+		SecHeader:  "Follow these instructions to install/use Composer packages from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func getComposerAuthClientSetupSection(
+	generateTokenType artifact.ClientSetupStepType,
+	staticStepType artifact.ClientSetupStepType,
+	registryURL string,
+) artifact.ClientSetupSection {
+	host := registryURL
+	if u, err := url.Parse(registryURL); err == nil {
+		host = u.Host
+	}
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Authentication"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Generate an identity token for authentication"),
+				Type:   &generateTokenType,
+			},
+			{
+				Header: registryutils.StringPtr("Store the credentials in the auth.json of your project:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("composer config http-basic." + host +
+							" <USERNAME> '<token from step 1>'"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getComposerRepositoryClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Repository"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Add the registry to the repositories of your composer.json:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{Value: registryutils.StringPtr("composer config repositories.<REGISTRY_NAME> composer " +
+						"'<REGISTRY_URL>'")},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getComposerPublishClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Create a dist archive of your package:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{Value: registryutils.StringPtr("composer archive --format=zip --file=<ARCHIVE_NAME>")},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Upload the archive:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/upload?version=<VERSION>' " +
+							"--form 'file=@\"<ARCHIVE_NAME>.zip\"' " +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getComposerInstallClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Install a package"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("composer require <ARTIFACT_NAME>:<VERSION>"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func (c *composerPackageType) BuildRegistryIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildRegistryIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *composerPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *composerPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *composerPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{"/" + packageName}, nil
+}
+
+func (c *composerPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	paths, err := c.GetNodePathsForImage(nil, packageName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(paths))
+	for i, path := range paths {
+		result[i] = path + "/" + version
+	}
+	return result, nil
+}
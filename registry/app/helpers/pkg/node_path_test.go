@@ -818,3 +818,83 @@ func TestHuggingFacePackageType_GetNodePathsForArtifact_Updated(t *testing.T) {
 		})
 	}
 }
+
+func TestDebianPackageType_GetNodePathsForImage(t *testing.T) {
+	debianPackage := NewDebianPackageType(nil, nil)
+
+	paths, err := debianPackage.GetNodePathsForImage(nil, "libssl3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/libssl3"}, paths)
+}
+
+func TestDebianPackageType_GetNodePathsForArtifact(t *testing.T) {
+	debianPackage := NewDebianPackageType(nil, nil)
+
+	tests := []struct {
+		name          string
+		packageName   string
+		version       string
+		expectedPaths []string
+	}{
+		{
+			name:          "architecture specific package",
+			packageName:   "hello",
+			version:       "2.10-3_amd64",
+			expectedPaths: []string{"/hello/2.10-3_amd64"},
+		},
+		{
+			name:          "architecture independent package",
+			packageName:   "python3-six",
+			version:       "1.16.0-4_all",
+			expectedPaths: []string{"/python3-six/1.16.0-4_all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := debianPackage.GetNodePathsForArtifact(nil, tt.packageName, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+func TestAlpinePackageType_GetNodePathsForImage(t *testing.T) {
+	alpinePackage := NewAlpinePackageType(nil, nil)
+
+	paths, err := alpinePackage.GetNodePathsForImage(nil, "busybox")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/busybox"}, paths)
+}
+
+func TestAlpinePackageType_GetNodePathsForArtifact(t *testing.T) {
+	alpinePackage := NewAlpinePackageType(nil, nil)
+
+	tests := []struct {
+		name          string
+		packageName   string
+		version       string
+		expectedPaths []string
+	}{
+		{
+			name:          "architecture specific package",
+			packageName:   "busybox",
+			version:       "1.36.1-r29.x86_64",
+			expectedPaths: []string{"/busybox/1.36.1-r29.x86_64"},
+		},
+		{
+			name:          "architecture independent package",
+			packageName:   "ca-certificates-bundle",
+			version:       "20240705-r0.noarch",
+			expectedPaths: []string{"/ca-certificates-bundle/20240705-r0.noarch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := alpinePackage.GetNodePathsForArtifact(nil, tt.packageName, tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, paths)
+		})
+	}
+}
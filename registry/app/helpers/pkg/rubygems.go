@@ -0,0 +1,441 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+type RubyGemsPackageType interface {
+	interfaces.PackageHelper
+}
+
+type rubyGemsPackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+}
+
+func NewRubyGemsPackageType(
+	registryHelper interfaces.RegistryHelper,
+) RubyGemsPackageType {
+	return &rubyGemsPackageType{
+		packageType:     string(artifact.PackageTypeRUBYGEMS),
+		pathPackageType: string(types.PathPackageTypeRubyGems),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeUPSTREAM),
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCustom),
+			string(artifact.UpstreamConfigSourceRubyGems),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+			string(artifact.UpstreamConfigSourceRubyGems): {
+				urlRequired: false,
+			},
+		},
+	}
+}
+
+func (c *rubyGemsPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *rubyGemsPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *rubyGemsPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *rubyGemsPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *rubyGemsPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *rubyGemsPackageType) GetPullCommand(_ string, image string, version string) string {
+	downloadCommand := "gem install <ARTIFACT> --version <VERSION>"
+
+	// Platform specific gems are stored as {version}-{platform}.
+	if i := strings.Index(version, "-"); i > 0 {
+		downloadCommand += " --platform " + version[i+1:]
+		version = version[:i]
+	}
+	replacements := map[string]string{
+		"<ARTIFACT>": image,
+		"<VERSION>":  version,
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *rubyGemsPackageType) GetDownloadFileCommand(
+	regURL string,
+	fileName string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/gems/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<FILENAME>":           fileName,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+func (c *rubyGemsPackageType) DeleteVersion(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete rubygems artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *rubyGemsPackageType) ReportDeleteVersionEvent(ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeRUBYGEMS,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *rubyGemsPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for rubygems, indexes are built on request
+}
+
+func (c *rubyGemsPackageType) ReportBuildRegistryIndexEvent(_ context.Context, _ int64, _ []types.SourceRef) {
+	// no-op for rubygems, indexes are built on request
+}
+
+func (c *rubyGemsPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	filePathPrefix := "/" + artifactName
+	if versionName != "" {
+		filePathPrefix += "/" + versionName
+	}
+	return filePathPrefix
+}
+
+func (c *rubyGemsPackageType) DeleteArtifact(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete rubygems artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *rubyGemsPackageType) GetPackageURL(ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "rubygems")
+}
+
+func (c *rubyGemsPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *rubyGemsPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *rubyGemsPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := "/" + artifactName + "/" + version + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, filename, auth.IsAnonymousSession(session))
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *rubyGemsPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromRubyGemsArtifactDetailConfig(artifact.RubyGemsArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *rubyGemsPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	registryType artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+
+	var sections []artifact.ClientSetupSection
+	isAnonymous := auth.IsAnonymousSession(session)
+	sourceURL := registryURL
+	if !isAnonymous {
+		sections = append(sections, getRubyGemsAuthClientSetupSection(generateTokenType))
+		// gem and bundler read basic auth credentials from the source URL.
+		sourceURL = strings.Replace(registryURL, "://",
+			"://"+url.QueryEscape(username)+":<token from step 1>@", 1)
+	}
+	sections = append(sections, getRubyGemsSourceClientSetupSection(staticStepType, sourceURL))
+	if registryType != artifact.RegistryTypeUPSTREAM && !isAnonymous {
+		sections = append(sections, getRubyGemsPublishClientSetupSection(staticStepType))
+	}
+	sections = append(sections, getRubyGemsInstallClientSetupSection(staticStepType))
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "RubyGems Client Setup",
+		SecHeader:  "Follow these instructions to install/use RubyGems packages from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", "")
+
+	return &clientSetupDetails, nil
+}
+
+func getRubyGemsAuthClientSetupSection(
+	generateTokenType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Authentication"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Generate an identity token for authentication"),
+				Type:   &generateTokenType,
+			},
+		},
+	})
+	return section
+}
+
+func getRubyGemsSourceClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+	sourceURL string,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Source"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Add the registry as a gem source:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{Value: registryutils.StringPtr("gem sources --add '" + sourceURL + "/'")},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Or use it as the source of your Gemfile:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{Value: registryutils.StringPtr("source '" + sourceURL + "/'")},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getRubyGemsPublishClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Push a gem:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("GEM_HOST_API_KEY='<token from step 1>' gem push <GEM_FILE>" +
+							" --host '<REGISTRY_URL>'"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getRubyGemsInstallClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Install Package"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Install a gem"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("gem install <ARTIFACT_NAME>"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func (c *rubyGemsPackageType) BuildRegistryIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildRegistryIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *rubyGemsPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *rubyGemsPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *rubyGemsPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{"/" + packageName}, nil
+}
+
+func (c *rubyGemsPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	paths, err := c.GetNodePathsForImage(nil, packageName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(paths))
+	for i, path := range paths {
+		result[i] = path + "/" + version
+	}
+	return result, nil
+}
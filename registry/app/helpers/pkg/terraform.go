@@ -0,0 +1,466 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/interfaces"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/webhook"
+	"github.com/harness/gitness/registry/types"
+	registryutils "github.com/harness/gitness/registry/utils"
+)
+
+type TerraformPackageType interface {
+	interfaces.PackageHelper
+}
+
+type terraformPackageType struct {
+	packageType          string
+	registryHelper       interfaces.RegistryHelper
+	pathPackageType      string
+	validRepoTypes       []string
+	validUpstreamSources []string
+	upstreamSourceConfig map[string]UpstreamSourceConfig
+}
+
+func NewTerraformPackageType(
+	registryHelper interfaces.RegistryHelper,
+) TerraformPackageType {
+	return &terraformPackageType{
+		packageType:     string(artifact.PackageTypeTERRAFORM),
+		pathPackageType: string(types.PathPackageTypeTerraform),
+		registryHelper:  registryHelper,
+		validRepoTypes: []string{
+			string(artifact.RegistryTypeVIRTUAL),
+		},
+		validUpstreamSources: []string{
+			string(artifact.UpstreamConfigSourceCustom),
+		},
+		upstreamSourceConfig: map[string]UpstreamSourceConfig{
+			string(artifact.UpstreamConfigSourceCustom): {
+				urlRequired: true,
+			},
+		},
+	}
+}
+
+func (c *terraformPackageType) GetPackageType() string {
+	return c.packageType
+}
+
+func (c *terraformPackageType) GetPathPackageType() string {
+	return c.pathPackageType
+}
+
+func (c *terraformPackageType) IsValidRepoType(repoType string) bool {
+	return slices.Contains(c.validRepoTypes, repoType)
+}
+
+func (c *terraformPackageType) IsValidUpstreamSource(upstreamSource string) bool {
+	return slices.Contains(c.validUpstreamSources, upstreamSource)
+}
+
+func (c *terraformPackageType) IsURLRequiredForUpstreamSource(upstreamSource string) bool {
+	config, ok := c.upstreamSourceConfig[upstreamSource]
+	if !ok {
+		return true
+	}
+	return config.urlRequired
+}
+
+func (c *terraformPackageType) GetPullCommand(_ string, _ string, _ string) string {
+	return ""
+}
+
+func (c *terraformPackageType) GetDownloadFileCommand(
+	regURL string,
+	artifactName string,
+	version string,
+	fileName string,
+	isAnonymous bool,
+) string {
+	var authHeader string
+	if !isAnonymous {
+		authHeader = " --header '<AUTH_HEADER_PREFIX> <API_KEY>'"
+	}
+	downloadCommand := "curl --location '<HOSTNAME>/<KIND>/<ARTIFACT>/<VERSION>/<FILENAME>'" + authHeader +
+		" -J -o '<OUTPUT_FILE_NAME>'"
+
+	replacements := map[string]string{
+		"<HOSTNAME>":           regURL,
+		"<KIND>":               terraformFilesPath(artifactName),
+		"<ARTIFACT>":           artifactName,
+		"<VERSION>":            version,
+		"<FILENAME>":           fileName,
+		"<AUTH_HEADER_PREFIX>": c.registryHelper.GetAuthHeaderPrefix(),
+	}
+
+	for placeholder, value := range replacements {
+		downloadCommand = strings.ReplaceAll(downloadCommand, placeholder, value)
+	}
+
+	return downloadCommand
+}
+
+// terraformFilesPath returns the path files of an artifact are served from, modules are named
+// {namespace}/{name}/{system} and providers {namespace}/{type}.
+func terraformFilesPath(artifactName string) string {
+	if strings.Count(artifactName, "/") == 2 {
+		return "modules"
+	}
+	return "providers"
+}
+
+func (c *terraformPackageType) DeleteVersion(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	imageInfo *types.Image,
+	artifactName string,
+	versionName string,
+) error {
+	err := c.registryHelper.DeleteVersion(
+		ctx, regInfo, imageInfo, artifactName, versionName,
+		c.GetFilePath(artifactName, versionName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete terraform artifact version: %w", err)
+	}
+	return nil
+}
+
+func (c *terraformPackageType) ReportDeleteVersionEvent(ctx context.Context,
+	principalID int64,
+	registryID int64,
+	artifactName string,
+	version string,
+) {
+	payload := webhook.GetArtifactDeletedPayloadForCommonArtifacts(
+		principalID,
+		registryID,
+		artifact.PackageTypeTERRAFORM,
+		artifactName,
+		version,
+	)
+	c.registryHelper.ReportDeleteVersionEvent(ctx, &payload)
+}
+
+func (c *terraformPackageType) ReportBuildPackageIndexEvent(_ context.Context, _ int64, _ string) {
+	// no-op for terraform, versions and checksums are built on request
+}
+
+func (c *terraformPackageType) ReportBuildRegistryIndexEvent(_ context.Context, _ int64, _ []types.SourceRef) {
+	// no-op for terraform, versions and checksums are built on request
+}
+
+func (c *terraformPackageType) GetFilePath(
+	artifactName string,
+	versionName string,
+) string {
+	filePathPrefix := "/" + artifactName
+	if versionName != "" {
+		filePathPrefix += "/" + versionName
+	}
+	return filePathPrefix
+}
+
+func (c *terraformPackageType) DeleteArtifact(ctx context.Context,
+	regInfo *types.RegistryRequestBaseInfo,
+	artifactName string,
+) error {
+	filePath := c.GetFilePath(artifactName, "")
+	err := c.registryHelper.DeleteGenericImage(ctx, regInfo, artifactName, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to delete terraform artifact: %w", err)
+	}
+	return nil
+}
+
+func (c *terraformPackageType) GetPackageURL(ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+) string {
+	return c.registryHelper.GetPackageURL(ctx, rootIdentifier, registryIdentifier, "terraform")
+}
+
+func (c *terraformPackageType) GetArtifactMetadata(
+	artifact types.ArtifactMetadata,
+) *artifact.ArtifactMetadata {
+	pullCommand := c.GetPullCommand("", artifact.Name, artifact.Version)
+	return c.registryHelper.GetArtifactMetadata(artifact, pullCommand)
+}
+
+func (c *terraformPackageType) GetArtifactVersionMetadata(
+	image string,
+	tag types.NonOCIArtifactMetadata,
+) *artifact.ArtifactVersionMetadata {
+	pullCommand := c.GetPullCommand("", image, tag.Name)
+	return c.registryHelper.GetArtifactVersionMetadata(tag, pullCommand, c.packageType)
+}
+
+func (c *terraformPackageType) GetFileMetadata(
+	ctx context.Context,
+	rootIdentifier string,
+	registryIdentifier string,
+	artifactName string,
+	version string,
+	file types.FileNodeMetadata,
+) *artifact.FileDetail {
+	filePathPrefix := "/" + artifactName + "/" + version + "/"
+	filename := strings.Replace(file.Path, filePathPrefix, "", 1)
+	regURL := c.GetPackageURL(ctx, rootIdentifier, registryIdentifier)
+	session, _ := request.AuthSessionFrom(ctx)
+	downloadCommand := c.GetDownloadFileCommand(regURL, artifactName, version, filename,
+		auth.IsAnonymousSession(session))
+	return c.registryHelper.GetFileMetadata(file, filename, downloadCommand)
+}
+
+func (c *terraformPackageType) GetArtifactDetail(
+	img *types.Image,
+	art *types.Artifact,
+	downloadCount int64,
+) (*artifact.ArtifactDetail, error) {
+	var result map[string]any
+	err := json.Unmarshal(art.Metadata, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	artifactDetails := c.registryHelper.GetArtifactDetail(img, art, result, downloadCount)
+	if artifactDetails == nil {
+		return nil, fmt.Errorf("failed to get artifact details")
+	}
+	err = artifactDetails.FromTerraformArtifactDetailConfig(artifact.TerraformArtifactDetailConfig{
+		Metadata: &result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact details: %w", err)
+	}
+	return artifactDetails, nil
+}
+
+func (c *terraformPackageType) GetClientSetupDetails(
+	ctx context.Context,
+	regRef string,
+	image *artifact.ArtifactParam,
+	tag *artifact.VersionParam,
+	_ artifact.RegistryType,
+) (*artifact.ClientSetupDetails, error) {
+	staticStepType := artifact.ClientSetupStepTypeStatic
+	generateTokenType := artifact.ClientSetupStepTypeGenerateToken
+
+	registryURL := c.GetPackageURL(ctx, regRef, "")
+	hostname := registryURL
+	if u, err := url.Parse(registryURL); err == nil {
+		hostname = u.Host
+	}
+	session, _ := request.AuthSessionFrom(ctx)
+	username := session.Principal.Email
+
+	isAnonymous := auth.IsAnonymousSession(session)
+	sections := []artifact.ClientSetupSection{
+		getTerraformHostClientSetupSection(generateTokenType, staticStepType, isAnonymous),
+	}
+	if !isAnonymous {
+		sections = append(sections, getTerraformPublishClientSetupSection(staticStepType))
+	}
+	sections = append(sections, getTerraformInstallClientSetupSection(staticStepType))
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Terraform Client Setup",
+		SecHeader:  "Follow these instructions to install/use Terraform modules and providers from this registry.",
+		Sections:   sections,
+	}
+	c.registryHelper.ReplacePlaceholders(
+		ctx, &clientSetupDetails.Sections, username, regRef, image, tag, registryURL, "", "", hostname)
+
+	return &clientSetupDetails, nil
+}
+
+func getTerraformHostClientSetupSection(
+	generateTokenType artifact.ClientSetupStepType,
+	staticStepType artifact.ClientSetupStepType,
+	isAnonymous bool,
+) artifact.ClientSetupSection {
+	var steps []artifact.ClientSetupStep
+	var credentials string
+	if !isAnonymous {
+		steps = append(steps, artifact.ClientSetupStep{
+			Header: registryutils.StringPtr("Generate an identity token for authentication"),
+			Type:   &generateTokenType,
+		})
+		credentials = "\n\ncredentials \"<HOSTNAME>\" {\n  token = \"<token from step 1>\"\n}"
+	}
+	steps = append(steps, artifact.ClientSetupStep{
+		Header: registryutils.StringPtr("Point Terraform to this registry in your ~/.terraformrc:"),
+		Type:   &staticStepType,
+		Commands: &[]artifact.ClientSetupStepCommand{
+			{
+				Value: registryutils.StringPtr("host \"<HOSTNAME>\" {\n" +
+					"  services = {\n" +
+					"    \"modules.v1\"   = \"<REGISTRY_URL>/v1/modules/\",\n" +
+					"    \"providers.v1\" = \"<REGISTRY_URL>/v1/providers/\"\n" +
+					"  }\n" +
+					"}" + credentials),
+			},
+		},
+	})
+
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Configure Registry"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &steps,
+	})
+	return section
+}
+
+func getTerraformPublishClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Publish Module or Provider"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Upload a module archive (.zip, .tar.gz or .tgz):"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/modules/<NAMESPACE>/<NAME>/<SYSTEM>/<VERSION>' " +
+							"--form 'file=@\"<ARCHIVE_NAME>.tar.gz\"' " +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Upload the zip package of a provider for every platform:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("curl --location --request PUT " +
+							"'<REGISTRY_URL>/providers/<NAMESPACE>/<TYPE>/<VERSION>/<OS>/<ARCH>?protocols=5.0' " +
+							"--form 'file=@\"terraform-provider-<TYPE>_<VERSION>_<OS>_<ARCH>.zip\"' " +
+							"--header '<AUTH_HEADER_PREFIX> <API_KEY>'"),
+					},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func getTerraformInstallClientSetupSection(
+	staticStepType artifact.ClientSetupStepType,
+) artifact.ClientSetupSection {
+	section := artifact.ClientSetupSection{
+		Header: registryutils.StringPtr("Use Module or Provider"),
+	}
+	_ = section.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &[]artifact.ClientSetupStep{
+			{
+				Header: registryutils.StringPtr("Reference a module:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("module \"<NAME>\" {\n" +
+							"  source  = \"<HOSTNAME>/<NAMESPACE>/<NAME>/<SYSTEM>\"\n" +
+							"  version = \"<VERSION>\"\n" +
+							"}"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Require a provider:"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{
+						Value: registryutils.StringPtr("terraform {\n" +
+							"  required_providers {\n" +
+							"    <TYPE> = {\n" +
+							"      source  = \"<HOSTNAME>/<NAMESPACE>/<TYPE>\"\n" +
+							"      version = \"<VERSION>\"\n" +
+							"    }\n" +
+							"  }\n" +
+							"}"),
+					},
+				},
+			},
+			{
+				Header: registryutils.StringPtr("Install"),
+				Type:   &staticStepType,
+				Commands: &[]artifact.ClientSetupStepCommand{
+					{Value: registryutils.StringPtr("terraform init")},
+				},
+			},
+		},
+	})
+	return section
+}
+
+func (c *terraformPackageType) BuildRegistryIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildRegistryIndexTaskPayload,
+) error {
+	return nil
+}
+
+func (c *terraformPackageType) BuildPackageIndexAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageIndexTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *terraformPackageType) BuildPackageMetadataAsync(
+	_ context.Context,
+	_ *types.Registry,
+	_ types.BuildPackageMetadataTaskPayload,
+) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *terraformPackageType) GetNodePathsForImage(
+	_ *string,
+	packageName string,
+) ([]string, error) {
+	return []string{"/" + packageName}, nil
+}
+
+func (c *terraformPackageType) GetNodePathsForArtifact(
+	_ *string,
+	packageName string,
+	version string,
+) ([]string, error) {
+	paths, err := c.GetNodePathsForImage(nil, packageName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(paths))
+	for i, path := range paths {
+		result[i] = path + "/" + version
+	}
+	return result, nil
+}
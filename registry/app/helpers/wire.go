@@ -24,7 +24,9 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/utils/alpine"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/app/utils/debian"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 
@@ -35,6 +37,8 @@ func ProvidePackageWrapperProvider(
 	registryHelper interfaces.RegistryHelper,
 	regFinder refcache.RegistryFinder,
 	cargoRegistryHelper cargo.RegistryHelper,
+	debianRegistryHelper debian.RegistryHelper,
+	alpineRegistryHelper alpine.RegistryHelper,
 ) interfaces.PackageWrapper {
 	// create package factory
 	packageFactory := factory.NewPackageFactory()
@@ -49,6 +53,11 @@ func ProvidePackageWrapperProvider(
 	packageFactory.Register(pkg.NewNPMPackageType(registryHelper))
 	packageFactory.Register(pkg.NewGoPackageType(registryHelper))
 	packageFactory.Register(pkg.NewHuggingFacePackageType(registryHelper))
+	packageFactory.Register(pkg.NewDebianPackageType(registryHelper, debianRegistryHelper))
+	packageFactory.Register(pkg.NewAlpinePackageType(registryHelper, alpineRegistryHelper))
+	packageFactory.Register(pkg.NewRubyGemsPackageType(registryHelper))
+	packageFactory.Register(pkg.NewComposerPackageType(registryHelper))
+	packageFactory.Register(pkg.NewTerraformPackageType(registryHelper))
 
 	return NewPackageWrapper(packageFactory, regFinder)
 }
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*AlpineMetadata)(nil)
+
+type Metadata struct {
+	Package       string   `json:"package"`
+	Version       string   `json:"version"`
+	Architecture  string   `json:"architecture"`
+	Description   string   `json:"description,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	License       string   `json:"license,omitempty"`
+	Origin        string   `json:"origin,omitempty"`
+	Maintainer    string   `json:"maintainer,omitempty"`
+	Packager      string   `json:"packager,omitempty"`
+	Commit        string   `json:"commit,omitempty"`
+	BuildDate     int64    `json:"build_date,omitempty"`
+	InstalledSize int64    `json:"installed_size,omitempty"`
+	Dependencies  []string `json:"dependencies,omitempty"`
+	Provides      []string `json:"provides,omitempty"`
+	InstallIf     []string `json:"install_if,omitempty"`
+
+	// Checksum is the "Q1" prefixed SHA1 of the control segment, used as the C: field of the APKINDEX.
+	Checksum string `json:"checksum"`
+
+	Repositories []Repository `json:"repositories,omitempty"`
+}
+
+// Repository is a branch/repository pair a package has been published to.
+type Repository struct {
+	Branch     string `json:"branch"`
+	Repository string `json:"repository"`
+}
+
+// HasRepository reports whether the package is already published to the given branch/repository.
+func (m *Metadata) HasRepository(branch, repository string) bool {
+	for _, r := range m.Repositories {
+		if r.Branch == branch && r.Repository == repository {
+			return true
+		}
+	}
+	return false
+}
+
+// AlpineMetadata represents the metadata for an Alpine package.
+//
+//nolint:revive
+type AlpineMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *AlpineMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *AlpineMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *AlpineMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *AlpineMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*ComposerMetadata)(nil)
+
+// Metadata Source: https://getcomposer.org/doc/04-schema.md
+type Metadata struct {
+	Name              string            `json:"name"`
+	Version           string            `json:"version"`
+	VersionNormalized string            `json:"version_normalized"`
+	Type              string            `json:"type,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Homepage          string            `json:"homepage,omitempty"`
+	Keywords          []string          `json:"keywords,omitempty"`
+	License           []string          `json:"license,omitempty"`
+	Authors           []Author          `json:"authors,omitempty"`
+	Require           map[string]string `json:"require,omitempty"`
+	RequireDev        map[string]string `json:"require-dev,omitempty"`
+	// Shasum is the SHA1 of the dist archive, which composer verifies after download.
+	Shasum string `json:"shasum,omitempty"`
+	// Manifest is the composer.json of the package, served as is in the repository metadata.
+	Manifest map[string]any `json:"manifest,omitempty"`
+}
+
+type Author struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+// ComposerMetadata represents the metadata for a Composer package.
+//
+//nolint:revive
+type ComposerMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *ComposerMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *ComposerMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *ComposerMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *ComposerMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*DebianMetadata)(nil)
+
+type Metadata struct {
+	Package       string `json:"package"`
+	Version       string `json:"version"`
+	Architecture  string `json:"architecture"`
+	Maintainer    string `json:"maintainer,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Homepage      string `json:"homepage,omitempty"`
+	Section       string `json:"section,omitempty"`
+	Priority      string `json:"priority,omitempty"`
+	Source        string `json:"source,omitempty"`
+	InstalledSize string `json:"installed_size,omitempty"`
+	Depends       string `json:"depends,omitempty"`
+
+	// Control is the raw control paragraph of the package, used to render the Packages index.
+	Control string `json:"control"`
+
+	MD5    string `json:"md5,omitempty"`
+	SHA1   string `json:"sha1,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+	SHA512 string `json:"sha512,omitempty"`
+
+	Distributions []Distribution `json:"distributions,omitempty"`
+}
+
+// Distribution is a distribution/component pair a package has been published to.
+type Distribution struct {
+	Distribution string `json:"distribution"`
+	Component    string `json:"component"`
+}
+
+// HasDistribution reports whether the package is already published to the given distribution/component.
+func (m *Metadata) HasDistribution(distribution, component string) bool {
+	for _, d := range m.Distributions {
+		if d.Distribution == distribution && d.Component == component {
+			return true
+		}
+	}
+	return false
+}
+
+// DebianMetadata represents the metadata for a Debian package.
+//
+//nolint:revive
+type DebianMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *DebianMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *DebianMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *DebianMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *DebianMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*RubyGemsMetadata)(nil)
+
+// Metadata Source: https://guides.rubygems.org/specification-reference/
+type Metadata struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Platform    string   `json:"platform"`
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Authors     []string `json:"authors,omitempty"`
+	Email       []string `json:"email,omitempty"`
+	Homepage    string   `json:"homepage,omitempty"`
+	Licenses    []string `json:"licenses,omitempty"`
+	// ProjectURLs holds the links of the gemspec metadata, e.g. source_code_uri.
+	ProjectURLs map[string]string `json:"project_urls,omitempty"`
+
+	RequiredRubyVersion     []Requirement `json:"required_ruby_version,omitempty"`
+	RequiredRubygemsVersion []Requirement `json:"required_rubygems_version,omitempty"`
+	Dependencies            []Dependency  `json:"dependencies,omitempty"`
+}
+
+// Requirement is a single version constraint, e.g. ">= 1.0".
+type Requirement struct {
+	Operator string `json:"operator"`
+	Version  string `json:"version"`
+}
+
+// Dependency is a gem the package depends on.
+type Dependency struct {
+	Name string `json:"name"`
+	// Type is either runtime or development.
+	Type         string        `json:"type"`
+	Requirements []Requirement `json:"requirements"`
+}
+
+// RubyGemsMetadata represents the metadata for a RubyGems package.
+//
+//nolint:revive
+type RubyGemsMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *RubyGemsMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *RubyGemsMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *RubyGemsMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *RubyGemsMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "github.com/harness/gitness/registry/app/metadata"
+
+var _ metadata.Metadata = (*TerraformMetadata)(nil)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+)
+
+// Metadata Source: https://developer.hashicorp.com/terraform/internals/module-registry-protocol
+// and https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+type Metadata struct {
+	// Kind is either module or provider.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	// Name is the module name or the provider type.
+	Name string `json:"name"`
+	// System is the target system of a module, e.g. aws.
+	System string `json:"system,omitempty"`
+	// Protocols are the Terraform plugin protocol versions a provider supports.
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// TerraformMetadata represents the metadata for a Terraform module or provider.
+//
+//nolint:revive
+type TerraformMetadata struct {
+	Metadata
+	Files     []metadata.File `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Size      int64           `json:"size"`
+}
+
+func (p *TerraformMetadata) GetSize() int64 {
+	return p.Size
+}
+
+func (p *TerraformMetadata) UpdateSize(size int64) {
+	p.Size += size
+}
+
+func (p *TerraformMetadata) GetFiles() []metadata.File {
+	return p.Files
+}
+
+func (p *TerraformMetadata) SetFiles(files []metadata.File) {
+	p.Files = files
+	p.FileCount = int64(len(files))
+}
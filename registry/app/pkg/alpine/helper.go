@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/registry/app/events/asyncprocessing"
+	alpinemetadata "github.com/harness/gitness/registry/app/metadata/alpine"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/alpine"
+	"github.com/harness/gitness/registry/app/store"
+	alpineutil "github.com/harness/gitness/registry/app/utils/alpine"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage stores an .apk file and publishes it to info.Branch/info.Repository when set.
+	UploadPackage(
+		ctx context.Context,
+		info alpine.ArtifactInfo,
+		file io.Reader,
+		fileName string,
+	) (*commons.ResponseHeaders, string, error)
+}
+
+type registryHelper struct {
+	localBase              base.LocalBase
+	fileManager            filemanager.FileManager
+	artifactDao            store.ArtifactRepository
+	postProcessingReporter *asyncprocessing.Reporter
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	postProcessingReporter *asyncprocessing.Reporter,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:              localBase,
+		fileManager:            fileManager,
+		artifactDao:            artifactDao,
+		postProcessingReporter: postProcessingReporter,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info alpine.ArtifactInfo,
+	file io.Reader,
+	fileName string,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	fileInfo, tempFileName, err := c.fileManager.UploadTempFile(ctx, info.RootIdentifier, nil, fileName, file)
+	if err != nil {
+		return nil, "", err
+	}
+	r, _, err := c.fileManager.DownloadTempFile(ctx, fileInfo.Size, tempFileName, info.RootIdentifier)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	p, err := alpineutil.ParsePackage(r)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to parse alpine package: %s", fileName)
+		return nil, "", err
+	}
+
+	info.Image = p.Name
+	info.Version = alpineutil.ArtifactVersion(p.Version, p.Architecture)
+	info.Metadata = p.Metadata
+	if info.Branch != "" {
+		info.Metadata.Repositories = []alpinemetadata.Repository{
+			{Branch: info.Branch, Repository: info.Repository},
+		}
+	}
+
+	apkFileName := alpineutil.FileName(p.Name, p.Version)
+	path := fmt.Sprintf("%s/%s/%s", p.Name, info.Version, apkFileName)
+	fileInfo.Filename = apkFileName
+	rs, sha256, artifactID, existent, err := c.localBase.MoveTempFileAndCreateArtifact(ctx, info.ArtifactInfo,
+		tempFileName, info.Version, path,
+		&alpinemetadata.AlpineMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if existent {
+		// The same file may be published to several branches and repositories.
+		artifactID, err = c.addRepository(ctx, info)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if artifactID != 0 {
+		sources := make([]types.SourceRef, 0)
+		sources = append(sources, types.SourceRef{Type: types.SourceTypeArtifact, ID: artifactID})
+		c.postProcessingReporter.BuildRegistryIndex(ctx, info.RegistryID, sources)
+	}
+	return rs, sha256, nil
+}
+
+// addRepository records the branch/repository of an already stored package. It returns the ID of the
+// updated artifact, or 0 when the package was already published to the repository.
+func (c *registryHelper) addRepository(ctx context.Context, info alpine.ArtifactInfo) (int64, error) {
+	if info.Branch == "" {
+		return 0, nil
+	}
+	a, err := c.artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get artifact %s:%s: %w", info.Image, info.Version, err)
+	}
+	md := alpinemetadata.AlpineMetadata{}
+	if err = json.Unmarshal(a.Metadata, &md); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal metadata for artifact %s:%s: %w", info.Image, info.Version, err)
+	}
+	if md.HasRepository(info.Branch, info.Repository) {
+		return 0, nil
+	}
+	md.Repositories = append(md.Repositories, alpinemetadata.Repository{
+		Branch:     info.Branch,
+		Repository: info.Repository,
+	})
+	metadataJSON, err := json.Marshal(&md)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal metadata for artifact %s:%s: %w", info.Image, info.Version, err)
+	}
+	if err = c.artifactDao.UpdateArtifactMetadata(ctx, metadataJSON, a.ID); err != nil {
+		return 0, fmt.Errorf("failed to update metadata for artifact %s:%s: %w", info.Image, info.Version, err)
+	}
+	return a.ID, nil
+}
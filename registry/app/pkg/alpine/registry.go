@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/alpine"
+	"github.com/harness/gitness/registry/app/storage"
+	alpineutil "github.com/harness/gitness/registry/app/utils/alpine"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	UploadPackageFile(
+		ctx context.Context,
+		info alpine.ArtifactInfo,
+		file io.Reader,
+		fileName string,
+	) (*commons.ResponseHeaders, string, error)
+
+	DownloadPackageFile(ctx context.Context, info alpine.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetIndex returns the APKINDEX.tar.gz of info.Branch/info.Repository/info.Architecture.
+	GetIndex(ctx context.Context, info alpine.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
+
+// downloadPackageFile serves a package of the requested architecture, falling back to the architecture
+// independent build since noarch packages are listed in the index of every architecture.
+func downloadPackageFile(
+	ctx context.Context,
+	info alpine.ArtifactInfo,
+	localBase base.LocalBase,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, redirectURL, err := localBase.Download(ctx, info.ArtifactInfo, info.Version, info.FileName)
+	if err != nil && info.Architecture != alpineutil.ArchitectureNoarch {
+		version := strings.TrimSuffix(info.Version, "."+info.Architecture)
+		headers, fileReader, redirectURL, err = localBase.Download(ctx, info.ArtifactInfo,
+			alpineutil.ArtifactVersion(version, alpineutil.ArchitectureNoarch), info.FileName)
+	}
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+// getIndex serves the stored index of the requested architecture, falling back to the noarch index of
+// repositories that only hold architecture independent packages.
+func getIndex(
+	ctx context.Context,
+	info alpine.ArtifactInfo,
+	fileManager filemanager.FileManager,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	fileReader, _, redirectURL, err := fileManager.DownloadFile(
+		ctx, indexPath(info.Branch, info.Repository, info.Architecture),
+		info.RegistryID, info.RegIdentifier, info.RootIdentifier, true,
+	)
+	if err != nil && info.Architecture != alpineutil.ArchitectureNoarch {
+		fileReader, _, redirectURL, err = fileManager.DownloadFile(
+			ctx, indexPath(info.Branch, info.Repository, alpineutil.ArchitectureNoarch),
+			info.RegistryID, info.RegIdentifier, info.RootIdentifier, true,
+		)
+	}
+	if err != nil {
+		return responseHeaders, nil, nil, "", err
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+func indexPath(branch, repository, architecture string) string {
+	key := alpineutil.IndexKey{Branch: branch, Repository: repository, Architecture: architecture}
+	return alpineutil.IndexPrefix + "/" + key.Path() + "/" + alpineutil.IndexFile
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	composermetadata "github.com/harness/gitness/registry/app/metadata/composer"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/composer"
+	composerutil "github.com/harness/gitness/registry/app/utils/composer"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage stores a dist archive under the name and version of its composer.json.
+	UploadPackage(
+		ctx context.Context,
+		info composer.ArtifactInfo,
+		file io.Reader,
+		fileName string,
+	) (*commons.ResponseHeaders, string, error)
+}
+
+type registryHelper struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:   localBase,
+		fileManager: fileManager,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+	file io.Reader,
+	fileName string,
+) (headers *commons.ResponseHeaders, sha256 string, err error) {
+	fileInfo, tempFileName, err := c.fileManager.UploadTempFile(ctx, info.RootIdentifier, nil, fileName, file)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := c.parsePackage(ctx, info, fileInfo.Size, tempFileName)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to parse composer package: %s", fileName)
+		return nil, "", err
+	}
+
+	info.Image = p.Name
+	info.Version = p.Version
+	info.Metadata = p.Metadata
+	info.Metadata.Shasum = fileInfo.Sha1
+
+	distFileName := composerutil.FileName(p.Name, p.Version)
+	path := fmt.Sprintf("%s/%s/%s", p.Name, p.Version, distFileName)
+	fileInfo.Filename = distFileName
+	rs, sha256, _, _, err := c.localBase.MoveTempFileAndCreateArtifact(ctx, info.ArtifactInfo,
+		tempFileName, info.Version, path,
+		&composermetadata.ComposerMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return rs, sha256, nil
+}
+
+// parsePackage reads the composer.json of an uploaded archive. Zip archives need random access, so the
+// archive is spooled to a local temporary file.
+func (c *registryHelper) parsePackage(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+	size int64,
+	tempFileName string,
+) (*composerutil.Package, error) {
+	r, _, err := c.fileManager.DownloadTempFile(ctx, size, tempFileName, info.RootIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	f, err := os.CreateTemp("", "composer-*"+composerutil.PackageExtension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		f.Close()
+		if err := os.Remove(f.Name()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove temporary file %s", f.Name())
+		}
+	}()
+
+	n, err := io.Copy(f, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool package archive: %w", err)
+	}
+	return composerutil.ParsePackage(f, n, info.Version)
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	composermetadata "github.com/harness/gitness/registry/app/metadata/composer"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/composer"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	composerutil "github.com/harness/gitness/registry/app/utils/composer"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase      base.LocalBase
+	fileManager    filemanager.FileManager
+	proxyStore     store.UpstreamProxyConfigRepository
+	tx             dbtx.Transactor
+	registryDao    store.RegistryRepository
+	imageDao       store.ImageRepository
+	artifactDao    store.ArtifactRepository
+	urlProvider    urlprovider.Provider
+	registryHelper RegistryHelper
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	registryHelper RegistryHelper,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:      localBase,
+		fileManager:    fileManager,
+		proxyStore:     proxyStore,
+		tx:             tx,
+		registryDao:    registryDao,
+		imageDao:       imageDao,
+		artifactDao:    artifactDao,
+		urlProvider:    urlProvider,
+		registryHelper: registryHelper,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeCOMPOSER}
+}
+
+func (c *localRegistry) UploadPackageFile(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+	file io.Reader,
+	fileName string,
+) (*commons.ResponseHeaders, string, error) {
+	return c.registryHelper.UploadPackage(ctx, info, file, fileName)
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	return downloadPackageFile(ctx, info, c.localBase)
+}
+
+func (c *localRegistry) GetPackageMetadata(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+	dev bool,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	if len(*artifacts) == 0 {
+		return nil, nil, nil, "", usererror.NotFoundf("package %s not found in registry %s", info.Image,
+			info.RegIdentifier)
+	}
+
+	pkgURL := c.urlProvider.PackageURL(ctx, info.RootIdentifier+"/"+info.RegIdentifier, "composer")
+	versions := make([]composerutil.PackageVersion, 0, len(*artifacts))
+	for _, a := range *artifacts {
+		md := composermetadata.ComposerMetadata{}
+		if err = json.Unmarshal(a.Metadata, &md); err != nil {
+			return nil, nil, nil, "", fmt.Errorf("failed to unmarshal metadata for artifact %s:%s: %w", info.Image,
+				a.Version, err)
+		}
+		if len(md.GetFiles()) == 0 || composerutil.IsDevVersion(md.VersionNormalized) != dev {
+			continue
+		}
+		versions = append(versions, composerutil.PackageVersion{
+			Metadata: md.Metadata,
+			DistURL: fmt.Sprintf("%s/files/%s/%s/%s", pkgURL, info.Image, a.Version,
+				md.GetFiles()[0].Filename),
+			Time: a.CreatedAt,
+		})
+	}
+
+	content, err := composerutil.BuildPackageMetadata(info.Image, versions)
+	if err != nil {
+		return nil, nil, nil, "", fmt.Errorf("failed to build metadata of package %s: %w", info.Image, err)
+	}
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, io.NopCloser(bytes.NewReader(content)), "", nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/composer"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// UploadPackageFile stores a dist archive. info.Version overrides the version of the composer.json.
+	UploadPackageFile(
+		ctx context.Context,
+		info composer.ArtifactInfo,
+		file io.Reader,
+		fileName string,
+	) (*commons.ResponseHeaders, string, error)
+
+	DownloadPackageFile(ctx context.Context, info composer.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetPackageMetadata returns the p2 metadata file of info.Image, listing either the tagged or, when dev is
+	// set, the development versions.
+	GetPackageMetadata(ctx context.Context, info composer.ArtifactInfo, dev bool) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
+
+func downloadPackageFile(
+	ctx context.Context,
+	info composer.ArtifactInfo,
+	localBase base.LocalBase,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, redirectURL, err := localBase.Download(ctx, info.ArtifactInfo, info.Version, info.FileName)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
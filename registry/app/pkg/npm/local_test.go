@@ -373,6 +373,12 @@ func (m *mockArtifactDAO) GetArtifactsByRepoAndImageBatch(
 ) (*[]types.ArtifactMetadata, error) {
 	return &[]types.ArtifactMetadata{}, nil
 }
+func (m *mockArtifactDAO) GetArtifactsForCleanup(
+	context.Context,
+	int64, int, int64,
+) ([]*types.ArtifactCleanupInfo, error) {
+	return nil, nil
+}
 func (m *mockArtifactDAO) SearchLatestByName(
 	ctx context.Context,
 	regID int64, name string, limit int, offset int,
@@ -411,6 +417,9 @@ func (m *mockURLProvider) GenerateUIRepoURL(_ context.Context, _ string) string
 func (m *mockURLProvider) GenerateUIPRURL(_ context.Context, _ string, _ int64) string {
 	return ""
 }
+func (m *mockURLProvider) GenerateUIIssueURL(_ context.Context, _ string, _ int64) string {
+	return ""
+}
 func (m *mockURLProvider) GenerateUICompareURL(_ context.Context, _ string, _ string, _ string) string {
 	return ""
 }
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RegistryHelper interface {
+	// UploadPackage stores a .gem file. Pushing an existing version fails unless failOnConflict is false.
+	UploadPackage(
+		ctx context.Context,
+		info rubygems.ArtifactInfo,
+		file io.Reader,
+		failOnConflict bool,
+	) (*commons.ResponseHeaders, *rubygemsutil.Package, error)
+}
+
+type registryHelper struct {
+	localBase   base.LocalBase
+	fileManager filemanager.FileManager
+}
+
+func NewRegistryHelper(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+) RegistryHelper {
+	return &registryHelper{
+		localBase:   localBase,
+		fileManager: fileManager,
+	}
+}
+
+func (c *registryHelper) UploadPackage(
+	ctx context.Context,
+	info rubygems.ArtifactInfo,
+	file io.Reader,
+	failOnConflict bool,
+) (*commons.ResponseHeaders, *rubygemsutil.Package, error) {
+	fileInfo, tempFileName, err := c.fileManager.UploadTempFile(ctx, info.RootIdentifier, nil,
+		"package"+rubygemsutil.PackageExtension, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, _, err := c.fileManager.DownloadTempFile(ctx, fileInfo.Size, tempFileName, info.RootIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	p, err := rubygemsutil.ParsePackage(r)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("failed to parse gem for registry: %s", info.RegIdentifier)
+		return nil, nil, err
+	}
+
+	info.Image = p.Name
+	info.Version = rubygemsutil.ArtifactVersion(p.Version, p.Platform)
+	info.Metadata = p.Metadata
+
+	gemFileName := rubygemsutil.FileName(p.Name, p.Version, p.Platform)
+	path := fmt.Sprintf("%s/%s/%s", p.Name, info.Version, gemFileName)
+	fileInfo.Filename = gemFileName
+	rs, _, _, _, err := c.localBase.MoveTempFileAndCreateArtifact(ctx, info.ArtifactInfo,
+		tempFileName, info.Version, path,
+		&rubygemsmetadata.RubyGemsMetadata{
+			Metadata: info.Metadata,
+		}, fileInfo, failOnConflict)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rs, p, nil
+}
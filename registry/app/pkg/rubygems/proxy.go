@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/refcache"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	rubygemstype "github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+	cfg "github.com/harness/gitness/registry/config"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/rs/zerolog/log"
+
+	_ "github.com/harness/gitness/registry/app/remote/adapter/rubygems" // This is required to init rubygems adapter
+)
+
+var _ pkg.Artifact = (*proxy)(nil)
+var _ Registry = (*proxy)(nil)
+
+type proxy struct {
+	fileManager    filemanager.FileManager
+	proxyStore     store.UpstreamProxyConfigRepository
+	tx             dbtx.Transactor
+	registryDao    store.RegistryRepository
+	imageDao       store.ImageRepository
+	artifactDao    store.ArtifactRepository
+	urlProvider    urlprovider.Provider
+	localBase      base.LocalBase
+	registryHelper RegistryHelper
+	spaceFinder    refcache.SpaceFinder
+	service        secret.Service
+}
+
+type Proxy interface {
+	Registry
+}
+
+func NewProxy(
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	localBase base.LocalBase,
+	registryHelper RegistryHelper,
+	spaceFinder refcache.SpaceFinder,
+	service secret.Service,
+) Proxy {
+	return &proxy{
+		proxyStore:     proxyStore,
+		registryDao:    registryDao,
+		imageDao:       imageDao,
+		artifactDao:    artifactDao,
+		fileManager:    fileManager,
+		tx:             tx,
+		urlProvider:    urlProvider,
+		localBase:      localBase,
+		registryHelper: registryHelper,
+		spaceFinder:    spaceFinder,
+		service:        service,
+	}
+}
+
+func (r *proxy) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeUPSTREAM
+}
+
+func (r *proxy) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeRUBYGEMS}
+}
+
+func (r *proxy) DownloadPackageFile(
+	ctx context.Context,
+	info rubygemstype.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, readcloser, redirect, err := downloadPackageFile(ctx, info, r.localBase)
+	if err == nil {
+		return headers, fileReader, readcloser, redirect, err
+	}
+	log.Warn().Ctx(ctx).Msgf("failed to download from local, err: %v", err)
+
+	if info.FileName == "" {
+		log.Ctx(ctx).Error().Msgf("Package file name is empty for registry %s", info.RegIdentifier)
+		return nil, nil, nil, "", errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("package file name is empty"))
+	}
+
+	helper, err := r.getRemoteHelper(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	closer, err := helper.GetPackage(ctx, info.FileName)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	go func() {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		closer2, err2 := helper.GetPackage(ctx2, info.FileName)
+		if err2 != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err2).Msgf("error while putting file to localRegistry, %v", err2)
+			return
+		}
+		defer closer2.Close()
+		_, _, err := r.registryHelper.UploadPackage(ctx2, info, closer2, false)
+		if err != nil {
+			log.Ctx(ctx2).Error().Stack().Err(err).Msgf("error while putting file to localRegistry, %v", err)
+			return
+		}
+		log.Ctx(ctx2).Info().Msgf("Successfully updated file: %s, registry: %s", info.FileName, info.RegIdentifier)
+	}()
+
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, closer, "", nil
+}
+
+// GetIndexFile serves the upstream index file, as the upstream lists gems that are not cached yet.
+// The last fetched copy is cached and served when the upstream is unavailable.
+func (r *proxy) GetIndexFile(
+	ctx context.Context,
+	info rubygemstype.ArtifactInfo,
+	filePath string,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	helper, err := r.getRemoteHelper(ctx, info)
+	if err == nil {
+		var closer io.ReadCloser
+		closer, err = helper.GetMetadataFile(ctx, filePath)
+		if err == nil {
+			r.cacheIndexFile(ctx, helper, info, filePath)
+			return &commons.ResponseHeaders{
+				Headers: make(map[string]string),
+				Code:    http.StatusOK,
+			}, nil, closer, "", nil
+		}
+	}
+	log.Ctx(ctx).Warn().Err(err).Msgf("failed to fetch %s from upstream, serving cached copy", filePath)
+
+	responseHeaders := &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    0,
+	}
+	fileReader, _, redirectURL, err := r.fileManager.DownloadFile(ctx, indexCachePath(filePath),
+		info.RegistryID, info.RegIdentifier, info.RootIdentifier, true)
+	if err != nil {
+		return responseHeaders, nil, nil, "", err
+	}
+	responseHeaders.Code = http.StatusOK
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+func (r *proxy) cacheIndexFile(
+	ctx context.Context,
+	helper RemoteRegistryHelper,
+	info rubygemstype.ArtifactInfo,
+	filePath string,
+) {
+	session, _ := request.AuthSessionFrom(ctx)
+	go func() {
+		ctx2 := context.WithoutCancel(ctx)
+		ctx2 = context.WithValue(ctx2, cfg.GoRoutineKey, "goRoutine")
+		closer, err := helper.GetMetadataFile(ctx2, filePath)
+		if err != nil {
+			return
+		}
+		defer closer.Close()
+		_, err = r.fileManager.UploadFile(ctx2, indexCachePath(filePath), info.RegistryID, info.RootParentID,
+			info.RootIdentifier, nil, closer, path.Base(filePath), session.Principal.ID)
+		if err != nil {
+			log.Ctx(ctx2).Error().Err(err).Msgf("failed to cache index file %s, registry: %s", filePath,
+				info.RegIdentifier)
+		}
+	}()
+}
+
+func (r *proxy) getRemoteHelper(
+	ctx context.Context,
+	info rubygemstype.ArtifactInfo,
+) (RemoteRegistryHelper, error) {
+	upstream, err := r.proxyStore.Get(ctx, info.RegistryID)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteRegistryHelper(ctx, r.spaceFinder, *upstream, r.service)
+}
+
+// UploadPackageFile FIXME: Extract this upload function for all types of packageTypes
+// uploads the package file to the storage.
+func (r *proxy) UploadPackageFile(
+	ctx context.Context,
+	_ rubygemstype.ArtifactInfo,
+	_ io.Reader,
+) (*commons.ResponseHeaders, *rubygemsutil.Package, error) {
+	log.Error().Ctx(ctx).Msg("Not implemented")
+	return nil, nil, errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("not implemented"))
+}
+
+// indexCachePath is the path below which upstream index files are cached.
+func indexCachePath(filePath string) string {
+	return "/index/" + filePath
+}
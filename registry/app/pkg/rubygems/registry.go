@@ -0,0 +1,304 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/rubygems"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	rubygemsutil "github.com/harness/gitness/registry/app/utils/rubygems"
+)
+
+const artifactBatchLimit = 50
+
+type Registry interface {
+	pkg.Artifact
+
+	// UploadPackageFile stores a .gem file pushed by gem push and returns the parsed specification.
+	UploadPackageFile(
+		ctx context.Context,
+		info rubygems.ArtifactInfo,
+		file io.Reader,
+	) (*commons.ResponseHeaders, *rubygemsutil.Package, error)
+
+	DownloadPackageFile(ctx context.Context, info rubygems.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	// GetIndexFile returns a specs index, compact index or quick gemspec file. filePath is relative to the
+	// registry root, e.g. specs.4.8.gz or info/rack.
+	GetIndexFile(ctx context.Context, info rubygems.ArtifactInfo, filePath string) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
+
+// storedGem is a gem version of the registry together with the checksum of its file.
+type storedGem struct {
+	metadata  rubygemsmetadata.Metadata
+	sha256    string
+	createdAt time.Time
+}
+
+func downloadPackageFile(
+	ctx context.Context,
+	info rubygems.ArtifactInfo,
+	localBase base.LocalBase,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, redirectURL, err := localBase.Download(ctx, info.ArtifactInfo, info.Version, info.FileName)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+// buildIndexFile renders an index file from the gems stored in the registry. Indexes are small enough to be
+// built on request, which keeps them consistent with the stored artifacts.
+func buildIndexFile(
+	ctx context.Context,
+	artifactDao store.ArtifactRepository,
+	info rubygems.ArtifactInfo,
+	filePath string,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	var (
+		content []byte
+		err     error
+	)
+	switch {
+	case filePath == rubygemsutil.SpecsFile || filePath == rubygemsutil.LatestSpecsFile ||
+		filePath == rubygemsutil.PrereleaseSpecsFile:
+		content, err = buildSpecsIndex(ctx, artifactDao, info.RegistryID, filePath)
+	case filePath == rubygemsutil.VersionsFile:
+		content, err = buildVersions(ctx, artifactDao, info.RegistryID)
+	case filePath == rubygemsutil.NamesFile:
+		content, err = buildNames(ctx, artifactDao, info.RegistryID)
+	case strings.HasPrefix(filePath, rubygemsutil.InfoDir+"/"):
+		content, err = buildInfo(ctx, artifactDao, info)
+	case strings.HasPrefix(filePath, rubygemsutil.QuickSpecDir+"/"):
+		content, err = buildQuickSpec(ctx, artifactDao, info)
+	default:
+		err = usererror.NotFoundf("index file not found: %s", filePath)
+	}
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, io.NopCloser(bytes.NewReader(content)), "", nil
+}
+
+func buildSpecsIndex(
+	ctx context.Context,
+	artifactDao store.ArtifactRepository,
+	registryID int64,
+	fileName string,
+) ([]byte, error) {
+	gems, err := listGems(ctx, artifactDao, registryID)
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]rubygemsutil.Spec, 0, len(gems))
+	for _, g := range gems {
+		if rubygemsutil.IsPrerelease(g.metadata.Version) != (fileName == rubygemsutil.PrereleaseSpecsFile) {
+			continue
+		}
+		specs = append(specs, rubygemsutil.Spec{
+			Name:     g.metadata.Name,
+			Version:  g.metadata.Version,
+			Platform: g.metadata.Platform,
+		})
+	}
+	if fileName == rubygemsutil.LatestSpecsFile {
+		specs = rubygemsutil.LatestSpecs(specs)
+	} else {
+		rubygemsutil.SortSpecs(specs)
+	}
+	return rubygemsutil.BuildSpecsIndex(specs)
+}
+
+func buildVersions(ctx context.Context, artifactDao store.ArtifactRepository, registryID int64) ([]byte, error) {
+	gems, err := listGems(ctx, artifactDao, registryID)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string][]storedGem{}
+	var createdAt time.Time
+	for _, g := range gems {
+		byName[g.metadata.Name] = append(byName[g.metadata.Name], g)
+		if g.createdAt.After(createdAt) {
+			createdAt = g.createdAt
+		}
+	}
+
+	entries := make([]rubygemsutil.VersionsEntry, 0, len(byName))
+	for _, name := range sortedNames(byName) {
+		versions := byName[name]
+		sortGems(versions)
+		entry := rubygemsutil.VersionsEntry{Name: name, Info: renderInfo(versions)}
+		for _, g := range versions {
+			entry.Versions = append(entry.Versions, rubygemsutil.ArtifactVersion(g.metadata.Version,
+				g.metadata.Platform))
+		}
+		entries = append(entries, entry)
+	}
+	return rubygemsutil.BuildVersions(createdAt, entries), nil
+}
+
+func buildNames(ctx context.Context, artifactDao store.ArtifactRepository, registryID int64) ([]byte, error) {
+	gems, err := listGems(ctx, artifactDao, registryID)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string][]storedGem{}
+	for _, g := range gems {
+		byName[g.metadata.Name] = append(byName[g.metadata.Name], g)
+	}
+	return rubygemsutil.BuildNames(sortedNames(byName)), nil
+}
+
+func buildInfo(
+	ctx context.Context,
+	artifactDao store.ArtifactRepository,
+	info rubygems.ArtifactInfo,
+) ([]byte, error) {
+	artifacts, err := artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, err
+	}
+	gems := make([]storedGem, 0, len(*artifacts))
+	for _, a := range *artifacts {
+		g, ok, err := toStoredGem(a.Metadata, a.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata of gem %s:%s: %w", info.Image, a.Version, err)
+		}
+		if ok {
+			gems = append(gems, g)
+		}
+	}
+	if len(gems) == 0 {
+		return nil, usererror.NotFoundf("gem %s not found in registry %s", info.Image, info.RegIdentifier)
+	}
+	sortGems(gems)
+	return renderInfo(gems), nil
+}
+
+func buildQuickSpec(
+	ctx context.Context,
+	artifactDao store.ArtifactRepository,
+	info rubygems.ArtifactInfo,
+) ([]byte, error) {
+	a, err := artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		return nil, usererror.NotFoundf("gem %s-%s not found in registry %s", info.Image, info.Version,
+			info.RegIdentifier)
+	}
+	md := rubygemsmetadata.RubyGemsMetadata{}
+	if err = json.Unmarshal(a.Metadata, &md); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata of gem %s-%s: %w", info.Image, info.Version, err)
+	}
+	return rubygemsutil.BuildQuickSpec(md.Metadata)
+}
+
+func renderInfo(gems []storedGem) []byte {
+	lines := make([]string, 0, len(gems))
+	for _, g := range gems {
+		lines = append(lines, rubygemsutil.InfoLine(g.metadata, g.sha256))
+	}
+	return rubygemsutil.BuildInfo(lines)
+}
+
+// listGems returns all gem versions of the registry.
+func listGems(ctx context.Context, artifactDao store.ArtifactRepository, registryID int64) ([]storedGem, error) {
+	var gems []storedGem
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+		for _, a := range *artifacts {
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+			g, ok, err := toStoredGem(a.Metadata, a.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read metadata of gem %s:%s: %w", a.Name, a.Version, err)
+			}
+			if ok {
+				gems = append(gems, g)
+			}
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			return gems, nil
+		}
+	}
+}
+
+// toStoredGem decodes the metadata of an artifact. Artifacts without a stored file are skipped.
+func toStoredGem(raw json.RawMessage, createdAt time.Time) (storedGem, bool, error) {
+	md := rubygemsmetadata.RubyGemsMetadata{}
+	if err := json.Unmarshal(raw, &md); err != nil {
+		return storedGem{}, false, err
+	}
+	if len(md.GetFiles()) == 0 || md.Name == "" {
+		return storedGem{}, false, nil
+	}
+	return storedGem{
+		metadata:  md.Metadata,
+		sha256:    md.GetFiles()[0].Sha256,
+		createdAt: createdAt,
+	}, true, nil
+}
+
+func sortGems(gems []storedGem) {
+	sort.SliceStable(gems, func(i, j int) bool {
+		if c := rubygemsutil.CompareVersions(gems[i].metadata.Version, gems[j].metadata.Version); c != 0 {
+			return c < 0
+		}
+		return gems[i].metadata.Platform < gems[j].metadata.Platform
+	})
+}
+
+func sortedNames(byName map[string][]storedGem) []string {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,323 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	terraformmetadata "github.com/harness/gitness/registry/app/metadata/terraform"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/base"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/generic"
+	generictype "github.com/harness/gitness/registry/app/pkg/types/generic"
+	"github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/services/signingkey"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ pkg.Artifact = (*localRegistry)(nil)
+var _ Registry = (*localRegistry)(nil)
+
+type localRegistry struct {
+	localBase         base.LocalBase
+	fileManager       filemanager.FileManager
+	proxyStore        store.UpstreamProxyConfigRepository
+	tx                dbtx.Transactor
+	registryDao       store.RegistryRepository
+	imageDao          store.ImageRepository
+	artifactDao       store.ArtifactRepository
+	urlProvider       urlprovider.Provider
+	genericRegistry   generic.LocalRegistryHelper
+	signingKeyService *signingkey.Service
+}
+
+type LocalRegistry interface {
+	Registry
+}
+
+func NewLocalRegistry(
+	localBase base.LocalBase,
+	fileManager filemanager.FileManager,
+	proxyStore store.UpstreamProxyConfigRepository,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	urlProvider urlprovider.Provider,
+	genericRegistry generic.LocalRegistryHelper,
+	signingKeyService *signingkey.Service,
+) LocalRegistry {
+	return &localRegistry{
+		localBase:         localBase,
+		fileManager:       fileManager,
+		proxyStore:        proxyStore,
+		tx:                tx,
+		registryDao:       registryDao,
+		imageDao:          imageDao,
+		artifactDao:       artifactDao,
+		urlProvider:       urlProvider,
+		genericRegistry:   genericRegistry,
+		signingKeyService: signingKeyService,
+	}
+}
+
+func (c *localRegistry) GetArtifactType() artifact.RegistryType {
+	return artifact.RegistryTypeVIRTUAL
+}
+
+func (c *localRegistry) GetPackageTypes() []artifact.PackageType {
+	return []artifact.PackageType{artifact.PackageTypeTERRAFORM}
+}
+
+// UploadPackageFile stores the file the way generic files are stored, below {image}/{version}.
+func (c *localRegistry) UploadPackageFile(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+	file io.ReadCloser,
+) (*commons.ResponseHeaders, string, error) {
+	completePath := pkg.JoinWithSeparator("/", info.Image, info.Version, info.FileName)
+	headers, sha256, err := c.localBase.Upload(ctx, info.ArtifactInfo, info.FileName, info.Version, completePath,
+		file, &terraformmetadata.TerraformMetadata{Metadata: info.Metadata})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("Failed to upload file: %q, %q, %q", info.FileName, info.Version,
+			completePath)
+		return nil, "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	return headers, sha256, nil
+}
+
+func (c *localRegistry) DownloadPackageFile(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	headers, fileReader, redirectURL, err := c.genericRegistry.DownloadFile(ctx, generictype.ArtifactInfo{
+		ArtifactInfo: info.ArtifactInfo,
+		Version:      info.Version,
+		FileName:     info.FileName,
+		FilePath:     info.FileName,
+	})
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return headers, fileReader, nil, redirectURL, nil
+}
+
+func (c *localRegistry) GetModuleVersions(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+) (*terraformutil.ModuleVersions, error) {
+	versions, err := c.listVersions(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.version)
+	}
+	moduleVersions := terraformutil.BuildModuleVersions(names)
+	return &moduleVersions, nil
+}
+
+func (c *localRegistry) GetModuleDownloadURL(ctx context.Context, info terraform.ArtifactInfo) (string, error) {
+	md, err := c.getVersion(ctx, info)
+	if err != nil {
+		return "", err
+	}
+	if len(md.Files) == 0 {
+		return "", usererror.NotFoundf("module %s has no archive for version %s", info.Image, info.Version)
+	}
+	return c.fileURL(ctx, info, "modules", md.Files[0].Filename), nil
+}
+
+func (c *localRegistry) GetProviderVersions(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+) (*terraformutil.ProviderVersions, error) {
+	versions, err := c.listVersions(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	providerVersions := &terraformutil.ProviderVersions{
+		Versions: make([]terraformutil.ProviderVersion, 0, len(versions)),
+	}
+	for _, v := range versions {
+		providerVersion := terraformutil.ProviderVersion{
+			Version:   v.version,
+			Protocols: protocols(v.metadata),
+			Platforms: []terraformutil.Platform{},
+		}
+		for _, f := range v.metadata.Files {
+			if os, arch, ok := terraformutil.ParseProviderFileName(v.metadata.Name, v.version, f.Filename); ok {
+				providerVersion.Platforms = append(providerVersion.Platforms, terraformutil.Platform{OS: os, Arch: arch})
+			}
+		}
+		providerVersions.Versions = append(providerVersions.Versions, providerVersion)
+	}
+	terraformutil.SortProviderVersions(providerVersions.Versions)
+	return providerVersions, nil
+}
+
+func (c *localRegistry) GetProviderPackage(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+	os string,
+	arch string,
+) (*terraformutil.ProviderPackage, error) {
+	md, err := c.getVersion(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	fileName := terraformutil.ProviderFileName(md.Name, info.Version, os, arch)
+	var shasum string
+	for _, f := range md.Files {
+		if f.Filename == fileName {
+			shasum = f.Sha256
+		}
+	}
+	if shasum == "" {
+		return nil, usererror.NotFoundf("provider %s %s has no package for %s_%s", info.Image, info.Version, os,
+			arch)
+	}
+
+	entity, err := c.signingKeyService.GetOpenPGPEntity(ctx, info.RegistryID, info.RegIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+	publicKey, err := c.signingKeyService.GetOpenPGPPublicKey(ctx, info.RegistryID, info.RegIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	return &terraformutil.ProviderPackage{
+		Protocols:   protocols(*md),
+		OS:          os,
+		Arch:        arch,
+		Filename:    fileName,
+		DownloadURL: c.fileURL(ctx, info, "providers", fileName),
+		SHASumsURL:  c.fileURL(ctx, info, "providers", terraformutil.SHA256SumsFileName(md.Name, info.Version)),
+		SHASumsSignatureURL: c.fileURL(ctx, info, "providers",
+			terraformutil.SignatureFileName(md.Name, info.Version)),
+		SHASum: shasum,
+		SigningKeys: terraformutil.SigningKeys{
+			GPGPublicKeys: []terraformutil.GPGPublicKey{
+				{KeyID: terraformutil.KeyID(entity), ASCIIArmor: publicKey},
+			},
+		},
+	}, nil
+}
+
+// GetChecksumsFile builds the checksums of the packages of a provider version from the stored SHA256 of every
+// file, the signature is created with the OpenPGP key of the registry.
+func (c *localRegistry) GetChecksumsFile(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+	signature bool,
+) (*commons.ResponseHeaders, *storage.FileReader, io.ReadCloser, string, error) {
+	md, err := c.getVersion(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	checksums := make([]terraformutil.FileChecksum, 0, len(md.Files))
+	for _, f := range md.Files {
+		checksums = append(checksums, terraformutil.FileChecksum{FileName: f.Filename, Sha256: f.Sha256})
+	}
+	content := terraformutil.BuildSHA256Sums(checksums)
+
+	if signature {
+		entity, err2 := c.signingKeyService.GetOpenPGPEntity(ctx, info.RegistryID, info.RegIdentifier)
+		if err2 != nil {
+			return nil, nil, nil, "", fmt.Errorf("failed to get signing key: %w", err2)
+		}
+		content, err = terraformutil.SignSHA256Sums(entity, content)
+		if err != nil {
+			return nil, nil, nil, "", err
+		}
+	}
+	return &commons.ResponseHeaders{
+		Headers: make(map[string]string),
+		Code:    http.StatusOK,
+	}, nil, io.NopCloser(bytes.NewReader(content)), "", nil
+}
+
+type storedVersion struct {
+	version  string
+	metadata terraformmetadata.TerraformMetadata
+}
+
+func (c *localRegistry) listVersions(ctx context.Context, info terraform.ArtifactInfo) ([]storedVersion, error) {
+	artifacts, err := c.artifactDao.GetByRegistryIDAndImage(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, err
+	}
+	if len(*artifacts) == 0 {
+		return nil, usererror.NotFoundf("%s not found in registry %s", info.Image, info.RegIdentifier)
+	}
+
+	versions := make([]storedVersion, 0, len(*artifacts))
+	for _, a := range *artifacts {
+		md := terraformmetadata.TerraformMetadata{}
+		if err = json.Unmarshal(a.Metadata, &md); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata for artifact %s:%s: %w", info.Image, a.Version,
+				err)
+		}
+		versions = append(versions, storedVersion{version: a.Version, metadata: md})
+	}
+	return versions, nil
+}
+
+func (c *localRegistry) getVersion(
+	ctx context.Context,
+	info terraform.ArtifactInfo,
+) (*terraformmetadata.TerraformMetadata, error) {
+	a, err := c.artifactDao.GetByRegistryImageAndVersion(ctx, info.RegistryID, info.Image, info.Version)
+	if err != nil {
+		return nil, usererror.NotFoundf("version %s of %s not found in registry %s", info.Version, info.Image,
+			info.RegIdentifier)
+	}
+	md := &terraformmetadata.TerraformMetadata{}
+	if err = json.Unmarshal(a.Metadata, md); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata for artifact %s:%s: %w", info.Image, info.Version, err)
+	}
+	return md, nil
+}
+
+// fileURL is the download URL of a file of info.Version below the modules or providers path of the registry.
+func (c *localRegistry) fileURL(ctx context.Context, info terraform.ArtifactInfo, kind, fileName string) string {
+	return c.urlProvider.PackageURL(ctx, info.RootIdentifier+"/"+info.RegIdentifier, "terraform", kind,
+		info.Image, info.Version, fileName)
+}
+
+func protocols(md terraformmetadata.TerraformMetadata) []string {
+	if len(md.Protocols) == 0 {
+		return terraformutil.DefaultProtocols
+	}
+	return md.Protocols
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/types/terraform"
+	"github.com/harness/gitness/registry/app/storage"
+	terraformutil "github.com/harness/gitness/registry/app/utils/terraform"
+)
+
+type Registry interface {
+	pkg.Artifact
+
+	// UploadPackageFile stores a module archive or a provider package as info.FileName of info.Version.
+	UploadPackageFile(
+		ctx context.Context,
+		info terraform.ArtifactInfo,
+		file io.ReadCloser,
+	) (*commons.ResponseHeaders, string, error)
+
+	DownloadPackageFile(ctx context.Context, info terraform.ArtifactInfo) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+
+	GetModuleVersions(ctx context.Context, info terraform.ArtifactInfo) (*terraformutil.ModuleVersions, error)
+
+	// GetModuleDownloadURL returns the URL of the archive of a module version, which Terraform reads from the
+	// X-Terraform-Get header.
+	GetModuleDownloadURL(ctx context.Context, info terraform.ArtifactInfo) (string, error)
+
+	GetProviderVersions(ctx context.Context, info terraform.ArtifactInfo) (*terraformutil.ProviderVersions, error)
+
+	GetProviderPackage(
+		ctx context.Context,
+		info terraform.ArtifactInfo,
+		os string,
+		arch string,
+	) (*terraformutil.ProviderPackage, error)
+
+	// GetChecksumsFile returns the SHA256SUMS file of a provider version or, when signature is set,
+	// its detached signature.
+	GetChecksumsFile(ctx context.Context, info terraform.ArtifactInfo, signature bool) (
+		*commons.ResponseHeaders,
+		*storage.FileReader,
+		io.ReadCloser,
+		string,
+		error,
+	)
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signingkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+const (
+	openPGPKeyBits = 3072
+	rsaKeyBits     = 4096
+)
+
+// Service manages the keys registries use to sign their index files.
+// Keys are generated on first use; private keys never leave the database unencrypted.
+type Service struct {
+	keyStore  store.SigningKeyRepository
+	encrypter encrypt.Encrypter
+}
+
+func NewService(
+	keyStore store.SigningKeyRepository,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		keyStore:  keyStore,
+		encrypter: encrypter,
+	}
+}
+
+// GetOpenPGPEntity returns the OpenPGP key of the registry, generating it if the registry has none yet.
+func (s *Service) GetOpenPGPEntity(
+	ctx context.Context,
+	registryID int64,
+	registryName string,
+) (*openpgp.Entity, error) {
+	key, err := s.findOrCreateOpenPGPKey(ctx, registryID, registryName)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := s.encrypter.Decrypt(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key: %w", err)
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("signing key of registry %d is empty", registryID)
+	}
+	return entities[0], nil
+}
+
+// GetOpenPGPPublicKey returns the armored OpenPGP public key of the registry,
+// generating the key pair if the registry has none yet.
+func (s *Service) GetOpenPGPPublicKey(
+	ctx context.Context,
+	registryID int64,
+	registryName string,
+) (string, error) {
+	key, err := s.findOrCreateOpenPGPKey(ctx, registryID, registryName)
+	if err != nil {
+		return "", err
+	}
+	return key.PublicKey, nil
+}
+
+func (s *Service) findOrCreateOpenPGPKey(
+	ctx context.Context,
+	registryID int64,
+	registryName string,
+) (*types.SigningKey, error) {
+	return s.findOrCreateKey(ctx, registryID, types.SigningKeyTypeOpenPGP, func() (*types.SigningKey, error) {
+		return s.generateOpenPGPKey(registryID, registryName)
+	})
+}
+
+func (s *Service) findOrCreateKey(
+	ctx context.Context,
+	registryID int64,
+	keyType types.SigningKeyType,
+	generate func() (*types.SigningKey, error),
+) (*types.SigningKey, error) {
+	key, err := s.keyStore.Find(ctx, registryID, keyType)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find signing key: %w", err)
+	}
+
+	key, err = generate()
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.keyStore.Create(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store signing key: %w", err)
+	}
+	if created {
+		return key, nil
+	}
+
+	// another request generated the key concurrently, use the one that got stored.
+	key, err = s.keyStore.Find(ctx, registryID, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signing key: %w", err)
+	}
+	return key, nil
+}
+
+func (s *Service) generateOpenPGPKey(registryID int64, registryName string) (*types.SigningKey, error) {
+	config := &packet.Config{
+		Algorithm: packet.PubKeyAlgoRSA,
+		RSABits:   openPGPKeyBits,
+	}
+	entity, err := openpgp.NewEntity(registryName, "Registry signing key", "", config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	privateKey, err := armorEntity(openpgp.PrivateKeyType, func(w io.Writer) error {
+		return entity.SerializePrivate(w, config)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize private key: %w", err)
+	}
+	publicKey, err := armorEntity(openpgp.PublicKeyType, func(w io.Writer) error {
+		return entity.Serialize(w)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize public key: %w", err)
+	}
+
+	encrypted, err := s.encrypter.Encrypt(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	return &types.SigningKey{
+		RegistryID:  registryID,
+		KeyType:     types.SigningKeyTypeOpenPGP,
+		PublicKey:   publicKey,
+		PrivateKey:  encrypted,
+		Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+	}, nil
+}
+
+// GetRSAPrivateKey returns the RSA key of the registry, generating it if the registry has none yet.
+func (s *Service) GetRSAPrivateKey(ctx context.Context, registryID int64) (*rsa.PrivateKey, error) {
+	key, err := s.findOrCreateRSAKey(ctx, registryID)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := s.encrypter.Decrypt(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key: %w", err)
+	}
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, fmt.Errorf("signing key of registry %d is not PEM encoded", registryID)
+	}
+	rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	return rsaKey, nil
+}
+
+// GetRSAPublicKey returns the PEM encoded RSA public key of the registry,
+// generating the key pair if the registry has none yet.
+func (s *Service) GetRSAPublicKey(ctx context.Context, registryID int64) (string, error) {
+	key, err := s.findOrCreateRSAKey(ctx, registryID)
+	if err != nil {
+		return "", err
+	}
+	return key.PublicKey, nil
+}
+
+func (s *Service) findOrCreateRSAKey(ctx context.Context, registryID int64) (*types.SigningKey, error) {
+	return s.findOrCreateKey(ctx, registryID, types.SigningKeyTypeRSA, func() (*types.SigningKey, error) {
+		return s.generateRSAKey(registryID)
+	})
+}
+
+func (s *Service) generateRSAKey(registryID int64) (*types.SigningKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize public key: %w", err)
+	}
+
+	encrypted, err := s.encrypter.Encrypt(string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	return &types.SigningKey{
+		RegistryID:  registryID,
+		KeyType:     types.SigningKeyTypeRSA,
+		PublicKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})),
+		PrivateKey:  encrypted,
+		Fingerprint: fmt.Sprintf("%X", sha1.Sum(publicKeyDER)), //nolint:gosec
+	}, nil
+}
+
+func armorEntity(blockType string, serialize func(w io.Writer) error) (string, error) {
+	var raw bytes.Buffer
+	if err := serialize(&raw); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, blockType, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(raw.Bytes()); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
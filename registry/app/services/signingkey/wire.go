@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signingkey
+
+import (
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/registry/app/store"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides the registry signing key service.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+// ProvideService provides the registry signing key service.
+func ProvideService(
+	keyStore store.SigningKeyRepository,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return NewService(keyStore, encrypter)
+}
@@ -55,6 +55,8 @@ type BlobRepository interface {
 type CleanupPolicyRepository interface {
 	// GetIDsByRegistryID the CleanupPolicy Ids specified by Registry Key
 	GetIDsByRegistryID(ctx context.Context, id int64) (ids []int64, err error)
+	// ListRegistryIDs returns the ids of all registries that have at least one CleanupPolicy.
+	ListRegistryIDs(ctx context.Context) (ids []int64, err error)
 	// GetByRegistryID the CleanupPolicy specified by Registry Key
 	GetByRegistryID(
 		ctx context.Context,
@@ -584,6 +586,12 @@ type ArtifactRepository interface {
 		ctx context.Context, registryID int64, imageName string, batchSize int, artifactID int64,
 	) (*[]types.ArtifactMetadata, error)
 
+	// GetArtifactsForCleanup returns a batch of artifact versions of a registry
+	// together with their last download time, ordered by artifact id.
+	GetArtifactsForCleanup(
+		ctx context.Context, registryID int64, batchSize int, lastArtifactID int64,
+	) ([]*types.ArtifactCleanupInfo, error)
+
 	SearchLatestByName(
 		ctx context.Context, regID int64, name string, limit int, offset int,
 	) (*[]types.Artifact, error)
@@ -722,6 +730,13 @@ type GenericBlobRepository interface {
 	TotalSizeByRootParentID(ctx context.Context, id int64) (int64, error)
 }
 
+type SigningKeyRepository interface {
+	// Find returns the signing key of the given type for a registry.
+	Find(ctx context.Context, registryID int64, keyType types.SigningKeyType) (*types.SigningKey, error)
+	// Create stores a signing key. It returns false if the registry already has a key of that type.
+	Create(ctx context.Context, key *types.SigningKey) (bool, error)
+}
+
 type WebhooksRepository interface {
 	Create(ctx context.Context, webhook *gitnesstypes.WebhookCore) error
 	GetByRegistryAndIdentifier(
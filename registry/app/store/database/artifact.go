@@ -1177,6 +1177,61 @@ func (a ArtifactDao) GetArtifactsByRepoAndImageBatch(
 	return a.mapToArtifactMetadataList(dst)
 }
 
+type artifactCleanupInfoDB struct {
+	ID               int64  `db:"artifact_id"`
+	ImageName        string `db:"image_name"`
+	Version          string `db:"artifact_version"`
+	CreatedAt        int64  `db:"artifact_created_at"`
+	UpdatedAt        int64  `db:"artifact_updated_at"`
+	LastDownloadedAt int64  `db:"last_downloaded_at"`
+}
+
+// GetArtifactsForCleanup retrieves a batch of artifact versions of a registry with their last download time.
+// Initial lastArtifactID can be set to 0 to start from the beginning.
+func (a ArtifactDao) GetArtifactsForCleanup(
+	ctx context.Context, registryID int64, batchSize int, lastArtifactID int64,
+) ([]*types.ArtifactCleanupInfo, error) {
+	q := databaseg.Builder.Select(
+		`a.artifact_id, i.image_name, a.artifact_version, a.artifact_created_at, a.artifact_updated_at,
+        COALESCE(MAX(ds.download_stat_timestamp), 0) as last_downloaded_at`,
+	).
+		From("artifacts a").
+		Join("images i ON i.image_id = a.artifact_image_id").
+		LeftJoin("download_stats ds ON ds.download_stat_artifact_id = a.artifact_id").
+		Where("a.artifact_id > ? AND i.image_registry_id = ?", lastArtifactID, registryID).
+		GroupBy("a.artifact_id", "i.image_name", "a.artifact_version", "a.artifact_created_at",
+			"a.artifact_updated_at").
+		OrderBy("a.artifact_id ASC").
+		Limit(util.SafeIntToUInt64(batchSize))
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	var dst []*artifactCleanupInfoDB
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed executing GetArtifactsForCleanup query")
+	}
+
+	result := make([]*types.ArtifactCleanupInfo, len(dst))
+	for i, d := range dst {
+		result[i] = &types.ArtifactCleanupInfo{
+			ID:        d.ID,
+			ImageName: d.ImageName,
+			Version:   d.Version,
+			CreatedAt: time.UnixMilli(d.CreatedAt),
+			UpdatedAt: time.UnixMilli(d.UpdatedAt),
+		}
+		if d.LastDownloadedAt > 0 {
+			result[i].LastDownloadedAt = time.UnixMilli(d.LastDownloadedAt)
+		}
+	}
+	return result, nil
+}
+
 func (a ArtifactDao) mapToArtifactMetadata(
 	dst *artifactMetadataDB,
 ) (*types.ArtifactMetadata, error) {
@@ -38,14 +38,16 @@ type CleanupPolicyDao struct {
 }
 
 type CleanupPolicyDB struct {
-	ID             int64  `db:"cp_id"`
-	RegistryID     int64  `db:"cp_registry_id"`
-	Name           string `db:"cp_name"`
-	ExpiryTimeInMs int64  `db:"cp_expiry_time_ms"`
-	CreatedAt      int64  `db:"cp_created_at"`
-	UpdatedAt      int64  `db:"cp_updated_at"`
-	CreatedBy      int64  `db:"cp_created_by"`
-	UpdatedBy      int64  `db:"cp_updated_by"`
+	ID                 int64  `db:"cp_id"`
+	RegistryID         int64  `db:"cp_registry_id"`
+	Name               string `db:"cp_name"`
+	ExpiryTimeInMs     int64  `db:"cp_expiry_time_ms"`
+	KeepLast           int    `db:"cp_keep_last"`
+	DownloadedWithinMs int64  `db:"cp_downloaded_within_ms"`
+	CreatedAt          int64  `db:"cp_created_at"`
+	UpdatedAt          int64  `db:"cp_updated_at"`
+	CreatedBy          int64  `db:"cp_created_by"`
+	UpdatedBy          int64  `db:"cp_updated_by"`
 }
 
 type CleanupPolicyPrefixMappingDB struct {
@@ -88,6 +90,23 @@ func (c CleanupPolicyDao) GetIDsByRegistryID(ctx context.Context, id int64) (ids
 	return res, nil
 }
 
+// ListRegistryIDs returns the ids of all registries that have at least one cleanup policy.
+func (c CleanupPolicyDao) ListRegistryIDs(ctx context.Context) ([]int64, error) {
+	stmt := databaseg.Builder.Select("DISTINCT cp_registry_id").From("cleanup_policies").
+		OrderBy("cp_registry_id")
+	db := dbtx.GetAccessor(ctx, c.db)
+	var res []int64
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to list registries with cleanup policies")
+	}
+
+	return res, nil
+}
+
 func (c CleanupPolicyDao) GetByRegistryID(
 	ctx context.Context,
 	id int64,
@@ -97,17 +116,19 @@ func (c CleanupPolicyDao) GetByRegistryID(
 		"cp_registry_id",
 		"cp_name",
 		"cp_expiry_time_ms",
+		"cp_keep_last",
+		"cp_downloaded_within_ms",
 		"cp_created_at",
 		"cp_updated_at",
 		"cp_created_by",
 		"cp_updated_by",
-		"cpp_id",
-		"cpp_cleanup_policy_id",
-		"cpp_prefix",
-		"cpp_prefix_type",
+		"COALESCE(cpp_id, 0) AS cpp_id",
+		"COALESCE(cpp_cleanup_policy_id, 0) AS cpp_cleanup_policy_id",
+		"COALESCE(cpp_prefix, '') AS cpp_prefix",
+		"COALESCE(cpp_prefix_type, '') AS cpp_prefix_type",
 	).
 		From("cleanup_policies").
-		Join("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
+		LeftJoin("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
 		Where("cp_registry_id = ?", id)
 
 	db := dbtx.GetAccessor(ctx, c.db)
@@ -142,6 +163,8 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			cp_registry_id
 			,cp_name
 			,cp_expiry_time_ms
+			,cp_keep_last
+			,cp_downloaded_within_ms
 			,cp_created_at
 			,cp_updated_at
 			,cp_created_by
@@ -150,6 +173,8 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			:cp_registry_id
 			,:cp_name
 			,:cp_expiry_time_ms
+			,:cp_keep_last
+			,:cp_downloaded_within_ms
 			,:cp_created_at
 			,:cp_updated_at
 			,:cp_created_by
@@ -340,14 +365,16 @@ func (c CleanupPolicyDao) mapToInternalCleanupPolicy(
 	cp.UpdatedBy = session.Principal.ID
 
 	return &CleanupPolicyDB{
-		ID:             cp.ID,
-		RegistryID:     cp.RegistryID,
-		Name:           cp.Name,
-		ExpiryTimeInMs: cp.ExpiryTime,
-		CreatedAt:      cp.CreatedAt.UnixMilli(),
-		UpdatedAt:      cp.UpdatedAt.UnixMilli(),
-		CreatedBy:      cp.CreatedBy,
-		UpdatedBy:      cp.UpdatedBy,
+		ID:                 cp.ID,
+		RegistryID:         cp.RegistryID,
+		Name:               cp.Name,
+		ExpiryTimeInMs:     cp.ExpiryTime,
+		KeepLast:           cp.KeepLast,
+		DownloadedWithinMs: cp.DownloadedWithin,
+		CreatedAt:          cp.CreatedAt.UnixMilli(),
+		UpdatedAt:          cp.UpdatedAt.UnixMilli(),
+		CreatedBy:          cp.CreatedBy,
+		UpdatedBy:          cp.UpdatedBy,
 	}
 }
 
@@ -366,14 +393,18 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 
 		if _, exists := cleanupPolicies[cp.ID]; !exists {
 			cleanupPolicies[cp.ID] = &types.CleanupPolicy{
-				ID:            cp.ID,
-				RegistryID:    cp.RegistryID,
-				Name:          cp.Name,
-				ExpiryTime:    cp.ExpiryTimeInMs,
-				CreatedAt:     time.UnixMilli(cp.CreatedAt),
-				UpdatedAt:     time.UnixMilli(cp.UpdatedAt),
-				PackagePrefix: make([]string, 0),
-				VersionPrefix: make([]string, 0),
+				ID:               cp.ID,
+				RegistryID:       cp.RegistryID,
+				Name:             cp.Name,
+				ExpiryTime:       cp.ExpiryTimeInMs,
+				KeepLast:         cp.KeepLast,
+				DownloadedWithin: cp.DownloadedWithinMs,
+				CreatedAt:        time.UnixMilli(cp.CreatedAt),
+				UpdatedAt:        time.UnixMilli(cp.UpdatedAt),
+				CreatedBy:        cp.CreatedBy,
+				UpdatedBy:        cp.UpdatedBy,
+				PackagePrefix:    make([]string, 0),
+				VersionPrefix:    make([]string, 0),
 			}
 		}
 
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/store/database/util"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type SigningKeyDao struct {
+	db *sqlx.DB
+}
+
+func NewSigningKeyDao(db *sqlx.DB) store.SigningKeyRepository {
+	return &SigningKeyDao{
+		db: db,
+	}
+}
+
+type signingKeyDB struct {
+	RegistryID  int64  `db:"rsk_registry_id"`
+	KeyType     string `db:"rsk_key_type"`
+	PublicKey   string `db:"rsk_public_key"`
+	PrivateKey  []byte `db:"rsk_private_key"`
+	Fingerprint string `db:"rsk_fingerprint"`
+	CreatedAt   int64  `db:"rsk_created_at"`
+}
+
+func (s SigningKeyDao) Find(
+	ctx context.Context,
+	registryID int64,
+	keyType types.SigningKeyType,
+) (*types.SigningKey, error) {
+	q := databaseg.Builder.
+		Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(signingKeyDB{}), ",")).
+		From("registry_signing_keys").
+		Where("rsk_registry_id = ? AND rsk_key_type = ?", registryID, string(keyType))
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(signingKeyDB)
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to find %s signing key for registry %d",
+			keyType, registryID)
+	}
+
+	return s.mapToSigningKey(dst), nil
+}
+
+func (s SigningKeyDao) Create(ctx context.Context, key *types.SigningKey) (bool, error) {
+	const sqlQuery = `
+		INSERT INTO registry_signing_keys (
+			rsk_registry_id,
+			rsk_key_type,
+			rsk_public_key,
+			rsk_private_key,
+			rsk_fingerprint,
+			rsk_created_at
+		) VALUES (
+			:rsk_registry_id,
+			:rsk_key_type,
+			:rsk_public_key,
+			:rsk_private_key,
+			:rsk_fingerprint,
+			:rsk_created_at
+		) ON CONFLICT (rsk_registry_id, rsk_key_type) DO NOTHING`
+
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+	query, args, err := db.BindNamed(sqlQuery, s.mapToInternalSigningKey(key))
+	if err != nil {
+		return false, databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind signing key object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, databaseg.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted rows")
+	}
+	return count == 1, nil
+}
+
+func (s SigningKeyDao) mapToSigningKey(dst *signingKeyDB) *types.SigningKey {
+	return &types.SigningKey{
+		RegistryID:  dst.RegistryID,
+		KeyType:     types.SigningKeyType(dst.KeyType),
+		PublicKey:   dst.PublicKey,
+		PrivateKey:  dst.PrivateKey,
+		Fingerprint: dst.Fingerprint,
+		CreatedAt:   time.UnixMilli(dst.CreatedAt),
+	}
+}
+
+func (s SigningKeyDao) mapToInternalSigningKey(key *types.SigningKey) *signingKeyDB {
+	return &signingKeyDB{
+		RegistryID:  key.RegistryID,
+		KeyType:     string(key.KeyType),
+		PublicKey:   key.PublicKey,
+		PrivateKey:  key.PrivateKey,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   key.CreatedAt.UnixMilli(),
+	}
+}
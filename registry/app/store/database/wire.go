@@ -107,6 +107,10 @@ func ProvideGenericBlobDao(db *sqlx.DB) store.GenericBlobRepository {
 	return NewGenericBlobDao(db)
 }
 
+func ProvideSigningKeyDao(db *sqlx.DB) store.SigningKeyRepository {
+	return NewSigningKeyDao(db)
+}
+
 func ProvideRegistryDao(
 	db *sqlx.DB, mtRepository store.MediaTypesRepository,
 ) store.RegistryRepository {
@@ -141,6 +145,7 @@ var WireSet = wire.NewSet(
 	ProvideBandwidthStatDao,
 	ProvideNodeDao,
 	ProvideGenericBlobDao,
+	ProvideSigningKeyDao,
 	ProvideWebhookDao,
 	ProvideWebhookExecutionDao,
 	ProvidePackageTagDao,
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	alpinemetadata "github.com/harness/gitness/registry/app/metadata/alpine"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPkgInfo = `# Generated by abuild 3.13.0
+# using fakeroot version 1.32.1
+pkgname = hello
+pkgver = 2.12-r1
+pkgdesc = example package
+url = https://example.com/hello
+builddate = 1700000000
+packager = Jane Doe <jane@example.com>
+size = 28672
+arch = x86_64
+origin = hello
+commit = 0123456789abcdef
+maintainer = Jane Doe <jane@example.com>
+license = GPL-3.0-or-later
+depend = so:libc.musl-x86_64.so.1
+depend = busybox
+provides = cmd:hello=2.12-r1
+`
+
+func gzipTar(t *testing.T, name string, content []byte, endArchive bool) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	if endArchive {
+		require.NoError(t, tw.Close())
+	} else {
+		require.NoError(t, tw.Flush())
+	}
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// buildApk returns an .apk file and the expected checksum of its control segment.
+func buildApk(t *testing.T, pkgInfo string, signed bool) ([]byte, string) {
+	t.Helper()
+
+	control := gzipTar(t, pkgInfoFile, []byte(pkgInfo), false)
+	var apk bytes.Buffer
+	if signed {
+		apk.Write(gzipTar(t, ".SIGN.RSA.builder.rsa.pub", []byte("signature"), false))
+	}
+	apk.Write(control)
+	apk.Write(gzipTar(t, "usr/bin/hello", []byte("binary"), true))
+
+	sum := sha1.Sum(control) //nolint:gosec
+	return apk.Bytes(), "Q1" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestParsePackage(t *testing.T) {
+	for _, signed := range []bool{true, false} {
+		apk, checksum := buildApk(t, testPkgInfo, signed)
+		p, err := ParsePackage(bytes.NewReader(apk))
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello", p.Name)
+		assert.Equal(t, "2.12-r1", p.Version)
+		assert.Equal(t, "x86_64", p.Architecture)
+		assert.Equal(t, checksum, p.Metadata.Checksum)
+		assert.Equal(t, "example package", p.Metadata.Description)
+		assert.Equal(t, int64(1700000000), p.Metadata.BuildDate)
+		assert.Equal(t, int64(28672), p.Metadata.InstalledSize)
+		assert.Equal(t, []string{"so:libc.musl-x86_64.so.1", "busybox"}, p.Metadata.Dependencies)
+		assert.Equal(t, []string{"cmd:hello=2.12-r1"}, p.Metadata.Provides)
+	}
+}
+
+func TestParsePackageErrors(t *testing.T) {
+	invalidName, _ := buildApk(t, "pkgname = -hello\npkgver = 1.0-r0\narch = x86_64\n", false)
+	invalidVersion, _ := buildApk(t, "pkgname = hello\npkgver = 1.0\narch = x86_64\n", false)
+	missingArch, _ := buildApk(t, "pkgname = hello\npkgver = 1.0-r0\n", false)
+
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr error
+	}{
+		{
+			name:    "not an archive",
+			content: []byte("hello world"),
+			wantErr: ErrInvalidArchive,
+		},
+		{
+			name:    "missing .PKGINFO",
+			content: gzipTar(t, "usr/bin/hello", []byte("binary"), true),
+			wantErr: ErrMissingPkgInfo,
+		},
+		{
+			name:    "invalid name",
+			content: invalidName,
+			wantErr: ErrInvalidPackageName,
+		},
+		{
+			name:    "invalid version",
+			content: invalidVersion,
+			wantErr: ErrInvalidVersion,
+		},
+		{
+			name:    "missing architecture",
+			content: missingArch,
+			wantErr: ErrInvalidArchitecture,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePackage(bytes.NewReader(tt.content))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName    string
+		wantName    string
+		wantVersion string
+		wantErr     bool
+	}{
+		{fileName: "hello-2.12-r1.apk", wantName: "hello", wantVersion: "2.12-r1"},
+		{fileName: "py3-hello-world-1.0_rc1-r0.apk", wantName: "py3-hello-world", wantVersion: "1.0_rc1-r0"},
+		{fileName: "hello-2.12.apk", wantErr: true},
+		{fileName: "hello-2.12-r1.deb", wantErr: true},
+		{fileName: "-2.12-r1.apk", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			name, version, err := ParseFileName(tt.fileName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantVersion, version)
+			assert.Equal(t, tt.fileName, FileName(name, version))
+		})
+	}
+}
+
+func TestPackageEntry(t *testing.T) {
+	p, err := ParsePkgInfo(bytes.NewReader([]byte(testPkgInfo)))
+	require.NoError(t, err)
+	p.Metadata.Checksum = "Q1abc="
+
+	expected := "C:Q1abc=\nP:hello\nV:2.12-r1\nA:x86_64\nS:1024\nI:28672\nT:example package\n" +
+		"U:https://example.com/hello\nL:GPL-3.0-or-later\no:hello\nm:Jane Doe <jane@example.com>\n" +
+		"t:1700000000\nc:0123456789abcdef\nD:so:libc.musl-x86_64.so.1 busybox\np:cmd:hello=2.12-r1\n"
+	assert.Equal(t, expected, PackageEntry(p.Metadata, 1024))
+}
+
+func TestSignIndex(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	entry := PackageEntry(alpinemetadata.Metadata{
+		Package: "hello", Version: "2.12-r1", Architecture: "x86_64", Checksum: "Q1abc=",
+	}, 1024)
+	index, err := BuildIndex("test v3.20/main", []string{entry}, time.Now())
+	require.NoError(t, err)
+	signed, err := SignIndex(index, key, KeyName("test"), time.Now())
+	require.NoError(t, err)
+
+	// The signature segment must end exactly where the unsigned index starts.
+	br := bufio.NewReader(bytes.NewReader(signed))
+	zr, err := gzip.NewReader(br)
+	require.NoError(t, err)
+	zr.Multistream(false)
+	tr := tar.NewReader(zr)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ".SIGN.RSA.test.rsa.pub", hdr.Name)
+	signature, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, zr)
+	require.NoError(t, err)
+
+	rest, err := io.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, index, rest)
+	digest := sha1.Sum(rest) //nolint:gosec
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], signature))
+
+	// apk reads both segments as one tar archive.
+	zr, err = gzip.NewReader(bytes.NewReader(signed))
+	require.NoError(t, err)
+	tr = tar.NewReader(zr)
+	files := map[string]string{}
+	for {
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+	assert.Contains(t, files, ".SIGN.RSA.test.rsa.pub")
+	assert.Equal(t, "test v3.20/main", files["DESCRIPTION"])
+	assert.Equal(t, entry+"\n", files["APKINDEX"])
+}
+
+func TestMergeNoarch(t *testing.T) {
+	x86 := IndexKey{Branch: "v3.20", Repository: "main", Architecture: "x86_64"}
+	arm := IndexKey{Branch: "v3.20", Repository: "main", Architecture: "aarch64"}
+	indexes := map[IndexKey][]string{
+		x86: {"a"},
+		arm: nil,
+	}
+	noarch := map[IndexKey][]string{
+		{Branch: "v3.20", Repository: "main"}:      {"n"},
+		{Branch: "v3.20", Repository: "community"}: {"c"},
+	}
+
+	mergeNoarch(indexes, noarch)
+
+	assert.Equal(t, []string{"a", "n"}, indexes[x86])
+	assert.Equal(t, []string{"n"}, indexes[arm])
+	assert.Equal(t, []string{"c"}, indexes[IndexKey{Branch: "v3.20", Repository: "community", Architecture: "noarch"}])
+	assert.Len(t, indexes, 3)
+}
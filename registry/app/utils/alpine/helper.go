@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	alpinemetadata "github.com/harness/gitness/registry/app/metadata/alpine"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/services/signingkey"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+)
+
+const artifactBatchLimit = 50
+
+type RegistryHelper interface {
+	// BuildRegistryIndex regenerates and signs the APKINDEX of every branch/repository/architecture of the registry.
+	BuildRegistryIndex(ctx context.Context, registry types.Registry, principalID int64) error
+}
+
+type registryHelper struct {
+	fileManager       filemanager.FileManager
+	artifactDao       store.ArtifactRepository
+	nodesDao          store.NodesRepository
+	spaceFinder       refcache.SpaceFinder
+	signingKeyService *signingkey.Service
+}
+
+func NewRegistryHelper(
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	nodesDao store.NodesRepository,
+	spaceFinder refcache.SpaceFinder,
+	signingKeyService *signingkey.Service,
+) RegistryHelper {
+	return &registryHelper{
+		fileManager:       fileManager,
+		artifactDao:       artifactDao,
+		nodesDao:          nodesDao,
+		spaceFinder:       spaceFinder,
+		signingKeyService: signingKeyService,
+	}
+}
+
+func (h *registryHelper) BuildRegistryIndex(ctx context.Context, registry types.Registry, principalID int64) error {
+	rootSpace, err := h.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space by ID: %w", err)
+	}
+
+	indexes, noarch, err := h.collectIndexEntries(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	// Indexes that no longer have packages are still published empty so apk drops the removed packages.
+	if err = h.addExistingIndexes(ctx, registry.ID, indexes); err != nil {
+		return err
+	}
+	mergeNoarch(indexes, noarch)
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	key, err := h.signingKeyService.GetRSAPrivateKey(ctx, registry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	now := time.Now()
+	for indexKey, entries := range indexes {
+		index, err := BuildIndex(registry.Name+" "+indexKey.Branch+"/"+indexKey.Repository, entries, now)
+		if err != nil {
+			return fmt.Errorf("failed to build index for %s: %w", indexKey.Path(), err)
+		}
+		signed, err := SignIndex(index, key, KeyName(registry.Name), now)
+		if err != nil {
+			return fmt.Errorf("failed to sign index for %s: %w", indexKey.Path(), err)
+		}
+
+		filePath := IndexPrefix + "/" + indexKey.Path() + "/" + IndexFile
+		_, err = h.fileManager.UploadFile(
+			ctx, filePath, registry.ID, registry.RootParentID, rootSpace.Identifier, nil,
+			bytes.NewReader(signed), IndexFile, principalID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upload index file %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// collectIndexEntries returns the package records per index and the architecture independent records
+// per branch/repository.
+func (h *registryHelper) collectIndexEntries(
+	ctx context.Context, registryID int64,
+) (map[IndexKey][]string, map[IndexKey][]string, error) {
+	indexes := map[IndexKey][]string{}
+	noarch := map[IndexKey][]string{}
+
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := h.artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+
+		for _, a := range *artifacts {
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+			md := alpinemetadata.AlpineMetadata{}
+			if err := json.Unmarshal(a.Metadata, &md); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal metadata for artifact %s: %w", a.Name, err)
+			}
+			if len(md.GetFiles()) == 0 || md.Checksum == "" {
+				continue
+			}
+			entry := PackageEntry(md.Metadata, md.GetFiles()[0].Size)
+
+			for _, r := range md.Repositories {
+				if md.Architecture == ArchitectureNoarch {
+					key := IndexKey{Branch: r.Branch, Repository: r.Repository}
+					noarch[key] = append(noarch[key], entry)
+					continue
+				}
+				key := IndexKey{Branch: r.Branch, Repository: r.Repository, Architecture: md.Architecture}
+				indexes[key] = append(indexes[key], entry)
+			}
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			break
+		}
+	}
+	return indexes, noarch, nil
+}
+
+// mergeNoarch lists architecture independent packages in every architecture of their repository.
+func mergeNoarch(indexes map[IndexKey][]string, noarch map[IndexKey][]string) {
+	for repository, entries := range noarch {
+		found := false
+		for key := range indexes {
+			if key.Branch == repository.Branch && key.Repository == repository.Repository {
+				indexes[key] = append(indexes[key], entries...)
+				found = true
+			}
+		}
+		if !found {
+			repository.Architecture = ArchitectureNoarch
+			indexes[repository] = append(indexes[repository], entries...)
+		}
+	}
+}
+
+func (h *registryHelper) addExistingIndexes(
+	ctx context.Context, registryID int64, indexes map[IndexKey][]string,
+) error {
+	nodes, err := h.nodesDao.GetAllFileNodesByPathPrefixAndRegistryID(ctx, registryID, IndexPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list existing index files: %w", err)
+	}
+	for _, node := range *nodes {
+		// Expected layout: /index/{branch}/{repository}/{architecture}/APKINDEX.tar.gz
+		parts := strings.Split(strings.TrimPrefix(node.NodePath, IndexPrefix+"/"), "/")
+		if len(parts) != 4 || parts[3] != IndexFile {
+			continue
+		}
+		key := IndexKey{Branch: parts[0], Repository: parts[1], Architecture: parts[2]}
+		if _, ok := indexes[key]; !ok {
+			indexes[key] = nil
+		}
+	}
+	return nil
+}
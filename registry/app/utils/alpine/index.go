@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	alpinemetadata "github.com/harness/gitness/registry/app/metadata/alpine"
+)
+
+const (
+	IndexPrefix = "/index"
+	IndexFile   = "APKINDEX.tar.gz"
+
+	indexEntry       = "APKINDEX"
+	descriptionEntry = "DESCRIPTION"
+	signatureEntry   = ".SIGN.RSA."
+)
+
+// IndexKey identifies one APKINDEX of a repository.
+type IndexKey struct {
+	Branch       string
+	Repository   string
+	Architecture string
+}
+
+// Path is the location of the index directory relative to the registry root, e.g. v3.20/main/x86_64.
+func (k IndexKey) Path() string {
+	return k.Branch + "/" + k.Repository + "/" + k.Architecture
+}
+
+// KeyName is the file name clients must store the public key of a registry under in /etc/apk/keys.
+func KeyName(registryName string) string {
+	return registryName + ".rsa.pub"
+}
+
+// PackageEntry renders the APKINDEX record of a stored package.
+func PackageEntry(md alpinemetadata.Metadata, size int64) string {
+	var b strings.Builder
+	field := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s:%s\n", key, value)
+		}
+	}
+	field("C", md.Checksum)
+	field("P", md.Package)
+	field("V", md.Version)
+	field("A", md.Architecture)
+	fmt.Fprintf(&b, "S:%d\n", size)
+	fmt.Fprintf(&b, "I:%d\n", md.InstalledSize)
+	field("T", md.Description)
+	field("U", md.URL)
+	field("L", md.License)
+	field("o", md.Origin)
+	field("m", md.Maintainer)
+	if md.BuildDate != 0 {
+		fmt.Fprintf(&b, "t:%d\n", md.BuildDate)
+	}
+	field("c", md.Commit)
+	field("D", strings.Join(md.Dependencies, " "))
+	field("p", strings.Join(md.Provides, " "))
+	field("i", strings.Join(md.InstallIf, " "))
+	return b.String()
+}
+
+// BuildIndex renders the unsigned APKINDEX.tar.gz holding the given package records.
+func BuildIndex(description string, entries []string, now time.Time) ([]byte, error) {
+	sorted := append([]string(nil), entries...)
+	sort.Strings(sorted)
+	var index strings.Builder
+	for _, e := range sorted {
+		index.WriteString(e)
+		index.WriteString("\n")
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{
+		{descriptionEntry, []byte(description)},
+		{indexEntry, []byte(index.String())},
+	} {
+		if err := writeTarFile(tw, f.name, f.content, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SignIndex prepends the signature segment to an unsigned index. The segment is a tar stream without
+// end-of-archive blocks, so apk reads it and the index as a single archive.
+func SignIndex(index []byte, key *rsa.PrivateKey, keyName string, now time.Time) ([]byte, error) {
+	digest := sha1.Sum(index) //nolint:gosec
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign index: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	if err = writeTarFile(tw, signatureEntry+keyName, signature, now); err != nil {
+		return nil, err
+	}
+	if err = tw.Flush(); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	buf.Write(index)
+	return buf.Bytes(), nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte, now time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		ModTime:  now,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(content)
+	return err
+}
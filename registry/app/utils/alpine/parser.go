@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alpine
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	alpinemetadata "github.com/harness/gitness/registry/app/metadata/alpine"
+)
+
+const (
+	pkgInfoFile     = ".PKGINFO"
+	signaturePrefix = ".SIGN."
+	maxPkgInfo      = 1 << 20
+
+	PackageExtension = ".apk"
+	// ArchitectureNoarch marks architecture independent packages, which are listed in every architecture.
+	ArchitectureNoarch = "noarch"
+)
+
+var (
+	ErrInvalidArchive      = errors.New("invalid alpine package")
+	ErrMissingPkgInfo      = errors.New(".PKGINFO not found in alpine package")
+	ErrInvalidPackageName  = errors.New("package name is invalid")
+	ErrInvalidVersion      = errors.New("package version is invalid")
+	ErrInvalidArchitecture = errors.New("package architecture is invalid")
+
+	namePattern         = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9_.+\-]*\z`)
+	versionPattern      = regexp.MustCompile(`\A[0-9][a-zA-Z0-9._~]*-r[0-9]+\z`)
+	architecturePattern = regexp.MustCompile(`\A[a-z0-9][a-z0-9_]*\z`)
+	// repositoryPattern validates branch and repository names used in repository paths.
+	repositoryPattern = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9\-_.]*\z`)
+)
+
+// Package is the package information extracted from an .apk file.
+type Package struct {
+	Name         string
+	Version      string
+	Architecture string
+	Metadata     alpinemetadata.Metadata
+}
+
+// segmentReader hashes every byte read from the package. It implements io.ByteReader so the gzip
+// reader does not read ahead past the end of a segment.
+type segmentReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (s *segmentReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.h.Write(p[:n])
+	return n, err
+}
+
+func (s *segmentReader) ReadByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == nil {
+		s.h.Write([]byte{b})
+	}
+	return b, err
+}
+
+// ParsePackage reads an .apk file and extracts the fields of its .PKGINFO file.
+//
+// An .apk file is a concatenation of gzip streams: an optional signature segment, the control segment
+// holding .PKGINFO and the data segment. The checksum of the package is the SHA1 of the control segment.
+func ParsePackage(r io.Reader) (*Package, error) {
+	sr := &segmentReader{r: bufio.NewReader(r), h: sha1.New()} //nolint:gosec
+	zr, err := gzip.NewReader(sr)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+
+	for segment := 0; segment < 2; segment++ {
+		zr.Multistream(false)
+		tr := tar.NewReader(zr)
+		hdr, err := tr.Next()
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if strings.HasPrefix(hdr.Name, signaturePrefix) {
+			if _, err = io.Copy(io.Discard, zr); err != nil {
+				return nil, ErrInvalidArchive
+			}
+			sr.h.Reset()
+			if err = zr.Reset(sr); err != nil {
+				return nil, ErrInvalidArchive
+			}
+			continue
+		}
+
+		p, err := parseControlTar(tr, hdr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.Copy(io.Discard, zr); err != nil {
+			return nil, ErrInvalidArchive
+		}
+		p.Metadata.Checksum = "Q1" + base64.StdEncoding.EncodeToString(sr.h.Sum(nil))
+		return p, nil
+	}
+	return nil, ErrMissingPkgInfo
+}
+
+func parseControlTar(tr *tar.Reader, hdr *tar.Header) (*Package, error) {
+	for {
+		if hdr.Name == pkgInfoFile {
+			return ParsePkgInfo(io.LimitReader(tr, maxPkgInfo))
+		}
+		var err error
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMissingPkgInfo
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+	}
+}
+
+// ParsePkgInfo parses the "key = value" lines of a .PKGINFO file.
+func ParsePkgInfo(r io.Reader) (*Package, error) {
+	md := alpinemetadata.Metadata{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "pkgname":
+			md.Package = value
+		case "pkgver":
+			md.Version = value
+		case "arch":
+			md.Architecture = value
+		case "pkgdesc":
+			md.Description = value
+		case "url":
+			md.URL = value
+		case "license":
+			md.License = value
+		case "origin":
+			md.Origin = value
+		case "maintainer":
+			md.Maintainer = value
+		case "packager":
+			md.Packager = value
+		case "commit":
+			md.Commit = value
+		case "builddate":
+			md.BuildDate, _ = strconv.ParseInt(value, 10, 64)
+		case "size":
+			md.InstalledSize, _ = strconv.ParseInt(value, 10, 64)
+		case "depend":
+			md.Dependencies = append(md.Dependencies, value)
+		case "provides":
+			md.Provides = append(md.Provides, value)
+		case "install_if":
+			md.InstallIf = append(md.InstallIf, strings.Fields(value)...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read .PKGINFO: %w", err)
+	}
+
+	if !namePattern.MatchString(md.Package) {
+		return nil, ErrInvalidPackageName
+	}
+	if !versionPattern.MatchString(md.Version) {
+		return nil, ErrInvalidVersion
+	}
+	if !architecturePattern.MatchString(md.Architecture) {
+		return nil, ErrInvalidArchitecture
+	}
+	return &Package{
+		Name:         md.Package,
+		Version:      md.Version,
+		Architecture: md.Architecture,
+		Metadata:     md,
+	}, nil
+}
+
+// IsValidRepository reports whether name can be used as a branch or repository name.
+func IsValidRepository(name string) bool {
+	return repositoryPattern.MatchString(name)
+}
+
+// IsValidArchitecture reports whether name is a valid package architecture.
+func IsValidArchitecture(name string) bool {
+	return architecturePattern.MatchString(name)
+}
+
+// ArtifactVersion is the registry version of a package: one artifact is stored per version and architecture.
+func ArtifactVersion(version, architecture string) string {
+	return version + "." + architecture
+}
+
+// FileName is the canonical file name of a package.
+func FileName(name, version string) string {
+	return name + "-" + version + PackageExtension
+}
+
+// ParseFileName splits a package file name ({name}-{version}-r{release}.apk) into its name and version.
+func ParseFileName(fileName string) (string, string, error) {
+	base, found := strings.CutSuffix(fileName, PackageExtension)
+	if !found {
+		return "", "", fmt.Errorf("invalid alpine package file name: %s", fileName)
+	}
+	parts := strings.Split(base, "-")
+	if len(parts) < 3 {
+		return "", "", fmt.Errorf("invalid alpine package file name: %s", fileName)
+	}
+	name := strings.Join(parts[:len(parts)-2], "-")
+	version := strings.Join(parts[len(parts)-2:], "-")
+	if !namePattern.MatchString(name) || !versionPattern.MatchString(version) {
+		return "", "", fmt.Errorf("invalid alpine package file name: %s", fileName)
+	}
+	return name, version, nil
+}
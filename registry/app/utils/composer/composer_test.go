@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	composermetadata "github.com/harness/gitness/registry/app/metadata/composer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `{
+	"name": "acme/hello-world",
+	"description": "Prints a friendly greeting",
+	"type": "library",
+	"license": "MIT",
+	"authors": [{"name": "Jane Doe", "email": "jane@example.com"}],
+	"require": {"php": ">=8.1", "psr/log": "^3.0"},
+	"autoload": {"psr-4": {"Acme\\HelloWorld\\": "src/"}}
+}`
+
+func buildArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		version string
+		wantErr error
+	}{
+		{
+			name:    "manifest at root",
+			files:   map[string]string{"composer.json": testManifest, "src/Hello.php": "<?php"},
+			version: "1.2.0",
+		},
+		{
+			name: "manifest in top level directory",
+			files: map[string]string{
+				"acme-hello-world-abc123/composer.json": testManifest,
+				"acme-hello-world-abc123/src/Hello.php": "<?php",
+			},
+			version: "1.2.0",
+		},
+		{
+			name: "manifest in one of several directories",
+			files: map[string]string{
+				"a/composer.json": testManifest,
+				"b/README.md":     "readme",
+			},
+			version: "1.2.0",
+			wantErr: ErrMissingManifest,
+		},
+		{
+			name:    "missing version",
+			files:   map[string]string{"composer.json": testManifest},
+			wantErr: ErrInvalidVersion,
+		},
+		{
+			name:    "invalid name",
+			files:   map[string]string{"composer.json": `{"name": "Acme/Hello"}`},
+			version: "1.0.0",
+			wantErr: ErrInvalidPackageName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := buildArchive(t, tt.files)
+			p, err := ParsePackage(bytes.NewReader(archive), int64(len(archive)), tt.version)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "acme/hello-world", p.Name)
+			assert.Equal(t, "1.2.0", p.Version)
+			assert.Equal(t, "1.2.0.0", p.Metadata.VersionNormalized)
+			assert.Equal(t, []string{"MIT"}, p.Metadata.License)
+			assert.Equal(t, []composermetadata.Author{{Name: "Jane Doe", Email: "jane@example.com"}},
+				p.Metadata.Authors)
+			assert.Equal(t, map[string]string{"php": ">=8.1", "psr/log": "^3.0"}, p.Metadata.Require)
+			assert.Contains(t, p.Metadata.Manifest, "autoload")
+		})
+	}
+}
+
+func TestParseManifestVersion(t *testing.T) {
+	p, err := ParseManifest([]byte(`{"name": "acme/lib", "version": "v2.0.1", "license": ["MIT", "GPL-3.0"]}`), "")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.1", p.Version)
+	assert.Equal(t, "2.0.1.0", p.Metadata.VersionNormalized)
+	assert.Equal(t, []string{"MIT", "GPL-3.0"}, p.Metadata.License)
+	assert.NotContains(t, p.Metadata.Manifest, "version")
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "1", want: "1.0.0.0"},
+		{version: "v1.2.3", want: "1.2.3.0"},
+		{version: "1.2.3.4", want: "1.2.3.4"},
+		{version: "1.0.0-beta2", want: "1.0.0.0-beta2"},
+		{version: "1.0.0-b.2", want: "1.0.0.0-beta2"},
+		{version: "1.0.0RC1", want: "1.0.0.0-RC1"},
+		{version: "1.0.0-alpha", want: "1.0.0.0-alpha"},
+		{version: "1.0.0-stable", want: "1.0.0.0"},
+		{version: "1.0.0-p1", want: "1.0.0.0-patch1"},
+		{version: "1.0.0-dev", want: "1.0.0.0-dev"},
+		{version: "2.x-dev", want: "2.9999999.9999999.9999999-dev"},
+		{version: "2.1.x-dev", want: "2.1.9999999.9999999-dev"},
+		{version: "dev-main", want: "dev-main"},
+		{version: "20240102", want: "20240102"},
+		{version: "2024.01.02", want: "2024.01.02.0"},
+		{version: "", wantErr: true},
+		{version: "latest", wantErr: true},
+		{version: "dev-feature/x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := NormalizeVersion(tt.version)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.True(t, IsDevVersion("dev-main"))
+	assert.True(t, IsDevVersion("2.9999999.9999999.9999999-dev"))
+	assert.False(t, IsDevVersion("1.0.0.0-RC1"))
+}
+
+func TestMetadataPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantName string
+		wantDev  bool
+		wantErr  bool
+	}{
+		{path: "acme/lib.json", wantName: "acme/lib"},
+		{path: "acme/lib~dev.json", wantName: "acme/lib", wantDev: true},
+		{path: "acme/lib", wantErr: true},
+		{path: "lib.json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			name, dev, err := ParseMetadataPath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantDev, dev)
+			assert.Equal(t, MetadataDir+"/"+tt.path, MetadataPath(name, dev))
+		})
+	}
+}
+
+func TestBuildRepositoryIndex(t *testing.T) {
+	index, err := json.Marshal(BuildRepositoryIndex("https://example.com/pkg/root/reg/composer/"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"packages": {},
+		"metadata-url": "https://example.com/pkg/root/reg/composer/p2/%package%.json"
+	}`, string(index))
+}
+
+func TestBuildPackageMetadata(t *testing.T) {
+	p, err := ParseManifest([]byte(testManifest), "1.2.0")
+	require.NoError(t, err)
+	older := p.Metadata
+	older.Version = "1.1.0"
+	older.VersionNormalized = "1.1.0.0"
+	p.Metadata.Shasum = "abc"
+
+	data, err := BuildPackageMetadata(p.Name, []PackageVersion{
+		{Metadata: older, DistURL: "https://example.com/old.zip", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Metadata: p.Metadata, DistURL: "https://example.com/new.zip", Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	var result struct {
+		Packages map[string][]map[string]any `json:"packages"`
+	}
+	require.NoError(t, json.Unmarshal(data, &result))
+	versions := result.Packages["acme/hello-world"]
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.2.0", versions[0]["version"])
+	assert.Equal(t, "1.2.0.0", versions[0]["version_normalized"])
+	assert.Equal(t, "2024-02-01T00:00:00Z", versions[0]["time"])
+	assert.Equal(t, map[string]any{"type": "zip", "url": "https://example.com/new.zip", "shasum": "abc"},
+		versions[0]["dist"])
+	assert.Contains(t, versions[0], "autoload")
+	assert.Equal(t, "1.1.0", versions[1]["version"])
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	composermetadata "github.com/harness/gitness/registry/app/metadata/composer"
+)
+
+const (
+	manifestFile = "composer.json"
+	maxManifest  = 10 << 20
+
+	PackageExtension = ".zip"
+)
+
+var (
+	ErrInvalidArchive     = errors.New("invalid zip archive")
+	ErrMissingManifest    = errors.New("composer.json not found in package archive")
+	ErrInvalidManifest    = errors.New("composer.json is invalid")
+	ErrInvalidPackageName = errors.New("package name is invalid")
+	ErrInvalidVersion     = errors.New("package version is invalid")
+
+	// Source: https://getcomposer.org/doc/04-schema.md#name
+	namePattern = regexp.MustCompile(`\A[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]|-{1,2})?[a-z0-9]+)*\z`)
+)
+
+// Package is the manifest extracted from a dist archive.
+type Package struct {
+	Name     string
+	Version  string
+	Metadata composermetadata.Metadata
+}
+
+// manifest holds the composer.json fields the registry indexes. License and authors come in several shapes.
+type manifest struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Homepage    string            `json:"homepage"`
+	Keywords    []string          `json:"keywords"`
+	License     json.RawMessage   `json:"license"`
+	Authors     []json.RawMessage `json:"authors"`
+	Require     map[string]string `json:"require"`
+	RequireDev  map[string]string `json:"require-dev"`
+}
+
+// ParsePackage reads the composer.json of a dist archive. The manifest is either at the root of the archive
+// or in its single top level directory, as in the archives of GitHub releases. version is used when the
+// manifest does not declare one.
+func ParsePackage(r io.ReaderAt, size int64, version string) (*Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	f := findManifest(zr.File)
+	if f == nil {
+		return nil, ErrMissingManifest
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxManifest))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	return ParseManifest(content, version)
+}
+
+func findManifest(files []*zip.File) *zip.File {
+	var nested *zip.File
+	topLevel := map[string]bool{}
+	for _, f := range files {
+		name := strings.TrimPrefix(f.Name, "/")
+		dir, _, _ := strings.Cut(name, "/")
+		topLevel[dir] = true
+		switch {
+		case name == manifestFile:
+			return f
+		case path.Dir(name) == dir && path.Base(name) == manifestFile:
+			nested = f
+		}
+	}
+	if nested != nil && len(topLevel) == 1 {
+		return nested
+	}
+	return nil
+}
+
+// ParseManifest validates a composer.json and extracts its metadata.
+func ParseManifest(content []byte, version string) (*Package, error) {
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	raw := map[string]any{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	if !IsValidName(m.Name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPackageName, m.Name)
+	}
+	if version == "" {
+		version = m.Version
+	}
+	normalized, err := NormalizeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	// The version is set by the repository, not by the manifest.
+	delete(raw, "version")
+
+	license, err := parseLicense(m.License)
+	if err != nil {
+		return nil, err
+	}
+	authors := make([]composermetadata.Author, 0, len(m.Authors))
+	for _, a := range m.Authors {
+		var author composermetadata.Author
+		if err := json.Unmarshal(a, &author); err != nil {
+			return nil, fmt.Errorf("%w: invalid author: %w", ErrInvalidManifest, err)
+		}
+		authors = append(authors, author)
+	}
+
+	return &Package{
+		Name:    m.Name,
+		Version: version,
+		Metadata: composermetadata.Metadata{
+			Name:              m.Name,
+			Version:           version,
+			VersionNormalized: normalized,
+			Type:              m.Type,
+			Description:       m.Description,
+			Homepage:          m.Homepage,
+			Keywords:          m.Keywords,
+			License:           license,
+			Authors:           authors,
+			Require:           m.Require,
+			RequireDev:        m.RequireDev,
+			Manifest:          raw,
+		},
+	}, nil
+}
+
+// parseLicense accepts a single license identifier or a list of them.
+func parseLicense(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var license string
+	if err := json.Unmarshal(raw, &license); err == nil {
+		return []string{license}, nil
+	}
+	var licenses []string
+	if err := json.Unmarshal(raw, &licenses); err != nil {
+		return nil, fmt.Errorf("%w: invalid license: %w", ErrInvalidManifest, err)
+	}
+	return licenses, nil
+}
+
+// IsValidName reports whether name is a valid vendor/package name.
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// FileName is the file name of the dist archive of a package version.
+func FileName(name, version string) string {
+	_, pkgName, _ := strings.Cut(name, "/")
+	return pkgName + "-" + version + PackageExtension
+}
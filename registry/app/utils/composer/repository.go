@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	composermetadata "github.com/harness/gitness/registry/app/metadata/composer"
+)
+
+const (
+	PackagesFile = "packages.json"
+	MetadataDir  = "p2"
+
+	metadataExtension = ".json"
+	devMetadataSuffix = "~dev"
+	distTypeZip       = "zip"
+)
+
+// RepositoryIndex is the packages.json of a composer repository.
+type RepositoryIndex struct {
+	Packages    map[string]any `json:"packages"`
+	MetadataURL string         `json:"metadata-url"`
+}
+
+// BuildRepositoryIndex returns the packages.json pointing composer to the p2 metadata below baseURL.
+func BuildRepositoryIndex(baseURL string) RepositoryIndex {
+	return RepositoryIndex{
+		Packages:    map[string]any{},
+		MetadataURL: strings.TrimSuffix(baseURL, "/") + "/" + MetadataDir + "/%package%" + metadataExtension,
+	}
+}
+
+// MetadataPath is the path of the p2 metadata file of a package, relative to the registry root.
+// Development versions are listed in a separate ~dev file.
+func MetadataPath(name string, dev bool) string {
+	p := MetadataDir + "/" + name
+	if dev {
+		p += devMetadataSuffix
+	}
+	return p + metadataExtension
+}
+
+// ParseMetadataPath splits the path of a p2 metadata file below the p2 directory, e.g. vendor/package~dev.json,
+// into the package name and whether it lists development versions.
+func ParseMetadataPath(p string) (string, bool, error) {
+	name, found := strings.CutSuffix(p, metadataExtension)
+	if !found {
+		return "", false, fmt.Errorf("invalid metadata path: %s", p)
+	}
+	name, dev := strings.CutSuffix(name, devMetadataSuffix)
+	if !IsValidName(name) {
+		return "", false, fmt.Errorf("invalid metadata path: %s", p)
+	}
+	return name, dev, nil
+}
+
+// PackageVersion is a stored version of a package together with the location of its dist archive.
+type PackageVersion struct {
+	Metadata composermetadata.Metadata
+	DistURL  string
+	Time     time.Time
+}
+
+// BuildPackageMetadata renders the p2 metadata file of a package, listing the newest versions first.
+func BuildPackageMetadata(name string, versions []PackageVersion) ([]byte, error) {
+	sorted := make([]PackageVersion, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.After(sorted[j].Time)
+	})
+
+	entries := make([]map[string]any, 0, len(sorted))
+	for _, v := range sorted {
+		entries = append(entries, versionEntry(v))
+	}
+	return json.Marshal(map[string]any{
+		"packages": map[string]any{
+			name: entries,
+		},
+	})
+}
+
+func versionEntry(v PackageVersion) map[string]any {
+	entry := maps.Clone(v.Metadata.Manifest)
+	if entry == nil {
+		entry = map[string]any{}
+	}
+	entry["name"] = v.Metadata.Name
+	entry["version"] = v.Metadata.Version
+	entry["version_normalized"] = v.Metadata.VersionNormalized
+	entry["dist"] = map[string]any{
+		"type":   distTypeZip,
+		"url":    v.DistURL,
+		"shasum": v.Metadata.Shasum,
+	}
+	entry["time"] = v.Time.UTC().Format(time.RFC3339)
+	return entry
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	devPrefix = "dev-"
+	devSuffix = "-dev"
+	// branchAlias replaces the wildcards of branch versions, e.g. 2.x-dev is 2.9999999.9999999.9999999-dev.
+	branchAlias = "9999999"
+)
+
+// The patterns follow the version parser of composer/semver.
+const modifierPattern = `[._-]?(?:(stable|beta|b|rc|alpha|a|patch|pl|p)((?:[.-]?\d+)*)?)?([.-]?dev)?`
+
+var (
+	classicalVersionPattern = regexp.MustCompile(`(?i)\A(\d{1,5})(\.\d+)?(\.\d+)?(\.\d+)?` + modifierPattern + `\z`)
+	dateVersionPattern      = regexp.MustCompile(
+		`(?i)\A(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3}){0,2})` + modifierPattern + `\z`)
+	branchVersionPattern = regexp.MustCompile(`(?i)\A(.*?)[.-]?dev\z`)
+	numericBranchPattern = regexp.MustCompile(`(?i)\A(\d+)(\.(?:\d+|[x*]))?(\.(?:\d+|[x*]))?(\.(?:\d+|[x*]))?\z`)
+	dateSeparatorPattern = regexp.MustCompile(`\D`)
+	branchNamePattern    = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9._\-]*\z`)
+)
+
+// NormalizeVersion returns the normalized form of a version, e.g. v1.2 is 1.2.0.0 and 2.x-dev is
+// 2.9999999.9999999.9999999-dev. Branch versions keep their dev- prefix.
+func NormalizeVersion(version string) (string, error) {
+	version = strings.TrimSpace(version)
+	if version == "" || strings.Contains(version, "/") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	if strings.HasPrefix(strings.ToLower(version), devPrefix) {
+		if !branchNamePattern.MatchString(version[len(devPrefix):]) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+		return devPrefix + version[len(devPrefix):], nil
+	}
+
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+	if m := classicalVersionPattern.FindStringSubmatch(trimmed); m != nil {
+		normalized := m[1]
+		for _, part := range m[2:5] {
+			if part == "" {
+				part = ".0"
+			}
+			normalized += part
+		}
+		return normalized + modifierSuffix(m[5], m[6], m[7]), nil
+	}
+	if m := dateVersionPattern.FindStringSubmatch(trimmed); m != nil {
+		return dateSeparatorPattern.ReplaceAllString(m[1], ".") + modifierSuffix(m[2], m[3], m[4]), nil
+	}
+	if m := branchVersionPattern.FindStringSubmatch(version); m != nil {
+		if branch := normalizeBranch(m[1]); branch != "" {
+			return branch, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+}
+
+func modifierSuffix(stability, number, dev string) string {
+	if strings.EqualFold(stability, "stable") {
+		return ""
+	}
+	var suffix string
+	if stability != "" {
+		suffix = "-" + expandStability(stability) + strings.TrimLeft(number, ".-")
+	}
+	if dev != "" {
+		suffix += devSuffix
+	}
+	return suffix
+}
+
+func expandStability(stability string) string {
+	switch strings.ToLower(stability) {
+	case "a":
+		return "alpha"
+	case "b":
+		return "beta"
+	case "p", "pl":
+		return "patch"
+	case "rc":
+		return "RC"
+	default:
+		return strings.ToLower(stability)
+	}
+}
+
+func normalizeBranch(name string) string {
+	m := numericBranchPattern.FindStringSubmatch(name)
+	if m == nil {
+		return ""
+	}
+	normalized := m[1]
+	for _, part := range m[2:5] {
+		part = strings.TrimPrefix(part, ".")
+		if part == "" || part == "x" || part == "X" || part == "*" {
+			part = branchAlias
+		}
+		normalized += "." + part
+	}
+	return normalized + devSuffix
+}
+
+// IsDevVersion reports whether a normalized version is a development version, which composer lists
+// in the ~dev metadata file.
+func IsDevVersion(normalized string) bool {
+	return strings.HasPrefix(normalized, devPrefix) || strings.HasSuffix(normalized, devSuffix)
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testControl = `Package: hello
+Version: 1:2.10-3
+Architecture: amd64
+Maintainer: Jane Doe <jane@example.com>
+Installed-Size: 280
+Depends: libc6 (>= 2.34)
+Section: devel
+Priority: optional
+Description: example package
+ Prints a friendly greeting.
+ .
+ Second paragraph.
+`
+
+func buildDeb(t *testing.T, control string) []byte {
+	t.Helper()
+
+	var controlTar bytes.Buffer
+	gz := gzip.NewWriter(&controlTar)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: "./control", Mode: 0o644, Size: int64(len(control)), Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write([]byte(control))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	var deb bytes.Buffer
+	deb.WriteString(arMagic)
+	writeMember := func(name string, data []byte) {
+		fmt.Fprintf(&deb, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", name, 0, 0, 0, "100644", len(data))
+		deb.Write(data)
+		if len(data)%2 == 1 {
+			deb.WriteByte('\n')
+		}
+	}
+	writeMember("debian-binary", []byte("2.0\n"))
+	writeMember("control.tar.gz", controlTar.Bytes())
+	writeMember("data.tar.gz", []byte("x"))
+	return deb.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	p, err := ParsePackage(bytes.NewReader(buildDeb(t, testControl)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", p.Name)
+	assert.Equal(t, "1:2.10-3", p.Version)
+	assert.Equal(t, "amd64", p.Architecture)
+	assert.Equal(t, "Jane Doe <jane@example.com>", p.Metadata.Maintainer)
+	assert.Equal(t, "libc6 (>= 2.34)", p.Metadata.Depends)
+	assert.Equal(t, "example package\n Prints a friendly greeting.\n .\n Second paragraph.", p.Metadata.Description)
+	assert.Equal(t, strings.TrimSuffix(testControl, "\n"), p.Metadata.Control)
+}
+
+func TestParsePackageErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr error
+	}{
+		{
+			name:    "not an archive",
+			content: []byte("hello world"),
+			wantErr: ErrInvalidArchive,
+		},
+		{
+			name:    "invalid name",
+			content: buildDeb(t, "Package: Hello\nVersion: 1.0\nArchitecture: amd64\n"),
+			wantErr: ErrInvalidPackageName,
+		},
+		{
+			name:    "invalid version",
+			content: buildDeb(t, "Package: hello\nVersion: v1.0\nArchitecture: amd64\n"),
+			wantErr: ErrInvalidVersion,
+		},
+		{
+			name:    "missing architecture",
+			content: buildDeb(t, "Package: hello\nVersion: 1.0\n"),
+			wantErr: ErrInvalidArchitecture,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePackage(bytes.NewReader(tt.content))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseControlFileDropsIndexFields(t *testing.T) {
+	p, err := ParseControlFile(strings.NewReader(
+		"Package: hello\nVersion: 1.0\nArchitecture: all\nFilename: pool/evil.deb\nSize: 1\nMD5sum: abc\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Package: hello\nVersion: 1.0\nArchitecture: all", p.Metadata.Control)
+}
+
+func TestFileNames(t *testing.T) {
+	assert.Equal(t, "2.10-3_amd64", ArtifactVersion("1:2.10-3", "amd64"))
+	assert.Equal(t, "hello_2.10-3_amd64.deb", FileName("hello", "1:2.10-3", "amd64"))
+
+	name, version, err := ParseFileName("hello_2.10-3_amd64.deb")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", name)
+	assert.Equal(t, "2.10-3_amd64", version)
+
+	for _, invalid := range []string{"hello.deb", "hello_1.0_amd64.rpm", "hello__amd64.deb", "a_b_c_d.deb"} {
+		_, _, err = ParseFileName(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBuildRelease(t *testing.T) {
+	key := IndexKey{Distribution: "stable", Component: "main", Architecture: "amd64"}
+	entry := PackageEntry("Package: hello\nVersion: 1.0\nArchitecture: amd64", "main", "hello",
+		"hello_1.0_amd64.deb", 42, "md5", "sha1", "sha256")
+	assert.Equal(t, "Package: hello\nVersion: 1.0\nArchitecture: amd64\n"+
+		"Filename: pool/main/hello/hello_1.0_amd64.deb\nSize: 42\nMD5sum: md5\nSHA1: sha1\nSHA256: sha256\n", entry)
+
+	files, err := BuildPackagesIndex(key, []string{entry})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "main/binary-amd64/Packages", files[0].Path)
+	assert.Equal(t, "main/binary-amd64/Packages.gz", files[1].Path)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	release := string(BuildRelease("my-registry", "stable", []IndexKey{key}, files, now))
+	assert.Contains(t, release, "Suite: stable\nCodename: stable\nDate: Tue, 02 Jan 2024 03:04:05 UTC\n")
+	assert.Contains(t, release, "Architectures: amd64\nComponents: main\n")
+	assert.Contains(t, release, fmt.Sprintf(" %d main/binary-amd64/Packages\n", len(files[0].Content)))
+	for _, section := range []string{"MD5Sum:", "SHA1:", "SHA256:", "SHA512:"} {
+		assert.Contains(t, release, "\n"+section+"\n")
+	}
+}
+
+func TestSignRelease(t *testing.T) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	release := []byte("Origin: test\nSuite: stable\n")
+	inRelease, releaseGPG, err := SignRelease(entity, release)
+	require.NoError(t, err)
+
+	keyring := openpgp.EntityList{entity}
+	block, _ := clearsign.Decode(inRelease)
+	require.NotNil(t, block)
+	_, err = block.VerifySignature(keyring, nil)
+	assert.NoError(t, err)
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(release), bytes.NewReader(releaseGPG), nil)
+	assert.NoError(t, err)
+}
+
+func TestMergeArchitectureAll(t *testing.T) {
+	amd64 := IndexKey{Distribution: "stable", Component: "main", Architecture: "amd64"}
+	arm64 := IndexKey{Distribution: "stable", Component: "main", Architecture: "arm64"}
+	indexes := map[IndexKey][]string{amd64: {"a"}, arm64: nil}
+	archAll := map[IndexKey][]string{
+		{Distribution: "stable", Component: "main"}:     {"all"},
+		{Distribution: "testing", Component: "contrib"}: {"other"},
+	}
+
+	mergeArchitectureAll(indexes, archAll)
+
+	assert.Equal(t, []string{"a", "all"}, indexes[amd64])
+	assert.Equal(t, []string{"all"}, indexes[arm64])
+	assert.Equal(t, []string{"other"},
+		indexes[IndexKey{Distribution: "testing", Component: "contrib", Architecture: ArchitectureAll}])
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	debianmetadata "github.com/harness/gitness/registry/app/metadata/debian"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/services/signingkey"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+)
+
+const artifactBatchLimit = 50
+
+type RegistryHelper interface {
+	// BuildRegistryIndex regenerates the Packages and signed Release files of every distribution of the registry.
+	BuildRegistryIndex(ctx context.Context, registry types.Registry, principalID int64) error
+}
+
+type registryHelper struct {
+	fileManager       filemanager.FileManager
+	artifactDao       store.ArtifactRepository
+	nodesDao          store.NodesRepository
+	spaceFinder       refcache.SpaceFinder
+	signingKeyService *signingkey.Service
+}
+
+func NewRegistryHelper(
+	fileManager filemanager.FileManager,
+	artifactDao store.ArtifactRepository,
+	nodesDao store.NodesRepository,
+	spaceFinder refcache.SpaceFinder,
+	signingKeyService *signingkey.Service,
+) RegistryHelper {
+	return &registryHelper{
+		fileManager:       fileManager,
+		artifactDao:       artifactDao,
+		nodesDao:          nodesDao,
+		spaceFinder:       spaceFinder,
+		signingKeyService: signingKeyService,
+	}
+}
+
+func (h *registryHelper) BuildRegistryIndex(ctx context.Context, registry types.Registry, principalID int64) error {
+	rootSpace, err := h.spaceFinder.FindByID(ctx, registry.RootParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space by ID: %w", err)
+	}
+
+	indexes, archAll, err := h.collectIndexEntries(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	// Indexes that no longer have packages are still published empty so apt drops the removed packages.
+	if err = h.addExistingIndexes(ctx, registry.ID, indexes); err != nil {
+		return err
+	}
+	mergeArchitectureAll(indexes, archAll)
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	entity, err := h.signingKeyService.GetOpenPGPEntity(ctx, registry.ID, registry.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	distributions := map[string][]IndexKey{}
+	for key := range indexes {
+		distributions[key.Distribution] = append(distributions[key.Distribution], key)
+	}
+
+	now := time.Now()
+	for distribution, keys := range distributions {
+		var files []IndexFile
+		for _, key := range keys {
+			packages, err := BuildPackagesIndex(key, indexes[key])
+			if err != nil {
+				return fmt.Errorf("failed to build packages index for %s/%s: %w", distribution, key.Path(), err)
+			}
+			files = append(files, packages...)
+		}
+
+		release := BuildRelease(registry.Name, distribution, keys, files, now)
+		inRelease, releaseGPG, err := SignRelease(entity, release)
+		if err != nil {
+			return fmt.Errorf("failed to sign release for distribution %s: %w", distribution, err)
+		}
+		files = append(files,
+			IndexFile{Path: ReleaseFile, Content: release},
+			IndexFile{Path: InReleaseFile, Content: inRelease},
+			IndexFile{Path: ReleaseGPGFile, Content: releaseGPG},
+		)
+
+		for _, f := range files {
+			filePath := DistsPrefix + "/" + distribution + "/" + f.Path
+			_, err = h.fileManager.UploadFile(
+				ctx, filePath, registry.ID, registry.RootParentID, rootSpace.Identifier, nil,
+				bytes.NewReader(f.Content), f.Path[strings.LastIndex(f.Path, "/")+1:], principalID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to upload index file %s: %w", filePath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// collectIndexEntries returns the package entries per index and the architecture independent entries
+// per distribution/component.
+func (h *registryHelper) collectIndexEntries(
+	ctx context.Context, registryID int64,
+) (map[IndexKey][]string, map[IndexKey][]string, error) {
+	indexes := map[IndexKey][]string{}
+	archAll := map[IndexKey][]string{}
+
+	lastArtifactID := int64(0)
+	for {
+		artifacts, err := h.artifactDao.GetAllArtifactsByRepo(ctx, registryID, artifactBatchLimit, lastArtifactID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get artifacts: %w", err)
+		}
+
+		for _, a := range *artifacts {
+			if a.ID > lastArtifactID {
+				lastArtifactID = a.ID
+			}
+			md := debianmetadata.DebianMetadata{}
+			if err := json.Unmarshal(a.Metadata, &md); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal metadata for artifact %s: %w", a.Name, err)
+			}
+			if len(md.GetFiles()) == 0 || md.Control == "" {
+				continue
+			}
+			file := md.GetFiles()[0]
+			sha256Sum := md.SHA256
+			if sha256Sum == "" {
+				sha256Sum = file.Sha256
+			}
+
+			for _, d := range md.Distributions {
+				entry := PackageEntry(md.Control, d.Component, md.Package, file.Filename, file.Size,
+					md.MD5, md.SHA1, sha256Sum)
+				if md.Architecture == ArchitectureAll {
+					key := IndexKey{Distribution: d.Distribution, Component: d.Component}
+					archAll[key] = append(archAll[key], entry)
+					continue
+				}
+				key := IndexKey{Distribution: d.Distribution, Component: d.Component, Architecture: md.Architecture}
+				indexes[key] = append(indexes[key], entry)
+			}
+		}
+		if len(*artifacts) < artifactBatchLimit {
+			break
+		}
+	}
+	return indexes, archAll, nil
+}
+
+// mergeArchitectureAll lists architecture independent packages in every architecture of their component.
+func mergeArchitectureAll(indexes map[IndexKey][]string, archAll map[IndexKey][]string) {
+	for component, entries := range archAll {
+		found := false
+		for key := range indexes {
+			if key.Distribution == component.Distribution && key.Component == component.Component {
+				indexes[key] = append(indexes[key], entries...)
+				found = true
+			}
+		}
+		if !found {
+			component.Architecture = ArchitectureAll
+			indexes[component] = append(indexes[component], entries...)
+		}
+	}
+}
+
+func (h *registryHelper) addExistingIndexes(
+	ctx context.Context, registryID int64, indexes map[IndexKey][]string,
+) error {
+	nodes, err := h.nodesDao.GetAllFileNodesByPathPrefixAndRegistryID(ctx, registryID, DistsPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list existing index files: %w", err)
+	}
+	for _, node := range *nodes {
+		// Expected layout: /dists/{distribution}/{component}/binary-{architecture}/Packages
+		parts := strings.Split(strings.TrimPrefix(node.NodePath, DistsPrefix+"/"), "/")
+		if len(parts) != 4 || parts[3] != PackagesFile || !strings.HasPrefix(parts[2], "binary-") {
+			continue
+		}
+		key := IndexKey{
+			Distribution: parts[0],
+			Component:    parts[1],
+			Architecture: strings.TrimPrefix(parts[2], "binary-"),
+		}
+		if _, ok := indexes[key]; !ok {
+			indexes[key] = nil
+		}
+	}
+	return nil
+}
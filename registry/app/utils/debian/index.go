@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+//nolint:gosec
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+)
+
+const (
+	ArchitectureAll = "all"
+
+	DistsPrefix = "/dists"
+	PoolPrefix  = "pool"
+
+	PackagesFile   = "Packages"
+	PackagesGzFile = "Packages.gz"
+	ReleaseFile    = "Release"
+	InReleaseFile  = "InRelease"
+	ReleaseGPGFile = "Release.gpg"
+)
+
+// IndexKey identifies one Packages index of a repository.
+type IndexKey struct {
+	Distribution string
+	Component    string
+	Architecture string
+}
+
+// Path is the location of the index relative to the distribution directory.
+func (k IndexKey) Path() string {
+	return k.Component + "/binary-" + k.Architecture
+}
+
+// IndexFile is a generated file listed in a Release file.
+type IndexFile struct {
+	// Path is relative to the distribution directory, e.g. main/binary-amd64/Packages.
+	Path    string
+	Content []byte
+}
+
+// PackageEntry renders the Packages paragraph of a stored package.
+func PackageEntry(control, component, name, fileName string, size int64, md5Sum, sha1Sum, sha256Sum string) string {
+	var b strings.Builder
+	b.WriteString(control)
+	fmt.Fprintf(&b, "\nFilename: %s/%s/%s/%s", PoolPrefix, component, name, fileName)
+	fmt.Fprintf(&b, "\nSize: %d", size)
+	if md5Sum != "" {
+		fmt.Fprintf(&b, "\nMD5sum: %s", md5Sum)
+	}
+	if sha1Sum != "" {
+		fmt.Fprintf(&b, "\nSHA1: %s", sha1Sum)
+	}
+	if sha256Sum != "" {
+		fmt.Fprintf(&b, "\nSHA256: %s", sha256Sum)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// BuildPackagesIndex joins package paragraphs into a Packages file and its gzip variant.
+func BuildPackagesIndex(key IndexKey, entries []string) ([]IndexFile, error) {
+	sorted := append([]string(nil), entries...)
+	sort.Strings(sorted)
+	content := []byte(strings.Join(sorted, "\n"))
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return []IndexFile{
+		{Path: key.Path() + "/" + PackagesFile, Content: content},
+		{Path: key.Path() + "/" + PackagesGzFile, Content: gz.Bytes()},
+	}, nil
+}
+
+// BuildRelease renders the Release file of a distribution listing the checksums of its index files.
+func BuildRelease(origin, distribution string, keys []IndexKey, files []IndexFile, now time.Time) []byte {
+	architectures := map[string]bool{}
+	components := map[string]bool{}
+	for _, k := range keys {
+		architectures[k.Architecture] = true
+		components[k.Component] = true
+	}
+
+	sortedFiles := append([]IndexFile(nil), files...)
+	sort.Slice(sortedFiles, func(i, j int) bool { return sortedFiles[i].Path < sortedFiles[j].Path })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Origin: %s\n", origin)
+	fmt.Fprintf(&b, "Label: %s\n", origin)
+	fmt.Fprintf(&b, "Suite: %s\n", distribution)
+	fmt.Fprintf(&b, "Codename: %s\n", distribution)
+	fmt.Fprintf(&b, "Date: %s\n", now.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&b, "Architectures: %s\n", strings.Join(sortedKeys(architectures), " "))
+	fmt.Fprintf(&b, "Components: %s\n", strings.Join(sortedKeys(components), " "))
+
+	//nolint:gosec
+	sums := []struct {
+		name string
+		hash func([]byte) string
+	}{
+		{"MD5Sum", func(c []byte) string { s := md5.Sum(c); return hex.EncodeToString(s[:]) }},
+		{"SHA1", func(c []byte) string { s := sha1.Sum(c); return hex.EncodeToString(s[:]) }},
+		{"SHA256", func(c []byte) string { s := sha256.Sum256(c); return hex.EncodeToString(s[:]) }},
+		{"SHA512", func(c []byte) string { s := sha512.Sum512(c); return hex.EncodeToString(s[:]) }},
+	}
+	for _, sum := range sums {
+		fmt.Fprintf(&b, "%s:\n", sum.name)
+		for _, f := range sortedFiles {
+			fmt.Fprintf(&b, " %s %d %s\n", sum.hash(f.Content), len(f.Content), f.Path)
+		}
+	}
+	return []byte(b.String())
+}
+
+// SignRelease returns the clear-signed InRelease and the detached armored Release.gpg signatures.
+func SignRelease(entity *openpgp.Entity, release []byte) ([]byte, []byte, error) {
+	var inRelease bytes.Buffer
+	w, err := clearsign.Encode(&inRelease, entity.PrivateKey, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create clearsign writer: %w", err)
+	}
+	if _, err = w.Write(release); err != nil {
+		return nil, nil, fmt.Errorf("failed to sign release: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to sign release: %w", err)
+	}
+
+	var releaseGPG bytes.Buffer
+	if err = openpgp.ArmoredDetachSign(&releaseGPG, entity, bytes.NewReader(release), nil); err != nil {
+		return nil, nil, fmt.Errorf("failed to create detached release signature: %w", err)
+	}
+	return inRelease.Bytes(), releaseGPG.Bytes(), nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
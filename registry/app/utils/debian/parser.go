@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debian
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	debianmetadata "github.com/harness/gitness/registry/app/metadata/debian"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	arMagic      = "!<arch>\n"
+	arHeaderSize = 60
+
+	controlFile = "control"
+	maxControl  = 1 << 20
+
+	PackageExtension = ".deb"
+)
+
+var (
+	ErrInvalidArchive      = errors.New("invalid debian archive")
+	ErrMissingControlFile  = errors.New("control file not found in debian archive")
+	ErrInvalidPackageName  = errors.New("package name is invalid")
+	ErrInvalidVersion      = errors.New("package version is invalid")
+	ErrInvalidArchitecture = errors.New("package architecture is invalid")
+
+	namePattern         = regexp.MustCompile(`\A[a-z0-9][a-z0-9+\-.]+\z`)
+	versionPattern      = regexp.MustCompile(`\A(?:[0-9]+:)?[0-9][A-Za-z0-9.+~\-]*\z`)
+	architecturePattern = regexp.MustCompile(`\A[a-z0-9][a-z0-9\-]*\z`)
+	// distributionPattern validates distribution and component names used in repository paths.
+	distributionPattern = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9\-_.]*\z`)
+
+	// indexFields are computed by the registry and never copied from the uploaded control file.
+	indexFields = map[string]bool{
+		"filename": true, "size": true, "md5sum": true, "sha1": true, "sha256": true, "sha512": true,
+	}
+)
+
+// Package is the control information extracted from a .deb file.
+type Package struct {
+	Name         string
+	Version      string
+	Architecture string
+	Metadata     debianmetadata.Metadata
+}
+
+// ParsePackage reads a .deb archive and extracts the fields of its control file.
+func ParsePackage(r io.Reader) (*Package, error) {
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != arMagic {
+		return nil, ErrInvalidArchive
+	}
+
+	header := make([]byte, arHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrMissingControlFile
+			}
+			return nil, ErrInvalidArchive
+		}
+		name := strings.TrimRight(strings.TrimSpace(string(header[0:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return nil, ErrInvalidArchive
+		}
+
+		if strings.HasPrefix(name, "control.tar") {
+			return parseControlTar(name, io.LimitReader(r, size))
+		}
+
+		// Entries are aligned to an even offset.
+		if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+			return nil, ErrInvalidArchive
+		}
+	}
+}
+
+func parseControlTar(name string, r io.Reader) (*Package, error) {
+	var inner io.Reader
+	switch strings.TrimPrefix(name, "control.tar") {
+	case "":
+		inner = r
+	case ".gz":
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		defer gzr.Close()
+		inner = gzr
+	case ".xz":
+		xzr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		inner = xzr
+	case ".zst":
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		defer zr.Close()
+		inner = zr
+	default:
+		return nil, fmt.Errorf("%w: unsupported control archive %s", ErrInvalidArchive, name)
+	}
+
+	tr := tar.NewReader(inner)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMissingControlFile
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		if hdr.Typeflag == tar.TypeReg && strings.TrimPrefix(hdr.Name, "./") == controlFile {
+			return ParseControlFile(io.LimitReader(tr, maxControl))
+		}
+	}
+}
+
+// ParseControlFile parses a debian control paragraph.
+func ParseControlFile(r io.Reader) (*Package, error) {
+	var (
+		control bytes.Buffer
+		key     string
+		skip    bool
+	)
+	fields := map[string]*strings.Builder{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxControl)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			// Only the first paragraph describes the binary package.
+			if control.Len() > 0 {
+				break
+			}
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			if key == "" {
+				return nil, fmt.Errorf("%w: continuation line without field", ErrInvalidArchive)
+			}
+			if !skip {
+				fields[key].WriteString("\n" + line)
+				control.WriteString(line + "\n")
+			}
+			continue
+		}
+
+		k, v, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("%w: malformed control line %q", ErrInvalidArchive, line)
+		}
+		key = strings.ToLower(strings.TrimSpace(k))
+		skip = indexFields[key]
+		if skip {
+			continue
+		}
+		fields[key] = &strings.Builder{}
+		fields[key].WriteString(strings.TrimSpace(v))
+		control.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	get := func(k string) string {
+		if b, ok := fields[k]; ok {
+			return b.String()
+		}
+		return ""
+	}
+
+	p := &Package{
+		Name:         get("package"),
+		Version:      get("version"),
+		Architecture: get("architecture"),
+	}
+	if !namePattern.MatchString(p.Name) {
+		return nil, ErrInvalidPackageName
+	}
+	if !versionPattern.MatchString(p.Version) {
+		return nil, ErrInvalidVersion
+	}
+	if !architecturePattern.MatchString(p.Architecture) {
+		return nil, ErrInvalidArchitecture
+	}
+
+	p.Metadata = debianmetadata.Metadata{
+		Package:       p.Name,
+		Version:       p.Version,
+		Architecture:  p.Architecture,
+		Maintainer:    get("maintainer"),
+		Description:   get("description"),
+		Homepage:      get("homepage"),
+		Section:       get("section"),
+		Priority:      get("priority"),
+		Source:        get("source"),
+		InstalledSize: get("installed-size"),
+		Depends:       get("depends"),
+		Control:       strings.TrimSuffix(control.String(), "\n"),
+	}
+	return p, nil
+}
+
+// IsValidDistribution reports whether name can be used as a distribution or component name.
+func IsValidDistribution(name string) bool {
+	return distributionPattern.MatchString(name)
+}
+
+// VersionWithoutEpoch strips the epoch prefix ("1:") from a debian version.
+func VersionWithoutEpoch(version string) string {
+	if _, v, found := strings.Cut(version, ":"); found {
+		return v
+	}
+	return version
+}
+
+// ArtifactVersion is the registry version of a package: one artifact is stored per version and architecture.
+func ArtifactVersion(version, architecture string) string {
+	return VersionWithoutEpoch(version) + "_" + architecture
+}
+
+// FileName is the canonical pool file name of a package.
+func FileName(name, version, architecture string) string {
+	return name + "_" + ArtifactVersion(version, architecture) + PackageExtension
+}
+
+// ParseFileName splits a pool file name into its package name and artifact version.
+func ParseFileName(fileName string) (string, string, error) {
+	base, found := strings.CutSuffix(fileName, PackageExtension)
+	if !found {
+		return "", "", fmt.Errorf("invalid debian package file name: %s", fileName)
+	}
+	parts := strings.Split(base, "_")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("invalid debian package file name: %s", fileName)
+	}
+	return parts[0], parts[1] + "_" + parts[2], nil
+}
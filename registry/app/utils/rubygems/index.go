@@ -0,0 +1,322 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+)
+
+const (
+	SpecsFile           = "specs.4.8.gz"
+	LatestSpecsFile     = "latest_specs.4.8.gz"
+	PrereleaseSpecsFile = "prerelease_specs.4.8.gz"
+	QuickSpecDir        = "quick/Marshal.4.8"
+	QuickSpecSuffix     = ".gemspec.rz"
+	VersionsFile        = "versions"
+	NamesFile           = "names"
+	InfoDir             = "info"
+
+	// rubygemsVersion is the version of RubyGems recorded in served gemspecs.
+	rubygemsVersion      = "3.5.0"
+	specificationVersion = 4
+)
+
+var versionSegmentPattern = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+
+// Spec is a gem version listed in the specs indexes.
+type Spec struct {
+	Name     string
+	Version  string
+	Platform string
+}
+
+// QuickSpecPath is the path of the marshaled gemspec of a gem version, relative to the registry root.
+func QuickSpecPath(name, version string) string {
+	return QuickSpecDir + "/" + name + "-" + version + QuickSpecSuffix
+}
+
+// InfoPath is the path of the compact index info file of a gem, relative to the registry root.
+func InfoPath(name string) string {
+	return InfoDir + "/" + name
+}
+
+// IsPrerelease reports whether a gem version is a prerelease, i.e. contains a letter.
+func IsPrerelease(version string) bool {
+	return strings.IndexFunc(version, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	}) >= 0
+}
+
+// CompareVersions compares two gem versions the way Gem::Version does.
+func CompareVersions(a, b string) int {
+	as := versionSegmentPattern.FindAllString(a, -1)
+	bs := versionSegmentPattern.FindAllString(b, -1)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xn, xErr := strconv.Atoi(x)
+		yn, yErr := strconv.Atoi(y)
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn != yn {
+				return compareInts(xn, yn)
+			}
+		case xErr == nil:
+			// a numeric segment is greater than a prerelease segment.
+			return 1
+		case yErr == nil:
+			return -1
+		case x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// SortSpecs orders specs by name, version and platform.
+func SortSpecs(specs []Spec) {
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].Name != specs[j].Name {
+			return specs[i].Name < specs[j].Name
+		}
+		if c := CompareVersions(specs[i].Version, specs[j].Version); c != 0 {
+			return c < 0
+		}
+		return specs[i].Platform < specs[j].Platform
+	})
+}
+
+// LatestSpecs returns the latest release of every gem and platform.
+func LatestSpecs(specs []Spec) []Spec {
+	latest := map[string]Spec{}
+	for _, s := range specs {
+		if IsPrerelease(s.Version) {
+			continue
+		}
+		key := s.Name + "\x00" + s.Platform
+		if current, ok := latest[key]; !ok || CompareVersions(s.Version, current.Version) > 0 {
+			latest[key] = s
+		}
+	}
+	result := make([]Spec, 0, len(latest))
+	for _, s := range latest {
+		result = append(result, s)
+	}
+	SortSpecs(result)
+	return result
+}
+
+// BuildSpecsIndex renders a gzipped specs index, a marshaled list of [name, Gem::Version, platform].
+func BuildSpecsIndex(specs []Spec) ([]byte, error) {
+	entries := make([]any, 0, len(specs))
+	for _, s := range specs {
+		entries = append(entries, []any{s.Name, gemVersion(s.Version), s.Platform})
+	}
+	data, err := Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BuildQuickSpec renders the deflated, marshaled Gem::Specification served below quick/Marshal.4.8.
+func BuildQuickSpec(md rubygemsmetadata.Metadata) ([]byte, error) {
+	dependencies := make([]any, 0, len(md.Dependencies))
+	for _, d := range md.Dependencies {
+		requirement := gemRequirementValue(d.Requirements)
+		dependencies = append(dependencies, Object{
+			Name: "Gem::Dependency",
+			Members: []ObjectMember{
+				{Name: "@name", Value: d.Name},
+				{Name: "@requirement", Value: requirement},
+				{Name: "@type", Value: Symbol(d.Type)},
+				{Name: "@prerelease", Value: false},
+				{Name: "@version_requirements", Value: requirement},
+			},
+		})
+	}
+	projectURLs := md.ProjectURLs
+	if projectURLs == nil {
+		projectURLs = map[string]string{}
+	}
+	email := any(nil)
+	if len(md.Email) > 0 {
+		email = md.Email
+	}
+
+	// Field order of Gem::Specification._load for specification version 4.
+	data, err := Marshal(UserDef{
+		Name: "Gem::Specification",
+		Value: []any{
+			rubygemsVersion,
+			specificationVersion,
+			md.Name,
+			gemVersion(md.Version),
+			nil, // date
+			md.Summary,
+			gemRequirementValue(md.RequiredRubyVersion),
+			gemRequirementValue(md.RequiredRubygemsVersion),
+			md.Platform, // original platform
+			dependencies,
+			nil, // rubyforge project
+			email,
+			md.Authors,
+			md.Description,
+			md.Homepage,
+			true, // has rdoc
+			md.Platform,
+			md.Licenses,
+			projectURLs,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err = zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gemVersion(version string) UserMarshal {
+	return UserMarshal{Name: "Gem::Version", Value: []any{version}}
+}
+
+func gemRequirementValue(requirements []rubygemsmetadata.Requirement) UserMarshal {
+	pairs := make([]any, 0, len(requirements))
+	for _, r := range requirements {
+		pairs = append(pairs, []any{r.Operator, gemVersion(r.Version)})
+	}
+	if len(pairs) == 0 {
+		pairs = append(pairs, []any{">=", gemVersion("0")})
+	}
+	return UserMarshal{Name: "Gem::Requirement", Value: []any{pairs}}
+}
+
+// InfoLine renders the compact index line of a gem version:
+// {version}[-{platform}] {dependency}:{requirements},...|checksum:{sha256},ruby:{requirements}.
+func InfoLine(md rubygemsmetadata.Metadata, sha256 string) string {
+	var dependencies []string
+	for _, d := range md.Dependencies {
+		if d.Type != DependencyTypeRuntime {
+			continue
+		}
+		dependencies = append(dependencies, d.Name+":"+requirementString(d.Requirements))
+	}
+
+	line := ArtifactVersion(md.Version, md.Platform) + " " + strings.Join(dependencies, ",") +
+		"|checksum:" + sha256
+	if len(md.RequiredRubyVersion) > 0 {
+		line += ",ruby:" + requirementString(md.RequiredRubyVersion)
+	}
+	if len(md.RequiredRubygemsVersion) > 0 {
+		line += ",rubygems:" + requirementString(md.RequiredRubygemsVersion)
+	}
+	return line
+}
+
+func requirementString(requirements []rubygemsmetadata.Requirement) string {
+	if len(requirements) == 0 {
+		return ">= 0"
+	}
+	parts := make([]string, 0, len(requirements))
+	for _, r := range requirements {
+		parts = append(parts, r.Operator+" "+r.Version)
+	}
+	return strings.Join(parts, "&")
+}
+
+// BuildInfo renders the compact index info file of a gem from its version lines.
+func BuildInfo(lines []string) []byte {
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+// VersionsEntry is the line of a gem in the compact index versions file.
+type VersionsEntry struct {
+	Name     string
+	Versions []string
+	Info     []byte
+}
+
+// BuildVersions renders the compact index versions file. Every line carries the MD5 of the info file of the gem
+// so clients only refetch changed gems.
+func BuildVersions(createdAt time.Time, entries []VersionsEntry) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "created_at: %s\n---\n", createdAt.UTC().Format(time.RFC3339))
+	for _, e := range entries {
+		sum := md5.Sum(e.Info) //nolint:gosec
+		fmt.Fprintf(&b, "%s %s %s\n", e.Name, strings.Join(e.Versions, ","), hex.EncodeToString(sum[:]))
+	}
+	return []byte(b.String())
+}
+
+// BuildNames renders the compact index names file.
+func BuildNames(names []string) []byte {
+	return BuildInfo(names)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
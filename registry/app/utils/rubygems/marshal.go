@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Ruby Marshal format 4.8, the encoding used by the RubyGems specs indexes and quick gemspecs.
+// Source: https://docs.ruby-lang.org/en/master/marshal_rdoc.html
+const (
+	marshalMajorVersion = 4
+	marshalMinorVersion = 8
+
+	typeNil         = '0'
+	typeTrue        = 'T'
+	typeFalse       = 'F'
+	typeFixnum      = 'i'
+	typeString      = '"'
+	typeSymbol      = ':'
+	typeSymlink     = ';'
+	typeArray       = '['
+	typeHash        = '{'
+	typeObject      = 'o'
+	typeUserDef     = 'u'
+	typeUserMarshal = 'U'
+	typeIvar        = 'I'
+)
+
+// Symbol is a ruby symbol, e.g. :runtime.
+type Symbol string
+
+// UserMarshal is an object of a class implementing marshal_dump, e.g. Gem::Version.
+type UserMarshal struct {
+	Name  string
+	Value any
+}
+
+// UserDef is an object of a class implementing _dump, e.g. Gem::Specification. Value is marshaled on its own
+// and embedded as a byte string.
+type UserDef struct {
+	Name  string
+	Value any
+}
+
+// Object is a plain ruby object with instance variables, e.g. Gem::Dependency.
+type Object struct {
+	Name string
+	// Members are the instance variables in order, names include the @ prefix.
+	Members []ObjectMember
+}
+
+type ObjectMember struct {
+	Name  string
+	Value any
+}
+
+// marshalEncoder writes values in the ruby Marshal format.
+type marshalEncoder struct {
+	w       *bytes.Buffer
+	symbols map[string]int
+}
+
+// Marshal encodes v in the ruby Marshal format.
+func Marshal(v any) ([]byte, error) {
+	e := &marshalEncoder{w: &bytes.Buffer{}, symbols: map[string]int{}}
+	e.w.WriteByte(marshalMajorVersion)
+	e.w.WriteByte(marshalMinorVersion)
+	if err := e.encode(v); err != nil {
+		return nil, err
+	}
+	return e.w.Bytes(), nil
+}
+
+//nolint:gocognit
+func (e *marshalEncoder) encode(v any) error {
+	switch val := v.(type) {
+	case nil:
+		e.w.WriteByte(typeNil)
+	case bool:
+		if val {
+			e.w.WriteByte(typeTrue)
+		} else {
+			e.w.WriteByte(typeFalse)
+		}
+	case int:
+		e.w.WriteByte(typeFixnum)
+		e.writeInt(val)
+	case string:
+		e.writeString(val)
+	case Symbol:
+		e.writeSymbol(string(val))
+	case []string:
+		e.w.WriteByte(typeArray)
+		e.writeInt(len(val))
+		for _, s := range val {
+			e.writeString(s)
+		}
+	case []any:
+		e.w.WriteByte(typeArray)
+		e.writeInt(len(val))
+		for _, item := range val {
+			if err := e.encode(item); err != nil {
+				return err
+			}
+		}
+	case map[string]string:
+		e.w.WriteByte(typeHash)
+		e.writeInt(len(val))
+		for _, k := range sortedKeys(val) {
+			e.writeString(k)
+			e.writeString(val[k])
+		}
+	case UserMarshal:
+		e.w.WriteByte(typeUserMarshal)
+		e.writeSymbol(val.Name)
+		return e.encode(val.Value)
+	case UserDef:
+		data, err := Marshal(val.Value)
+		if err != nil {
+			return err
+		}
+		e.w.WriteByte(typeUserDef)
+		e.writeSymbol(val.Name)
+		e.writeBytes(data)
+	case Object:
+		e.w.WriteByte(typeObject)
+		e.writeSymbol(val.Name)
+		e.writeInt(len(val.Members))
+		for _, m := range val.Members {
+			e.writeSymbol(m.Name)
+			if err := e.encode(m.Value); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type for ruby marshal: %T", v)
+	}
+	return nil
+}
+
+// writeInt writes an integer in the compact Marshal representation.
+func (e *marshalEncoder) writeInt(n int) {
+	switch {
+	case n == 0:
+		e.w.WriteByte(0)
+	case n > 0 && n < 123:
+		e.w.WriteByte(byte(n + 5))
+	case n < 0 && n > -124:
+		e.w.WriteByte(byte(n - 5))
+	default:
+		var buf [8]byte
+		count := 0
+		for x := n; count < 4; count++ {
+			buf[count] = byte(x)
+			x >>= 8
+			if (n > 0 && x == 0) || (n < 0 && x == -1) {
+				count++
+				break
+			}
+		}
+		if n > 0 {
+			e.w.WriteByte(byte(count))
+		} else {
+			e.w.WriteByte(byte(-count))
+		}
+		e.w.Write(buf[:count])
+	}
+}
+
+func (e *marshalEncoder) writeBytes(b []byte) {
+	e.writeInt(len(b))
+	e.w.Write(b)
+}
+
+// writeString writes an UTF-8 string, which carries its encoding as instance variable E.
+func (e *marshalEncoder) writeString(s string) {
+	e.w.WriteByte(typeIvar)
+	e.w.WriteByte(typeString)
+	e.writeBytes([]byte(s))
+	e.writeInt(1)
+	e.writeSymbol("E")
+	e.w.WriteByte(typeTrue)
+}
+
+// writeSymbol writes a symbol, or a reference to it when it was written before.
+func (e *marshalEncoder) writeSymbol(s string) {
+	if i, ok := e.symbols[s]; ok {
+		e.w.WriteByte(typeSymlink)
+		e.writeInt(i)
+		return
+	}
+	e.symbols[s] = len(e.symbols)
+	e.w.WriteByte(typeSymbol)
+	e.writeBytes([]byte(s))
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	metadataFile = "metadata.gz"
+	maxMetadata  = 10 << 20
+
+	PackageExtension = ".gem"
+	// PlatformRuby is the platform of pure ruby gems, which is omitted from file names and versions.
+	PlatformRuby = "ruby"
+
+	DependencyTypeRuntime = "runtime"
+)
+
+var (
+	ErrInvalidArchive     = errors.New("invalid gem archive")
+	ErrMissingMetadata    = errors.New("metadata.gz not found in gem archive")
+	ErrInvalidPackageName = errors.New("gem name is invalid")
+	ErrInvalidVersion     = errors.New("gem version is invalid")
+	ErrInvalidPlatform    = errors.New("gem platform is invalid")
+
+	namePattern     = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9_.\-]*\z`)
+	versionPattern  = regexp.MustCompile(`\A[0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z\-.]+)?\z`)
+	platformPattern = regexp.MustCompile(`\A[a-zA-Z0-9_.\-]+\z`)
+)
+
+// Package is the specification extracted from a .gem file.
+type Package struct {
+	Name     string
+	Version  string
+	Platform string
+	Metadata rubygemsmetadata.Metadata
+}
+
+// gemspec is the YAML serialization of a Gem::Specification.
+type gemspec struct {
+	Name    string `yaml:"name"`
+	Version struct {
+		Version string `yaml:"version"`
+	} `yaml:"version"`
+	Platform                string            `yaml:"platform"`
+	Summary                 string            `yaml:"summary"`
+	Description             string            `yaml:"description"`
+	Authors                 []string          `yaml:"authors"`
+	Email                   yaml.Node         `yaml:"email"`
+	Homepage                string            `yaml:"homepage"`
+	Licenses                []string          `yaml:"licenses"`
+	Metadata                map[string]string `yaml:"metadata"`
+	RequiredRubyVersion     gemRequirement    `yaml:"required_ruby_version"`
+	RequiredRubygemsVersion gemRequirement    `yaml:"required_rubygems_version"`
+	Dependencies            []struct {
+		Name        string         `yaml:"name"`
+		Requirement gemRequirement `yaml:"requirement"`
+		Type        string         `yaml:"type"`
+	} `yaml:"dependencies"`
+}
+
+// gemRequirement is a Gem::Requirement, a list of [operator, Gem::Version] pairs. The pairs are kept as
+// nodes so versions like 1.10 are not read as numbers.
+type gemRequirement struct {
+	Requirements [][]yaml.Node `yaml:"requirements"`
+}
+
+func (r gemRequirement) toMetadata() []rubygemsmetadata.Requirement {
+	var requirements []rubygemsmetadata.Requirement
+	for _, pair := range r.Requirements {
+		if len(pair) != 2 {
+			continue
+		}
+		var version struct {
+			Version string `yaml:"version"`
+		}
+		if err := pair[1].Decode(&version); err != nil {
+			continue
+		}
+		requirements = append(requirements, rubygemsmetadata.Requirement{
+			Operator: pair[0].Value,
+			Version:  version.Version,
+		})
+	}
+	return requirements
+}
+
+// ParsePackage reads a .gem archive and extracts its specification.
+func ParsePackage(r io.Reader) (*Package, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMissingMetadata
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+		if hdr.Name != metadataFile {
+			continue
+		}
+
+		zr, err := gzip.NewReader(tr)
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+		defer zr.Close()
+		return ParseSpecification(io.LimitReader(zr, maxMetadata))
+	}
+}
+
+// ParseSpecification parses the YAML gem specification stored in the metadata.gz file of a gem.
+func ParseSpecification(r io.Reader) (*Package, error) {
+	spec := gemspec{}
+	if err := yaml.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse gem specification: %w", err)
+	}
+
+	if spec.Platform == "" {
+		spec.Platform = PlatformRuby
+	}
+	if !namePattern.MatchString(spec.Name) {
+		return nil, ErrInvalidPackageName
+	}
+	if !versionPattern.MatchString(spec.Version.Version) {
+		return nil, ErrInvalidVersion
+	}
+	if !platformPattern.MatchString(spec.Platform) {
+		return nil, ErrInvalidPlatform
+	}
+
+	md := rubygemsmetadata.Metadata{
+		Name:                    spec.Name,
+		Version:                 spec.Version.Version,
+		Platform:                spec.Platform,
+		Summary:                 spec.Summary,
+		Description:             spec.Description,
+		Authors:                 spec.Authors,
+		Homepage:                spec.Homepage,
+		Licenses:                spec.Licenses,
+		ProjectURLs:             spec.Metadata,
+		RequiredRubyVersion:     spec.RequiredRubyVersion.toMetadata(),
+		RequiredRubygemsVersion: spec.RequiredRubygemsVersion.toMetadata(),
+	}
+	// email is either a single address or a list.
+	switch spec.Email.Kind {
+	case yaml.ScalarNode:
+		if spec.Email.Value != "" {
+			md.Email = []string{spec.Email.Value}
+		}
+	case yaml.SequenceNode:
+		_ = spec.Email.Decode(&md.Email)
+	default:
+	}
+	for _, d := range spec.Dependencies {
+		md.Dependencies = append(md.Dependencies, rubygemsmetadata.Dependency{
+			Name:         d.Name,
+			Type:         strings.TrimPrefix(d.Type, ":"),
+			Requirements: d.Requirement.toMetadata(),
+		})
+	}
+
+	return &Package{
+		Name:     spec.Name,
+		Version:  spec.Version.Version,
+		Platform: spec.Platform,
+		Metadata: md,
+	}, nil
+}
+
+// IsValidName reports whether name is a valid gem name.
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// ArtifactVersion is the registry version of a gem: platform specific builds are stored as separate versions,
+// following the gem file name convention.
+func ArtifactVersion(version, platform string) string {
+	if platform == "" || platform == PlatformRuby {
+		return version
+	}
+	return version + "-" + platform
+}
+
+// FileName is the canonical file name of a gem.
+func FileName(name, version, platform string) string {
+	return name + "-" + ArtifactVersion(version, platform) + PackageExtension
+}
+
+// ParseFullName splits a gem full name ({name}-{version}[-{platform}]) into its name and artifact version.
+// The version is the first dash separated part starting with a digit.
+func ParseFullName(fullName string) (string, string, error) {
+	parts := strings.Split(fullName, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "" || parts[i][0] < '0' || parts[i][0] > '9' {
+			continue
+		}
+		name := strings.Join(parts[:i], "-")
+		if !namePattern.MatchString(name) {
+			break
+		}
+		return name, strings.Join(parts[i:], "-"), nil
+	}
+	return "", "", fmt.Errorf("invalid gem name: %s", fullName)
+}
+
+// ParseFileName splits a gem file name into its name and artifact version.
+func ParseFileName(fileName string) (string, string, error) {
+	fullName, found := strings.CutSuffix(fileName, PackageExtension)
+	if !found {
+		return "", "", fmt.Errorf("invalid gem file name: %s", fileName)
+	}
+	return ParseFullName(fullName)
+}
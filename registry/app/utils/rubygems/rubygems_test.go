@@ -0,0 +1,291 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rubygems
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"testing"
+	"time"
+
+	rubygemsmetadata "github.com/harness/gitness/registry/app/metadata/rubygems"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `--- !ruby/object:Gem::Specification
+name: hello-world
+version: !ruby/object:Gem::Version
+  version: 1.10.0
+platform: ruby
+authors:
+- Jane Doe
+email: jane@example.com
+summary: example gem
+description: Prints a friendly greeting.
+homepage: https://example.com/hello
+licenses:
+- MIT
+metadata:
+  source_code_uri: https://example.com/hello/src
+required_ruby_version: !ruby/object:Gem::Requirement
+  requirements:
+  - - ">="
+    - !ruby/object:Gem::Version
+      version: 2.7.0
+required_rubygems_version: !ruby/object:Gem::Requirement
+  requirements:
+  - - ">="
+    - !ruby/object:Gem::Version
+      version: '0'
+dependencies:
+- !ruby/object:Gem::Dependency
+  name: rack
+  requirement: !ruby/object:Gem::Requirement
+    requirements:
+    - - "~>"
+      - !ruby/object:Gem::Version
+        version: '3.0'
+    - - "<"
+      - !ruby/object:Gem::Version
+        version: '4'
+  type: :runtime
+  prerelease: false
+- !ruby/object:Gem::Dependency
+  name: rspec
+  requirement: !ruby/object:Gem::Requirement
+    requirements:
+    - - ">="
+      - !ruby/object:Gem::Version
+        version: '0'
+  type: :development
+  prerelease: false
+`
+
+func buildGem(t *testing.T, spec string) []byte {
+	t.Helper()
+
+	var metadata bytes.Buffer
+	zw := gzip.NewWriter(&metadata)
+	_, err := zw.Write([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var gem bytes.Buffer
+	tw := tar.NewWriter(&gem)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{
+		{"metadata.gz", metadata.Bytes()},
+		{"data.tar.gz", []byte("data")},
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: f.name, Mode: 0o644, Size: int64(len(f.content)), Typeflag: tar.TypeReg,
+		}))
+		_, err = tw.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return gem.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	p, err := ParsePackage(bytes.NewReader(buildGem(t, testSpec)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello-world", p.Name)
+	assert.Equal(t, "1.10.0", p.Version)
+	assert.Equal(t, PlatformRuby, p.Platform)
+	assert.Equal(t, []string{"jane@example.com"}, p.Metadata.Email)
+	assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+	assert.Equal(t, "https://example.com/hello/src", p.Metadata.ProjectURLs["source_code_uri"])
+	assert.Equal(t, []rubygemsmetadata.Requirement{{Operator: ">=", Version: "2.7.0"}}, p.Metadata.RequiredRubyVersion)
+	assert.Equal(t, []rubygemsmetadata.Dependency{
+		{
+			Name: "rack",
+			Type: DependencyTypeRuntime,
+			Requirements: []rubygemsmetadata.Requirement{
+				{Operator: "~>", Version: "3.0"},
+				{Operator: "<", Version: "4"},
+			},
+		},
+		{
+			Name:         "rspec",
+			Type:         "development",
+			Requirements: []rubygemsmetadata.Requirement{{Operator: ">=", Version: "0"}},
+		},
+	}, p.Metadata.Dependencies)
+}
+
+func TestParsePackageErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr error
+	}{
+		{
+			name:    "missing metadata",
+			content: buildGem(t, testSpec)[512:],
+			wantErr: ErrInvalidArchive,
+		},
+		{
+			name: "invalid name",
+			content: buildGem(t, "--- !ruby/object:Gem::Specification\nname: -hello\n"+
+				"version: !ruby/object:Gem::Version\n  version: 1.0.0\n"),
+			wantErr: ErrInvalidPackageName,
+		},
+		{
+			name:    "missing version",
+			content: buildGem(t, "--- !ruby/object:Gem::Specification\nname: hello\n"),
+			wantErr: ErrInvalidVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePackage(bytes.NewReader(tt.content))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName    string
+		wantName    string
+		wantVersion string
+		wantErr     bool
+	}{
+		{fileName: "rack-3.0.8.gem", wantName: "rack", wantVersion: "3.0.8"},
+		{fileName: "net-http-0.4.1.gem", wantName: "net-http", wantVersion: "0.4.1"},
+		{fileName: "nokogiri-1.16.0-x86_64-linux.gem", wantName: "nokogiri", wantVersion: "1.16.0-x86_64-linux"},
+		{fileName: "rack.gem", wantErr: true},
+		{fileName: "rack-3.0.8.zip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			name, version, err := ParseFileName(tt.fileName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+	assert.Equal(t, "nokogiri-1.16.0-x86_64-linux.gem", FileName("nokogiri", "1.16.0", "x86_64-linux"))
+	assert.Equal(t, "rack-3.0.8.gem", FileName("rack", "3.0.8", PlatformRuby))
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "array", value: []any{"a", 1, nil, Symbol("b")}, want: "\x04\b[\tI\"\x06a\x06:\x06ETi\x060:\x06b"},
+		{name: "symlink", value: []any{Symbol("a"), Symbol("a")}, want: "\x04\b[\a:\x06a;\x00"},
+		{name: "positive int", value: 300, want: "\x04\bi\x02,\x01"},
+		{name: "negative int", value: -300, want: "\x04\bi\xfe\xd4\xfe"},
+		{name: "small negative int", value: -1, want: "\x04\bi\xfa"},
+		{
+			name:  "user marshal",
+			value: UserMarshal{Name: "Gem::Version", Value: []any{"1.0"}},
+			want:  "\x04\bU:\x11Gem::Version[\x06I\"\b1.0\x06:\x06ET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(tt.want), b)
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("1.0", "1.0.0"))
+	assert.Equal(t, -1, CompareVersions("1.9.0", "1.10.0"))
+	assert.Equal(t, -1, CompareVersions("2.0.0.rc1", "2.0.0"))
+	assert.Equal(t, 1, CompareVersions("2.0.0.rc2", "2.0.0.rc1"))
+	assert.Equal(t, 1, CompareVersions("2.0.0", "1.99"))
+}
+
+func TestLatestSpecs(t *testing.T) {
+	specs := []Spec{
+		{Name: "rack", Version: "3.0.0", Platform: PlatformRuby},
+		{Name: "rack", Version: "3.0.10", Platform: PlatformRuby},
+		{Name: "rack", Version: "3.1.0.beta1", Platform: PlatformRuby},
+		{Name: "nokogiri", Version: "1.16.0", Platform: "java"},
+		{Name: "nokogiri", Version: "1.15.0", Platform: PlatformRuby},
+	}
+	assert.Equal(t, []Spec{
+		{Name: "nokogiri", Version: "1.15.0", Platform: PlatformRuby},
+		{Name: "nokogiri", Version: "1.16.0", Platform: "java"},
+		{Name: "rack", Version: "3.0.10", Platform: PlatformRuby},
+	}, LatestSpecs(specs))
+}
+
+func TestBuildSpecsIndex(t *testing.T) {
+	index, err := BuildSpecsIndex([]Spec{{Name: "rack", Version: "3.0.8", Platform: PlatformRuby}})
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(index))
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "\x04\b[\x06[\bI\"\track\x06:\x06ETU:\x11Gem::Version[\x06I\"\n3.0.8\x06;\x00TI\"\truby\x06;\x00T",
+		string(data))
+}
+
+func TestBuildQuickSpec(t *testing.T) {
+	p, err := ParseSpecification(bytes.NewReader([]byte(testSpec)))
+	require.NoError(t, err)
+	spec, err := BuildQuickSpec(p.Metadata)
+	require.NoError(t, err)
+
+	zr, err := zlib.NewReader(bytes.NewReader(spec))
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("\x04\bu:\x17Gem::Specification")))
+	assert.Contains(t, string(data), "hello-world")
+	assert.Contains(t, string(data), "Gem::Dependency")
+}
+
+func TestCompactIndex(t *testing.T) {
+	p, err := ParseSpecification(bytes.NewReader([]byte(testSpec)))
+	require.NoError(t, err)
+
+	line := InfoLine(p.Metadata, "abc123")
+	assert.Equal(t, "1.10.0 rack:~> 3.0&< 4|checksum:abc123,ruby:>= 2.7.0,rubygems:>= 0", line)
+
+	info := BuildInfo([]string{line})
+	assert.Equal(t, "---\n"+line+"\n", string(info))
+
+	versions := BuildVersions(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), []VersionsEntry{
+		{Name: "hello-world", Versions: []string{"1.10.0"}, Info: []byte("---\n")},
+	})
+	assert.Equal(t, "created_at: 2024-01-02T03:04:05Z\n---\nhello-world 1.10.0 6105347ebb9825ac754615ca55ff3b0c\n",
+		string(versions))
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// FileChecksum is the SHA256 checksum of a provider package.
+type FileChecksum struct {
+	FileName string
+	Sha256   string
+}
+
+// BuildSHA256Sums renders the checksums file of a provider version in the sha256sum format.
+func BuildSHA256Sums(files []FileChecksum) []byte {
+	sorted := make([]FileChecksum, len(files))
+	copy(sorted, files)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FileName < sorted[j].FileName
+	})
+
+	var b bytes.Buffer
+	for _, f := range sorted {
+		fmt.Fprintf(&b, "%s  %s\n", f.Sha256, f.FileName)
+	}
+	return b.Bytes()
+}
+
+// SignSHA256Sums creates the binary detached signature Terraform verifies the checksums file with.
+func SignSHA256Sums(entity *openpgp.Entity, sums []byte) ([]byte, error) {
+	var signature bytes.Buffer
+	if err := openpgp.DetachSign(&signature, entity, bytes.NewReader(sums), nil); err != nil {
+		return nil, fmt.Errorf("failed to sign checksums: %w", err)
+	}
+	return signature.Bytes(), nil
+}
+
+// KeyID returns the key ID Terraform expects next to the public key of a signing key.
+func KeyID(entity *openpgp.Entity) string {
+	return entity.PrimaryKey.KeyIdString()
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	providerFilePrefix = "terraform-provider-"
+	providerExtension  = ".zip"
+	sha256SumsSuffix   = "_SHA256SUMS"
+	signatureExtension = ".sig"
+)
+
+var (
+	ErrInvalidNamespace = errors.New("terraform namespace is invalid")
+	ErrInvalidName      = errors.New("terraform name is invalid")
+	ErrInvalidSystem    = errors.New("terraform module system is invalid")
+	ErrInvalidPlatform  = errors.New("terraform provider platform is invalid")
+	ErrInvalidProtocol  = errors.New("terraform provider protocol is invalid")
+	ErrInvalidVersion   = errors.New("terraform version is not a valid semantic version")
+	ErrInvalidArchive   = errors.New("terraform module archive must be a .zip, .tar.gz or .tgz file")
+
+	// ModuleArchiveExtensions are the archive formats Terraform unpacks module downloads from.
+	ModuleArchiveExtensions = []string{".tar.gz", ".tgz", ".zip"}
+	// DefaultProtocols are the plugin protocols assumed for providers uploaded without any.
+	DefaultProtocols = []string{"5.0"}
+
+	namePattern         = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z\-_]{0,62}[0-9A-Za-z])?\z`)
+	systemPattern       = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z\-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z_]{1,32}\z`)
+	protocolPattern     = regexp.MustCompile(`\A[0-9]+\.[0-9]+\z`)
+)
+
+// NormalizeVersion validates a semantic version and strips the optional v prefix.
+func NormalizeVersion(version string) (string, error) {
+	v, err := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return "", ErrInvalidVersion
+	}
+	return v.String(), nil
+}
+
+// CompareVersions compares two semantic versions, ordering invalid versions first.
+func CompareVersions(a, b string) int {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	default:
+		return va.Compare(vb)
+	}
+}
+
+// ModuleImage is the artifact name of a module: {namespace}/{name}/{system}.
+func ModuleImage(namespace, name, system string) (string, error) {
+	if !namePattern.MatchString(namespace) {
+		return "", ErrInvalidNamespace
+	}
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidName
+	}
+	if !systemPattern.MatchString(system) {
+		return "", ErrInvalidSystem
+	}
+	return namespace + "/" + name + "/" + system, nil
+}
+
+// ProviderImage is the artifact name of a provider: {namespace}/{type}.
+func ProviderImage(namespace, providerType string) (string, error) {
+	if !namePattern.MatchString(namespace) {
+		return "", ErrInvalidNamespace
+	}
+	if !providerTypePattern.MatchString(providerType) {
+		return "", ErrInvalidName
+	}
+	return namespace + "/" + providerType, nil
+}
+
+// ModuleArchiveExtension returns the archive extension of an uploaded module file.
+func ModuleArchiveExtension(fileName string) (string, error) {
+	for _, ext := range ModuleArchiveExtensions {
+		if strings.HasSuffix(strings.ToLower(fileName), ext) {
+			return ext, nil
+		}
+	}
+	return "", ErrInvalidArchive
+}
+
+// ModuleFileName is the file name a module archive is stored with: {name}-{system}-{version}{ext}.
+func ModuleFileName(name, system, version, ext string) string {
+	return name + "-" + system + "-" + version + ext
+}
+
+// ValidatePlatform checks the os and architecture of a provider package.
+func ValidatePlatform(os, arch string) error {
+	if !platformPattern.MatchString(os) || !platformPattern.MatchString(arch) {
+		return ErrInvalidPlatform
+	}
+	return nil
+}
+
+// ParseProtocols parses a comma separated list of plugin protocol versions, e.g. 5.0,6.0.
+func ParseProtocols(protocols string) ([]string, error) {
+	if protocols == "" {
+		return DefaultProtocols, nil
+	}
+	parts := strings.Split(protocols, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+		if !protocolPattern.MatchString(parts[i]) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProtocol, p)
+		}
+	}
+	return parts, nil
+}
+
+// ProviderFileName is the file name of a provider package, following the naming of the HashiCorp releases:
+// terraform-provider-{type}_{version}_{os}_{arch}.zip.
+func ProviderFileName(providerType, version, os, arch string) string {
+	return providerFilePrefix + providerType + "_" + version + "_" + os + "_" + arch + providerExtension
+}
+
+// ParseProviderFileName returns the platform of a provider package file name.
+func ParseProviderFileName(providerType, version, fileName string) (string, string, bool) {
+	platform, found := strings.CutPrefix(fileName, providerFilePrefix+providerType+"_"+version+"_")
+	if !found {
+		return "", "", false
+	}
+	platform, found = strings.CutSuffix(platform, providerExtension)
+	if !found {
+		return "", "", false
+	}
+	// os never contains an underscore, the architecture may, e.g. linux_arm_v7.
+	os, arch, found := strings.Cut(platform, "_")
+	if !found || ValidatePlatform(os, arch) != nil {
+		return "", "", false
+	}
+	return os, arch, true
+}
+
+// SHA256SumsFileName is the file name of the checksums of all packages of a provider version.
+func SHA256SumsFileName(providerType, version string) string {
+	return providerFilePrefix + providerType + "_" + version + sha256SumsSuffix
+}
+
+// SignatureFileName is the file name of the detached signature of the checksums of a provider version.
+func SignatureFileName(providerType, version string) string {
+	return SHA256SumsFileName(providerType, version) + signatureExtension
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// DiscoveryPath is the path Terraform reads the service discovery document of a host from.
+	DiscoveryPath = "/.well-known/terraform.json"
+
+	modulesPath   = "/v1/modules/"
+	providersPath = "/v1/providers/"
+)
+
+// ServiceDiscovery is the service discovery document, pointing Terraform to the module and provider registry
+// protocol endpoints.
+type ServiceDiscovery struct {
+	ModulesV1   string `json:"modules.v1"`
+	ProvidersV1 string `json:"providers.v1"`
+}
+
+// BuildServiceDiscovery returns the discovery document of a registry served below baseURL.
+func BuildServiceDiscovery(baseURL string) ServiceDiscovery {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return ServiceDiscovery{
+		ModulesV1:   baseURL + modulesPath,
+		ProvidersV1: baseURL + providersPath,
+	}
+}
+
+// ModuleVersions is the response of the module versions endpoint.
+type ModuleVersions struct {
+	Modules []ModuleVersionList `json:"modules"`
+}
+
+type ModuleVersionList struct {
+	Versions []ModuleVersion `json:"versions"`
+}
+
+type ModuleVersion struct {
+	Version string `json:"version"`
+}
+
+// BuildModuleVersions lists the versions of a module, newest first.
+func BuildModuleVersions(versions []string) ModuleVersions {
+	sorted := sortVersions(versions)
+	list := ModuleVersionList{Versions: make([]ModuleVersion, 0, len(sorted))}
+	for _, v := range sorted {
+		list.Versions = append(list.Versions, ModuleVersion{Version: v})
+	}
+	return ModuleVersions{Modules: []ModuleVersionList{list}}
+}
+
+// ProviderVersions is the response of the provider versions endpoint.
+type ProviderVersions struct {
+	Versions []ProviderVersion `json:"versions"`
+}
+
+type ProviderVersion struct {
+	Version   string     `json:"version"`
+	Protocols []string   `json:"protocols"`
+	Platforms []Platform `json:"platforms"`
+}
+
+type Platform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+// SortProviderVersions orders provider versions newest first and their platforms by os and architecture.
+func SortProviderVersions(versions []ProviderVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return CompareVersions(versions[i].Version, versions[j].Version) > 0
+	})
+	for _, v := range versions {
+		sort.Slice(v.Platforms, func(i, j int) bool {
+			if v.Platforms[i].OS != v.Platforms[j].OS {
+				return v.Platforms[i].OS < v.Platforms[j].OS
+			}
+			return v.Platforms[i].Arch < v.Platforms[j].Arch
+		})
+	}
+}
+
+// ProviderPackage is the response of the provider download endpoint.
+type ProviderPackage struct {
+	Protocols           []string    `json:"protocols"`
+	OS                  string      `json:"os"`
+	Arch                string      `json:"arch"`
+	Filename            string      `json:"filename"`
+	DownloadURL         string      `json:"download_url"`
+	SHASumsURL          string      `json:"shasums_url"`
+	SHASumsSignatureURL string      `json:"shasums_signature_url"`
+	SHASum              string      `json:"shasum"`
+	SigningKeys         SigningKeys `json:"signing_keys"`
+}
+
+type SigningKeys struct {
+	GPGPublicKeys []GPGPublicKey `json:"gpg_public_keys"`
+}
+
+type GPGPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+func sortVersions(versions []string) []string {
+	sorted := make([]string, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return CompareVersions(sorted[i], sorted[j]) > 0
+	})
+	return sorted
+}
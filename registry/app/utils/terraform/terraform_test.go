@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+		valid    bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"v1.2.3", "1.2.3", true},
+		{"1.0.0-beta.1", "1.0.0-beta.1", true},
+		{"1.2", "", false},
+		{"latest", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := NormalizeVersion(tt.version)
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestModuleImage(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		module    string
+		system    string
+		expected  string
+		err       error
+	}{
+		{"valid", "hashicorp", "consul", "aws", "hashicorp/consul/aws", nil},
+		{"underscore", "my-org", "vpc_peering", "azurerm", "my-org/vpc_peering/azurerm", nil},
+		{"invalid namespace", "-org", "consul", "aws", "", ErrInvalidNamespace},
+		{"invalid name", "hashicorp", "consul/x", "aws", "", ErrInvalidName},
+		{"upper case system", "hashicorp", "consul", "AWS", "", ErrInvalidSystem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := ModuleImage(tt.namespace, tt.module, tt.system)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, image)
+		})
+	}
+}
+
+func TestProviderImage(t *testing.T) {
+	image, err := ProviderImage("hashicorp", "random")
+	require.NoError(t, err)
+	assert.Equal(t, "hashicorp/random", image)
+
+	_, err = ProviderImage("hashicorp", "Random")
+	assert.ErrorIs(t, err, ErrInvalidName)
+}
+
+func TestModuleArchiveExtension(t *testing.T) {
+	for fileName, expected := range map[string]string{
+		"module.tar.gz": ".tar.gz",
+		"module.TGZ":    ".tgz",
+		"module.zip":    ".zip",
+	} {
+		ext, err := ModuleArchiveExtension(fileName)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ext)
+	}
+
+	_, err := ModuleArchiveExtension("module.tar")
+	assert.ErrorIs(t, err, ErrInvalidArchive)
+	assert.Equal(t, "consul-aws-1.0.0.tar.gz", ModuleFileName("consul", "aws", "1.0.0", ".tar.gz"))
+}
+
+func TestProviderFileNames(t *testing.T) {
+	fileName := ProviderFileName("random", "3.1.0", "linux", "arm_v7")
+	assert.Equal(t, "terraform-provider-random_3.1.0_linux_arm_v7.zip", fileName)
+
+	os, arch, ok := ParseProviderFileName("random", "3.1.0", fileName)
+	assert.True(t, ok)
+	assert.Equal(t, "linux", os)
+	assert.Equal(t, "arm_v7", arch)
+
+	_, _, ok = ParseProviderFileName("random", "3.1.0", SHA256SumsFileName("random", "3.1.0"))
+	assert.False(t, ok)
+	_, _, ok = ParseProviderFileName("random", "3.1.0", "terraform-provider-random_3.1.0_linux.zip")
+	assert.False(t, ok)
+
+	assert.Equal(t, "terraform-provider-random_3.1.0_SHA256SUMS.sig", SignatureFileName("random", "3.1.0"))
+}
+
+func TestParseProtocols(t *testing.T) {
+	protocols, err := ParseProtocols("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProtocols, protocols)
+
+	protocols, err = ParseProtocols("5.0, 6.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+
+	_, err = ParseProtocols("5")
+	assert.ErrorIs(t, err, ErrInvalidProtocol)
+}
+
+func TestBuildServiceDiscovery(t *testing.T) {
+	assert.Equal(t, ServiceDiscovery{
+		ModulesV1:   "https://example.com/pkg/root/tf/terraform/v1/modules/",
+		ProvidersV1: "https://example.com/pkg/root/tf/terraform/v1/providers/",
+	}, BuildServiceDiscovery("https://example.com/pkg/root/tf/terraform/"))
+}
+
+func TestBuildModuleVersions(t *testing.T) {
+	versions := BuildModuleVersions([]string{"1.2.0", "1.10.0", "1.10.0-rc.1", "0.9.1"})
+	require.Len(t, versions.Modules, 1)
+	assert.Equal(t, []ModuleVersion{
+		{Version: "1.10.0"}, {Version: "1.10.0-rc.1"}, {Version: "1.2.0"}, {Version: "0.9.1"},
+	}, versions.Modules[0].Versions)
+}
+
+func TestSortProviderVersions(t *testing.T) {
+	versions := []ProviderVersion{
+		{Version: "1.0.0", Platforms: []Platform{{"linux", "arm64"}, {"darwin", "arm64"}, {"linux", "amd64"}}},
+		{Version: "2.0.0"},
+	}
+	SortProviderVersions(versions)
+	assert.Equal(t, "2.0.0", versions[0].Version)
+	assert.Equal(t, []Platform{{"darwin", "arm64"}, {"linux", "amd64"}, {"linux", "arm64"}}, versions[1].Platforms)
+}
+
+func TestSHA256Sums(t *testing.T) {
+	sums := BuildSHA256Sums([]FileChecksum{
+		{FileName: "terraform-provider-random_3.1.0_linux_amd64.zip", Sha256: "bbbb"},
+		{FileName: "terraform-provider-random_3.1.0_darwin_arm64.zip", Sha256: "aaaa"},
+	})
+	assert.Equal(t, "aaaa  terraform-provider-random_3.1.0_darwin_arm64.zip\n"+
+		"bbbb  terraform-provider-random_3.1.0_linux_amd64.zip\n", string(sums))
+
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	signature, err := SignSHA256Sums(entity, sums)
+	require.NoError(t, err)
+
+	_, err = openpgp.CheckDetachedSignature(openpgp.EntityList{entity}, bytes.NewReader(sums),
+		bytes.NewReader(signature), nil)
+	assert.NoError(t, err)
+	assert.Len(t, KeyID(entity), 16)
+}
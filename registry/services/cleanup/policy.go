@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// candidate is an artifact version that is selected for deletion by a cleanup policy.
+type candidate struct {
+	Package string `json:"package"`
+	Version string `json:"version"`
+	Policy  string `json:"policy"`
+}
+
+// selectVersions returns the versions of a single package that are deleted by the provided policies.
+// A version is deleted if any policy matches it, it's older than the policy's expiry time
+// and it wasn't downloaded recently, unless it's among the most recent versions kept by any matching policy.
+func selectVersions(
+	policies []types.CleanupPolicy,
+	pkg string,
+	versions []*types.ArtifactCleanupInfo,
+	now time.Time,
+) []candidate {
+	// most recent versions first, the id breaks ties between versions pushed at the same time.
+	sorted := make([]*types.ArtifactCleanupInfo, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].UpdatedAt.Equal(sorted[j].UpdatedAt) {
+			return sorted[i].UpdatedAt.After(sorted[j].UpdatedAt)
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	// the versions kept by a policy are protected from all other policies as well.
+	kept := make(map[int64]bool)
+	for _, policy := range policies {
+		if !matchesPrefix(pkg, policy.PackagePrefix) {
+			continue
+		}
+
+		keep := policy.KeepLast
+		for _, v := range sorted {
+			if keep <= 0 {
+				break
+			}
+			if matchesPrefix(v.Version, policy.VersionPrefix) {
+				kept[v.ID] = true
+				keep--
+			}
+		}
+	}
+
+	selected := make(map[int64]bool)
+	var candidates []candidate
+	for _, policy := range policies {
+		if policy.ExpiryTime <= 0 || !matchesPrefix(pkg, policy.PackagePrefix) {
+			continue
+		}
+
+		expiredBefore := now.Add(-time.Duration(policy.ExpiryTime) * time.Millisecond)
+		downloadedAfter := now.Add(-time.Duration(policy.DownloadedWithin) * time.Millisecond)
+
+		for _, v := range sorted {
+			if !matchesPrefix(v.Version, policy.VersionPrefix) || kept[v.ID] {
+				continue
+			}
+			if !v.UpdatedAt.Before(expiredBefore) {
+				continue
+			}
+			if policy.DownloadedWithin > 0 && v.LastDownloadedAt.After(downloadedAfter) {
+				continue
+			}
+			if selected[v.ID] {
+				continue
+			}
+
+			selected[v.ID] = true
+			candidates = append(candidates, candidate{
+				Package: pkg,
+				Version: v.Version,
+				Policy:  policy.Name,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// matchesPrefix returns true if there are no prefixes or the value starts with any of them.
+func matchesPrefix(value string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
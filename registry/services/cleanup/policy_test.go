@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectVersions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	days := func(n int) int64 { return (time.Duration(n) * day).Milliseconds() }
+
+	versions := []*types.ArtifactCleanupInfo{
+		{ID: 1, Version: "1.0.0", UpdatedAt: now.Add(-40 * day)},
+		{ID: 2, Version: "1.1.0", UpdatedAt: now.Add(-35 * day), LastDownloadedAt: now.Add(-2 * day)},
+		{ID: 3, Version: "2.0.0-rc1", UpdatedAt: now.Add(-32 * day)},
+		{ID: 4, Version: "2.0.0", UpdatedAt: now.Add(-31 * day)},
+		{ID: 5, Version: "2.1.0", UpdatedAt: now.Add(-1 * day)},
+	}
+
+	tests := []struct {
+		name     string
+		pkg      string
+		policies []types.CleanupPolicy
+		expected []string
+	}{
+		{
+			name:     "expired versions are deleted",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: days(30)}},
+			expected: []string{"2.0.0", "2.0.0-rc1", "1.1.0", "1.0.0"},
+		},
+		{
+			name:     "policy without expiry time deletes nothing",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p"}},
+			expected: nil,
+		},
+		{
+			name:     "most recent versions are kept",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: days(30), KeepLast: 3}},
+			expected: []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name:     "recently downloaded versions are kept",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: days(30), DownloadedWithin: days(7)}},
+			expected: []string{"2.0.0", "2.0.0-rc1", "1.0.0"},
+		},
+		{
+			name:     "version prefix",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: days(30), VersionPrefix: []string{"1."}}},
+			expected: []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name: "keep last counts matching versions only",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "p", ExpiryTime: days(30), KeepLast: 1, VersionPrefix: []string{"2."}},
+			},
+			expected: []string{"2.0.0", "2.0.0-rc1"},
+		},
+		{
+			name:     "package prefix doesn't match",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: days(30), PackagePrefix: []string{"lib-"}}},
+			expected: nil,
+		},
+		{
+			name: "versions matched by several policies are deleted once",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "a", ExpiryTime: days(30), VersionPrefix: []string{"1."}},
+				{Name: "b", ExpiryTime: days(36)},
+			},
+			expected: []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name: "versions kept by a policy aren't deleted by other policies",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "a", ExpiryTime: days(30), KeepLast: 2, VersionPrefix: []string{"1."}},
+				{Name: "b", ExpiryTime: days(30)},
+			},
+			expected: []string{"2.0.0", "2.0.0-rc1"},
+		},
+		{
+			name: "policy without expiry time keeps versions",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "a", KeepLast: 1, VersionPrefix: []string{"2.0.0-"}},
+				{Name: "b", ExpiryTime: days(30)},
+			},
+			expected: []string{"2.0.0", "1.1.0", "1.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			candidates := selectVersions(test.policies, test.pkg, versions, now)
+
+			var got []string
+			for _, c := range candidates {
+				assert.Equal(t, test.pkg, c.Package)
+				got = append(got, c.Version)
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
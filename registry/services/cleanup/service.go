@@ -0,0 +1,373 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/gc"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:registry:cleanup-policies"
+	jobMaxDuration = 30 * time.Minute
+
+	artifactsBatchSize = 500
+	// maxReportedVersions limits the number of versions listed per registry in the job result.
+	maxReportedVersions = 100
+	// gcReviewWindow is how far ahead blob reviews are pulled in once their last reference was deleted.
+	gcReviewWindow = 48 * time.Hour
+)
+
+type Config struct {
+	Enabled bool
+	// DryRun only reports the versions that would be deleted.
+	DryRun bool
+	Cron   string
+}
+
+// ArtifactVersionDeleter deletes a single artifact version.
+// It's implemented by the registry metadata API, so deletions done by cleanup policies
+// behave exactly like deletions done by users (webhooks, audit logs, index rebuilds).
+type ArtifactVersionDeleter interface {
+	DeleteArtifactVersion(
+		ctx context.Context,
+		r artifact.DeleteArtifactVersionRequestObject,
+	) (artifact.DeleteArtifactVersionResponseObject, error)
+}
+
+// Service periodically applies the cleanup policies of all registries.
+type Service struct {
+	config    Config
+	scheduler *job.Scheduler
+	executor  *job.Executor
+	tx        dbtx.Transactor
+
+	registryDao      store.RegistryRepository
+	cleanupPolicyDao store.CleanupPolicyRepository
+	artifactDao      store.ArtifactRepository
+	manifestDao      store.ManifestRepository
+	layerDao         store.LayerRepository
+	spaceFinder      refcache.SpaceFinder
+	gcService        gc.Service
+	deleter          ArtifactVersionDeleter
+}
+
+func NewService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	cleanupPolicyDao store.CleanupPolicyRepository,
+	artifactDao store.ArtifactRepository,
+	manifestDao store.ManifestRepository,
+	layerDao store.LayerRepository,
+	spaceFinder refcache.SpaceFinder,
+	gcService gc.Service,
+	deleter ArtifactVersionDeleter,
+) *Service {
+	return &Service{
+		config:    config,
+		scheduler: scheduler,
+		executor:  executor,
+		tx:        tx,
+
+		registryDao:      registryDao,
+		cleanupPolicyDao: cleanupPolicyDao,
+		artifactDao:      artifactDao,
+		manifestDao:      manifestDao,
+		layerDao:         layerDao,
+		spaceFinder:      spaceFinder,
+		gcService:        gcService,
+		deleter:          deleter,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for registry cleanup policies: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.Cron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule registry cleanup policies job: %w", err)
+	}
+
+	return nil
+}
+
+// report summarizes a single run of the registry cleanup policies.
+type report struct {
+	DryRun     bool              `json:"dry_run"`
+	Registries []*registryReport `json:"registries,omitempty"`
+}
+
+type registryReport struct {
+	Registry string      `json:"registry"`
+	Matched  int         `json:"matched"`
+	Deleted  int         `json:"deleted"`
+	Failed   int         `json:"failed"`
+	Versions []candidate `json:"versions,omitempty"`
+}
+
+// Handle applies the cleanup policies of all registries and returns a JSON report of the run.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	// deletions are executed by the system, the same way as if they were triggered through the API.
+	ctx = request.WithAuthSession(ctx, bootstrap.NewSystemServiceSession())
+
+	registryIDs, err := s.cleanupPolicyDao.ListRegistryIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list registries with cleanup policies: %w", err)
+	}
+
+	r := &report{DryRun: s.config.DryRun}
+	for _, registryID := range registryIDs {
+		regReport, err := s.cleanupRegistry(ctx, registryID)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply cleanup policies of registry %d: %w", registryID, err)
+		}
+		if regReport.Matched > 0 {
+			r.Registries = append(r.Registries, regReport)
+		}
+	}
+
+	result, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal registry cleanup report: %w", err)
+	}
+
+	return string(result), nil
+}
+
+func (s *Service) cleanupRegistry(ctx context.Context, registryID int64) (*registryReport, error) {
+	registry, err := s.registryDao.Get(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry: %w", err)
+	}
+
+	space, err := s.spaceFinder.FindByID(ctx, registry.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space of registry: %w", err)
+	}
+	registryRef := space.Path + "/" + registry.Name
+
+	policies, err := s.cleanupPolicyDao.GetByRegistryID(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup policies: %w", err)
+	}
+	if policies == nil || len(*policies) == 0 {
+		return &registryReport{Registry: registryRef}, nil
+	}
+	// policies are evaluated in a stable order, so the reported policy of a version doesn't change between runs.
+	sort.Slice(*policies, func(i, j int) bool {
+		return (*policies)[i].Name < (*policies)[j].Name
+	})
+
+	versionsByPackage, err := s.listVersions(ctx, registryID)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]string, 0, len(versionsByPackage))
+	for pkg := range versionsByPackage {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	now := time.Now()
+	regReport := &registryReport{Registry: registryRef}
+	for _, pkg := range packages {
+		candidates := selectVersions(*policies, pkg, versionsByPackage[pkg], now)
+		for _, c := range candidates {
+			regReport.Matched++
+			if len(regReport.Versions) < maxReportedVersions {
+				regReport.Versions = append(regReport.Versions, c)
+			}
+
+			if s.config.DryRun {
+				log.Ctx(ctx).Info().Msgf("registry cleanup policy %q would delete %s:%s of registry %s",
+					c.Policy, c.Package, c.Version, registryRef)
+				continue
+			}
+
+			if err := s.deleteVersion(ctx, registry, registryRef, c); err != nil {
+				regReport.Failed++
+				log.Ctx(ctx).Warn().Err(err).Msgf("registry cleanup policy %q failed to delete %s:%s of registry %s",
+					c.Policy, c.Package, c.Version, registryRef)
+				continue
+			}
+
+			regReport.Deleted++
+		}
+	}
+
+	if regReport.Matched > 0 {
+		log.Ctx(ctx).Info().Msgf("registry cleanup policies of %s matched %d versions (deleted: %d, failed: %d)",
+			registryRef, regReport.Matched, regReport.Deleted, regReport.Failed)
+	}
+
+	return regReport, nil
+}
+
+// listVersions returns all artifact versions of the registry grouped by package.
+func (s *Service) listVersions(
+	ctx context.Context,
+	registryID int64,
+) (map[string][]*types.ArtifactCleanupInfo, error) {
+	versionsByPackage := make(map[string][]*types.ArtifactCleanupInfo)
+	lastArtifactID := int64(0)
+	for {
+		versions, err := s.artifactDao.GetArtifactsForCleanup(ctx, registryID, artifactsBatchSize, lastArtifactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifact versions: %w", err)
+		}
+
+		for _, v := range versions {
+			versionsByPackage[v.ImageName] = append(versionsByPackage[v.ImageName], v)
+			lastArtifactID = v.ID
+		}
+
+		if len(versions) < artifactsBatchSize {
+			return versionsByPackage, nil
+		}
+	}
+}
+
+func (s *Service) deleteVersion(
+	ctx context.Context,
+	registry *types.Registry,
+	registryRef string,
+	c candidate,
+) error {
+	isOCI := registry.PackageType == artifact.PackageTypeDOCKER || registry.PackageType == artifact.PackageTypeHELM
+
+	// the blobs are collected before the manifest is gone, so they can be handed over to the garbage collector.
+	var blobIDs []int64
+	if isOCI {
+		var err error
+		blobIDs, err = s.manifestBlobIDs(ctx, registry.ID, c.Package, c.Version)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.deleter.DeleteArtifactVersion(ctx, artifact.DeleteArtifactVersionRequestObject{
+		RegistryRef: artifact.RegistryRefPathParam(registryRef),
+		Artifact:    artifact.ArtifactPathParam(c.Package),
+		Version:     artifact.VersionPathParam(c.Version),
+	})
+	if err != nil {
+		return err
+	}
+
+	//nolint:exhaustive
+	switch resp := resp.(type) {
+	case artifact.DeleteArtifactVersion200JSONResponse:
+	case artifact.DeleteArtifactVersion404JSONResponse:
+		// the version was deleted in the meantime, nothing left to do.
+		return nil
+	case artifact.DeleteArtifactVersion500JSONResponse:
+		return fmt.Errorf("failed to delete artifact version: %s", resp.Message)
+	default:
+		return fmt.Errorf("failed to delete artifact version: unexpected response %T", resp)
+	}
+
+	if len(blobIDs) > 0 {
+		if err := s.scheduleBlobReviews(ctx, blobIDs); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to schedule blob reviews for %s:%s of registry %s",
+				c.Package, c.Version, registryRef)
+		}
+	}
+
+	return nil
+}
+
+// manifestBlobIDs returns the ids of the config and layer blobs of an OCI manifest.
+func (s *Service) manifestBlobIDs(
+	ctx context.Context,
+	registryID int64,
+	image string,
+	version string,
+) ([]int64, error) {
+	dgst, err := types.NewDigest(digest.Digest(version))
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest digest %q: %w", version, err)
+	}
+
+	manifest, err := s.manifestDao.FindManifestByDigest(ctx, registryID, image, dgst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifest: %w", err)
+	}
+
+	var blobIDs []int64
+	if manifest.Configuration != nil && manifest.Configuration.BlobID > 0 {
+		blobIDs = append(blobIDs, manifest.Configuration.BlobID)
+	}
+
+	layers, err := s.layerDao.GetAllLayersByManifestID(ctx, manifest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find manifest layers: %w", err)
+	}
+	if layers != nil {
+		for _, l := range *layers {
+			blobIDs = append(blobIDs, l.BlobID)
+		}
+	}
+
+	return blobIDs, nil
+}
+
+// scheduleBlobReviews makes the garbage collector review the blobs right away
+// instead of waiting for the regular review delay.
+func (s *Service) scheduleBlobReviews(ctx context.Context, blobIDs []int64) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, blobID := range blobIDs {
+			task, err := s.gcService.BlobFindAndLockBefore(ctx, blobID, time.Now().Add(gcReviewWindow))
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("failed to lock review of blob %d: %w", blobID, err)
+			}
+			if task == nil {
+				continue
+			}
+
+			if err = s.gcService.BlobReschedule(ctx, task, 0); err != nil {
+				return fmt.Errorf("failed to reschedule review of blob %d: %w", blobID, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/gc"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideConfig,
+	ProvideService,
+)
+
+func ProvideConfig(config *types.Config) Config {
+	return Config{
+		Enabled: config.Registry.CleanupPolicies.Enabled,
+		DryRun:  config.Registry.CleanupPolicies.DryRun,
+		Cron:    config.Registry.CleanupPolicies.Cron,
+	}
+}
+
+func ProvideService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	tx dbtx.Transactor,
+	registryDao store.RegistryRepository,
+	cleanupPolicyDao store.CleanupPolicyRepository,
+	artifactDao store.ArtifactRepository,
+	manifestDao store.ManifestRepository,
+	layerDao store.LayerRepository,
+	spaceFinder refcache.SpaceFinder,
+	gcService gc.Service,
+	apiController *metadata.APIController,
+) *Service {
+	return NewService(
+		config,
+		scheduler,
+		executor,
+		tx,
+		registryDao,
+		cleanupPolicyDao,
+		artifactDao,
+		manifestDao,
+		layerDao,
+		spaceFinder,
+		gcService,
+		apiController,
+	)
+}
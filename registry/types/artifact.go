@@ -34,6 +34,17 @@ type Artifact struct {
 	UpdatedBy int64
 }
 
+// ArtifactCleanupInfo describes an artifact version as seen by the registry cleanup policies.
+type ArtifactCleanupInfo struct {
+	ID        int64
+	ImageName string
+	Version   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// LastDownloadedAt is the zero time if the version was never downloaded.
+	LastDownloadedAt time.Time
+}
+
 type NonOCIArtifactMetadata struct {
 	ID               string
 	Name             string
@@ -28,10 +28,15 @@ type CleanupPolicy struct {
 	VersionPrefix []string
 	PackagePrefix []string
 	ExpiryTime    int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	CreatedBy     int64
-	UpdatedBy     int64
+	// KeepLast is the number of most recent versions of each package that are never deleted,
+	// not even by the other cleanup policies of the registry.
+	KeepLast int
+	// DownloadedWithin protects versions downloaded within this time (in milliseconds) from deletion.
+	DownloadedWithin int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CreatedBy        int64
+	UpdatedBy        int64
 }
 
 // CleanupPolicyPrefix DTO object.
@@ -28,4 +28,9 @@ const (
 	PathPackageTypeCargo       PathPackageType = "cargo"
 	PathPackageTypeGo          PathPackageType = "go"
 	PathPackageTypeHuggingFace PathPackageType = "huggingface"
+	PathPackageTypeDebian      PathPackageType = "debian"
+	PathPackageTypeAlpine      PathPackageType = "alpine"
+	PathPackageTypeRubyGems    PathPackageType = "rubygems"
+	PathPackageTypeComposer    PathPackageType = "composer"
+	PathPackageTypeTerraform   PathPackageType = "terraform"
 )
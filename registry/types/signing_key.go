@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// SigningKeyType identifies the kind of key a registry signs its index files with.
+type SigningKeyType string
+
+const (
+	// SigningKeyTypeOpenPGP is used to sign Debian repository metadata and Terraform provider checksums.
+	SigningKeyTypeOpenPGP SigningKeyType = "openpgp"
+	// SigningKeyTypeRSA is used to sign Alpine package indexes.
+	SigningKeyTypeRSA SigningKeyType = "rsa"
+)
+
+// SigningKey is a key pair owned by a registry. The private key is stored encrypted.
+type SigningKey struct {
+	RegistryID  int64
+	KeyType     SigningKeyType
+	PublicKey   string
+	PrivateKey  []byte
+	Fingerprint string
+	CreatedAt   time.Time
+}
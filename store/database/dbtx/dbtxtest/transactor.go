@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbtxtest provides database transaction helpers for tests.
+package dbtxtest
+
+import (
+	"context"
+
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+// Transactor runs the transaction functions directly, without a database transaction.
+// It can be used in tests of code that works with fake stores.
+type Transactor struct{}
+
+var _ dbtx.Transactor = Transactor{}
+
+func (Transactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...any) error {
+	return txFn(ctx)
+}
@@ -158,6 +158,22 @@ type Config struct {
 		ServerKeyPath           string        `envconfig:"GITNESS_SSH_SERVER_KEY_PATH" default:"ssh/gitness.rsa"`
 	}
 
+	// SignatureVerification defines the configuration of the git commit and tag signature verification.
+	SignatureVerification struct {
+		// X509 defines the trust roots for signatures with X.509 certificates (S/MIME and Sigstore gitsign).
+		X509 struct {
+			// CABundlePath is a path to a PEM file with trusted CA certificates, e.g. a corporate CA bundle.
+			CABundlePath string `envconfig:"GITNESS_SIGNATURE_X509_CA_BUNDLE_PATH"`
+			// FulcioRootsPath is a path to a PEM file with the Sigstore Fulcio root and intermediate certificates.
+			FulcioRootsPath string `envconfig:"GITNESS_SIGNATURE_X509_FULCIO_ROOTS_PATH"`
+			// RekorPublicKeysPath is a path to a PEM file with public keys of the trusted Rekor transparency logs.
+			// Required if the Fulcio roots are configured.
+			RekorPublicKeysPath string `envconfig:"GITNESS_SIGNATURE_X509_REKOR_PUBLIC_KEYS_PATH"`
+			// FulcioIssuers optionally limits the accepted OIDC issuers of Fulcio certificates.
+			FulcioIssuers []string `envconfig:"GITNESS_SIGNATURE_X509_FULCIO_ISSUERS"`
+		}
+	}
+
 	// CI defines configuration related to build executions.
 	CI struct {
 		ParallelWorkers int `envconfig:"GITNESS_CI_PARALLEL_WORKERS" default:"2"`
@@ -174,6 +190,13 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// ArtifactMaxSize is the maximum size of a single artifact or cache uploaded by a pipeline step (in bytes).
+		ArtifactMaxSize int64 `envconfig:"GITNESS_CI_ARTIFACT_MAX_SIZE" default:"1073741824"` // 1GB default
+		// ArtifactRetentionTime is the time after which the artifacts of executions are deleted.
+		ArtifactRetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION_TIME" default:"720h"`
+		// CacheRetentionTime is the time after which pipeline caches which weren't used are deleted.
+		CacheRetentionTime time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION_TIME" default:"168h"`
 	}
 
 	// Database defines the database configuration parameters.
@@ -186,7 +209,7 @@ type Config struct {
 	BlobStore struct {
 		// MaxFileSize defines the maximum size of files that can be uploaded (in bytes)
 		MaxFileSize int64 `envconfig:"GITNESS_BLOBSTORE_MAX_FILE_SIZE" default:"10485760"` // 10MB default
-		// Provider is a name of blob storage service like filesystem or gcs or cloudflare
+		// Provider is a name of blob storage service like filesystem or gcs or s3
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
 		// in case of gcs provider this will be the actual bucket where the images are stored.
@@ -199,6 +222,16 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// S3 defines the configuration of the S3 compatible blob storage (used with the s3 provider).
+		S3 struct {
+			// Endpoint of the S3 compatible service, e.g. http://localhost:9000 for MinIO. Empty for AWS S3.
+			Endpoint        string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			Region          string `envconfig:"GITNESS_BLOBSTORE_S3_REGION" default:"us-east-1"`
+			AccessKeyID     string `envconfig:"GITNESS_BLOBSTORE_S3_ACCESS_KEY_ID"`
+			SecretAccessKey string `envconfig:"GITNESS_BLOBSTORE_S3_SECRET_ACCESS_KEY"`
+			ForcePathStyle  bool   `envconfig:"GITNESS_BLOBSTORE_S3_FORCE_PATH_STYLE" default:"false"`
+		}
 	}
 
 	// Token defines token configuration parameters.
@@ -401,6 +434,11 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexDir (optional) specifies the directory of the local search index (defaults to a folder in Git.Root).
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
+		// MaxFileSize is the maximum size (in bytes) of files added to the local search index.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1 MiB
 	}
 
 	Repos struct {
@@ -571,6 +609,20 @@ type Config struct {
 			MaxRetries    int  `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_MAX_RETRIES" default:"3"`
 			AllowLoopback bool `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_ALLOW_LOOPBACK" default:"false"`
 		}
+
+		CleanupPolicies struct {
+			Enabled bool `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICIES_ENABLED" default:"true"`
+			// DryRun only reports the artifact versions the cleanup policies would delete.
+			// It's on by default, so the existing policies can be reviewed before anything gets deleted.
+			DryRun bool   `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICIES_DRY_RUN" default:"true"`
+			Cron   string `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICIES_CRON" default:"30 2 * * *"`
+		}
+
+		Terraform struct {
+			// DiscoveryRegistry is the registry, as `root/registry`, that /.well-known/terraform.json of the host
+			// points to. Other terraform registries are reached through their own discovery document.
+			DiscoveryRegistry string `envconfig:"GITNESS_REGISTRY_TERRAFORM_DISCOVERY_REGISTRY"`
+		}
 	}
 
 	Auth struct {
@@ -587,6 +639,11 @@ type Config struct {
 		MaxWorkers int  `envconfig:"GITNESS_USAGE_METRICS_MAX_WORKERS" default:"5"`
 	}
 
+	GitHubAPI struct {
+		// Enabled exposes the GitHub v3 compatible REST API under /api/v3.
+		Enabled bool `envconfig:"GITNESS_GITHUB_API_ENABLED" default:"false"`
+	}
+
 	Development struct {
 		UISourceOverride string `envconfig:"GITNESS_DEVELOPMENT_UI_SOURCE_OVERRIDE"`
 	}
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AutoMergeDisableReason defines why the auto-merge of a pull request has been disabled.
+type AutoMergeDisableReason string
+
+func (AutoMergeDisableReason) Enum() []any { return toInterfaceSlice(autoMergeDisableReasons) }
+
+// AutoMergeDisableReason enumeration.
+const (
+	// AutoMergeDisableReasonUser means that a user has explicitly disabled the auto-merge.
+	AutoMergeDisableReasonUser AutoMergeDisableReason = "user"
+	// AutoMergeDisableReasonBranchUpdated means that the auto-merge got cancelled by a new push to the source branch.
+	AutoMergeDisableReasonBranchUpdated AutoMergeDisableReason = "branch_updated"
+	// AutoMergeDisableReasonClosed means that the pull request has been closed.
+	AutoMergeDisableReasonClosed AutoMergeDisableReason = "closed"
+)
+
+var autoMergeDisableReasons = sortEnum([]AutoMergeDisableReason{
+	AutoMergeDisableReasonUser,
+	AutoMergeDisableReasonBranchUpdated,
+	AutoMergeDisableReasonClosed,
+})
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a pull request in a merge queue.
+type MergeQueueEntryState string
+
+func (MergeQueueEntryState) Enum() []any { return toInterfaceSlice(mergeQueueEntryStates) }
+
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, ""
+}
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateQueued means that the merge group commit for the entry hasn't been created yet.
+	MergeQueueEntryStateQueued MergeQueueEntryState = "queued"
+	// MergeQueueEntryStateChecking means that the merge group commit exists and awaits the required status checks.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStateQueued,
+	MergeQueueEntryStateChecking,
+})
+
+// MergeQueueRemoveReason defines why a pull request has been removed from a merge queue without being merged.
+type MergeQueueRemoveReason string
+
+func (MergeQueueRemoveReason) Enum() []any { return toInterfaceSlice(mergeQueueRemoveReasons) }
+
+// MergeQueueRemoveReason enumeration.
+const (
+	MergeQueueRemoveReasonDequeued       MergeQueueRemoveReason = "dequeued"
+	MergeQueueRemoveReasonConflict       MergeQueueRemoveReason = "conflict"
+	MergeQueueRemoveReasonChecksFailed   MergeQueueRemoveReason = "checks_failed"
+	MergeQueueRemoveReasonRuleViolations MergeQueueRemoveReason = "rule_violations"
+	MergeQueueRemoveReasonBranchUpdated  MergeQueueRemoveReason = "branch_updated"
+	MergeQueueRemoveReasonTargetChanged  MergeQueueRemoveReason = "target_changed"
+	MergeQueueRemoveReasonClosed         MergeQueueRemoveReason = "closed"
+)
+
+var mergeQueueRemoveReasons = sortEnum([]MergeQueueRemoveReason{
+	MergeQueueRemoveReasonDequeued,
+	MergeQueueRemoveReasonConflict,
+	MergeQueueRemoveReasonChecksFailed,
+	MergeQueueRemoveReasonRuleViolations,
+	MergeQueueRemoveReasonBranchUpdated,
+	MergeQueueRemoveReasonTargetChanged,
+	MergeQueueRemoveReasonClosed,
+})
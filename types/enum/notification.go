@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "time"
+
+// NotificationEvent defines the type of event a user can be notified about.
+type NotificationEvent string
+
+const (
+	// NotificationEventPullReqCreated is sent when a pull request is created in a watched repository.
+	NotificationEventPullReqCreated NotificationEvent = "pullreq_created"
+	// NotificationEventReviewerAdded is sent when a user is added as a reviewer of a pull request.
+	NotificationEventReviewerAdded NotificationEvent = "reviewer_added"
+	// NotificationEventCommentCreated is sent when a comment is added to a pull request
+	// authored, commented or mentioning the user.
+	NotificationEventCommentCreated NotificationEvent = "comment_created"
+	// NotificationEventPullReqBranchUpdated is sent when new commits are pushed to a pull request
+	// the user reviews.
+	NotificationEventPullReqBranchUpdated NotificationEvent = "pullreq_branch_updated"
+	// NotificationEventReviewSubmitted is sent when a review is submitted for a pull request of the user.
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	// NotificationEventPullReqStateChanged is sent when a pull request is merged, closed or reopened.
+	NotificationEventPullReqStateChanged NotificationEvent = "pullreq_state_changed"
+	// NotificationEventWebhookDisabled is sent when a webhook is disabled after repeated failures.
+	NotificationEventWebhookDisabled NotificationEvent = "webhook_disabled"
+	// NotificationEventTagCreated is sent when a tag is created in a watched repository.
+	NotificationEventTagCreated NotificationEvent = "tag_created"
+)
+
+// Enum returns all possible NotificationEvent values.
+func (NotificationEvent) Enum() []any {
+	return toInterfaceSlice(notificationEvents)
+}
+
+// Sanitize validates and returns a sanitized NotificationEvent value.
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+
+// GetAllNotificationEvents returns all possible NotificationEvent values. There is no default value.
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return notificationEvents, ""
+}
+
+// List of all NotificationEvent values.
+var notificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventPullReqCreated,
+	NotificationEventReviewerAdded,
+	NotificationEventCommentCreated,
+	NotificationEventPullReqBranchUpdated,
+	NotificationEventReviewSubmitted,
+	NotificationEventPullReqStateChanged,
+	NotificationEventWebhookDisabled,
+	NotificationEventTagCreated,
+})
+
+// NotificationDelivery defines how notifications are delivered to a user.
+type NotificationDelivery string
+
+const (
+	// NotificationDeliveryInstant sends a notification as soon as the event happens.
+	NotificationDeliveryInstant NotificationDelivery = "instant"
+	// NotificationDeliveryHourly batches notifications into an hourly digest.
+	NotificationDeliveryHourly NotificationDelivery = "hourly"
+	// NotificationDeliveryDaily batches notifications into a daily digest.
+	NotificationDeliveryDaily NotificationDelivery = "daily"
+)
+
+// Enum returns all possible NotificationDelivery values.
+func (NotificationDelivery) Enum() []any {
+	return toInterfaceSlice(notificationDeliveries)
+}
+
+// Sanitize validates and returns a sanitized NotificationDelivery value.
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+
+// GetAllNotificationDeliveries returns all possible NotificationDelivery values and a default value.
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryInstant
+}
+
+// Interval returns how often digests are sent, or zero for instant delivery.
+func (d NotificationDelivery) Interval() time.Duration {
+	switch d {
+	case NotificationDeliveryHourly:
+		return time.Hour
+	case NotificationDeliveryDaily:
+		return 24 * time.Hour
+	case NotificationDeliveryInstant:
+		return 0
+	}
+	return 0
+}
+
+// List of all NotificationDelivery values.
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryInstant,
+	NotificationDeliveryHourly,
+	NotificationDeliveryDaily,
+})
+
+// NotificationScopeMode defines how notifications from a space or repository are handled for a user.
+type NotificationScopeMode string
+
+const (
+	// NotificationScopeModeWatch delivers all notifications from the scope, including disabled events.
+	NotificationScopeModeWatch NotificationScopeMode = "watch"
+	// NotificationScopeModeIgnore suppresses all notifications from the scope.
+	NotificationScopeModeIgnore NotificationScopeMode = "ignore"
+)
+
+// Enum returns all possible NotificationScopeMode values.
+func (NotificationScopeMode) Enum() []any {
+	return toInterfaceSlice(notificationScopeModes)
+}
+
+// Sanitize validates and returns a sanitized NotificationScopeMode value.
+func (m NotificationScopeMode) Sanitize() (NotificationScopeMode, bool) {
+	return Sanitize(m, GetAllNotificationScopeModes)
+}
+
+// GetAllNotificationScopeModes returns all possible NotificationScopeMode values. There is no default value.
+func GetAllNotificationScopeModes() ([]NotificationScopeMode, NotificationScopeMode) {
+	return notificationScopeModes, ""
+}
+
+// List of all NotificationScopeMode values.
+var notificationScopeModes = sortEnum([]NotificationScopeMode{
+	NotificationScopeModeWatch,
+	NotificationScopeModeIgnore,
+})
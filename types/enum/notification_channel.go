@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationChannelType defines the type of service a notification channel posts messages to.
+type NotificationChannelType string
+
+const (
+	// NotificationChannelTypeSlack posts messages to a Slack incoming webhook.
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
+	// NotificationChannelTypeTeams posts messages to a Microsoft Teams incoming webhook.
+	NotificationChannelTypeTeams NotificationChannelType = "teams"
+	// NotificationChannelTypeGeneric posts messages as plain JSON documents.
+	NotificationChannelTypeGeneric NotificationChannelType = "generic"
+)
+
+// Enum returns all possible NotificationChannelType values.
+func (NotificationChannelType) Enum() []any {
+	return toInterfaceSlice(notificationChannelTypes)
+}
+
+// Sanitize validates and returns a sanitized NotificationChannelType value.
+func (t NotificationChannelType) Sanitize() (NotificationChannelType, bool) {
+	return Sanitize(t, GetAllNotificationChannelTypes)
+}
+
+// GetAllNotificationChannelTypes returns all possible NotificationChannelType values and a default value.
+func GetAllNotificationChannelTypes() ([]NotificationChannelType, NotificationChannelType) {
+	return notificationChannelTypes, NotificationChannelTypeGeneric
+}
+
+// List of all NotificationChannelType values.
+var notificationChannelTypes = sortEnum([]NotificationChannelType{
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeTeams,
+	NotificationChannelTypeGeneric,
+})
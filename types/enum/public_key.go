@@ -19,10 +19,14 @@ type PublicKeyScheme string
 const (
 	PublicKeySchemeSSH PublicKeyScheme = "ssh"
 	PublicKeySchemePGP PublicKeyScheme = "pgp"
+
+	// PublicKeySchemeX509 is used for signatures with X.509 certificates (S/MIME and Sigstore gitsign).
+	// Certificates are not uploaded by users, they are verified using the configured trust roots.
+	PublicKeySchemeX509 PublicKeyScheme = "x509"
 )
 
 var publicKeySchemes = sortEnum([]PublicKeyScheme{
-	PublicKeySchemeSSH, PublicKeySchemePGP,
+	PublicKeySchemeSSH, PublicKeySchemePGP, PublicKeySchemeX509,
 })
 
 func (PublicKeyScheme) Enum() []any { return toInterfaceSlice(publicKeySchemes) }
@@ -94,6 +94,18 @@ const (
 	PullReqActivityTypeMerge                   PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify             PullReqActivityType = "label-modify"
 	PullReqActivityTypeNonUniqueMergeBase      PullReqActivityType = "non-unique-merge-base"
+	PullReqActivityTypeMergeQueueAdd           PullReqActivityType = "merge-queue-add"
+	PullReqActivityTypeMergeQueueRemove        PullReqActivityType = "merge-queue-remove"
+	PullReqActivityTypeAutoMergeEnable         PullReqActivityType = "auto-merge-enable"
+	PullReqActivityTypeAutoMergeDisable        PullReqActivityType = "auto-merge-disable"
+	PullReqActivityTypeStackRebase             PullReqActivityType = "stack-rebase"
+
+	// activity types specific to issues.
+	PullReqActivityTypeIssueStateChange PullReqActivityType = "issue-state-change"
+	PullReqActivityTypeIssueReference   PullReqActivityType = "issue-reference"
+	PullReqActivityTypeAssigneeAdd      PullReqActivityType = "assignee-add"
+	PullReqActivityTypeAssigneeDelete   PullReqActivityType = "assignee-delete"
+	PullReqActivityTypeMilestoneChange  PullReqActivityType = "milestone-change"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -112,6 +124,16 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeTargetBranchChange,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeMergeQueueAdd,
+	PullReqActivityTypeMergeQueueRemove,
+	PullReqActivityTypeAutoMergeEnable,
+	PullReqActivityTypeAutoMergeDisable,
+	PullReqActivityTypeStackRebase,
+	PullReqActivityTypeIssueStateChange,
+	PullReqActivityTypeIssueReference,
+	PullReqActivityTypeAssigneeAdd,
+	PullReqActivityTypeAssigneeDelete,
+	PullReqActivityTypeMilestoneChange,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -84,4 +84,15 @@ const (
 	SSETypeWebhookCreated SSEType = "webhook_created"
 	SSETypeWebhookUpdated SSEType = "webhook_updated"
 	SSETypeWebhookDeleted SSEType = "webhook_deleted"
+
+	// Issues.
+
+	SSETypeIssueCreated        SSEType = "issue_created"
+	SSETypeIssueUpdated        SSEType = "issue_updated"
+	SSETypeIssueCommentCreated SSEType = "issue_comment_created"
+	SSETypeIssueCommentUpdated SSEType = "issue_comment_updated"
+
+	// Notifications.
+
+	SSETypeNotification SSEType = "notification"
 )
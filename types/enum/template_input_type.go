@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TemplateInputType defines the type of template input.
+type TemplateInputType string
+
+func (TemplateInputType) Enum() []any { return toInterfaceSlice(templateInputTypes) }
+func (t TemplateInputType) Sanitize() (TemplateInputType, bool) {
+	return Sanitize(t, GetAllTemplateInputTypes)
+}
+func GetAllTemplateInputTypes() ([]TemplateInputType, TemplateInputType) {
+	return templateInputTypes, TemplateInputTypeString
+}
+
+// TemplateInputType enumeration.
+const (
+	TemplateInputTypeString  TemplateInputType = "string"
+	TemplateInputTypeNumber  TemplateInputType = "number"
+	TemplateInputTypeBoolean TemplateInputType = "boolean"
+)
+
+var templateInputTypes = sortEnum([]TemplateInputType{
+	TemplateInputTypeString,
+	TemplateInputTypeNumber,
+	TemplateInputTypeBoolean,
+})
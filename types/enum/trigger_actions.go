@@ -40,6 +40,9 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+	// TriggerActionPullReqMergeGroupCreated gets triggered when the merge queue creates
+	// a merge group commit of a pull request.
+	TriggerActionPullReqMergeGroupCreated TriggerAction = "pullreq_merge_group_created"
 )
 
 func (TriggerAction) Enum() []any                       { return toInterfaceSlice(triggerActions) }
@@ -49,7 +52,8 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeGroupCreated {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,6 +79,7 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeGroupCreated,
 })
 
 // Trigger types.
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TriggerCatchUp defines how a cron trigger handles scheduled runs
+// that were missed, e.g. because the server wasn't running.
+type TriggerCatchUp string
+
+const (
+	// TriggerCatchUpSkip drops all missed runs. A run is started only if it's not overdue.
+	TriggerCatchUpSkip TriggerCatchUp = "skip"
+	// TriggerCatchUpOnce starts a single run for all missed runs.
+	TriggerCatchUpOnce TriggerCatchUp = "once"
+	// TriggerCatchUpAll starts a run for every missed run (up to a limit).
+	TriggerCatchUpAll TriggerCatchUp = "all"
+)
+
+// Enum returns all possible TriggerCatchUp values.
+func (TriggerCatchUp) Enum() []any {
+	return toInterfaceSlice(triggerCatchUps)
+}
+
+// Sanitize validates and returns a sanitized TriggerCatchUp value.
+func (c TriggerCatchUp) Sanitize() (TriggerCatchUp, bool) {
+	return Sanitize(c, GetAllTriggerCatchUps)
+}
+
+// GetAllTriggerCatchUps returns all possible TriggerCatchUp values and a default value.
+func GetAllTriggerCatchUps() ([]TriggerCatchUp, TriggerCatchUp) {
+	return triggerCatchUps, TriggerCatchUpOnce
+}
+
+// List of all TriggerCatchUp values.
+var triggerCatchUps = sortEnum([]TriggerCatchUp{
+	TriggerCatchUpSkip,
+	TriggerCatchUpOnce,
+	TriggerCatchUpAll,
+})
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// WatchLevel defines which activity of a watched repository or space a user is notified about.
+type WatchLevel string
+
+const (
+	// WatchLevelAll notifies the user about all activity.
+	WatchLevelAll WatchLevel = "all"
+	// WatchLevelPullReqs notifies the user about pull request activity only.
+	WatchLevelPullReqs WatchLevel = "pullreqs"
+	// WatchLevelReleases notifies the user about new releases (tags) only.
+	WatchLevelReleases WatchLevel = "releases"
+	// WatchLevelParticipating notifies the user only about activity they participate in,
+	// which is the same as not watching at all, but it overrides watch levels of parent spaces.
+	WatchLevelParticipating WatchLevel = "participating"
+)
+
+// Enum returns all possible WatchLevel values.
+func (WatchLevel) Enum() []any {
+	return toInterfaceSlice(watchLevels)
+}
+
+// Sanitize validates and returns a sanitized WatchLevel value.
+func (l WatchLevel) Sanitize() (WatchLevel, bool) {
+	return Sanitize(l, GetAllWatchLevels)
+}
+
+// GetAllWatchLevels returns all possible WatchLevel values. There is no default value.
+func GetAllWatchLevels() ([]WatchLevel, WatchLevel) {
+	return watchLevels, ""
+}
+
+// IncludesPullReqs returns true if the watch level includes pull request activity.
+func (l WatchLevel) IncludesPullReqs() bool {
+	return l == WatchLevelAll || l == WatchLevelPullReqs
+}
+
+// IncludesReleases returns true if the watch level includes new releases.
+func (l WatchLevel) IncludesReleases() bool {
+	return l == WatchLevelAll || l == WatchLevelReleases
+}
+
+// List of all WatchLevel values.
+var watchLevels = sortEnum([]WatchLevel{
+	WatchLevelAll,
+	WatchLevelPullReqs,
+	WatchLevelReleases,
+	WatchLevelParticipating,
+})
@@ -196,6 +196,21 @@ const (
 	WebhookTriggerPullReqReviewSubmitted = "pullreq_review_submitted"
 	// WebhookTriggerPullReqTargetBranchChanged gets triggered when a pull request target branch is changed.
 	WebhookTriggerPullReqTargetBranchChanged = "pullreq_target_branch_changed"
+	// WebhookTriggerPullReqAutoMergeEnabled gets triggered when auto-merge is enabled for a pull request.
+	WebhookTriggerPullReqAutoMergeEnabled WebhookTrigger = "pullreq_auto_merge_enabled"
+	// WebhookTriggerPullReqAutoMergeDisabled gets triggered when auto-merge is disabled for a pull request.
+	WebhookTriggerPullReqAutoMergeDisabled WebhookTrigger = "pullreq_auto_merge_disabled"
+
+	// WebhookTriggerIssueCreated gets triggered when an issue gets created.
+	WebhookTriggerIssueCreated WebhookTrigger = "issue_created"
+	// WebhookTriggerIssueUpdated gets triggered when the title or the description of an issue gets updated.
+	WebhookTriggerIssueUpdated WebhookTrigger = "issue_updated"
+	// WebhookTriggerIssueClosed gets triggered when an issue gets closed.
+	WebhookTriggerIssueClosed WebhookTrigger = "issue_closed"
+	// WebhookTriggerIssueReopened gets triggered when an issue gets reopened.
+	WebhookTriggerIssueReopened WebhookTrigger = "issue_reopened"
+	// WebhookTriggerIssueCommentCreated gets triggered when an issue comment gets created.
+	WebhookTriggerIssueCommentCreated WebhookTrigger = "issue_comment_created"
 
 	// WebhookTriggerArtifactCreated gets triggered when an artifact gets created.
 	WebhookTriggerArtifactCreated WebhookTrigger = "artifact_created"
@@ -222,6 +237,13 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqLabelAssigned,
 	WebhookTriggerPullReqReviewSubmitted,
 	WebhookTriggerPullReqTargetBranchChanged,
+	WebhookTriggerPullReqAutoMergeEnabled,
+	WebhookTriggerPullReqAutoMergeDisabled,
+	WebhookTriggerIssueCreated,
+	WebhookTriggerIssueUpdated,
+	WebhookTriggerIssueClosed,
+	WebhookTriggerIssueReopened,
+	WebhookTriggerIssueCommentCreated,
 	WebhookTriggerArtifactCreated,
 	WebhookTriggerArtifactDeleted,
 })
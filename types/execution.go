@@ -64,6 +64,9 @@ type Execution struct {
 	Version      int64              `json:"-"`
 	Stages       []*Stage           `json:"stages,omitempty"`
 
+	// Template versions the pipelines of the execution were resolved with.
+	Templates []*ExecutionTemplate `json:"templates,omitempty"`
+
 	// Pipeline specific information not stored with executions
 	PipelineUID string `json:"pipeline_uid,omitempty"`
 
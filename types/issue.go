@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Issue represents an issue of a repository.
+type Issue struct {
+	ID      int64 `json:"-"` // not returned, it's an internal field
+	Version int64 `json:"-"` // not returned, it's an internal field
+	RepoID  int64 `json:"repo_id"`
+	Number  int64 `json:"number"`
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Edited    int64  `json:"edited"`
+	Closed    *int64 `json:"closed,omitempty"`
+	ClosedBy  *int64 `json:"-"` // not returned, because the closer info is in the Closer field
+
+	State       enum.IssueState `json:"state"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
+	ActivitySeq  int64 `json:"-"` // not returned, because it's a server's internal field
+	CommentCount int   `json:"comment_count"`
+
+	Author PrincipalInfo  `json:"author"`
+	Closer *PrincipalInfo `json:"closer,omitempty"`
+
+	Assignees []*PrincipalInfo            `json:"assignees"`
+	Labels    []*LabelIssueAssignmentInfo `json:"labels"`
+	Milestone *Milestone                  `json:"milestone,omitempty"`
+}
+
+// IssueFilter stores issue query parameters.
+type IssueFilter struct {
+	Page        int               `json:"page"`
+	Size        int               `json:"size"`
+	Query       string            `json:"query"`
+	CreatedBy   []int64           `json:"created_by"`
+	AssigneeID  int64             `json:"assignee_id"`
+	MilestoneID int64             `json:"milestone_id"`
+	States      []enum.IssueState `json:"state"`
+	Sort        enum.IssueSort    `json:"sort"`
+	Order       enum.Order        `json:"order"`
+	LabelID     []int64           `json:"label_id"`
+	ValueID     []int64           `json:"value_id"`
+	CreatedFilter
+	UpdatedFilter
+
+	// internal use only
+	RepoID int64
+}
+
+// IssueAssignee holds a principal assigned to an issue.
+type IssueAssignee struct {
+	IssueID     int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	Created     int64 `json:"created"`
+	CreatedBy   int64 `json:"-"`
+
+	Assignee PrincipalInfo `json:"assignee"`
+}
+
+// IssueLabel holds a label assigned to an issue.
+type IssueLabel struct {
+	IssueID   int64  `json:"issue_id"`
+	LabelID   int64  `json:"label_id"`
+	ValueID   *int64 `json:"value_id,omitempty"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	CreatedBy int64  `json:"created_by"`
+	UpdatedBy int64  `json:"updated_by"`
+}
+
+// LabelIssueAssignmentInfo holds the info of a label assigned to an issue.
+type LabelIssueAssignmentInfo struct {
+	IssueID    int64            `json:"-"`
+	LabelID    int64            `json:"id"`
+	LabelKey   string           `json:"key"`
+	LabelColor enum.LabelColor  `json:"color,omitempty"`
+	LabelScope int64            `json:"scope"`
+	ValueCount int64            `json:"value_count"`
+	ValueID    *int64           `json:"value_id,omitempty"`
+	Value      *string          `json:"value,omitempty"`
+	ValueColor *enum.LabelColor `json:"value_color,omitempty"`
+}
+
+// Milestone represents a milestone of a repository, used to group issues.
+type Milestone struct {
+	ID          int64               `json:"id"`
+	Version     int64               `json:"-"`
+	RepoID      int64               `json:"repo_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	State       enum.MilestoneState `json:"state"`
+	Due         *int64              `json:"due,omitempty"`
+	Closed      *int64              `json:"closed,omitempty"`
+	Created     int64               `json:"created"`
+	Updated     int64               `json:"updated"`
+	CreatedBy   int64               `json:"created_by"`
+	UpdatedBy   int64               `json:"updated_by"`
+
+	// used only in response
+	OpenIssues   int64 `json:"open_issues"`
+	ClosedIssues int64 `json:"closed_issues"`
+}
+
+// MilestoneFilter stores milestone query parameters.
+type MilestoneFilter struct {
+	ListQueryFilter
+	States []enum.MilestoneState `json:"state"`
+}
+
+// IssueActivity represents an entry of the issue timeline.
+// It reuses the pull request activity types, kinds, payloads and metadata.
+type IssueActivity struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Edited    int64  `json:"edited"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+
+	ParentID *int64 `json:"parent_id"`
+	RepoID   int64  `json:"repo_id"`
+	IssueID  int64  `json:"issue_id"`
+
+	Order    int64 `json:"order"`
+	SubOrder int64 `json:"sub_order"`
+	ReplySeq int64 `json:"-"` // not returned, because it's a server's internal field
+
+	Type enum.PullReqActivityType `json:"type"`
+	Kind enum.PullReqActivityKind `json:"kind"`
+
+	Text       string                   `json:"text"`
+	PayloadRaw json.RawMessage          `json:"payload"`
+	Metadata   *PullReqActivityMetadata `json:"metadata,omitempty"`
+
+	Author PrincipalInfo `json:"author"`
+
+	// used only in response
+	Mentions map[int64]*PrincipalInfo `json:"mentions,omitempty"`
+}
+
+func (a *IssueActivity) IsReplyable() bool {
+	return a.Type == enum.PullReqActivityTypeComment && a.SubOrder == 0
+}
+
+func (a *IssueActivity) IsReply() bool {
+	return a.SubOrder > 0
+}
+
+// SetPayload sets the payload and verifies it's of correct type for the activity.
+func (a *IssueActivity) SetPayload(payload PullReqActivityPayload) error {
+	if payload == nil {
+		a.PayloadRaw = json.RawMessage(nil)
+		return nil
+	}
+
+	if payload.ActivityType() != a.Type {
+		return fmt.Errorf("wrong payload type %T for activity %s, payload is for %s",
+			payload, a.Type, payload.ActivityType())
+	}
+
+	var err error
+	if a.PayloadRaw, err = json.Marshal(payload); err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayload returns the payload of the activity.
+func (a *IssueActivity) GetPayload() (PullReqActivityPayload, error) {
+	if a.PayloadRaw == nil ||
+		bytes.Equal(a.PayloadRaw, jsonRawMessageNullBytes) {
+		return nil, ErrNoPayload
+	}
+
+	payload, err := newPayloadForActivity(a.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new payload: %w", err)
+	}
+
+	if err = json.Unmarshal(a.PayloadRaw, payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// UpdateMetadata updates the metadata with the provided options.
+func (a *IssueActivity) UpdateMetadata(updates ...PullReqActivityMetadataUpdate) {
+	if a.Metadata == nil {
+		a.Metadata = &PullReqActivityMetadata{}
+	}
+
+	for _, update := range updates {
+		update.apply(a.Metadata)
+	}
+
+	if a.Metadata.IsEmpty() {
+		a.Metadata = nil
+	}
+}
+
+type IssueActivityPayloadStateChange struct {
+	Old enum.IssueState `json:"old"`
+	New enum.IssueState `json:"new"`
+	// ClosedByPullReq is set if the issue got closed because a referencing pull request got merged.
+	ClosedByPullReq *int64 `json:"closed_by_pullreq,omitempty"`
+	// ClosedByCommit is set if the issue got closed by a commit pushed to the default branch.
+	ClosedByCommit string `json:"closed_by_commit,omitempty"`
+}
+
+func (a *IssueActivityPayloadStateChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeIssueStateChange
+}
+
+// IssueActivityPayloadReference is written when an issue gets referenced by a pull request or a commit.
+type IssueActivityPayloadReference struct {
+	PullReqNumber *int64 `json:"pullreq_number,omitempty"`
+	CommitSHA     string `json:"commit_sha,omitempty"`
+	Title         string `json:"title"`
+	Closing       bool   `json:"closing"`
+}
+
+func (a *IssueActivityPayloadReference) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeIssueReference
+}
+
+type IssueActivityPayloadAssigneeAdd struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+func (a *IssueActivityPayloadAssigneeAdd) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAssigneeAdd
+}
+
+type IssueActivityPayloadAssigneeDelete struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+func (a *IssueActivityPayloadAssigneeDelete) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAssigneeDelete
+}
+
+type IssueActivityPayloadMilestoneChange struct {
+	Old *string `json:"old,omitempty"`
+	New *string `json:"new,omitempty"`
+}
+
+func (a *IssueActivityPayloadMilestoneChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMilestoneChange
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeQueueEntry is a pull request waiting in the merge queue of its target branch.
+//
+// Entries of the same target branch are processed in order of their IDs. Each entry has a merge group commit
+// (GroupSHA) which is the result of merging the pull request on top of the merge group commit of the previous
+// entry (or on top of the target branch for the first entry). BaseSHA is the commit the group commit was built on.
+type MergeQueueEntry struct {
+	ID                 int64                     `json:"id"`
+	Version            int64                     `json:"-"`
+	Created            int64                     `json:"created"`
+	Updated            int64                     `json:"updated"`
+	CreatedBy          int64                     `json:"created_by"`
+	RepoID             int64                     `json:"repo_id"`
+	PullReqID          int64                     `json:"pullreq_id"`
+	PullReqNumber      int64                     `json:"pullreq_number"`
+	TargetBranch       string                    `json:"target_branch"`
+	State              enum.MergeQueueEntryState `json:"state"`
+	Method             enum.MergeMethod          `json:"method"`
+	Title              string                    `json:"title"`
+	Message            string                    `json:"message"`
+	DeleteSourceBranch bool                      `json:"delete_source_branch"`
+	RulesBypassed      bool                      `json:"rules_bypassed"`
+	SourceSHA          string                    `json:"source_sha"`
+	BaseSHA            string                    `json:"base_sha"`
+	MergeBaseSHA       string                    `json:"merge_base_sha"`
+	GroupSHA           string                    `json:"group_sha"`
+}
+
+// MergeQueue identifies the merge queue of a branch.
+type MergeQueue struct {
+	RepoID int64  `json:"repo_id"`
+	Branch string `json:"branch"`
+}
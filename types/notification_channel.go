@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannel is a chat channel of a space or repository (e.g. Slack or Microsoft Teams)
+// that receives pull request notifications through an incoming webhook.
+type NotificationChannel struct {
+	ID          int64                        `json:"id"`
+	Version     int64                        `json:"-"`
+	SpaceID     *int64                       `json:"space_id,omitempty"`
+	RepoID      *int64                       `json:"repo_id,omitempty"`
+	Identifier  string                       `json:"identifier"`
+	DisplayName string                       `json:"display_name"`
+	Type        enum.NotificationChannelType `json:"type"`
+	URL         string                       `json:"-"` // the URL contains credentials and is stored encrypted
+	Enabled     bool                         `json:"enabled"`
+	Created     int64                        `json:"created"`
+	Updated     int64                        `json:"updated"`
+	CreatedBy   int64                        `json:"created_by"`
+}
+
+type NotificationChannelCreateInput struct {
+	Identifier  string                       `json:"identifier"`
+	DisplayName string                       `json:"display_name"`
+	Type        enum.NotificationChannelType `json:"type"`
+	URL         string                       `json:"url"`
+	Enabled     bool                         `json:"enabled"`
+}
+
+type NotificationChannelUpdateInput struct {
+	Identifier  *string `json:"identifier"`
+	DisplayName *string `json:"display_name"`
+	URL         *string `json:"url"`
+	Enabled     *bool   `json:"enabled"`
+}
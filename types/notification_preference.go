@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationPreferences holds the notification settings of a user.
+type NotificationPreferences struct {
+	PrincipalID    int64                     `json:"-"`
+	DisabledEvents []enum.NotificationEvent  `json:"disabled_events"`
+	Delivery       enum.NotificationDelivery `json:"delivery"`
+	Created        int64                     `json:"created"`
+	Updated        int64                     `json:"updated"`
+}
+
+// EventEnabled returns true if the user wants to be notified about the event.
+func (p *NotificationPreferences) EventEnabled(event enum.NotificationEvent) bool {
+	return !slices.Contains(p.DisabledEvents, event)
+}
+
+type NotificationPreferencesUpdateInput struct {
+	DisabledEvents *[]enum.NotificationEvent  `json:"disabled_events"`
+	Delivery       *enum.NotificationDelivery `json:"delivery"`
+}
+
+func (in *NotificationPreferencesUpdateInput) Sanitize() error {
+	if in.DisabledEvents != nil {
+		events := make([]enum.NotificationEvent, 0, len(*in.DisabledEvents))
+		for _, event := range *in.DisabledEvents {
+			sanitized, ok := event.Sanitize()
+			if !ok {
+				return errors.InvalidArgumentf("invalid notification event %q", event)
+			}
+			if !slices.Contains(events, sanitized) {
+				events = append(events, sanitized)
+			}
+		}
+		in.DisabledEvents = &events
+	}
+
+	if in.Delivery != nil {
+		delivery, ok := in.Delivery.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("invalid notification delivery")
+		}
+		in.Delivery = &delivery
+	}
+
+	return nil
+}
+
+// NotificationScopePreference overrides the notification settings of a user for a space or a repository.
+type NotificationScopePreference struct {
+	PrincipalID int64                      `json:"-"`
+	SpaceID     *int64                     `json:"space_id,omitempty"`
+	RepoID      *int64                     `json:"repo_id,omitempty"`
+	Mode        enum.NotificationScopeMode `json:"mode"`
+	Created     int64                      `json:"created"`
+	Updated     int64                      `json:"updated"`
+}
+
+type NotificationScopePreferenceInput struct {
+	ResourceType enum.ResourceType          `json:"resource_type"`
+	ResourceID   int64                      `json:"resource_id"`
+	Mode         enum.NotificationScopeMode `json:"mode"`
+}
+
+func (in *NotificationScopePreferenceInput) Sanitize() error {
+	if in.ResourceType != enum.ResourceTypeRepo && in.ResourceType != enum.ResourceTypeSpace {
+		return errors.InvalidArgument("notification preferences can be set only for spaces and repositories")
+	}
+
+	mode, ok := in.Mode.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("invalid notification mode")
+	}
+	in.Mode = mode
+
+	return nil
+}
+
+// NotificationDigestItem is a notification waiting to be sent to a user as part of a digest.
+type NotificationDigestItem struct {
+	ID          int64
+	PrincipalID int64
+	Delivery    enum.NotificationDelivery
+	Event       enum.NotificationEvent
+	Subject     string
+	Body        string
+	Created     int64
+}
+
+// NotificationDigestPending describes pending digest items of a user for a delivery mode.
+type NotificationDigestPending struct {
+	PrincipalID int64
+	Delivery    enum.NotificationDelivery
+	Oldest      int64
+}
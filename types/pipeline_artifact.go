@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PipelineArtifact is a named file uploaded by a step of a pipeline execution.
+type PipelineArtifact struct {
+	ID          int64  `json:"-"`
+	RepoID      int64  `json:"-"`
+	PipelineID  int64  `json:"-"`
+	ExecutionID int64  `json:"-"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+	BlobPath    string `json:"-"`
+}
+
+// PipelineCache is a keyed archive shared by the executions of a pipeline (e.g. downloaded dependencies).
+type PipelineCache struct {
+	ID         int64  `json:"-"`
+	RepoID     int64  `json:"-"`
+	PipelineID int64  `json:"-"`
+	Key        string `json:"key"`
+	Size       int64  `json:"size"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+	LastUsed   int64  `json:"last_used"`
+	BlobPath   string `json:"-"`
+}
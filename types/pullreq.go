@@ -70,6 +70,7 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+	AutoMerge    *AutoMerge                    `json:"auto_merge,omitempty"`
 
 	SourceRepo *RepositoryCore `json:"source_repo,omitempty"`
 }
@@ -139,6 +140,15 @@ func NewDiffStats(commitCount, fileCount, additions, deletions int) DiffStats {
 	}
 }
 
+// PullReqStack holds the open pull requests that are stacked with a pull request.
+// A pull request is stacked on top of another one if it targets the other one's source branch.
+type PullReqStack struct {
+	// Parents are ordered from the pull request's direct parent to the bottom of the stack.
+	Parents []*PullReq `json:"parents"`
+	// Children are the pull requests stacked directly on top of the pull request.
+	Children []*PullReq `json:"children"`
+}
+
 // PullReqStats shows Diff statistics and number of conversations.
 type PullReqStats struct {
 	DiffStats
@@ -274,6 +284,10 @@ type MergeResponse struct {
 	BranchDeleted  bool             `json:"branch_deleted,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 
+	// MergeQueued is set if the pull request has been added to the merge queue of the target branch
+	// instead of being merged directly.
+	MergeQueued bool `json:"merge_queued,omitempty"`
+
 	// values only returned on dryrun
 	DryRunRules    bool               `json:"dry_run_rules,omitempty"`
 	DryRun         bool               `json:"dry_run,omitempty"`
@@ -290,6 +304,8 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest bool `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution        bool `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue               bool `json:"requires_merge_queue,omitempty"`
+	CancelsAutoMergeOnPush           bool `json:"cancels_auto_merge_on_push,omitempty"`
 }
 
 type MergeViolations struct {
@@ -62,6 +62,16 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueueAdd{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueueRemove{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMergeEnable{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMergeDisable{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadStackRebase{} },
+	func() PullReqActivityPayload { return &IssueActivityPayloadStateChange{} },
+	func() PullReqActivityPayload { return &IssueActivityPayloadReference{} },
+	func() PullReqActivityPayload { return &IssueActivityPayloadAssigneeAdd{} },
+	func() PullReqActivityPayload { return &IssueActivityPayloadAssigneeDelete{} },
+	func() PullReqActivityPayload { return &IssueActivityPayloadMilestoneChange{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -242,3 +252,57 @@ type PullRequestActivityPayloadNonUniqueMergeBase struct {
 func (a *PullRequestActivityPayloadNonUniqueMergeBase) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeNonUniqueMergeBase
 }
+
+type PullRequestActivityPayloadMergeQueueAdd struct {
+	MergeMethod enum.MergeMethod `json:"merge_method"`
+	SourceSHA   string           `json:"source_sha"`
+	Position    int              `json:"position"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueueAdd) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueueAdd
+}
+
+type PullRequestActivityPayloadMergeQueueRemove struct {
+	Reason    enum.MergeQueueRemoveReason `json:"reason"`
+	SourceSHA string                      `json:"source_sha"`
+	GroupSHA  string                      `json:"group_sha,omitempty"`
+	Details   []string                    `json:"details,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueueRemove) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueueRemove
+}
+
+type PullRequestActivityPayloadAutoMergeEnable struct {
+	MergeMethod        enum.MergeMethod `json:"merge_method"`
+	DeleteSourceBranch bool             `json:"delete_source_branch,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMergeEnable) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMergeEnable
+}
+
+type PullRequestActivityPayloadAutoMergeDisable struct {
+	Reason enum.AutoMergeDisableReason `json:"reason"`
+}
+
+func (a *PullRequestActivityPayloadAutoMergeDisable) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMergeDisable
+}
+
+// PullRequestActivityPayloadStackRebase is the payload of the activity written when the source branch
+// of a stacked pull request is rebased after its parent pull request got merged.
+type PullRequestActivityPayloadStackRebase struct {
+	ParentNumber  int64    `json:"parent_number"`
+	TargetBranch  string   `json:"target_branch"`
+	OldSHA        string   `json:"old_sha"`
+	NewSHA        string   `json:"new_sha,omitempty"`
+	ConflictFiles []string `json:"conflict_files,omitempty"`
+	// Message holds the reason why the rebase failed.
+	Message string `json:"message,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadStackRebase) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeStackRebase
+}
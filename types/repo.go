@@ -61,6 +61,7 @@ type Repository struct {
 	DefaultBranch string `json:"default_branch" yaml:"default_branch"`
 	ForkID        int64  `json:"fork_id" yaml:"fork_id"`
 	PullReqSeq    int64  `json:"-" yaml:"-"`
+	IssueSeq      int64  `json:"-" yaml:"-"`
 
 	NumForks       int `json:"num_forks" yaml:"num_forks"`
 	NumPulls       int `json:"num_pulls" yaml:"num_pulls"`
@@ -45,4 +45,15 @@ type Stage struct {
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
 	Steps       []*Step           `json:"steps,omitempty"`
+
+	// Matrix contains the matrix values of the stage in case it was expanded from a pipeline with a matrix.
+	// All stages expanded from the same pipeline share the name of the pipeline.
+	Matrix map[string]string `json:"matrix,omitempty"`
+	// Envs contains additional environment variables of the stage
+	// which are merged with the parameters of the execution.
+	Envs map[string]string `json:"envs,omitempty"`
+	// FailFast indicates whether the pending stages of the same matrix are skipped once one of them failed.
+	FailFast bool `json:"fail_fast,omitempty"`
+	// MaxParallel limits the number of stages of the same matrix which run at the same time (0 - unlimited).
+	MaxParallel int `json:"max_parallel,omitempty"`
 }
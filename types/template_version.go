@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// TemplateVersion represents an immutable version of a template that pipelines can use.
+type TemplateVersion struct {
+	ID         int64                     `json:"-"`
+	TemplateID int64                     `json:"-"`
+	Version    string                    `json:"version"`
+	Data       string                    `json:"data"`
+	Inputs     map[string]*TemplateInput `json:"inputs"`
+	CreatedBy  int64                     `json:"created_by"`
+	Created    int64                     `json:"created"`
+}
+
+// TemplateInput declares an input of a template version.
+type TemplateInput struct {
+	Type        enum.TemplateInputType `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Default     any                    `json:"default,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Enum        []any                  `json:"enum,omitempty"`
+}
+
+// ExecutionTemplate records the template version a pipeline of an execution was resolved with.
+type ExecutionTemplate struct {
+	Pipeline   string `json:"pipeline"`
+	SpacePath  string `json:"space_path"`
+	Identifier string `json:"identifier"`
+	Version    string `json:"version"`
+}
@@ -34,6 +34,35 @@ type Trigger struct {
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
 	Version     int64                `json:"-"`
+
+	// Cron related fields are set only for scheduled triggers (trigger type is enum.TriggerCron).
+	Cron     string              `json:"cron,omitempty"`
+	Timezone string              `json:"timezone,omitempty"`
+	Branch   string              `json:"branch,omitempty"`
+	CatchUp  enum.TriggerCatchUp `json:"catch_up,omitempty"`
+	LastRun  int64               `json:"last_run,omitempty"`
+	NextRun  int64               `json:"next_run,omitempty"`
+
+	// Paths restricts the trigger to pushes and pull requests changing matching files.
+	Paths TriggerPaths `json:"paths"`
+}
+
+// TriggerPaths holds glob patterns which are matched against the files changed by a push or a pull request.
+// A changed file matches if it matches any of the include patterns (or there are none)
+// and it doesn't match any of the exclude patterns.
+type TriggerPaths struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// IsEmpty returns true if there are no path patterns.
+func (p TriggerPaths) IsEmpty() bool {
+	return len(p.Include) == 0 && len(p.Exclude) == 0
+}
+
+// IsCron returns true if the trigger fires executions on a timetable.
+func (s Trigger) IsCron() bool {
+	return s.Type == enum.TriggerCron
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
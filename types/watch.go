@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Watch is a subscription of a user to the activity of a space or a repository.
+type Watch struct {
+	PrincipalID int64           `json:"-"`
+	SpaceID     *int64          `json:"space_id,omitempty"`
+	RepoID      *int64          `json:"repo_id,omitempty"`
+	Level       enum.WatchLevel `json:"level"`
+	Created     int64           `json:"created"`
+	Updated     int64           `json:"updated"`
+}
+
+type WatchInput struct {
+	Level enum.WatchLevel `json:"level"`
+}
+
+func (in *WatchInput) Sanitize() error {
+	level, ok := in.Level.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("invalid watch level")
+	}
+	in.Level = level
+
+	return nil
+}
@@ -45,6 +45,15 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure" yaml:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers" yaml:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty" yaml:"-"`
+	RetryPolicy           WebhookRetryPolicy           `json:"retry_policy" yaml:"retry_policy"`
+	ConsecutiveFailures   int                          `json:"consecutive_failures" yaml:"-"`
+
+	// Filter is an optional boolean expression evaluated against the event payload.
+	// The webhook is triggered only if the expression evaluates to true.
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+
+	// PayloadTemplate is an optional Go template used to render the request body from the event payload.
+	PayloadTemplate string `json:"payload_template,omitempty" yaml:"payload_template,omitempty"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -86,9 +95,45 @@ func (w Webhook) Clone() Webhook {
 		webhook.Triggers = triggers
 	}
 
+	webhook.RetryPolicy = w.RetryPolicy.Clone()
+
 	return webhook
 }
 
+// WebhookRetryPolicy defines how failed deliveries of a webhook are retried.
+type WebhookRetryPolicy struct {
+	// MaxAttempts is the max number of delivery attempts, including the first one.
+	// Zero disables the policy, in which case failed deliveries are retried only while processing the event.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+
+	// Backoff is the delay in seconds before the first retry. The delay doubles with every following retry.
+	Backoff int64 `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+
+	// RetryableStatusCodes are the response status codes for which the delivery is retried.
+	// If empty, 408, 429 and 5xx responses (except 501) are retried.
+	RetryableStatusCodes []int `json:"retryable_status_codes,omitempty" yaml:"retryable_status_codes,omitempty"`
+
+	// DisableAfterFailures is the number of consecutive failed deliveries after which the webhook is disabled.
+	// Zero means the webhook is never disabled automatically.
+	DisableAfterFailures int `json:"disable_after_failures,omitempty" yaml:"disable_after_failures,omitempty"`
+}
+
+// Enabled returns true if failed deliveries are handled according to the retry policy.
+func (p WebhookRetryPolicy) Enabled() bool {
+	return p.MaxAttempts > 0
+}
+
+// Clone makes a deep copy of the retry policy.
+func (p WebhookRetryPolicy) Clone() WebhookRetryPolicy {
+	policy := p
+	if len(p.RetryableStatusCodes) > 0 {
+		policy.RetryableStatusCodes = make([]int, len(p.RetryableStatusCodes))
+		copy(policy.RetryableStatusCodes, p.RetryableStatusCodes)
+	}
+
+	return policy
+}
+
 type WebhookCreateInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
 	UID        string `json:"uid" deprecated:"true"`
@@ -101,6 +146,10 @@ type WebhookCreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	RetryPolicy WebhookRetryPolicy    `json:"retry_policy"`
+
+	Filter          string `json:"filter"`
+	PayloadTemplate string `json:"payload_template"`
 }
 
 type WebhookSignatureMetadata struct {
@@ -120,6 +169,10 @@ type WebhookUpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	RetryPolicy *WebhookRetryPolicy   `json:"retry_policy"`
+
+	Filter          *string `json:"filter"`
+	PayloadTemplate *string `json:"payload_template"`
 }
 
 // WebhookExecution represents a single execution of a webhook.
@@ -197,6 +250,10 @@ type WebhookCore struct {
 	SecretIdentifier      string
 	SecretSpaceID         int64
 	ExtraHeaders          []ExtraHeader
+	RetryPolicy           WebhookRetryPolicy
+	ConsecutiveFailures   int
+	Filter                string
+	PayloadTemplate       string
 }
 
 // WebhookExecutionCore represents a webhook execution DTO object.
@@ -236,6 +236,7 @@ export type EnumTriggerAction =
   | 'pullreq_branch_updated'
   | 'pullreq_closed'
   | 'pullreq_created'
+  | 'pullreq_merge_group_created'
   | 'pullreq_merged'
   | 'pullreq_reopened'
   | 'tag_created'
@@ -612,6 +613,7 @@ export interface OpenapiLookupRepoGitspaceRequest {
 }
 
 export interface OpenapiMergePullReq {
+  bypass_merge_queue?: boolean
   bypass_rules?: boolean
   delete_source_branch?: boolean
   dry_run?: boolean